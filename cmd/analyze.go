@@ -12,6 +12,7 @@ import (
 	"github.com/opd-ai/go-stats-generator/internal/config"
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 	"github.com/opd-ai/go-stats-generator/internal/reporter"
+	"github.com/opd-ai/go-stats-generator/pkg/generator"
 )
 
 var (
@@ -23,7 +24,7 @@ var (
 
 // analyzeCmd represents the analyze command
 var analyzeCmd = &cobra.Command{
-	Use:   "analyze [directory|file]",
+	Use:   "analyze [directory|file|-]",
 	Short: "Analyze Go source code in a directory or single file",
 	Long: `Analyze Go source code in the specified directory or file and generate comprehensive
 statistics about code structure, complexity, and patterns.
@@ -55,6 +56,9 @@ Examples:
   # Analyze a single file with detailed output
   go-stats-generator analyze ./internal/analyzer/function.go --format json --verbose
 
+  # Analyze Go source piped on stdin (or use --stdin)
+  cat foo.go | go-stats-generator analyze -
+
   # Analyze with custom worker count and timeout
   go-stats-generator analyze . --workers 8 --timeout 5m
 
@@ -68,7 +72,10 @@ Examples:
   go-stats-generator analyze . --format json --sections functions,duplication
 
   # Shorthand: output only the functions section
-  go-stats-generator analyze . --format json --only functions`,
+  go-stats-generator analyze . --format json --only functions
+
+  # Print only the concurrency and anti-pattern sections
+  go-stats-generator analyze . --sections concurrency,anti-patterns`,
 
 	Args: cobra.MaximumNArgs(1),
 	RunE: runAnalyze,
@@ -91,15 +98,29 @@ func init() {
 // registerOutputFlags adds output format and section filtering flags.
 func registerOutputFlags() {
 	analyzeCmd.Flags().StringVarP(&outputFormat, "format", "f", "console",
-		"output format (console, json, csv, html, markdown)")
+		"output format (console, json, jsonl, csv, html, markdown, dot)")
 	analyzeCmd.Flags().StringVarP(&outputFile, "output", "o", "",
 		"output file (default: stdout)")
+	analyzeCmd.Flags().BoolVar(&analyzeStdin, "stdin", false,
+		"read Go source from stdin and analyze it as a single file (same as passing - as the path)")
 	analyzeCmd.Flags().Bool("verbose", false,
 		"enable verbose output")
 	analyzeCmd.Flags().StringSlice("sections", []string{},
-		"include only these report sections in output (comma-separated: functions,structs,interfaces,packages,patterns,complexity,documentation,generics,duplication,naming,placement,organization,burden,scores,suggestions,metadata,overview)")
+		"include only these report sections in output (comma-separated, default all: functions,structs,interfaces,packages,patterns,concurrency,anti-patterns,complexity,documentation,generics,duplication,naming,placement,organization,burden,scores,test_presence,error_handling,suggestions,tree,metadata,overview)")
 	analyzeCmd.Flags().StringSlice("only", []string{},
 		"alias for --sections: include only these report sections in output")
+	analyzeCmd.Flags().Bool("warnings-only", false,
+		"emit only the report's warnings, one row or object per warning (json and csv formats)")
+	analyzeCmd.Flags().Bool("csv-structs", true,
+		"include the structs table in csv output")
+	analyzeCmd.Flags().Bool("csv-packages", true,
+		"include the packages table in csv output")
+	analyzeCmd.Flags().Bool("snapshot", false,
+		"store the analysis as a snapshot in the configured storage, with git commit, branch and tag metadata")
+	analyzeCmd.Flags().String("snapshot-description", "",
+		"description recorded with the --snapshot snapshot")
+	analyzeCmd.Flags().StringSlice("snapshot-tag", []string{},
+		"key=value tag recorded with the --snapshot snapshot (repeatable)")
 }
 
 // registerPerformanceFlags adds concurrency and timeout flags.
@@ -108,6 +129,16 @@ func registerPerformanceFlags() {
 		"number of worker goroutines (default: number of CPU cores)")
 	analyzeCmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute,
 		"analysis timeout")
+	analyzeCmd.Flags().Bool("bench", false,
+		"report files/sec, functions/sec, bytes/sec and peak memory to stderr after analysis")
+	analyzeCmd.Flags().Bool("low-memory", false,
+		"spool function and struct metrics to a temporary file during analysis to bound memory on large repositories")
+	analyzeCmd.Flags().Bool("cache", false,
+		"reuse results for unchanged files from the analysis cache in performance.cache_directory; "+
+			"unless no file changed, every file is still parsed for the package-wide analyses")
+	analyzeCmd.Flags().Bool("no-cache", false,
+		"analyze every file afresh, overriding performance.enable_cache")
+	analyzeCmd.MarkFlagsMutuallyExclusive("cache", "no-cache")
 }
 
 // registerFilterFlags adds file filtering and exclusion flags.
@@ -136,6 +167,8 @@ func registerAnalysisFlags() {
 		"include documentation analysis")
 	analyzeCmd.Flags().Bool("include-generics", true,
 		"include generic usage analysis")
+	analyzeCmd.Flags().Bool("include-performance", false,
+		"include heuristic hot-path allocation warnings for loops (not escape analysis)")
 	analyzeCmd.Flags().Bool("enable-team-metrics", false,
 		"enable team productivity analysis (requires Git repository)")
 	analyzeCmd.Flags().String("coverage-profile", "",
@@ -146,8 +179,12 @@ func registerAnalysisFlags() {
 func registerThresholdFlags() {
 	analyzeCmd.Flags().Int("max-function-length", 30,
 		"maximum function length warning threshold")
+	analyzeCmd.Flags().Int("max-struct-members", 30,
+		"maximum struct fields plus methods before flagging a god object")
 	analyzeCmd.Flags().Int("max-complexity", 10,
 		"maximum cyclomatic complexity warning threshold")
+	analyzeCmd.Flags().Int("max-test-complexity", 15,
+		"maximum cyclomatic complexity warning threshold for Test* functions")
 	analyzeCmd.Flags().Float64("min-doc-coverage", 0.7,
 		"minimum documentation coverage warning threshold")
 	analyzeCmd.Flags().Float64("min-package-doc-coverage", 0.4,
@@ -158,6 +195,12 @@ func registerThresholdFlags() {
 		"maximum number of undocumented exported symbols allowed")
 	analyzeCmd.Flags().Bool("enforce-thresholds", false,
 		"exit with non-zero code if quality thresholds are violated (for CI/CD integration)")
+	analyzeCmd.Flags().Int("fail-on-complexity", 0,
+		"exit with code 2 if any function's cyclomatic complexity exceeds this value (0 = disabled)")
+	analyzeCmd.Flags().Int("fail-on-function-length", 0,
+		"exit with code 2 if any function has more code lines than this value (0 = disabled)")
+	analyzeCmd.Flags().Float64("fail-on-doc-coverage", 0,
+		"exit with code 2 if overall documentation coverage is below this percentage (0 = disabled)")
 }
 
 // registerDuplicationFlags adds code duplication detection flags.
@@ -191,15 +234,41 @@ func registerOrganizationFlags() {
 // registerBurdenFlags adds maintenance burden analysis flags.
 func registerBurdenFlags() {
 	analyzeCmd.Flags().Int("max-params", 5,
-		"maximum function parameters before flagging high signature complexity")
+		"maximum function parameters before flagging high signature complexity and a long parameter list")
 	analyzeCmd.Flags().Int("max-returns", 3,
 		"maximum return values before flagging high signature complexity")
 	analyzeCmd.Flags().Int("max-nesting", 4,
 		"maximum nesting depth before flagging deeply nested code")
+	analyzeCmd.Flags().Int("max-type-depth", 3,
+		"maximum nesting of map/slice/pointer/channel types in a signature before suggesting a named type")
+	analyzeCmd.Flags().Int("max-chain-depth", 4,
+		"maximum chained method calls (a.B().C().D()) before emitting a Law of Demeter advisory")
+	analyzeCmd.Flags().Int("max-naked-return-lines", 10,
+		"maximum body lines of a function with named results before its naked returns are flagged (0 disables)")
+	analyzeCmd.Flags().StringSlice("chain-exclusions", []string{"With*", "Set*", "Add*", "Build", "Wrap*", "Errorf"},
+		"method name globs for fluent builder and error-wrapping calls that do not count toward chain depth")
+	analyzeCmd.Flags().StringSlice("allowed-magic-numbers", []string{"0", "1", "-1"},
+		"numeric values that are never reported as magic number anti-patterns")
 	analyzeCmd.Flags().Float64("feature-envy-ratio", 2.0,
 		"threshold ratio for detecting feature envy (external references / self references)")
+	analyzeCmd.Flags().Bool("detect-constructor-bypass", true,
+		"flag struct literals that skip an existing New<Type> constructor in the same package")
+	analyzeCmd.Flags().Bool("exempt-constructor-params", true,
+		"do not flag long parameter lists of New* constructor functions")
+	analyzeCmd.Flags().Bool("detect-interface-pollution", true,
+		"flag single-method interfaces with one implementer and no test double")
+	analyzeCmd.Flags().Bool("detect-unimplemented-interfaces", true,
+		"list exported interfaces that no analyzed type implements")
+	analyzeCmd.Flags().Int("external-interface-max-methods", 1,
+		"most methods an unimplemented interface used as a parameter type may have and still be assumed implemented externally (0 disables)")
+	analyzeCmd.Flags().Int("max-interface-params", 5,
+		"maximum parameters of an interface method before it is reported as oversized")
+	analyzeCmd.Flags().Int("max-interface-returns", 3,
+		"maximum return values of an interface method before it is reported as oversized")
 	analyzeCmd.Flags().Float64("max-burden-score", 70.0,
 		"maximum Maintenance Burden Index (MBI) score allowed (0-100 scale, default 70=critical threshold)")
+	analyzeCmd.Flags().Float64("test-code-weight", 0.0,
+		"weight of test-file quality in the overall quality score (0 = production code only)")
 }
 
 // bindFlagsToViper binds all command flags to viper configuration keys.
@@ -220,6 +289,12 @@ func bindOutputFlags() {
 		{"verbose", "output.verbose"},
 		{"sections", "output.sections"},
 		{"only", "output.only"},
+		{"warnings-only", "output.warnings_only"},
+		{"csv-structs", "output.csv.include_structs"},
+		{"csv-packages", "output.csv.include_packages"},
+		{"snapshot", "storage.snapshot"},
+		{"snapshot-description", "storage.snapshot_description"},
+		{"snapshot-tag", "storage.snapshot_tags"},
 	})
 }
 
@@ -228,6 +303,10 @@ func bindPerformanceFlags() {
 	bindFlags(analyzeCmd, []flagBinding{
 		{"workers", "performance.worker_count"},
 		{"timeout", "performance.timeout"},
+		{"bench", "performance.bench"},
+		{"low-memory", "performance.low_memory"},
+		{"cache", "performance.enable_cache"},
+		{"no-cache", "performance.no_cache"},
 	})
 }
 
@@ -250,15 +329,21 @@ func bindAnalysisFlags() {
 		{"include-complexity", "analysis.include_complexity"},
 		{"include-documentation", "analysis.include_documentation"},
 		{"include-generics", "analysis.include_generics"},
+		{"include-performance", "analysis.include_performance"},
 		{"enable-team-metrics", "analysis.enable_team_metrics"},
 		{"coverage-profile", "analysis.coverage_profile"},
 		{"max-function-length", "analysis.max_function_length"},
+		{"max-struct-members", "analysis.max_struct_members"},
 		{"max-complexity", "analysis.max_cyclomatic_complexity"},
+		{"max-test-complexity", "analysis.max_test_complexity"},
 		{"min-doc-coverage", "analysis.min_documentation_coverage"},
 		{"min-package-doc-coverage", "analysis.min_package_doc_coverage"},
 		{"max-duplication-ratio", "analysis.max_duplication_ratio"},
 		{"max-undocumented-exports", "analysis.max_undocumented_exports"},
 		{"enforce-thresholds", "analysis.enforce_thresholds"},
+		{"fail-on-complexity", "analysis.fail_on_complexity"},
+		{"fail-on-function-length", "analysis.fail_on_function_length"},
+		{"fail-on-doc-coverage", "analysis.fail_on_doc_coverage"},
 		{"min-block-lines", "analysis.duplication.min_block_lines"},
 		{"similarity-threshold", "analysis.duplication.similarity_threshold"},
 		{"ignore-test-duplication", "analysis.duplication.ignore_test_files"},
@@ -284,37 +369,71 @@ func bindBurdenFlags() {
 		{"max-params", "analysis.burden.max_params"},
 		{"max-returns", "analysis.burden.max_returns"},
 		{"max-nesting", "analysis.burden.max_nesting"},
+		{"max-type-depth", "analysis.burden.max_type_depth"},
+		{"max-chain-depth", "analysis.burden.max_chain_depth"},
+		{"max-naked-return-lines", "analysis.burden.max_naked_return_lines"},
+		{"chain-exclusions", "analysis.burden.chain_exclusions"},
+		{"allowed-magic-numbers", "analysis.burden.allowed_magic_numbers"},
 		{"feature-envy-ratio", "analysis.burden.feature_envy_ratio"},
+		{"detect-constructor-bypass", "analysis.burden.detect_constructor_bypass"},
+		{"exempt-constructor-params", "analysis.burden.exempt_constructor_params"},
+		{"detect-interface-pollution", "analysis.burden.detect_interface_pollution"},
+		{"detect-unimplemented-interfaces", "analysis.burden.detect_unimplemented_interfaces"},
+		{"external-interface-max-methods", "analysis.burden.external_interface_max_methods"},
+		{"max-interface-params", "analysis.burden.max_interface_params"},
+		{"max-interface-returns", "analysis.burden.max_interface_returns"},
 		{"max-burden-score", "analysis.scoring.max_burden_score"},
+		{"test-code-weight", "analysis.scoring.test_code_weight"},
 	})
 }
 
 // runAnalyze is the main entry point for the analyze command.
 func runAnalyze(cmd *cobra.Command, args []string) error {
-	absPath, fileInfo, err := validateAndResolvePath(args)
+	if isStdinTarget(args) {
+		return runAnalyzeStdin(commandStdin(cmd))
+	}
+
+	absPath, _, err := validateAndResolvePath(args)
 	if err != nil {
 		return err
 	}
 
-	cfg := loadConfiguration()
+	cfg, err := loadProjectConfiguration(absPath)
+	if err != nil {
+		return err
+	}
 
 	if err := validateFilterFlags(cfg); err != nil {
 		return err
 	}
 
-	report, err := executeAnalysis(absPath, fileInfo, cfg)
+	report, err := executeAnalysisWithBench(absPath, cfg)
 	if err != nil {
 		return err
 	}
 
+	if err := storeAnalysisSnapshot(absPath, report, cfg); err != nil {
+		return err
+	}
+
 	return processResults(report, cfg)
 }
 
-// validateFilterFlags checks for mutually exclusive filter flag combinations.
+// validateFilterFlags checks for mutually exclusive filter flag combinations, unknown section
+// names, and output modes the format does not support, before the analysis runs.
 func validateFilterFlags(cfg *config.Config) error {
 	if cfg.Filters.SkipTestFiles && cfg.Filters.OnlyTestFiles {
 		return fmt.Errorf("--skip-tests and --only-tests are mutually exclusive: cannot both skip and exclusively analyze test files")
 	}
+	if err := metrics.ValidateSections(cfg.Output.Sections); err != nil {
+		return fmt.Errorf("invalid --sections: %w", err)
+	}
+	if reporter.Type(cfg.Output.Format) == reporter.TypeJSONPatch {
+		return fmt.Errorf("--format jsonpatch is only supported by the diff command")
+	}
+	if cfg.Output.WarningsOnly && cfg.Output.Format != config.FormatJSON && cfg.Output.Format != config.FormatCSV {
+		return fmt.Errorf("--warnings-only is supported by the json and csv formats, not %s", cfg.Output.Format)
+	}
 	return nil
 }
 
@@ -339,19 +458,11 @@ func validateAndResolvePath(args []string) (string, os.FileInfo, error) {
 }
 
 // executeAnalysis runs the analysis with timeout context for file or directory.
-func executeAnalysis(absPath string, fileInfo os.FileInfo, cfg *config.Config) (*metrics.Report, error) {
+func executeAnalysis(absPath string, cfg *config.Config) (*metrics.Report, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Performance.Timeout)
 	defer cancel()
 
-	var report *metrics.Report
-	var err error
-
-	if fileInfo.IsDir() {
-		report, err = runDirectoryAnalysis(ctx, absPath, cfg)
-	} else {
-		report, err = runFileAnalysis(ctx, absPath, cfg)
-	}
-
+	report, err := runAnalysis(ctx, absPath, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("analysis failed: %w", err)
 	}
@@ -359,8 +470,45 @@ func executeAnalysis(absPath string, fileInfo os.FileInfo, cfg *config.Config) (
 	return report, nil
 }
 
+// runAnalysis analyzes a file or directory through the generator API, printing verbose
+// diagnostics and file progress to stderr when the configuration asks for them.
+func runAnalysis(ctx context.Context, path string, cfg *config.Config) (*metrics.Report, error) {
+	return generator.Analyze(ctx, path, runtimeConfig(cfg))
+}
+
+// runtimeConfig returns a copy of cfg whose logger and progress callback write verbose
+// diagnostics and file progress to stderr when the configuration asks for them
+func runtimeConfig(cfg *config.Config) config.Config {
+	runCfg := *cfg
+	if runCfg.Output.Verbose {
+		runCfg.Output.Logger = func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format, args...)
+		}
+	}
+	if runCfg.Output.ShowProgress {
+		runCfg.Output.Progress = printProgress
+	}
+	return runCfg
+}
+
+// printProgress overwrites the file progress line on stderr, ending it once every file is
+// processed. Events from the other phases are not shown.
+func printProgress(event config.ProgressEvent) {
+	if event.Phase != config.PhaseAnalysis {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\rProcessing files: %d/%d (%.1f%%)",
+		event.Completed, event.Total, float64(event.Completed)/float64(event.Total)*100)
+	if event.Completed == event.Total {
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+}
+
 // processResults filters report sections, generates output, and checks quality gates.
 func processResults(report *metrics.Report, cfg *config.Config) error {
+	// Fail-on limits judge the full report, not just the sections selected for output
+	failures := metrics.CheckThresholds(report, failOnThresholds(cfg))
+
 	metrics.FilterReportSections(report, cfg.Output.Sections)
 
 	if err := generateOutput(report, cfg); err != nil {
@@ -371,16 +519,19 @@ func processResults(report *metrics.Report, cfg *config.Config) error {
 		return err
 	}
 
-	return nil
+	return reportThresholdViolations(failures)
 }
 
 // generateOutput creates the output report using the configured reporter and destination.
 func generateOutput(report *metrics.Report, cfg *config.Config) error {
 	// Create appropriate reporter using the factory
-	rep, err := reporter.NewReporter(string(cfg.Output.Format))
+	rep, err := reporter.NewReporterWithConfig(string(cfg.Output.Format), &cfg.Output)
 	if err != nil {
 		return fmt.Errorf("failed to create reporter: %w", err)
 	}
+	if exporter, ok := rep.(reporter.WarningsExporter); ok {
+		exporter.SetWarningsOnly(cfg.Output.WarningsOnly)
+	}
 
 	// Determine output destination
 	var output *os.File
@@ -394,6 +545,11 @@ func generateOutput(report *metrics.Report, cfg *config.Config) error {
 		defer output.Close()
 	}
 
+	// Restrict reporter output to the requested sections
+	if selector, ok := rep.(reporter.SectionSelector); ok {
+		selector.SetSections(cfg.Output.Sections)
+	}
+
 	// Generate report
 	err = rep.Generate(report, output)
 	if err != nil {
@@ -429,6 +585,44 @@ func checkQualityGates(report *metrics.Report, cfg *config.Config) error {
 	return nil
 }
 
+// thresholdViolationError reports that the analysis succeeded but broke a --fail-on limit or a
+// diff regression gate; it carries its own exit code so CI can tell it apart from a failed run
+type thresholdViolationError struct {
+	count int
+}
+
+func (e *thresholdViolationError) Error() string {
+	return fmt.Sprintf("threshold check failed: %d violation(s)", e.count)
+}
+
+// ExitCode returns the process exit code for threshold violations
+func (e *thresholdViolationError) ExitCode() int {
+	return 2
+}
+
+// failOnThresholds maps the --fail-on limits onto a threshold configuration, leaving every
+// other limit at zero so CheckThresholds skips it
+func failOnThresholds(cfg *config.Config) metrics.ThresholdConfig {
+	var thresholds metrics.ThresholdConfig
+	thresholds.FunctionComplexity.Error = cfg.Analysis.FailOnComplexity
+	thresholds.FunctionLength.MaxLines = cfg.Analysis.FailOnFunctionLength
+	thresholds.Documentation.MinCoverage = cfg.Analysis.FailOnDocCoverage
+	return thresholds
+}
+
+// reportThresholdViolations prints a summary of --fail-on violations to stderr and returns a
+// thresholdViolationError when there are any
+func reportThresholdViolations(violations []metrics.ThresholdViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "\n=== THRESHOLD VIOLATIONS ===\n")
+	for _, violation := range violations {
+		fmt.Fprintf(os.Stderr, "❌ %s\n", violation)
+	}
+	return &thresholdViolationError{count: len(violations)}
+}
+
 // countUndocumentedExports counts exported symbols without documentation
 func countUndocumentedExports(report *metrics.Report) int {
 	return countUndocumentedFunctions(report) +
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// benchSampleInterval is how often heap usage is polled while --bench is active
+const benchSampleInterval = 50 * time.Millisecond
+
+// benchmarkStats summarizes the throughput and memory use of a single analysis run
+type benchmarkStats struct {
+	Elapsed            time.Duration
+	Files              int
+	Functions          int
+	Bytes              int64
+	FilesPerSecond     float64
+	FunctionsPerSecond float64
+	BytesPerSecond     float64
+	PeakMemoryBytes    uint64
+}
+
+// memorySampler polls runtime.MemStats in the background and keeps the highest heap usage seen
+type memorySampler struct {
+	stop chan struct{}
+	wg   sync.WaitGroup
+	peak uint64
+}
+
+// executeAnalysisWithBench runs executeAnalysis and, when --bench is set, writes throughput
+// and peak memory figures to stderr once the analysis has finished.
+func executeAnalysisWithBench(absPath string, cfg *config.Config) (*metrics.Report, error) {
+	if !cfg.Performance.Bench {
+		return executeAnalysis(absPath, cfg)
+	}
+
+	sampler := startMemorySampler(benchSampleInterval)
+	startTime := time.Now()
+	report, err := executeAnalysis(absPath, cfg)
+	elapsed := time.Since(startTime)
+	peak := sampler.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	writeBenchmarkStats(os.Stderr, computeBenchmarkStats(report, elapsed, peak))
+	return report, nil
+}
+
+// startMemorySampler begins polling heap usage every interval until Stop is called
+func startMemorySampler(interval time.Duration) *memorySampler {
+	s := &memorySampler{stop: make(chan struct{})}
+	s.sample()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.sample()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// sample records the current heap usage if it exceeds the peak seen so far
+func (s *memorySampler) sample() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	s.peak = max(s.peak, stats.HeapInuse)
+}
+
+// Stop ends sampling, takes a final reading, and returns the peak heap usage in bytes
+func (s *memorySampler) Stop() uint64 {
+	close(s.stop)
+	s.wg.Wait()
+	s.sample()
+	return s.peak
+}
+
+// computeBenchmarkStats derives per-second throughput from the report totals and elapsed time
+func computeBenchmarkStats(report *metrics.Report, elapsed time.Duration, peakMemory uint64) benchmarkStats {
+	stats := benchmarkStats{
+		Elapsed:         elapsed,
+		Files:           report.Metadata.FilesProcessed,
+		Functions:       len(report.Functions),
+		Bytes:           report.Metadata.BytesProcessed,
+		PeakMemoryBytes: peakMemory,
+	}
+
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		stats.FilesPerSecond = float64(stats.Files) / seconds
+		stats.FunctionsPerSecond = float64(stats.Functions) / seconds
+		stats.BytesPerSecond = float64(stats.Bytes) / seconds
+	}
+	return stats
+}
+
+// writeBenchmarkStats prints the benchmark summary in a compact, grep-friendly layout
+func writeBenchmarkStats(w io.Writer, stats benchmarkStats) {
+	fmt.Fprintf(w, "=== BENCHMARK ===\n")
+	fmt.Fprintf(w, "Elapsed:        %s\n", stats.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Files:          %d (%.1f files/sec)\n", stats.Files, stats.FilesPerSecond)
+	fmt.Fprintf(w, "Functions:      %d (%.1f functions/sec)\n", stats.Functions, stats.FunctionsPerSecond)
+	fmt.Fprintf(w, "Bytes:          %d (%.1f KB/sec)\n", stats.Bytes, stats.BytesPerSecond/1024)
+	fmt.Fprintf(w, "Peak heap:      %.1f MB\n", float64(stats.PeakMemoryBytes)/(1024*1024))
+}
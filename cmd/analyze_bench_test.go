@@ -1,15 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
 	"github.com/opd-ai/go-stats-generator/pkg/generator"
 )
 
@@ -110,3 +113,50 @@ func BenchmarkFullAnalysis_WithMemoryTracking(b *testing.B) {
 	os.Stdout = oldStdout
 	_, _ = io.WriteString(oldStdout, "\n")
 }
+
+// TestComputeBenchmarkStats verifies that --bench throughput figures are populated for a real run
+func TestComputeBenchmarkStats(t *testing.T) {
+	testDir := filepath.Join("..", "testdata", "simple")
+	cfg := config.DefaultConfig()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sampler := startMemorySampler(time.Millisecond)
+	startTime := time.Now()
+	report, err := runAnalysis(ctx, testDir, cfg)
+	elapsed := time.Since(startTime)
+	peak := sampler.Stop()
+	if err != nil {
+		t.Fatalf("Analysis failed: %v", err)
+	}
+
+	stats := computeBenchmarkStats(report, elapsed, peak)
+	if stats.Files == 0 || stats.Functions == 0 || stats.Bytes == 0 {
+		t.Fatalf("Expected non-zero totals, got files=%d functions=%d bytes=%d", stats.Files, stats.Functions, stats.Bytes)
+	}
+	if stats.FilesPerSecond <= 0 || stats.FunctionsPerSecond <= 0 || stats.BytesPerSecond <= 0 {
+		t.Errorf("Expected positive throughput, got %.2f files/sec, %.2f functions/sec, %.2f bytes/sec",
+			stats.FilesPerSecond, stats.FunctionsPerSecond, stats.BytesPerSecond)
+	}
+	if stats.PeakMemoryBytes == 0 {
+		t.Error("Expected peak memory to be recorded")
+	}
+
+	var buf bytes.Buffer
+	writeBenchmarkStats(&buf, stats)
+	for _, want := range []string{"files/sec", "functions/sec", "KB/sec", "Peak heap"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Benchmark output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+// TestComputeBenchmarkStats_ZeroElapsed verifies that a zero duration does not divide by zero
+func TestComputeBenchmarkStats_ZeroElapsed(t *testing.T) {
+	report := &metrics.Report{Metadata: metrics.ReportMetadata{FilesProcessed: 3, BytesProcessed: 100}}
+	stats := computeBenchmarkStats(report, 0, 0)
+	if stats.FilesPerSecond != 0 || stats.BytesPerSecond != 0 {
+		t.Errorf("Expected zero throughput for zero elapsed time, got %+v", stats)
+	}
+}
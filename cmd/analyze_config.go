@@ -1,12 +1,68 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/viper"
 
-	"github.com/opd-ai/go-stats-generator/internal/analyzer"
 	"github.com/opd-ai/go-stats-generator/internal/config"
 )
 
+// loadProjectConfiguration reads and validates the config file that applies to targetPath and
+// returns the configuration assembled from flags, environment, file and defaults, in that order
+// of precedence. Unknown keys in the file and out-of-range values are errors.
+func loadProjectConfiguration(targetPath string) (*config.Config, error) {
+	if err := readProjectConfigFile(targetPath); err != nil {
+		return nil, err
+	}
+	cfg := loadConfiguration()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// readProjectConfigFile selects the --config file, else the .go-stats-generator.yaml or
+// .go-stats.yaml in the analyzed directory, else the file found at startup, checks its keys
+// against the configuration schema and loads it into viper
+func readProjectConfigFile(targetPath string) error {
+	path := cfgFile
+	if path == "" {
+		path = config.FindConfigFile(projectDirectory(targetPath))
+	}
+	if path == "" {
+		path = viper.ConfigFileUsed()
+	}
+	if path == "" {
+		return nil
+	}
+
+	file := viper.New()
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to load config file %s: %w", path, err)
+	}
+	if err := config.ValidateKeys(file.AllSettings()); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to load config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// projectDirectory returns targetPath when it is a directory and its parent otherwise
+func projectDirectory(targetPath string) string {
+	if info, err := os.Stat(targetPath); err == nil && !info.IsDir() {
+		return filepath.Dir(targetPath)
+	}
+	return targetPath
+}
+
 // loadConfiguration creates and populates a Config object from viper settings
 func loadConfiguration() *config.Config {
 	cfg := config.DefaultConfig()
@@ -14,9 +70,21 @@ func loadConfiguration() *config.Config {
 	loadPerformanceConfiguration(cfg)
 	loadFilterConfiguration(cfg)
 	loadAnalysisConfiguration(cfg)
+	loadSnapshotConfiguration(cfg)
 	return cfg
 }
 
+// loadSnapshotConfiguration loads the analyze --snapshot settings from viper
+func loadSnapshotConfiguration(cfg *config.Config) {
+	setBoolIfSet("storage.snapshot", &cfg.Storage.Snapshot)
+	if viper.IsSet("storage.snapshot_description") {
+		cfg.Storage.SnapshotDescription = viper.GetString("storage.snapshot_description")
+	}
+	if viper.IsSet("storage.snapshot_tags") {
+		cfg.Storage.SnapshotTags = viper.GetStringSlice("storage.snapshot_tags")
+	}
+}
+
 // loadOutputConfiguration loads output-related settings from viper
 func loadOutputConfiguration(cfg *config.Config) {
 	applyOutputSettings(cfg)
@@ -36,6 +104,9 @@ func applyOutputSettings(cfg *config.Config) {
 	setBoolIfSet("output.show_progress", &cfg.Output.ShowProgress)
 	setBoolIfSet("output.use_colors", &cfg.Output.UseColors)
 	setBoolIfSet("output.include_examples", &cfg.Output.IncludeExamples)
+	setBoolIfSet("output.warnings_only", &cfg.Output.WarningsOnly)
+	setBoolIfSet("output.csv.include_structs", &cfg.Output.CSV.IncludeStructs)
+	setBoolIfSet("output.csv.include_packages", &cfg.Output.CSV.IncludePackages)
 }
 
 // setBoolIfSet sets a boolean pointer if the viper key is set
@@ -89,12 +160,20 @@ func loadPerformanceConfiguration(cfg *config.Config) {
 	if viper.IsSet("performance.enable_cache") {
 		cfg.Performance.EnableCache = viper.GetBool("performance.enable_cache")
 	}
+	if viper.GetBool("performance.no_cache") {
+		cfg.Performance.EnableCache = false
+	}
+	if viper.IsSet("performance.cache_directory") {
+		cfg.Performance.CacheDirectory = viper.GetString("performance.cache_directory")
+	}
 	if viper.IsSet("performance.max_memory_mb") {
 		cfg.Performance.MaxMemoryMB = viper.GetInt("performance.max_memory_mb")
 	}
 	if viper.IsSet("performance.enable_profiling") {
 		cfg.Performance.EnableProfiling = viper.GetBool("performance.enable_profiling")
 	}
+	setBoolIfSet("performance.bench", &cfg.Performance.Bench)
+	setBoolIfSet("performance.low_memory", &cfg.Performance.LowMemory)
 }
 
 // loadFilterConfiguration loads file filtering settings from viper
@@ -131,6 +210,7 @@ func loadAnalysisConfiguration(cfg *config.Config) {
 	loadBurdenSettings(cfg)
 	loadDocumentationSettings(cfg)
 	loadScoringSettings(cfg)
+	loadComplexityWeightSettings(cfg)
 }
 
 // loadBasicAnalysisSettings loads core analysis toggles from viper
@@ -149,6 +229,7 @@ func loadBooleanAnalysisSettings(cfg *config.Config) {
 		"analysis.include_complexity":    &cfg.Analysis.IncludeComplexity,
 		"analysis.include_documentation": &cfg.Analysis.IncludeDocumentation,
 		"analysis.include_generics":      &cfg.Analysis.IncludeGenerics,
+		"analysis.include_performance":   &cfg.Analysis.IncludePerformance,
 		"analysis.enable_team_metrics":   &cfg.Analysis.EnableTeamMetrics,
 	}
 
@@ -171,9 +252,15 @@ func loadThresholdSettings(cfg *config.Config) {
 	if viper.IsSet("analysis.max_function_length") {
 		cfg.Analysis.MaxFunctionLength = viper.GetInt("analysis.max_function_length")
 	}
+	if viper.IsSet("analysis.max_struct_members") {
+		cfg.Analysis.MaxStructMembers = viper.GetInt("analysis.max_struct_members")
+	}
 	if viper.IsSet("analysis.max_cyclomatic_complexity") {
 		cfg.Analysis.MaxCyclomaticComplexity = viper.GetInt("analysis.max_cyclomatic_complexity")
 	}
+	if viper.IsSet("analysis.max_test_complexity") {
+		cfg.Analysis.MaxTestComplexity = viper.GetInt("analysis.max_test_complexity")
+	}
 	if viper.IsSet("analysis.min_documentation_coverage") {
 		cfg.Analysis.MinDocumentationCoverage = viper.GetFloat64("analysis.min_documentation_coverage")
 	}
@@ -183,6 +270,15 @@ func loadThresholdSettings(cfg *config.Config) {
 	if viper.IsSet("analysis.enforce_thresholds") {
 		cfg.Analysis.EnforceThresholds = viper.GetBool("analysis.enforce_thresholds")
 	}
+	if viper.IsSet("analysis.fail_on_complexity") {
+		cfg.Analysis.FailOnComplexity = viper.GetInt("analysis.fail_on_complexity")
+	}
+	if viper.IsSet("analysis.fail_on_function_length") {
+		cfg.Analysis.FailOnFunctionLength = viper.GetInt("analysis.fail_on_function_length")
+	}
+	if viper.IsSet("analysis.fail_on_doc_coverage") {
+		cfg.Analysis.FailOnDocCoverage = viper.GetFloat64("analysis.fail_on_doc_coverage")
+	}
 }
 
 // loadDuplicationSettings loads code duplication detection settings from viper
@@ -279,9 +375,37 @@ func loadBurdenSettings(cfg *config.Config) {
 	if viper.IsSet("analysis.burden.max_nesting") {
 		cfg.Analysis.Burden.MaxNesting = viper.GetInt("analysis.burden.max_nesting")
 	}
+	if viper.IsSet("analysis.burden.max_type_depth") {
+		cfg.Analysis.Burden.MaxTypeDepth = viper.GetInt("analysis.burden.max_type_depth")
+	}
+	if viper.IsSet("analysis.burden.max_chain_depth") {
+		cfg.Analysis.Burden.MaxChainDepth = viper.GetInt("analysis.burden.max_chain_depth")
+	}
+	if viper.IsSet("analysis.burden.max_naked_return_lines") {
+		cfg.Analysis.Burden.MaxNakedReturnLines = viper.GetInt("analysis.burden.max_naked_return_lines")
+	}
+	if viper.IsSet("analysis.burden.external_interface_max_methods") {
+		cfg.Analysis.Burden.ExternalInterfaceMaxMethods = viper.GetInt("analysis.burden.external_interface_max_methods")
+	}
+	if viper.IsSet("analysis.burden.max_interface_params") {
+		cfg.Analysis.Burden.MaxInterfaceParams = viper.GetInt("analysis.burden.max_interface_params")
+	}
+	if viper.IsSet("analysis.burden.max_interface_returns") {
+		cfg.Analysis.Burden.MaxInterfaceReturns = viper.GetInt("analysis.burden.max_interface_returns")
+	}
+	if viper.IsSet("analysis.burden.chain_exclusions") {
+		cfg.Analysis.Burden.ChainExclusions = viper.GetStringSlice("analysis.burden.chain_exclusions")
+	}
+	if viper.IsSet("analysis.burden.allowed_magic_numbers") {
+		cfg.Analysis.Burden.AllowedMagicNumbers = viper.GetStringSlice("analysis.burden.allowed_magic_numbers")
+	}
 	if viper.IsSet("analysis.burden.feature_envy_ratio") {
 		cfg.Analysis.Burden.FeatureEnvyRatio = viper.GetFloat64("analysis.burden.feature_envy_ratio")
 	}
+	setBoolIfSet("analysis.burden.detect_constructor_bypass", &cfg.Analysis.Burden.DetectConstructorBypass)
+	setBoolIfSet("analysis.burden.exempt_constructor_params", &cfg.Analysis.Burden.ExemptConstructorParams)
+	setBoolIfSet("analysis.burden.detect_interface_pollution", &cfg.Analysis.Burden.DetectInterfacePollution)
+	setBoolIfSet("analysis.burden.detect_unimplemented_interfaces", &cfg.Analysis.Burden.DetectUnimplementedInterfaces)
 }
 
 // loadDocumentationSettings loads documentation analysis settings from viper
@@ -305,17 +429,25 @@ func loadScoringSettings(cfg *config.Config) {
 	if viper.IsSet("analysis.scoring.max_burden_score") {
 		cfg.Analysis.Scoring.MaxBurdenScore = viper.GetFloat64("analysis.scoring.max_burden_score")
 	}
+	if viper.IsSet("analysis.scoring.test_code_weight") {
+		cfg.Analysis.Scoring.TestCodeWeight = viper.GetFloat64("analysis.scoring.test_code_weight")
+	}
 }
 
-// getOrganizationConfig extracts organization analysis configuration from the main config
-func getOrganizationConfig(cfg *config.Config) analyzer.OrganizationConfig {
-	return analyzer.OrganizationConfig{
-		MaxFileLines:       cfg.Analysis.Organization.MaxFileLines,
-		MaxFileFunctions:   cfg.Analysis.Organization.MaxFileFunctions,
-		MaxFileTypes:       cfg.Analysis.Organization.MaxFileTypes,
-		MaxPackageFiles:    cfg.Analysis.Organization.MaxPackageFiles,
-		MaxExportedSymbols: cfg.Analysis.Organization.MaxExportedSymbols,
-		MaxDirectoryDepth:  cfg.Analysis.Organization.MaxDirectoryDepth,
-		MaxFileImports:     cfg.Analysis.Organization.MaxFileImports,
+// loadComplexityWeightSettings loads the weights of the overall complexity score from viper
+func loadComplexityWeightSettings(cfg *config.Config) {
+	weights := &cfg.Analysis.ComplexityWeights
+	floatSettings := map[string]*float64{
+		"analysis.complexity_weights.function.cyclomatic": &weights.Function.Cyclomatic,
+		"analysis.complexity_weights.function.nesting":    &weights.Function.Nesting,
+		"analysis.complexity_weights.function.cognitive":  &weights.Function.Cognitive,
+		"analysis.complexity_weights.struct.cyclomatic":   &weights.Struct.Cyclomatic,
+		"analysis.complexity_weights.struct.nesting":      &weights.Struct.Nesting,
+	}
+
+	for key, target := range floatSettings {
+		if viper.IsSet(key) {
+			*target = viper.GetFloat64(key)
+		}
 	}
 }
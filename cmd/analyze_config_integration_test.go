@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,3 +237,79 @@ func TestConfigValidationAfterLoad(t *testing.T) {
 		})
 	}
 }
+
+// writeProjectConfig writes content as the named config file in a fresh project directory and
+// resets viper to the state initConfig leaves it in
+func writeProjectConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	viper.Reset()
+	setupEnvironment()
+	t.Cleanup(viper.Reset)
+	return dir
+}
+
+// TestLoadProjectConfiguration_ReadsFileFromTargetDirectory verifies a valid config in the analyzed directory is applied
+func TestLoadProjectConfiguration_ReadsFileFromTargetDirectory(t *testing.T) {
+	dir := writeProjectConfig(t, ".go-stats.yaml", `analysis:
+  max_function_length: 45
+  burden:
+    max_params: 7
+filters:
+  skip_test_files: true
+`)
+
+	cfg, err := loadProjectConfiguration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 45, cfg.Analysis.MaxFunctionLength)
+	assert.Equal(t, 7, cfg.Analysis.Burden.MaxParams)
+	assert.True(t, cfg.Filters.SkipTestFiles)
+	assert.Equal(t, 10, cfg.Analysis.MaxCyclomaticComplexity, "unset keys keep their defaults")
+}
+
+// TestLoadProjectConfiguration_RejectsUnknownKeys verifies a misspelled key is reported instead of ignored
+func TestLoadProjectConfiguration_RejectsUnknownKeys(t *testing.T) {
+	dir := writeProjectConfig(t, ".go-stats-generator.yaml", `analysis:
+  max_function_lenght: 45
+`)
+
+	_, err := loadProjectConfiguration(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "analysis.max_function_lenght")
+}
+
+// TestLoadProjectConfiguration_RejectsOutOfRangeValues verifies thresholds are range checked
+func TestLoadProjectConfiguration_RejectsOutOfRangeValues(t *testing.T) {
+	dir := writeProjectConfig(t, ".go-stats-generator.yaml", `analysis:
+  duplication:
+    similarity_threshold: 1.5
+`)
+
+	_, err := loadProjectConfiguration(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "analysis.duplication.similarity_threshold")
+}
+
+// TestLoadProjectConfiguration_Precedence verifies flags override the environment, which overrides the file
+func TestLoadProjectConfiguration_Precedence(t *testing.T) {
+	dir := writeProjectConfig(t, ".go-stats-generator.yaml", `analysis:
+  max_function_length: 45
+  max_cyclomatic_complexity: 12
+  max_struct_members: 40
+`)
+	flags := (&cobra.Command{Use: "analyze"}).Flags()
+	flags.Int("max-function-length", 30, "")
+	flags.Int("max-complexity", 10, "")
+	require.NoError(t, viper.BindPFlag("analysis.max_function_length", flags.Lookup("max-function-length")))
+	require.NoError(t, viper.BindPFlag("analysis.max_cyclomatic_complexity", flags.Lookup("max-complexity")))
+	require.NoError(t, flags.Set("max-function-length", "60"))
+	t.Setenv("GO_STATS_ANALYSIS_MAX_FUNCTION_LENGTH", "50")
+	t.Setenv("GO_STATS_ANALYSIS_MAX_CYCLOMATIC_COMPLEXITY", "14")
+
+	cfg, err := loadProjectConfiguration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 60, cfg.Analysis.MaxFunctionLength, "a flag overrides the environment and the file")
+	assert.Equal(t, 14, cfg.Analysis.MaxCyclomaticComplexity, "the environment overrides the file when the flag is unset")
+	assert.Equal(t, 40, cfg.Analysis.MaxStructMembers, "the file overrides the defaults")
+}
@@ -206,6 +206,27 @@ func TestLoadPerformanceConfiguration(t *testing.T) {
 				assert.False(t, cfg.Performance.EnableCache)
 			},
 		},
+		{
+			name: "no_cache overrides enable_cache",
+			setup: func() {
+				viper.Reset()
+				viper.Set("performance.enable_cache", true)
+				viper.Set("performance.no_cache", true)
+			},
+			validate: func(t *testing.T, cfg *config.Config) {
+				assert.False(t, cfg.Performance.EnableCache)
+			},
+		},
+		{
+			name: "loads cache_directory from config",
+			setup: func() {
+				viper.Reset()
+				viper.Set("performance.cache_directory", "/tmp/stats-cache")
+			},
+			validate: func(t *testing.T, cfg *config.Config) {
+				assert.Equal(t, "/tmp/stats-cache", cfg.Performance.CacheDirectory)
+			},
+		},
 		{
 			name: "loads max_memory_mb from config",
 			setup: func() {
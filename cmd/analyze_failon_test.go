@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const failOnFixture = `package gate
+
+// Classify has a cyclomatic complexity of 5
+func Classify(n int) string {
+	if n < 0 {
+		return "negative"
+	}
+	if n == 0 {
+		return "zero"
+	}
+	if n < 10 {
+		return "small"
+	}
+	if n < 100 {
+		return "medium"
+	}
+	return "large"
+}
+`
+
+// runFailOnAnalysis analyzes the fixture with the given viper settings, writing the report to a
+// temporary file, and returns the runAnalyze error
+func runFailOnAnalysis(t *testing.T, settings map[string]interface{}) error {
+	t.Helper()
+	return runFailOnAnalysisOf(t, failOnFixture, settings)
+}
+
+// runFailOnAnalysisOf is runFailOnAnalysis for a custom gate.go source
+func runFailOnAnalysisOf(t *testing.T, source string, settings map[string]interface{}) error {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gate.go"), []byte(source), 0o644))
+
+	viper.Set("output.format", "json")
+	viper.Set("output.destination", filepath.Join(t.TempDir(), "report.json"))
+	for key, value := range settings {
+		viper.Set(key, value)
+	}
+	return runAnalyze(nil, []string{dir})
+}
+
+func TestRunAnalyze_FailOnComplexityTrips(t *testing.T) {
+	err := runFailOnAnalysis(t, map[string]interface{}{"analysis.fail_on_complexity": 3})
+	require.Error(t, err)
+
+	var violation *thresholdViolationError
+	require.True(t, errors.As(err, &violation), "expected a threshold violation, got %v", err)
+	assert.Equal(t, 1, violation.count)
+	assert.Equal(t, 2, violation.ExitCode(), "threshold violations exit differently from analysis errors")
+}
+
+func TestRunAnalyze_FailOnThresholdsPass(t *testing.T) {
+	err := runFailOnAnalysis(t, map[string]interface{}{
+		"analysis.fail_on_complexity":      10,
+		"analysis.fail_on_function_length": 50,
+		"analysis.fail_on_doc_coverage":    50.0,
+	})
+	assert.NoError(t, err)
+}
+
+func TestRunAnalyze_FailOnFunctionLengthTrips(t *testing.T) {
+	err := runFailOnAnalysis(t, map[string]interface{}{"analysis.fail_on_function_length": 5})
+
+	var violation *thresholdViolationError
+	require.True(t, errors.As(err, &violation), "expected a threshold violation, got %v", err)
+	assert.Equal(t, 1, violation.count)
+}
+
+// complexFunction returns a function named name with a cyclomatic complexity of 30, preceded by
+// the given comment lines
+func complexFunction(name string, comments ...string) string {
+	var b strings.Builder
+	for _, comment := range comments {
+		b.WriteString(comment + "\n")
+	}
+	b.WriteString("func " + name + "(n int) int {\n")
+	for i := 0; i < 29; i++ {
+		b.WriteString("\tif n == " + strconv.Itoa(i) + " {\n\t\treturn n\n\t}\n")
+	}
+	b.WriteString("\treturn 0\n}\n\n")
+	return b.String()
+}
+
+func TestRunAnalyze_FailOnComplexityHonorsSuppressionDirectives(t *testing.T) {
+	ignored := "package gate\n\n" +
+		complexFunction("Dispatch", "// Dispatch is an intentionally flat lookup table", "//nolint:gocyclo // generated from the opcode list") +
+		complexFunction("route", "//stats:ignore")
+	err := runFailOnAnalysisOf(t, ignored, map[string]interface{}{"analysis.fail_on_complexity": 10})
+	assert.NoError(t, err, "suppressed functions do not fail the gate")
+
+	err = runFailOnAnalysisOf(t, ignored+complexFunction("Handle", "// Handle is not suppressed"),
+		map[string]interface{}{"analysis.fail_on_complexity": 10})
+	var violation *thresholdViolationError
+	require.True(t, errors.As(err, &violation), "expected a threshold violation, got %v", err)
+	assert.Equal(t, 1, violation.count)
+}
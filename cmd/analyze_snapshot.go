@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// storeAnalysisSnapshot stores the full report in the configured storage when --snapshot is set.
+// It runs before section filtering so the stored snapshot is complete regardless of --sections.
+// Storing the same content at the same commit again replaces the earlier snapshot.
+func storeAnalysisSnapshot(absPath string, report *metrics.Report, cfg *config.Config) error {
+	if !cfg.Storage.Snapshot {
+		return nil
+	}
+
+	backend, err := initializeStorageBackend()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer backend.Close()
+
+	snapshot := createSnapshot("", absPath, report, cfg.Storage.SnapshotDescription, cfg.Storage.SnapshotTags)
+	snapshot.ID = snapshotID(snapshot.Metadata.GitCommit, report)
+	if err := storeSnapshotWithRetry(backend, snapshot, true); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Stored snapshot %s\n", snapshot.ID)
+	return nil
+}
+
+// snapshotID derives a deterministic snapshot ID from the git commit and the report's content
+// hash, so re-running an unchanged analysis yields the same ID while uncommitted changes at the
+// same commit get their own. Outside git the content hash alone is used, and a timestamp when
+// the report has no content hash.
+func snapshotID(commit string, report *metrics.Report) string {
+	contentHash := report.Metadata.ContentHash
+	switch {
+	case commit != "" && contentHash != "":
+		return fmt.Sprintf("%s-%s", shortHash(commit, 12), shortHash(contentHash, 8))
+	case contentHash != "":
+		return "snapshot-" + shortHash(contentHash, 12)
+	default:
+		return generateBaselineID()
+	}
+}
+
+// shortHash truncates a hex hash to at most n characters
+func shortHash(hash string, n int) string {
+	if len(hash) > n {
+		return hash[:n]
+	}
+	return hash
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSnapshotAnalysis analyzes dir with --snapshot into JSON storage in a temporary directory
+func runSnapshotAnalysis(t *testing.T, dir string) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	viper.Set("storage.type", "json")
+	viper.Set("storage.path", t.TempDir())
+	viper.Set("storage.snapshot", true)
+	viper.Set("storage.snapshot_description", "nightly run")
+	viper.Set("storage.snapshot_tags", []string{"env=ci", "release"})
+	viper.Set("output.format", "json")
+	viper.Set("output.destination", filepath.Join(t.TempDir(), "report.json"))
+	require.NoError(t, runAnalyze(nil, []string{dir}))
+}
+
+// writeSnapshotFixture writes a one-file package to a fresh directory
+func writeSnapshotFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\n// main runs the program\nfunc main() {}\n"), 0o644))
+	return dir
+}
+
+func TestAnalyzeSnapshot_StoresGitMetadata(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := writeSnapshotFixture(t)
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+		{"tag", "v1.0.0"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, out)
+	}
+	commit := getCurrentCommit(dir)
+	require.Len(t, commit, 40)
+
+	runSnapshotAnalysis(t, dir)
+
+	backend, err := initializeStorageBackend()
+	require.NoError(t, err)
+	defer backend.Close()
+	latest, err := backend.GetLatest(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, commit, latest.Metadata.GitCommit)
+	assert.Equal(t, "main", latest.Metadata.GitBranch)
+	assert.Equal(t, "v1.0.0", latest.Metadata.GitTag)
+	assert.Equal(t, "nightly run", latest.Metadata.Description)
+	assert.Equal(t, map[string]string{"env": "ci", "release": "true"}, latest.Metadata.Tags)
+	assert.Equal(t, commit[:12]+"-"+latest.Report.Metadata.ContentHash[:8], latest.ID)
+	assert.NotEmpty(t, latest.Report.Functions)
+}
+
+func TestAnalyzeSnapshot_NonGitDirectory(t *testing.T) {
+	dir := writeSnapshotFixture(t)
+	runSnapshotAnalysis(t, dir)
+
+	backend, err := initializeStorageBackend()
+	require.NoError(t, err)
+	defer backend.Close()
+	latest, err := backend.GetLatest(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, latest.Metadata.GitCommit)
+	assert.Empty(t, latest.Metadata.GitBranch)
+	assert.Empty(t, latest.Metadata.GitTag)
+	assert.Equal(t, "snapshot-"+latest.Report.Metadata.ContentHash[:12], latest.ID)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/opd-ai/go-stats-generator/pkg/generator"
+)
+
+// stdinFilename is the synthetic file name under which source read from stdin is reported
+const stdinFilename = "stdin.go"
+
+// analyzeStdin is set by --stdin to read the Go source to analyze from stdin
+var analyzeStdin bool
+
+// isStdinTarget reports whether the analyze arguments ask for source on stdin, either with
+// --stdin or with - as the path
+func isStdinTarget(args []string) bool {
+	return analyzeStdin || (len(args) > 0 && args[0] == "-")
+}
+
+// commandStdin returns the input of cmd, or os.Stdin when there is no command
+func commandStdin(cmd *cobra.Command) io.Reader {
+	if cmd == nil {
+		return os.Stdin
+	}
+	return cmd.InOrStdin()
+}
+
+// runAnalyzeStdin analyzes the Go source read from stdin as a single file and writes the report.
+// The configuration file is looked up from the working directory.
+func runAnalyzeStdin(stdin io.Reader) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to resolve working directory: %w", err)
+	}
+
+	cfg, err := loadProjectConfiguration(wd)
+	if err != nil {
+		return err
+	}
+
+	if err := validateFilterFlags(cfg); err != nil {
+		return err
+	}
+
+	src, err := io.ReadAll(stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read source from stdin: %w", err)
+	}
+
+	report, err := executeSourceAnalysis(src, cfg)
+	if err != nil {
+		return err
+	}
+
+	return processResults(report, cfg)
+}
+
+// executeSourceAnalysis analyzes src under the configured timeout
+func executeSourceAnalysis(src []byte, cfg *config.Config) (*metrics.Report, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Performance.Timeout)
+	defer cancel()
+
+	report, err := generator.AnalyzeSource(ctx, stdinFilename, src, runtimeConfig(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze source from stdin: %w", err)
+	}
+
+	return report, nil
+}
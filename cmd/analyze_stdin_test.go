@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const stdinFixture = `package piped
+
+func Add(a, b int) int { return a + b }
+
+func Sub(a, b int) int { return a - b }
+
+type Calculator struct{}
+
+func (c Calculator) Mul(a, b int) int { return a * b }
+`
+
+// runStdinAnalysis pipes src into the analyze command with args and returns the JSON report
+func runStdinAnalysis(t *testing.T, src string, args []string) (*metrics.Report, error) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Chdir(t.TempDir())
+
+	output := filepath.Join(t.TempDir(), "report.json")
+	viper.Set("output.format", "json")
+	viper.Set("output.destination", output)
+	viper.Set("output.show_progress", false)
+	analyzeCmd.SetIn(strings.NewReader(src))
+	t.Cleanup(func() { analyzeCmd.SetIn(nil) })
+
+	if err := runAnalyze(analyzeCmd, args); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	var report metrics.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	return &report, nil
+}
+
+func TestRunAnalyze_Stdin(t *testing.T) {
+	report, err := runStdinAnalysis(t, stdinFixture, []string{"-"})
+	require.NoError(t, err)
+
+	assert.Len(t, report.Functions, 3)
+	assert.Equal(t, 1, report.Metadata.FilesProcessed)
+	for _, fn := range report.Functions {
+		assert.Equal(t, "piped", fn.Package, "the package name comes from the parsed source")
+		assert.Equal(t, stdinFilename, fn.File)
+	}
+
+	analyzeStdin = true
+	t.Cleanup(func() { analyzeStdin = false })
+	report, err = runStdinAnalysis(t, stdinFixture, nil)
+	require.NoError(t, err)
+	assert.Len(t, report.Functions, 3, "--stdin behaves like -")
+}
+
+func TestRunAnalyze_StdinParseError(t *testing.T) {
+	_, err := runStdinAnalysis(t, "package broken\n\nfunc {\n", []string{"-"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to analyze source from stdin")
+	assert.Contains(t, err.Error(), "stdin.go:3")
+}
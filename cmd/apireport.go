@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/opd-ai/go-stats-generator/internal/analyzer"
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/opd-ai/go-stats-generator/internal/scanner"
+)
+
+var (
+	apiReportFormat          string
+	apiReportOutputFile      string
+	apiReportCompareFile     string
+	apiReportIncludeInternal bool
+)
+
+// apiReportCmd represents the apireport command
+var apiReportCmd = &cobra.Command{
+	Use:   "apireport [directory]",
+	Short: "List the exported API surface with signatures",
+	Long: `List every exported function, method, type, interface, constant, and variable of a
+module with its declaration signature, for API review and changelog generation.
+
+The listing is sorted by package, kind, and name and omits source positions, bodies, comments,
+and unexported fields, so two listings differ only when the public API changes. Test files,
+package main, and internal and testdata packages are not part of the importable API and are
+skipped.
+
+Compare against a listing saved from a previous version to see added, removed, and changed
+symbols. Removed and changed symbols may break callers and make the command exit non-zero.
+
+Examples:
+  # Save the API of the current release
+  go-stats-generator apireport . --output api-v1.json
+
+  # Review the API as plain text
+  go-stats-generator apireport ./pkg --format text
+
+  # Fail if the working tree breaks the v1 API
+  go-stats-generator apireport . --compare api-v1.json`,
+
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAPIReport,
+}
+
+// init registers the apireport command and its flags with the root command.
+func init() {
+	rootCmd.AddCommand(apiReportCmd)
+
+	apiReportCmd.Flags().StringVarP(&apiReportFormat, "format", "f", "json", "Output format (json, text)")
+	apiReportCmd.Flags().StringVarP(&apiReportOutputFile, "output", "o", "", "Output file (default: stdout)")
+	apiReportCmd.Flags().StringVar(&apiReportCompareFile, "compare", "", "JSON API listing from a previous version to diff against")
+	apiReportCmd.Flags().BoolVar(&apiReportIncludeInternal, "include-internal", false, "Include packages under internal/ directories")
+}
+
+// runAPIReport builds the API surface of the target directory and writes it, or its diff
+// against a previous listing when --compare is given.
+func runAPIReport(cmd *cobra.Command, args []string) error {
+	targetDir := "."
+	if len(args) > 0 {
+		targetDir = args[0]
+	}
+	if apiReportFormat != "json" && apiReportFormat != "text" {
+		return fmt.Errorf("unsupported format %q (use json or text)", apiReportFormat)
+	}
+
+	surface, err := buildAPISurface(targetDir, apiReportIncludeInternal)
+	if err != nil {
+		return err
+	}
+
+	output, err := openOutputFile(apiReportOutputFile)
+	if err != nil {
+		return err
+	}
+	if output != os.Stdout {
+		defer output.Close()
+	}
+
+	if apiReportCompareFile == "" {
+		return writeAPISurface(output, surface, apiReportFormat)
+	}
+
+	baseline, err := loadAPISurface(apiReportCompareFile)
+	if err != nil {
+		return err
+	}
+	diff := metrics.DiffAPISurfaces(baseline, surface)
+	if err := writeAPISurfaceDiff(output, diff, apiReportFormat); err != nil {
+		return err
+	}
+	if diff.HasBreakingChanges() {
+		return fmt.Errorf("API has %d removed and %d changed symbol(s) since %s",
+			len(diff.Removed), len(diff.Changed), apiReportCompareFile)
+	}
+	return nil
+}
+
+// buildAPISurface parses the non-test Go files under targetDir and collects their exported
+// symbols, keyed by import path when the directory belongs to a module
+func buildAPISurface(targetDir string, includeInternal bool) (*metrics.APISurface, error) {
+	filters := config.DefaultConfig().Filters
+	filters.SkipTestFiles = true
+	files, err := scanner.NewDiscoverer(&filters).DiscoverFiles(targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover files: %w", err)
+	}
+
+	module, err := scanner.LoadModuleInfo(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	surface := &metrics.APISurface{Symbols: []metrics.APISymbol{}}
+	if module != nil {
+		surface.Module = module.Path
+	}
+
+	fset := token.NewFileSet()
+	apiAnalyzer := analyzer.NewAPIAnalyzer()
+	for _, file := range files {
+		pkgPath := apiPackagePath(module, targetDir, file)
+		if hasPathElement(pkgPath, "testdata") || (!includeInternal && hasPathElement(pkgPath, "internal")) {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file.Path, file.Src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file.Path, err)
+		}
+		surface.Symbols = append(surface.Symbols, apiAnalyzer.ExtractAPI(parsed, pkgPath)...)
+	}
+
+	metrics.SortAPISymbols(surface.Symbols)
+	return surface, nil
+}
+
+// apiPackagePath returns the import path of a file's package. Without a module, the path is
+// the package directory relative to the analyzed directory.
+func apiPackagePath(module *metrics.ModuleInfo, targetDir string, file scanner.FileInfo) string {
+	dir := filepath.Dir(file.Path)
+	if module == nil || module.GoModPath == "" {
+		rel, err := filepath.Rel(targetDir, dir)
+		if err != nil {
+			return filepath.ToSlash(filepath.Dir(file.RelPath))
+		}
+		return filepath.ToSlash(rel)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return module.Path
+	}
+	rel, err := filepath.Rel(filepath.Dir(module.GoModPath), absDir)
+	if err != nil || rel == "." {
+		return module.Path
+	}
+	return path.Join(module.Path, filepath.ToSlash(rel))
+}
+
+// hasPathElement reports whether an import path contains the given element. Packages under
+// testdata cannot be imported at all, and packages under internal only from their parent tree.
+func hasPathElement(pkgPath, name string) bool {
+	for _, element := range strings.Split(pkgPath, "/") {
+		if element == name {
+			return true
+		}
+	}
+	return false
+}
+
+// loadAPISurface reads an API listing previously written by apireport in JSON format
+func loadAPISurface(filename string) (*metrics.APISurface, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var surface metrics.APISurface
+	if err := json.Unmarshal(data, &surface); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON in %s: %w", filename, err)
+	}
+	return &surface, nil
+}
+
+// writeAPISurface writes the API listing as indented JSON or as signatures grouped by package
+func writeAPISurface(w io.Writer, surface *metrics.APISurface, format string) error {
+	if format == "json" {
+		return writeIndentedJSON(w, surface)
+	}
+
+	currentPackage := ""
+	for _, symbol := range surface.Symbols {
+		if symbol.Package != currentPackage {
+			if currentPackage != "" {
+				fmt.Fprintln(w)
+			}
+			currentPackage = symbol.Package
+			fmt.Fprintf(w, "package %s\n\n", currentPackage)
+		}
+		fmt.Fprintln(w, symbol.Signature)
+	}
+	return nil
+}
+
+// writeAPISurfaceDiff writes the API diff as indented JSON or as +/-/~ prefixed signatures
+func writeAPISurfaceDiff(w io.Writer, diff metrics.APISurfaceDiff, format string) error {
+	if format == "json" {
+		return writeIndentedJSON(w, diff)
+	}
+
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) == 0 {
+		fmt.Fprintln(w, "No API changes")
+		return nil
+	}
+	for _, symbol := range diff.Removed {
+		fmt.Fprintf(w, "- %s: %s\n", symbol.Package, symbol.Signature)
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(w, "~ %s: %s\n    was: %s\n", change.New.Package, change.New.Signature, change.Old.Signature)
+	}
+	for _, symbol := range diff.Added {
+		fmt.Fprintf(w, "+ %s: %s\n", symbol.Package, symbol.Signature)
+	}
+	return nil
+}
+
+// writeIndentedJSON encodes v as two-space indented JSON
+func writeIndentedJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode API report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+func writeAPIModule(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestBuildAPISurface(t *testing.T) {
+	dir := t.TempDir()
+	writeAPIModule(t, dir, map[string]string{
+		"go.mod": "module example.com/lib\n\ngo 1.24\n",
+		"store/store.go": `package store
+
+import "context"
+
+// Store keeps records
+type Store struct {
+	Name  string
+	cache map[string]string
+}
+
+type Option func(*Store)
+
+const (
+	ModeRead Mode = iota
+	ModeWrite
+	modeHidden
+)
+
+type Mode int
+
+func Open(ctx context.Context, name string, opts ...Option) (*Store, error) {
+	return &Store{Name: name}, nil
+}
+
+func (s *Store) Get(key string) (string, bool) {
+	v, ok := s.cache[key]
+	return v, ok
+}
+
+func (s *Store) evict(key string) {}
+
+func helper() {}
+`,
+		"store/store_test.go":   "package store\n\nfunc TestHelper() {}\n",
+		"internal/impl/impl.go": "package impl\n\nfunc Exported() {}\n",
+		"cmd/tool/main.go":      "package main\n\nfunc Run() {}\n\nfunc main() {}\n",
+	})
+
+	surface, err := buildAPISurface(dir, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com/lib", surface.Module)
+	signatures := make(map[string]string)
+	for _, symbol := range surface.Symbols {
+		assert.Equal(t, "example.com/lib/store", symbol.Package, "only the public store package is listed")
+		signatures[symbol.Name] = symbol.Signature
+	}
+	assert.Equal(t, map[string]string{
+		"Store":     "type Store struct{ Name string }",
+		"Option":    "type Option func(*Store)",
+		"Mode":      "type Mode int",
+		"ModeRead":  "const ModeRead Mode = iota",
+		"ModeWrite": "const ModeWrite Mode = iota",
+		"Open":      "func Open(ctx context.Context, name string, opts ...Option) (*Store, error)",
+		"Store.Get": "func (*Store) Get(key string) (string, bool)",
+	}, signatures)
+
+	var names []string
+	for _, symbol := range surface.Symbols {
+		names = append(names, string(symbol.Kind)+" "+symbol.Name)
+	}
+	assert.Equal(t, []string{
+		"const ModeRead", "const ModeWrite", "func Open", "method Store.Get",
+		"type Mode", "type Option", "type Store",
+	}, names, "symbols are sorted by package, kind, and name")
+
+	withInternal, err := buildAPISurface(dir, true)
+	require.NoError(t, err)
+	assert.Len(t, withInternal.Symbols, len(surface.Symbols)+1, "--include-internal adds internal/impl.Exported")
+}
+
+func TestBuildAPISurface_DiffAcrossVersions(t *testing.T) {
+	dir := t.TempDir()
+	writeAPIModule(t, dir, map[string]string{
+		"go.mod":    "module example.com/calc\n\ngo 1.24\n",
+		"calc.go":   "package calc\n\nfunc Add(a, b int) int { return a + b }\n\nfunc Sub(a, b int) int { return a - b }\n",
+		"scale.go":  "package calc\n\nfunc Scale(v, f int) int { return v * f }\n",
+		"format.go": "package calc\n\n// Format renders v\nfunc Format(v int) string { return \"\" }\n",
+	})
+	v1, err := buildAPISurface(dir, false)
+	require.NoError(t, err)
+
+	writeAPIModule(t, dir, map[string]string{
+		"calc.go":   "package calc\n\nfunc Add(a, b int) int { return a + b }\n\nfunc Mul(a, b int) int { return a * b }\n",
+		"scale.go":  "package calc\n\nfunc Scale(v, f float64) float64 { return v * f }\n",
+		"format.go": "package calc\n\n// Format renders v in base 10\nfunc Format(v int) string {\n\treturn \"\"\n}\n",
+	})
+	v2, err := buildAPISurface(dir, false)
+	require.NoError(t, err)
+
+	diff := metrics.DiffAPISurfaces(v1, v2)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "func Mul(a, b int) int", diff.Added[0].Signature)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "func Sub(a, b int) int", diff.Removed[0].Signature)
+	require.Len(t, diff.Changed, 1, "comment and body edits to Format are not API changes")
+	assert.Equal(t, "Scale", diff.Changed[0].New.Name)
+	assert.True(t, diff.HasBreakingChanges())
+}
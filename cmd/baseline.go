@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -113,8 +115,8 @@ func runCreateBaseline(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create and store snapshot
-	snapshot := createSnapshot(baselineID, report)
-	if err := storeSnapshotWithRetry(storageBackend, snapshot); err != nil {
+	snapshot := createSnapshot(baselineID, targetPath, report, baselineMessage, tags)
+	if err := storeSnapshotWithRetry(storageBackend, snapshot, overwriteFlag); err != nil {
 		return err
 	}
 
@@ -141,9 +143,10 @@ func initializeStorageBackend() (storage.MetricsStorage, error) {
 	compression := getCompressionSetting()
 
 	storageConfig := storage.Config{
-		Type:   storageType,
-		SQLite: buildSQLiteConfig(storagePath, compression),
-		JSON:   buildJSONConfig(storagePath, compression),
+		Type:     storageType,
+		SQLite:   buildSQLiteConfig(storagePath, compression),
+		Postgres: buildPostgresConfig(compression),
+		JSON:     buildJSONConfig(storagePath, compression),
 	}
 	return storage.NewStorage(storageConfig)
 }
@@ -182,6 +185,14 @@ func getCompressionSetting() bool {
 func buildSQLiteConfig(path string, compression bool) storage.SQLiteConfig {
 	return storage.SQLiteConfig{
 		Path: path, EnableWAL: true, MaxConnections: 10, EnableFK: true, EnableCompression: compression,
+		EnableDeltas: viper.GetBool("storage.deltas"), FullSnapshotInterval: viper.GetInt("storage.full_snapshot_interval"),
+	}
+}
+
+// buildPostgresConfig creates PostgreSQL configuration from settings
+func buildPostgresConfig(compression bool) storage.PostgresConfig {
+	return storage.PostgresConfig{
+		ConnectionString: viper.GetString("storage.postgres_connection_string"), MaxConnections: 10, EnableCompression: compression,
 	}
 }
 
@@ -200,10 +211,18 @@ func convertToStorageConfig(cfg config.StorageConfig) storage.Config {
 
 	// Configure SQLite settings based on configuration
 	storageConfig.SQLite = storage.SQLiteConfig{
-		Path:              cfg.Path,
-		EnableWAL:         true, // Sensible default for performance
-		MaxConnections:    10,   // Sensible default for CLI tool
-		EnableFK:          true, // Sensible default for data integrity
+		Path:                 cfg.Path,
+		EnableWAL:            true, // Sensible default for performance
+		MaxConnections:       10,   // Sensible default for CLI tool
+		EnableFK:             true, // Sensible default for data integrity
+		EnableCompression:    cfg.Compression,
+		EnableDeltas:         cfg.Deltas,
+		FullSnapshotInterval: cfg.FullSnapshotInterval,
+	}
+
+	storageConfig.Postgres = storage.PostgresConfig{
+		ConnectionString:  cfg.PostgresConnectionString,
+		MaxConnections:    10,
 		EnableCompression: cfg.Compression,
 	}
 
@@ -217,14 +236,17 @@ func convertToStorageConfig(cfg config.StorageConfig) storage.Config {
 	return storageConfig
 }
 
-// createSnapshot builds a snapshot with metadata from the analysis report
-func createSnapshot(baselineID string, report *metrics.Report) metrics.Snapshot {
+// createSnapshot builds a snapshot of the analysis report for targetPath, with git metadata read
+// from the repository containing it
+func createSnapshot(baselineID, targetPath string, report *metrics.Report, description string, tagList []string) metrics.Snapshot {
 	metadata := metrics.SnapshotMetadata{
 		Timestamp:   time.Now(),
-		GitBranch:   getCurrentBranch(),
-		GitCommit:   getCurrentCommit(),
-		Description: baselineMessage,
-		Tags:        convertToTagMap(tags),
+		GitBranch:   getCurrentBranch(targetPath),
+		GitCommit:   getCurrentCommit(targetPath),
+		GitTag:      getCurrentTag(targetPath),
+		Version:     report.Metadata.ToolVersion,
+		Description: description,
+		Tags:        convertToTagMap(tagList),
 	}
 
 	return metrics.Snapshot{
@@ -235,14 +257,14 @@ func createSnapshot(baselineID string, report *metrics.Report) metrics.Snapshot
 }
 
 // storeSnapshotWithRetry stores the snapshot, retrying with overwrite if necessary
-func storeSnapshotWithRetry(storageBackend storage.MetricsStorage, snapshot metrics.Snapshot) error {
+func storeSnapshotWithRetry(storageBackend storage.MetricsStorage, snapshot metrics.Snapshot, overwrite bool) error {
 	ctx := context.Background()
 	err := storageBackend.Store(ctx, snapshot, snapshot.Metadata)
 	if err == nil {
 		return nil
 	}
 
-	if !overwriteFlag {
+	if !overwrite {
 		return fmt.Errorf("failed to store baseline snapshot: %w", err)
 	}
 
@@ -493,7 +515,7 @@ func analyzeCodebase(targetPath string) (*metrics.Report, error) {
 	cfg.Output.Verbose = false
 
 	// Use the full analysis workflow to ensure all metrics are populated
-	report, err := runAnalysisWorkflow(context.Background(), targetPath, cfg)
+	report, err := runAnalysis(context.Background(), targetPath, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze project: %w", err)
 	}
@@ -507,18 +529,41 @@ func generateBaselineID() string {
 	return fmt.Sprintf("baseline-%s", timestamp)
 }
 
-// getCurrentBranch attempts to retrieve the current git branch name.
-func getCurrentBranch() string {
-	// Try to get current git branch
-	// This is a placeholder - in real implementation you'd use git commands
-	return ""
+// getCurrentBranch returns the git branch checked out in dir, or an empty string when dir is not
+// in a git repository or HEAD is detached.
+func getCurrentBranch(dir string) string {
+	branch := gitOutput(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if branch == "HEAD" {
+		return ""
+	}
+	return branch
+}
+
+// getCurrentCommit returns the git commit hash of HEAD in dir, or an empty string when dir is not
+// in a git repository or has no commits.
+func getCurrentCommit(dir string) string {
+	return gitOutput(dir, "rev-parse", "HEAD")
 }
 
-// getCurrentCommit attempts to retrieve the current git commit hash.
-func getCurrentCommit() string {
-	// Try to get current git commit hash
-	// This is a placeholder - in real implementation you'd use git commands
-	return ""
+// getCurrentTag returns the git tag pointing exactly at HEAD in dir, or an empty string when
+// there is none.
+func getCurrentTag(dir string) string {
+	return gitOutput(dir, "describe", "--tags", "--exact-match", "HEAD")
+}
+
+// gitOutput runs git in dir and returns its trimmed output, or an empty string if git is not
+// installed or the command fails, so snapshots of non-git directories simply lack git metadata.
+func gitOutput(dir string, args ...string) string {
+	info, err := os.Stat(dir)
+	if err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
 }
 
 // convertToTagMap converts a slice of key=value tag strings to a map.
@@ -209,13 +209,13 @@ func TestGenerateBaselineID(t *testing.T) {
 }
 
 func TestGetCurrentBranch(t *testing.T) {
-	branch := getCurrentBranch()
+	branch := getCurrentBranch(".")
 	// Should return a string (empty if not in git repo, or branch name)
 	assert.NotNil(t, branch)
 }
 
 func TestGetCurrentCommit(t *testing.T) {
-	commit := getCurrentCommit()
+	commit := getCurrentCommit(".")
 	// Should return a string (empty if not in git repo, or commit hash)
 	assert.NotNil(t, commit)
 }
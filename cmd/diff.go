@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -16,16 +17,28 @@ var (
 	diffOutputFile   string
 	showOnlyChanges  bool
 	thresholdPercent float64
+	diffEpsilon      float64
+	baselineStdin    bool
+	diffBaseline     string
+	diffFailOnError  bool
+	diffFailOnCrit   bool
 )
 
 // diffCmd represents the diff command
 var diffCmd = &cobra.Command{
-	Use:   "diff [baseline-report] [comparison-report]",
-	Short: "Compare two complexity analysis reports",
+	Use:   "diff [baseline] [comparison] | --baseline <selector> [comparison] | --baseline-stdin [directory]",
+	Short: "Compare two complexity analysis reports or stored snapshots",
 	Long: `Compare two complexity analysis reports to determine if complexity was increased or reduced.
 
-The diff command takes two JSON report files generated by the analyze command and produces
-a detailed comparison showing:
+The diff command compares a baseline with a comparison. Each may be a JSON report file
+generated by the analyze command, a directory to analyze afresh, or a snapshot from the
+configured storage (see the baseline command) selected by:
+
+  latest                 the most recent snapshot
+  tag:<key>[=<value>]    the newest snapshot with that tag (value defaults to "true")
+  <snapshot-id>          the snapshot with that ID
+
+It produces a detailed comparison showing:
 
   • Overall complexity changes (increased/decreased/unchanged)
   • Function-level complexity deltas with percentage changes
@@ -48,9 +61,22 @@ Examples:
   go-stats-generator diff baseline.json current.json --threshold 10 --changes-only
 
   # Generate detailed HTML diff report
-  go-stats-generator diff baseline.json current.json --format html --output diff-report.html`,
+  go-stats-generator diff baseline.json current.json --format html --output diff-report.html
+
+  # Emit an RFC 6902 JSON Patch transforming the baseline report into the current one
+  go-stats-generator diff baseline.json current.json --format jsonpatch
+
+  # Read the baseline report from stdin and compare it against a fresh analysis of a directory
+  git show main:report.json | go-stats-generator diff --baseline-stdin .
+
+  # Compare two stored snapshots
+  go-stats-generator diff v1.0-baseline v1.1-baseline
 
-	Args: cobra.ExactArgs(2),
+  # Compare the working tree against the newest snapshot tagged release, failing CI
+  # with exit code 2 on regressions of violation severity or worse
+  go-stats-generator diff --baseline tag:release . --fail-on-error`,
+
+	Args: validateDiffArgs,
 	RunE: runDiff,
 }
 
@@ -58,62 +84,110 @@ Examples:
 func init() {
 	rootCmd.AddCommand(diffCmd)
 
-	diffCmd.Flags().StringVarP(&diffOutputFormat, "format", "f", "console", "Output format (console, json, html)")
+	diffCmd.Flags().StringVarP(&diffOutputFormat, "format", "f", "console", "Output format (console, json, html, markdown, jsonpatch)")
 	diffCmd.Flags().StringVarP(&diffOutputFile, "output", "o", "", "Output file (default: stdout)")
 	diffCmd.Flags().BoolVar(&showOnlyChanges, "changes-only", false, "Show only items with changes above threshold")
 	diffCmd.Flags().Float64Var(&thresholdPercent, "threshold", 5.0, "Threshold percentage for significant changes")
+	diffCmd.Flags().Float64Var(&diffEpsilon, "epsilon", metrics.DefaultFloatEpsilon, "Largest float metric difference treated as no change")
+	diffCmd.Flags().BoolVar(&baselineStdin, "baseline-stdin", false, "Read the baseline JSON report from stdin and compare it against an analysis of the directory argument")
+	diffCmd.Flags().StringVar(&diffBaseline, "baseline", "", "Baseline report, directory or stored snapshot (latest, tag:<key>[=<value>] or an ID); the only argument is then the comparison")
+	diffCmd.Flags().BoolVar(&diffFailOnError, "fail-on-error", false, "Exit with code 2 if any regression has violation or critical severity")
+	diffCmd.Flags().BoolVar(&diffFailOnCrit, "fail-on-critical", false, "Exit with code 2 if any regression has critical severity")
+	diffCmd.MarkFlagsMutuallyExclusive("baseline", "baseline-stdin")
 }
 
-// runDiff loads baseline and comparison reports from JSON files, creates snapshots,
-// performs differential analysis, applies change threshold filtering if requested,
-// and outputs the diff results in the specified format (console/JSON/CSV/Markdown).
+// validateDiffArgs requires a single argument with --baseline-stdin or --baseline and a baseline
+// and comparison otherwise.
+func validateDiffArgs(cmd *cobra.Command, args []string) error {
+	if baselineStdin || diffBaseline != "" {
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+	return cobra.ExactArgs(2)(cmd, args)
+}
+
+// runDiff loads the baseline and comparison from report files, directories or stored snapshots,
+// performs differential analysis, applies change threshold filtering if requested, outputs the
+// diff results in the specified format (console/JSON/CSV/Markdown), and fails when
+// --fail-on-error or --fail-on-critical gating is tripped.
 func runDiff(cmd *cobra.Command, args []string) error {
-	baseline, comparison, err := loadBothReports(args[0], args[1])
+	var baseline, comparison metrics.Snapshot
+	var err error
+	switch {
+	case baselineStdin:
+		var baselineReport, comparisonReport *metrics.Report
+		baselineReport, comparisonReport, err = loadStdinBaselineAndAnalyze(cmd.InOrStdin(), args[0])
+		if err == nil {
+			baseline, comparison = reportSnapshot("baseline", baselineReport), reportSnapshot("current", comparisonReport)
+		}
+	case diffBaseline != "":
+		baseline, comparison, err = loadBothSnapshots(diffBaseline, args[0])
+	default:
+		baseline, comparison, err = loadBothSnapshots(args[0], args[1])
+	}
 	if err != nil {
 		return err
 	}
 
-	diffReport, err := generateDiffReport(baseline, comparison)
+	diffReport, err := compareDiffSnapshots(baseline, comparison)
 	if err != nil {
 		return err
 	}
 
-	return writeDiffOutput(diffReport)
+	if err := writeDiffOutput(diffReport); err != nil {
+		return err
+	}
+
+	return checkDiffGates(diffReport)
 }
 
-// loadBothReports loads baseline and comparison reports.
-func loadBothReports(baselineFile, comparisonFile string) (*metrics.Report, *metrics.Report, error) {
-	baseline, err := loadReport(baselineFile)
+// loadBothSnapshots resolves the baseline and comparison arguments to snapshots.
+func loadBothSnapshots(baselineArg, comparisonArg string) (metrics.Snapshot, metrics.Snapshot, error) {
+	sources := &diffSources{}
+	defer sources.close()
+
+	baseline, err := sources.load(baselineArg, "baseline")
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load baseline report: %w", err)
+		return metrics.Snapshot{}, metrics.Snapshot{}, fmt.Errorf("failed to load baseline report: %w", err)
 	}
 
-	comparison, err := loadReport(comparisonFile)
+	comparison, err := sources.load(comparisonArg, "current")
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load comparison report: %w", err)
+		return metrics.Snapshot{}, metrics.Snapshot{}, fmt.Errorf("failed to load comparison report: %w", err)
 	}
 
 	return baseline, comparison, nil
 }
 
-// generateDiffReport creates snapshots and generates diff.
-func generateDiffReport(baseline, comparison *metrics.Report) (*metrics.ComplexityDiff, error) {
-	baselineSnapshot := metrics.Snapshot{
-		ID:       "baseline",
-		Report:   *baseline,
-		Metadata: metrics.SnapshotMetadata{Timestamp: baseline.Metadata.GeneratedAt},
+// loadStdinBaselineAndAnalyze decodes the baseline report from stdin and analyzes targetDir
+// to produce the comparison report.
+func loadStdinBaselineAndAnalyze(stdin io.Reader, targetDir string) (*metrics.Report, *metrics.Report, error) {
+	baseline, err := decodeReport(stdin, "stdin")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load baseline report: %w", err)
 	}
 
-	comparisonSnapshot := metrics.Snapshot{
-		ID:       "current",
-		Report:   *comparison,
-		Metadata: metrics.SnapshotMetadata{Timestamp: comparison.Metadata.GeneratedAt},
+	comparison, err := analyzeCodebase(targetDir)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	return baseline, comparison, nil
+}
+
+// generateDiffReport creates snapshots and generates diff.
+func generateDiffReport(baseline, comparison *metrics.Report) (*metrics.ComplexityDiff, error) {
+	return compareDiffSnapshots(reportSnapshot("baseline", baseline), reportSnapshot("current", comparison))
+}
+
+// compareDiffSnapshots compares two snapshots using the thresholds and gating set by the flags.
+func compareDiffSnapshots(baseline, comparison metrics.Snapshot) (*metrics.ComplexityDiff, error) {
 	config := metrics.DefaultThresholdConfig()
 	config.Global.SignificanceLevel = thresholdPercent
+	config.Global.Epsilon = diffEpsilon
+	config.Global.FailOnError = diffFailOnError
+	config.Global.FailOnCritical = diffFailOnCrit
 
-	diffReport, err := metrics.CompareSnapshots(baselineSnapshot, comparisonSnapshot, config)
+	diffReport, err := metrics.CompareSnapshots(baseline, comparison, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate diff: %w", err)
 	}
@@ -121,6 +195,21 @@ func generateDiffReport(baseline, comparison *metrics.Report) (*metrics.Complexi
 	return diffReport, nil
 }
 
+// checkDiffGates prints the regressions that fail the diff's gating to stderr and returns a
+// thresholdViolationError when there are any.
+func checkDiffGates(diffReport *metrics.ComplexityDiff) error {
+	failures := diffReport.GateFailures()
+	if len(failures) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n=== REGRESSION GATE FAILURES ===\n")
+	for _, regression := range failures {
+		fmt.Fprintf(os.Stderr, "❌ [%s] %s: %s\n", regression.Severity, regression.Location, regression.Description)
+	}
+	return &thresholdViolationError{count: len(failures)}
+}
+
 // writeDiffOutput creates reporter and writes diff to output.
 func writeDiffOutput(diffReport *metrics.ComplexityDiff) error {
 	rep, err := reporter.NewReporter(diffOutputFormat)
@@ -155,14 +244,25 @@ func openOutputFile(filename string) (*os.File, error) {
 
 // loadReport reads and parses a metrics report from a JSON file.
 func loadReport(filename string) (*metrics.Report, error) {
-	data, err := os.ReadFile(filename)
+	file, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
 	}
+	defer file.Close()
+
+	return decodeReport(file, filename)
+}
+
+// decodeReport parses a metrics report from JSON read from r; source names the input in errors.
+func decodeReport(r io.Reader, source string) (*metrics.Report, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
 
 	var report metrics.Report
 	if err := json.Unmarshal(data, &report); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON in %s: %w", filename, err)
+		return nil, fmt.Errorf("failed to parse JSON in %s: %w", source, err)
 	}
 
 	return &report, nil
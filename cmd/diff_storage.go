@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/opd-ai/go-stats-generator/internal/storage"
+)
+
+// diffSources resolves diff arguments to snapshots, opening the configured storage backend only
+// when an argument refers to a stored snapshot
+type diffSources struct {
+	backend storage.MetricsStorage
+}
+
+// load resolves arg to a snapshot. Existing files and paths ending in .json are read as report
+// files and directories are analyzed afresh; both get role as their snapshot ID. Anything else is
+// a stored snapshot selector: "latest", "tag:<key>[=<value>]" or a snapshot ID.
+func (s *diffSources) load(arg, role string) (metrics.Snapshot, error) {
+	info, statErr := os.Stat(arg)
+	switch {
+	case statErr == nil && info.IsDir():
+		report, err := analyzeCodebase(arg)
+		if err != nil {
+			return metrics.Snapshot{}, err
+		}
+		return reportSnapshot(role, report), nil
+	case statErr == nil || strings.HasSuffix(arg, ".json"):
+		report, err := loadReport(arg)
+		if err != nil {
+			return metrics.Snapshot{}, err
+		}
+		return reportSnapshot(role, report), nil
+	}
+
+	if s.backend == nil {
+		backend, err := initializeStorageBackend()
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("failed to initialize storage: %w", err)
+		}
+		s.backend = backend
+	}
+	return retrieveSelectedSnapshot(context.Background(), s.backend, arg)
+}
+
+// close releases the storage backend if one was opened
+func (s *diffSources) close() {
+	if s.backend != nil {
+		s.backend.Close()
+	}
+}
+
+// retrieveSelectedSnapshot returns the stored snapshot named by selector. A tag selector uses the
+// same key=value syntax as baseline create --tags and picks the newest matching snapshot.
+func retrieveSelectedSnapshot(ctx context.Context, backend storage.MetricsStorage, selector string) (metrics.Snapshot, error) {
+	if selector == "latest" {
+		snapshot, err := backend.GetLatest(ctx)
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("failed to retrieve latest snapshot: %w", err)
+		}
+		return snapshot, nil
+	}
+
+	tagSpec, isTag := strings.CutPrefix(selector, "tag:")
+	if !isTag {
+		snapshot, err := backend.Retrieve(ctx, selector)
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("failed to retrieve snapshot %s: %w", selector, err)
+		}
+		return snapshot, nil
+	}
+
+	for key, value := range convertToTagMap([]string{tagSpec}) {
+		snapshots, err := backend.GetByTag(ctx, key, value)
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("failed to retrieve snapshots tagged %s: %w", tagSpec, err)
+		}
+		if len(snapshots) == 0 {
+			return metrics.Snapshot{}, fmt.Errorf("no snapshot tagged %s", tagSpec)
+		}
+		newest := snapshots[0]
+		for _, snapshot := range snapshots[1:] {
+			if snapshot.Metadata.Timestamp.After(newest.Metadata.Timestamp) {
+				newest = snapshot
+			}
+		}
+		return newest, nil
+	}
+	return metrics.Snapshot{}, fmt.Errorf("invalid tag selector %q", selector)
+}
+
+// reportSnapshot wraps a report that was not loaded from storage in a snapshot with the given ID
+func reportSnapshot(id string, report *metrics.Report) metrics.Snapshot {
+	return metrics.Snapshot{
+		ID:       id,
+		Report:   *report,
+		Metadata: metrics.SnapshotMetadata{Timestamp: report.Metadata.GeneratedAt},
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// storeDiffSnapshots configures JSON storage in a temporary directory and stores a baseline
+// snapshot tagged release followed by a snapshot in which TestFunc became far more complex
+func storeDiffSnapshots(t *testing.T) {
+	t.Helper()
+	viper.Reset()
+	viper.Set("storage.type", "json")
+	viper.Set("storage.path", t.TempDir())
+	t.Cleanup(func() {
+		viper.Reset()
+		diffBaseline, diffOutputFormat, diffOutputFile = "", "console", ""
+		diffFailOnError, diffFailOnCrit = false, false
+		for _, name := range []string{"baseline", "fail-on-error", "fail-on-critical"} {
+			diffCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+
+	backend, err := initializeStorageBackend()
+	require.NoError(t, err)
+	defer backend.Close()
+
+	baseline := createTestReport("repo", "v1.0.0", "abc123")
+	current := createTestReport("repo", "v1.1.0", "def456")
+	current.Functions[0].Complexity.Cyclomatic = 30
+
+	now := time.Now()
+	for _, snapshot := range []metrics.Snapshot{
+		{ID: "v1", Report: *baseline, Metadata: metrics.SnapshotMetadata{Timestamp: now.Add(-time.Hour), Tags: map[string]string{"release": "true"}}},
+		{ID: "v2", Report: *current, Metadata: metrics.SnapshotMetadata{Timestamp: now}},
+	} {
+		require.NoError(t, backend.Store(context.Background(), snapshot, snapshot.Metadata))
+	}
+}
+
+// runStoredDiff runs the diff command with JSON output and returns the decoded diff and error
+func runStoredDiff(t *testing.T, args ...string) (*metrics.ComplexityDiff, error) {
+	t.Helper()
+	outputFile := filepath.Join(t.TempDir(), "diff.json")
+	rootCmd.SetArgs(append(append([]string{"diff"}, args...), "--format", "json", "--output", outputFile))
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	runErr := rootCmd.Execute()
+
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err, "diff output should be written: %v", runErr)
+	var diff metrics.ComplexityDiff
+	require.NoError(t, json.Unmarshal(data, &diff))
+	return &diff, runErr
+}
+
+func TestDiffCommand_StoredSnapshots(t *testing.T) {
+	storeDiffSnapshots(t)
+
+	diff, err := runStoredDiff(t, "v1", "v2")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", diff.Baseline.ID)
+	assert.Equal(t, "v2", diff.Current.ID)
+	assert.Equal(t, 1, diff.Summary.RegressionCount)
+	require.Len(t, diff.Regressions, 1)
+	assert.Equal(t, "TestFunc", diff.Regressions[0].Function)
+}
+
+func TestDiffCommand_BaselineSelectors(t *testing.T) {
+	storeDiffSnapshots(t)
+
+	diff, err := runStoredDiff(t, "--baseline", "tag:release", "latest")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", diff.Baseline.ID)
+	assert.Equal(t, "v2", diff.Current.ID)
+	assert.Equal(t, 1, diff.Summary.RegressionCount)
+}
+
+func TestDiffCommand_FailOnCritical(t *testing.T) {
+	storeDiffSnapshots(t)
+
+	diff, err := runStoredDiff(t, "v1", "v2", "--fail-on-critical")
+	require.Error(t, err)
+
+	var violation *thresholdViolationError
+	require.True(t, errors.As(err, &violation), "expected a threshold violation, got %v", err)
+	assert.Equal(t, diff.Summary.CriticalIssues, violation.count)
+	assert.Equal(t, 2, violation.ExitCode())
+}
+
+func TestRetrieveSelectedSnapshot_UnknownTag(t *testing.T) {
+	storeDiffSnapshots(t)
+
+	backend, err := initializeStorageBackend()
+	require.NoError(t, err)
+	defer backend.Close()
+
+	_, err = retrieveSelectedSnapshot(context.Background(), backend, "tag:nightly")
+	assert.ErrorContains(t, err, "no snapshot tagged nightly")
+}
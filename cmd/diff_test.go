@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -125,7 +126,7 @@ func TestLoadReport(t *testing.T) {
 	})
 }
 
-func TestLoadBothReports(t *testing.T) {
+func TestLoadBothSnapshots(t *testing.T) {
 	tempDir := t.TempDir()
 
 	baselineReport := createTestReport("baseline", "v1.0.0", "abc123")
@@ -138,22 +139,22 @@ func TestLoadBothReports(t *testing.T) {
 	require.NoError(t, writeReportToFile(comparisonReport, comparisonFile))
 
 	t.Run("both valid", func(t *testing.T) {
-		baseline, comparison, err := loadBothReports(baselineFile, comparisonFile)
+		baseline, comparison, err := loadBothSnapshots(baselineFile, comparisonFile)
 		require.NoError(t, err)
-		assert.NotNil(t, baseline)
-		assert.NotNil(t, comparison)
-		assert.Equal(t, "baseline", baseline.Metadata.Repository)
-		assert.Equal(t, "comparison", comparison.Metadata.Repository)
+		assert.Equal(t, "baseline", baseline.ID)
+		assert.Equal(t, "current", comparison.ID)
+		assert.Equal(t, "baseline", baseline.Report.Metadata.Repository)
+		assert.Equal(t, "comparison", comparison.Report.Metadata.Repository)
 	})
 
 	t.Run("invalid baseline", func(t *testing.T) {
-		_, _, err := loadBothReports("nonexistent.json", comparisonFile)
+		_, _, err := loadBothSnapshots("nonexistent.json", comparisonFile)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load baseline report")
 	})
 
 	t.Run("invalid comparison", func(t *testing.T) {
-		_, _, err := loadBothReports(baselineFile, "nonexistent.json")
+		_, _, err := loadBothSnapshots(baselineFile, "nonexistent.json")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load comparison report")
 	})
@@ -323,3 +324,64 @@ func TestRunDiff(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestRunDiff_BaselineStdin(t *testing.T) {
+	testDir := filepath.Join("..", "testdata", "simple")
+
+	baselineReport, err := analyzeCodebase(testDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, baselineReport.Functions)
+
+	// Make the baseline simpler than the tree so the fresh analysis shows a regression
+	target := baselineReport.Functions[0]
+	baselineReport.Functions[0].Complexity.Cyclomatic = 1
+	baselineReport.Functions[0].Complexity.Overall = target.Complexity.Overall / 2
+	data, err := json.Marshal(baselineReport)
+	require.NoError(t, err)
+
+	outputFile := filepath.Join(t.TempDir(), "diff.json")
+	diffOutputFormat = "json"
+	diffOutputFile = outputFile
+	baselineStdin = true
+	diffCmd.SetIn(bytes.NewReader(data))
+	defer func() {
+		diffOutputFormat = "console"
+		diffOutputFile = ""
+		baselineStdin = false
+		diffCmd.SetIn(nil)
+	}()
+
+	require.NoError(t, validateDiffArgs(diffCmd, []string{testDir}))
+	assert.Error(t, validateDiffArgs(diffCmd, []string{"baseline.json", testDir}),
+		"--baseline-stdin takes only the directory argument")
+
+	require.NoError(t, runDiff(diffCmd, []string{testDir}))
+
+	output, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	var diff metrics.ComplexityDiff
+	require.NoError(t, json.Unmarshal(output, &diff))
+
+	found := false
+	for _, change := range diff.Changes {
+		if change.Name == target.Name {
+			found = true
+		}
+	}
+	assert.True(t, found, "Expected a change for %s, got %+v", target.Name, diff.Changes)
+	assert.Equal(t, len(baselineReport.Functions), len(diff.Current.Report.Functions),
+		"Current snapshot should come from analyzing the directory")
+}
+
+func TestRunDiff_BaselineStdinInvalidJSON(t *testing.T) {
+	baselineStdin = true
+	diffCmd.SetIn(strings.NewReader("not valid json"))
+	defer func() {
+		baselineStdin = false
+		diffCmd.SetIn(nil)
+	}()
+
+	err := runDiff(diffCmd, []string{filepath.Join("..", "testdata", "simple")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse JSON in stdin")
+}
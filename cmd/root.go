@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
 )
 
 var (
@@ -39,7 +43,8 @@ Performance:
 
 Exit Codes:
   0 - Success: Analysis completed without errors and all thresholds passed
-  1 - Failure: Analysis failed, invalid arguments, or threshold violations when --enforce-thresholds is set`,
+  1 - Failure: Analysis failed, invalid arguments, or threshold violations when --enforce-thresholds is set
+  2 - Threshold violation: A --fail-on-complexity, --fail-on-function-length, or --fail-on-doc-coverage limit was exceeded`,
 
 	Version: "1.0.0",
 }
@@ -51,6 +56,10 @@ Exit Codes:
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
+		var coded interface{ ExitCode() int }
+		if errors.As(err, &coded) {
+			os.Exit(coded.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
@@ -60,7 +69,7 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.go-stats-generator.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .go-stats-generator.yaml or .go-stats.yaml in the analyzed, working or home directory)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 
 	// Bind flags to viper
@@ -70,10 +79,19 @@ func init() {
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	setupConfigFile()
-	viper.AutomaticEnv()
+	setupEnvironment()
 	handleConfigLoad()
 }
 
+// setupEnvironment maps configuration keys onto GO_STATS_ environment variables, so that
+// analysis.max_function_length is read from GO_STATS_ANALYSIS_MAX_FUNCTION_LENGTH. Environment
+// variables override the config file and are overridden by flags.
+func setupEnvironment() {
+	viper.SetEnvPrefix("GO_STATS")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+}
+
 // setupConfigFile configures viper to use the correct config file.
 func setupConfigFile() {
 	if cfgFile != "" {
@@ -84,15 +102,18 @@ func setupConfigFile() {
 	setupDefaultConfigPaths()
 }
 
-// setupDefaultConfigPaths sets up default config search paths.
+// setupDefaultConfigPaths selects the first config file found in the working directory, then
+// the home directory. The analyze command additionally looks in the analyzed directory.
 func setupDefaultConfigPaths() {
 	home, err := os.UserHomeDir()
 	cobra.CheckErr(err)
 
-	viper.AddConfigPath(home)
-	viper.AddConfigPath(".")
-	viper.SetConfigType("yaml")
-	viper.SetConfigName(".go-stats-generator")
+	for _, dir := range []string{".", home} {
+		if path := config.FindConfigFile(dir); path != "" {
+			viper.SetConfigFile(path)
+			return
+		}
+	}
 }
 
 // handleConfigLoad reads config and handles errors appropriately.
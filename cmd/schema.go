@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+var schemaOutputFile string
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the JSON report",
+	Long: `Print a JSON Schema (draft 2020-12) document describing the report written by
+analyze --format json.
+
+The schema is generated from the report types of this binary, so it always matches the
+output of the same version. Use it to validate reports in CI or to generate types for
+tools that consume them.
+
+Examples:
+  # Save the schema next to a report
+  go-stats-generator schema --output report.schema.json
+
+  # Validate a report with any JSON Schema validator
+  go-stats-generator analyze . --format json --output report.json
+  check-jsonschema --schemafile report.schema.json report.json`,
+
+	Args: cobra.NoArgs,
+	RunE: runSchema,
+}
+
+// init registers the schema command and its flags with the root command.
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().StringVarP(&schemaOutputFile, "output", "o", "", "Output file (default: stdout)")
+}
+
+// runSchema writes the report JSON Schema to the output file or stdout.
+func runSchema(cmd *cobra.Command, args []string) error {
+	output, err := openOutputFile(schemaOutputFile)
+	if err != nil {
+		return err
+	}
+	if output != os.Stdout {
+		defer output.Close()
+	}
+	return writeReportSchema(output)
+}
+
+// writeReportSchema encodes the report JSON Schema as indented JSON.
+func writeReportSchema(output io.Writer) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(metrics.ReportSchema()); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/pkg/generator"
+)
+
+// compileReportSchema compiles the schema emitted by the schema command
+func compileReportSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, writeReportSchema(&buf))
+
+	doc, err := jsonschema.UnmarshalJSON(&buf)
+	require.NoError(t, err)
+	compiler := jsonschema.NewCompiler()
+	require.NoError(t, compiler.AddResource("report.schema.json", doc))
+	schema, err := compiler.Compile("report.schema.json")
+	require.NoError(t, err)
+	return schema
+}
+
+// encodeForValidation serializes v as JSON and decodes it into the generic form the validator expects
+func encodeForValidation(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	require.NoError(t, err)
+	return instance
+}
+
+func TestReportSchema_ValidatesGeneratedReport(t *testing.T) {
+	schema := compileReportSchema(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Performance.EnableCache = false
+	cfg.Analysis.EnableTeamMetrics = false
+	report, err := generator.Analyze(context.Background(), "../testdata/simple", *cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Functions)
+
+	assert.NoError(t, schema.Validate(encodeForValidation(t, report)))
+}
+
+func TestReportSchema_RejectsMismatchedReports(t *testing.T) {
+	schema := compileReportSchema(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Performance.EnableCache = false
+	report, err := generator.Analyze(context.Background(), "../testdata/simple", *cfg)
+	require.NoError(t, err)
+	doc := encodeForValidation(t, report).(map[string]interface{})
+
+	doc["unexpected_section"] = true
+	assert.Error(t, schema.Validate(doc), "undeclared properties are rejected")
+	delete(doc, "unexpected_section")
+
+	doc["functions"].([]interface{})[0].(map[string]interface{})["line"] = "forty-two"
+	assert.Error(t, schema.Validate(doc), "mistyped fields are rejected")
+}
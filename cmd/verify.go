@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+var (
+	verifyBaselineFile       string
+	verifyTolerancePercent   float64
+	verifyToleranceAbsolute  float64
+	verifyAllowMBIRegression bool
+	verifyEpsilon            float64
+)
+
+// ratchetCategories are the diff change categories where an increase is always a regression
+var ratchetCategories = map[string]bool{
+	"function_complexity":         true,
+	"function_overall_complexity": true,
+	"struct_fields":               true,
+	"package_coupling":            true,
+	"overall_complexity":          true,
+}
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [directory]",
+	Short: "Fail if the code regresses against a committed metrics baseline",
+	Long: `Analyze a directory and compare it against a committed baseline report, failing with a
+non-zero exit status if any tracked metric got worse (a ratchet).
+
+Tracked metrics never allowed to increase beyond the tolerances:
+
+  • Function cyclomatic and overall complexity
+  • Struct field counts
+  • Package coupling
+  • Average function and struct complexity
+  • File and package Maintenance Burden Index (MBI) scores
+
+Symbols added or removed since the baseline are not treated as regressions. Refresh the
+baseline file whenever metrics improve so the ratchet tightens over time.
+
+Examples:
+  # Create the baseline once and commit it
+  go-stats-generator analyze . --format json --output metrics-baseline.json
+
+  # Fail CI if anything got worse
+  go-stats-generator verify --baseline metrics-baseline.json
+
+  # Allow small increases of up to 10% or 1 point per metric
+  go-stats-generator verify . --baseline metrics-baseline.json --tolerance 10 --tolerance-abs 1`,
+
+	Args: cobra.MaximumNArgs(1),
+	RunE: runVerify,
+}
+
+// init registers the verify command and its flags with the root command.
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringVar(&verifyBaselineFile, "baseline", "metrics-baseline.json", "Baseline JSON report to verify against")
+	verifyCmd.Flags().Float64Var(&verifyTolerancePercent, "tolerance", 0, "Percentage increase allowed per metric before it counts as a regression")
+	verifyCmd.Flags().Float64Var(&verifyToleranceAbsolute, "tolerance-abs", 0, "Absolute increase allowed per metric before it counts as a regression")
+	verifyCmd.Flags().Float64Var(&verifyEpsilon, "epsilon", metrics.DefaultFloatEpsilon, "Largest float metric difference treated as no change")
+	verifyCmd.Flags().BoolVar(&verifyAllowMBIRegression, "allow-mbi-regression", false, "Do not fail on file or package MBI score regressions")
+}
+
+// runVerify analyzes the target directory, compares it with the baseline report, and returns an
+// error listing every metric that regressed beyond the configured tolerances.
+func runVerify(cmd *cobra.Command, args []string) error {
+	targetDir := "."
+	if len(args) > 0 {
+		targetDir = args[0]
+	}
+
+	baseline, err := loadReport(verifyBaselineFile)
+	if err != nil {
+		return fmt.Errorf("failed to load baseline report: %w", err)
+	}
+
+	current, err := analyzeCodebase(targetDir)
+	if err != nil {
+		return err
+	}
+
+	diff, err := compareForVerify(baseline, current)
+	if err != nil {
+		return err
+	}
+
+	failures := findRatchetRegressions(diff, verifyTolerancePercent, verifyToleranceAbsolute, !verifyAllowMBIRegression)
+	if len(failures) > 0 {
+		writeVerifyFailures(cmd.ErrOrStderr(), failures)
+		return fmt.Errorf("verify failed: %d metric(s) regressed against %s", len(failures), verifyBaselineFile)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ No regressions against %s\n", verifyBaselineFile)
+	return nil
+}
+
+// compareForVerify diffs the reports with significance disabled so every change beyond the
+// float epsilon is evaluated
+func compareForVerify(baseline, current *metrics.Report) (*metrics.ComplexityDiff, error) {
+	config := metrics.DefaultThresholdConfig()
+	config.Global.SignificanceLevel = 0
+	config.Global.Epsilon = verifyEpsilon
+
+	diff, err := metrics.CompareSnapshots(
+		metrics.Snapshot{ID: "baseline", Report: *baseline},
+		metrics.Snapshot{ID: "current", Report: *current},
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate diff: %w", err)
+	}
+	return diff, nil
+}
+
+// ratchetFailure describes one tracked metric that got worse than the baseline
+type ratchetFailure struct {
+	Location    string
+	File        string
+	Line        int
+	Description string
+	OldValue    interface{}
+	NewValue    interface{}
+	Delta       metrics.Delta
+}
+
+// findRatchetRegressions returns the tracked metric increases exceeding both tolerances. Burden
+// (MBI) regressions from the diff are included when includeBurden is set.
+func findRatchetRegressions(diff *metrics.ComplexityDiff, tolerancePercent, toleranceAbsolute float64, includeBurden bool) []ratchetFailure {
+	var failures []ratchetFailure
+	for _, change := range diff.Changes {
+		if !ratchetCategories[change.Category] || !exceedsTolerance(change.Delta, tolerancePercent, toleranceAbsolute) {
+			continue
+		}
+		failures = append(failures, ratchetFailure{
+			Location:    change.Path,
+			File:        change.File,
+			Line:        change.Line,
+			Description: change.Description,
+			OldValue:    change.OldValue,
+			NewValue:    change.NewValue,
+			Delta:       change.Delta,
+		})
+	}
+
+	if includeBurden {
+		for _, reg := range diff.Regressions {
+			if reg.Type != metrics.BurdenRegression || !exceedsTolerance(reg.Delta, tolerancePercent, toleranceAbsolute) {
+				continue
+			}
+			failures = append(failures, ratchetFailure{
+				Location:    reg.Location,
+				File:        reg.File,
+				Line:        reg.Line,
+				Description: reg.Description,
+				OldValue:    reg.OldValue,
+				NewValue:    reg.NewValue,
+				Delta:       reg.Delta,
+			})
+		}
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Location != failures[j].Location {
+			return failures[i].Location < failures[j].Location
+		}
+		return failures[i].Description < failures[j].Description
+	})
+	return failures
+}
+
+// exceedsTolerance reports whether delta is an increase larger than both tolerances
+func exceedsTolerance(delta metrics.Delta, tolerancePercent, toleranceAbsolute float64) bool {
+	return delta.Direction == metrics.ChangeDirectionIncrease &&
+		delta.Absolute > toleranceAbsolute &&
+		delta.Percentage > tolerancePercent
+}
+
+// writeVerifyFailures prints each regressed metric with its location and old and new values
+func writeVerifyFailures(w io.Writer, failures []ratchetFailure) {
+	fmt.Fprintf(w, "\n=== VERIFY FAILURES ===\n")
+	for _, f := range failures {
+		location := f.Location
+		if f.Line > 0 {
+			location = fmt.Sprintf("%s (%s:%d)", f.Location, f.File, f.Line)
+		}
+		fmt.Fprintf(w, "❌ %s: %s %v → %v (+%.1f%%)\n", location, f.Description, f.OldValue, f.NewValue, f.Delta.Percentage)
+	}
+	fmt.Fprintf(w, "\nFix the regressions or refresh the baseline if the increase is intended.\n")
+}
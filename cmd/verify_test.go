@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const verifyBaselineSource = `package sample
+
+// Classify buckets n into a label
+func Classify(n int) string {
+	if n < 0 {
+		return "negative"
+	}
+	if n == 0 {
+		return "zero"
+	}
+	return "positive"
+}
+`
+
+func TestRunVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		wantErr    bool
+		wantOutput string
+	}{
+		{
+			name: "worsened function fails",
+			source: `package sample
+
+// Classify buckets n into a label
+func Classify(n int) string {
+	if n < 0 {
+		return "negative"
+	}
+	if n == 0 {
+		return "zero"
+	}
+	if n < 10 {
+		return "small"
+	}
+	if n < 100 {
+		return "medium"
+	}
+	if n < 1000 {
+		return "large"
+	}
+	return "huge"
+}
+`,
+			wantErr:    true,
+			wantOutput: "sample.Classify",
+		},
+		{
+			name: "improved function passes",
+			source: `package sample
+
+// Classify buckets n into a label
+func Classify(n int) string {
+	if n < 0 {
+		return "negative"
+	}
+	return "non-negative"
+}
+`,
+			wantErr: false,
+		},
+		{
+			name:    "unchanged function passes",
+			source:  verifyBaselineSource,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			sourceFile := filepath.Join(dir, "sample.go")
+			require.NoError(t, os.WriteFile(sourceFile, []byte(verifyBaselineSource), 0o644))
+
+			baseline, err := analyzeCodebase(dir)
+			require.NoError(t, err)
+			data, err := json.Marshal(baseline)
+			require.NoError(t, err)
+			baselineFile := filepath.Join(t.TempDir(), "metrics-baseline.json")
+			require.NoError(t, os.WriteFile(baselineFile, data, 0o644))
+
+			require.NoError(t, os.WriteFile(sourceFile, []byte(tt.source), 0o644))
+
+			var stderr, stdout bytes.Buffer
+			verifyBaselineFile = baselineFile
+			verifyCmd.SetErr(&stderr)
+			verifyCmd.SetOut(&stdout)
+			defer func() {
+				verifyBaselineFile = "metrics-baseline.json"
+				verifyCmd.SetErr(nil)
+				verifyCmd.SetOut(nil)
+			}()
+
+			err = runVerify(verifyCmd, []string{dir})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "verify failed")
+				assert.Contains(t, stderr.String(), tt.wantOutput)
+			} else {
+				require.NoError(t, err, stderr.String())
+				assert.Contains(t, stdout.String(), "No regressions")
+			}
+		})
+	}
+}
+
+func TestRunVerify_Tolerance(t *testing.T) {
+	dir := t.TempDir()
+	sourceFile := filepath.Join(dir, "sample.go")
+	require.NoError(t, os.WriteFile(sourceFile, []byte(verifyBaselineSource), 0o644))
+
+	baseline, err := analyzeCodebase(dir)
+	require.NoError(t, err)
+	data, err := json.Marshal(baseline)
+	require.NoError(t, err)
+	baselineFile := filepath.Join(t.TempDir(), "metrics-baseline.json")
+	require.NoError(t, os.WriteFile(baselineFile, data, 0o644))
+
+	// One extra branch raises cyclomatic complexity from 3 to 4
+	require.NoError(t, os.WriteFile(sourceFile, []byte(`package sample
+
+// Classify buckets n into a label
+func Classify(n int) string {
+	if n < 0 {
+		return "negative"
+	}
+	if n == 0 {
+		return "zero"
+	}
+	if n == 1 {
+		return "one"
+	}
+	return "positive"
+}
+`), 0o644))
+
+	verifyBaselineFile = baselineFile
+	verifyToleranceAbsolute = 5
+	verifyCmd.SetErr(&bytes.Buffer{})
+	verifyCmd.SetOut(&bytes.Buffer{})
+	defer func() {
+		verifyBaselineFile = "metrics-baseline.json"
+		verifyToleranceAbsolute = 0
+		verifyCmd.SetErr(nil)
+		verifyCmd.SetOut(nil)
+	}()
+
+	assert.NoError(t, runVerify(verifyCmd, []string{dir}), "increases within the absolute tolerance pass")
+}
+
+func TestRunVerify_MissingBaseline(t *testing.T) {
+	verifyBaselineFile = filepath.Join(t.TempDir(), "missing.json")
+	defer func() { verifyBaselineFile = "metrics-baseline.json" }()
+
+	err := runVerify(verifyCmd, []string{filepath.Join("..", "testdata", "simple")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load baseline report")
+}
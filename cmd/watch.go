@@ -196,7 +196,7 @@ func analyzeWithWatch(path string) {
 	cfg.Output.ShowProgress = false
 
 	ctx := context.Background()
-	report, err := runDirectoryAnalysis(ctx, path, cfg)
+	report, err := runAnalysis(ctx, path, cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Analysis failed: %v\n", err)
 		return
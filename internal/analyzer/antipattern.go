@@ -26,24 +26,31 @@ func NewAntipatternAnalyzer(fset *token.FileSet) *AntipatternAnalyzer {
 // common inefficiencies. It checks for memory allocation issues (append without capacity),
 // string concatenation in loops, goroutine leaks (missing channel closes), and resource
 // management problems (defer in loops, unclosed resources). Returns a list of detected patterns.
+// Functions with a suppression directive such as //nolint:complexity are skipped.
 func (a *AntipatternAnalyzer) Analyze(file *ast.File) []metrics.PerformanceAntipattern {
 	var patterns []metrics.PerformanceAntipattern
 
 	// Check if this is library code (non-main package)
-	isLibraryCode := file.Name != nil && file.Name.Name != "main"
+	pkgName := ""
+	if file.Name != nil {
+		pkgName = file.Name.Name
+	}
+	isLibraryCode := pkgName != "" && pkgName != "main"
 
 	for _, decl := range file.Decls {
 		funcDecl, ok := decl.(*ast.FuncDecl)
-		if !ok || funcDecl.Body == nil {
+		if !ok || funcDecl.Body == nil || isSuppressed(a.fset, file, funcDecl) {
 			continue
 		}
 		patterns = append(patterns, a.analyzeFunction(funcDecl)...)
 		patterns = append(patterns, a.checkAnyOveruse(funcDecl)...)
 		patterns = append(patterns, a.checkInitFunctionComplexity(funcDecl)...)
-		patterns = append(patterns, a.checkNakedReturnInLongFunction(funcDecl)...)
 		patterns = append(patterns, a.checkPanicInLibraryCode(funcDecl, isLibraryCode)...)
 		patterns = append(patterns, a.checkGiantBranchingChains(funcDecl)...)
 		patterns = append(patterns, a.checkUnusedReceiverName(funcDecl)...)
+		patterns = append(patterns, a.checkParameterMutation(funcDecl)...)
+		patterns = append(patterns, a.checkUnnecessaryElse(funcDecl)...)
+		patterns = append(patterns, a.checkNonReturningFunction(funcDecl, pkgName)...)
 	}
 
 	return patterns
@@ -726,102 +733,6 @@ func (a *AntipatternAnalyzer) calculateCyclomaticComplexity(body *ast.BlockStmt)
 	return complexity
 }
 
-// checkNakedReturnInLongFunction detects naked returns (return without explicit values)
-// in functions exceeding a line threshold. Short functions with named returns are idiomatic
-// Go; long functions with naked returns harm readability. This is a common LLM slop pattern
-// where generated code uses named returns inappropriately in complex functions.
-func (a *AntipatternAnalyzer) checkNakedReturnInLongFunction(funcDecl *ast.FuncDecl) []metrics.PerformanceAntipattern {
-	var patterns []metrics.PerformanceAntipattern
-
-	// Only check functions with named return parameters
-	if !a.hasNamedReturns(funcDecl) {
-		return patterns
-	}
-
-	// Check if function body exists
-	if funcDecl.Body == nil {
-		return patterns
-	}
-
-	// Count lines in function body
-	lineCount := a.countFunctionLines(funcDecl)
-
-	// Threshold: only flag if function > 10 lines
-	const maxLinesForNakedReturn = 10
-	if lineCount <= maxLinesForNakedReturn {
-		return patterns
-	}
-
-	// Check for naked return statements
-	if a.hasNakedReturn(funcDecl.Body) {
-		patterns = append(patterns, metrics.PerformanceAntipattern{
-			Type:        "naked_return_long_function",
-			Description: "Naked return in long function with named returns",
-			Severity:    metrics.SeverityLevelWarning,
-			File:        a.fset.Position(funcDecl.Pos()).Filename,
-			Line:        a.fset.Position(funcDecl.Pos()).Line,
-			Suggestion:  "Use explicit return values in long functions to improve readability",
-		})
-	}
-
-	return patterns
-}
-
-// hasNamedReturns checks if function has named return parameters
-func (a *AntipatternAnalyzer) hasNamedReturns(funcDecl *ast.FuncDecl) bool {
-	if funcDecl.Type == nil || funcDecl.Type.Results == nil {
-		return false
-	}
-
-	for _, result := range funcDecl.Type.Results.List {
-		// If any result has a name, it's a named return
-		if len(result.Names) > 0 {
-			return true
-		}
-	}
-
-	return false
-}
-
-// countFunctionLines counts the number of lines in a function body
-func (a *AntipatternAnalyzer) countFunctionLines(funcDecl *ast.FuncDecl) int {
-	if funcDecl.Body == nil {
-		return 0
-	}
-
-	start := a.fset.Position(funcDecl.Body.Lbrace)
-	end := a.fset.Position(funcDecl.Body.Rbrace)
-
-	// Return number of lines between braces (exclusive of braces themselves)
-	return end.Line - start.Line - 1
-}
-
-// hasNakedReturn checks if function body contains any naked return statements
-func (a *AntipatternAnalyzer) hasNakedReturn(body *ast.BlockStmt) bool {
-	found := false
-
-	ast.Inspect(body, func(n ast.Node) bool {
-		if found {
-			return false
-		}
-
-		retStmt, ok := n.(*ast.ReturnStmt)
-		if !ok {
-			return true
-		}
-
-		// Naked return: return statement with no explicit values
-		if len(retStmt.Results) == 0 {
-			found = true
-			return false
-		}
-
-		return true
-	})
-
-	return found
-}
-
 // checkPanicInLibraryCode detects panic() and log.Fatal() calls in library code (non-main packages).
 // Library code should return errors instead of terminating the process. The check excludes init()
 // functions where panic() is acceptable for configuration validation during initialization.
@@ -1076,6 +987,344 @@ func (a *AntipatternAnalyzer) isReceiverUsed(body *ast.BlockStmt, receiverName s
 	return used
 }
 
+// checkParameterMutation detects functions that write into the elements of slice or map
+// parameters (s[i] = v, m[k] = v, delete(m, k)) or append to a slice parameter and let the
+// result escape. Such writes are visible to the caller through the shared backing storage,
+// so an advisory is emitted once per mutated parameter to make the aliasing explicit.
+// An append escapes when its result is returned, stored outside the function's locals, or
+// held by a local that is itself returned or stored; appending to a reslice of the parameter,
+// as in append(s[:i], s[i+1:]...), overwrites elements the caller sees wherever the result goes.
+func (a *AntipatternAnalyzer) checkParameterMutation(funcDecl *ast.FuncDecl) []metrics.PerformanceAntipattern {
+	var patterns []metrics.PerformanceAntipattern
+
+	params := a.collectSliceMapParams(funcDecl.Type)
+	if len(params) == 0 {
+		return patterns
+	}
+
+	mc := mutationContext{fn: funcDecl, params: params}
+	mc.escaping = mc.collectEscapingLocals()
+	reported := make(map[string]bool)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		name, pos, detail := a.findParameterMutation(n, mc)
+		if name == "" || reported[name] {
+			return true
+		}
+		reported[name] = true
+		patterns = append(patterns, metrics.PerformanceAntipattern{
+			Type:        "parameter_mutation",
+			Description: fmt.Sprintf("Function %s %s parameter %q, which is visible to the caller", funcDecl.Name.Name, detail, name),
+			Severity:    metrics.SeverityLevelInfo,
+			File:        a.fset.Position(pos).Filename,
+			Line:        a.fset.Position(pos).Line,
+			Suggestion:  "Document the mutation, or copy the slice/map before modifying it to avoid aliasing side effects",
+		})
+		return true
+	})
+
+	return patterns
+}
+
+// collectSliceMapParams returns the names of parameters declared with slice or map types
+func (a *AntipatternAnalyzer) collectSliceMapParams(funcType *ast.FuncType) map[string]bool {
+	params := make(map[string]bool)
+	if funcType == nil || funcType.Params == nil {
+		return params
+	}
+	for _, field := range funcType.Params.List {
+		if !a.isSliceOrMapType(field.Type) {
+			continue
+		}
+		for _, name := range field.Names {
+			if name.Name != "_" {
+				params[name.Name] = true
+			}
+		}
+	}
+	return params
+}
+
+// isSliceOrMapType checks if a type expression is a slice or map type
+func (a *AntipatternAnalyzer) isSliceOrMapType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.ArrayType:
+		return t.Len == nil
+	case *ast.MapType:
+		return true
+	}
+	return false
+}
+
+// mutationContext holds the slice and map parameters of a function and the locals whose value
+// leaves it, so appends to a parameter are only reported when the caller can observe them
+type mutationContext struct {
+	fn       *ast.FuncDecl
+	params   map[string]bool
+	escaping map[string]bool
+}
+
+// isLocal reports whether ident names a variable declared in the function, parameters included
+func (mc mutationContext) isLocal(ident *ast.Ident) bool {
+	if ident.Obj == nil || ident.Obj.Kind != ast.Var {
+		return false
+	}
+	pos := ident.Obj.Pos()
+	return pos >= mc.fn.Pos() && pos < mc.fn.End()
+}
+
+// escapes reports whether a value assigned to lhs leaves the function: lhs is a named result, a
+// local that is returned or stored, or anything other than a local, such as a field or global
+func (mc mutationContext) escapes(lhs ast.Expr) bool {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return true
+	}
+	if ident.Name == "_" {
+		return false
+	}
+	return mc.escaping[ident.Name] || !mc.isLocal(ident)
+}
+
+// collectEscapingLocals returns the names of the named results and of the locals that are
+// returned or stored outside the function's locals, directly or through other locals
+func (mc mutationContext) collectEscapingLocals() map[string]bool {
+	escaping := make(map[string]bool)
+	if results := mc.fn.Type.Results; results != nil {
+		for _, field := range results.List {
+			for _, name := range field.Names {
+				escaping[name.Name] = true
+			}
+		}
+	}
+	mc.escaping = escaping
+
+	for changed := true; changed; {
+		changed = false
+		ast.Inspect(mc.fn.Body, func(n ast.Node) bool {
+			switch stmt := n.(type) {
+			case *ast.ReturnStmt:
+				for _, result := range stmt.Results {
+					if name := aliasedLocal(result); name != "" && !escaping[name] {
+						escaping[name], changed = true, true
+					}
+				}
+			case *ast.AssignStmt:
+				if len(stmt.Lhs) != len(stmt.Rhs) {
+					return true
+				}
+				for i, rhs := range stmt.Rhs {
+					if name := aliasedLocal(rhs); name != "" && !escaping[name] && mc.escapes(stmt.Lhs[i]) {
+						escaping[name], changed = true, true
+					}
+				}
+			}
+			return true
+		})
+	}
+	return escaping
+}
+
+// aliasedLocal returns the variable whose backing array expr shares: the variable itself, or
+// the slice an append call grows
+func aliasedLocal(expr ast.Expr) string {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if ident, isIdent := call.Fun.(*ast.Ident); isIdent && ident.Name == "append" && len(call.Args) > 0 {
+			expr = call.Args[0]
+		}
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// findParameterMutation reports the parameter mutated by a node, its position, and a short
+// description of the mutation kind. An empty name means the node does not mutate a parameter.
+func (a *AntipatternAnalyzer) findParameterMutation(n ast.Node, mc mutationContext) (string, token.Pos, string) {
+	params := mc.params
+	switch stmt := n.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range stmt.Lhs {
+			if name := a.indexedParamName(lhs, params); name != "" {
+				return name, stmt.Pos(), "writes to an element of"
+			}
+		}
+		for i, rhs := range stmt.Rhs {
+			name, resliced := a.appendedParamName(rhs, params)
+			switch {
+			case name == "":
+			case resliced:
+				return name, stmt.Pos(), "overwrites elements of"
+			case len(stmt.Lhs) != len(stmt.Rhs) || mc.escapes(stmt.Lhs[i]):
+				return name, stmt.Pos(), "appends to"
+			}
+		}
+	case *ast.IncDecStmt:
+		if name := a.indexedParamName(stmt.X, params); name != "" {
+			return name, stmt.Pos(), "writes to an element of"
+		}
+	case *ast.ReturnStmt:
+		for _, result := range stmt.Results {
+			if name, _ := a.appendedParamName(result, params); name != "" {
+				return name, stmt.Pos(), "appends to"
+			}
+		}
+	case *ast.ExprStmt:
+		if call, ok := stmt.X.(*ast.CallExpr); ok && a.isBuiltinCall(call, "delete") && len(call.Args) > 0 {
+			if ident, ok := call.Args[0].(*ast.Ident); ok && params[ident.Name] {
+				return ident.Name, stmt.Pos(), "deletes keys from"
+			}
+		}
+	}
+	return "", token.NoPos, ""
+}
+
+// indexedParamName returns the parameter name if expr is an index expression on a parameter
+func (a *AntipatternAnalyzer) indexedParamName(expr ast.Expr, params map[string]bool) string {
+	indexExpr, ok := expr.(*ast.IndexExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := indexExpr.X.(*ast.Ident); ok && params[ident.Name] {
+		return ident.Name
+	}
+	return ""
+}
+
+// appendedParamName returns the parameter name if expr is append(param, ...) or
+// append(param[i:j], ...), and whether the parameter is resliced
+func (a *AntipatternAnalyzer) appendedParamName(expr ast.Expr, params map[string]bool) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || !a.isAppendCall(call) || len(call.Args) < 2 {
+		return "", false
+	}
+	target, resliced := call.Args[0], false
+	if slice, ok := target.(*ast.SliceExpr); ok {
+		target, resliced = slice.X, true
+	}
+	if ident, ok := target.(*ast.Ident); ok && params[ident.Name] {
+		return ident.Name, resliced
+	}
+	return "", false
+}
+
+// isBuiltinCall checks if a call expression invokes the named builtin function
+func (a *AntipatternAnalyzer) isBuiltinCall(call *ast.CallExpr, name string) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// checkUnnecessaryElse detects if statements whose then-branch always terminates (return, break,
+// continue, goto, or panic) but which still carry an else block. The else can be unwrapped into a
+// guard clause, reducing nesting depth. If statements with an init clause are skipped because
+// unwrapping would change the scope of the variables it declares.
+func (a *AntipatternAnalyzer) checkUnnecessaryElse(funcDecl *ast.FuncDecl) []metrics.PerformanceAntipattern {
+	var patterns []metrics.PerformanceAntipattern
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		ifStmt, ok := n.(*ast.IfStmt)
+		if !ok || ifStmt.Init != nil {
+			return true
+		}
+		if _, isBlock := ifStmt.Else.(*ast.BlockStmt); !isBlock {
+			return true
+		}
+		if !a.endsWithTerminatingStmt(ifStmt.Body) {
+			return true
+		}
+
+		pos := a.fset.Position(ifStmt.Else.Pos())
+		patterns = append(patterns, metrics.PerformanceAntipattern{
+			Type:        "unnecessary_else",
+			Description: "else block follows an if branch that always terminates",
+			Severity:    metrics.SeverityLevelInfo,
+			File:        pos.Filename,
+			Line:        pos.Line,
+			Suggestion:  "Drop the else and unindent its body to use a guard clause",
+		})
+		return true
+	})
+
+	return patterns
+}
+
+// endsWithTerminatingStmt checks if a block's last statement unconditionally leaves the block
+func (a *AntipatternAnalyzer) endsWithTerminatingStmt(block *ast.BlockStmt) bool {
+	if block == nil || len(block.List) == 0 {
+		return false
+	}
+
+	switch stmt := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return stmt.Tok == token.BREAK || stmt.Tok == token.CONTINUE || stmt.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		return ok && a.isBuiltinCall(call, "panic")
+	}
+	return false
+}
+
+// AnalyzeReceiverConsistency finds the structs whose methods mix value and pointer receivers,
+// recording the methods of each kind. Value-receiver getters (no parameters, at least one
+// result) are listed separately and do not count, because they are commonly declared on
+// otherwise pointer-receiver types. Structs should already carry the methods declared in other
+// files of their package (see AttachCrossFileMethods).
+func AnalyzeReceiverConsistency(structs []metrics.StructMetrics) []metrics.ReceiverConsistency {
+	var mixed []metrics.ReceiverConsistency
+
+	for _, s := range structs {
+		entry := metrics.ReceiverConsistency{Type: s.Name, Package: s.Package, File: s.File, Line: s.Line}
+		for _, m := range s.Methods {
+			switch {
+			case m.IsPointer:
+				entry.PointerMethods = append(entry.PointerMethods, m.Name)
+			case isGetterMethod(m):
+				entry.ValueGetters = append(entry.ValueGetters, m.Name)
+			default:
+				entry.ValueMethods = append(entry.ValueMethods, m.Name)
+			}
+		}
+
+		if len(entry.PointerMethods) == 0 || len(entry.ValueMethods) == 0 {
+			continue
+		}
+		entry.PointerReceivers = len(entry.PointerMethods)
+		entry.ValueReceivers = len(entry.ValueMethods)
+		mixed = append(mixed, entry)
+	}
+
+	return mixed
+}
+
+// CheckReceiverConsistency converts the results of AnalyzeReceiverConsistency into
+// informational anti-patterns, one per type mixing value and pointer receivers.
+func CheckReceiverConsistency(mixed []metrics.ReceiverConsistency) []metrics.PerformanceAntipattern {
+	var patterns []metrics.PerformanceAntipattern
+
+	for _, r := range mixed {
+		patterns = append(patterns, metrics.PerformanceAntipattern{
+			Type: "inconsistent_receiver",
+			Description: fmt.Sprintf("Type '%s' mixes %d pointer receivers (%s) and %d value receivers (%s)",
+				r.Type, r.PointerReceivers, strings.Join(r.PointerMethods, ", "),
+				r.ValueReceivers, strings.Join(r.ValueMethods, ", ")),
+			Severity:   metrics.SeverityLevelInfo,
+			File:       r.File,
+			Line:       r.Line,
+			Suggestion: "Use pointer receivers for all methods of the type, or value receivers for all of them",
+		})
+	}
+
+	return patterns
+}
+
+// isGetterMethod reports whether a method looks like a simple accessor: it takes no
+// parameters and returns at least one value.
+func isGetterMethod(m metrics.MethodInfo) bool {
+	return m.Signature.ParameterCount == 0 && m.Signature.ReturnCount > 0
+}
+
 // CheckTestOnlyExports detects exported symbols with zero cross-package references outside
 // test files. This identifies symbols that are exported solely for test access, which could
 // instead use export_test.go patterns or be restructured to test via the public API. This
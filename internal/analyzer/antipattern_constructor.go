@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// constructorInfo records a New<Type> function that builds a struct declared in the same package
+type constructorInfo struct {
+	name string
+	decl *ast.FuncDecl
+}
+
+// CheckConstructorBypass detects composite literals (T{...} or &T{...}) of struct types that
+// have a New<T> constructor in the same package. Literals inside the constructor itself, inside
+// methods of T, and in test files are ignored because those places legitimately build the value
+// directly. All files passed in must belong to a single package.
+func CheckConstructorBypass(fileInfos []BurdenFileInfo) []metrics.PerformanceAntipattern {
+	constructors := collectStructConstructors(fileInfos)
+	if len(constructors) == 0 {
+		return nil
+	}
+
+	var patterns []metrics.PerformanceAntipattern
+	for _, fi := range fileInfos {
+		if fi.File == nil || fi.Fset == nil || isTestFile(fi.Fset.Position(fi.File.Pos()).Filename) {
+			continue
+		}
+		for _, decl := range fi.File.Decls {
+			patterns = append(patterns, findConstructorBypasses(decl, fi.Fset, constructors)...)
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].File != patterns[j].File {
+			return patterns[i].File < patterns[j].File
+		}
+		return patterns[i].Line < patterns[j].Line
+	})
+	return patterns
+}
+
+// collectStructConstructors maps struct type names to their New<Type> constructor. A function
+// qualifies when it has no receiver and its first result is the struct type or a pointer to it.
+func collectStructConstructors(fileInfos []BurdenFileInfo) map[string]constructorInfo {
+	structTypes := make(map[string]bool)
+	for _, fi := range fileInfos {
+		if fi.File == nil {
+			continue
+		}
+		ast.Inspect(fi.File, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if _, isStruct := spec.Type.(*ast.StructType); isStruct {
+					structTypes[spec.Name.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	constructors := make(map[string]constructorInfo)
+	for _, fi := range fileInfos {
+		if fi.File == nil {
+			continue
+		}
+		for _, decl := range fi.File.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil {
+				continue
+			}
+			typeName := constructedTypeName(funcDecl)
+			if typeName != "" && structTypes[typeName] && funcDecl.Name.Name == "New"+typeName {
+				constructors[typeName] = constructorInfo{name: funcDecl.Name.Name, decl: funcDecl}
+			}
+		}
+	}
+	return constructors
+}
+
+// constructedTypeName returns the type name of the first result of funcDecl, dereferencing a pointer
+func constructedTypeName(funcDecl *ast.FuncDecl) string {
+	results := funcDecl.Type.Results
+	if results == nil || len(results.List) == 0 {
+		return ""
+	}
+	expr := results.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// findConstructorBypasses returns one anti-pattern per composite literal in decl that builds a
+// type with a known constructor, unless decl is that constructor or a method of the type
+func findConstructorBypasses(decl ast.Decl, fset *token.FileSet, constructors map[string]constructorInfo) []metrics.PerformanceAntipattern {
+	owner := ""
+	if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+		owner = receiverTypeName(funcDecl)
+		if owner == "" {
+			owner = constructedTypeName(funcDecl)
+			if info, exists := constructors[owner]; !exists || info.decl != funcDecl {
+				owner = ""
+			}
+		}
+	}
+
+	var patterns []metrics.PerformanceAntipattern
+	ast.Inspect(decl, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		ident, ok := lit.Type.(*ast.Ident)
+		if !ok || ident.Name == owner {
+			return true
+		}
+		info, exists := constructors[ident.Name]
+		if !exists {
+			return true
+		}
+
+		pos := fset.Position(lit.Pos())
+		patterns = append(patterns, metrics.PerformanceAntipattern{
+			Type:        "constructor_bypass",
+			Description: fmt.Sprintf("Struct '%s' is initialized directly although constructor %s exists", ident.Name, info.name),
+			Severity:    metrics.SeverityLevelInfo,
+			File:        pos.Filename,
+			Line:        pos.Line,
+			Suggestion:  fmt.Sprintf("Use %s() so the invariants it establishes are not skipped", info.name),
+		})
+		return true
+	})
+	return patterns
+}
+
+// receiverTypeName returns the base type name of a method receiver, or "" for plain functions
+func receiverTypeName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return ""
+	}
+	expr := funcDecl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	case *ast.IndexListExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConstructorBypass(t *testing.T) {
+	const constructor = `package main
+type Server struct{ port int }
+func NewServer(port int) *Server { return &Server{port: port} }
+`
+
+	tests := []struct {
+		name          string
+		code          string
+		filename      string
+		expectPattern int
+		description   string
+	}{
+		{
+			name: "pointer literal outside constructor",
+			code: `package main
+func start() *Server { return &Server{port: 80} }
+`,
+			filename:      "main.go",
+			expectPattern: 1,
+			description:   "Direct &Server{} should be flagged when NewServer exists",
+		},
+		{
+			name: "zero value literal",
+			code: `package main
+func start() { s := Server{}; _ = s }
+`,
+			filename:      "main.go",
+			expectPattern: 1,
+			description:   "Zero-value Server{} should be flagged when NewServer exists",
+		},
+		{
+			name: "uses constructor",
+			code: `package main
+func start() *Server { return NewServer(80) }
+`,
+			filename:      "main.go",
+			expectPattern: 0,
+			description:   "Calling the constructor is clean",
+		},
+		{
+			name: "method of the type",
+			code: `package main
+func (s *Server) Clone() *Server { return &Server{port: s.port} }
+`,
+			filename:      "main.go",
+			expectPattern: 0,
+			description:   "Methods of the type may build it directly",
+		},
+		{
+			name: "test file",
+			code: `package main
+func fixture() *Server { return &Server{port: 80} }
+`,
+			filename:      "main_test.go",
+			expectPattern: 0,
+			description:   "Test fixtures are not flagged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			ctorFile, err := parser.ParseFile(fset, "server.go", constructor, 0)
+			require.NoError(t, err)
+			file, err := parser.ParseFile(fset, tt.filename, tt.code, 0)
+			require.NoError(t, err)
+
+			fileInfos := []BurdenFileInfo{
+				{File: ctorFile, Fset: fset, Pkg: "main"},
+				{File: file, Fset: fset, Pkg: "main"},
+			}
+
+			count := 0
+			for _, p := range CheckConstructorBypass(fileInfos) {
+				if p.Type == "constructor_bypass" {
+					assert.Equal(t, tt.filename, p.File)
+					count++
+				}
+			}
+			assert.Equal(t, tt.expectPattern, count, tt.description)
+		})
+	}
+}
+
+func TestCheckConstructorBypass_NoConstructor(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", `package main
+type Point struct{ X, Y int }
+func origin() Point { return Point{} }
+`, 0)
+	require.NoError(t, err)
+
+	patterns := CheckConstructorBypass([]BurdenFileInfo{{File: file, Fset: fset, Pkg: "main"}})
+	assert.Empty(t, patterns, "Structs without a New<Type> constructor are never flagged")
+}
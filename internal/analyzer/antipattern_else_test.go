@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnnecessaryElse(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		expectPattern int
+		description   string
+	}{
+		{
+			name: "else after return",
+			code: `package main
+func Sign(x int) string {
+	if x < 0 {
+		return "negative"
+	} else {
+		return "non-negative"
+	}
+}`,
+			expectPattern: 1,
+			description:   "Then-branch returns so the else can be unwrapped",
+		},
+		{
+			name: "then-branch falls through",
+			code: `package main
+func Label(x int) string {
+	var s string
+	if x < 0 {
+		s = "negative"
+	} else {
+		s = "non-negative"
+	}
+	return s
+}`,
+			expectPattern: 0,
+			description:   "Both branches fall through so the else is required",
+		},
+		{
+			name: "else after continue in loop",
+			code: `package main
+func Count(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		if x == 0 {
+			continue
+		} else {
+			n++
+		}
+	}
+	return n
+}`,
+			expectPattern: 1,
+			description:   "continue terminates the then-branch",
+		},
+		{
+			name: "else after panic",
+			code: `package main
+func Must(err error) {
+	if err != nil {
+		panic(err)
+	} else {
+		println("ok")
+	}
+}`,
+			expectPattern: 1,
+			description:   "panic terminates the then-branch",
+		},
+		{
+			name: "init clause scopes variables",
+			code: `package main
+func Lookup(m map[string]int) int {
+	if v, ok := m["k"]; !ok {
+		return 0
+	} else {
+		return v
+	}
+}`,
+			expectPattern: 0,
+			description:   "Unwrapping would move v out of scope",
+		},
+		{
+			name: "else if chain",
+			code: `package main
+func Grade(x int) string {
+	if x > 90 {
+		return "A"
+	} else if x > 80 {
+		return "B"
+	}
+	return "C"
+}`,
+			expectPattern: 0,
+			description:   "else-if chains are not flagged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			assert.NoError(t, err, "Failed to parse test code")
+
+			analyzer := NewAntipatternAnalyzer(fset)
+			patterns := analyzer.Analyze(file)
+
+			count := 0
+			for _, p := range patterns {
+				if p.Type == "unnecessary_else" {
+					count++
+					assert.NotEmpty(t, p.Suggestion)
+				}
+			}
+			assert.Equal(t, tt.expectPattern, count, tt.description)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// Hot-path warning kinds reported by DetectHotPathAllocations
+const (
+	HotPathAppendInLoop       = "append_in_loop"
+	HotPathSprintfInLoop      = "sprintf_in_loop"
+	HotPathMapWriteInLoop     = "map_write_in_loop"
+	HotPathStringConcatInLoop = "string_concat_in_loop"
+)
+
+// valueKind is what the hot-path heuristics know about a local variable from its declaration
+type valueKind int
+
+const (
+	kindUnknown valueKind = iota
+	kindSlice
+	kindPresizedSlice
+	kindMap
+	kindSizedMap
+	kindString
+)
+
+// hotPathDetector collects hot-path allocation warnings for one function
+type hotPathDetector struct {
+	fset     *token.FileSet
+	function string
+	kinds    map[string]valueKind
+	warnings []metrics.PerformanceWarning
+}
+
+// DetectHotPathAllocations flags statements inside for and range loops that are likely to
+// allocate on every iteration: append to a slice not created with a capacity, fmt.Sprintf and
+// its siblings, writes to a map created without a size hint, and string concatenation with +
+// or +=. The detection is a syntactic heuristic, not escape analysis: variables are classified
+// only from their declarations in the same function, so it can miss allocations and flag
+// loops the compiler optimizes. Function literals are skipped, as are test files and
+// suppressed functions.
+func (a *AntipatternAnalyzer) DetectHotPathAllocations(file *ast.File) []metrics.PerformanceWarning {
+	if isTestFile(a.fset.Position(file.Pos()).Filename) {
+		return nil
+	}
+
+	var warnings []metrics.PerformanceWarning
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || isSuppressed(a.fset, file, fn) {
+			continue
+		}
+		d := &hotPathDetector{fset: a.fset, function: fn.Name.Name, kinds: make(map[string]valueKind)}
+		d.collectKinds(fn)
+		d.inspect(fn.Body, false)
+		warnings = append(warnings, d.warnings...)
+	}
+	return warnings
+}
+
+// collectKinds classifies the parameters, named results, and local variables of fn from their declared types
+// and initial values
+func (d *hotPathDetector) collectKinds(fn *ast.FuncDecl) {
+	for _, fields := range []*ast.FieldList{fn.Type.Params, fn.Type.Results} {
+		if fields == nil {
+			continue
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				d.kinds[name.Name] = typeKind(field.Type)
+			}
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				kind := typeKind(n.Type)
+				if i < len(n.Values) {
+					if initKind := exprKind(n.Values[i]); initKind != kindUnknown {
+						kind = initKind
+					}
+				}
+				d.kinds[name.Name] = kind
+			}
+		case *ast.AssignStmt:
+			// A later make with a capacity upgrades the variable; other reassignments keep
+			// what the declaration said
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if kind := exprKind(n.Rhs[i]); n.Tok == token.DEFINE || kind == kindPresizedSlice || kind == kindSizedMap {
+					d.kinds[ident.Name] = kind
+				}
+			}
+		}
+		return true
+	})
+}
+
+// typeKind classifies a declared type
+func typeKind(expr ast.Expr) valueKind {
+	switch t := expr.(type) {
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return kindSlice
+		}
+	case *ast.MapType:
+		return kindMap
+	case *ast.Ident:
+		if t.Name == "string" {
+			return kindString
+		}
+	}
+	return kindUnknown
+}
+
+// exprKind classifies the value of an initializer expression
+func exprKind(expr ast.Expr) valueKind {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "make" && len(e.Args) > 0 {
+			switch typeKind(e.Args[0]) {
+			case kindSlice:
+				if len(e.Args) == 3 {
+					return kindPresizedSlice
+				}
+				return kindSlice
+			case kindMap:
+				if len(e.Args) == 2 {
+					return kindSizedMap
+				}
+				return kindMap
+			}
+		}
+		if isSprintCall(e) {
+			return kindString
+		}
+	case *ast.CompositeLit:
+		return typeKind(e.Type)
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			return kindString
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD && (exprKind(e.X) == kindString || exprKind(e.Y) == kindString) {
+			return kindString
+		}
+	}
+	return kindUnknown
+}
+
+// isSprintCall reports whether call is fmt.Sprintf, fmt.Sprint, or fmt.Sprintln
+func isSprintCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "fmt" {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Sprintf", "Sprint", "Sprintln":
+		return true
+	}
+	return false
+}
+
+// inspect walks node, checking statements once inLoop is set. Loop bodies are walked with
+// inLoop set; function literals are not walked, since they may never run in the loop.
+func (d *hotPathDetector) inspect(node ast.Node, inLoop bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt:
+			d.inspect(n.Body, true)
+			return false
+		case *ast.RangeStmt:
+			d.inspect(n.Body, true)
+			return false
+		case *ast.AssignStmt:
+			if inLoop {
+				d.checkAssign(n)
+			}
+		case *ast.CallExpr:
+			if inLoop && isSprintCall(n) {
+				sel := n.Fun.(*ast.SelectorExpr)
+				d.warn(HotPathSprintfInLoop, n, fmt.Sprintf("fmt.%s in a loop allocates a new string on every iteration", sel.Sel.Name),
+					"Write into a strings.Builder or bytes.Buffer created outside the loop, or use strconv for simple conversions")
+			}
+		}
+		return true
+	})
+}
+
+// checkAssign flags appends to slices without capacity, writes to maps without a size hint,
+// and string concatenation in an assignment inside a loop
+func (d *hotPathDetector) checkAssign(assign *ast.AssignStmt) {
+	for i, lhs := range assign.Lhs {
+		if index, ok := lhs.(*ast.IndexExpr); ok && assign.Tok != token.DEFINE && d.kindOf(index.X) == kindMap {
+			d.warn(HotPathMapWriteInLoop, assign, fmt.Sprintf("Writes to map '%s' in a loop grow it repeatedly", types.ExprString(index.X)),
+				"Pass the expected number of entries to make so the map is allocated once")
+		}
+		if i >= len(assign.Rhs) {
+			continue
+		}
+		rhs := assign.Rhs[i]
+
+		if call, ok := rhs.(*ast.CallExpr); ok && isBuiltinAppend(call) && d.kindOf(lhs) != kindPresizedSlice {
+			d.warn(HotPathAppendInLoop, assign, fmt.Sprintf("append to '%s' in a loop without a pre-sized capacity", types.ExprString(lhs)),
+				"Create the slice with make([]T, 0, n) when the number of elements is known")
+		}
+		if d.isStringConcat(assign.Tok, lhs, rhs) {
+			d.warn(HotPathStringConcatInLoop, assign, fmt.Sprintf("String concatenation to '%s' in a loop copies the string on every iteration", types.ExprString(lhs)),
+				"Build the string with a strings.Builder created outside the loop")
+		}
+	}
+}
+
+// isStringConcat reports whether lhs tok rhs appends to a string: s += x, or s = s + x
+func (d *hotPathDetector) isStringConcat(tok token.Token, lhs, rhs ast.Expr) bool {
+	switch tok {
+	case token.ADD_ASSIGN:
+		return d.kindOf(lhs) == kindString || exprKind(rhs) == kindString
+	case token.ASSIGN:
+		bin, ok := rhs.(*ast.BinaryExpr)
+		if !ok || bin.Op != token.ADD || types.ExprString(bin.X) != types.ExprString(lhs) {
+			return false
+		}
+		return d.kindOf(lhs) == kindString || exprKind(bin.Y) == kindString
+	}
+	return false
+}
+
+// kindOf returns what is known about the variable expr names; only plain identifiers are tracked
+func (d *hotPathDetector) kindOf(expr ast.Expr) valueKind {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return d.kinds[ident.Name]
+	}
+	return kindUnknown
+}
+
+// warn records a warning of the given kind at node
+func (d *hotPathDetector) warn(kind string, node ast.Node, description, suggestion string) {
+	pos := d.fset.Position(node.Pos())
+	d.warnings = append(d.warnings, metrics.PerformanceWarning{
+		Type:        kind,
+		File:        pos.Filename,
+		Line:        pos.Line,
+		Function:    d.function,
+		Severity:    metrics.SeverityLevelWarning,
+		Description: description,
+		Suggestion:  suggestion,
+	})
+}
@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// detectHotPaths parses src as filename and returns its hot-path allocation warnings
+func detectHotPaths(t *testing.T, filename, src string) []metrics.PerformanceWarning {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	require.NoError(t, err)
+	return NewAntipatternAnalyzer(fset).DetectHotPathAllocations(file)
+}
+
+func TestDetectHotPathAllocations_StringConcatInLoop(t *testing.T) {
+	warnings := detectHotPaths(t, "join.go", `package sample
+
+func join(parts []string) string {
+	s := ""
+	for _, x := range parts {
+		s += x
+	}
+	return s
+}
+`)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, HotPathStringConcatInLoop, warnings[0].Type)
+	assert.Equal(t, "join", warnings[0].Function)
+	assert.Equal(t, "join.go", warnings[0].File)
+	assert.Equal(t, 6, warnings[0].Line)
+	assert.Equal(t, metrics.SeverityLevelWarning, warnings[0].Severity)
+}
+
+func TestDetectHotPathAllocations_AppendWithoutCapacity(t *testing.T) {
+	warnings := detectHotPaths(t, "collect.go", `package sample
+
+func collect(n int) []int {
+	var s []int
+	for v := 0; v < n; v++ {
+		s = append(s, v)
+	}
+	return s
+}
+
+func collectSized(n int) []int {
+	s := make([]int, 0, n)
+	for v := 0; v < n; v++ {
+		s = append(s, v)
+	}
+	return s
+}
+`)
+
+	require.Len(t, warnings, 1, "the slice made with a capacity is not flagged")
+	assert.Equal(t, HotPathAppendInLoop, warnings[0].Type)
+	assert.Equal(t, "collect", warnings[0].Function)
+	assert.Equal(t, 6, warnings[0].Line)
+}
+
+func TestDetectHotPathAllocations_SprintfAndMapWrites(t *testing.T) {
+	warnings := detectHotPaths(t, "index.go", `package sample
+
+import "fmt"
+
+func index(ids []int) (map[string]int, map[string]int) {
+	byName := make(map[string]int)
+	sized := make(map[string]int, len(ids))
+	for i, id := range ids {
+		key := fmt.Sprintf("id-%d", id)
+		byName[key] = i
+		sized[key] = i
+	}
+	return byName, sized
+}
+`)
+
+	types := make([]string, len(warnings))
+	for i, w := range warnings {
+		types[i] = w.Type
+	}
+	assert.ElementsMatch(t, []string{HotPathSprintfInLoop, HotPathMapWriteInLoop}, types,
+		"only the map created without a size hint is flagged")
+}
+
+func TestDetectHotPathAllocations_OutsideLoopsAndExemptions(t *testing.T) {
+	src := `package sample
+
+import "fmt"
+
+func once(items []int, name string) ([]int, string) {
+	items = append(items, 1)
+	name += "!"
+	run := func() {
+		for i := 0; i < 3; i++ {
+			name = fmt.Sprint(i)
+		}
+	}
+	run()
+	return items, name
+}
+
+//nolint
+func suppressed(n int) (s string) {
+	for i := 0; i < n; i++ {
+		s += "x"
+	}
+	return s
+}
+`
+	assert.Empty(t, detectHotPaths(t, "once.go", src), "statements outside loops, inside function literals, and in suppressed functions are not flagged")
+	assert.Empty(t, detectHotPaths(t, "once_test.go", `package sample
+
+func build(n int) (s string) {
+	for i := 0; i < n; i++ {
+		s += "x"
+	}
+	return s
+}
+`), "test files are skipped")
+}
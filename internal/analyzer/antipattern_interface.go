@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// testDoubleMarkers are name fragments that identify mocks, fakes, stubs, and spies
+var testDoubleMarkers = []string{"mock", "fake", "stub", "spy"}
+
+// CheckInterfacePollution flags single-method interfaces that have exactly one concrete
+// implementer and no test double, which suggests the abstraction is premature. Implementers
+// are matched by method name across the methods in functions, and any implementer declared
+// in a test file or named like a mock, fake, stub, or spy counts as a test double. Interfaces
+// declared in test files or built from embedded interfaces are skipped.
+func CheckInterfacePollution(interfaces []metrics.InterfaceMetrics, functions []metrics.FunctionMetrics) []metrics.PerformanceAntipattern {
+	implementers := collectMethodImplementers(functions)
+
+	var patterns []metrics.PerformanceAntipattern
+	for _, iface := range interfaces {
+		if iface.MethodCount != 1 || len(iface.Methods) != 1 || len(iface.EmbeddedInterfaces) > 0 || isTestFile(iface.File) {
+			continue
+		}
+
+		candidates := implementers[iface.Methods[0].Name]
+		if len(candidates) != 1 || candidates[0].isTestDouble {
+			continue
+		}
+		impl := candidates[0]
+
+		location := fmt.Sprintf("package '%s'", impl.pkg)
+		if impl.pkg == iface.Package {
+			location = "the same package"
+		}
+		patterns = append(patterns, metrics.PerformanceAntipattern{
+			Type: "interface_pollution",
+			Description: fmt.Sprintf("Interface '%s' has a single implementer '%s' in %s and no test double",
+				iface.Name, impl.typeName, location),
+			Severity:   metrics.SeverityLevelInfo,
+			File:       iface.File,
+			Line:       iface.Line,
+			Suggestion: fmt.Sprintf("Depend on '%s' directly until a second implementation or a test double is needed", impl.typeName),
+		})
+	}
+
+	return patterns
+}
+
+// methodImplementer identifies a named type that declares a given method
+type methodImplementer struct {
+	pkg          string
+	typeName     string
+	isTestDouble bool
+}
+
+// collectMethodImplementers maps each method name to the distinct receiver types declaring it
+func collectMethodImplementers(functions []metrics.FunctionMetrics) map[string][]methodImplementer {
+	byMethod := make(map[string]map[string]*methodImplementer)
+	for _, fn := range functions {
+		if !fn.IsMethod || fn.ReceiverType == "" {
+			continue
+		}
+		typeName := strings.TrimPrefix(fn.ReceiverType, "*")
+		key := fn.Package + "." + typeName
+
+		types, ok := byMethod[fn.Name]
+		if !ok {
+			types = make(map[string]*methodImplementer)
+			byMethod[fn.Name] = types
+		}
+		impl, ok := types[key]
+		if !ok {
+			impl = &methodImplementer{pkg: fn.Package, typeName: typeName, isTestDouble: isTestDoubleName(typeName)}
+			types[key] = impl
+		}
+		if fn.IsTestFile || isTestFile(fn.File) {
+			impl.isTestDouble = true
+		}
+	}
+
+	result := make(map[string][]methodImplementer, len(byMethod))
+	for method, types := range byMethod {
+		impls := make([]methodImplementer, 0, len(types))
+		for _, impl := range types {
+			impls = append(impls, *impl)
+		}
+		sort.Slice(impls, func(i, j int) bool {
+			return impls[i].pkg+"."+impls[i].typeName < impls[j].pkg+"."+impls[j].typeName
+		})
+		result[method] = impls
+	}
+	return result
+}
+
+// isTestDoubleName reports whether a type name marks it as a mock, fake, stub, or spy
+func isTestDoubleName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range testDoubleMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
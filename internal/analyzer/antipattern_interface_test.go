@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInterfacePollution(t *testing.T) {
+	const iface = `package main
+type Store interface{ Save(key string) error }
+type diskStore struct{}
+func (d *diskStore) Save(key string) error { return nil }
+`
+
+	tests := []struct {
+		name          string
+		extraCode     string
+		extraFile     string
+		expectPattern int
+		description   string
+	}{
+		{
+			name:          "single implementer without mock",
+			expectPattern: 1,
+			description:   "One concrete implementer and no test double should be flagged",
+		},
+		{
+			name: "second concrete implementer",
+			extraCode: `package main
+type memStore struct{}
+func (m memStore) Save(key string) error { return nil }
+`,
+			extraFile:     "mem.go",
+			expectPattern: 0,
+			description:   "Two implementers justify the interface",
+		},
+		{
+			name: "mock implementer in test file",
+			extraCode: `package main
+type recorder struct{ keys []string }
+func (r *recorder) Save(key string) error { r.keys = append(r.keys, key); return nil }
+`,
+			extraFile:     "store_test.go",
+			expectPattern: 0,
+			description:   "A test double in a _test.go file justifies the interface",
+		},
+		{
+			name: "mock named implementer",
+			extraCode: `package main
+type MockStore struct{}
+func (m *MockStore) Save(key string) error { return nil }
+`,
+			extraFile:     "mocks.go",
+			expectPattern: 0,
+			description:   "A type named like a mock counts as a test double",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			sources := map[string]string{"store.go": iface}
+			if tt.extraFile != "" {
+				sources[tt.extraFile] = tt.extraCode
+			}
+
+			var interfaces []metrics.InterfaceMetrics
+			var functions []metrics.FunctionMetrics
+			for path, code := range sources {
+				file, err := parser.ParseFile(fset, path, code, parser.ParseComments)
+				require.NoError(t, err)
+
+				ifaces, err := NewInterfaceAnalyzer(fset).AnalyzeInterfacesWithPath(file, "main", path)
+				require.NoError(t, err)
+				interfaces = append(interfaces, ifaces...)
+
+				funcs, err := NewFunctionAnalyzer(fset).AnalyzeFunctionsWithPath(file, "main", path)
+				require.NoError(t, err)
+				functions = append(functions, funcs...)
+			}
+
+			count := 0
+			for _, p := range CheckInterfacePollution(interfaces, functions) {
+				if p.Type == "interface_pollution" {
+					assert.Contains(t, p.Description, "same package")
+					count++
+				}
+			}
+			assert.Equal(t, tt.expectPattern, count, tt.description)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckParameterMutation(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		expectPattern int
+		description   string
+	}{
+		{
+			name: "writes into slice parameter",
+			code: `package main
+func Normalize(values []int) {
+	for i := range values {
+		values[i] = values[i] * 2
+	}
+}`,
+			expectPattern: 1,
+			description:   "Element writes to a slice parameter are visible to the caller",
+		},
+		{
+			name: "only reads slice parameter",
+			code: `package main
+func Sum(values []int) int {
+	total := 0
+	for i := range values {
+		total += values[i]
+	}
+	return total
+}`,
+			expectPattern: 0,
+			description:   "Reading a slice parameter has no aliasing side effects",
+		},
+		{
+			name: "writes into map parameter",
+			code: `package main
+func Tag(labels map[string]string) {
+	labels["owner"] = "team"
+	delete(labels, "tmp")
+}`,
+			expectPattern: 1,
+			description:   "Map parameter mutations are reported once per parameter",
+		},
+		{
+			name: "returns append of parameter",
+			code: `package main
+func WithDefault(items []string) []string {
+	return append(items, "default")
+}`,
+			expectPattern: 1,
+			description:   "Appending to a parameter may write into the caller's backing array",
+		},
+		{
+			name: "appends to parameter without the result escaping",
+			code: `package main
+func Count(items []string, extra string) int {
+	items = append(items, extra)
+	all := append(items, "default")
+	return len(all)
+}`,
+			expectPattern: 0,
+			description:   "An append whose result stays in locals neither the caller nor anyone else sees",
+		},
+		{
+			name: "appends to parameter and returns the local",
+			code: `package main
+func WithExtra(items []string, extra string) []string {
+	items = append(items, extra)
+	return items
+}`,
+			expectPattern: 1,
+			description:   "The appended slice is returned through the reassigned parameter",
+		},
+		{
+			name: "stores append of parameter in a field",
+			code: `package main
+type Queue struct{ items []string }
+func (q *Queue) Push(items []string, extra string) {
+	q.items = append(items, extra)
+}`,
+			expectPattern: 1,
+			description:   "The appended slice is stored where it outlives the call",
+		},
+		{
+			name: "appends to reslice of parameter",
+			code: `package main
+func Remove(items []string, i int) int {
+	items = append(items[:i], items[i+1:]...)
+	return len(items)
+}`,
+			expectPattern: 1,
+			description:   "Appending to a reslice overwrites elements of the caller's backing array",
+		},
+		{
+			name: "writes into local copy",
+			code: `package main
+func Doubled(values []int) []int {
+	out := make([]int, len(values))
+	for i, v := range values {
+		out[i] = v * 2
+	}
+	return out
+}`,
+			expectPattern: 0,
+			description:   "Writes to a local copy are not parameter mutations",
+		},
+		{
+			name: "increments array parameter element",
+			code: `package main
+func Bump(counts [4]int) {
+	counts[0]++
+}`,
+			expectPattern: 0,
+			description:   "Arrays are passed by value so mutations are local",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			assert.NoError(t, err, "Failed to parse test code")
+
+			analyzer := NewAntipatternAnalyzer(fset)
+			patterns := analyzer.Analyze(file)
+
+			var found []metrics.PerformanceAntipattern
+			for _, p := range patterns {
+				if p.Type == "parameter_mutation" {
+					found = append(found, p)
+				}
+			}
+
+			assert.Len(t, found, tt.expectPattern, tt.description)
+			for _, p := range found {
+				assert.Equal(t, metrics.SeverityLevelInfo, p.Severity)
+				assert.NotEmpty(t, p.Suggestion)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// DetectNakedReturns flags every bare return statement in functions with named results whose
+// body is longer than maxLines. Naked returns are idiomatic in short functions, but in long ones
+// the reader has to scroll back to the signature to see what is returned. Returns inside
+// function literals belong to the literal and are not counted. A maxLines of zero or less
+// disables the check, and suppressed functions never produce warnings.
+func (a *AntipatternAnalyzer) DetectNakedReturns(file *ast.File, maxLines int) []metrics.AntiPatternWarning {
+	if maxLines <= 0 {
+		return nil
+	}
+
+	var warnings []metrics.AntiPatternWarning
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || !a.hasNamedReturns(fn) || isSuppressed(a.fset, file, fn) {
+			continue
+		}
+		lines := a.countFunctionLines(fn)
+		if lines <= maxLines {
+			continue
+		}
+		for _, ret := range nakedReturns(fn.Body) {
+			pos := a.fset.Position(ret.Pos())
+			warnings = append(warnings, metrics.AntiPatternWarning{
+				Type:     "naked_return",
+				File:     pos.Filename,
+				Line:     pos.Line,
+				Function: fn.Name.Name,
+				Severity: metrics.SeverityLevelWarning,
+				Description: fmt.Sprintf("Naked return in '%s', a %d-line function with named results, over the limit of %d",
+					fn.Name.Name, lines, maxLines),
+				Recommendation: "Return the result values explicitly so the reader does not have to look them up in the signature",
+				ItemName:       fn.Name.Name,
+				Metric:         "body_lines",
+				ActualValue:    float64(lines),
+				Threshold:      float64(maxLines),
+			})
+		}
+	}
+	return warnings
+}
+
+// nakedReturns returns the return statements without result expressions in body, excluding
+// those of nested function literals
+func nakedReturns(body *ast.BlockStmt) []*ast.ReturnStmt {
+	var returns []*ast.ReturnStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 {
+				returns = append(returns, n)
+			}
+		}
+		return true
+	})
+	return returns
+}
+
+// hasNamedReturns checks if function has named return parameters
+func (a *AntipatternAnalyzer) hasNamedReturns(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Type == nil || funcDecl.Type.Results == nil {
+		return false
+	}
+
+	for _, result := range funcDecl.Type.Results.List {
+		// If any result has a name, it's a named return
+		if len(result.Names) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countFunctionLines counts the number of lines in a function body
+func (a *AntipatternAnalyzer) countFunctionLines(funcDecl *ast.FuncDecl) int {
+	if funcDecl.Body == nil {
+		return 0
+	}
+
+	start := a.fset.Position(funcDecl.Body.Lbrace)
+	end := a.fset.Position(funcDecl.Body.Rbrace)
+
+	// Return number of lines between braces (exclusive of braces themselves)
+	return end.Line - start.Line - 1
+}
@@ -0,0 +1,239 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+func TestDetectNakedReturns_LineThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected int
+	}{
+		{
+			name: "short function with naked return - should NOT detect",
+			src: `package main
+func process() (result int, err error) {
+	result = 42
+	return
+}`,
+			expected: 0,
+		},
+		{
+			name: "long function with naked return - should detect",
+			src: `package main
+func process() (result int, err error) {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	d := 7
+	e := 8
+	f := 9
+	result = x + y + z + a + b + c + d + e + f
+	return
+}`,
+			expected: 1,
+		},
+		{
+			name: "long function with explicit return - should NOT detect",
+			src: `package main
+func process() (int, error) {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	d := 7
+	e := 8
+	result := x + y + z + a + b + c + d + e
+	return result, nil
+}`,
+			expected: 0,
+		},
+		{
+			name: "long function without named returns - should NOT detect",
+			src: `package main
+func process() int {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	d := 7
+	e := 8
+	return x + y + z + a + b + c + d + e
+}`,
+			expected: 0,
+		},
+		{
+			name: "exactly 10 lines with naked return - should NOT detect",
+			src: `package main
+func process() (result int, err error) {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	d := 7
+	result = x + y + z + a + b + c + d
+	return
+}`,
+			expected: 0,
+		},
+		{
+			name: "11 lines with naked return - should detect",
+			src: `package main
+func process() (result int, err error) {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	d := 7
+	e := 8
+	f := 9
+	result = x + y + z + a + b + c + d + e + f
+	return
+}`,
+			expected: 1,
+		},
+		{
+			name: "long function with mixed returns - should detect naked",
+			src: `package main
+func process(flag bool) (result int, err error) {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	if flag {
+		return 0, nil
+	}
+	result = x + y + z + a + b + c
+	return
+}`,
+			expected: 1,
+		},
+		{
+			name: "long function with only explicit returns - should NOT detect",
+			src: `package main
+func process(flag bool) (result int, err error) {
+	x := 1
+	y := 2
+	z := 3
+	a := 4
+	b := 5
+	c := 6
+	d := 7
+	e := 8
+	if flag {
+		return 0, nil
+	}
+	result = x + y + z + a + b + c + d + e
+	return result, err
+}`,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.src, 0)
+			require.NoError(t, err)
+
+			analyzer := NewAntipatternAnalyzer(fset)
+			warnings := analyzer.DetectNakedReturns(file, 10)
+
+			for _, w := range warnings {
+				assert.Equal(t, "naked_return", w.Type)
+				assert.Equal(t, metrics.SeverityLevelWarning, w.Severity)
+				assert.Equal(t, "process", w.Function)
+				assert.Contains(t, w.Description, "Naked return in 'process'")
+				assert.Contains(t, w.Recommendation, "explicitly")
+			}
+			assert.Len(t, warnings, tt.expected)
+		})
+	}
+}
+
+// namedResultFunction returns a function with named results whose body is bodyLines long and
+// ends in a naked return
+func namedResultFunction(name string, bodyLines int) string {
+	var src strings.Builder
+	fmt.Fprintf(&src, "func %s(n int) (total int, err error) {\n", name)
+	for i := 0; i < bodyLines-1; i++ {
+		fmt.Fprintf(&src, "\ttotal += n * %d\n", i)
+	}
+	src.WriteString("\treturn\n}\n")
+	return src.String()
+}
+
+func TestDetectNakedReturns_LongAndShortFunctions(t *testing.T) {
+	src := "package sample\n\n" + namedResultFunction("long", 40) + "\n" + namedResultFunction("short", 5)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "sample.go", src, 0)
+	require.NoError(t, err)
+
+	warnings := NewAntipatternAnalyzer(fset).DetectNakedReturns(file, 10)
+
+	require.Len(t, warnings, 1, "only the 40-line function is flagged")
+	w := warnings[0]
+	assert.Equal(t, "long", w.Function)
+	assert.Equal(t, "sample.go", w.File)
+	assert.Equal(t, 43, w.Line, "the warning points at the return statement")
+	assert.Equal(t, float64(40), w.ActualValue)
+	assert.Equal(t, float64(10), w.Threshold)
+}
+
+func TestDetectNakedReturns_EachReturnAndClosures(t *testing.T) {
+	src := `package sample
+
+func parse(s string) (n int, err error) {
+	if s == "" {
+		return
+	}
+	read := func() (v int) {
+		v = len(s)
+		return
+	}
+	n = read()
+	n++
+	n++
+	n++
+	n++
+	n++
+	n++
+	return
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "sample.go", src, 0)
+	require.NoError(t, err)
+	analyzer := NewAntipatternAnalyzer(fset)
+
+	warnings := analyzer.DetectNakedReturns(file, 10)
+	require.Len(t, warnings, 2, "the closure's own naked return is not attributed to parse")
+	assert.Equal(t, 5, warnings[0].Line)
+	assert.Equal(t, 18, warnings[1].Line)
+
+	assert.Empty(t, analyzer.DetectNakedReturns(file, 20), "a higher threshold exempts the function")
+	assert.Empty(t, analyzer.DetectNakedReturns(file, 0), "a zero threshold disables the check")
+}
@@ -5,8 +5,10 @@ import (
 	"go/token"
 	"testing"
 
-	"github.com/opd-ai/go-stats-generator/internal/metrics"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
 func TestCheckUnusedReceiverName(t *testing.T) {
@@ -220,3 +222,118 @@ func (e *Empty) String() string {
 	// (developer can choose to use _ if they want to suppress the warning)
 	assert.Equal(t, 1, unusedReceiverPatterns, "Should flag interface method with unused receiver")
 }
+
+func TestCheckReceiverConsistency(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		expectPattern int
+		description   string
+	}{
+		{
+			name: "mixed receiver kinds",
+			code: `package main
+type Counter struct{ n int }
+func (c *Counter) Inc() { c.n++ }
+func (c Counter) Reset(to int) { _ = to }
+`,
+			expectPattern: 1,
+			description:   "Pointer and non-getter value receivers should be flagged",
+		},
+		{
+			name: "consistently pointer",
+			code: `package main
+type Counter struct{ n int }
+func (c *Counter) Inc() { c.n++ }
+func (c *Counter) Value() int { return c.n }
+`,
+			expectPattern: 0,
+			description:   "All pointer receivers is consistent",
+		},
+		{
+			name: "consistently value",
+			code: `package main
+type Point struct{ X, Y int }
+func (p Point) Add(o Point) Point { return Point{p.X + o.X, p.Y + o.Y} }
+func (p Point) Scale(k int) Point { return Point{p.X * k, p.Y * k} }
+`,
+			expectPattern: 0,
+			description:   "All value receivers is consistent",
+		},
+		{
+			name: "value getter on pointer type",
+			code: `package main
+type Counter struct{ n int }
+func (c *Counter) Inc() { c.n++ }
+func (c Counter) Value() int { return c.n }
+func (c Counter) String() string { return "" }
+`,
+			expectPattern: 0,
+			description:   "Value-receiver getters are exempt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			require.NoError(t, err)
+
+			structs, err := NewStructAnalyzer(fset).AnalyzeStructsWithPath(file, "main", "test.go")
+			require.NoError(t, err)
+
+			count := 0
+			for _, p := range CheckReceiverConsistency(AnalyzeReceiverConsistency(structs)) {
+				if p.Type == "inconsistent_receiver" {
+					count++
+				}
+			}
+			assert.Equal(t, tt.expectPattern, count, tt.description)
+		})
+	}
+}
+
+const userReceiverSource = `package users
+
+type User struct {
+	Name  string
+	Email string
+}
+
+func (u User) Greeting(prefix string) string { return prefix + u.Name }
+
+func (u User) Display() string { return u.Name }
+`
+
+const userPointerMethodsSource = `package users
+
+func (u *User) Rename(name string) { u.Name = name }
+
+func (u *User) SetEmail(email string) { u.Email = email }
+`
+
+func TestAnalyzeReceiverConsistency_MixedUserAcrossFiles(t *testing.T) {
+	typeFile, structs := parseBurdenFile(t, "users/user.go", userReceiverSource)
+	methodsFile, _ := parseBurdenFile(t, "users/user_methods.go", userPointerMethodsSource)
+	require.Empty(t, AnalyzeReceiverConsistency(structs), "the declaring file alone only has value receivers")
+
+	AttachCrossFileMethods(structs, []BurdenFileInfo{typeFile, methodsFile}, DefaultStructComplexityWeights())
+	mixed := AnalyzeReceiverConsistency(structs)
+
+	require.Len(t, mixed, 1)
+	user := mixed[0]
+	assert.Equal(t, "User", user.Type)
+	assert.Equal(t, "users", user.Package)
+	assert.Equal(t, "users/user.go", user.File)
+	assert.Equal(t, 2, user.PointerReceivers)
+	assert.Equal(t, 1, user.ValueReceivers)
+	assert.Equal(t, []string{"Rename", "SetEmail"}, user.PointerMethods)
+	assert.Equal(t, []string{"Greeting"}, user.ValueMethods)
+	assert.Equal(t, []string{"Display"}, user.ValueGetters, "getters are listed but not counted")
+
+	patterns := CheckReceiverConsistency(mixed)
+	require.Len(t, patterns, 1)
+	assert.Equal(t, "inconsistent_receiver", patterns[0].Type)
+	assert.Equal(t, metrics.SeverityLevelInfo, patterns[0].Severity, "receiver mixing is informational")
+	assert.Equal(t, "Type 'User' mixes 2 pointer receivers (Rename, SetEmail) and 1 value receivers (Greeting)", patterns[0].Description)
+}
@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// StructuralThresholds are the limits used by CheckStructuralAntiPatterns
+type StructuralThresholds struct {
+	// MaxStructMembers is the most fields plus methods a struct may have before it is a god object,
+	// counting those promoted by embedded types
+	MaxStructMembers int
+	// MaxFunctionLength is the most code lines a function may have before it is a long method
+	MaxFunctionLength int
+	// MaxNesting is the deepest block nesting a function may have before it is deeply nested
+	MaxNesting int
+	// MaxParameters is the most parameters a function may take before it has a long parameter list
+	MaxParameters int
+	// ExemptConstructors skips the parameter lists of New* constructor functions
+	ExemptConstructors bool
+}
+
+// StructuralAntiPatterns holds the god object, long method, deep nesting, and long parameter
+// list warnings for a report
+type StructuralAntiPatterns struct {
+	GodObjects         []metrics.AntiPatternWarning
+	LongMethods        []metrics.AntiPatternWarning
+	DeepNesting        []metrics.AntiPatternWarning
+	LongParameterLists []metrics.AntiPatternWarning
+}
+
+// CheckStructuralAntiPatterns flags god objects and long methods from the already analyzed
+// structs and functions. Deeply nested functions and long parameter lists are taken from the
+// burden analysis, which detects them with the same thresholds, so both sections list the same
+// functions. Severity grows with how far the measured value is over its threshold.
+// Declarations in test files and non-positive thresholds are skipped.
+func CheckStructuralAntiPatterns(structs []metrics.StructMetrics, functions []metrics.FunctionMetrics, burden metrics.BurdenMetrics, thresholds StructuralThresholds) StructuralAntiPatterns {
+	result := StructuralAntiPatterns{
+		GodObjects:         []metrics.AntiPatternWarning{},
+		LongMethods:        []metrics.AntiPatternWarning{},
+		DeepNesting:        []metrics.AntiPatternWarning{},
+		LongParameterLists: []metrics.AntiPatternWarning{},
+	}
+
+	for _, s := range structs {
+		members := s.TotalFields + len(s.Methods) + s.PromotedFields + s.PromotedMethods
+		if s.IsTestFile || thresholds.MaxStructMembers <= 0 || members <= thresholds.MaxStructMembers {
+			continue
+		}
+		result.GodObjects = append(result.GodObjects, newStructuralWarning("god_object", s.File, s.Line, "",
+			s.Name, "members", members, thresholds.MaxStructMembers,
+			fmt.Sprintf("Struct '%s' has %d members (%d fields, %d methods, %d promoted), over the limit of %d",
+				s.Name, members, s.TotalFields, len(s.Methods), s.PromotedFields+s.PromotedMethods, thresholds.MaxStructMembers),
+			"Split the struct into smaller types that each own one responsibility"))
+	}
+
+	for _, fn := range functions {
+		if fn.IsTestFile || thresholds.MaxFunctionLength <= 0 || fn.Lines.Code <= thresholds.MaxFunctionLength {
+			continue
+		}
+		result.LongMethods = append(result.LongMethods, newStructuralWarning("long_method", fn.File, fn.Line, fn.Name,
+			fn.Name, "code_lines", fn.Lines.Code, thresholds.MaxFunctionLength,
+			fmt.Sprintf("Function '%s' has %d lines of code, over the limit of %d", fn.Name, fn.Lines.Code, thresholds.MaxFunctionLength),
+			"Extract cohesive blocks of the function into well-named helpers"))
+	}
+
+	for _, n := range burden.DeeplyNestedFunctions {
+		if isTestFile(n.File) || thresholds.MaxNesting <= 0 || n.MaxDepth <= thresholds.MaxNesting {
+			continue
+		}
+		result.DeepNesting = append(result.DeepNesting, newStructuralWarning("deep_nesting", n.File, n.Line, n.Function,
+			n.Function, "nesting_depth", n.MaxDepth, thresholds.MaxNesting,
+			fmt.Sprintf("Function '%s' nests blocks %d levels deep, over the limit of %d", n.Function, n.MaxDepth, thresholds.MaxNesting),
+			"Use guard clauses and early returns, or extract nested blocks into helpers"))
+	}
+
+	for _, sig := range burden.ComplexSignatures {
+		params := sig.ParameterCount
+		if isTestFile(sig.File) || thresholds.MaxParameters <= 0 || params <= thresholds.MaxParameters ||
+			(thresholds.ExemptConstructors && isConstructorName(sig.Function, sig.IsMethod)) {
+			continue
+		}
+		result.LongParameterLists = append(result.LongParameterLists, newStructuralWarning("long_parameter_list", sig.File, sig.Line, sig.Function,
+			sig.Function, "parameters", params, thresholds.MaxParameters,
+			fmt.Sprintf("Function '%s' takes %d parameters, over the limit of %d", sig.Function, params, thresholds.MaxParameters),
+			"Group related parameters into an options struct, or use functional options for the optional ones"))
+	}
+
+	return result
+}
+
+// isConstructorName reports whether a function, not a method, is named New or New followed by
+// an upper-case letter, such as NewServer
+func isConstructorName(name string, isMethod bool) bool {
+	if isMethod {
+		return false
+	}
+	rest, ok := strings.CutPrefix(name, "New")
+	return ok && (rest == "" || unicode.IsUpper(rune(rest[0])))
+}
+
+// newStructuralWarning builds an anti-pattern warning whose severity reflects how far actual
+// exceeds threshold
+func newStructuralWarning(typ, file string, line int, function, item, metric string, actual, threshold int, description, recommendation string) metrics.AntiPatternWarning {
+	return metrics.AntiPatternWarning{
+		Type:           typ,
+		File:           file,
+		Line:           line,
+		Function:       function,
+		Severity:       overThresholdSeverity(actual, threshold),
+		Description:    description,
+		Recommendation: recommendation,
+		ItemName:       item,
+		Metric:         metric,
+		ActualValue:    float64(actual),
+		Threshold:      float64(threshold),
+	}
+}
+
+// overThresholdSeverity maps the ratio of actual to threshold onto a severity: up to 1.5x is
+// info, up to 2x is a warning, and anything beyond is a violation
+func overThresholdSeverity(actual, threshold int) metrics.SeverityLevel {
+	ratio := float64(actual) / float64(threshold)
+	switch {
+	case ratio > 2:
+		return metrics.SeverityLevelViolation
+	case ratio > 1.5:
+		return metrics.SeverityLevelWarning
+	default:
+		return metrics.SeverityLevelInfo
+	}
+}
@@ -0,0 +1,214 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// structuralSource builds a file with a 40-field struct, a 6-level-nested function, and a long function
+func structuralSource() string {
+	var src strings.Builder
+	src.WriteString("package p\n\ntype Everything struct {\n")
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&src, "\tField%d int\n", i)
+	}
+	src.WriteString("}\n\ntype Small struct {\n\tName string\n}\n")
+
+	src.WriteString(`
+func nested(grid [][][]int) int {
+	total := 0
+	for _, plane := range grid {
+		for _, row := range plane {
+			for _, v := range row {
+				if v > 0 {
+					if v%2 == 0 {
+						if v < 100 {
+							total += v
+						}
+					}
+				}
+			}
+		}
+	}
+	return total
+}
+`)
+
+	src.WriteString("\nfunc long() int {\n\tx := 0\n")
+	for i := 0; i < 40; i++ {
+		src.WriteString("\tx++\n")
+	}
+	src.WriteString("\treturn x\n}\n")
+	return src.String()
+}
+
+// burdenIssues runs the signature and nesting detectors over every function of files with
+// thresholds of zero, so that CheckStructuralAntiPatterns applies the real limits
+func burdenIssues(files []BurdenFileInfo) metrics.BurdenMetrics {
+	var burden metrics.BurdenMetrics
+	for _, info := range files {
+		ba := NewBurdenAnalyzer(info.Fset)
+		for _, decl := range info.File.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if sig := ba.AnalyzeSignatureComplexity(fn, 0, 100); sig != nil {
+				burden.ComplexSignatures = append(burden.ComplexSignatures, *sig)
+			}
+			if nesting := ba.DetectDeepNesting(fn, 0); nesting != nil {
+				burden.DeeplyNestedFunctions = append(burden.DeeplyNestedFunctions, *nesting)
+			}
+		}
+	}
+	return burden
+}
+
+func TestCheckStructuralAntiPatterns(t *testing.T) {
+	// Line counts are read from disk, so the source must exist as a file
+	path := filepath.Join(t.TempDir(), "p.go")
+	require.NoError(t, os.WriteFile(path, []byte(structuralSource()), 0o644))
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	require.NoError(t, err)
+	structs, err := NewStructAnalyzer(fset).AnalyzeStructs(file, "p")
+	require.NoError(t, err)
+	functions, err := NewFunctionAnalyzer(fset).AnalyzeFunctions(file, "p")
+	require.NoError(t, err)
+
+	burden := burdenIssues([]BurdenFileInfo{{File: file, Fset: fset, Pkg: "p"}})
+
+	result := CheckStructuralAntiPatterns(structs, functions, burden, StructuralThresholds{
+		MaxStructMembers:  30,
+		MaxFunctionLength: 30,
+		MaxNesting:        4,
+	})
+
+	require.Len(t, result.GodObjects, 1)
+	god := result.GodObjects[0]
+	assert.Equal(t, "god_object", god.Type)
+	assert.Equal(t, "Everything", god.ItemName)
+	assert.Equal(t, 40.0, god.ActualValue)
+	assert.Equal(t, 30.0, god.Threshold)
+	assert.Equal(t, metrics.SeverityLevelInfo, god.Severity)
+
+	require.Len(t, result.DeepNesting, 1)
+	deep := result.DeepNesting[0]
+	assert.Equal(t, "deep_nesting", deep.Type)
+	assert.Equal(t, "nested", deep.Function)
+	assert.Equal(t, 6.0, deep.ActualValue)
+	assert.Equal(t, metrics.SeverityLevelInfo, deep.Severity)
+
+	require.Len(t, result.LongMethods, 1)
+	long := result.LongMethods[0]
+	assert.Equal(t, "long_method", long.Type)
+	assert.Equal(t, "long", long.Function)
+	assert.Equal(t, 42.0, long.ActualValue)
+}
+
+func TestCheckStructuralAntiPatterns_SeverityScalesWithExcess(t *testing.T) {
+	structs := []metrics.StructMetrics{
+		{Name: "Slight", TotalFields: 12},
+		{Name: "Large", TotalFields: 18},
+		{Name: "Huge", TotalFields: 40, Methods: []metrics.MethodInfo{{Name: "Do"}}},
+		{Name: "Fixture", TotalFields: 40, IsTestFile: true},
+	}
+	burden := metrics.BurdenMetrics{DeeplyNestedFunctions: []metrics.NestingIssue{
+		{Function: "deep", File: "p.go", MaxDepth: 6},
+		{Function: "deeper", File: "p.go", MaxDepth: 9},
+		{Function: "TestDeep", File: "p_test.go", MaxDepth: 9},
+	}}
+
+	result := CheckStructuralAntiPatterns(structs, nil, burden, StructuralThresholds{MaxStructMembers: 10, MaxNesting: 4})
+
+	require.Len(t, result.GodObjects, 3)
+	assert.Equal(t, metrics.SeverityLevelInfo, result.GodObjects[0].Severity)
+	assert.Equal(t, metrics.SeverityLevelWarning, result.GodObjects[1].Severity)
+	assert.Equal(t, metrics.SeverityLevelViolation, result.GodObjects[2].Severity)
+	assert.Equal(t, 41.0, result.GodObjects[2].ActualValue)
+
+	require.Len(t, result.DeepNesting, 2, "test files are skipped")
+	assert.Equal(t, metrics.SeverityLevelInfo, result.DeepNesting[0].Severity)
+	assert.Equal(t, metrics.SeverityLevelViolation, result.DeepNesting[1].Severity)
+
+	assert.Empty(t, result.LongMethods, "a zero length threshold disables long method detection")
+}
+
+func TestCheckStructuralAntiPatterns_CountsPromotedMembers(t *testing.T) {
+	structs := []metrics.StructMetrics{{
+		Name:            "Wrapper",
+		TotalFields:     4,
+		Methods:         make([]metrics.MethodInfo, 2),
+		PromotedFields:  3,
+		PromotedMethods: 2,
+	}}
+
+	result := CheckStructuralAntiPatterns(structs, nil, metrics.BurdenMetrics{}, StructuralThresholds{MaxStructMembers: 8})
+
+	require.Len(t, result.GodObjects, 1)
+	assert.Equal(t, 11.0, result.GodObjects[0].ActualValue)
+	assert.Contains(t, result.GodObjects[0].Description, "5 promoted")
+}
+
+// parameterListSource declares functions of 7, 3, and 8 parameters, the last a constructor
+const parameterListSource = `package main
+
+type Server struct{}
+
+func Render(name, title, body string, width, height int, escape bool, theme string) string { return "" }
+
+func Join(a, b string, sep byte) string { return a + b }
+
+func NewServer(host string, port int, tls bool, cert, key string, timeout, retries, workers int) *Server {
+	return &Server{}
+}
+
+func (s *Server) NewListener(a, b, c, d, e, f int) {}
+`
+
+func TestCheckStructuralAntiPatterns_LongParameterLists(t *testing.T) {
+	_, _, files := analyzeInterfaceSources(t, map[string]string{"params.go": parameterListSource})
+	burden := burdenIssues(files)
+	names := func(warnings []metrics.AntiPatternWarning) []string {
+		var flagged []string
+		for _, w := range warnings {
+			flagged = append(flagged, w.Function)
+		}
+		return flagged
+	}
+
+	result := CheckStructuralAntiPatterns(nil, nil, burden, StructuralThresholds{MaxParameters: 5, ExemptConstructors: true})
+
+	assert.Equal(t, []string{"Render", "NewListener"}, names(result.LongParameterLists),
+		"Join has 3 parameters, and NewServer is an exempt constructor while the method is not")
+	render := result.LongParameterLists[0]
+	assert.Equal(t, "long_parameter_list", render.Type)
+	assert.Equal(t, "parameters", render.Metric)
+	assert.Equal(t, 7.0, render.ActualValue, "grouped parameters count once per name")
+	assert.Equal(t, 5.0, render.Threshold)
+	assert.Equal(t, metrics.SeverityLevelInfo, render.Severity)
+	assert.Contains(t, render.Recommendation, "options struct")
+
+	result = CheckStructuralAntiPatterns(nil, nil, burden, StructuralThresholds{MaxParameters: 5})
+	assert.Equal(t, []string{"Render", "NewServer", "NewListener"}, names(result.LongParameterLists))
+
+	result = CheckStructuralAntiPatterns(nil, nil, burden, StructuralThresholds{MaxParameters: 7, ExemptConstructors: true})
+	assert.Empty(t, result.LongParameterLists, "a raised threshold accepts 7 parameters")
+
+	result = CheckStructuralAntiPatterns(nil, nil, burden, StructuralThresholds{MaxParameters: 2, ExemptConstructors: true})
+	assert.Equal(t, []string{"Render", "Join", "NewListener"}, names(result.LongParameterLists))
+	assert.Equal(t, metrics.SeverityLevelViolation, result.LongParameterLists[0].Severity, "7 is more than twice the threshold")
+
+	assert.Empty(t, CheckStructuralAntiPatterns(nil, nil, burden, StructuralThresholds{}).LongParameterLists,
+		"a zero threshold disables the check")
+}
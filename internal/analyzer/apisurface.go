@@ -0,0 +1,186 @@
+package analyzer
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// apiPrinter renders declaration signatures the way gofmt lays them out
+var apiPrinter = printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+
+// APIAnalyzer extracts the exported API surface of Go source files. Signatures are printed
+// without source positions, so they do not depend on the layout or comments of the file.
+type APIAnalyzer struct{}
+
+// NewAPIAnalyzer creates an analyzer that lists the exported declarations of a file with their
+// signatures, for reviewing a library's public API and diffing it across versions.
+func NewAPIAnalyzer() *APIAnalyzer {
+	return &APIAnalyzer{}
+}
+
+// ExtractAPI returns the exported functions, methods on exported types, types, interfaces,
+// constants, and variables declared in file. Bodies, comments, receiver names, and unexported
+// struct fields and interface methods are omitted from the signatures. Files of package main
+// have no importable API and yield no symbols.
+func (aa *APIAnalyzer) ExtractAPI(file *ast.File, pkgPath string) []metrics.APISymbol {
+	if file.Name.Name == "main" {
+		return nil
+	}
+
+	var symbols []metrics.APISymbol
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if symbol, ok := aa.funcSymbol(d, pkgPath); ok {
+				symbols = append(symbols, symbol)
+			}
+		case *ast.GenDecl:
+			symbols = append(symbols, aa.genDeclSymbols(d, pkgPath)...)
+		}
+	}
+	return symbols
+}
+
+// funcSymbol describes an exported function, or an exported method of an exported type
+func (aa *APIAnalyzer) funcSymbol(fn *ast.FuncDecl, pkgPath string) (metrics.APISymbol, bool) {
+	if !fn.Name.IsExported() {
+		return metrics.APISymbol{}, false
+	}
+
+	signature := &ast.FuncDecl{Name: fn.Name, Type: fn.Type}
+	symbol := metrics.APISymbol{Package: pkgPath, Kind: metrics.APISymbolFunc, Name: fn.Name.Name}
+	if IsMethod(fn) {
+		receiverType := GetMethodReceiverType(fn)
+		if !ast.IsExported(receiverType) {
+			return metrics.APISymbol{}, false
+		}
+		signature.Recv = &ast.FieldList{List: []*ast.Field{{Type: fn.Recv.List[0].Type}}}
+		symbol.Kind = metrics.APISymbolMethod
+		symbol.Name = receiverType + "." + fn.Name.Name
+	}
+
+	symbol.Signature = aa.render(signature)
+	return symbol, true
+}
+
+// genDeclSymbols describes the exported types, constants, and variables of a declaration.
+// Constants without a type or value repeat the previous spec's, as in an iota sequence.
+func (aa *APIAnalyzer) genDeclSymbols(decl *ast.GenDecl, pkgPath string) []metrics.APISymbol {
+	var symbols []metrics.APISymbol
+	var previous *ast.ValueSpec
+	for _, spec := range decl.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			if s.Name.IsExported() {
+				symbols = append(symbols, aa.typeSymbol(s, pkgPath))
+			}
+		case *ast.ValueSpec:
+			if decl.Tok == token.CONST && s.Type == nil && len(s.Values) == 0 && previous != nil {
+				s = &ast.ValueSpec{Names: s.Names, Type: previous.Type, Values: previous.Values}
+			}
+			previous = s
+			symbols = append(symbols, aa.valueSymbols(decl.Tok, s, pkgPath)...)
+		}
+	}
+	return symbols
+}
+
+// typeSymbol describes an exported type declaration
+func (aa *APIAnalyzer) typeSymbol(spec *ast.TypeSpec, pkgPath string) metrics.APISymbol {
+	kind := metrics.APISymbolType
+	exported := *spec
+	exported.Doc, exported.Comment = nil, nil
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		exported.Type = &ast.StructType{Struct: t.Struct, Fields: exportedFields(t.Fields)}
+	case *ast.InterfaceType:
+		kind = metrics.APISymbolInterface
+		exported.Type = &ast.InterfaceType{Interface: t.Interface, Methods: exportedFields(t.Methods)}
+	}
+
+	return metrics.APISymbol{
+		Package:   pkgPath,
+		Kind:      kind,
+		Name:      spec.Name.Name,
+		Signature: aa.render(&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{&exported}}),
+	}
+}
+
+// valueSymbols describes each exported name of a const or var spec. Constants keep their
+// value because it is part of the contract; variables keep only their declared type.
+func (aa *APIAnalyzer) valueSymbols(tok token.Token, spec *ast.ValueSpec, pkgPath string) []metrics.APISymbol {
+	kind := metrics.APISymbolVar
+	if tok == token.CONST {
+		kind = metrics.APISymbolConst
+	}
+
+	var symbols []metrics.APISymbol
+	for i, name := range spec.Names {
+		if !name.IsExported() {
+			continue
+		}
+		single := &ast.ValueSpec{Names: []*ast.Ident{name}, Type: spec.Type}
+		if len(spec.Values) == len(spec.Names) && (kind == metrics.APISymbolConst || spec.Type == nil) {
+			single.Values = []ast.Expr{spec.Values[i]}
+		}
+		symbols = append(symbols, metrics.APISymbol{
+			Package:   pkgPath,
+			Kind:      kind,
+			Name:      name.Name,
+			Signature: aa.render(&ast.GenDecl{Tok: tok, Specs: []ast.Spec{single}}),
+		})
+	}
+	return symbols
+}
+
+// exportedFields keeps the exported names of a struct field or interface method list, along
+// with embedded exported types and embedded interface constraints
+func exportedFields(fields *ast.FieldList) *ast.FieldList {
+	filtered := &ast.FieldList{Opening: fields.Opening, Closing: fields.Closing}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			if name := ExtractReceiverType(embeddedTypeExpr(field.Type)); name == "" || ast.IsExported(name) {
+				filtered.List = append(filtered.List, &ast.Field{Type: field.Type, Tag: field.Tag})
+			}
+			continue
+		}
+
+		var names []*ast.Ident
+		for _, name := range field.Names {
+			if name.IsExported() {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			filtered.List = append(filtered.List, &ast.Field{Names: names, Type: field.Type, Tag: field.Tag})
+		}
+	}
+	return filtered
+}
+
+// embeddedTypeExpr strips a package qualifier so the embedded type's own name is checked
+func embeddedTypeExpr(expr ast.Expr) ast.Expr {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		return sel.Sel
+	}
+	if star, ok := expr.(*ast.StarExpr); ok {
+		if sel, ok := star.X.(*ast.SelectorExpr); ok {
+			return sel.Sel
+		}
+	}
+	return expr
+}
+
+// render prints a declaration node as source text, using the empty normFset so line breaks
+// and blank lines of the original source are not reproduced
+func (aa *APIAnalyzer) render(node ast.Node) string {
+	var buf bytes.Buffer
+	if err := apiPrinter.Fprint(&buf, normFset, node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"path"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
@@ -31,108 +33,15 @@ func (ba *BurdenAnalyzer) FileSet() *token.FileSet {
 }
 
 // DetectMagicNumbers identifies numeric and string literals that lack meaningful names,
-// making code harder to understand and maintain. It excludes benign values (0, 1, -1, empty strings)
-// and constants declared in const blocks. Magic numbers increase cognitive burden and risk of bugs
-// when values need to change. Returns a list of detected magic numbers with file locations.
+// making code harder to understand and maintain. It excludes benign values (0, 1, -1, common
+// sizes and powers of two, empty strings) and constants declared in const blocks. Magic numbers
+// increase cognitive burden and risk of bugs when values need to change. Returns a list of
+// detected magic numbers with file locations; see DetectMagicNumbersWithOptions for the rules.
 func (ba *BurdenAnalyzer) DetectMagicNumbers(file *ast.File, pkg string) []metrics.MagicNumber {
-	var magicNumbers []metrics.MagicNumber
-	var currentFunc string
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.FuncDecl:
-			if node.Name != nil {
-				currentFunc = node.Name.Name
-			}
-			return true
-
-		case *ast.GenDecl:
-			// Skip const declarations - these are intentional constants
-			if node.Tok == token.CONST {
-				return false
-			}
-			return true
-
-		case *ast.BasicLit:
-			if mn := ba.checkBasicLit(node, file, currentFunc); mn != nil {
-				magicNumbers = append(magicNumbers, *mn)
-			}
-			return true
-		}
-		return true
+	return ba.DetectMagicNumbersWithOptions(file, pkg, MagicNumberOptions{
+		Allowed:      []string{"0", "1", "-1"},
+		IgnoreBenign: true,
 	})
-
-	return magicNumbers
-}
-
-// checkBasicLit examines a basic literal for magic number patterns
-func (ba *BurdenAnalyzer) checkBasicLit(lit *ast.BasicLit, file *ast.File, fn string) *metrics.MagicNumber {
-	// Check numeric literals
-	if lit.Kind == token.INT || lit.Kind == token.FLOAT {
-		if ba.isBenignNumber(lit.Value) {
-			return nil
-		}
-		return ba.createMagicNumber(lit, file, fn, "numeric")
-	}
-
-	// Check string literals
-	if lit.Kind == token.STRING {
-		// Exclude empty strings
-		if lit.Value == `""` || lit.Value == "``" {
-			return nil
-		}
-		return ba.createMagicNumber(lit, file, fn, "string")
-	}
-
-	return nil
-}
-
-// isBenignNumber checks if a numeric value is a common constant that should not be flagged
-func (ba *BurdenAnalyzer) isBenignNumber(value string) bool {
-	// Common benign values that shouldn't be flagged
-	benign := map[string]bool{
-		"0":    true,
-		"1":    true,
-		"2":    true,
-		"-1":   true,
-		"0.0":  true,
-		"1.0":  true,
-		"0.5":  true,
-		"2.0":  true,
-		"10":   true,
-		"100":  true,
-		"1000": true,
-		"8":    true,
-		"16":   true,
-		"32":   true,
-		"64":   true,
-		"128":  true,
-		"256":  true,
-		"512":  true,
-		"1024": true,
-		"0x00": true,
-		"0xff": true,
-		"0xFF": true,
-	}
-	return benign[value]
-}
-
-// createMagicNumber constructs a MagicNumber metric from a literal and its context
-func (ba *BurdenAnalyzer) createMagicNumber(lit *ast.BasicLit, file *ast.File, fn, typ string) *metrics.MagicNumber {
-	pos := ba.fset.Position(lit.Pos())
-	severity, suggestion := ba.getMagicNumberSeverityAndSuggestion(typ, lit.Value)
-
-	return &metrics.MagicNumber{
-		File:       pos.Filename,
-		Line:       pos.Line,
-		Column:     pos.Column,
-		Value:      lit.Value,
-		Type:       typ,
-		Context:    ba.extractContext(lit, file),
-		Function:   fn,
-		Severity:   severity,
-		Suggestion: suggestion,
-	}
 }
 
 // getMagicNumberSeverityAndSuggestion determines severity and suggestion for magic numbers
@@ -243,6 +152,9 @@ type BurdenFileInfo struct {
 	// It is used to determine which entry points are always-live (init everywhere;
 	// main only when Pkg == "main").
 	Pkg string
+	// RelPath is the file path reported by package-scope pattern detection; when empty the
+	// FileSet's file name is used.
+	RelPath string
 }
 
 // callGraphNode returns the disambiguated call-graph node key for a function declaration.
@@ -782,6 +694,7 @@ func (ba *BurdenAnalyzer) AnalyzeSignatureComplexity(fn *ast.FuncDecl, maxParams
 		Line:           pos.Line,
 		ParameterCount: paramCount,
 		ReturnCount:    returnCount,
+		IsMethod:       fn.Recv != nil,
 		BoolParams:     boolParams,
 		Severity:       severity,
 		Suggestion:     suggestion,
@@ -1013,6 +926,164 @@ func (ba *BurdenAnalyzer) updateMaxDepth(newDepth int, pos token.Pos, maxDepth *
 	}
 }
 
+// DetectComplexTypeExpressions flags parameter and return types whose syntactic nesting of maps,
+// slices, arrays, pointers, channels, and function types exceeds maxDepth. Signatures such as
+// map[string]map[string][]*T usually hide a missing named type; naming it documents intent and
+// shortens every signature that uses it. Returns one issue per offending parameter or result.
+func (ba *BurdenAnalyzer) DetectComplexTypeExpressions(fn *ast.FuncDecl, maxDepth int) []metrics.TypeDepthIssue {
+	if fn == nil || fn.Type == nil {
+		return nil
+	}
+
+	var issues []metrics.TypeDepthIssue
+	issues = append(issues, ba.checkFieldListTypeDepth(fn, fn.Type.Params, "parameter", maxDepth)...)
+	issues = append(issues, ba.checkFieldListTypeDepth(fn, fn.Type.Results, "return", maxDepth)...)
+	return issues
+}
+
+// checkFieldListTypeDepth reports fields of list whose type expression is nested deeper than maxDepth
+func (ba *BurdenAnalyzer) checkFieldListTypeDepth(fn *ast.FuncDecl, list *ast.FieldList, position string, maxDepth int) []metrics.TypeDepthIssue {
+	if list == nil {
+		return nil
+	}
+
+	var issues []metrics.TypeDepthIssue
+	for _, field := range list.List {
+		depth := TypeExprDepth(field.Type)
+		if depth <= maxDepth {
+			continue
+		}
+
+		name := ""
+		if len(field.Names) > 0 {
+			name = field.Names[0].Name
+		}
+
+		severity := metrics.SeverityLevelWarning
+		if depth > maxDepth+2 {
+			severity = metrics.SeverityLevelViolation
+		}
+
+		pos := ba.fset.Position(field.Pos())
+		issues = append(issues, metrics.TypeDepthIssue{
+			Function:   fn.Name.Name,
+			File:       pos.Filename,
+			Line:       pos.Line,
+			Position:   position,
+			Name:       name,
+			TypeExpr:   types.ExprString(field.Type),
+			Depth:      depth,
+			Severity:   severity,
+			Suggestion: fmt.Sprintf("Type nests %d levels deep. Consider declaring a named type for it or its inner components", depth),
+		})
+	}
+	return issues
+}
+
+// DetectLongMethodChains flags chained method calls such as a.B().C().D().E() whose length
+// exceeds maxDepth. Each reach through a returned object couples the caller to another type's
+// structure (Law of Demeter), so long chains break when any intermediate type changes. Calls
+// whose method name matches one of the exclusion globs (fluent builders, error wrapping) do not
+// count toward the depth. Only the outermost call of each chain is reported.
+func (ba *BurdenAnalyzer) DetectLongMethodChains(fn *ast.FuncDecl, maxDepth int, exclusions []string) []metrics.MethodChainIssue {
+	if fn == nil || fn.Body == nil || maxDepth <= 0 {
+		return nil
+	}
+
+	var issues []metrics.MethodChainIssue
+	inChain := make(map[*ast.CallExpr]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || inChain[call] {
+			return true
+		}
+
+		depth := ba.methodChainDepth(call, exclusions, inChain)
+		if depth <= maxDepth {
+			return true
+		}
+
+		pos := ba.fset.Position(call.Pos())
+		issues = append(issues, metrics.MethodChainIssue{
+			Function:   fn.Name.Name,
+			File:       pos.Filename,
+			Line:       pos.Line,
+			Chain:      types.ExprString(call),
+			Depth:      depth,
+			Severity:   metrics.SeverityLevelInfo,
+			Suggestion: fmt.Sprintf("Chain reaches through %d calls. Consider asking the first object for what you need directly", depth),
+		})
+		return true
+	})
+	return issues
+}
+
+// methodChainDepth counts the non-excluded method calls linked through call's receiver
+// expressions and marks every call in the chain so nested links are not reported again
+func (ba *BurdenAnalyzer) methodChainDepth(call *ast.CallExpr, exclusions []string, inChain map[*ast.CallExpr]bool) int {
+	depth := 0
+	for call != nil {
+		inChain[call] = true
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		if !matchesAnyGlob(sel.Sel.Name, exclusions) {
+			depth++
+		}
+		call, _ = ast.Unparen(sel.X).(*ast.CallExpr)
+	}
+	return depth
+}
+
+// matchesAnyGlob reports whether name matches one of the path.Match style patterns
+func matchesAnyGlob(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeExprDepth returns the nesting depth of a type expression. Named types contribute 0 and each
+// map, slice, array, pointer, channel, variadic, or function type adds one level on top of its
+// deepest component. Inline struct and interface types count as a single level.
+func TypeExprDepth(expr ast.Expr) int {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return 1 + TypeExprDepth(t.X)
+	case *ast.ArrayType:
+		return 1 + TypeExprDepth(t.Elt)
+	case *ast.Ellipsis:
+		return 1 + TypeExprDepth(t.Elt)
+	case *ast.ChanType:
+		return 1 + TypeExprDepth(t.Value)
+	case *ast.MapType:
+		return 1 + max(TypeExprDepth(t.Key), TypeExprDepth(t.Value))
+	case *ast.FuncType:
+		return 1 + max(fieldListTypeDepth(t.Params), fieldListTypeDepth(t.Results))
+	case *ast.ParenExpr:
+		return TypeExprDepth(t.X)
+	case *ast.StructType, *ast.InterfaceType:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// fieldListTypeDepth returns the deepest type expression in a field list
+func fieldListTypeDepth(list *ast.FieldList) int {
+	if list == nil {
+		return 0
+	}
+	deepest := 0
+	for _, field := range list.List {
+		deepest = max(deepest, TypeExprDepth(field.Type))
+	}
+	return deepest
+}
+
 // DetectFeatureEnvy identifies methods that reference external types more than their own receiver,
 // suggesting the method may belong to a different type. Feature envy is a code smell indicating
 // poor cohesion and potential design issues. When a method uses another object's data/methods
@@ -1133,12 +1204,13 @@ func (ba *BurdenAnalyzer) getVarName(expr ast.Expr) string {
 
 // findMostReferencedType identifies the most frequently referenced external type
 // from a map of type names to reference counts, used in feature envy detection.
+// Ties resolve to the alphabetically first name so the result is stable across runs.
 func (ba *BurdenAnalyzer) findMostReferencedType(refs map[string]int) (string, int) {
 	maxType := ""
 	maxCount := 0
 
 	for typeName, count := range refs {
-		if count > maxCount {
+		if count > maxCount || (count == maxCount && typeName < maxType) {
 			maxType = typeName
 			maxCount = count
 		}
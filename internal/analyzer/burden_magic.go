@@ -0,0 +1,250 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// MagicNumberOptions configures DetectMagicNumbersWithOptions
+type MagicNumberOptions struct {
+	// Allowed lists numeric values, such as "0", "1", or "-1", that are never reported
+	Allowed []string
+	// IgnoreBenign also allows the common sizes, bit widths, and round numbers in benignNumbers,
+	// whatever their sign
+	IgnoreBenign bool
+	// SkipTests reports nothing for _test.go files
+	SkipTests bool
+}
+
+// benignNumbers are values common enough that naming them rarely helps the reader
+var benignNumbers = []float64{0, 0.5, 1, 2, 8, 10, 16, 32, 64, 100, 128, 255, 256, 512, 1000, 1024}
+
+// magicNumberDetector collects the magic numbers and strings of a single file
+type magicNumberDetector struct {
+	ba       *BurdenAnalyzer
+	file     *ast.File
+	allowed  []float64
+	benign   bool
+	function string
+	// contexts records literals whose parent makes them worth flagging outside function
+	// bodies: array lengths and comparison operands
+	contexts map[ast.Expr]string
+	// exempt records literals used as positional indices
+	exempt  map[ast.Expr]bool
+	results []metrics.MagicNumber
+}
+
+// DetectMagicNumbersWithOptions flags numeric literals that should be named constants, and
+// non-empty string literals outside const declarations. Every numeric literal in a function
+// body is checked, while outside bodies only array sizes and comparison operands are: var buf
+// [4096]byte is flagged, but var retries = 3 documents itself through its name. Allowed values,
+// literals in const declarations, and positional indices like parts[2] or reflect field lookups
+// such as t.Field(3) used to read struct tags are not flagged. Suppressed functions are skipped.
+func (ba *BurdenAnalyzer) DetectMagicNumbersWithOptions(file *ast.File, pkg string, opts MagicNumberOptions) []metrics.MagicNumber {
+	if opts.SkipTests && isTestFile(ba.fset.Position(file.Pos()).Filename) {
+		return nil
+	}
+
+	detector := &magicNumberDetector{
+		ba:       ba,
+		file:     file,
+		allowed:  parseAllowedNumbers(opts.Allowed),
+		benign:   opts.IgnoreBenign,
+		contexts: make(map[ast.Expr]string),
+		exempt:   make(map[ast.Expr]bool),
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if isSuppressed(ba.fset, file, decl) {
+				continue
+			}
+			detector.function = decl.Name.Name
+			detector.inspect(decl.Type, false)
+			if decl.Body != nil {
+				detector.inspect(decl.Body, true)
+			}
+		case *ast.GenDecl:
+			detector.function = ""
+			detector.inspect(decl, false)
+		}
+	}
+	return detector.results
+}
+
+// parseAllowedNumbers converts configured allowed values to floats, ignoring invalid entries
+func parseAllowedNumbers(allowed []string) []float64 {
+	values := make([]float64, 0, len(allowed))
+	for _, text := range allowed {
+		if value, ok := parseNumber(text); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseNumber parses a Go integer or floating-point literal, optionally negated
+func parseNumber(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	if i, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return float64(i), true
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f, true
+	}
+	return 0, false
+}
+
+// inspect walks node, checking every numeric literal when inBody is set and only array
+// sizes and comparison operands otherwise; string literals are checked everywhere
+func (d *magicNumberDetector) inspect(node ast.Node, inBody bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			return n.Tok != token.CONST
+		case *ast.IndexExpr:
+			d.exempt[ast.Unparen(n.Index)] = true
+		case *ast.CallExpr:
+			if isFieldIndexCall(n) {
+				d.exempt[ast.Unparen(n.Args[0])] = true
+			}
+		case *ast.ArrayType:
+			if n.Len != nil {
+				d.contexts[ast.Unparen(n.Len)] = "array size"
+			}
+		case *ast.BinaryExpr:
+			if isComparisonOperator(n.Op) {
+				d.contexts[ast.Unparen(n.X)] = "comparison"
+				d.contexts[ast.Unparen(n.Y)] = "comparison"
+			}
+		case *ast.UnaryExpr:
+			if lit, ok := n.X.(*ast.BasicLit); ok && n.Op == token.SUB {
+				d.checkNumber(n, lit, true, inBody)
+				return false
+			}
+		case *ast.BasicLit:
+			if n.Kind == token.STRING {
+				d.checkString(n)
+			} else {
+				d.checkNumber(n, n, false, inBody)
+			}
+		}
+		return true
+	})
+}
+
+// checkNumber records a numeric literal, or its negation, unless it is allowed, exempt, or
+// outside a function body without a flagged context
+func (d *magicNumberDetector) checkNumber(expr ast.Expr, lit *ast.BasicLit, negative, inBody bool) {
+	if (lit.Kind != token.INT && lit.Kind != token.FLOAT) || d.exempt[expr] {
+		return
+	}
+	context, flagged := d.contexts[expr]
+	if !inBody && !flagged {
+		return
+	}
+
+	value, _ := constant.Float64Val(constant.ToFloat(constant.MakeFromLiteral(lit.Value, lit.Kind, 0)))
+	text := lit.Value
+	if negative {
+		value, text = -value, "-"+text
+	}
+	if d.isAllowed(value) {
+		return
+	}
+	if !flagged {
+		context = d.ba.extractContext(lit, d.file)
+	}
+	d.add(expr, text, "numeric", context)
+}
+
+// checkString records a non-empty string literal
+func (d *magicNumberDetector) checkString(lit *ast.BasicLit) {
+	if lit.Value == `""` || lit.Value == "``" {
+		return
+	}
+	d.add(lit, lit.Value, "string", d.ba.extractContext(lit, d.file))
+}
+
+// isAllowed reports whether value is configured as allowed or, when benign numbers are
+// ignored, whether its magnitude is one of them
+func (d *magicNumberDetector) isAllowed(value float64) bool {
+	for _, allowed := range d.allowed {
+		if value == allowed {
+			return true
+		}
+	}
+	if d.benign {
+		for _, benign := range benignNumbers {
+			if math.Abs(value) == benign {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// add records a magic number or string found at expr
+func (d *magicNumberDetector) add(expr ast.Expr, text, typ, context string) {
+	pos := d.ba.fset.Position(expr.Pos())
+	severity, suggestion := d.ba.getMagicNumberSeverityAndSuggestion(typ, text)
+	d.results = append(d.results, metrics.MagicNumber{
+		File:       pos.Filename,
+		Line:       pos.Line,
+		Column:     pos.Column,
+		Value:      text,
+		Type:       typ,
+		Context:    context,
+		Function:   d.function,
+		Severity:   severity,
+		Suggestion: suggestion,
+	})
+}
+
+// MagicNumberAntiPatterns presents the numeric magic numbers found by the burden analysis as
+// anti-pattern warnings, so both report sections list the same literals
+func MagicNumberAntiPatterns(numbers []metrics.MagicNumber) []metrics.AntiPatternWarning {
+	warnings := []metrics.AntiPatternWarning{}
+	for _, n := range numbers {
+		if n.Type != "numeric" {
+			continue
+		}
+		value, _ := parseNumber(n.Value)
+		warnings = append(warnings, metrics.AntiPatternWarning{
+			Type:           "magic_number",
+			File:           n.File,
+			Line:           n.Line,
+			Function:       n.Function,
+			Severity:       n.Severity,
+			Description:    fmt.Sprintf("Magic number %s used in %s", n.Value, n.Context),
+			Recommendation: fmt.Sprintf("Extract %s into a named constant that explains its meaning", n.Value),
+			ItemName:       n.Value,
+			Metric:         n.Context,
+			ActualValue:    value,
+		})
+	}
+	return warnings
+}
+
+// isFieldIndexCall reports whether call looks up a struct field by position, as in
+// reflect.Type.Field(i) or reflect.Value.Field(i)
+func isFieldIndexCall(call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && selector.Sel.Name == "Field" && len(call.Args) == 1
+}
+
+// isComparisonOperator reports whether op compares two values
+func isComparisonOperator(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var defaultAllowedMagicNumbers = []string{"0", "1", "-1"}
+
+// detectMagicNumbers parses src as filename and returns its numeric magic numbers
+func detectMagicNumbers(t *testing.T, filename, src string, allowed []string, skipTests bool) []metrics.MagicNumber {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	require.NoError(t, err)
+	var numbers []metrics.MagicNumber
+	for _, n := range NewBurdenAnalyzer(fset).DetectMagicNumbersWithOptions(file, "p", MagicNumberOptions{Allowed: allowed, SkipTests: skipTests}) {
+		if n.Type == "numeric" {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// magicValues lists the literal text of each magic number
+func magicValues(numbers []metrics.MagicNumber) []string {
+	values := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		values = append(values, n.Value)
+	}
+	return values
+}
+
+func TestDetectMagicNumbers_ComparisonFlagged(t *testing.T) {
+	src := `package p
+
+func expired(x int) bool {
+	if x > 86400 {
+		return true
+	}
+	return false
+}
+`
+	numbers := detectMagicNumbers(t, "p.go", src, defaultAllowedMagicNumbers, false)
+
+	require.Len(t, numbers, 1)
+	n := numbers[0]
+	assert.Equal(t, "p.go", n.File)
+	assert.Equal(t, 4, n.Line)
+	assert.Equal(t, "expired", n.Function)
+	assert.Equal(t, "86400", n.Value)
+	assert.Equal(t, "comparison", n.Context)
+	assert.Equal(t, metrics.SeverityLevelWarning, n.Severity)
+	assert.Contains(t, n.Suggestion, "named constant")
+
+	warnings := MagicNumberAntiPatterns(append(numbers, metrics.MagicNumber{Value: `"x"`, Type: "string"}))
+	require.Len(t, warnings, 1, "string literals are not magic number anti-patterns")
+	w := warnings[0]
+	assert.Equal(t, "magic_number", w.Type)
+	assert.Equal(t, 4, w.Line)
+	assert.Equal(t, "expired", w.Function)
+	assert.Equal(t, "86400", w.ItemName)
+	assert.Equal(t, "comparison", w.Metric)
+	assert.Equal(t, 86400.0, w.ActualValue)
+	assert.Equal(t, metrics.SeverityLevelWarning, w.Severity)
+	assert.Contains(t, w.Recommendation, "named constant")
+}
+
+func TestDetectMagicNumbers_AllowedValuesIgnored(t *testing.T) {
+	src := `package p
+
+func loop() int {
+	n := -1
+	for i := 0; i < 1; i++ {
+		n += i
+	}
+	return n
+}
+`
+	assert.Empty(t, detectMagicNumbers(t, "p.go", src, defaultAllowedMagicNumbers, false))
+}
+
+func TestDetectMagicNumbers_Contexts(t *testing.T) {
+	src := `package p
+
+import "reflect"
+
+const timeout = 30
+
+var retries = 3
+
+var buffer [4096]byte
+
+var tooLarge = len(buffer) > 2048
+
+func process(parts []string, v interface{}) string {
+	const limit = 99
+	tag := reflect.TypeOf(v).Field(2).Tag
+	_ = tag
+	if len(parts) > limit {
+		return parts[3]
+	}
+	return parts[-1+len(parts)] + string(rune(42+timeout))
+}
+`
+	numbers := detectMagicNumbers(t, "p.go", src, defaultAllowedMagicNumbers, false)
+
+	assert.Equal(t, []string{"4096", "2048", "42"}, magicValues(numbers))
+	assert.Equal(t, "array size", numbers[0].Context)
+	assert.Empty(t, numbers[0].Function)
+	assert.Equal(t, "comparison", numbers[1].Context)
+	assert.Equal(t, "return", numbers[2].Context)
+	assert.Equal(t, "process", numbers[2].Function)
+}
+
+func TestDetectMagicNumbers_ConfigurableAllowList(t *testing.T) {
+	src := `package p
+
+func scale(x float64) float64 {
+	if x < -1 {
+		return x * 0x10
+	}
+	return x * 2.5
+}
+`
+	assert.Equal(t, []string{"-1", "0x10", "2.5"}, magicValues(detectMagicNumbers(t, "p.go", src, nil, false)))
+	assert.Equal(t, []string{"2.5"}, magicValues(detectMagicNumbers(t, "p.go", src, []string{"-1", "16"}, false)))
+}
+
+func TestDetectMagicNumbers_TestFiles(t *testing.T) {
+	src := `package p
+
+func TestTimeout(t int) bool {
+	return t > 86400
+}
+`
+	assert.Len(t, detectMagicNumbers(t, "p_test.go", src, defaultAllowedMagicNumbers, false), 1)
+	assert.Empty(t, detectMagicNumbers(t, "p_test.go", src, defaultAllowedMagicNumbers, true))
+	assert.Len(t, detectMagicNumbers(t, "p.go", src, defaultAllowedMagicNumbers, true), 1)
+}
+
+func TestDetectMagicNumbers_IgnoreBenign(t *testing.T) {
+	src := `package p
+
+func size(n int) int {
+	if n > 1024 {
+		return n * -2
+	}
+	return n * 3
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+	ba := NewBurdenAnalyzer(fset)
+
+	numbers := ba.DetectMagicNumbersWithOptions(file, "p", MagicNumberOptions{Allowed: defaultAllowedMagicNumbers, IgnoreBenign: true})
+	assert.Equal(t, []string{"3"}, magicValues(numbers))
+	assert.Equal(t, numbers, ba.DetectMagicNumbers(file, "p"), "DetectMagicNumbers ignores benign numbers")
+
+	numbers = ba.DetectMagicNumbersWithOptions(file, "p", MagicNumberOptions{Allowed: defaultAllowedMagicNumbers})
+	assert.Equal(t, []string{"1024", "-2", "3"}, magicValues(numbers))
+}
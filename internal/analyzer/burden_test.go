@@ -841,3 +841,136 @@ func (h *Handler) Log(logger Logger) {
 		})
 	}
 }
+
+func TestDetectComplexTypeExpressions(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		maxDepth   int
+		wantIssues int
+		wantDepth  int
+		wantPos    string
+	}{
+		{
+			name: "deeply nested parameter type",
+			src: `package test
+type Item struct{}
+func Index(groups map[string]map[string][]*Item) error { return nil }`,
+			maxDepth:   3,
+			wantIssues: 1,
+			wantDepth:  4,
+			wantPos:    "parameter",
+		},
+		{
+			name: "deeply nested return type",
+			src: `package test
+type Event struct{}
+func Stream() (chan map[int][]*Event, error) { return nil, nil }`,
+			maxDepth:   3,
+			wantIssues: 1,
+			wantDepth:  4,
+			wantPos:    "return",
+		},
+		{
+			name: "simple types",
+			src: `package test
+type Item struct{}
+func Lookup(items []*Item, index map[string]int) (*Item, error) { return nil, nil }`,
+			maxDepth:   3,
+			wantIssues: 0,
+		},
+		{
+			name: "nested function type",
+			src: `package test
+func Register(handler func(map[string][]string) error) {}`,
+			maxDepth:   2,
+			wantIssues: 1,
+			wantDepth:  3,
+			wantPos:    "parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.src, 0)
+			require.NoError(t, err)
+
+			var fn *ast.FuncDecl
+			for _, decl := range file.Decls {
+				if f, ok := decl.(*ast.FuncDecl); ok {
+					fn = f
+				}
+			}
+			require.NotNil(t, fn)
+
+			issues := NewBurdenAnalyzer(fset).DetectComplexTypeExpressions(fn, tt.maxDepth)
+			require.Len(t, issues, tt.wantIssues)
+			if tt.wantIssues > 0 {
+				assert.Equal(t, tt.wantDepth, issues[0].Depth)
+				assert.Equal(t, tt.wantPos, issues[0].Position)
+				assert.NotEmpty(t, issues[0].TypeExpr)
+				assert.NotEmpty(t, issues[0].Suggestion)
+			}
+		})
+	}
+}
+
+func TestDetectLongMethodChains(t *testing.T) {
+	exclusions := []string{"With*", "Build"}
+
+	tests := []struct {
+		name       string
+		src        string
+		wantIssues int
+		wantDepth  int
+	}{
+		{
+			name: "five-deep call chain",
+			src: `package test
+func Run(a A) { a.B().C().D().E().F() }`,
+			wantIssues: 1,
+			wantDepth:  5,
+		},
+		{
+			name: "short chain",
+			src: `package test
+func Run(a A) { a.B().C() }`,
+			wantIssues: 0,
+		},
+		{
+			name: "fluent builder excluded",
+			src: `package test
+func Run() { NewBuilder().WithName("x").WithPort(1).WithTLS().WithRetry(3).Build() }`,
+			wantIssues: 0,
+		},
+		{
+			name: "chain in call argument reported separately",
+			src: `package test
+func Run(a A) { use(a.B().C().D().E().F(), a.B()) }`,
+			wantIssues: 1,
+			wantDepth:  5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.src, 0)
+			require.NoError(t, err)
+
+			fn, ok := file.Decls[0].(*ast.FuncDecl)
+			require.True(t, ok)
+
+			issues := NewBurdenAnalyzer(fset).DetectLongMethodChains(fn, 4, exclusions)
+			require.Len(t, issues, tt.wantIssues)
+			if tt.wantIssues > 0 {
+				assert.Equal(t, tt.wantDepth, issues[0].Depth)
+				assert.Equal(t, "Run", issues[0].Function)
+				assert.Equal(t, 2, issues[0].Line)
+				assert.Equal(t, "a.B().C().D().E().F()", issues[0].Chain)
+				assert.Equal(t, metrics.SeverityLevelInfo, issues[0].Severity)
+			}
+		})
+	}
+}
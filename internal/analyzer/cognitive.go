@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// cognitiveWeight is the weight of cognitive complexity in ComplexityScore.Overall. Cyclomatic
+// complexity counts paths through a function at full weight; cognitive complexity adds a
+// readability signal on top that grows with nesting, so deeply nested code ranks above flat
+// code with the same number of branches.
+const cognitiveWeight = 0.3
+
+// cognitiveWalker accumulates the cognitive complexity of a function body following the
+// SonarSource rules:
+//   - if, switch, select, for, and range add 1 plus the current nesting level
+//   - else and else if add 1 without a nesting penalty
+//   - each sequence of like boolean operators (&& or ||) adds 1
+//   - goto and labeled break and continue add 1
+//
+// Control-flow bodies and function literals increase the nesting level.
+type cognitiveWalker struct {
+	score int
+}
+
+// calculateCognitiveComplexity returns the cognitive complexity of a function body
+func calculateCognitiveComplexity(body *ast.BlockStmt) int {
+	if body == nil {
+		return 0
+	}
+	walker := &cognitiveWalker{}
+	walker.walk(body, 0)
+	return walker.score
+}
+
+// walk scores node and its children at the given nesting level
+func (w *cognitiveWalker) walk(node ast.Node, nesting int) {
+	if node == nil {
+		return
+	}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			w.score += 1 + nesting
+			w.walkIf(n, nesting)
+			return false
+		case *ast.ForStmt:
+			w.score += 1 + nesting
+			w.walkStmt(n.Init, nesting)
+			w.walkExpr(n.Cond, nesting)
+			w.walkStmt(n.Post, nesting)
+			w.walk(n.Body, nesting+1)
+			return false
+		case *ast.RangeStmt:
+			w.score += 1 + nesting
+			w.walkExpr(n.X, nesting)
+			w.walk(n.Body, nesting+1)
+			return false
+		case *ast.SwitchStmt:
+			w.score += 1 + nesting
+			w.walkStmt(n.Init, nesting)
+			w.walkExpr(n.Tag, nesting)
+			w.walk(n.Body, nesting+1)
+			return false
+		case *ast.TypeSwitchStmt:
+			w.score += 1 + nesting
+			w.walkStmt(n.Init, nesting)
+			w.walk(n.Body, nesting+1)
+			return false
+		case *ast.SelectStmt:
+			w.score += 1 + nesting
+			w.walk(n.Body, nesting+1)
+			return false
+		case *ast.FuncLit:
+			w.walk(n.Body, nesting+1)
+			return false
+		case *ast.BranchStmt:
+			if n.Tok == token.GOTO || (n.Label != nil && (n.Tok == token.BREAK || n.Tok == token.CONTINUE)) {
+				w.score++
+			}
+		case *ast.BinaryExpr:
+			if isLogicalOperator(n.Op) {
+				w.walkLogical(n, nesting)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// walkIf scores the condition, body, and else chain of an if statement whose own increment
+// has already been added
+func (w *cognitiveWalker) walkIf(ifStmt *ast.IfStmt, nesting int) {
+	w.walkStmt(ifStmt.Init, nesting)
+	w.walkExpr(ifStmt.Cond, nesting)
+	w.walk(ifStmt.Body, nesting+1)
+
+	switch elseStmt := ifStmt.Else.(type) {
+	case *ast.IfStmt:
+		w.score++
+		w.walkIf(elseStmt, nesting)
+	case *ast.BlockStmt:
+		w.score++
+		w.walk(elseStmt, nesting+1)
+	}
+}
+
+// walkStmt walks an optional statement such as an if or for initializer
+func (w *cognitiveWalker) walkStmt(stmt ast.Stmt, nesting int) {
+	if stmt != nil {
+		w.walk(stmt, nesting)
+	}
+}
+
+// walkExpr walks an optional expression such as a loop condition
+func (w *cognitiveWalker) walkExpr(expr ast.Expr, nesting int) {
+	if expr != nil {
+		w.walk(expr, nesting)
+	}
+}
+
+// walkLogical adds one for each run of like operators in a boolean expression, so a && b && c
+// scores 1 and a && b || c scores 2, then walks the operands
+func (w *cognitiveWalker) walkLogical(expr *ast.BinaryExpr, nesting int) {
+	var operators []token.Token
+	var operands []ast.Expr
+	flattenLogical(expr, &operators, &operands)
+
+	for i, op := range operators {
+		if i == 0 || op != operators[i-1] {
+			w.score++
+		}
+	}
+	for _, operand := range operands {
+		w.walk(operand, nesting)
+	}
+}
+
+// flattenLogical lists the operators of a boolean expression in source order along with the
+// operands that are not themselves && or || expressions. Parentheses do not end a sequence.
+func flattenLogical(expr ast.Expr, operators *[]token.Token, operands *[]ast.Expr) {
+	binary, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	if !ok || !isLogicalOperator(binary.Op) {
+		*operands = append(*operands, expr)
+		return
+	}
+	flattenLogical(binary.X, operators, operands)
+	*operators = append(*operators, binary.Op)
+	flattenLogical(binary.Y, operators, operands)
+}
+
+// isLogicalOperator reports whether op is a short-circuit boolean operator
+func isLogicalOperator(op token.Token) bool {
+	return op == token.LAND || op == token.LOR
+}
@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateCognitiveComplexity(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "straight-line code",
+			body: `x := 1
+	_ = x`,
+			want: 0,
+		},
+		{
+			name: "if with else if and else",
+			body: `if a {
+		return
+	} else if b {
+		return
+	} else {
+		return
+	}`,
+			want: 3, // if +1, else if +1, else +1
+		},
+		{
+			name: "nested loops pay nesting penalties",
+			body: `for i := 0; i < 10; i++ {
+		for j := range items {
+			if j > i {
+				println(j)
+			}
+		}
+	}`,
+			want: 6, // for +1, range +2, if +3
+		},
+		{
+			name: "switch counts once regardless of cases",
+			body: `switch v {
+	case 1:
+	case 2:
+	case 3:
+	default:
+	}`,
+			want: 1,
+		},
+		{
+			name: "like boolean operators form one sequence",
+			body: `if a && b && c {
+		return
+	}`,
+			want: 2, // if +1, && sequence +1
+		},
+		{
+			name: "mixed boolean operators form two sequences",
+			body: `if a && b || c {
+		return
+	}`,
+			want: 3, // if +1, && +1, || +1
+		},
+		{
+			name: "labeled break and continue",
+			body: `outer:
+	for _, row := range rows {
+		for _, v := range row {
+			if v == 0 {
+				continue outer
+			}
+			if v < 0 {
+				break outer
+			}
+		}
+	}`,
+			want: 11, // range +1, range +2, if +3, continue +1, if +3, break +1
+		},
+		{
+			name: "unlabeled break is free",
+			body: `for {
+		if done() {
+			break
+		}
+	}`,
+			want: 3, // for +1, if +2
+		},
+		{
+			name: "function literals increase nesting",
+			body: `go func() {
+		if ready {
+			run()
+		}
+	}()`,
+			want: 2, // if +2 inside the closure
+		},
+		{
+			name: "select and goto",
+			body: `select {
+	case <-done:
+		goto cleanup
+	default:
+	}
+cleanup:
+	close(ch)`,
+			want: 2, // select +1, goto +1
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package p\n\nfunc f() {\n\t" + tt.body + "\n}\n"
+			file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+			require.NoError(t, err)
+			fn := file.Decls[0].(*ast.FuncDecl)
+
+			assert.Equal(t, tt.want, calculateCognitiveComplexity(fn.Body))
+		})
+	}
+}
+
+func TestFunctionAnalyzer_CognitiveExceedsCyclomaticForNestedCode(t *testing.T) {
+	src := `package p
+
+func nested(grid [][]int, limit int) int {
+	total := 0
+	for _, row := range grid {
+		for _, v := range row {
+			if v > 0 {
+				if v < limit {
+					for i := 0; i < v; i++ {
+						if i%2 == 0 && i != limit {
+							total += i
+						}
+					}
+				}
+			}
+		}
+	}
+	return total
+}
+
+func flat(a, b, c, d, e, f int) int {
+	if a > 0 {
+		return 1
+	}
+	if b > 0 {
+		return 2
+	}
+	if c > 0 {
+		return 3
+	}
+	if d > 0 {
+		return 4
+	}
+	if e > 0 {
+		return 5
+	}
+	if f > 0 {
+		return 6
+	}
+	return 0
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	functions, err := NewFunctionAnalyzer(fset).AnalyzeFunctions(file, "p")
+	require.NoError(t, err)
+	require.Len(t, functions, 2)
+	nested, flat := functions[0].Complexity, functions[1].Complexity
+
+	// Both functions have similar decision points, but the nested one is far harder to read
+	assert.Equal(t, 8, nested.Cyclomatic)
+	assert.Equal(t, 7, flat.Cyclomatic)
+	assert.Equal(t, 1+2+3+4+5+6+1, nested.Cognitive)
+	assert.Equal(t, 6, flat.Cognitive)
+	assert.Greater(t, nested.Cognitive, 2*nested.Cyclomatic)
+	assert.Greater(t, nested.Overall, flat.Overall)
+}
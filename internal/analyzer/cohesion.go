@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"go/ast"
+	"sort"
+)
+
+// methodAccess maps each method of a type to the fields and methods it reaches through its receiver
+type methodAccess map[string]map[string]bool
+
+// collectMethodAccess records, for every method declared in file, the receiver members its body
+// references (r.field or r.method()). Results are keyed by receiver type name and merged into
+// types so a type whose methods span several files is measured as a whole.
+func collectMethodAccess(file *ast.File, types map[string]methodAccess) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		typeName := receiverTypeName(funcDecl)
+		if typeName == "" {
+			continue
+		}
+		if types[typeName] == nil {
+			types[typeName] = make(methodAccess)
+		}
+		members := make(map[string]bool)
+		types[typeName][funcDecl.Name.Name] = members
+
+		names := funcDecl.Recv.List[0].Names
+		if funcDecl.Body == nil || len(names) == 0 || names[0].Name == "_" {
+			continue
+		}
+		recv := names[0].Name
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == recv {
+					members[sel.Sel.Name] = true
+				}
+			}
+			return true
+		})
+	}
+}
+
+// lcom4 returns the Lack of Cohesion of Methods (LCOM4) of a type: the number of connected
+// components in the graph whose nodes are the type's methods, where two methods are connected
+// when they reference a common field or one calls the other through the receiver. A value of 1
+// means every method works on shared state; each extra component is a separable responsibility.
+func lcom4(access methodAccess) int {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(x string) string {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+	union := func(a, b string) {
+		if _, ok := parent[a]; !ok {
+			parent[a] = a
+		}
+		if _, ok := parent[b]; !ok {
+			parent[b] = b
+		}
+		parent[find(a)] = find(b)
+	}
+
+	// Methods and fields share one node space, so a method calling another joins them
+	// directly and two methods touching the same field join through that field's node
+	methods := make([]string, 0, len(access))
+	for method, members := range access {
+		methods = append(methods, method)
+		union(method, method)
+		for member := range members {
+			union(method, member)
+		}
+	}
+	sort.Strings(methods)
+
+	roots := make(map[string]bool)
+	for _, method := range methods {
+		roots[find(method)] = true
+	}
+	return len(roots)
+}
+
+// typeCohesion normalizes LCOM4 onto 0-1 for a type with methodCount methods: 1 when all methods
+// form one component and 0 when no two methods share anything
+func typeCohesion(components, methodCount int) float64 {
+	if methodCount <= 1 {
+		return 1.0
+	}
+	return float64(methodCount-components) / float64(methodCount-1)
+}
+
+// packageCohesion averages the normalized LCOM4 of the package's types, weighted by method count.
+// Types with fewer than two methods cannot be incohesive and are skipped; a package with no
+// measurable type scores 1.0.
+func packageCohesion(types map[string]methodAccess) float64 {
+	// Sum in type name order so the result does not depend on map iteration order
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	weighted, methods := 0.0, 0
+	for _, name := range names {
+		access := types[name]
+		if len(access) < 2 {
+			continue
+		}
+		weighted += typeCohesion(lcom4(access), len(access)) * float64(len(access))
+		methods += len(access)
+	}
+	if methods == 0 {
+		return 1.0
+	}
+	return weighted / float64(methods)
+}
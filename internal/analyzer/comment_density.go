@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const (
+	// lowCommentRatio is the comment-to-code ratio below which a package is flagged as under-documented
+	lowCommentRatio = 0.05
+	// highCommentRatio is the ratio above which a package likely contains commented-out code
+	highCommentRatio = 0.50
+)
+
+// FileLineCounts holds the line metrics of one analyzed file
+type FileLineCounts struct {
+	// File is the path relative to the analyzed root
+	File    string
+	Package string
+	Lines   metrics.LineMetrics
+}
+
+// AnalyzeCommentDensity computes comment-to-code line ratios for every file from its line
+// metrics and rolls them up by package directory, so two packages of the same name in different
+// directories stay apart. Packages whose ratio is below 5% are flagged "low" and those above 50%
+// are flagged "high".
+func AnalyzeCommentDensity(files []FileLineCounts) metrics.CommentDensityMetrics {
+	density := metrics.CommentDensityMetrics{
+		Files:    make([]metrics.CommentRatio, 0, len(files)),
+		Packages: make([]metrics.CommentRatio, 0),
+	}
+
+	packageTotals := make(map[string]*metrics.CommentRatio)
+	var totalCode, totalComments int
+
+	for _, f := range files {
+		code, comments := f.Lines.Code, f.Lines.Comments
+		density.Files = append(density.Files, metrics.CommentRatio{
+			Name:         f.File,
+			Package:      f.Package,
+			CodeLines:    code,
+			CommentLines: comments,
+			Ratio:        commentRatio(comments, code),
+		})
+
+		dir := filepath.Dir(f.File)
+		pkg, ok := packageTotals[dir]
+		if !ok {
+			pkg = &metrics.CommentRatio{Name: dir, Package: f.Package}
+			packageTotals[dir] = pkg
+		}
+		pkg.CodeLines += code
+		pkg.CommentLines += comments
+
+		totalCode += code
+		totalComments += comments
+	}
+
+	for _, pkg := range packageTotals {
+		pkg.Ratio = commentRatio(pkg.CommentLines, pkg.CodeLines)
+		pkg.Flag = commentRatioFlag(pkg.Ratio)
+		density.Packages = append(density.Packages, *pkg)
+	}
+
+	sort.Slice(density.Files, func(i, j int) bool { return density.Files[i].Name < density.Files[j].Name })
+	sort.Slice(density.Packages, func(i, j int) bool { return density.Packages[i].Name < density.Packages[j].Name })
+	density.Overall = commentRatio(totalComments, totalCode)
+
+	return density
+}
+
+// commentRatio returns comment lines divided by code lines, or 0 when there is no code
+func commentRatio(comments, code int) float64 {
+	if code == 0 {
+		return 0
+	}
+	return float64(comments) / float64(code)
+}
+
+// commentRatioFlag classifies a package comment ratio as "low", "high", or "" (expected range)
+func commentRatioFlag(ratio float64) string {
+	if ratio < lowCommentRatio {
+		return "low"
+	}
+	if ratio > highCommentRatio {
+		return "high"
+	}
+	return ""
+}
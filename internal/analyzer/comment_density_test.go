@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+func TestFunctionAnalyzer_CountFileLines(t *testing.T) {
+	// 7 code lines (the struct field with a trailing comment counts as code), 6 comment lines
+	src := `// Package store persists things.
+package store
+
+// Item is a stored value.
+type Item struct {
+	ID int // identifier
+}
+
+/*
+Block comment spanning
+three lines
+*/
+func Get() int {
+	return 1
+}
+`
+	path := filepath.Join(t.TempDir(), "a.go")
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	require.NoError(t, err)
+
+	lines := NewFunctionAnalyzer(fset).CountFileLines(file)
+	assert.Equal(t, metrics.LineMetrics{Total: 15, Code: 7, Comments: 6, Blank: 2}, lines)
+}
+
+func TestAnalyzeCommentDensity(t *testing.T) {
+	density := AnalyzeCommentDensity([]FileLineCounts{
+		{File: "store/b.go", Package: "store", Lines: metrics.LineMetrics{Code: 3}},
+		{File: "store/a.go", Package: "store", Lines: metrics.LineMetrics{Code: 7, Comments: 6}},
+		{File: "bare/c.go", Package: "bare", Lines: metrics.LineMetrics{Code: 2}},
+		// Another package named store, in a directory of its own
+		{File: "legacy/store/d.go", Package: "store", Lines: metrics.LineMetrics{Code: 10, Comments: 1}},
+	})
+
+	require.Len(t, density.Files, 4)
+	assert.Equal(t, "bare/c.go", density.Files[0].Name)
+	a := density.Files[2]
+	assert.Equal(t, "store/a.go", a.Name)
+	assert.Equal(t, "store", a.Package)
+	assert.Equal(t, 7, a.CodeLines)
+	assert.Equal(t, 6, a.CommentLines)
+	assert.InDelta(t, 6.0/7.0, a.Ratio, 0.0001)
+
+	require.Len(t, density.Packages, 3, "packages are grouped by directory, not name")
+	bare, legacy, store := density.Packages[0], density.Packages[1], density.Packages[2]
+
+	assert.Equal(t, "store", store.Name)
+	assert.Equal(t, "store", store.Package)
+	assert.Equal(t, 10, store.CodeLines)
+	assert.Equal(t, 6, store.CommentLines)
+	assert.InDelta(t, 0.6, store.Ratio, 0.0001)
+	assert.Equal(t, "high", store.Flag, "ratio above 50% suggests commented-out code")
+
+	assert.Equal(t, "legacy/store", legacy.Name)
+	assert.Equal(t, "store", legacy.Package)
+	assert.InDelta(t, 0.1, legacy.Ratio, 0.0001)
+	assert.Empty(t, legacy.Flag)
+
+	assert.Equal(t, "bare", bare.Name)
+	assert.Equal(t, 0.0, bare.Ratio)
+	assert.Equal(t, "low", bare.Flag, "ratio below 5% is under-documented")
+
+	assert.InDelta(t, 7.0/22.0, density.Overall, 0.0001)
+}
+
+func TestCommentRatioFlag(t *testing.T) {
+	tests := []struct {
+		ratio    float64
+		expected string
+	}{
+		{0.0, "low"},
+		{0.049, "low"},
+		{0.05, ""},
+		{0.25, ""},
+		{0.5, ""},
+		{0.51, "high"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, commentRatioFlag(tt.ratio), "ratio %.3f", tt.ratio)
+	}
+}
@@ -0,0 +1,32 @@
+package analyzer
+
+import "github.com/opd-ai/go-stats-generator/internal/metrics"
+
+// ComplexityWeights are the weights that combine the components of a ComplexityScore into its
+// Overall value: each component is multiplied by its weight and the products are summed.
+type ComplexityWeights struct {
+	Cyclomatic float64
+	Nesting    float64
+	Cognitive  float64
+}
+
+// DefaultFunctionComplexityWeights returns the weights used for functions and methods when none
+// are configured: cyclomatic complexity at full weight, nesting depth at half, and cognitive
+// complexity at cognitiveWeight.
+func DefaultFunctionComplexityWeights() ComplexityWeights {
+	return ComplexityWeights{Cyclomatic: 1, Nesting: 0.5, Cognitive: cognitiveWeight}
+}
+
+// DefaultStructComplexityWeights returns the weights used for structs and their methods when
+// none are configured. Struct scores have no cognitive component, so only the field-based
+// cyclomatic count and the embedding depth are weighted.
+func DefaultStructComplexityWeights() ComplexityWeights {
+	return ComplexityWeights{Cyclomatic: 1, Nesting: 0.5}
+}
+
+// Overall returns the weighted sum of the components of score
+func (w ComplexityWeights) Overall(score metrics.ComplexityScore) float64 {
+	return float64(score.Cyclomatic)*w.Cyclomatic +
+		float64(score.NestingDepth)*w.Nesting +
+		float64(score.Cognitive)*w.Cognitive
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const weightedSource = `package sample
+
+type Server struct {
+	Name    string
+	Handler func()
+	Routes  map[string]int
+	Base
+}
+
+func (s *Server) route(items []int) int {
+	total := 0
+	for _, item := range items {
+		if item > 0 && item < 10 {
+			total += item
+		}
+	}
+	return total
+}
+`
+
+// analyzeWeighted returns the complexity of the route function and the Server struct
+func analyzeWeighted(t *testing.T, function, structs *ComplexityWeights) (metrics.ComplexityScore, metrics.ComplexityScore) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "server.go", weightedSource, parser.ParseComments)
+	require.NoError(t, err)
+
+	fa := NewFunctionAnalyzer(fset)
+	if function != nil {
+		fa.SetComplexityWeights(*function)
+	}
+	functions, err := fa.AnalyzeFunctions(file, "sample")
+	require.NoError(t, err)
+	require.Len(t, functions, 1)
+
+	sa := NewStructAnalyzer(fset)
+	if structs != nil {
+		sa.SetComplexityWeights(*structs)
+	}
+	structMetrics, err := sa.AnalyzeStructs(file, "sample")
+	require.NoError(t, err)
+	require.Len(t, structMetrics, 1)
+	return functions[0].Complexity, structMetrics[0].Complexity
+}
+
+func TestComplexityWeights_DefaultsReproducePriorFormula(t *testing.T) {
+	fn, st := analyzeWeighted(t, nil, nil)
+
+	assert.Equal(t, 4, fn.Cyclomatic)
+	assert.Equal(t, 2, fn.NestingDepth)
+	assert.InDelta(t, float64(fn.Cyclomatic)+float64(fn.NestingDepth)*0.5+float64(fn.Cognitive)*0.3, fn.Overall, 1e-9)
+	assert.InDelta(t, float64(st.Cyclomatic)+float64(st.NestingDepth)*0.5, st.Overall, 1e-9)
+	assert.Equal(t, 1, st.NestingDepth)
+
+	explicitFn, explicitSt := DefaultFunctionComplexityWeights(), DefaultStructComplexityWeights()
+	again, againSt := analyzeWeighted(t, &explicitFn, &explicitSt)
+	assert.Equal(t, fn, again)
+	assert.Equal(t, st, againSt)
+}
+
+func TestComplexityWeights_ChangingAWeightChangesOverall(t *testing.T) {
+	base, baseStruct := analyzeWeighted(t, nil, nil)
+
+	fnWeights := DefaultFunctionComplexityWeights()
+	fnWeights.Cognitive = 1
+	structWeights := DefaultStructComplexityWeights()
+	structWeights.Nesting = 2
+	fn, st := analyzeWeighted(t, &fnWeights, &structWeights)
+
+	assert.Equal(t, base.Cognitive, fn.Cognitive, "weights only change the overall score")
+	assert.InDelta(t, base.Overall+float64(fn.Cognitive)*0.7, fn.Overall, 1e-9)
+	assert.InDelta(t, baseStruct.Overall+float64(st.NestingDepth)*1.5, st.Overall, 1e-9)
+
+	cyclomaticOnly := ComplexityWeights{Cyclomatic: 1}
+	fn, _ = analyzeWeighted(t, &cyclomaticOnly, nil)
+	assert.InDelta(t, float64(fn.Cyclomatic), fn.Overall, 1e-9)
+}
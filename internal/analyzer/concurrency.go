@@ -8,9 +8,34 @@ import (
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
+// packageScopeFunction is reported as the enclosing function of declarations at package scope
+const packageScopeFunction = "<package>"
+
 // ConcurrencyAnalyzer analyzes concurrency patterns in Go source code
 type ConcurrencyAnalyzer struct {
 	fset *token.FileSet
+	// enclosingFuncs holds the names of the function declarations enclosing the node
+	// currently visited by AnalyzeConcurrency, innermost last
+	enclosingFuncs []string
+	// path holds the nodes from the file down to the node currently visited, inclusive
+	path []ast.Node
+	// fileIndex holds the functions and channel usage of the file being analyzed
+	fileIndex *goroutineFileIndex
+	// makeChannels maps the make(chan T) calls of the file to their index in Channels.Instances
+	makeChannels map[*ast.CallExpr]int
+	// pipeline holds the channel topology of the file's goroutines
+	pipeline *pipelineTopology
+	// atomicPkg is the name sync/atomic is imported under in the file, or empty
+	atomicPkg string
+	// errgroupPkg is the name golang.org/x/sync/errgroup is imported under in the file, or empty
+	errgroupPkg string
+	// errGroups maps errgroup variables to their index in SyncPrims.ErrGroups
+	errGroups map[errGroupKey]int
+	// contextPkg, httpPkg and testingPkg are the names context, net/http and testing are
+	// imported under, or empty
+	contextPkg string
+	httpPkg    string
+	testingPkg string
 }
 
 // NewConcurrencyAnalyzer creates a new concurrency analyzer for detecting Go concurrency patterns
@@ -37,6 +62,7 @@ func (ca *ConcurrencyAnalyzer) AnalyzeConcurrency(file *ast.File, pkgName string
 		Goroutines: metrics.GoroutineMetrics{
 			Instances:      []metrics.GoroutineInstance{},
 			GoroutineLeaks: []metrics.GoroutineLeakWarning{},
+			DataRaces:      []metrics.DataRaceWarning{},
 		},
 		Channels: metrics.ChannelMetrics{
 			Instances: []metrics.ChannelInstance{},
@@ -48,15 +74,47 @@ func (ca *ConcurrencyAnalyzer) AnalyzeConcurrency(file *ast.File, pkgName string
 			Once:       []metrics.SyncPrimitiveInstance{},
 			Cond:       []metrics.SyncPrimitiveInstance{},
 			Atomic:     []metrics.SyncPrimitiveInstance{},
+			ErrGroups:  []metrics.SyncPrimitiveInstance{},
 		},
-	}
-
-	// Walk through the AST to analyze concurrency patterns
+		SelectStatements: []metrics.SelectInstance{},
+		SyncWarnings:     []metrics.SyncWarning{},
+		ContextWarnings:  []metrics.ContextWarning{},
+	}
+
+	// Walk through the AST to analyze concurrency patterns, tracking the enclosing
+	// function declaration so instances are attributed to it
+	ca.path = nil
+	ca.enclosingFuncs = nil
+	ca.fileIndex = indexGoroutineFile(file)
+	ca.makeChannels = make(map[*ast.CallExpr]int)
+	ca.atomicPkg = importName(file, "sync/atomic")
+	ca.errgroupPkg = importName(file, "golang.org/x/sync/errgroup")
+	ca.contextPkg = importName(file, "context")
+	ca.httpPkg = importName(file, "net/http")
+	ca.testingPkg = importName(file, "testing")
+	ca.errGroups = make(map[errGroupKey]int)
 	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			if _, ok := ca.path[len(ca.path)-1].(*ast.FuncDecl); ok {
+				ca.enclosingFuncs = ca.enclosingFuncs[:len(ca.enclosingFuncs)-1]
+			}
+			ca.path = ca.path[:len(ca.path)-1]
+			return true
+		}
+		ca.path = append(ca.path, n)
+		if funcDecl, ok := n.(*ast.FuncDecl); ok {
+			ca.enclosingFuncs = append(ca.enclosingFuncs, callGraphNode(funcDecl))
+		}
 		ca.analyzeNode(n, &concurrency, pkgName)
 		return true
 	})
 
+	// Name channels after the variables they are assigned to and count their send and receive sites
+	ca.bindChannelNames(file, &concurrency)
+
+	// Correlate goroutine bodies with map writes and lock usage
+	ca.detectConcurrentMapWrites(file, &concurrency)
+
 	// Calculate summary statistics
 	ca.calculateSummaryStats(&concurrency)
 
@@ -77,8 +135,12 @@ func (ca *ConcurrencyAnalyzer) analyzeNode(n ast.Node, concurrency *metrics.Conc
 		ca.analyzeCallExpr(node, concurrency, fileName)
 	case *ast.GenDecl:
 		ca.analyzeGenDecl(node, concurrency, fileName)
+	case *ast.AssignStmt:
+		ca.analyzeErrGroupAssign(node, concurrency, fileName)
 	case *ast.FuncDecl:
 		ca.analyzeFuncDecl(node, concurrency, fileName)
+	case *ast.SelectStmt:
+		ca.analyzeSelect(node, concurrency, fileName)
 	}
 }
 
@@ -86,43 +148,40 @@ func (ca *ConcurrencyAnalyzer) analyzeNode(n ast.Node, concurrency *metrics.Conc
 func (ca *ConcurrencyAnalyzer) analyzeGoroutine(goStmt *ast.GoStmt, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
 	pos := ca.fset.Position(goStmt.Pos())
 
-	var functionName string
 	var isAnonymous bool
 	var context string
 
-	// Determine if it's an anonymous function or named function call
+	// Determine if it's an anonymous function or named function call; the launched
+	// function is recorded as the context, the enclosing function as the Function
 	switch call := goStmt.Call.Fun.(type) {
 	case *ast.FuncLit:
 		isAnonymous = true
-		functionName = "anonymous"
 		context = ca.extractFunctionContext(call, 50) // Extract up to 50 characters
 	case *ast.Ident:
-		functionName = call.Name
-		context = functionName
+		context = call.Name
 	case *ast.SelectorExpr:
-		functionName = ca.extractSelectorName(call)
-		context = functionName
+		context = ca.extractSelectorName(call)
 	default:
-		functionName = "unknown"
 		context = "unknown"
 	}
 
-	// Check for defer statements in function (basic leak detection)
-	hasDefer := ca.containsDefer(goStmt.Call)
+	// Resolve the goroutine's function body to find its exit signals
+	funcType, body := ca.goroutineFunc(goStmt.Call)
+	signals := findExitSignals(funcType, body)
 
 	instance := metrics.GoroutineInstance{
 		File:        fileName,
 		Line:        pos.Line,
-		Function:    functionName,
+		Function:    ca.getCurrentFunction(),
 		IsAnonymous: isAnonymous,
-		HasDefer:    hasDefer,
+		HasDefer:    signals.hasDefer,
 		Context:     context,
 	}
 
 	concurrency.Goroutines.Instances = append(concurrency.Goroutines.Instances, instance)
 
 	// Check for potential goroutine leaks
-	ca.checkGoroutineLeak(goStmt, concurrency, fileName, functionName)
+	ca.checkGoroutineLeak(goStmt, body, signals, concurrency, fileName, instance.Function)
 }
 
 // analyzeChannelType analyzes channel type declarations
@@ -143,7 +202,7 @@ func (ca *ConcurrencyAnalyzer) analyzeChannelType(chanType *ast.ChanType, concur
 	instance := metrics.ChannelInstance{
 		File:          fileName,
 		Line:          pos.Line,
-		Function:      ca.getCurrentFunction(chanType),
+		Function:      ca.getCurrentFunction(),
 		Type:          ca.extractTypeString(chanType.Value),
 		IsBuffered:    false, // Will be determined in make calls
 		BufferSize:    0,
@@ -157,7 +216,7 @@ func (ca *ConcurrencyAnalyzer) analyzeChannelType(chanType *ast.ChanType, concur
 // analyzeCallExpr analyzes function calls for concurrency-related functions
 func (ca *ConcurrencyAnalyzer) analyzeCallExpr(call *ast.CallExpr, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
 	pos := ca.fset.Position(call.Pos())
-	functionName := ca.getCurrentFunction(call)
+	functionName := ca.getCurrentFunction()
 
 	// Check for make(chan ...) calls
 	if ca.isMakeCall(call, "chan") {
@@ -165,9 +224,16 @@ func (ca *ConcurrencyAnalyzer) analyzeCallExpr(call *ast.CallExpr, concurrency *
 		return
 	}
 
-	// Check for sync package usage
+	// Check for sync and sync/atomic package usage
 	if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selector.X.(*ast.Ident); ok {
+			if ca.atomicPkg != "" && ident.Name == ca.atomicPkg {
+				ca.analyzeAtomicCall(selector.Sel.Name, call, concurrency, fileName, functionName, pos.Line)
+				return
+			}
+			if ca.recordErrGroupCall(ident.Name, selector.Sel.Name, concurrency) {
+				return
+			}
 			ca.analyzeSyncCall(ident.Name, selector.Sel.Name, call, concurrency, fileName, functionName, pos.Line)
 		}
 	}
@@ -194,6 +260,12 @@ func (ca *ConcurrencyAnalyzer) analyzeFuncDecl(funcDecl *ast.FuncDecl, concurren
 
 	// Look for worker pool patterns, pipelines, etc.
 	ca.analyzeForPatterns(funcDecl, concurrency, fileName)
+
+	// Check lock and unlock calls of the function and of each function literal it contains
+	ca.analyzeLockBalance(funcDecl, concurrency, fileName)
+
+	// Check that the function accepts a context where it needs one and uses the one it accepts
+	ca.analyzeContextPropagation(funcDecl, concurrency, fileName)
 }
 
 // analyzeMakeChannel analyzes make(chan) calls for buffer size and type
@@ -211,6 +283,7 @@ func (ca *ConcurrencyAnalyzer) analyzeMakeChannel(call *ast.CallExpr, concurrenc
 	direction, isDirectional := ca.determineChannelDirection(chanType)
 	instance := ca.createChannelInstance(fileName, line, functionName, chanType, isBuffered, bufferSize, direction, isDirectional)
 
+	ca.makeChannels[call] = len(concurrency.Channels.Instances)
 	concurrency.Channels.Instances = append(concurrency.Channels.Instances, instance)
 }
 
@@ -296,14 +369,29 @@ func (ca *ConcurrencyAnalyzer) analyzeVarSpec(valueSpec *ast.ValueSpec, concurre
 	}
 }
 
-// checkSyncPrimitiveType checks if a type is a sync primitive
+// checkSyncPrimitiveType checks if a type is a sync primitive or a typed sync/atomic value
 func (ca *ConcurrencyAnalyzer) checkSyncPrimitiveType(typeExpr ast.Expr, varName string, concurrency *metrics.ConcurrencyPatternMetrics, fileName string, line int) {
+	if ca.isErrGroupType(typeExpr) {
+		ca.addErrGroup(varName, concurrency, fileName, line, "declaration")
+		return
+	}
+	if typeName, ok := ca.atomicTypeName(typeExpr); ok {
+		concurrency.SyncPrims.Atomic = append(concurrency.SyncPrims.Atomic, metrics.SyncPrimitiveInstance{
+			File:     fileName,
+			Line:     line,
+			Function: ca.getCurrentFunction(),
+			Type:     typeName,
+			Variable: varName,
+			Context:  "declaration",
+		})
+		return
+	}
 	if selector, ok := typeExpr.(*ast.SelectorExpr); ok {
 		if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == "sync" {
 			instance := metrics.SyncPrimitiveInstance{
 				File:     fileName,
 				Line:     line,
-				Function: ca.getCurrentFunction(typeExpr),
+				Function: ca.getCurrentFunction(),
 				Type:     selector.Sel.Name,
 				Variable: varName,
 				Context:  "declaration",
@@ -362,6 +450,9 @@ func (ca *ConcurrencyAnalyzer) calculateSummaryStats(concurrency *metrics.Concur
 			concurrency.Channels.DirectionalCount++
 		}
 	}
+
+	// Calculate atomic operation stats
+	concurrency.SyncPrims.AtomicOperations = metrics.CountAtomicOperations(concurrency.SyncPrims.Atomic)
 }
 
 // detectPatterns detects higher-level concurrency patterns
@@ -399,51 +490,14 @@ func (ca *ConcurrencyAnalyzer) extractSelectorName(selector *ast.SelectorExpr) s
 	return selector.Sel.Name
 }
 
-// containsDefer checks if a call expression contains a defer statement for cleanup
-func (ca *ConcurrencyAnalyzer) containsDefer(call *ast.CallExpr) bool {
-	funcLit, ok := call.Fun.(*ast.FuncLit)
-	if !ok || funcLit.Body == nil {
-		return false
-	}
-
-	hasDefer := false
-	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
-		if n == nil || hasDefer {
-			return false
-		}
-		if _, ok := n.(*ast.DeferStmt); ok {
-			hasDefer = true
-			return false
-		}
-		return true
-	})
-
-	return hasDefer
-}
-
-// checkGoroutineLeak analyzes a goroutine for potential leaks such as infinite loops without exit conditions
-func (ca *ConcurrencyAnalyzer) checkGoroutineLeak(goStmt *ast.GoStmt, concurrency *metrics.ConcurrencyPatternMetrics, fileName, functionName string) {
-	// Basic goroutine leak detection
-	pos := ca.fset.Position(goStmt.Pos())
-
-	// Check for infinite loops without proper exit conditions
-	if ca.hasInfiniteLoop(goStmt.Call) {
-		warning := metrics.GoroutineLeakWarning{
-			File:           fileName,
-			Line:           pos.Line,
-			Function:       functionName,
-			RiskLevel:      "medium",
-			Description:    "Goroutine with potential infinite loop detected",
-			Recommendation: "Ensure proper exit conditions using context, channels, or other signaling mechanisms",
-		}
-		concurrency.Goroutines.GoroutineLeaks = append(concurrency.Goroutines.GoroutineLeaks, warning)
+// getCurrentFunction returns the innermost function declaration enclosing the node being visited,
+// qualified as "ReceiverType.Method" for methods. Function literals are attributed to the
+// declaration containing them, and nodes outside any function to packageScopeFunction.
+func (ca *ConcurrencyAnalyzer) getCurrentFunction() string {
+	if len(ca.enclosingFuncs) == 0 {
+		return packageScopeFunction
 	}
-}
-
-// getCurrentFunction attempts to find the name of the enclosing function for a given AST node
-func (ca *ConcurrencyAnalyzer) getCurrentFunction(node ast.Node) string {
-	// Find the enclosing function - simplified implementation
-	return "unknown"
+	return ca.enclosingFuncs[len(ca.enclosingFuncs)-1]
 }
 
 // extractTypeString converts a type expression to its string representation
@@ -496,32 +550,6 @@ func (ca *ConcurrencyAnalyzer) analyzeForPatterns(funcDecl *ast.FuncDecl, concur
 	// Analyze function body for concurrency patterns - placeholder for complex pattern detection
 }
 
-// hasInfiniteLoop checks if a call expression contains an infinite loop pattern
-func (ca *ConcurrencyAnalyzer) hasInfiniteLoop(call *ast.CallExpr) bool {
-	funcLit, ok := call.Fun.(*ast.FuncLit)
-	if !ok || funcLit.Body == nil {
-		return false
-	}
-
-	found := false
-	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
-		if found {
-			return false
-		}
-		forStmt, ok := n.(*ast.ForStmt)
-		if !ok {
-			return true
-		}
-		// A for statement with no condition is an infinite loop: for { ... }
-		if forStmt.Cond == nil && forStmt.Init == nil && forStmt.Post == nil {
-			found = true
-			return false
-		}
-		return true
-	})
-	return found
-}
-
 // detectWorkerPools identifies worker pool patterns in the code by analyzing
 // goroutine usage, channel operations, and WaitGroup synchronization. Groups
 // goroutines by file and detects pools with multiple workers, shared channels,
@@ -581,99 +609,49 @@ func (ca *ConcurrencyAnalyzer) buildWorkerPoolPattern(file string, goroutines []
 	}
 }
 
-// detectPipelines identifies pipeline patterns based on sequential channel chaining between goroutines
+// detectPipelines identifies pipeline patterns from the channel topology of the file: at least
+// one goroutine must receive from one channel and send to another, so files that merely
+// declare unrelated channels and goroutines are not reported
 func (ca *ConcurrencyAnalyzer) detectPipelines(concurrency *metrics.ConcurrencyPatternMetrics) {
-	fileChannels := ca.groupChannelsByFile(concurrency.Channels.Instances)
-	fileGoroutines := ca.groupGoroutinesByFile(concurrency.Goroutines.Instances)
-	ca.findPipelinePatterns(fileChannels, fileGoroutines, concurrency)
-}
-
-// groupChannelsByFile groups channels by their source file
-func (ca *ConcurrencyAnalyzer) groupChannelsByFile(channels []metrics.ChannelInstance) map[string][]metrics.ChannelInstance {
-	fileChannels := make(map[string][]metrics.ChannelInstance)
-	for _, channel := range channels {
-		fileChannels[channel.File] = append(fileChannels[channel.File], channel)
-	}
-	return fileChannels
-}
-
-// groupGoroutinesByFile groups goroutines by their source file
-func (ca *ConcurrencyAnalyzer) groupGoroutinesByFile(goroutines []metrics.GoroutineInstance) map[string][]metrics.GoroutineInstance {
-	fileGoroutines := make(map[string][]metrics.GoroutineInstance)
-	for _, goroutine := range goroutines {
-		fileGoroutines[goroutine.File] = append(fileGoroutines[goroutine.File], goroutine)
-	}
-	return fileGoroutines
-}
-
-// findPipelinePatterns finds pipeline patterns in files with multiple channels and goroutines
-func (ca *ConcurrencyAnalyzer) findPipelinePatterns(fileChannels map[string][]metrics.ChannelInstance, fileGoroutines map[string][]metrics.GoroutineInstance, concurrency *metrics.ConcurrencyPatternMetrics) {
-	for file, channels := range fileChannels {
-		goroutines := fileGoroutines[file]
-		if len(channels) >= 2 && len(goroutines) >= 2 {
-			ca.addPipelinePattern(file, channels, goroutines, concurrency)
-		}
+	topology := ca.pipeline
+	if topology == nil || topology.stages == 0 || len(concurrency.Goroutines.Instances) == 0 {
+		return
 	}
-}
 
-// addPipelinePattern adds a pipeline pattern if confidence is high enough
-func (ca *ConcurrencyAnalyzer) addPipelinePattern(file string, channels []metrics.ChannelInstance, goroutines []metrics.GoroutineInstance, concurrency *metrics.ConcurrencyPatternMetrics) {
-	confidence := ca.calculatePipelineConfidence(channels, goroutines)
+	confidence := ca.calculatePipelineConfidence(topology)
 	if confidence > 0.6 {
+		file := concurrency.Goroutines.Instances[0].File
 		pattern := metrics.PatternInstance{
 			Name:            "Pipeline",
 			File:            file,
-			Line:            goroutines[0].Line,
+			Line:            topology.line,
 			ConfidenceScore: confidence,
-			Description:     fmt.Sprintf("Pipeline with %d stages and %d channels", len(goroutines), len(channels)),
+			Description:     fmt.Sprintf("Pipeline with %d stages and %d channels", topology.members, topology.channels),
 			Example:         fmt.Sprintf("File '%s' implements pipeline pattern", file),
 		}
 		concurrency.Pipelines = append(concurrency.Pipelines, pattern)
 	}
 }
 
-// calculatePipelineConfidence calculates confidence for pipeline pattern
-func (ca *ConcurrencyAnalyzer) calculatePipelineConfidence(channels []metrics.ChannelInstance, goroutines []metrics.GoroutineInstance) float64 {
-	confidence := 0.0
-
-	// Multiple channels suggest pipeline stages
-	if len(channels) >= 2 {
-		confidence += 0.3
-	}
-
-	// Multiple goroutines suggest processing stages
-	if len(goroutines) >= 2 {
-		confidence += 0.3
+// calculatePipelineConfidence scores a pipeline topology. A stage on its own only suggests a
+// pipeline; a producer feeding it, a consumer draining it, and further chained stages each
+// raise confidence.
+func (ca *ConcurrencyAnalyzer) calculatePipelineConfidence(topology *pipelineTopology) float64 {
+	if topology.stages == 0 {
+		return 0.0
 	}
 
-	// Sequential channels (mostly unbuffered) are typical for pipelines
-	confidence += ca.calculateUnbufferedChannelBonus(channels)
-
-	// More stages increase confidence
-	if len(channels) >= 3 {
+	confidence := 0.5
+	if topology.fed {
 		confidence += 0.2
 	}
-
-	return confidence
-}
-
-// calculateUnbufferedChannelBonus calculates confidence bonus based on unbuffered channel ratio
-func (ca *ConcurrencyAnalyzer) calculateUnbufferedChannelBonus(channels []metrics.ChannelInstance) float64 {
-	if len(channels) == 0 {
-		return 0.0
-	}
-
-	unbufferedCount := 0
-	for _, channel := range channels {
-		if !channel.IsBuffered {
-			unbufferedCount++
-		}
+	if topology.consumed {
+		confidence += 0.2
 	}
-
-	if float64(unbufferedCount)/float64(len(channels)) > 0.7 {
-		return 0.3
+	if topology.stages >= 2 {
+		confidence += 0.2
 	}
-	return 0.0
+	return min(confidence, 1.0)
 }
 
 // detectFanPatterns detects fan-out and fan-in concurrency patterns
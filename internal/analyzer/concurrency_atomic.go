@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// importName returns the name the package at importPath is imported under in file, or "" when
+// the file does not import it by name. Unnamed imports use the last path element.
+func importName(file *ast.File, importPath string) string {
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || path != importPath {
+			continue
+		}
+		if imp.Name == nil {
+			return importPath[strings.LastIndex(importPath, "/")+1:]
+		}
+		if imp.Name.Name != "_" && imp.Name.Name != "." {
+			return imp.Name.Name
+		}
+	}
+	return ""
+}
+
+// analyzeAtomicCall records calls to sync/atomic functions such as atomic.AddInt64(&n, 1). The
+// variable is the operand whose address is passed as the first argument.
+func (ca *ConcurrencyAnalyzer) analyzeAtomicCall(functionName string, call *ast.CallExpr, concurrency *metrics.ConcurrencyPatternMetrics, fileName, currentFunc string, line int) {
+	if metrics.AtomicOperationKind(functionName) == metrics.AtomicTypedKind {
+		return
+	}
+
+	variable := "unknown"
+	if len(call.Args) > 0 {
+		variable = ca.atomicOperand(call.Args[0])
+	}
+	concurrency.SyncPrims.Atomic = append(concurrency.SyncPrims.Atomic, metrics.SyncPrimitiveInstance{
+		File:     fileName,
+		Line:     line,
+		Function: currentFunc,
+		Type:     functionName,
+		Variable: variable,
+		Context:  "function call",
+	})
+}
+
+// atomicOperand names the variable an atomic function operates on, such as "counter" for
+// &counter or "s.hits" for &s.hits
+func (ca *ConcurrencyAnalyzer) atomicOperand(arg ast.Expr) string {
+	if unary, ok := ast.Unparen(arg).(*ast.UnaryExpr); ok {
+		arg = unary.X
+	}
+	switch expr := ast.Unparen(arg).(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return ca.extractSelectorName(expr)
+	}
+	return "unknown"
+}
+
+// atomicTypeName returns the sync/atomic type a declaration uses, such as Int64, Value, or
+// Pointer for the generic atomic.Pointer[T]
+func (ca *ConcurrencyAnalyzer) atomicTypeName(typeExpr ast.Expr) (string, bool) {
+	switch expr := typeExpr.(type) {
+	case *ast.IndexExpr:
+		typeExpr = expr.X
+	case *ast.IndexListExpr:
+		typeExpr = expr.X
+	}
+	selector, ok := typeExpr.(*ast.SelectorExpr)
+	if !ok || ca.atomicPkg == "" {
+		return "", false
+	}
+	if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == ca.atomicPkg {
+		return selector.Sel.Name, true
+	}
+	return "", false
+}
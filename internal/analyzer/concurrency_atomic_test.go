@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_AtomicDetection(t *testing.T) {
+	code := `package main
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+var requests atomic.Int64
+
+type stats struct {
+	hits uint64
+}
+
+func record(s *stats, ptr *unsafe.Pointer, next unsafe.Pointer) {
+	var total int64
+	var config atomic.Value
+	var head atomic.Pointer[stats]
+
+	atomic.AddInt64(&total, 1)
+	_ = atomic.LoadUint32(new(uint32))
+	atomic.AddUint64(&s.hits, 1)
+	atomic.CompareAndSwapPointer(ptr, nil, next)
+	requests.Add(1)
+	_, _ = config, head
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+
+	type usage struct{ Type, Variable, Function, Context string }
+	var got []usage
+	for _, instance := range result.SyncPrims.Atomic {
+		got = append(got, usage{instance.Type, instance.Variable, instance.Function, instance.Context})
+	}
+	assert.Equal(t, []usage{
+		{"Int64", "requests", packageScopeFunction, "declaration"},
+		{"Value", "config", "record", "declaration"},
+		{"Pointer", "head", "record", "declaration"},
+		{"AddInt64", "total", "record", "function call"},
+		{"LoadUint32", "unknown", "record", "function call"},
+		{"AddUint64", "s.hits", "record", "function call"},
+		{"CompareAndSwapPointer", "ptr", "record", "function call"},
+	}, got)
+
+	assert.Equal(t, map[string]int{"typed": 3, "add": 2, "load": 1, "compare_and_swap": 1},
+		result.SyncPrims.AtomicOperations)
+	assert.Empty(t, result.SyncPrims.Mutexes, "atomic calls are not sync primitives")
+}
+
+func TestConcurrencyAnalyzer_AtomicImportAlias(t *testing.T) {
+	code := `package main
+
+import (
+	atom "sync/atomic"
+)
+
+type atomic struct{}
+
+func (atomic) AddInt64(p *int64, d int64) {}
+
+func main() {
+	var n int64
+	atom.StoreInt64(&n, 2)
+	var local atomic
+	local.AddInt64(&n, 1)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+
+	require.Len(t, result.SyncPrims.Atomic, 1, "only calls through the sync/atomic import count")
+	assert.Equal(t, "StoreInt64", result.SyncPrims.Atomic[0].Type)
+	assert.Equal(t, "n", result.SyncPrims.Atomic[0].Variable)
+}
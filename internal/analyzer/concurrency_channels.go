@@ -0,0 +1,322 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// unboundChannel marks a variable in scope that does not hold a channel created in the file,
+// such as a parameter or a variable assigned from a call. It still shadows outer channels.
+const unboundChannel = -1
+
+// fieldChannelScope is the scope of channelKeys naming struct fields such as s.jobs, which are
+// identified by field name alone
+const fieldChannelScope = -1
+
+// channelScope is one block scope of the binder
+type channelScope struct {
+	id    int
+	vars  map[string]int  // values index instances or are unboundChannel
+	chans map[string]bool // variables declared with a channel type
+}
+
+// newChannelScope creates an empty scope with the given id
+func newChannelScope(id int) channelScope {
+	return channelScope{id: id, vars: make(map[string]int), chans: make(map[string]bool)}
+}
+
+// channelKey identifies a channel variable by its declaring scope and name, so closures that
+// capture a variable share its key while a shadowing declaration gets a new one. Identifiers
+// that are not declared in the file resolve to the file scope.
+type channelKey struct {
+	scope int
+	name  string
+}
+
+// channelFlow records the channels a function body sends to and receives from
+type channelFlow struct {
+	sends    map[channelKey]bool
+	receives map[channelKey]bool
+}
+
+// newChannelFlow creates an empty channel flow
+func newChannelFlow() *channelFlow {
+	return &channelFlow{sends: make(map[channelKey]bool), receives: make(map[channelKey]bool)}
+}
+
+// goroutineLaunch is a go statement with the channel keys of its call arguments, which stand in
+// for the launched function's parameters
+type goroutineLaunch struct {
+	stmt *ast.GoStmt
+	args []*channelKey // nil for arguments that are not channel variables
+}
+
+// channelBinder resolves identifiers to the make(chan T) calls assigned to them, following
+// Go's block scoping so a redeclared name in an inner block shadows the outer channel
+type channelBinder struct {
+	instances []metrics.ChannelInstance
+	makes     map[*ast.CallExpr]int
+	scopes    []channelScope // innermost last
+	nextScope int
+
+	bodies   []*ast.BlockStmt // bodies of the enclosing function declarations and literals
+	flows    map[*ast.BlockStmt]*channelFlow
+	params   map[*ast.BlockStmt][]channelKey
+	launches []goroutineLaunch
+}
+
+// bindChannelNames walks the assignments of file to name each make(chan T) instance after the
+// variable it is bound to, then counts the send statements and receive expressions on that
+// variable. Rebinding a variable with = attributes later sends and receives to the new
+// channel. Package-level channels are only resolved after their declaration in the file.
+// The sends and receives of each function body are kept for pipeline detection.
+func (ca *ConcurrencyAnalyzer) bindChannelNames(file *ast.File, concurrency *metrics.ConcurrencyPatternMetrics) {
+	binder := &channelBinder{
+		instances: concurrency.Channels.Instances,
+		makes:     ca.makeChannels,
+		scopes:    []channelScope{newChannelScope(0)},
+		nextScope: 1,
+		flows:     make(map[*ast.BlockStmt]*channelFlow),
+		params:    make(map[*ast.BlockStmt][]channelKey),
+	}
+
+	var path []ast.Node
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			last := path[len(path)-1]
+			if opensChannelScope(last) {
+				binder.scopes = binder.scopes[:len(binder.scopes)-1]
+			}
+			if isFunctionNode(last) {
+				binder.bodies = binder.bodies[:len(binder.bodies)-1]
+			}
+			path = path[:len(path)-1]
+			return true
+		}
+		path = append(path, n)
+		if opensChannelScope(n) {
+			binder.scopes = append(binder.scopes, newChannelScope(binder.nextScope))
+			binder.nextScope++
+		}
+		binder.visit(n)
+		return true
+	})
+
+	ca.pipeline = ca.buildPipelineTopology(binder)
+}
+
+// opensChannelScope reports whether a node starts a new block scope for variable declarations
+func opensChannelScope(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.FuncDecl, *ast.FuncLit, *ast.BlockStmt, *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt,
+		*ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.CaseClause, *ast.CommClause:
+		return true
+	}
+	return false
+}
+
+// isFunctionNode reports whether a node is a function declaration or literal
+func isFunctionNode(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.FuncDecl, *ast.FuncLit:
+		return true
+	}
+	return false
+}
+
+// visit records the declarations, assignments, sends, and receives of a single node
+func (cb *channelBinder) visit(n ast.Node) {
+	switch node := n.(type) {
+	case *ast.FuncDecl:
+		cb.bodies = append(cb.bodies, node.Body)
+		cb.declareFields(node.Recv)
+		cb.params[node.Body] = cb.declareFields(node.Type.Params)
+		cb.declareFields(node.Type.Results)
+	case *ast.FuncLit:
+		cb.bodies = append(cb.bodies, node.Body)
+		cb.params[node.Body] = cb.declareFields(node.Type.Params)
+		cb.declareFields(node.Type.Results)
+	case *ast.GoStmt:
+		cb.recordLaunch(node)
+	case *ast.AssignStmt:
+		cb.assign(node.Lhs, node.Rhs, node.Tok == token.DEFINE)
+	case *ast.ValueSpec:
+		names := make([]ast.Expr, len(node.Names))
+		for i, name := range node.Names {
+			names[i] = name
+		}
+		cb.assign(names, node.Values, true)
+		if _, ok := node.Type.(*ast.ChanType); ok {
+			for _, name := range node.Names {
+				cb.scopes[len(cb.scopes)-1].chans[name.Name] = true
+			}
+		}
+	case *ast.RangeStmt:
+		if cb.isChannel(node.X) {
+			cb.recordReceive(node.X)
+		}
+		if node.Tok == token.DEFINE {
+			cb.assign([]ast.Expr{node.Key, node.Value}, nil, true)
+		}
+	case *ast.SendStmt:
+		cb.recordSend(node.Chan)
+	case *ast.UnaryExpr:
+		if node.Op == token.ARROW {
+			cb.recordReceive(node.X)
+		}
+	}
+}
+
+// declareFields declares the named parameters, results, or receiver of a function and returns
+// their keys in order
+func (cb *channelBinder) declareFields(fields *ast.FieldList) []channelKey {
+	if fields == nil {
+		return nil
+	}
+	scope := cb.scopes[len(cb.scopes)-1]
+	var keys []channelKey
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			keys = append(keys, channelKey{})
+		}
+		_, isChan := field.Type.(*ast.ChanType)
+		for _, name := range field.Names {
+			scope.vars[name.Name] = unboundChannel
+			scope.chans[name.Name] = isChan
+			keys = append(keys, channelKey{scope: scope.id, name: name.Name})
+		}
+	}
+	return keys
+}
+
+// recordLaunch keeps a go statement and the channel keys of its arguments, resolved in the
+// scope of the go statement
+func (cb *channelBinder) recordLaunch(goStmt *ast.GoStmt) {
+	launch := goroutineLaunch{stmt: goStmt, args: make([]*channelKey, len(goStmt.Call.Args))}
+	for i, arg := range goStmt.Call.Args {
+		if key, ok := cb.key(arg); ok {
+			launch.args[i] = &key
+		}
+	}
+	cb.launches = append(cb.launches, launch)
+}
+
+// recordSend counts a send on a channel and adds it to the enclosing body's flow
+func (cb *channelBinder) recordSend(expr ast.Expr) {
+	if index, ok := cb.resolve(expr); ok {
+		cb.instances[index].SendCount++
+	}
+	if key, ok := cb.key(expr); ok {
+		if flow := cb.currentFlow(); flow != nil {
+			flow.sends[key] = true
+		}
+	}
+}
+
+// recordReceive counts a receive from a channel and adds it to the enclosing body's flow
+func (cb *channelBinder) recordReceive(expr ast.Expr) {
+	if index, ok := cb.resolve(expr); ok {
+		cb.instances[index].ReceiveCount++
+	}
+	if key, ok := cb.key(expr); ok {
+		if flow := cb.currentFlow(); flow != nil {
+			flow.receives[key] = true
+		}
+	}
+}
+
+// currentFlow returns the flow of the innermost enclosing function body, or nil at package scope
+func (cb *channelBinder) currentFlow() *channelFlow {
+	if len(cb.bodies) == 0 || cb.bodies[len(cb.bodies)-1] == nil {
+		return nil
+	}
+	body := cb.bodies[len(cb.bodies)-1]
+	if cb.flows[body] == nil {
+		cb.flows[body] = newChannelFlow()
+	}
+	return cb.flows[body]
+}
+
+// assign binds the identifiers of lhs to the make(chan T) calls at the same position of rhs.
+// Declarations add the names to the innermost scope; plain assignments rebind the variable in
+// the scope that declared it.
+func (cb *channelBinder) assign(lhs, rhs []ast.Expr, declare bool) {
+	for i, expr := range lhs {
+		ident, ok := expr.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+
+		index := unboundChannel
+		if len(lhs) == len(rhs) {
+			if call, ok := ast.Unparen(rhs[i]).(*ast.CallExpr); ok {
+				if makeIndex, ok := cb.makes[call]; ok {
+					index = makeIndex
+					cb.instances[index].Name = ident.Name
+				}
+			}
+		}
+
+		if declare {
+			cb.scopes[len(cb.scopes)-1].vars[ident.Name] = index
+			continue
+		}
+		if scope := cb.declaringScope(ident.Name); scope != nil {
+			scope.vars[ident.Name] = index
+		}
+	}
+}
+
+// declaringScope returns the innermost scope that declares name, or nil
+func (cb *channelBinder) declaringScope(name string) *channelScope {
+	for i := len(cb.scopes) - 1; i >= 0; i-- {
+		if _, ok := cb.scopes[i].vars[name]; ok {
+			return &cb.scopes[i]
+		}
+	}
+	return nil
+}
+
+// resolve returns the channel instance an identifier currently refers to
+func (cb *channelBinder) resolve(expr ast.Expr) (int, bool) {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return 0, false
+	}
+	scope := cb.declaringScope(ident.Name)
+	if scope == nil || scope.vars[ident.Name] == unboundChannel {
+		return 0, false
+	}
+	return scope.vars[ident.Name], true
+}
+
+// isChannel reports whether an identifier is known to hold a channel, because it was assigned
+// a make(chan T) call or declared with a channel type. Range statements only receive when
+// they range over a channel, unlike send statements and receive expressions.
+func (cb *channelBinder) isChannel(expr ast.Expr) bool {
+	if _, ok := cb.resolve(expr); ok {
+		return true
+	}
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	scope := cb.declaringScope(ident.Name)
+	return scope != nil && scope.chans[ident.Name]
+}
+
+// key returns the variable identity of a channel identifier or struct field selector
+func (cb *channelBinder) key(expr ast.Expr) (channelKey, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		if scope := cb.declaringScope(e.Name); scope != nil {
+			return channelKey{scope: scope.id, name: e.Name}, true
+		}
+		return channelKey{scope: 0, name: e.Name}, true
+	case *ast.SelectorExpr:
+		return channelKey{scope: fieldChannelScope, name: e.Sel.Name}, true
+	}
+	return channelKey{}, false
+}
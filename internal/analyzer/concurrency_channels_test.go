@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// madeChannels returns the channel instances created by make calls, keyed by line and name
+func madeChannels(t *testing.T, code string) map[string]metrics.ChannelInstance {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test")
+	require.NoError(t, err)
+
+	channels := make(map[string]metrics.ChannelInstance)
+	for _, instance := range result.Channels.Instances {
+		if instance.Name != "" {
+			channels[fmt.Sprintf("%s@%d", instance.Name, instance.Line)] = instance
+		}
+	}
+	return channels
+}
+
+func TestConcurrencyAnalyzer_ChannelNames(t *testing.T) {
+	code := `package main
+
+func pipeline() {
+	jobs, results := make(chan int, 4), make(chan int)
+	var done = make(chan struct{})
+	go func() {
+		for job := range jobs {
+			results <- job * 2
+		}
+		close(done)
+	}()
+	jobs <- 1
+	jobs <- 2
+	<-results
+	<-done
+}
+`
+	channels := madeChannels(t, code)
+	require.Len(t, channels, 3)
+
+	jobs := channels["jobs@4"]
+	assert.True(t, jobs.IsBuffered)
+	assert.Equal(t, 4, jobs.BufferSize)
+	assert.Equal(t, 2, jobs.SendCount)
+	assert.Equal(t, 1, jobs.ReceiveCount, "range over the channel is a receive site")
+
+	results := channels["results@4"]
+	assert.False(t, results.IsBuffered)
+	assert.Equal(t, 1, results.SendCount)
+	assert.Equal(t, 1, results.ReceiveCount)
+
+	done := channels["done@5"]
+	assert.Equal(t, 0, done.SendCount)
+	assert.Equal(t, 1, done.ReceiveCount)
+}
+
+func TestConcurrencyAnalyzer_ChannelNameShadowing(t *testing.T) {
+	code := `package main
+
+func shadow(in chan int) {
+	ch := make(chan int)
+	ch <- 1
+	{
+		ch := make(chan string)
+		ch <- "inner"
+		<-ch
+	}
+	go func(ch chan int) {
+		ch <- 2
+	}(in)
+	<-ch
+	ch = make(chan int)
+	<-ch
+	in <- 3
+}
+`
+	channels := madeChannels(t, code)
+	require.Len(t, channels, 3)
+
+	outer := channels["ch@4"]
+	assert.Equal(t, 1, outer.SendCount, "the inner block and closure parameter shadow ch")
+	assert.Equal(t, 1, outer.ReceiveCount)
+
+	inner := channels["ch@7"]
+	assert.Equal(t, "string", inner.Type)
+	assert.Equal(t, 1, inner.SendCount)
+	assert.Equal(t, 1, inner.ReceiveCount)
+
+	reassigned := channels["ch@15"]
+	assert.Equal(t, 0, reassigned.SendCount)
+	assert.Equal(t, 1, reassigned.ReceiveCount, "receives after = count for the new channel")
+}
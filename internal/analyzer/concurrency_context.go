@@ -0,0 +1,159 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// contextExemptPrefixes are function name prefixes that legitimately create root contexts:
+// program entry points and the functions the testing package runs
+var contextExemptPrefixes = []string{"Test", "Benchmark", "Fuzz", "Example"}
+
+// analyzeContextPropagation warns when a function starts goroutines or creates a root context
+// with context.Background() or context.TODO() but accepts neither a context.Context nor an
+// *http.Request or testing handle to take one from, and when a named context parameter is never
+// used. A context parameter named _ documents that it is ignored on purpose and is not reported.
+// main, init and test functions may create root contexts freely.
+func (ca *ConcurrencyAnalyzer) analyzeContextPropagation(funcDecl *ast.FuncDecl, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
+	function := ca.getCurrentFunction()
+	contextParams, hasContextSource := ca.contextParameters(funcDecl.Type)
+
+	for _, param := range contextParams {
+		if param.Name == "_" || identUsed(funcDecl.Body, param.Name) {
+			continue
+		}
+		concurrency.ContextWarnings = append(concurrency.ContextWarnings, metrics.ContextWarning{
+			File:        fileName,
+			Line:        ca.fset.Position(param.Pos()).Line,
+			Function:    function,
+			Type:        "unused_context",
+			Parameter:   param.Name,
+			Description: fmt.Sprintf("context parameter %s is never used; pass it on or rename it to _", param.Name),
+		})
+	}
+
+	if hasContextSource || isContextExempt(funcDecl) {
+		return
+	}
+	if node, reason := ca.contextRequirement(funcDecl.Body); node != nil {
+		concurrency.ContextWarnings = append(concurrency.ContextWarnings, metrics.ContextWarning{
+			File:        fileName,
+			Line:        ca.fset.Position(node.Pos()).Line,
+			Function:    function,
+			Type:        "missing_context",
+			Description: reason + " but the function does not accept a context.Context",
+		})
+	}
+}
+
+// contextParameters returns the named context.Context parameters of funcType and whether it has
+// any parameter a context can be taken from, including unnamed ones, *http.Request and the
+// testing package's *T, *B, *F and TB, whose Context method returns one
+func (ca *ConcurrencyAnalyzer) contextParameters(funcType *ast.FuncType) ([]*ast.Ident, bool) {
+	if funcType.Params == nil {
+		return nil, false
+	}
+
+	var names []*ast.Ident
+	hasSource := false
+	for _, field := range funcType.Params.List {
+		switch {
+		case ca.isContextType(field.Type):
+			names = append(names, field.Names...)
+			hasSource = true
+		case ca.isHTTPRequestType(field.Type), ca.isTestingType(field.Type):
+			hasSource = true
+		}
+	}
+	return names, hasSource
+}
+
+// isContextType reports whether expr is context.Context under the file's import name
+func (ca *ConcurrencyAnalyzer) isContextType(expr ast.Expr) bool {
+	return ca.contextPkg != "" && isQualifiedIdent(expr, ca.contextPkg, "Context")
+}
+
+// isHTTPRequestType reports whether expr is *http.Request under the file's import name
+func (ca *ConcurrencyAnalyzer) isHTTPRequestType(expr ast.Expr) bool {
+	star, ok := expr.(*ast.StarExpr)
+	return ok && ca.httpPkg != "" && isQualifiedIdent(star.X, ca.httpPkg, "Request")
+}
+
+// isTestingType reports whether expr is *testing.T, *testing.B, *testing.F or testing.TB
+func (ca *ConcurrencyAnalyzer) isTestingType(expr ast.Expr) bool {
+	if ca.testingPkg == "" {
+		return false
+	}
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	for _, name := range []string{"T", "B", "F", "TB"} {
+		if isQualifiedIdent(expr, ca.testingPkg, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// contextRequirement returns the first node in body that calls for a context, with a description:
+// a go statement, or a context.Background() or context.TODO() call
+func (ca *ConcurrencyAnalyzer) contextRequirement(body *ast.BlockStmt) (ast.Node, string) {
+	var found ast.Node
+	var reason string
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.GoStmt:
+			found, reason = n, "starts a goroutine"
+		case *ast.CallExpr:
+			for _, root := range []string{"Background", "TODO"} {
+				if ca.contextPkg != "" && isQualifiedIdent(n.Fun, ca.contextPkg, root) {
+					found, reason = n, fmt.Sprintf("creates context.%s()", root)
+				}
+			}
+		}
+		return found == nil
+	})
+	return found, reason
+}
+
+// isContextExempt reports whether funcDecl is main, init or a test function
+func isContextExempt(funcDecl *ast.FuncDecl) bool {
+	name := funcDecl.Name.Name
+	if funcDecl.Recv == nil && (name == "main" || name == "init") {
+		return true
+	}
+	for _, prefix := range contextExemptPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isQualifiedIdent reports whether expr is the selector pkg.name
+func isQualifiedIdent(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// identUsed reports whether body refers to an identifier named name
+func identUsed(body *ast.BlockStmt, name string) bool {
+	used := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_ContextWarnings(t *testing.T) {
+	code := `package server
+
+import (
+	stdctx "context"
+	"net/http"
+	"testing"
+)
+
+type Server struct{ jobs chan int }
+
+func (s *Server) Start() {
+	go s.loop()
+}
+
+func (s *Server) Run(ctx stdctx.Context) {
+	go s.loop()
+	<-ctx.Done()
+}
+
+func Fetch(url string) error {
+	return get(stdctx.TODO(), url)
+}
+
+func get(ctx stdctx.Context, url string) error {
+	return nil
+}
+
+func (s *Server) Close(_ stdctx.Context) error {
+	return nil
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	go s.process(stdctx.Background())
+}
+
+func helper(t *testing.T) {
+	_ = get(stdctx.Background(), "http://localhost")
+}
+
+func TestFetch(t *testing.T) {
+	_ = get(stdctx.Background(), "http://localhost")
+}
+
+func main() {
+	ctx := stdctx.Background()
+	_ = get(ctx, "http://localhost")
+}
+
+func (s *Server) loop() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "server.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "server")
+	require.NoError(t, err)
+
+	type warning struct {
+		function, kind, parameter string
+		line                      int
+	}
+	var got []warning
+	for _, w := range result.ContextWarnings {
+		assert.Equal(t, "server", w.File)
+		assert.NotEmpty(t, w.Description)
+		got = append(got, warning{w.Function, w.Type, w.Parameter, w.Line})
+	}
+
+	assert.Equal(t, []warning{
+		{"Server.Start", "missing_context", "", 12},
+		{"Fetch", "missing_context", "", 21},
+		{"get", "unused_context", "ctx", 24},
+	}, got, "contexts taken from parameters, requests and testing handles satisfy the check; _ marks an ignored context")
+	assert.Contains(t, result.ContextWarnings[1].Description, "context.TODO()")
+}
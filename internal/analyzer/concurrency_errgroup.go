@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"go/ast"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// errGroupKey identifies an errgroup variable by its enclosing function and name
+type errGroupKey struct {
+	function string
+	name     string
+}
+
+// isErrGroupType reports whether a type expression is errgroup.Group or *errgroup.Group
+func (ca *ConcurrencyAnalyzer) isErrGroupType(typeExpr ast.Expr) bool {
+	if star, ok := typeExpr.(*ast.StarExpr); ok {
+		typeExpr = star.X
+	}
+	selector, ok := typeExpr.(*ast.SelectorExpr)
+	if !ok || ca.errgroupPkg == "" || selector.Sel.Name != "Group" {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == ca.errgroupPkg
+}
+
+// analyzeErrGroupAssign records groups created in short variable declarations and assignments:
+// g, ctx := errgroup.WithContext(ctx), g := new(errgroup.Group), and g := &errgroup.Group{}
+func (ca *ConcurrencyAnalyzer) analyzeErrGroupAssign(assign *ast.AssignStmt, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
+	if ca.errgroupPkg == "" || len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+		return
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+
+	context := ""
+	switch value := assign.Rhs[0].(type) {
+	case *ast.CallExpr:
+		if selector, ok := value.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "WithContext" {
+			if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == ca.errgroupPkg {
+				context = "WithContext"
+			}
+		}
+		if fn, ok := value.Fun.(*ast.Ident); ok && fn.Name == "new" && len(value.Args) == 1 && ca.isErrGroupType(value.Args[0]) {
+			context = "new"
+		}
+	case *ast.UnaryExpr:
+		if lit, ok := value.X.(*ast.CompositeLit); ok && ca.isErrGroupType(lit.Type) {
+			context = "composite literal"
+		}
+	case *ast.CompositeLit:
+		if ca.isErrGroupType(value.Type) {
+			context = "composite literal"
+		}
+	}
+	if context != "" {
+		ca.addErrGroup(ident.Name, concurrency, fileName, ca.fset.Position(ident.Pos()).Line, context)
+	}
+}
+
+// addErrGroup appends an errgroup instance and remembers its variable so later Go and Wait
+// calls in the same function are attributed to it
+func (ca *ConcurrencyAnalyzer) addErrGroup(varName string, concurrency *metrics.ConcurrencyPatternMetrics, fileName string, line int, context string) {
+	function := ca.getCurrentFunction()
+	ca.errGroups[errGroupKey{function: function, name: varName}] = len(concurrency.SyncPrims.ErrGroups)
+	concurrency.SyncPrims.ErrGroups = append(concurrency.SyncPrims.ErrGroups, metrics.SyncPrimitiveInstance{
+		File:     fileName,
+		Line:     line,
+		Function: function,
+		Type:     "Group",
+		Variable: varName,
+		Context:  context,
+	})
+}
+
+// recordErrGroupCall counts Go, TryGo, and Wait calls on a known errgroup variable of the
+// current function, falling back to package-level groups. It reports whether the call was
+// on an errgroup.
+func (ca *ConcurrencyAnalyzer) recordErrGroupCall(varName, method string, concurrency *metrics.ConcurrencyPatternMetrics) bool {
+	index, ok := ca.errGroups[errGroupKey{function: ca.getCurrentFunction(), name: varName}]
+	if !ok {
+		index, ok = ca.errGroups[errGroupKey{function: packageScopeFunction, name: varName}]
+	}
+	if !ok {
+		return false
+	}
+
+	switch method {
+	case "Go", "TryGo":
+		concurrency.SyncPrims.ErrGroups[index].GoCalls++
+	case "Wait":
+		concurrency.SyncPrims.ErrGroups[index].HasWait = true
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_ErrGroupDetection(t *testing.T) {
+	code := `package main
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func fetchAll(ctx context.Context, urls []string) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return fetch(ctx, urls[0]) })
+	g.Go(func() error { return fetch(ctx, urls[1]) })
+	g.Go(func() error { return fetch(ctx, urls[2]) })
+	return g.Wait()
+}
+
+func fireAndForget(urls []string) {
+	var g errgroup.Group
+	for _, url := range urls {
+		g.Go(func() error { return fetch(context.Background(), url) })
+	}
+}
+
+func fetch(ctx context.Context, url string) error { return nil }
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+
+	require.Len(t, result.SyncPrims.ErrGroups, 2)
+
+	waited := result.SyncPrims.ErrGroups[0]
+	assert.Equal(t, "fetchAll", waited.Function)
+	assert.Equal(t, "g", waited.Variable)
+	assert.Equal(t, "WithContext", waited.Context)
+	assert.Equal(t, 10, waited.Line)
+	assert.Equal(t, 3, waited.GoCalls)
+	assert.True(t, waited.HasWait)
+
+	unwaited := result.SyncPrims.ErrGroups[1]
+	assert.Equal(t, "fireAndForget", unwaited.Function)
+	assert.Equal(t, "declaration", unwaited.Context)
+	assert.Equal(t, 1, unwaited.GoCalls, "a Go call inside a loop is one call site")
+	assert.False(t, unwaited.HasWait, "the group's errors are never waited on")
+
+	assert.Empty(t, result.SyncPrims.WaitGroups)
+}
+
+func TestConcurrencyAnalyzer_ErrGroupRequiresImport(t *testing.T) {
+	code := `package main
+
+type errgroup struct{ Group int }
+
+type runner struct{}
+
+func (runner) Go(f func() error) {}
+func (runner) Wait() error     { return nil }
+
+func main() {
+	g := new(runner)
+	g.Go(func() error { return nil })
+	_ = g.Wait()
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+	assert.Empty(t, result.SyncPrims.ErrGroups)
+}
@@ -0,0 +1,375 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// goroutineFileIndex records the declarations of the file being analyzed that goroutine leak
+// checks resolve against. Channels are tracked by name, so the index is a syntactic
+// approximation that cannot follow aliasing or channels passed in from other files.
+type goroutineFileIndex struct {
+	funcs      map[string]*ast.FuncDecl   // free functions by name
+	methods    map[string][]*ast.FuncDecl // methods by bare name
+	unbuffered map[string]bool            // channels created with make(chan T)
+	sent       map[string]bool            // channels that appear in a send statement
+	closed     map[string]bool            // channels passed to close
+}
+
+// goroutineExitSignals describes the ways a goroutine body can be told to stop
+type goroutineExitSignals struct {
+	hasDefer         bool // defer cleanup runs when the goroutine exits
+	hasContext       bool // a context.Context parameter, or Done/Err calls on a captured context
+	selectsDone      bool // a select case receives from X.Done()
+	hasCancelChannel bool // a select case receives from a channel and returns
+}
+
+// missing counts the exit signals a leaking goroutine lacks, for risk scoring
+func (s goroutineExitSignals) missing() int {
+	count := 0
+	for _, present := range []bool{s.hasDefer, s.hasContext, s.hasCancelChannel} {
+		if !present {
+			count++
+		}
+	}
+	return count
+}
+
+// indexGoroutineFile collects the functions, methods, and channel usage of file
+func indexGoroutineFile(file *ast.File) *goroutineFileIndex {
+	index := &goroutineFileIndex{
+		funcs:      make(map[string]*ast.FuncDecl),
+		methods:    make(map[string][]*ast.FuncDecl),
+		unbuffered: make(map[string]bool),
+		sent:       make(map[string]bool),
+		closed:     make(map[string]bool),
+	}
+
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+			if IsMethod(funcDecl) {
+				index.methods[funcDecl.Name.Name] = append(index.methods[funcDecl.Name.Name], funcDecl)
+			} else {
+				index.funcs[funcDecl.Name.Name] = funcDecl
+			}
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SendStmt:
+			index.sent[channelName(node.Chan)] = true
+		case *ast.CallExpr:
+			if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == "close" && len(node.Args) == 1 {
+				index.closed[channelName(node.Args[0])] = true
+			}
+		case *ast.AssignStmt:
+			index.recordUnbufferedChannels(node.Lhs, node.Rhs)
+		case *ast.ValueSpec:
+			names := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				names[i] = name
+			}
+			index.recordUnbufferedChannels(names, node.Values)
+		}
+		return true
+	})
+	return index
+}
+
+// recordUnbufferedChannels marks names assigned make(chan T) without a buffer size
+func (index *goroutineFileIndex) recordUnbufferedChannels(lhs, rhs []ast.Expr) {
+	if len(lhs) != len(rhs) {
+		return
+	}
+	for i, value := range rhs {
+		call, ok := value.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			continue
+		}
+		if ident, ok := call.Fun.(*ast.Ident); !ok || ident.Name != "make" {
+			continue
+		}
+		if _, ok := call.Args[0].(*ast.ChanType); ok {
+			index.unbuffered[channelName(lhs[i])] = true
+		}
+	}
+}
+
+// channelName returns the identifier naming a channel expression, using the field name for
+// selectors such as s.jobs
+func channelName(expr ast.Expr) string {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+	return ""
+}
+
+// goroutineFunc resolves the function a go statement runs: a function literal, a function
+// declared in the file, or a method declared in the file whose name is unambiguous
+func (ca *ConcurrencyAnalyzer) goroutineFunc(call *ast.CallExpr) (*ast.FuncType, *ast.BlockStmt) {
+	switch fn := call.Fun.(type) {
+	case *ast.FuncLit:
+		return fn.Type, fn.Body
+	case *ast.Ident:
+		if decl, ok := ca.fileIndex.funcs[fn.Name]; ok {
+			return decl.Type, decl.Body
+		}
+	case *ast.SelectorExpr:
+		if methods := ca.fileIndex.methods[fn.Sel.Name]; len(methods) == 1 {
+			return methods[0].Type, methods[0].Body
+		}
+	}
+	return nil, nil
+}
+
+// inspectGoroutineBody walks body without descending into nested function literals, which
+// run as separate closures or goroutines and are checked on their own
+func inspectGoroutineBody(body *ast.BlockStmt, visit func(ast.Node) bool) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		return visit(n)
+	})
+}
+
+// findExitSignals reports the defer statements, context usage, and cancellation selects
+// of a goroutine function
+func findExitSignals(funcType *ast.FuncType, body *ast.BlockStmt) goroutineExitSignals {
+	var signals goroutineExitSignals
+	if body == nil {
+		return signals
+	}
+	signals.hasContext = hasContextParam(funcType)
+
+	inspectGoroutineBody(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.DeferStmt:
+			signals.hasDefer = true
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "Done" || sel.Sel.Name == "Err") {
+				signals.hasContext = true
+			}
+		case *ast.CommClause:
+			switch receiveSource(node.Comm).(type) {
+			case *ast.CallExpr:
+				signals.selectsDone = signals.selectsDone || isDoneCall(receiveSource(node.Comm))
+			case *ast.Ident, *ast.SelectorExpr:
+				signals.hasCancelChannel = signals.hasCancelChannel || slices.ContainsFunc(node.Body, containsReturn)
+			}
+		}
+		return true
+	})
+	return signals
+}
+
+// hasContextParam reports whether a function takes a context.Context parameter
+func hasContextParam(funcType *ast.FuncType) bool {
+	if funcType == nil || funcType.Params == nil {
+		return false
+	}
+	for _, field := range funcType.Params.List {
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Context" {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "context" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// receiveSource returns the channel expression a select case receives from, or nil
+func receiveSource(comm ast.Stmt) ast.Expr {
+	var expr ast.Expr
+	switch stmt := comm.(type) {
+	case *ast.ExprStmt:
+		expr = stmt.X
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) == 1 {
+			expr = stmt.Rhs[0]
+		}
+	}
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.ARROW {
+		return unary.X
+	}
+	return nil
+}
+
+// isDoneCall reports whether expr is a call such as ctx.Done()
+func isDoneCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Done"
+}
+
+// LoopAnalysis records the statically evident ways out of a loop body. Breaks count only when
+// they leave the loop itself, not an inner loop, switch, or select.
+type LoopAnalysis struct {
+	HasBreak    bool // an unlabeled break at loop level, or a break naming the loop's label
+	HasReturn   bool // a return anywhere in the body outside nested function literals
+	HasDoneCase bool // a select case receiving from X.Done()
+}
+
+// HasExit reports whether the loop has any statically evident exit path
+func (la LoopAnalysis) HasExit() bool {
+	return la.HasBreak || la.HasReturn || la.HasDoneCase
+}
+
+// analyzeLoop collects the exit paths of a loop body. label is the loop's label, or empty.
+func analyzeLoop(body *ast.BlockStmt, label string) LoopAnalysis {
+	var analysis LoopAnalysis
+	var walk func(node ast.Node, nested bool)
+	walk = func(node ast.Node, nested bool) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch stmt := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				analysis.HasReturn = true
+			case *ast.BranchStmt:
+				if stmt.Tok == token.BREAK && ((stmt.Label == nil && !nested) || (stmt.Label != nil && stmt.Label.Name == label)) {
+					analysis.HasBreak = true
+				}
+			case *ast.CommClause:
+				analysis.HasDoneCase = analysis.HasDoneCase || isDoneCall(receiveSource(stmt.Comm))
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if n != node {
+					// unlabeled breaks inside bind to this statement, not the analyzed loop
+					walk(n, true)
+					return false
+				}
+			}
+			return true
+		})
+	}
+	walk(body, false)
+	return analysis
+}
+
+// hasInfiniteLoop reports whether body contains a loop with no statically evident exit path: a
+// for loop without a condition, or a range over a channel that is never closed in the file,
+// with no break out of the loop, return, or select on ctx.Done(). It also describes the loop.
+func (ca *ConcurrencyAnalyzer) hasInfiniteLoop(body *ast.BlockStmt) (bool, string) {
+	description := ""
+	labels := make(map[ast.Stmt]string)
+	inspectGoroutineBody(body, func(n ast.Node) bool {
+		if description != "" {
+			return false
+		}
+		switch stmt := n.(type) {
+		case *ast.LabeledStmt:
+			labels[stmt.Stmt] = stmt.Label.Name
+		case *ast.ForStmt:
+			if stmt.Cond == nil && !analyzeLoop(stmt.Body, labels[stmt]).HasExit() {
+				description = "infinite for loop"
+			}
+		case *ast.RangeStmt:
+			name := channelName(stmt.X)
+			if ca.isFileChannel(name) && !ca.fileIndex.closed[name] && !analyzeLoop(stmt.Body, labels[stmt]).HasExit() {
+				description = fmt.Sprintf("range over channel '%s' that is never closed", name)
+			}
+		}
+		return description == ""
+	})
+	return description != "", description
+}
+
+// isFileChannel reports whether name is a channel created or sent to in the file
+func (ca *ConcurrencyAnalyzer) isFileChannel(name string) bool {
+	return name != "" && (ca.fileIndex.unbuffered[name] || ca.fileIndex.sent[name])
+}
+
+// findBlockingReceive returns the name of an unbuffered channel the goroutine receives from
+// outside a select when nothing in the file sends to or closes it, so the receive never completes
+func (ca *ConcurrencyAnalyzer) findBlockingReceive(body *ast.BlockStmt) string {
+	blocked := ""
+	inspectGoroutineBody(body, func(n ast.Node) bool {
+		if blocked != "" {
+			return false
+		}
+		if _, ok := n.(*ast.SelectStmt); ok {
+			return false
+		}
+		if unary, ok := n.(*ast.UnaryExpr); ok && unary.Op == token.ARROW {
+			name := channelName(unary.X)
+			if ca.fileIndex.unbuffered[name] && !ca.fileIndex.sent[name] && !ca.fileIndex.closed[name] {
+				blocked = name
+			}
+		}
+		return true
+	})
+	return blocked
+}
+
+// checkGoroutineLeak warns about goroutines that may never exit: an unbounded loop with no
+// select on ctx.Done() and no deferred cleanup, or a receive from an unbuffered channel that
+// is never sent to or closed. Loop risk rises with each missing exit signal (deferred cleanup,
+// a context, and a cancellation channel): one missing is low, two medium, and all three high.
+func (ca *ConcurrencyAnalyzer) checkGoroutineLeak(goStmt *ast.GoStmt, body *ast.BlockStmt, signals goroutineExitSignals, concurrency *metrics.ConcurrencyPatternMetrics, fileName, functionName string) {
+	if body == nil {
+		return
+	}
+	pos := ca.fset.Position(goStmt.Pos())
+
+	if channel := ca.findBlockingReceive(body); channel != "" {
+		concurrency.Goroutines.GoroutineLeaks = append(concurrency.Goroutines.GoroutineLeaks, metrics.GoroutineLeakWarning{
+			File:           fileName,
+			Line:           pos.Line,
+			Function:       functionName,
+			RiskLevel:      "high",
+			Description:    fmt.Sprintf("Goroutine blocks forever receiving from unbuffered channel '%s', which is never sent to or closed", channel),
+			Recommendation: "Send on or close the channel, or receive inside a select with a cancellation case",
+		})
+		return
+	}
+
+	infinite, description := ca.hasInfiniteLoop(body)
+	if !infinite || signals.selectsDone || signals.hasDefer {
+		return
+	}
+
+	missing := signals.missing()
+	riskLevel := "low"
+	switch {
+	case missing >= 3:
+		riskLevel = "high"
+	case missing == 2:
+		riskLevel = "medium"
+	}
+
+	concurrency.Goroutines.GoroutineLeaks = append(concurrency.Goroutines.GoroutineLeaks, metrics.GoroutineLeakWarning{
+		File:           fileName,
+		Line:           pos.Line,
+		Function:       functionName,
+		RiskLevel:      riskLevel,
+		Description:    fmt.Sprintf("Goroutine with %s and no exit signal (missing %s)", description, strings.Join(missingSignalNames(signals), ", ")),
+		Recommendation: "Ensure proper exit conditions using context, channels, or other signaling mechanisms",
+	})
+}
+
+// missingSignalNames lists the exit signals a goroutine lacks
+func missingSignalNames(signals goroutineExitSignals) []string {
+	var names []string
+	if !signals.hasDefer {
+		names = append(names, "deferred cleanup")
+	}
+	if !signals.hasContext {
+		names = append(names, "context")
+	}
+	if !signals.hasCancelChannel {
+		names = append(names, "cancellation channel")
+	}
+	return names
+}
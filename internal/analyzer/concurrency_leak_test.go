@@ -0,0 +1,254 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_GoroutineLeakDetection(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		wantRisk     string // empty when no leak should be reported
+		wantInDesc   string
+		wantFunc     string
+		wantHasDefer bool
+	}{
+		{
+			name: "blocks forever on unbuffered channel read",
+			code: `package main
+
+func main() {
+	ch := make(chan int)
+	go func() {
+		v := <-ch
+		_ = v
+	}()
+}`,
+			wantRisk:   "high",
+			wantInDesc: "unbuffered channel 'ch'",
+			wantFunc:   "main",
+		},
+		{
+			name: "range over channel that is never closed",
+			code: `package main
+
+func produce() {
+	jobs := make(chan int)
+	go func() {
+		for job := range jobs {
+			_ = job
+		}
+	}()
+	jobs <- 1
+}`,
+			wantRisk:   "high",
+			wantInDesc: "range over channel 'jobs' that is never closed",
+			wantFunc:   "produce",
+		},
+		{
+			name: "range over channel that is closed",
+			code: `package main
+
+func produce() {
+	jobs := make(chan int)
+	go func() {
+		for job := range jobs {
+			_ = job
+		}
+	}()
+	jobs <- 1
+	close(jobs)
+}`,
+		},
+		{
+			name: "loop selecting on ctx.Done",
+			code: `package main
+
+import "context"
+
+func run(ctx context.Context) {
+	go func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+		}
+	}(ctx)
+}`,
+		},
+		{
+			name: "infinite loop with context but no cancellation",
+			code: `package main
+
+import "context"
+
+func run(ctx context.Context) {
+	go func(ctx context.Context) {
+		for {
+			work(ctx)
+		}
+	}(ctx)
+}
+
+func work(ctx context.Context) {}`,
+			wantRisk:   "medium",
+			wantInDesc: "missing deferred cleanup, cancellation channel",
+			wantFunc:   "run",
+		},
+		{
+			name: "loop returning on quit channel",
+			code: `package main
+
+import "context"
+
+func run(ctx context.Context, quit chan struct{}, results chan int) {
+	go func(ctx context.Context) {
+		for {
+			select {
+			case <-quit:
+				return
+			case v := <-results:
+				_ = v
+			}
+		}
+	}(ctx)
+}`,
+		},
+		{
+			name: "infinite loop after waiting on quit channel",
+			code: `package main
+
+import "context"
+
+func run(ctx context.Context, quit, start chan struct{}) {
+	go func(ctx context.Context) {
+		select {
+		case <-quit:
+			return
+		case <-start:
+		}
+		for {
+			work(ctx)
+		}
+	}(ctx)
+}
+
+func work(ctx context.Context) {}`,
+			wantRisk:   "low",
+			wantInDesc: "missing deferred cleanup)",
+			wantFunc:   "run",
+		},
+		{
+			name: "named worker with deferred cleanup",
+			code: `package main
+
+import "sync"
+
+func start(wg *sync.WaitGroup) {
+	go worker(wg)
+}
+
+func worker(wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+	}
+}`,
+			wantHasDefer: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, parser.ParseComments)
+			require.NoError(t, err)
+
+			result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+			require.NoError(t, err)
+			require.Len(t, result.Goroutines.Instances, 1)
+			assert.Equal(t, tt.wantHasDefer, result.Goroutines.Instances[0].HasDefer)
+
+			if tt.wantRisk == "" {
+				assert.Empty(t, result.Goroutines.GoroutineLeaks)
+				return
+			}
+			require.Len(t, result.Goroutines.GoroutineLeaks, 1)
+			leak := result.Goroutines.GoroutineLeaks[0]
+			assert.Equal(t, tt.wantRisk, leak.RiskLevel)
+			assert.Contains(t, leak.Description, tt.wantInDesc)
+			assert.Equal(t, tt.wantFunc, leak.Function)
+			assert.Greater(t, leak.Line, 0)
+		})
+	}
+}
+
+func TestAnalyzeLoop(t *testing.T) {
+	tests := []struct {
+		name     string
+		loop     string
+		want     LoopAnalysis
+		wantExit bool
+	}{
+		{
+			name:     "select on ctx.Done with return",
+			loop:     "for { select { case <-ctx.Done(): return } }",
+			want:     LoopAnalysis{HasReturn: true, HasDoneCase: true},
+			wantExit: true,
+		},
+		{
+			name: "work without exit",
+			loop: "for { doWork() }",
+		},
+		{
+			name:     "conditional break",
+			loop:     "for { if done() { break } }",
+			want:     LoopAnalysis{HasBreak: true},
+			wantExit: true,
+		},
+		{
+			name: "break leaves only the inner select",
+			loop: "for { select { case <-ch: break } }",
+		},
+		{
+			name: "break leaves only the inner loop",
+			loop: "for { for i := 0; i < 3; i++ { break } }",
+		},
+		{
+			name:     "labeled break from inner switch",
+			loop:     "outer: for { switch { default: break outer } }",
+			want:     LoopAnalysis{HasBreak: true},
+			wantExit: true,
+		},
+		{
+			name: "return inside a closure",
+			loop: "for { f := func() { return }; f() }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package test\nfunc example() {\n" + tt.loop + "\n}"
+			file, err := parser.ParseFile(token.NewFileSet(), "test.go", src, 0)
+			require.NoError(t, err)
+
+			body := file.Decls[0].(*ast.FuncDecl).Body
+			label := ""
+			stmt := body.List[0]
+			if labeled, ok := stmt.(*ast.LabeledStmt); ok {
+				label, stmt = labeled.Label.Name, labeled.Stmt
+			}
+
+			got := analyzeLoop(stmt.(*ast.ForStmt).Body, label)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantExit, got.HasExit())
+		})
+	}
+}
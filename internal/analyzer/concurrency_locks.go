@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// lockState is how a lock variable is held at a point in a function's straight-line statements
+type lockState int
+
+const (
+	lockReleased lockState = iota
+	lockHeld
+	lockReadHeld
+)
+
+// lockCall is a Lock, RLock, Unlock or RUnlock call on a variable
+type lockCall struct {
+	variable string
+	method   string
+}
+
+// analyzeLockBalance checks the lock calls of a function declaration and of each function
+// literal in it. Functions whose name contains "lock" are skipped, since wrappers such as
+// lock() or Unlock() acquire and release on behalf of their callers.
+func (ca *ConcurrencyAnalyzer) analyzeLockBalance(funcDecl *ast.FuncDecl, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
+	if strings.Contains(strings.ToLower(funcDecl.Name.Name), "lock") {
+		return
+	}
+
+	function := ca.getCurrentFunction()
+	ca.checkLockBalance(funcDecl.Body, function, concurrency, fileName)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			ca.checkLockBalance(lit.Body, function, concurrency, fileName)
+		}
+		return true
+	})
+}
+
+// checkLockBalance follows the top-level statements of body and warns about a Lock or RLock
+// whose variable is never unlocked anywhere in body, and about a Lock on a variable that an
+// earlier statement still holds. Any statement that unlocks the variable, however deeply
+// nested, counts as releasing it, and locks inside branches or loops are not tracked; this
+// trades missed cases for few false positives. Lock states are not followed across calls.
+func (ca *ConcurrencyAnalyzer) checkLockBalance(body *ast.BlockStmt, function string, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
+	unlocked := unlockedVariables(body)
+	held := make(map[string]lockState)
+
+	for _, stmt := range body.List {
+		call, ok := topLevelLockCall(stmt)
+		if !ok {
+			// A nested unlock, for instance on an early-return branch, may release any held lock
+			for variable := range unlockedVariables(stmt) {
+				delete(held, variable)
+			}
+			continue
+		}
+
+		line := ca.fset.Position(stmt.Pos()).Line
+		switch call.method {
+		case "Lock", "RLock":
+			if call.method == "Lock" && held[call.variable] != lockReleased {
+				concurrency.SyncWarnings = append(concurrency.SyncWarnings, metrics.SyncWarning{
+					File:        fileName,
+					Line:        line,
+					Function:    function,
+					Variable:    call.variable,
+					Type:        "double_lock",
+					RiskLevel:   "high",
+					Description: fmt.Sprintf("%s.Lock() while %s is still held by an earlier lock in the same function (deadlock)", call.variable, call.variable),
+				})
+			}
+			if !unlocked[call.variable] {
+				concurrency.SyncWarnings = append(concurrency.SyncWarnings, metrics.SyncWarning{
+					File:        fileName,
+					Line:        line,
+					Function:    function,
+					Variable:    call.variable,
+					Type:        "missing_unlock",
+					RiskLevel:   "medium",
+					Description: fmt.Sprintf("%s.%s() has no matching unlock or deferred unlock in the function", call.variable, call.method),
+				})
+			}
+			if call.method == "Lock" {
+				held[call.variable] = lockHeld
+			} else if held[call.variable] == lockReleased {
+				held[call.variable] = lockReadHeld
+			}
+		default:
+			delete(held, call.variable)
+		}
+	}
+}
+
+// topLevelLockCall returns the lock call a statement consists of: a plain x.Lock() style call,
+// or a deferred x.Unlock() style call, which releases the lock from the caller's point of view
+func topLevelLockCall(stmt ast.Stmt) (lockCall, bool) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		return asLockCall(s.X)
+	case *ast.DeferStmt:
+		call, ok := asLockCall(s.Call)
+		if ok && (call.method == "Unlock" || call.method == "RUnlock") {
+			return call, true
+		}
+	}
+	return lockCall{}, false
+}
+
+// asLockCall returns the variable and method of a call to Lock, RLock, Unlock or RUnlock
+func asLockCall(expr ast.Expr) (lockCall, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return lockCall{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return lockCall{}, false
+	}
+	switch sel.Sel.Name {
+	case "Lock", "RLock", "Unlock", "RUnlock":
+		return lockCall{variable: types.ExprString(sel.X), method: sel.Sel.Name}, true
+	}
+	return lockCall{}, false
+}
+
+// unlockedVariables returns the variables Unlock or RUnlock is called on anywhere within node,
+// including deferred calls and function literals
+func unlockedVariables(node ast.Node) map[string]bool {
+	unlocked := make(map[string]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		if expr, ok := n.(ast.Expr); ok {
+			if call, ok := asLockCall(expr); ok && (call.method == "Unlock" || call.method == "RUnlock") {
+				unlocked[call.variable] = true
+			}
+		}
+		return true
+	})
+	return unlocked
+}
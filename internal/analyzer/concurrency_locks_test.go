@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_LockBalanceWarnings(t *testing.T) {
+	code := `package main
+
+import "sync"
+
+type Cache struct {
+	mu    sync.RWMutex
+	items map[string]int
+}
+
+func (c *Cache) Forget(key string) {
+	c.mu.Lock()
+	delete(c.items, key)
+}
+
+func (c *Cache) Set(key string, v int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = v
+}
+
+func (c *Cache) Get(key string) (int, bool) {
+	c.mu.RLock()
+	v, ok := c.items[key]
+	c.mu.RUnlock()
+	return v, ok
+}
+
+func (c *Cache) Reset() {
+	c.mu.Lock()
+	if c.items == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.items = map[string]int{}
+	c.mu.Unlock()
+}
+
+func (c *Cache) Upgrade(key string) {
+	c.mu.RLock()
+	_, ok := c.items[key]
+	c.mu.Lock()
+	if !ok {
+		c.items[key] = 0
+	}
+	c.mu.Unlock()
+}
+
+func (c *Cache) lockAll() {
+	c.mu.Lock()
+}
+
+func worker(mu *sync.Mutex, jobs <-chan int) {
+	go func() {
+		mu.Lock()
+		for range jobs {
+		}
+	}()
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+
+	type warning struct {
+		function, variable, kind, risk string
+		line                           int
+	}
+	var got []warning
+	for _, w := range result.SyncWarnings {
+		assert.Equal(t, "test.go", w.File)
+		assert.NotEmpty(t, w.Description)
+		got = append(got, warning{w.Function, w.Variable, w.Type, w.RiskLevel, w.Line})
+	}
+
+	assert.Equal(t, []warning{
+		{"Cache.Forget", "c.mu", "missing_unlock", "medium", 11},
+		{"Cache.Upgrade", "c.mu", "double_lock", "high", 41},
+		{"worker", "mu", "missing_unlock", "medium", 54},
+	}, got, "deferred, straight-line and branch unlocks balance their locks; lock helpers are skipped")
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"go/ast"
+)
+
+// pipelineParticipant is the channel flow of one function body. For bodies launched as
+// goroutines, parameters are replaced by the channels passed at the go statement.
+type pipelineParticipant struct {
+	flow *channelFlow
+	line int // line of the go statement, or 0 for bodies that are not launched
+}
+
+// isStage reports whether a launched body receives from one channel and sends to another
+func (p pipelineParticipant) isStage() bool {
+	if p.line == 0 {
+		return false
+	}
+	for received := range p.flow.receives {
+		for sent := range p.flow.sends {
+			if received != sent {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pipelineTopology summarizes how the goroutines of a file are chained by channels
+type pipelineTopology struct {
+	stages   int  // goroutines that receive from one channel and send to another
+	fed      bool // a stage's input is sent to by another function body
+	consumed bool // a stage's output is received by another function body
+	members  int  // stages plus the bodies that feed or consume them
+	channels int  // distinct channels entering or leaving a stage
+	line     int  // line of the first stage's go statement
+}
+
+// buildPipelineTopology links the channel flows recorded by binder: each go statement whose
+// function resolves in the file contributes its body's flow, and every other function body
+// contributes its own sends and receives as a potential producer or consumer
+func (ca *ConcurrencyAnalyzer) buildPipelineTopology(binder *channelBinder) *pipelineTopology {
+	var participants []pipelineParticipant
+	launched := make(map[*ast.BlockStmt]bool)
+	for _, launch := range binder.launches {
+		_, body := ca.goroutineFunc(launch.stmt.Call)
+		if body == nil {
+			continue
+		}
+		launched[body] = true
+		if flow := binder.flows[body]; flow != nil {
+			participants = append(participants, pipelineParticipant{
+				flow: substituteChannelArgs(flow, binder.params[body], launch.args),
+				line: ca.fset.Position(launch.stmt.Pos()).Line,
+			})
+		}
+	}
+	for body, flow := range binder.flows {
+		if !launched[body] {
+			participants = append(participants, pipelineParticipant{flow: flow})
+		}
+	}
+
+	topology := &pipelineTopology{}
+	members := make(map[int]bool)
+	channels := make(map[channelKey]bool)
+	for i, stage := range participants {
+		if !stage.isStage() {
+			continue
+		}
+		topology.stages++
+		if topology.line == 0 || stage.line < topology.line {
+			topology.line = stage.line
+		}
+		members[i] = true
+		for key := range stage.flow.receives {
+			channels[key] = true
+			for j, other := range participants {
+				if j != i && other.flow.sends[key] {
+					topology.fed = true
+					members[j] = true
+				}
+			}
+		}
+		for key := range stage.flow.sends {
+			channels[key] = true
+			for j, other := range participants {
+				if j != i && other.flow.receives[key] {
+					topology.consumed = true
+					members[j] = true
+				}
+			}
+		}
+	}
+	topology.members = len(members)
+	topology.channels = len(channels)
+	return topology
+}
+
+// substituteChannelArgs rewrites a flow in terms of the caller's channels, replacing each
+// parameter key with the key of the argument passed for it
+func substituteChannelArgs(flow *channelFlow, params []channelKey, args []*channelKey) *channelFlow {
+	substitutes := make(map[channelKey]channelKey)
+	for i, param := range params {
+		if i < len(args) && args[i] != nil && param.name != "" {
+			substitutes[param] = *args[i]
+		}
+	}
+	if len(substitutes) == 0 {
+		return flow
+	}
+
+	substituted := newChannelFlow()
+	for key := range flow.sends {
+		if arg, ok := substitutes[key]; ok {
+			key = arg
+		}
+		substituted.sends[key] = true
+	}
+	for key := range flow.receives {
+		if arg, ok := substitutes[key]; ok {
+			key = arg
+		}
+		substituted.receives[key] = true
+	}
+	return substituted
+}
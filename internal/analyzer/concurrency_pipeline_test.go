@@ -0,0 +1,151 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_PipelineTopology(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		wantFound   bool
+		wantDesc    string
+		wantMinConf float64
+	}{
+		{
+			name: "three-stage pipeline",
+			code: `package main
+
+func run() {
+	numbers := make(chan int)
+	squares := make(chan int)
+	labels := make(chan string)
+
+	go func() {
+		defer close(numbers)
+		for i := 0; i < 10; i++ {
+			numbers <- i
+		}
+	}()
+	go func() {
+		defer close(squares)
+		for n := range numbers {
+			squares <- n * n
+		}
+	}()
+	go func() {
+		defer close(labels)
+		for s := range squares {
+			labels <- format(s)
+		}
+	}()
+	for label := range labels {
+		println(label)
+	}
+}
+
+func format(v int) string { return "" }
+`,
+			wantFound:   true,
+			wantDesc:    "Pipeline with 4 stages and 3 channels",
+			wantMinConf: 0.95,
+		},
+		{
+			name: "named stage functions wired by arguments",
+			code: `package main
+
+func run(input []int) {
+	raw := make(chan int)
+	doubled := make(chan int)
+	go produce(input, raw)
+	go double(raw, doubled)
+	for v := range doubled {
+		println(v)
+	}
+}
+
+func produce(input []int, out chan<- int) {
+	for _, v := range input {
+		out <- v
+	}
+	close(out)
+}
+
+func double(in <-chan int, out chan<- int) {
+	for v := range in {
+		out <- v * 2
+	}
+	close(out)
+}
+`,
+			wantFound:   true,
+			wantDesc:    "Pipeline with 3 stages and 2 channels",
+			wantMinConf: 0.85,
+		},
+		{
+			name: "independent channels",
+			code: `package main
+
+func run() {
+	ticks := make(chan int)
+	errs := make(chan error)
+	done := make(chan struct{})
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			ticks <- i
+		}
+	}()
+	go func() {
+		errs <- nil
+	}()
+	go func() {
+		close(done)
+	}()
+
+	<-ticks
+	<-errs
+	<-done
+}
+`,
+		},
+		{
+			name: "lone stage without producer or consumer",
+			code: `package main
+
+func run(jobs <-chan int, results chan<- int) {
+	go func() {
+		for job := range jobs {
+			results <- job
+		}
+	}()
+	go func() {}()
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			require.NoError(t, err)
+
+			result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test")
+			require.NoError(t, err)
+
+			if !tt.wantFound {
+				assert.Empty(t, result.Pipelines)
+				return
+			}
+			require.Len(t, result.Pipelines, 1)
+			assert.Equal(t, tt.wantDesc, result.Pipelines[0].Description)
+			assert.GreaterOrEqual(t, result.Pipelines[0].ConfidenceScore, tt.wantMinConf)
+		})
+	}
+}
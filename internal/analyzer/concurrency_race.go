@@ -0,0 +1,265 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// detectConcurrentMapWrites flags map variables written from goroutines without a mutex in scope.
+// Two shapes are recognized: a goroutine function literal writing a captured map, and a named
+// function launched as a goroutine several times (or inside a loop) that writes a package-level map.
+// A Lock call anywhere in the goroutine body counts as synchronization. The check is advisory
+// because it relies on declarations visible in the file and cannot follow aliasing.
+func (ca *ConcurrencyAnalyzer) detectConcurrentMapWrites(file *ast.File, concurrency *metrics.ConcurrencyPatternMetrics) {
+	pkgMaps := collectPackageMapVars(file)
+	funcDecls := make(map[string]*ast.FuncDecl)
+	launches := make(map[string]int)
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		if funcDecl.Recv == nil {
+			funcDecls[funcDecl.Name.Name] = funcDecl
+		}
+
+		maps := collectFunctionMapVars(funcDecl, pkgMaps)
+		ca.walkGoStmts(funcDecl.Body, false, func(goStmt *ast.GoStmt, inLoop bool) {
+			switch fn := goStmt.Call.Fun.(type) {
+			case *ast.FuncLit:
+				ca.checkGoroutineMapWrites(fn.Body, excludeLocalNames(maps, fn), funcDecl.Name.Name, goStmt.Pos(), concurrency)
+			case *ast.Ident:
+				launches[fn.Name]++
+				if inLoop {
+					launches[fn.Name]++
+				}
+			}
+		})
+	}
+
+	names := make([]string, 0, len(launches))
+	for name := range launches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		funcDecl, ok := funcDecls[name]
+		if !ok || launches[name] < 2 {
+			continue
+		}
+		ca.checkGoroutineMapWrites(funcDecl.Body, excludeLocalNames(pkgMaps, funcDecl), name, funcDecl.Pos(), concurrency)
+	}
+}
+
+// walkGoStmts calls visit for every go statement under node, reporting whether it sits inside a loop
+func (ca *ConcurrencyAnalyzer) walkGoStmts(node ast.Node, inLoop bool, visit func(*ast.GoStmt, bool)) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.ForStmt:
+			ca.walkGoStmts(stmt.Body, true, visit)
+			return false
+		case *ast.RangeStmt:
+			ca.walkGoStmts(stmt.Body, true, visit)
+			return false
+		case *ast.GoStmt:
+			visit(stmt, inLoop)
+		}
+		return true
+	})
+}
+
+// checkGoroutineMapWrites records one warning per map variable written in body when body holds no lock.
+// The risk is medium rather than high because the check cannot see locks held by callers or
+// goroutines confined by other means.
+func (ca *ConcurrencyAnalyzer) checkGoroutineMapWrites(body *ast.BlockStmt, maps map[string]bool, functionName string, pos token.Pos, concurrency *metrics.ConcurrencyPatternMetrics) {
+	if body == nil || len(maps) == 0 || containsLockCall(body) {
+		return
+	}
+
+	written := findMapWrites(body, maps)
+	position := ca.fset.Position(pos)
+	for _, name := range written {
+		concurrency.Goroutines.DataRaces = append(concurrency.Goroutines.DataRaces, metrics.DataRaceWarning{
+			File:           position.Filename,
+			Line:           position.Line,
+			Function:       functionName,
+			Variable:       name,
+			RiskLevel:      "medium",
+			Description:    fmt.Sprintf("Map '%s' is written from a goroutine without a mutex in scope", name),
+			Recommendation: "Guard map writes with a sync.Mutex, use sync.Map, or confine the map to a single goroutine",
+		})
+	}
+}
+
+// collectPackageMapVars returns the names of package-level variables declared with a map type or value
+func collectPackageMapVars(file *ast.File) map[string]bool {
+	maps := make(map[string]bool)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				addMapValueSpec(valueSpec, maps)
+			}
+		}
+	}
+	return maps
+}
+
+// collectFunctionMapVars returns package maps plus the map parameters and locals of funcDecl
+func collectFunctionMapVars(funcDecl *ast.FuncDecl, pkgMaps map[string]bool) map[string]bool {
+	maps := make(map[string]bool, len(pkgMaps))
+	for name := range pkgMaps {
+		maps[name] = true
+	}
+
+	if funcDecl.Type.Params != nil {
+		for _, field := range funcDecl.Type.Params.List {
+			if _, ok := field.Type.(*ast.MapType); ok {
+				for _, name := range field.Names {
+					maps[name.Name] = true
+				}
+			}
+		}
+	}
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if node.Tok != token.DEFINE || len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && isMapExpr(node.Rhs[i]) {
+					maps[ident.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			addMapValueSpec(node, maps)
+		}
+		return true
+	})
+
+	return maps
+}
+
+// addMapValueSpec records the names in a var spec that have a map type or map-valued initializer
+func addMapValueSpec(spec *ast.ValueSpec, maps map[string]bool) {
+	_, typedMap := spec.Type.(*ast.MapType)
+	for i, name := range spec.Names {
+		if typedMap || (i < len(spec.Values) && isMapExpr(spec.Values[i])) {
+			maps[name.Name] = true
+		}
+	}
+}
+
+// isMapExpr reports whether expr is a make(map...) call or a map composite literal
+func isMapExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		_, ok := e.Type.(*ast.MapType)
+		return ok
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "make" && len(e.Args) > 0 {
+			_, ok := e.Args[0].(*ast.MapType)
+			return ok
+		}
+	}
+	return false
+}
+
+// excludeLocalNames returns maps without the names declared as parameters or locals of node,
+// since those shadow the outer map variables
+func excludeLocalNames(maps map[string]bool, node ast.Node) map[string]bool {
+	shadowed := make(map[string]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch decl := n.(type) {
+		case *ast.Field:
+			for _, name := range decl.Names {
+				shadowed[name.Name] = true
+			}
+		case *ast.AssignStmt:
+			if decl.Tok == token.DEFINE {
+				for _, lhs := range decl.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						shadowed[ident.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range decl.Names {
+				shadowed[name.Name] = true
+			}
+		}
+		return true
+	})
+
+	remaining := make(map[string]bool, len(maps))
+	for name := range maps {
+		if !shadowed[name] {
+			remaining[name] = true
+		}
+	}
+	return remaining
+}
+
+// findMapWrites returns the sorted names of maps assigned to, incremented, or deleted from in body
+func findMapWrites(body *ast.BlockStmt, maps map[string]bool) []string {
+	written := make(map[string]bool)
+	record := func(expr ast.Expr) {
+		if index, ok := expr.(*ast.IndexExpr); ok {
+			if ident, ok := index.X.(*ast.Ident); ok && maps[ident.Name] {
+				written[ident.Name] = true
+			}
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range stmt.Lhs {
+				record(lhs)
+			}
+		case *ast.IncDecStmt:
+			record(stmt.X)
+		case *ast.CallExpr:
+			if ident, ok := stmt.Fun.(*ast.Ident); ok && ident.Name == "delete" && len(stmt.Args) > 0 {
+				if target, ok := stmt.Args[0].(*ast.Ident); ok && maps[target.Name] {
+					written[target.Name] = true
+				}
+			}
+		}
+		return true
+	})
+
+	names := make([]string, 0, len(written))
+	for name := range written {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// containsLockCall reports whether body calls a Lock method on any value
+func containsLockCall(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Lock" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyAnalyzer_ConcurrentMapWrites(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		expectRaces  int
+		expectedMaps []string
+		description  string
+	}{
+		{
+			name: "unsynchronized write in goroutine",
+			code: `package main
+
+func count(words []string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range words {
+		go func(w string) {
+			counts[w]++
+		}(w)
+	}
+	return counts
+}`,
+			expectRaces:  1,
+			expectedMaps: []string{"counts"},
+			description:  "Captured map incremented from goroutine without a lock",
+		},
+		{
+			name: "mutex guarded write",
+			code: `package main
+
+import "sync"
+
+func count(words []string) map[string]int {
+	var mu sync.Mutex
+	counts := make(map[string]int)
+	for _, w := range words {
+		go func(w string) {
+			mu.Lock()
+			defer mu.Unlock()
+			counts[w]++
+		}(w)
+	}
+	return counts
+}`,
+			expectRaces: 0,
+			description: "Lock in goroutine body counts as synchronization",
+		},
+		{
+			name: "delete from captured map",
+			code: `package main
+
+func prune(cache map[string]int, keys []string) {
+	go func() {
+		for _, k := range keys {
+			delete(cache, k)
+		}
+	}()
+}`,
+			expectRaces:  1,
+			expectedMaps: []string{"cache"},
+			description:  "delete on a map parameter from goroutine",
+		},
+		{
+			name: "map local to goroutine",
+			code: `package main
+
+func run() {
+	go func() {
+		seen := map[string]bool{}
+		seen["x"] = true
+	}()
+}`,
+			expectRaces: 0,
+			description: "Maps declared inside the goroutine are not shared",
+		},
+		{
+			name: "named function launched in loop writes package map",
+			code: `package main
+
+var registry = map[int]string{}
+
+func register(id int) {
+	registry[id] = "worker"
+}
+
+func main() {
+	for i := 0; i < 4; i++ {
+		go register(i)
+	}
+}`,
+			expectRaces:  1,
+			expectedMaps: []string{"registry"},
+			description:  "Function called from multiple goroutines writes a package-level map",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			require.NoError(t, err)
+
+			result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "main")
+			require.NoError(t, err)
+
+			races := result.Goroutines.DataRaces
+			assert.Len(t, races, tt.expectRaces, tt.description)
+			for i, name := range tt.expectedMaps {
+				if i < len(races) {
+					assert.Equal(t, name, races[i].Variable)
+					assert.Equal(t, "test.go", races[i].File)
+					assert.Equal(t, "medium", races[i].RiskLevel, "the heuristic is advisory")
+				}
+			}
+		})
+	}
+}
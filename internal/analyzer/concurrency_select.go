@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"go/ast"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// analyzeSelect records a select statement with its case structure and whether it runs inside a loop
+func (ca *ConcurrencyAnalyzer) analyzeSelect(selectStmt *ast.SelectStmt, concurrency *metrics.ConcurrencyPatternMetrics, fileName string) {
+	instance := metrics.SelectInstance{
+		File:     fileName,
+		Line:     ca.fset.Position(selectStmt.Pos()).Line,
+		Function: ca.getCurrentFunction(),
+		InLoop:   ca.selectInLoop(),
+	}
+
+	for _, stmt := range selectStmt.Body.List {
+		clause, ok := stmt.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+		if clause.Comm == nil {
+			instance.HasDefault = true
+			continue
+		}
+		instance.CaseCount++
+		source := receiveSource(clause.Comm)
+		instance.HasCancelCase = instance.HasCancelCase || isDoneCall(source)
+		instance.HasTimeoutCase = instance.HasTimeoutCase || isTimeoutChannel(source)
+	}
+
+	if instance.CaseCount == 0 && !instance.HasDefault {
+		instance.Warning = "empty select blocks forever"
+	}
+
+	concurrency.SelectStatements = append(concurrency.SelectStatements, instance)
+}
+
+// selectInLoop reports whether the select being visited runs inside a for or range loop of its
+// own function; loops outside an enclosing function literal do not count
+func (ca *ConcurrencyAnalyzer) selectInLoop() bool {
+	for i := len(ca.path) - 2; i >= 0; i-- {
+		switch ca.path[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		}
+	}
+	return false
+}
+
+// isTimeoutChannel reports whether expr is a time.After or time.Tick call or the C channel of a
+// timer or ticker
+func isTimeoutChannel(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		return ok && pkg.Name == "time" && (sel.Sel.Name == "After" || sel.Sel.Name == "Tick")
+	case *ast.SelectorExpr:
+		return e.Sel.Name == "C"
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+func TestConcurrencyAnalyzer_SelectStatements(t *testing.T) {
+	code := `package main
+
+import (
+	"context"
+	"time"
+)
+
+func trySend(ch chan<- int, v int) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+func waitResult(ctx context.Context, results <-chan int) (int, error) {
+	select {
+	case r := <-results:
+		return r, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(time.Second):
+		return 0, context.DeadlineExceeded
+	}
+}
+
+func poll(ticker *time.Ticker, events <-chan string) {
+	for {
+		select {
+		case <-ticker.C:
+		case e := <-events:
+			go func() {
+				select {
+				case <-time.Tick(time.Minute):
+				}
+				_ = e
+			}()
+		}
+	}
+}
+
+func main() {
+	select {}
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, 0)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+
+	require.Len(t, result.SelectStatements, 5)
+	assert.Equal(t, metrics.SelectInstance{
+		File: "test.go", Line: 9, Function: "trySend", CaseCount: 1, HasDefault: true,
+	}, result.SelectStatements[0], "a select with default never blocks")
+	assert.Equal(t, metrics.SelectInstance{
+		File: "test.go", Line: 18, Function: "waitResult", CaseCount: 3, HasCancelCase: true, HasTimeoutCase: true,
+	}, result.SelectStatements[1], "a blocking select bounded by cancellation and a timeout")
+
+	polling := result.SelectStatements[2]
+	assert.Equal(t, 2, polling.CaseCount)
+	assert.True(t, polling.HasTimeoutCase, "a ticker's C channel is a timeout case")
+	assert.True(t, polling.InLoop)
+
+	nested := result.SelectStatements[3]
+	assert.True(t, nested.HasTimeoutCase)
+	assert.False(t, nested.InLoop, "the loop is outside the goroutine's function literal")
+
+	empty := result.SelectStatements[4]
+	assert.Equal(t, "main", empty.Function)
+	assert.Zero(t, empty.CaseCount)
+	assert.Equal(t, "empty select blocks forever", empty.Warning)
+	for _, s := range result.SelectStatements[:4] {
+		assert.Empty(t, s.Warning)
+	}
+}
@@ -146,14 +146,16 @@ func (m *MyStruct) Method() {}`
 	// Check first goroutine (anonymous)
 	firstGoroutine := result.Goroutines.Instances[0]
 	assert.True(t, firstGoroutine.IsAnonymous)
-	assert.Equal(t, "anonymous", firstGoroutine.Function)
+	assert.Equal(t, "main", firstGoroutine.Function)
+	assert.Equal(t, "anonymous function", firstGoroutine.Context)
 	assert.Equal(t, "test.go", firstGoroutine.File)
 	assert.Greater(t, firstGoroutine.Line, 0)
 
 	// Check second goroutine (named function)
 	secondGoroutine := result.Goroutines.Instances[1]
 	assert.False(t, secondGoroutine.IsAnonymous)
-	assert.Equal(t, "namedFunction", secondGoroutine.Function)
+	assert.Equal(t, "main", secondGoroutine.Function)
+	assert.Equal(t, "namedFunction", secondGoroutine.Context)
 }
 
 func TestConcurrencyAnalyzer_ChannelDetection(t *testing.T) {
@@ -580,3 +582,65 @@ func main() {
 		require.NoError(b, err)
 	}
 }
+
+func TestConcurrencyAnalyzer_EnclosingFunctionNames(t *testing.T) {
+	code := `package main
+
+import "sync"
+
+var events = make(chan string, 8)
+
+var registry sync.Mutex
+
+type Server struct{}
+
+func (s *Server) Serve() {
+	done := make(chan bool)
+	go func() {
+		done <- true
+	}()
+	var wg sync.WaitGroup
+	wg.Wait()
+}
+
+func process() {
+	go func() {
+		inner := func() {
+			results := make(chan int)
+			go func() {
+				results <- 1
+			}()
+		}
+		inner()
+	}()
+}
+
+func main() {
+	go process()
+}`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	require.NoError(t, err)
+
+	result, err := NewConcurrencyAnalyzer(fset).AnalyzeConcurrency(file, "test.go")
+	require.NoError(t, err)
+
+	channelFuncs := make(map[int]string)
+	for _, ch := range result.Channels.Instances {
+		channelFuncs[ch.Line] = ch.Function
+	}
+	assert.Equal(t, map[int]string{5: "<package>", 12: "Server.Serve", 23: "process"}, channelFuncs)
+
+	goroutineFuncs := make(map[int]string)
+	for _, g := range result.Goroutines.Instances {
+		goroutineFuncs[g.Line] = g.Function
+	}
+	assert.Equal(t, map[int]string{13: "Server.Serve", 21: "process", 24: "process", 33: "main"}, goroutineFuncs,
+		"goroutines in nested literals are attributed to the innermost named function")
+
+	require.Len(t, result.SyncPrims.Mutexes, 1)
+	assert.Equal(t, "<package>", result.SyncPrims.Mutexes[0].Function)
+	require.Len(t, result.SyncPrims.WaitGroups, 1)
+	assert.Equal(t, "Server.Serve", result.SyncPrims.WaitGroups[0].Function)
+}
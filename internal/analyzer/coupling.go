@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// SetModule records the module the analyzed files belong to. Once set, only imports under
+// modulePath count as internal dependencies, and imports are matched to analyzed packages by
+// the import path their directory has relative to moduleRoot. Without a module the analyzer
+// falls back to prefix heuristics and directory-suffix matching.
+func (pa *PackageAnalyzer) SetModule(modulePath, moduleRoot string) {
+	pa.modulePath = modulePath
+	pa.moduleRoot = moduleRoot
+}
+
+// SetNestedModules records the modules rooted inside the analyzed module's directory. Imports
+// of their packages are external to the analyzed module despite sharing its path prefix.
+func (pa *PackageAnalyzer) SetNestedModules(modules []metrics.NestedModule) {
+	pa.nestedModules = modules
+}
+
+// isModuleImport reports whether importPath refers to a package of the analyzed module
+func (pa *PackageAnalyzer) isModuleImport(importPath string) bool {
+	if pa.modulePath == "" {
+		return isInternalPackage(importPath)
+	}
+	if !hasPathPrefix(importPath, pa.modulePath) {
+		return false
+	}
+	for _, nested := range pa.nestedModules {
+		if hasPathPrefix(importPath, nested.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasPathPrefix reports whether importPath is prefix or a package below it
+func hasPathPrefix(importPath, prefix string) bool {
+	return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+}
+
+// packageImportPath derives the import path of pkgName from its directory and the module root,
+// or returns "" when no module is set or the directory lies outside it
+func (pa *PackageAnalyzer) packageImportPath(pkgName string) string {
+	dir, ok := pa.packageDirs[pkgName]
+	if !ok || pa.modulePath == "" || pa.moduleRoot == "" {
+		return ""
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(pa.moduleRoot, absDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	for _, nested := range pa.nestedModules {
+		if absDir == nested.Dir || strings.HasPrefix(absDir, nested.Dir+string(filepath.Separator)) {
+			return ""
+		}
+	}
+	if rel == "." {
+		return pa.modulePath
+	}
+	return pa.modulePath + "/" + filepath.ToSlash(rel)
+}
+
+// resolveImport maps an import path to the analyzed package it refers to, or "" when the
+// imported package was not part of the analysis. Packages whose import path is known match
+// exactly; otherwise the package whose directory shares the most trailing path segments with
+// the import path wins, preferring the non-test package of a directory on ties.
+func (pa *PackageAnalyzer) resolveImport(importPath string) string {
+	names := make([]string, 0, len(pa.packageDirs))
+	for name := range pa.packageDirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best, bestScore := "", 0
+	for _, name := range names {
+		score := 0
+		if pkgPath := pa.packageImportPath(name); pkgPath != "" {
+			if pkgPath == importPath {
+				score = len(strings.Split(importPath, "/")) + 1
+			}
+		} else {
+			score = trailingSegmentMatch(importPath, pa.packageDirs[name])
+		}
+		isTestPkg := strings.HasSuffix(name, "_test")
+		if score > bestScore || (score == bestScore && score > 0 && strings.HasSuffix(best, "_test") && !isTestPkg) {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+// trailingSegmentMatch counts how many trailing segments of the slash-separated importPath
+// equal the trailing segments of dir
+func trailingSegmentMatch(importPath, dir string) int {
+	importSegments := strings.Split(importPath, "/")
+	dirSegments := strings.Split(filepath.ToSlash(filepath.Clean(dir)), "/")
+
+	count := 0
+	for i, j := len(importSegments)-1, len(dirSegments)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if importSegments[i] != dirSegments[j] || dirSegments[j] == "." || dirSegments[j] == "" {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// buildDependents inverts the import graph: for every analyzed package it lists, sorted, the
+// analyzed packages that import it. Aliased, dot, and blank imports all count, since each
+// makes the importer depend on the imported package.
+func (pa *PackageAnalyzer) buildDependents() map[string][]string {
+	dependents := make(map[string][]string)
+	for pkgName, deps := range pa.packageDeps {
+		for _, dep := range deps {
+			target := pa.resolveImport(dep)
+			if target == "" || target == pkgName {
+				continue
+			}
+			dependents[target] = mergeUniqueStrings(dependents[target], []string{pkgName})
+		}
+	}
+	return dependents
+}
+
+// PackageInstability computes Robert Martin's instability metric I = Ce/(Ca+Ce) from the
+// efferent (outgoing) and afferent (incoming) coupling of a package: 0 for a maximally stable
+// package that only others depend on, 1 for one that depends on others while nothing depends on
+// it. An isolated package scores 0.
+func PackageInstability(efferent, afferent int) float64 {
+	if efferent+afferent == 0 {
+		return 0.0
+	}
+	return float64(efferent) / float64(efferent+afferent)
+}
@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// suppressedLinters are the nolint linter names that exempt a function from complexity checks
+var suppressedLinters = map[string]bool{
+	"all":        true,
+	"complexity": true,
+	"cyclop":     true,
+	"gocognit":   true,
+	"gocyclo":    true,
+}
+
+// isSuppressed reports whether funcDecl carries a suppression directive in its doc comment or in
+// a comment on the line just before the func keyword. A bare //nolint, a //nolint list naming
+// complexity, gocyclo, gocognit, cyclop or all, and //stats:ignore all count. Suppressed
+// functions are still measured but raise no anti-pattern warnings or threshold failures.
+func isSuppressed(fset *token.FileSet, file *ast.File, funcDecl *ast.FuncDecl) bool {
+	if hasSuppressionDirective(funcDecl.Doc) {
+		return true
+	}
+	funcLine := fset.Position(funcDecl.Pos()).Line
+	for _, group := range file.Comments {
+		if fset.Position(group.End()).Line == funcLine-1 && hasSuppressionDirective(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSuppressionDirective reports whether any comment in group is a suppression directive
+func hasSuppressionDirective(group *ast.CommentGroup) bool {
+	if group == nil {
+		return false
+	}
+	for _, comment := range group.List {
+		if isSuppressionDirective(comment.Text) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuppressionDirective parses a single //nolint or //stats:ignore comment. An explanation after
+// the directive, as in //nolint:gocyclo // state machine, is ignored.
+func isSuppressionDirective(text string) bool {
+	directive, ok := strings.CutPrefix(text, "//")
+	if !ok {
+		return false
+	}
+	directive, _, _ = strings.Cut(strings.TrimSpace(directive), " ")
+	if directive == "stats:ignore" || directive == "nolint" {
+		return true
+	}
+	linters, ok := strings.CutPrefix(directive, "nolint:")
+	if !ok {
+		return false
+	}
+	for _, linter := range strings.Split(linters, ",") {
+		if suppressedLinters[strings.TrimSpace(linter)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSuppressionDirective(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"//nolint", true},
+		{"//nolint:gocyclo", true},
+		{"//nolint:errcheck,complexity", true},
+		{"//nolint:gocognit // parser state machine", true},
+		{"//stats:ignore", true},
+		{"// stats:ignore", true},
+		{"//nolint:errcheck", false},
+		{"//nolintfoo", false},
+		{"// Process handles nolint cases", false},
+		{"/* nolint */", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isSuppressionDirective(tt.text), tt.text)
+	}
+}
+
+func TestSuppressedFunctionsAreMeasuredButNotWarned(t *testing.T) {
+	src := `package lib
+
+// Documented explains itself
+//nolint:complexity
+func Documented(data string) {
+	panic(data)
+}
+
+//stats:ignore
+func directive(data string) {
+	panic(data)
+}
+
+func Plain(data string) {
+	panic(data)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "lib.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	functions, err := NewFunctionAnalyzer(fset).AnalyzeFunctions(file, "lib")
+	require.NoError(t, err)
+	require.Len(t, functions, 3, "suppressed functions are still counted")
+	suppressed := make(map[string]bool)
+	for _, fn := range functions {
+		suppressed[fn.Name] = fn.Suppressed
+	}
+	assert.Equal(t, map[string]bool{"Documented": true, "directive": true, "Plain": false}, suppressed)
+
+	patterns := NewAntipatternAnalyzer(fset).Analyze(file)
+	require.NotEmpty(t, patterns)
+	for _, pattern := range patterns {
+		assert.Equal(t, 15, pattern.Line, "only Plain raises warnings, got %s", pattern.Type)
+	}
+}
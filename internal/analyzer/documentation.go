@@ -17,6 +17,19 @@ type DocumentationAnalyzer struct {
 	severityClassifier map[string]string
 }
 
+// annotation is a single marker parsed from one line of a comment
+type annotation struct {
+	category    string
+	author      string
+	description string
+}
+
+// hackReasonRegex captures the justification that follows "because" or "due to" in a HACK
+var hackReasonRegex = regexp.MustCompile(`(?i)\b(?:because|due to)\b\s*(.*)`)
+
+// urgentFixmeKeywords mark a FIXME as critical rather than a plain violation
+var urgentFixmeKeywords = []string{"urgent", "critical", "asap", "security", "crash", "panic", "data loss"}
+
 // DocumentationConfig contains configuration for documentation analysis
 type DocumentationConfig struct {
 	RequireExportedDoc  bool
@@ -42,7 +55,7 @@ func NewDocumentationAnalyzer(fset *token.FileSet, cfg *DocumentationConfig) *Do
 	return &DocumentationAnalyzer{
 		fset:            fset,
 		cfg:             cfg,
-		annotationRegex: regexp.MustCompile(`(?i)(TODO|FIXME|HACK|BUG|XXX|DEPRECATED|NOTE)[\s:]+(.*)`),
+		annotationRegex: regexp.MustCompile(`(?i)\b(TODO|FIXME|HACK|BUG|XXX|DEPRECATED|NOTE)(?:\(([^)]*)\))?[\s:]+(.*)`),
 		severityClassifier: map[string]string{
 			"FIXME":      string(metrics.SeverityLevelCritical),
 			"BUG":        string(metrics.SeverityLevelCritical),
@@ -70,32 +83,11 @@ type DocFileInfo struct {
 // annotation markers (TODO/FIXME/BUG/HACK/DEPRECATED), computes quality scores based on comment
 // length and content, and identifies undocumented exported symbols. Returns complete documentation metrics.
 func (d *DocumentationAnalyzer) Analyze(files []*ast.File, pkgs map[string]*ast.Package) *metrics.DocumentationMetrics {
-	m := &metrics.DocumentationMetrics{
-		Coverage:              metrics.DocumentationCoverage{},
-		Quality:               metrics.DocumentationQuality{},
-		TODOComments:          []metrics.TODOComment{},
-		FIXMEComments:         []metrics.FIXMEComment{},
-		HACKComments:          []metrics.HACKComment{},
-		BUGComments:           []metrics.BUGComment{},
-		XXXComments:           []metrics.XXXComment{},
-		DEPRECATEDComments:    []metrics.DEPRECATEDComment{},
-		NOTEComments:          []metrics.NOTEComment{},
-		AnnotationsByCategory: make(map[string]int),
+	fileInfos := make([]DocFileInfo, len(files))
+	for i, file := range files {
+		fileInfos[i] = DocFileInfo{File: file, Fset: d.fset, Path: d.fset.Position(file.Pos()).Filename}
 	}
-
-	// Analyze exported symbols for documentation coverage
-	d.analyzeExportedSymbols(files, m)
-
-	// Analyze package-level documentation
-	d.analyzePackageDocs(files, pkgs, m)
-
-	// Analyze annotations (TODO, FIXME, HACK, etc.)
-	d.analyzeAnnotations(files, m)
-
-	// Analyze documentation quality
-	d.analyzeQuality(files, m)
-
-	return m
+	return d.AnalyzeWithFileSets(fileInfos, pkgs)
 }
 
 // AnalyzeWithFileSets performs the same documentation analysis as Analyze, but accepts files
@@ -103,74 +95,86 @@ func (d *DocumentationAnalyzer) Analyze(files []*ast.File, pkgs map[string]*ast.
 // FileSets (to avoid shared-mutex contention) so that annotation line numbers are resolved
 // against the correct position table rather than a stale shared FileSet.
 func (d *DocumentationAnalyzer) AnalyzeWithFileSets(fileInfos []DocFileInfo, pkgs map[string]*ast.Package) *metrics.DocumentationMetrics {
-	m := &metrics.DocumentationMetrics{
-		Coverage:              metrics.DocumentationCoverage{},
-		Quality:               metrics.DocumentationQuality{},
-		TODOComments:          []metrics.TODOComment{},
-		FIXMEComments:         []metrics.FIXMEComment{},
-		HACKComments:          []metrics.HACKComment{},
-		BUGComments:           []metrics.BUGComment{},
-		XXXComments:           []metrics.XXXComment{},
-		DEPRECATEDComments:    []metrics.DEPRECATEDComment{},
-		NOTEComments:          []metrics.NOTEComment{},
-		AnnotationsByCategory: make(map[string]int),
-	}
-
-	files := make([]*ast.File, len(fileInfos))
-	for i, fi := range fileInfos {
-		files[i] = fi.File
+	collector := d.NewCollector()
+	for _, fi := range fileInfos {
+		collector.Add(fi)
+	}
+	return collector.Metrics()
+}
+
+// DocumentationCollector accumulates the documentation analysis one file at a time, so callers
+// can add each file while its AST is at hand and let it be reclaimed before the next one
+type DocumentationCollector struct {
+	d *DocumentationAnalyzer
+	m *metrics.DocumentationMetrics
+
+	totalFuncs, documentedFuncs     int
+	totalTypes, documentedTypes     int
+	totalMethods, documentedMethods int
+	// pkgSeen and pkgDocs record, by package name, the packages seen and those with a doc comment
+	pkgSeen, pkgDocs map[string]bool
+	quality          qualityStats
+}
+
+// NewCollector returns an empty collector that analyzes files with the settings of d
+func (d *DocumentationAnalyzer) NewCollector() *DocumentationCollector {
+	return &DocumentationCollector{
+		d: d,
+		m: &metrics.DocumentationMetrics{
+			Coverage:              metrics.DocumentationCoverage{},
+			Quality:               metrics.DocumentationQuality{},
+			TODOComments:          []metrics.TODOComment{},
+			FIXMEComments:         []metrics.FIXMEComment{},
+			HACKComments:          []metrics.HACKComment{},
+			BUGComments:           []metrics.BUGComment{},
+			XXXComments:           []metrics.XXXComment{},
+			DEPRECATEDComments:    []metrics.DEPRECATEDComment{},
+			NOTEComments:          []metrics.NOTEComment{},
+			AnnotationsByCategory: make(map[string]int),
+		},
+		pkgSeen: make(map[string]bool),
+		pkgDocs: make(map[string]bool),
 	}
-
-	d.analyzeExportedSymbols(files, m)
-	d.analyzePackageDocs(files, pkgs, m)
-	d.analyzeAnnotationsPerFile(fileInfos, m)
-	d.analyzeQuality(files, m)
-
-	return m
 }
 
-// analyzeAnnotationsPerFile scans comments for annotations, using each file's own FileSet
-// for accurate line-number resolution.
-func (d *DocumentationAnalyzer) analyzeAnnotationsPerFile(fileInfos []DocFileInfo, m *metrics.DocumentationMetrics) {
-	for _, fi := range fileInfos {
-		d.scanFileCommentsWithFset(fi.File, fi.Fset, fi.Path, m)
+// Add counts the exported symbols, package doc, annotations, and comments of one file.
+// Annotations are listed in the order files are added.
+func (c *DocumentationCollector) Add(fi DocFileInfo) {
+	ast.Inspect(fi.File, func(n ast.Node) bool {
+		c.d.processNode(n, &c.totalFuncs, &c.documentedFuncs, &c.totalTypes, &c.documentedTypes,
+			&c.totalMethods, &c.documentedMethods)
+		return true
+	})
+
+	pkgName := fi.File.Name.Name
+	c.pkgSeen[pkgName] = true
+	if c.d.hasPackageDoc(fi.File) {
+		c.pkgDocs[pkgName] = true
 	}
-}
 
-// scanFileCommentsWithFset processes all comment groups in a file using the provided FileSet.
-func (d *DocumentationAnalyzer) scanFileCommentsWithFset(file *ast.File, fset *token.FileSet, filePath string, m *metrics.DocumentationMetrics) {
-	for _, cg := range file.Comments {
+	for _, cg := range fi.File.Comments {
 		for _, comment := range cg.List {
-			d.processCommentWithFset(comment, fset, filePath, m)
+			c.d.recordAnnotations(comment, fi.Fset.Position(comment.Pos()).Line, fi.Path, c.m)
 		}
+		c.d.processCommentGroup(cg, &c.quality)
 	}
 }
 
-// processCommentWithFset extracts and categorizes an annotation using the provided FileSet for line lookup.
-func (d *DocumentationAnalyzer) processCommentWithFset(comment *ast.Comment, fset *token.FileSet, filePath string, m *metrics.DocumentationMetrics) {
-	category, description := d.extractAnnotation(comment.Text)
-	if category == "" {
-		return
-	}
-	line := fset.Position(comment.Pos()).Line
-	m.AnnotationsByCategory[category]++
-	d.addAnnotationToMetrics(category, filePath, line, description, m)
-}
-
-// analyzeExportedSymbols checks documentation coverage for exported symbols
-func (d *DocumentationAnalyzer) analyzeExportedSymbols(files []*ast.File, m *metrics.DocumentationMetrics) {
-	var totalFuncs, documentedFuncs int
-	var totalTypes, documentedTypes int
-	var totalMethods, documentedMethods int
+// Metrics computes the coverage and quality of every file added so far
+func (c *DocumentationCollector) Metrics() *metrics.DocumentationMetrics {
+	c.d.calculateCoverageMetrics(c.m, c.totalFuncs, c.documentedFuncs, c.totalTypes, c.documentedTypes,
+		c.totalMethods, c.documentedMethods)
 
-	for _, file := range files {
-		ast.Inspect(file, func(n ast.Node) bool {
-			d.processNode(n, &totalFuncs, &documentedFuncs, &totalTypes, &documentedTypes, &totalMethods, &documentedMethods)
-			return true
-		})
+	documented := 0
+	for pkgName := range c.pkgSeen {
+		if c.pkgDocs[pkgName] {
+			documented++
+		}
 	}
+	c.m.Coverage.Packages = calculatePercentage(documented, len(c.pkgSeen))
 
-	d.calculateCoverageMetrics(m, totalFuncs, documentedFuncs, totalTypes, documentedTypes, totalMethods, documentedMethods)
+	c.d.populateQualityMetrics(&c.quality, c.m)
+	return c.m
 }
 
 // processNode processes a single AST node for documentation analysis
@@ -258,47 +262,54 @@ func (d *DocumentationAnalyzer) checkExportedDoc(name string, doc *ast.CommentGr
 
 // extractAnnotation parses annotation comments (TODO, FIXME, etc.)
 func (d *DocumentationAnalyzer) extractAnnotation(comment string) (category, description string) {
-	matches := d.annotationRegex.FindStringSubmatch(comment)
-	if len(matches) < 3 {
+	a, ok := d.parseAnnotation(comment)
+	if !ok {
 		return "", ""
 	}
-
-	category = strings.ToUpper(matches[1])
-	description = strings.TrimSpace(matches[2])
-	return category, description
+	return a.category, a.description
 }
 
-// getSeverity returns severity classification for an annotation
-func (d *DocumentationAnalyzer) getSeverity(category string) metrics.SeverityLevel {
-	if severity, ok := d.severityClassifier[strings.ToUpper(category)]; ok {
-		return metrics.SeverityLevel(severity)
+// parseAnnotation parses a marker such as "TODO(alice): refactor this" into its category, the
+// optional author in parentheses, and the trailing description
+func (d *DocumentationAnalyzer) parseAnnotation(line string) (annotation, bool) {
+	matches := d.annotationRegex.FindStringSubmatch(line)
+	if len(matches) < 4 {
+		return annotation{}, false
 	}
-	return metrics.SeverityLevelInfo
+	return annotation{
+		category:    strings.ToUpper(matches[1]),
+		author:      strings.TrimSpace(matches[2]),
+		description: strings.TrimSpace(matches[3]),
+	}, true
 }
 
-// analyzePackageDocs checks for package-level documentation
-func (d *DocumentationAnalyzer) analyzePackageDocs(files []*ast.File, pkgs map[string]*ast.Package, m *metrics.DocumentationMetrics) {
-	pkgDocs := make(map[string]bool)
-	pkgSeen := make(map[string]bool)
-
-	for _, file := range files {
-		pkgName := file.Name.Name
-		if !pkgSeen[pkgName] {
-			pkgSeen[pkgName] = true
-		}
-		if d.hasPackageDoc(file) {
-			pkgDocs[pkgName] = true
+// fixmeSeverity guesses how pressing a FIXME is: critical when its description contains an
+// urgency keyword, otherwise a violation
+func fixmeSeverity(description string) metrics.SeverityLevel {
+	lower := strings.ToLower(description)
+	for _, keyword := range urgentFixmeKeywords {
+		if strings.Contains(lower, keyword) {
+			return metrics.SeverityLevelCritical
 		}
 	}
+	return metrics.SeverityLevelViolation
+}
 
-	documented := 0
-	for pkgName := range pkgSeen {
-		if pkgDocs[pkgName] {
-			documented++
-		}
+// hackReason returns the justification following "because" or "due to", or "" when none is given
+func hackReason(description string) string {
+	matches := hackReasonRegex.FindStringSubmatch(description)
+	if len(matches) < 2 {
+		return ""
 	}
+	return strings.TrimRight(strings.TrimSpace(matches[1]), ".")
+}
 
-	m.Coverage.Packages = calculatePercentage(documented, len(pkgSeen))
+// getSeverity returns severity classification for an annotation
+func (d *DocumentationAnalyzer) getSeverity(category string) metrics.SeverityLevel {
+	if severity, ok := d.severityClassifier[strings.ToUpper(category)]; ok {
+		return metrics.SeverityLevel(severity)
+	}
+	return metrics.SeverityLevelInfo
 }
 
 // hasPackageDoc checks if a file has package-level documentation
@@ -309,75 +320,54 @@ func (d *DocumentationAnalyzer) hasPackageDoc(file *ast.File) bool {
 	return false
 }
 
-// analyzeAnnotations scans all comments for annotations
-func (d *DocumentationAnalyzer) analyzeAnnotations(files []*ast.File, m *metrics.DocumentationMetrics) {
-	for _, file := range files {
-		filePath := d.fset.Position(file.Pos()).Filename
-		d.scanFileComments(file, filePath, m)
-	}
-}
-
-// scanFileComments processes all comment groups in a file
-func (d *DocumentationAnalyzer) scanFileComments(file *ast.File, filePath string, m *metrics.DocumentationMetrics) {
-	for _, cg := range file.Comments {
-		for _, comment := range cg.List {
-			d.processComment(comment, filePath, m)
+// recordAnnotations parses every line of a comment that starts on startLine, so each marker in
+// a multiline block comment is reported on its own line
+func (d *DocumentationAnalyzer) recordAnnotations(comment *ast.Comment, startLine int, filePath string, m *metrics.DocumentationMetrics) {
+	text := strings.TrimSuffix(comment.Text, "*/")
+	for i, line := range strings.Split(text, "\n") {
+		a, ok := d.parseAnnotation(line)
+		if !ok {
+			continue
 		}
+		m.AnnotationsByCategory[a.category]++
+		d.addAnnotationToMetrics(a, filePath, startLine+i, m)
 	}
 }
 
-// processComment extracts and categorizes an annotation
-func (d *DocumentationAnalyzer) processComment(comment *ast.Comment, filePath string, m *metrics.DocumentationMetrics) {
-	category, description := d.extractAnnotation(comment.Text)
-	if category == "" {
-		return
-	}
-
-	line := d.fset.Position(comment.Pos()).Line
-	m.AnnotationsByCategory[category]++
-	d.addAnnotationToMetrics(category, filePath, line, description, m)
-}
-
 // addAnnotationToMetrics appends the annotation to appropriate metrics list
-func (d *DocumentationAnalyzer) addAnnotationToMetrics(category, filePath string, line int, description string, m *metrics.DocumentationMetrics) {
-	switch category {
+func (d *DocumentationAnalyzer) addAnnotationToMetrics(a annotation, filePath string, line int, m *metrics.DocumentationMetrics) {
+	switch a.category {
 	case "TODO":
 		m.TODOComments = append(m.TODOComments, metrics.TODOComment{
-			File: filePath, Line: line, Description: description,
+			File: filePath, Line: line, Author: a.author, Description: a.description,
 		})
 	case "FIXME":
 		m.FIXMEComments = append(m.FIXMEComments, metrics.FIXMEComment{
-			File: filePath, Line: line, Description: description, Severity: d.getSeverity(category),
+			File: filePath, Line: line, Author: a.author, Description: a.description, Severity: fixmeSeverity(a.description),
 		})
 	case "HACK":
 		m.HACKComments = append(m.HACKComments, metrics.HACKComment{
-			File: filePath, Line: line, Description: description, Reason: description,
+			File: filePath, Line: line, Author: a.author, Description: a.description, Reason: hackReason(a.description),
 		})
 	case "BUG":
 		m.BUGComments = append(m.BUGComments, metrics.BUGComment{
-			File: filePath, Line: line, Description: description, Severity: d.getSeverity(category),
+			File: filePath, Line: line, Author: a.author, Description: a.description, Severity: d.getSeverity(a.category),
 		})
 	case "XXX":
 		m.XXXComments = append(m.XXXComments, metrics.XXXComment{
-			File: filePath, Line: line, Description: description,
+			File: filePath, Line: line, Author: a.author, Description: a.description,
 		})
 	case "DEPRECATED":
 		m.DEPRECATEDComments = append(m.DEPRECATEDComments, metrics.DEPRECATEDComment{
-			File: filePath, Line: line, Description: description,
+			File: filePath, Line: line, Author: a.author, Description: a.description,
 		})
 	case "NOTE":
 		m.NOTEComments = append(m.NOTEComments, metrics.NOTEComment{
-			File: filePath, Line: line, Description: description,
+			File: filePath, Line: line, Author: a.author, Description: a.description,
 		})
 	}
 }
 
-// analyzeQuality performs comprehensive quality analysis on documentation
-func (d *DocumentationAnalyzer) analyzeQuality(files []*ast.File, m *metrics.DocumentationMetrics) {
-	stats := d.collectQualityStats(files)
-	d.populateQualityMetrics(stats, m)
-}
-
 // qualityStats holds intermediate quality analysis statistics
 type qualityStats struct {
 	totalLength    int
@@ -387,22 +377,6 @@ type qualityStats struct {
 	codeExamples   int
 }
 
-// collectQualityStats gathers quality statistics from all files
-func (d *DocumentationAnalyzer) collectQualityStats(files []*ast.File) *qualityStats {
-	stats := &qualityStats{}
-	for _, file := range files {
-		d.processFileComments(file, stats)
-	}
-	return stats
-}
-
-// processFileComments analyzes all comment groups in a file
-func (d *DocumentationAnalyzer) processFileComments(file *ast.File, stats *qualityStats) {
-	for _, cg := range file.Comments {
-		d.processCommentGroup(cg, stats)
-	}
-}
-
 // processCommentGroup analyzes a single comment group
 func (d *DocumentationAnalyzer) processCommentGroup(cg *ast.CommentGroup, stats *qualityStats) {
 	commentText := cg.Text()
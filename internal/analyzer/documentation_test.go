@@ -707,3 +707,82 @@ func TestSeverityClassification(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotationAuthorSeverityAndReason(t *testing.T) {
+	source := `package test
+
+// TODO(alice): refactor this
+func Example1() {}
+
+// FIXME: urgent, breaks on empty input
+func Example2() {}
+
+// FIXME(bob): tidy the naming
+func Example3() {}
+
+// HACK: sleep before retrying because the upstream API rate limits.
+func Example4() {}
+
+// HACK: hard-coded path
+func Example5() {}
+
+// This is a footnote: not an annotation
+func Example6() {}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", source, parser.ParseComments)
+	require.NoError(t, err)
+
+	result := NewDocumentationAnalyzer(fset, nil).Analyze([]*ast.File{file}, nil)
+
+	require.Len(t, result.TODOComments, 1)
+	assert.Equal(t, metrics.TODOComment{File: "test.go", Line: 3, Author: "alice", Description: "refactor this"}, result.TODOComments[0])
+
+	require.Len(t, result.FIXMEComments, 2)
+	assert.Empty(t, result.FIXMEComments[0].Author)
+	assert.Equal(t, metrics.SeverityLevelCritical, result.FIXMEComments[0].Severity)
+	assert.Equal(t, "bob", result.FIXMEComments[1].Author)
+	assert.Equal(t, "tidy the naming", result.FIXMEComments[1].Description)
+	assert.Equal(t, metrics.SeverityLevelViolation, result.FIXMEComments[1].Severity)
+
+	require.Len(t, result.HACKComments, 2)
+	assert.Equal(t, "the upstream API rate limits", result.HACKComments[0].Reason)
+	assert.Empty(t, result.HACKComments[1].Reason)
+
+	assert.Empty(t, result.NOTEComments)
+}
+
+func TestAnnotationsInBlockComment(t *testing.T) {
+	source := `package test
+
+/*
+Package notes.
+
+TODO(carol): split this file
+ * FIXME: critical race on shutdown
+HACK: retry twice due to flaky DNS */
+func Example() {}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", source, parser.ParseComments)
+	require.NoError(t, err)
+
+	result := NewDocumentationAnalyzer(fset, nil).AnalyzeWithFileSets(
+		[]DocFileInfo{{File: file, Fset: fset, Path: "test.go"}}, nil)
+
+	require.Len(t, result.TODOComments, 1)
+	assert.Equal(t, 6, result.TODOComments[0].Line)
+	assert.Equal(t, "carol", result.TODOComments[0].Author)
+	assert.Equal(t, "split this file", result.TODOComments[0].Description)
+
+	require.Len(t, result.FIXMEComments, 1)
+	assert.Equal(t, 7, result.FIXMEComments[0].Line)
+	assert.Equal(t, metrics.SeverityLevelCritical, result.FIXMEComments[0].Severity)
+
+	require.Len(t, result.HACKComments, 1)
+	assert.Equal(t, 8, result.HACKComments[0].Line)
+	assert.Equal(t, "retry twice due to flaky DNS", result.HACKComments[0].Description)
+	assert.Equal(t, "flaky DNS", result.HACKComments[0].Reason)
+}
@@ -721,10 +721,14 @@ func filterSubsumedClonePairs(pairs []metrics.ClonePair) []metrics.ClonePair {
 	return kept
 }
 
-// sortPairsByLineCount sorts clone pairs by line count descending.
+// sortPairsByLineCount sorts clone pairs by line count descending, breaking ties by hash so
+// the subsumption filter keeps the same pairs on every run.
 func sortPairsByLineCount(pairs []metrics.ClonePair) {
 	sort.Slice(pairs, func(i, j int) bool {
-		return pairs[i].LineCount > pairs[j].LineCount
+		if pairs[i].LineCount != pairs[j].LineCount {
+			return pairs[i].LineCount > pairs[j].LineCount
+		}
+		return pairs[i].Hash < pairs[j].Hash
 	})
 }
 
@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// minHelperBodyStatements is the smallest function body compared against methods; one-statement
+// bodies are typically delegating wrappers such as validateUser(u) { return u.Validate() }
+const minHelperBodyStatements = 2
+
+// FunctionBody is the normalized body of a method, or of a free function taking a single
+// parameter of a named type, retained so helpers can be matched against methods after all
+// structs are known
+type FunctionBody struct {
+	Name      string
+	Package   string
+	File      string
+	Line      int
+	Type      string // receiver type for methods, sole parameter type for free functions
+	IsMethod  bool
+	Hash      string
+	Structure NormalizedBlock
+}
+
+// ExtractFunctionBodies fingerprints the bodies of methods and single-parameter free functions
+// using the same normalization as clone detection, so receiver and parameter names do not
+// affect the comparison
+func (da *DuplicationAnalyzer) ExtractFunctionBodies(file *ast.File, pkgName, filePath string) []FunctionBody {
+	var bodies []FunctionBody
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil || len(funcDecl.Body.List) < minHelperBodyStatements {
+			continue
+		}
+
+		typeName := GetMethodReceiverType(funcDecl)
+		isMethod := typeName != ""
+		if !isMethod {
+			typeName = soleParameterType(funcDecl)
+		}
+		if typeName == "" {
+			continue
+		}
+
+		normalized := da.NormalizeBlock(StatementBlock{
+			File:       filePath,
+			Statements: funcDecl.Body.List,
+			NodeCount:  CountNodes(funcDecl.Body.List),
+		})
+		bodies = append(bodies, FunctionBody{
+			Name:      funcDecl.Name.Name,
+			Package:   pkgName,
+			File:      filePath,
+			Line:      da.fset.Position(funcDecl.Pos()).Line,
+			Type:      typeName,
+			IsMethod:  isMethod,
+			Hash:      da.ComputeHash(normalized),
+			Structure: normalized,
+		})
+	}
+	return bodies
+}
+
+// soleParameterType returns the named type (T or *T) of a function's only parameter
+func soleParameterType(funcDecl *ast.FuncDecl) string {
+	params := funcDecl.Type.Params
+	if params == nil || len(params.List) != 1 || len(params.List[0].Names) > 1 {
+		return ""
+	}
+	return ExtractReceiverType(params.List[0].Type)
+}
+
+// DetectHelperDuplicates flags free functions whose sole parameter is a struct and whose body
+// matches one of that struct's methods, a common residue of moving logic onto a type without
+// deleting the original helper. Bodies with the same structural hash are identical apart from
+// names; otherwise the token similarity and node-count ratio must both reach the threshold.
+func (da *DuplicationAnalyzer) DetectHelperDuplicates(bodies []FunctionBody, structs []metrics.StructMetrics, similarityThreshold float64) []metrics.HelperDuplicate {
+	structKeys := make(map[string]bool, len(structs))
+	for _, s := range structs {
+		structKeys[helperTypeKey(s.File, s.Package, s.Name)] = true
+	}
+
+	methodsByType := make(map[string][]FunctionBody)
+	for _, body := range bodies {
+		if body.IsMethod {
+			key := helperTypeKey(body.File, body.Package, body.Type)
+			methodsByType[key] = append(methodsByType[key], body)
+		}
+	}
+
+	var duplicates []metrics.HelperDuplicate
+	for _, helper := range bodies {
+		key := helperTypeKey(helper.File, helper.Package, helper.Type)
+		if helper.IsMethod || !structKeys[key] {
+			continue
+		}
+		method, similarity := da.bestMatchingMethod(helper, methodsByType[key])
+		if similarity >= similarityThreshold {
+			duplicates = append(duplicates, buildHelperDuplicate(helper, method, similarity))
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].File != duplicates[j].File {
+			return duplicates[i].File < duplicates[j].File
+		}
+		return duplicates[i].Line < duplicates[j].Line
+	})
+	return duplicates
+}
+
+// helperTypeKey identifies a type by its directory, package, and name, since package names
+// alone are not unique across a repository
+func helperTypeKey(file, pkg, typeName string) string {
+	return filepath.Dir(file) + "|" + pkg + "|" + typeName
+}
+
+// bestMatchingMethod returns the method most similar to the helper and its similarity
+func (da *DuplicationAnalyzer) bestMatchingMethod(helper FunctionBody, methods []FunctionBody) (FunctionBody, float64) {
+	var best FunctionBody
+	bestSimilarity := 0.0
+	for _, method := range methods {
+		similarity := da.bodySimilarity(helper, method)
+		if similarity > bestSimilarity {
+			best, bestSimilarity = method, similarity
+		}
+	}
+	return best, bestSimilarity
+}
+
+// bodySimilarity is 1.0 for structurally identical bodies, and otherwise the lower of the token
+// similarity and node-count ratio, because token sets alone overlap heavily once identifiers
+// are normalized away
+func (da *DuplicationAnalyzer) bodySimilarity(a, b FunctionBody) float64 {
+	if a.Hash == b.Hash {
+		return 1.0
+	}
+	smaller, larger := float64(a.Structure.NodeCount), float64(b.Structure.NodeCount)
+	if smaller > larger {
+		smaller, larger = larger, smaller
+	}
+	sizeRatio := smaller / larger
+	return min(da.ComputeSimilarity(a.Structure, b.Structure), sizeRatio)
+}
+
+// buildHelperDuplicate describes a helper that repeats a method of its parameter's type
+func buildHelperDuplicate(helper, method FunctionBody, similarity float64) metrics.HelperDuplicate {
+	methodName := method.Type + "." + method.Name
+	severity := metrics.SeverityLevelInfo
+	if similarity == 1.0 {
+		severity = metrics.SeverityLevelWarning
+	}
+	return metrics.HelperDuplicate{
+		Function:   helper.Name,
+		Method:     methodName,
+		Type:       helper.Type,
+		Package:    helper.Package,
+		File:       helper.File,
+		Line:       helper.Line,
+		MethodFile: method.File,
+		MethodLine: method.Line,
+		Similarity: similarity,
+		Severity:   severity,
+		Suggestion: fmt.Sprintf("Remove %s and call %s instead; the bodies are %.0f%% similar", helper.Name, methodName, similarity*100),
+	}
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicationAnalyzer_DetectHelperDuplicates(t *testing.T) {
+	src := `package users
+
+import (
+	"errors"
+	"strings"
+)
+
+type User struct {
+	Name  string
+	Email string
+}
+
+func (u *User) Validate() error {
+	if u.Name == "" {
+		return errors.New("name is required")
+	}
+	if !strings.Contains(u.Email, "@") {
+		return errors.New("email is invalid")
+	}
+	return nil
+}
+
+func validateUser(user *User) error {
+	if user.Name == "" {
+		return errors.New("missing name")
+	}
+	if !strings.Contains(user.Email, "@") {
+		return errors.New("invalid email")
+	}
+	return nil
+}
+
+func checkUser(u *User) error {
+	return u.Validate()
+}
+
+func describeUser(u User) string {
+	parts := []string{u.Name}
+	for _, p := range strings.Split(u.Email, "@") {
+		parts = append(parts, strings.ToUpper(p))
+	}
+	return strings.Join(parts, " ")
+}
+
+type ID string
+
+func (id ID) Validate() error {
+	if id == "" {
+		return errors.New("empty id")
+	}
+	return nil
+}
+
+func validateID(id ID) error {
+	if id == "" {
+		return errors.New("empty id")
+	}
+	return nil
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "users.go", src, 0)
+	require.NoError(t, err)
+
+	da := NewDuplicationAnalyzer(fset)
+	bodies := da.ExtractFunctionBodies(file, "users", "users/users.go")
+	structs := []metrics.StructMetrics{{Name: "User", Package: "users", File: "users/users.go"}}
+
+	duplicates := da.DetectHelperDuplicates(bodies, structs, 0.80)
+
+	require.Len(t, duplicates, 1, "only the helper repeating a struct method should be flagged")
+	dup := duplicates[0]
+	assert.Equal(t, "validateUser", dup.Function)
+	assert.Equal(t, "User.Validate", dup.Method)
+	assert.Equal(t, "User", dup.Type)
+	assert.Equal(t, 23, dup.Line)
+	assert.Equal(t, 13, dup.MethodLine)
+	assert.Equal(t, 1.0, dup.Similarity, "bodies differing only in names and literals are structurally identical")
+	assert.Equal(t, metrics.SeverityLevelWarning, dup.Severity)
+	assert.Contains(t, dup.Suggestion, "User.Validate")
+
+	otherPackage := []metrics.StructMetrics{{Name: "User", Package: "users", File: "legacy/users.go"}}
+	assert.Empty(t, da.DetectHelperDuplicates(bodies, otherPackage, 0.80),
+		"a struct with the same name in another directory is a different type")
+}
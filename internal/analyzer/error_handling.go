@@ -0,0 +1,339 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// minBareErrorReturns is the number of bare error returns from which a function is listed
+const minBareErrorReturns = 2
+
+// pkgErrorsPath is the import path of github.com/pkg/errors
+const pkgErrorsPath = "github.com/pkg/errors"
+
+// pkgErrorsWrappers are the github.com/pkg/errors functions that add context to an error
+var pkgErrorsWrappers = map[string]bool{
+	"Wrap":         true,
+	"Wrapf":        true,
+	"WithMessage":  true,
+	"WithMessagef": true,
+	"WithStack":    true,
+}
+
+// errorReturnKind is how a return statement builds its error result
+type errorReturnKind int
+
+const (
+	errorReturnNone errorReturnKind = iota
+	errorReturnBare
+	errorReturnWrapped
+	errorReturnCreated
+	errorReturnSentinel
+	errorReturnOther
+)
+
+// AnalyzeErrorHandling counts how the functions of the production files that return an error
+// build the errors they return, per package directory, and lists the functions with at least
+// two bare error returns. Returns inside function literals count for the function declaring the
+// literal, classified by the literal's own results. Test files are skipped.
+func AnalyzeErrorHandling(files []BurdenFileInfo) *metrics.ErrorHandlingMetrics {
+	collector := NewErrorHandlingCollector()
+	for _, fi := range files {
+		collector.Add(fi)
+	}
+	return collector.Metrics()
+}
+
+// ErrorHandlingCollector accumulates the error handling analysis of AnalyzeErrorHandling one
+// file at a time, so a file's AST can be released once it has been added
+type ErrorHandlingCollector struct {
+	packages map[string]*metrics.PackageErrorHandling
+	bare     []metrics.BareErrorFunction
+}
+
+// NewErrorHandlingCollector returns an empty error handling collector
+func NewErrorHandlingCollector() *ErrorHandlingCollector {
+	return &ErrorHandlingCollector{
+		packages: make(map[string]*metrics.PackageErrorHandling),
+		bare:     []metrics.BareErrorFunction{},
+	}
+}
+
+// Add counts the error returns of one file, unless it is a test file
+func (c *ErrorHandlingCollector) Add(fi BurdenFileInfo) {
+	if fi.File == nil || isTestFile(fi.RelPath) {
+		return
+	}
+	dir := filepath.Dir(fi.RelPath)
+	pkg := c.packages[dir]
+	if pkg == nil {
+		pkg = &metrics.PackageErrorHandling{Package: fi.Pkg, Directory: dir}
+		c.packages[dir] = pkg
+	}
+
+	ea := errorReturnAnalyzer{fi: fi, imports: fileImports(fi.File), counts: &pkg.ErrorHandlingCounts}
+	ast.Inspect(fi.File, ea.countConstructorCall)
+	for _, decl := range fi.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		if bare := ea.analyzeFunction(fn); len(bare) >= minBareErrorReturns {
+			c.bare = append(c.bare, ea.bareErrorFunction(fn, bare))
+		}
+	}
+}
+
+// Metrics totals the counts of the files added so far
+func (c *ErrorHandlingCollector) Metrics() *metrics.ErrorHandlingMetrics {
+	result := &metrics.ErrorHandlingMetrics{
+		Packages:            []metrics.PackageErrorHandling{},
+		BareReturnFunctions: append([]metrics.BareErrorFunction{}, c.bare...),
+	}
+	for _, pkg := range c.packages {
+		pkg.UpdateWrapRatio()
+		result.Add(pkg.ErrorHandlingCounts)
+		result.Packages = append(result.Packages, *pkg)
+	}
+	sort.Slice(result.Packages, func(i, j int) bool {
+		return result.Packages[i].Directory < result.Packages[j].Directory
+	})
+	SortBareErrorFunctions(result.BareReturnFunctions)
+	return result
+}
+
+// SortBareErrorFunctions orders functions from the most bare error returns, then by position
+func SortBareErrorFunctions(functions []metrics.BareErrorFunction) {
+	sort.Slice(functions, func(i, j int) bool {
+		a, b := functions[i], functions[j]
+		if a.BareReturns != b.BareReturns {
+			return a.BareReturns > b.BareReturns
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+}
+
+// errorReturnAnalyzer classifies the error returns of one file into its package's counts
+type errorReturnAnalyzer struct {
+	fi      BurdenFileInfo
+	imports map[string]string
+	counts  *metrics.ErrorHandlingCounts
+}
+
+// countConstructorCall counts n if it is a call to errors.New, fmt.Errorf, or a
+// github.com/pkg/errors wrapper
+func (ea *errorReturnAnalyzer) countConstructorCall(n ast.Node) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return true
+	}
+	path, name, ok := ea.importedCall(call)
+	switch {
+	case !ok:
+	case name == "New" && (path == "errors" || path == pkgErrorsPath):
+		ea.counts.ErrorsNewCalls++
+	case path == "fmt" && name == "Errorf":
+		ea.counts.ErrorfCalls++
+		if formatWraps(call) {
+			ea.counts.ErrorfWrapCalls++
+		}
+	case path == pkgErrorsPath && pkgErrorsWrappers[name]:
+		ea.counts.PkgErrorsWrapCalls++
+	}
+	return true
+}
+
+// analyzeFunction counts the error returns of fn and of the function literals in it, and
+// returns the positions of the bare ones
+func (ea *errorReturnAnalyzer) analyzeFunction(fn *ast.FuncDecl) []token.Pos {
+	if returnsError(fn.Type) {
+		ea.counts.ErrorFunctions++
+	}
+	var bare []token.Pos
+	var walk func(body *ast.BlockStmt, results *ast.FieldList)
+	walk = func(body *ast.BlockStmt, results *ast.FieldList) {
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				walk(node.Body, node.Type.Results)
+				return false
+			case *ast.ReturnStmt:
+				if errorResultIndex(results) < 0 {
+					return true
+				}
+				kind := ea.classifyReturn(fn, node, results)
+				ea.count(kind)
+				if kind == errorReturnBare {
+					bare = append(bare, node.Pos())
+				}
+			}
+			return true
+		})
+	}
+	walk(fn.Body, fn.Type.Results)
+	return bare
+}
+
+// count adds a classified return to the counts
+func (ea *errorReturnAnalyzer) count(kind errorReturnKind) {
+	if kind == errorReturnNone {
+		return
+	}
+	ea.counts.ErrorReturns++
+	switch kind {
+	case errorReturnBare:
+		ea.counts.BareReturns++
+	case errorReturnWrapped:
+		ea.counts.WrappedReturns++
+	case errorReturnCreated:
+		ea.counts.CreatedReturns++
+	case errorReturnSentinel:
+		ea.counts.SentinelReturns++
+	default:
+		ea.counts.OtherReturns++
+	}
+}
+
+// classifyReturn tells how ret builds the error result of a function with the given results.
+// A naked return and a nil error are not error returns, and a single call returning all the
+// results, as in return f(), counts as other.
+func (ea *errorReturnAnalyzer) classifyReturn(fn *ast.FuncDecl, ret *ast.ReturnStmt, results *ast.FieldList) errorReturnKind {
+	if len(ret.Results) == 0 {
+		return errorReturnNone
+	}
+	index := errorResultIndex(results)
+	if len(ret.Results) <= index {
+		return errorReturnOther
+	}
+
+	switch expr := ast.Unparen(ret.Results[index]).(type) {
+	case *ast.Ident:
+		if expr.Name == "nil" && expr.Obj == nil {
+			return errorReturnNone
+		}
+		if expr.Obj != nil && expr.Obj.Pos() >= fn.Pos() && expr.Obj.Pos() < fn.End() {
+			return errorReturnBare
+		}
+		return errorReturnSentinel
+	case *ast.SelectorExpr:
+		if pkg, ok := expr.X.(*ast.Ident); ok && pkg.Obj == nil && ea.imports[pkg.Name] != "" {
+			return errorReturnSentinel
+		}
+	case *ast.CallExpr:
+		return ea.classifyCall(expr)
+	case *ast.CompositeLit:
+		return errorReturnCreated
+	case *ast.UnaryExpr:
+		if _, ok := expr.X.(*ast.CompositeLit); ok && expr.Op == token.AND {
+			return errorReturnCreated
+		}
+	}
+	return errorReturnOther
+}
+
+// classifyCall tells whether a returned call wraps an error, creates one, or does neither
+func (ea *errorReturnAnalyzer) classifyCall(call *ast.CallExpr) errorReturnKind {
+	path, name, ok := ea.importedCall(call)
+	switch {
+	case !ok:
+		return errorReturnOther
+	case path == "fmt" && name == "Errorf":
+		if formatWraps(call) {
+			return errorReturnWrapped
+		}
+		return errorReturnCreated
+	case path == "errors" && name == "New":
+		return errorReturnCreated
+	case path == pkgErrorsPath && pkgErrorsWrappers[name]:
+		return errorReturnWrapped
+	case path == pkgErrorsPath && (name == "New" || name == "Errorf"):
+		return errorReturnCreated
+	}
+	return errorReturnOther
+}
+
+// importedCall returns the import path and function name of a pkg.Func(...) call
+func (ea *errorReturnAnalyzer) importedCall(call *ast.CallExpr) (string, string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Obj != nil {
+		return "", "", false
+	}
+	path, ok := ea.imports[pkg.Name]
+	return path, sel.Sel.Name, ok
+}
+
+// bareErrorFunction describes fn as a function with the given bare error returns, as a violation
+// when it has more than twice the minimum
+func (ea *errorReturnAnalyzer) bareErrorFunction(fn *ast.FuncDecl, bare []token.Pos) metrics.BareErrorFunction {
+	severity := metrics.SeverityLevelWarning
+	if len(bare) > 2*minBareErrorReturns {
+		severity = metrics.SeverityLevelViolation
+	}
+	lines := make([]int, len(bare))
+	for i, pos := range bare {
+		lines[i] = ea.fi.Fset.Position(pos).Line
+	}
+
+	name := fn.Name.Name
+	receiver := GetMethodReceiverType(fn)
+	if receiver != "" {
+		name = receiver + "." + name
+	}
+	return metrics.BareErrorFunction{
+		Name:         fn.Name.Name,
+		ReceiverType: receiver,
+		Package:      ea.fi.Pkg,
+		File:         ea.fi.RelPath,
+		Line:         ea.fi.Fset.Position(fn.Pos()).Line,
+		BareReturns:  len(bare),
+		ReturnLines:  lines,
+		Severity:     severity,
+		Suggestion: fmt.Sprintf("Wrap the %d bare error returns of '%s' with fmt.Errorf(\"...: %%w\", err) so callers can tell which call failed",
+			len(bare), name),
+	}
+}
+
+// returnsError reports whether the last result of a function type is an error
+func returnsError(fn *ast.FuncType) bool {
+	return errorResultIndex(fn.Results) >= 0
+}
+
+// errorResultIndex returns the position of the error result among the results, which must be
+// the last, or -1 when the last result is not an error
+func errorResultIndex(results *ast.FieldList) int {
+	if results == nil || len(results.List) == 0 {
+		return -1
+	}
+	last, ok := results.List[len(results.List)-1].Type.(*ast.Ident)
+	if !ok || last.Name != "error" {
+		return -1
+	}
+	return results.NumFields() - 1
+}
+
+// formatWraps reports whether the format string of a fmt.Errorf call is a literal with a %w verb
+func formatWraps(call *ast.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return false
+	}
+	format, err := strconv.Unquote(lit.Value)
+	return err == nil && strings.Contains(format, "%w")
+}
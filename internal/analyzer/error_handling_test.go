@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// analyzeErrorSources analyzes the error handling of the given sources
+func analyzeErrorSources(t *testing.T, sources map[string]string) *metrics.ErrorHandlingMetrics {
+	t.Helper()
+	_, _, files := analyzeInterfaceSources(t, sources)
+	return AnalyzeErrorHandling(files)
+}
+
+// bareFunctionNames lists the names of the functions with bare error returns in order
+func bareFunctionNames(result *metrics.ErrorHandlingMetrics) []string {
+	names := make([]string, len(result.BareReturnFunctions))
+	for i, fn := range result.BareReturnFunctions {
+		names[i] = fn.Name
+	}
+	return names
+}
+
+func TestAnalyzeErrorHandling_WrappedVersusBare(t *testing.T) {
+	result := analyzeErrorSources(t, map[string]string{
+		"load.go": `package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+func Load(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty file")
+	}
+	return data, nil
+}
+
+func Copy(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		return err
+	}
+	return nil
+}
+`,
+	})
+
+	assert.Equal(t, metrics.ErrorHandlingCounts{
+		ErrorFunctions: 2,
+		ErrorReturns:   4,
+		BareReturns:    2,
+		WrappedReturns: 1,
+		CreatedReturns: 1,
+		WrapRatio:      1.0 / 3,
+		ErrorsNewCalls: 1,
+		ErrorfCalls:    1,
+		// Only the fmt.Errorf in Load has %w
+		ErrorfWrapCalls: 1,
+	}, result.ErrorHandlingCounts)
+
+	require.Equal(t, []string{"Copy"}, bareFunctionNames(result), "the wrapped return of Load is not bare")
+	bare := result.BareReturnFunctions[0]
+	assert.Equal(t, "load.go", bare.File)
+	assert.Equal(t, 20, bare.Line)
+	assert.Equal(t, 2, bare.BareReturns)
+	assert.Equal(t, []int{23, 26}, bare.ReturnLines)
+	assert.Equal(t, metrics.SeverityLevelWarning, bare.Severity)
+	assert.Contains(t, bare.Suggestion, "'Copy'")
+
+	require.Len(t, result.Packages, 1)
+	assert.Equal(t, ".", result.Packages[0].Directory)
+	assert.Equal(t, result.ErrorHandlingCounts, result.Packages[0].ErrorHandlingCounts)
+}
+
+func TestAnalyzeErrorHandling_ReturnKinds(t *testing.T) {
+	result := analyzeErrorSources(t, map[string]string{
+		"kinds.go": `package main
+
+import (
+	"fmt"
+	"io"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+var ErrClosed = fmt.Errorf("closed")
+
+type parseError struct{ line int }
+
+func (e *parseError) Error() string { return fmt.Sprint(e.line) }
+
+type Reader struct{ err error }
+
+func (r *Reader) Next(line int) (string, error) {
+	switch line {
+	case 0:
+		return "", io.EOF
+	case 1:
+		return "", ErrClosed
+	case 2:
+		return "", &parseError{line: line}
+	case 3:
+		return "", pkgerrors.Wrap(r.err, "next")
+	case 4:
+		return "", pkgerrors.Errorf("line %d", line)
+	case 5:
+		return "", fmt.Errorf("line %d: %v", line, r.err)
+	case 6:
+		return "", r.err
+	case 7:
+		return r.read()
+	}
+	return "", nil
+}
+
+func (r *Reader) read() (string, error) {
+	return "", pkgerrors.WithStack(r.err)
+}
+
+func (r *Reader) Close() (err error) {
+	if r.err != nil {
+		err = r.err
+		return
+	}
+	return nil
+}
+`,
+	})
+
+	assert.Equal(t, 2, result.SentinelReturns, "io.EOF and ErrClosed")
+	assert.Equal(t, 3, result.CreatedReturns, "the struct literal, pkg/errors Errorf, and fmt.Errorf without %w")
+	assert.Equal(t, 2, result.WrappedReturns, "pkg/errors Wrap and WithStack")
+	assert.Equal(t, 2, result.OtherReturns, "a field and a call returning every result")
+	assert.Zero(t, result.BareReturns, "the naked return of Close is not counted")
+	assert.Equal(t, 9, result.ErrorReturns)
+	assert.Equal(t, 3, result.ErrorFunctions, "Error returns a string")
+	assert.Equal(t, 1.0, result.WrapRatio)
+	assert.Equal(t, 2, result.PkgErrorsWrapCalls)
+	assert.Equal(t, 2, result.ErrorfCalls, "calls outside return statements count")
+	assert.Empty(t, result.BareReturnFunctions)
+}
+
+func TestAnalyzeErrorHandling_FunctionLiteralsAndTests(t *testing.T) {
+	result := analyzeErrorSources(t, map[string]string{
+		"run.go": `package main
+
+type Server struct{}
+
+func (s *Server) Run(steps []func() error) {
+	for _, step := range steps {
+		go func() error {
+			if err := step(); err != nil {
+				return err
+			}
+			return nil
+		}()
+	}
+	check := func(err error) error {
+		return err
+	}
+	_ = check
+}
+
+func Step() error { return nil }
+`,
+		"run_test.go": `package main
+
+func helper(a, b func() error) error {
+	if err := a(); err != nil {
+		return err
+	}
+	return b()
+}
+`,
+	})
+
+	assert.Equal(t, 1, result.ErrorFunctions, "Run returns nothing, and test files are skipped")
+	assert.Equal(t, 2, result.BareReturns, "returns in function literals count")
+	require.Equal(t, []string{"Run"}, bareFunctionNames(result))
+	assert.Equal(t, "Server", result.BareReturnFunctions[0].ReceiverType)
+	assert.Contains(t, result.BareReturnFunctions[0].Suggestion, "'Server.Run'")
+}
@@ -289,6 +289,11 @@ type Strategy interface {
 type Context struct {
 	strategy Strategy
 }
+func (c *Context) Use(s Strategy) { c.strategy = s }
+type Fast struct{}
+func (Fast) Execute() {}
+type Safe struct{}
+func (Safe) Execute() {}
 `
 		fset := token.NewFileSet()
 		file, err := parser.ParseFile(fset, "test.go", src, 0)
@@ -299,7 +304,7 @@ type Context struct {
 		require.NoError(t, err)
 
 		assert.NotEmpty(t, patterns.Strategy,
-			"struct with interface field should still be detected as strategy")
+			"swappable interface field with two implementers should still be detected as strategy")
 	})
 }
 
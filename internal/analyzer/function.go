@@ -3,16 +3,27 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 	"os"
 	"strings"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
+const (
+	// thinWrapperMaxLines is the longest body (in code lines) that can be a thin wrapper
+	thinWrapperMaxLines = 3
+	// minParameterHeavyParams is the fewest parameters a parameter-heavy function takes
+	minParameterHeavyParams = 3
+	// parameterHeavyRatio is the parameters-per-line ratio above which a longer body is parameter-heavy
+	parameterHeavyRatio = 0.5
+)
+
 // FunctionAnalyzer analyzes functions and methods in Go source code
 type FunctionAnalyzer struct {
 	fset          *token.FileSet
 	fileLineCache map[string][]string // keyed by file path; populated once per unique file
+	weights       ComplexityWeights
 }
 
 // NewFunctionAnalyzer creates a new function analyzer for computing comprehensive function-level
@@ -23,9 +34,16 @@ func NewFunctionAnalyzer(fset *token.FileSet) *FunctionAnalyzer {
 	return &FunctionAnalyzer{
 		fset:          fset,
 		fileLineCache: make(map[string][]string),
+		weights:       DefaultFunctionComplexityWeights(),
 	}
 }
 
+// SetComplexityWeights sets the weights combining cyclomatic complexity, nesting depth, and
+// cognitive complexity into ComplexityScore.Overall
+func (fa *FunctionAnalyzer) SetComplexityWeights(weights ComplexityWeights) {
+	fa.weights = weights
+}
+
 // AnalyzeFunctions analyzes all functions in an AST file and returns metrics.
 func (fa *FunctionAnalyzer) AnalyzeFunctions(file *ast.File, pkgName string) ([]metrics.FunctionMetrics, error) {
 	return fa.AnalyzeFunctionsWithPath(file, pkgName, file.Name.Name)
@@ -45,6 +63,7 @@ func (fa *FunctionAnalyzer) AnalyzeFunctionsWithPath(file *ast.File, pkgName, fi
 			if err != nil {
 				continue // Log warning and continue
 			}
+			function.Suppressed = isSuppressed(fa.fset, file, funcDecl)
 			functions = append(functions, function)
 		}
 	}
@@ -70,11 +89,14 @@ func (fa *FunctionAnalyzer) analyzeFunction(funcDecl *ast.FuncDecl, fileName, pk
 		function.ReceiverType = fa.extractReceiverType(funcDecl.Recv)
 	}
 
-	// Analyze function signature
+	// Analyze function signature; parameter categories need the receiver's type parameters too
 	function.Signature = fa.analyzeSignature(funcDecl.Type)
+	function.Signature.ParamTypes = fa.classifyParamTypes(funcDecl)
 
 	// Count lines
 	function.Lines = fa.countLines(funcDecl)
+	function.ParamRatio, function.Shape = ClassifyFunctionShape(funcDecl.Type.Params.NumFields(), function.Lines.Code)
+	function.NonReturning = ClassifyNonReturning(funcDecl, pkgName)
 
 	// Calculate complexity
 	function.Complexity = fa.calculateComplexity(funcDecl)
@@ -85,6 +107,26 @@ func (fa *FunctionAnalyzer) analyzeFunction(funcDecl *ast.FuncDecl, fileName, pk
 	return function, nil
 }
 
+// ClassifyFunctionShape returns the parameters-per-body-line ratio of a function and its shape.
+// Bodies of at most thinWrapperMaxLines code lines with at least as many parameters as lines are
+// thin wrappers; longer bodies with minParameterHeavyParams or more parameters and a ratio above
+// parameterHeavyRatio are parameter-heavy. Empty bodies are always normal.
+func ClassifyFunctionShape(paramCount, bodyLines int) (float64, metrics.FunctionShape) {
+	if bodyLines <= 0 {
+		return 0, metrics.FunctionShapeNormal
+	}
+
+	ratio := float64(paramCount) / float64(bodyLines)
+	switch {
+	case bodyLines <= thinWrapperMaxLines && paramCount > 0 && paramCount >= bodyLines:
+		return ratio, metrics.FunctionShapeThinWrapper
+	case bodyLines > thinWrapperMaxLines && paramCount >= minParameterHeavyParams && ratio > parameterHeavyRatio:
+		return ratio, metrics.FunctionShapeParameterHeavy
+	default:
+		return ratio, metrics.FunctionShapeNormal
+	}
+}
+
 // extractReceiverType extracts the receiver type name from a method
 func (fa *FunctionAnalyzer) extractReceiverType(recv *ast.FieldList) string {
 	if recv == nil || len(recv.List) == 0 {
@@ -128,7 +170,8 @@ func (fa *FunctionAnalyzer) analyzeSignatureParameters(funcType *ast.FuncType, s
 		return
 	}
 
-	signature.ParameterCount = len(funcType.Params.List)
+	// NumFields counts each declared name, so a, b int are two parameters
+	signature.ParameterCount = funcType.Params.NumFields()
 
 	for _, param := range funcType.Params.List {
 		// Check for variadic parameters
@@ -143,6 +186,95 @@ func (fa *FunctionAnalyzer) analyzeSignatureParameters(funcType *ast.FuncType, s
 	}
 }
 
+// classifyParamTypes counts the function's parameters by category, one per declared name, in the
+// categories of metrics.ParamTypeOrder. Returns nil for functions without parameters.
+func (fa *FunctionAnalyzer) classifyParamTypes(funcDecl *ast.FuncDecl) map[string]int {
+	if funcDecl.Type.Params == nil || len(funcDecl.Type.Params.List) == 0 {
+		return nil
+	}
+
+	typeParams := typeParameterNames(funcDecl)
+	counts := make(map[string]int)
+	for _, param := range funcDecl.Type.Params.List {
+		names := len(param.Names)
+		if names == 0 {
+			names = 1
+		}
+		counts[fa.paramCategory(param.Type, typeParams)] += names
+	}
+	return counts
+}
+
+// paramCategory classifies a parameter type much like categorizeFieldType does for struct fields,
+// with variadic parameters and the function's own type parameters in categories of their own
+func (fa *FunctionAnalyzer) paramCategory(expr ast.Expr, typeParams map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.Ellipsis:
+		return metrics.ParamTypeVariadic
+	case *ast.ParenExpr:
+		return fa.paramCategory(t.X, typeParams)
+	case *ast.Ident:
+		switch {
+		case typeParams[t.Name]:
+			return metrics.ParamTypeTypeParameter
+		case primitiveTypeNames[t.Name]:
+			return metrics.ParamTypePrimitive
+		case t.Name == "any" || t.Name == "error":
+			return metrics.ParamTypeInterface
+		}
+		return metrics.ParamTypeNamed
+	case *ast.StarExpr:
+		return metrics.ParamTypePointer
+	case *ast.ArrayType:
+		return metrics.ParamTypeSlice
+	case *ast.MapType:
+		return metrics.ParamTypeMap
+	case *ast.ChanType:
+		return metrics.ParamTypeChannel
+	case *ast.InterfaceType:
+		return metrics.ParamTypeInterface
+	case *ast.FuncType:
+		return metrics.ParamTypeFunc
+	default:
+		// Qualified and instantiated generic types such as pkg.Type or List[T]
+		return metrics.ParamTypeNamed
+	}
+}
+
+// typeParameterNames returns the type parameters in scope for a function's parameters: its own
+// and, for methods on generic types, those declared by the receiver such as T in (s *Set[T])
+func typeParameterNames(funcDecl *ast.FuncDecl) map[string]bool {
+	names := make(map[string]bool)
+	if funcDecl.Type.TypeParams != nil {
+		for _, field := range funcDecl.Type.TypeParams.List {
+			for _, name := range field.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return names
+	}
+
+	recvType := funcDecl.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+	var indices []ast.Expr
+	switch t := recvType.(type) {
+	case *ast.IndexExpr:
+		indices = []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		indices = t.Indices
+	}
+	for _, index := range indices {
+		if ident, ok := index.(*ast.Ident); ok {
+			names[ident.Name] = true
+		}
+	}
+	return names
+}
+
 // analyzeSignatureReturns analyzes function return values
 func (fa *FunctionAnalyzer) analyzeSignatureReturns(funcType *ast.FuncType, signature *metrics.FunctionSignature) {
 	if funcType.Results == nil {
@@ -177,6 +309,16 @@ func (fa *FunctionAnalyzer) analyzeGenericParameters(funcType *ast.FuncType, sig
 	}
 }
 
+// CountFileLines classifies every line of file as code, comment, or blank the same way function
+// bodies are counted, so the line metrics of a file and of its functions agree
+func (fa *FunctionAnalyzer) CountFileLines(file *ast.File) metrics.LineMetrics {
+	tokenFile := fa.fset.File(file.Pos())
+	if tokenFile == nil {
+		return metrics.LineMetrics{}
+	}
+	return fa.countLinesInRange(tokenFile, 1, tokenFile.LineCount())
+}
+
 // countLines counts various types of lines in a function with precise categorization
 func (fa *FunctionAnalyzer) countLines(funcDecl *ast.FuncDecl) metrics.LineMetrics {
 	if funcDecl.Body == nil {
@@ -483,34 +625,39 @@ func (fa *FunctionAnalyzer) calculateComplexity(funcDecl *ast.FuncDecl) metrics.
 
 	complexity := metrics.ComplexityScore{
 		Cyclomatic:   fa.calculateCyclomaticComplexity(funcDecl.Body),
+		Cognitive:    calculateCognitiveComplexity(funcDecl.Body),
 		NestingDepth: fa.calculateNestingDepth(funcDecl.Body),
 	}
 
-	// Calculate cognitive complexity (simplified for now)
-	complexity.Cognitive = complexity.Cyclomatic
-
 	// Calculate overall complexity score
-	complexity.Overall = float64(complexity.Cyclomatic) +
-		float64(complexity.NestingDepth)*0.5 +
-		float64(complexity.Cognitive)*0.3
+	complexity.Overall = fa.weights.Overall(complexity)
 
 	return complexity
 }
 
-// calculateCyclomaticComplexity calculates cyclomatic complexity
+// calculateCyclomaticComplexity calculates McCabe cyclomatic complexity the way gocyclo does:
+// one plus one for each if, for and range statement, each non-default case of a switch, type
+// switch or select, and each && and || operator. The switch and select statements themselves
+// add nothing beyond their cases.
 func (fa *FunctionAnalyzer) calculateCyclomaticComplexity(block *ast.BlockStmt) int {
 	complexity := 1 // Base complexity
 
 	ast.Inspect(block, func(n ast.Node) bool {
-		switch n.(type) {
-		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt,
-			*ast.TypeSwitchStmt, *ast.SelectStmt:
+		switch node := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
 			complexity++
 		case *ast.CaseClause:
-			// Each case adds complexity, but we'll count the switch itself
+			if node.List != nil {
+				complexity++
+			}
 		case *ast.CommClause:
-			// Each select case adds complexity
-			complexity++
+			if node.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if node.Op == token.LAND || node.Op == token.LOR {
+				complexity++
+			}
 		}
 		return true
 	})
@@ -754,15 +901,21 @@ func (fa *FunctionAnalyzer) isErrorType(expr ast.Expr) bool {
 	return false
 }
 
-// extractConstraints extracts type constraints from generic parameters
+// extractConstraints extracts type constraints from generic parameters. Qualified constraints
+// keep their package prefix, each member of a union such as ~int | ~string is returned on its
+// own with any ~ kept, and inline interface constraints are returned as written.
 func (fa *FunctionAnalyzer) extractConstraints(expr ast.Expr) []string {
-	var constraints []string
-
-	// This is a simplified implementation
-	// In practice, you'd need to handle more complex constraint expressions
-	if ident, ok := expr.(*ast.Ident); ok {
-		constraints = append(constraints, ident.Name)
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return fa.extractConstraints(t.X)
+	case *ast.BinaryExpr:
+		if t.Op == token.OR {
+			return append(fa.extractConstraints(t.X), fa.extractConstraints(t.Y)...)
+		}
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return []string{"interface{}"}
+		}
 	}
-
-	return constraints
+	return []string{types.ExprString(expr)}
 }
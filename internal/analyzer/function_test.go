@@ -4,10 +4,13 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
 // Helper function to create a temporary test file
@@ -440,3 +443,187 @@ func BenchmarkFunctionAnalyzer_CountLinesInRange(b *testing.B) {
 		_ = fa.countLinesInRange(tokenFile, 3, tokenFile.LineCount()-1)
 	}
 }
+
+func TestClassifyFunctionShape(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      int
+		bodyLines   int
+		wantShape   metrics.FunctionShape
+		wantRatio   float64
+		description string
+	}{
+		{"thin wrapper", 5, 2, metrics.FunctionShapeThinWrapper, 2.5, "2-line body forwarding 5 parameters"},
+		{"normal long body", 2, 40, metrics.FunctionShapeNormal, 0.05, "40-line body with 2 parameters"},
+		{"parameter heavy", 6, 8, metrics.FunctionShapeParameterHeavy, 0.75, "8-line body with 6 parameters"},
+		{"short without params", 0, 2, metrics.FunctionShapeNormal, 0, "short body with no parameters is not a wrapper"},
+		{"empty body", 3, 0, metrics.FunctionShapeNormal, 0, "empty bodies are never classified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratio, shape := ClassifyFunctionShape(tt.params, tt.bodyLines)
+			if shape != tt.wantShape {
+				t.Errorf("%s: expected shape %s, got %s", tt.description, tt.wantShape, shape)
+			}
+			if math.Abs(ratio-tt.wantRatio) > 0.001 {
+				t.Errorf("%s: expected ratio %.3f, got %.3f", tt.description, tt.wantRatio, ratio)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFunctions_Shape(t *testing.T) {
+	var body strings.Builder
+	for i := 0; i < 40; i++ {
+		body.WriteString("\tx += a * b\n")
+	}
+	src := `package test
+
+func forward(a, b, c, d, e int) int {
+	result := target(a, b, c, d, e)
+	return result
+}
+
+func compute(a, b int) int {
+	x := 0
+` + body.String() + `	return x
+}
+
+func target(a, b, c, d, e int) int { return a + b + c + d + e }
+`
+
+	path := createTestFile(t, src)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	functions, err := NewFunctionAnalyzer(fset).AnalyzeFunctionsWithPath(file, "test", path)
+	if err != nil {
+		t.Fatalf("AnalyzeFunctions failed: %v", err)
+	}
+
+	shapes := make(map[string]metrics.FunctionShape)
+	for _, fn := range functions {
+		shapes[fn.Name] = fn.Shape
+	}
+	if shapes["forward"] != metrics.FunctionShapeThinWrapper {
+		t.Errorf("Expected forward to be a thin wrapper, got %s", shapes["forward"])
+	}
+	if shapes["compute"] != metrics.FunctionShapeNormal {
+		t.Errorf("Expected compute to be normal, got %s", shapes["compute"])
+	}
+}
+
+func TestAnalyzeGenericParameters_Constraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected []string
+	}{
+		{"identifier", "func f[T comparable](x T) {}", []string{"comparable"}},
+		{"qualified", "func f[T constraints.Ordered](x T) {}", []string{"constraints.Ordered"}},
+		{"union with tilde", "func f[T ~int | ~string](x T) {}", []string{"~int", "~string"}},
+		{"mixed union", "func f[T int8 | ~int16 | ~int32](x T) {}", []string{"int8", "~int16", "~int32"}},
+		{"inline interface", "func f[T interface{ Len() int }](x T) {}", []string{"interface{Len() int}"}},
+		{"empty interface", "func f[T interface{}](x T) {}", []string{"interface{}"}},
+		{"generic constraint", "func f[T Container[int]](x T) {}", []string{"Container[int]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcDecl, fset := parseTestFunction(t, "package test\n\n"+tt.src+"\n")
+			signature := NewFunctionAnalyzer(fset).analyzeSignature(funcDecl.Type)
+			if len(signature.GenericParams) != 1 {
+				t.Fatalf("Expected 1 generic parameter, got %d", len(signature.GenericParams))
+			}
+			got := signature.GenericParams[0].Constraints
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected constraints %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCalculateCyclomaticComplexity_MatchesGocyclo(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"straight line", "x := 1\n\t_ = x", 1},
+		{"compound condition", "if a && b || c {\n\t\treturn\n\t}", 4},
+		{"condition in loop", "for i := 0; i < 10 || a; i++ {\n\t}", 3},
+		{"switch with default", "switch {\n\tcase a:\n\tcase b && c:\n\tdefault:\n\t}", 4},
+		{"type switch", "var v interface{}\n\tswitch v.(type) {\n\tcase int, string:\n\tcase bool:\n\t}", 3},
+		{"select with default", "ch := make(chan int)\n\tselect {\n\tcase <-ch:\n\tcase ch <- 1:\n\tdefault:\n\t}", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package test\n\nfunc f(a, b, c bool) {\n\t" + tt.body + "\n}\n"
+			funcDecl, fset := parseTestFunction(t, src)
+
+			got := NewFunctionAnalyzer(fset).calculateCyclomaticComplexity(funcDecl.Body)
+			if got != tt.expected {
+				t.Errorf("Expected cyclomatic complexity %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClassifyParamTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected map[string]int
+	}{
+		{
+			name:   "context, slice and callback",
+			source: "func f(ctx context.Context, ids []int, cb func() error) {}",
+			expected: map[string]int{
+				metrics.ParamTypeNamed: 1, metrics.ParamTypeSlice: 1, metrics.ParamTypeFunc: 1,
+			},
+		},
+		{
+			name:   "grouped names and variadic",
+			source: "func f(a, b int, opts map[string]any, ch <-chan error, args ...string) {}",
+			expected: map[string]int{
+				metrics.ParamTypePrimitive: 2, metrics.ParamTypeMap: 1, metrics.ParamTypeChannel: 1, metrics.ParamTypeVariadic: 1,
+			},
+		},
+		{
+			name:   "function type parameters",
+			source: "func f[K comparable, V any](m *Cache[K, V], key K, err error, v interface{ Size() int }) {}",
+			expected: map[string]int{
+				metrics.ParamTypePointer: 1, metrics.ParamTypeTypeParameter: 1, metrics.ParamTypeInterface: 2,
+			},
+		},
+		{
+			name:     "receiver type parameters",
+			source:   "func (s *Set[T]) Add(item T, items List[T]) {}",
+			expected: map[string]int{metrics.ParamTypeTypeParameter: 1, metrics.ParamTypeNamed: 1},
+		},
+		{
+			name:   "no parameters",
+			source: "func f() {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcDecl, fset := parseTestFunction(t, "package test\n\n"+tt.source+"\n")
+			got := NewFunctionAnalyzer(fset).classifyParamTypes(funcDecl)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected categories %v, got %v", tt.expected, got)
+			}
+			for category, count := range tt.expected {
+				if got[category] != count {
+					t.Errorf("Expected %d %s parameters, got %d (all: %v)", count, category, got[category], got)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strings"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -13,6 +14,20 @@ type GenericAnalyzer struct {
 	fset *token.FileSet
 }
 
+// genericFileIndex records the declarations of one file that instantiation detection needs
+type genericFileIndex struct {
+	// genericFuncs and genericTypes name the functions and types declared with type parameters
+	genericFuncs map[string]bool
+	genericTypes map[string]bool
+	// typeNames holds every type and type parameter declared in the file
+	typeNames map[string]bool
+	// callees, literals, and receivers mark index expressions used as a call target, as the
+	// type of a composite literal, or as a method receiver type
+	callees   map[ast.Expr]bool
+	literals  map[ast.Expr]bool
+	receivers map[ast.Expr]bool
+}
+
 // NewGenericAnalyzer creates a new analyzer for Go 1.18+ generic code constructs including type
 // parameters, constraints, instantiations, and variance analysis. It detects generic functions,
 // generic types, constraint usage patterns, and calculates complexity scores for generic code.
@@ -22,21 +37,29 @@ func NewGenericAnalyzer(fset *token.FileSet) *GenericAnalyzer {
 }
 
 // AnalyzeGenerics analyzes generic types and functions in a Go source file, detecting type parameters,
-// constraints (any, comparable, custom interfaces), generic instantiations, and constraint usage patterns.
-// It calculates a complexity score based on the number and complexity of type parameters and constraints.
+// constraints (any, comparable, unions such as ~int | ~string, custom interfaces), generic
+// instantiations, and constraint usage patterns. Each generic declaration gets a complexity entry;
+// methods on generic receivers reuse their type's parameters and are not counted again.
 // Returns comprehensive metrics for assessing generic code usage and complexity in Go 1.18+ codebases.
 func (ga *GenericAnalyzer) AnalyzeGenerics(file *ast.File, pkgName, filePath string) (metrics.GenericMetrics, error) {
 	result := metrics.GenericMetrics{
 		TypeParameters: metrics.GenericTypeParameters{
 			Constraints: make(map[string]int),
+			Complexity:  []metrics.GenericComplexity{},
+		},
+		Instantiations: metrics.GenericInstantiations{
+			Functions: []metrics.GenericInstantiation{},
+			Types:     []metrics.GenericInstantiation{},
+			Methods:   []metrics.GenericInstantiation{},
 		},
-		Instantiations:  metrics.GenericInstantiations{},
 		ConstraintUsage: make(map[string]int),
 	}
 
+	index := ga.indexFile(file)
+
 	// Walk the AST to collect generic information
 	ast.Inspect(file, func(n ast.Node) bool {
-		ga.processNode(n, filePath, &result)
+		ga.processNode(n, filePath, index, &result)
 		return true
 	})
 
@@ -46,15 +69,88 @@ func (ga *GenericAnalyzer) AnalyzeGenerics(file *ast.File, pkgName, filePath str
 	return result, nil
 }
 
+// indexFile collects generic declarations, type names, and the syntactic context of index
+// expressions so processInstantiation can tell Foo[int] apart from items[i]
+func (ga *GenericAnalyzer) indexFile(file *ast.File) *genericFileIndex {
+	index := &genericFileIndex{
+		genericFuncs: make(map[string]bool),
+		genericTypes: make(map[string]bool),
+		typeNames:    make(map[string]bool),
+		callees:      make(map[ast.Expr]bool),
+		literals:     make(map[ast.Expr]bool),
+		receivers:    make(map[ast.Expr]bool),
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if node.Recv != nil && len(node.Recv.List) > 0 {
+				recv := node.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				index.receivers[recv] = true
+				ga.indexReceiverParams(recv, index)
+			}
+			if node.Type.TypeParams != nil && node.Recv == nil {
+				index.genericFuncs[node.Name.Name] = true
+			}
+			ga.indexTypeParamNames(node.Type.TypeParams, index)
+		case *ast.TypeSpec:
+			index.typeNames[node.Name.Name] = true
+			if node.TypeParams != nil {
+				index.genericTypes[node.Name.Name] = true
+			}
+			ga.indexTypeParamNames(node.TypeParams, index)
+		case *ast.CallExpr:
+			index.callees[ast.Unparen(node.Fun)] = true
+		case *ast.CompositeLit:
+			if node.Type != nil {
+				index.literals[node.Type] = true
+			}
+		}
+		return true
+	})
+	return index
+}
+
+// indexTypeParamNames adds the names declared in a type parameter list to the known type names
+func (ga *GenericAnalyzer) indexTypeParamNames(fieldList *ast.FieldList, index *genericFileIndex) {
+	if fieldList == nil {
+		return
+	}
+	for _, field := range fieldList.List {
+		for _, name := range field.Names {
+			index.typeNames[name.Name] = true
+		}
+	}
+}
+
+// indexReceiverParams adds the type parameter names of a generic receiver such as Map[K, V]
+func (ga *GenericAnalyzer) indexReceiverParams(recv ast.Expr, index *genericFileIndex) {
+	var params []ast.Expr
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		params = []ast.Expr{r.Index}
+	case *ast.IndexListExpr:
+		params = r.Indices
+	}
+	for _, param := range params {
+		if ident, ok := param.(*ast.Ident); ok {
+			index.typeNames[ident.Name] = true
+		}
+	}
+}
+
 // processNode processes individual AST nodes
-func (ga *GenericAnalyzer) processNode(n ast.Node, filePath string, result *metrics.GenericMetrics) {
+func (ga *GenericAnalyzer) processNode(n ast.Node, filePath string, index *genericFileIndex, result *metrics.GenericMetrics) {
 	switch node := n.(type) {
 	case *ast.FuncDecl:
 		ga.processFuncDecl(node, filePath, result)
 	case *ast.TypeSpec:
 		ga.processTypeSpec(node, filePath, result)
 	case *ast.IndexExpr, *ast.IndexListExpr:
-		ga.processInstantiation(node, filePath, result)
+		ga.processInstantiation(node.(ast.Expr), filePath, index, result)
 	}
 }
 
@@ -63,12 +159,7 @@ func (ga *GenericAnalyzer) processFuncDecl(fn *ast.FuncDecl, filePath string, re
 	if fn.Type.TypeParams == nil {
 		return
 	}
-
-	params := ga.extractTypeParams(fn.Type.TypeParams)
-	result.TypeParameters.Count += len(params.Complexity)
-	ga.mergeConstraints(result.TypeParameters.Constraints, params.Constraints)
-	ga.mergeConstraints(result.ConstraintUsage, params.Constraints)
-	result.TypeParameters.Complexity = append(result.TypeParameters.Complexity, params.Complexity...)
+	ga.recordDeclaration(fn.Name.Name, fn.Type.TypeParams, filePath, ga.fset.Position(fn.Pos()).Line, result)
 }
 
 // processTypeSpec analyzes generic type declarations
@@ -76,12 +167,27 @@ func (ga *GenericAnalyzer) processTypeSpec(ts *ast.TypeSpec, filePath string, re
 	if ts.TypeParams == nil {
 		return
 	}
+	ga.recordDeclaration(ts.Name.Name, ts.TypeParams, filePath, ga.fset.Position(ts.Pos()).Line, result)
+}
 
-	params := ga.extractTypeParams(ts.TypeParams)
-	result.TypeParameters.Count += len(params.Complexity)
+// recordDeclaration adds the type parameters of one generic declaration to the result
+func (ga *GenericAnalyzer) recordDeclaration(name string, typeParams *ast.FieldList, filePath string, line int, result *metrics.GenericMetrics) {
+	params := ga.extractTypeParams(typeParams)
+	result.TypeParameters.Count += params.Count
 	ga.mergeConstraints(result.TypeParameters.Constraints, params.Constraints)
 	ga.mergeConstraints(result.ConstraintUsage, params.Constraints)
-	result.TypeParameters.Complexity = append(result.TypeParameters.Complexity, params.Complexity...)
+
+	decl := metrics.GenericComplexity{
+		Name:           name,
+		File:           filePath,
+		Line:           line,
+		ParameterCount: params.Count,
+	}
+	for _, c := range params.Complexity {
+		decl.ConstraintCount += c.ConstraintCount
+		decl.ComplexityScore += c.ComplexityScore
+	}
+	result.TypeParameters.Complexity = append(result.TypeParameters.Complexity, decl)
 }
 
 // extractTypeParams extracts type parameter info
@@ -96,7 +202,7 @@ func (ga *GenericAnalyzer) extractTypeParams(fieldList *ast.FieldList) metrics.G
 
 	for _, field := range fieldList.List {
 		constraintName := ga.extractConstraint(field.Type)
-		params.Constraints[constraintName]++
+		params.Constraints[constraintName] += len(field.Names)
 
 		for _, name := range field.Names {
 			complexity := metrics.GenericComplexity{
@@ -108,6 +214,7 @@ func (ga *GenericAnalyzer) extractTypeParams(fieldList *ast.FieldList) metrics.G
 			params.Complexity = append(params.Complexity, complexity)
 		}
 	}
+	params.Count = len(params.Complexity)
 
 	return params
 }
@@ -120,9 +227,16 @@ func (ga *GenericAnalyzer) extractConstraint(expr ast.Expr) string {
 	case *ast.SelectorExpr:
 		return ga.selectorName(t)
 	case *ast.InterfaceType:
-		return "interface{}"
+		if len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+		return types.ExprString(t)
+	case *ast.BinaryExpr, *ast.UnaryExpr:
+		return types.ExprString(t)
 	case *ast.IndexExpr:
 		return ga.extractConstraint(t.X)
+	case *ast.IndexListExpr:
+		return ga.extractConstraint(t.X)
 	default:
 		return "any"
 	}
@@ -138,10 +252,20 @@ func (ga *GenericAnalyzer) selectorName(sel *ast.SelectorExpr) string {
 	return strings.Join(parts, ".")
 }
 
-// countConstraints counts constraint complexity
+// countConstraints counts constraint complexity: each term of a union, each method and embedded
+// constraint of an interface, and 1 for a named constraint
 func (ga *GenericAnalyzer) countConstraints(expr ast.Expr) int {
-	if iface, ok := expr.(*ast.InterfaceType); ok {
-		return len(iface.Methods.List)
+	switch t := expr.(type) {
+	case *ast.InterfaceType:
+		count := 0
+		for _, elem := range t.Methods.List {
+			count += ga.countConstraints(elem.Type)
+		}
+		return count
+	case *ast.BinaryExpr:
+		if t.Op == token.OR {
+			return ga.countConstraints(t.X) + ga.countConstraints(t.Y)
+		}
 	}
 	return 1
 }
@@ -155,27 +279,81 @@ func (ga *GenericAnalyzer) scoreConstraint(expr ast.Expr) float64 {
 	return count
 }
 
-// processInstantiation tracks generic instantiations
-func (ga *GenericAnalyzer) processInstantiation(n ast.Node, filePath string, result *metrics.GenericMetrics) {
-	var inst metrics.GenericInstantiation
-	inst.File = filePath
+// processInstantiation tracks generic instantiations. Index expressions with several indices
+// are always instantiations; a single index counts when the indexed name is a generic declared
+// in the file or the index is a type, so slice and map indexing are ignored. Receiver types of
+// methods on generic types are declarations, not instantiations.
+func (ga *GenericAnalyzer) processInstantiation(expr ast.Expr, filePath string, index *genericFileIndex, result *metrics.GenericMetrics) {
+	if index.receivers[expr] {
+		return
+	}
 
-	switch node := n.(type) {
+	var generic ast.Expr
+	var args []ast.Expr
+	switch node := expr.(type) {
 	case *ast.IndexExpr:
-		inst.GenericName = ga.exprName(node.X)
-		inst.TypeArgs = []string{ga.exprName(node.Index)}
-		inst.Line = ga.fset.Position(node.Pos()).Line
-	case *ast.IndexListExpr:
-		inst.GenericName = ga.exprName(node.X)
-		for _, idx := range node.Indices {
-			inst.TypeArgs = append(inst.TypeArgs, ga.exprName(idx))
+		if !index.isGenericName(node.X) && !index.isTypeExpr(node.Index) {
+			return
 		}
-		inst.Line = ga.fset.Position(node.Pos()).Line
+		generic, args = node.X, []ast.Expr{node.Index}
+	case *ast.IndexListExpr:
+		generic, args = node.X, node.Indices
 	default:
 		return
 	}
 
-	result.Instantiations.Functions = append(result.Instantiations.Functions, inst)
+	inst := metrics.GenericInstantiation{
+		GenericName: ga.exprName(generic),
+		File:        filePath,
+		Line:        ga.fset.Position(expr.Pos()).Line,
+	}
+	for _, arg := range args {
+		inst.TypeArgs = append(inst.TypeArgs, types.ExprString(arg))
+	}
+
+	name := ga.exprName(generic)
+	switch {
+	case index.literals[expr]:
+		inst.Usage = "composite_literal"
+		result.Instantiations.Types = append(result.Instantiations.Types, inst)
+	case index.genericTypes[name]:
+		inst.Usage = "type_reference"
+		if index.callees[expr] {
+			inst.Usage = "conversion"
+		}
+		result.Instantiations.Types = append(result.Instantiations.Types, inst)
+	case index.callees[expr]:
+		inst.Usage = "call"
+		result.Instantiations.Functions = append(result.Instantiations.Functions, inst)
+	case index.genericFuncs[name]:
+		inst.Usage = "value"
+		result.Instantiations.Functions = append(result.Instantiations.Functions, inst)
+	default:
+		inst.Usage = "type_reference"
+		result.Instantiations.Types = append(result.Instantiations.Types, inst)
+	}
+}
+
+// isGenericName reports whether expr names a generic function or type declared in the file
+func (idx *genericFileIndex) isGenericName(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && (idx.genericFuncs[ident.Name] || idx.genericTypes[ident.Name])
+}
+
+// isTypeExpr reports whether expr can only be a type: a type literal, a predeclared type, or a
+// type or type parameter declared in the file
+func (idx *genericFileIndex) isTypeExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType, *ast.StructType:
+		return true
+	case *ast.Ident:
+		if idx.typeNames[e.Name] {
+			return true
+		}
+		_, isType := types.Universe.Lookup(e.Name).(*types.TypeName)
+		return isType
+	}
+	return false
 }
 
 // exprName extracts name from expression
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -128,3 +129,90 @@ func Use() {
 	assert.Equal(t, "Identity", result.Instantiations.Functions[0].GenericName)
 	assert.Equal(t, []string{"int"}, result.Instantiations.Functions[0].TypeArgs)
 }
+
+func TestGenericAnalyzer_MapTypeAndInstantiations(t *testing.T) {
+	src := `package test
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+type Map[K comparable, V any] struct {
+	items map[K]V
+}
+
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	v, ok := m.items[key]
+	return v, ok
+}
+
+func (m *Map[K, V]) Clone() *Map[K, V] {
+	return &Map[K, V]{items: m.items}
+}
+
+func Sum[T Number](values []T) T {
+	var total T
+	for i := range values {
+		total += values[i]
+	}
+	return total
+}
+
+func Keys[K ~string | ~int, V interface{ comparable; String() string }](m map[K]V) []K {
+	return nil
+}
+
+var ages Map[string, int]
+
+func Use(names []string) {
+	m := Map[string, []int]{}
+	_ = m
+	_ = names[0]
+	_ = Sum[int]([]int{1, 2})
+	sum := Sum[float64]
+	_ = sum
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	result, err := NewGenericAnalyzer(fset).AnalyzeGenerics(file, "test", "test.go")
+	require.NoError(t, err)
+
+	// Map's K and V, Sum's T, and Keys' K and V; methods on Map add none
+	assert.Equal(t, 5, result.TypeParameters.Count)
+	assert.Equal(t, 1, result.TypeParameters.Constraints["comparable"])
+	assert.Equal(t, 1, result.TypeParameters.Constraints["any"])
+	assert.Equal(t, 1, result.TypeParameters.Constraints["Number"])
+	assert.Equal(t, 1, result.TypeParameters.Constraints["~string | ~int"])
+	assert.Equal(t, 1, result.TypeParameters.Constraints["interface{comparable; String() string}"])
+
+	require.Len(t, result.TypeParameters.Complexity, 3)
+	mapDecl := result.TypeParameters.Complexity[0]
+	assert.Equal(t, "Map", mapDecl.Name)
+	assert.Equal(t, "test.go", mapDecl.File)
+	assert.Equal(t, 7, mapDecl.Line)
+	assert.Equal(t, 2, mapDecl.ParameterCount)
+	keysDecl := result.TypeParameters.Complexity[2]
+	assert.Equal(t, "Keys", keysDecl.Name)
+	assert.Equal(t, 4, keysDecl.ConstraintCount, "two union terms plus an embedded constraint and a method")
+
+	var typeUses []string
+	for _, inst := range result.Instantiations.Types {
+		assert.Equal(t, "Map", inst.GenericName)
+		typeUses = append(typeUses, inst.Usage+" "+strings.Join(inst.TypeArgs, ","))
+	}
+	assert.Equal(t, []string{
+		"type_reference K,V",
+		"composite_literal K,V",
+		"type_reference string,int",
+		"composite_literal string,[]int",
+	}, typeUses)
+
+	require.Len(t, result.Instantiations.Functions, 2)
+	assert.Equal(t, "Sum", result.Instantiations.Functions[0].GenericName)
+	assert.Equal(t, []string{"int"}, result.Instantiations.Functions[0].TypeArgs)
+	assert.Equal(t, "call", result.Instantiations.Functions[0].Usage)
+	assert.Equal(t, "value", result.Instantiations.Functions[1].Usage)
+}
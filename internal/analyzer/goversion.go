@@ -0,0 +1,450 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/version"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// goFeature describes a version-gated language feature or standard library API
+type goFeature struct {
+	name        string
+	version     string
+	description string
+}
+
+var (
+	featureGenerics      = goFeature{"generics", "1.18", "type parameters on functions or types"}
+	featureAny           = goFeature{"any", "1.18", "the predeclared any alias"}
+	featureComparable    = goFeature{"comparable", "1.18", "the predeclared comparable constraint"}
+	featureMinMax        = goFeature{"min_max_builtins", "1.21", "the min and max builtins"}
+	featureClear         = goFeature{"clear_builtin", "1.21", "the clear builtin"}
+	featureLoopVar       = goFeature{"loopvar_semantics", "1.22", "goroutines or deferred closures capturing a per-iteration loop variable"}
+	featureRangeOverInt  = goFeature{"range_over_int", "1.22", "ranging over an integer"}
+	featureRangeOverFunc = goFeature{"range_over_func", "1.23", "ranging over an iterator function"}
+)
+
+// goVersionedPackages maps standard library packages to the Go version that added them
+var goVersionedPackages = map[string]string{
+	"cmp":          "1.21",
+	"log/slog":     "1.21",
+	"maps":         "1.21",
+	"slices":       "1.21",
+	"go/version":   "1.22",
+	"math/rand/v2": "1.22",
+	"iter":         "1.23",
+	"unique":       "1.23",
+	"weak":         "1.24",
+}
+
+// goVersionedAPIs maps package path and name to the Go version that added standard library
+// functions of otherwise older packages
+var goVersionedAPIs = map[string]map[string]string{
+	"errors":  {"Join": "1.20"},
+	"strings": {"CutPrefix": "1.20", "CutSuffix": "1.20"},
+	"bytes":   {"CutPrefix": "1.20", "CutSuffix": "1.20"},
+	"context": {"WithCancelCause": "1.20", "AfterFunc": "1.21", "WithoutCancel": "1.21"},
+	"sync":    {"OnceFunc": "1.21", "OnceValue": "1.21", "OnceValues": "1.21"},
+	"reflect": {"TypeFor": "1.22"},
+}
+
+// goIteratorFuncs lists the standard library functions returning iterators, keyed by package path
+var goIteratorFuncs = map[string]map[string]bool{
+	"maps":   {"All": true, "Keys": true, "Values": true},
+	"slices": {"All": true, "Backward": true, "Values": true, "Chunk": true},
+}
+
+// DetectGoVersionFeatures infers the minimum Go version the files need from the version-gated
+// features they use, returning the features sorted from the newest version. Files are grouped
+// into packages by directory and package name, so names declared by another file of the
+// package, such as a package-level min function, are not mistaken for builtins. Without type
+// information the detection is syntactic: ranging over an integer is only recognized for
+// integer literals and len calls, ranging over a function for function literals, the maps and
+// slices iterators, and package functions and methods whose result is an iterator, and loop
+// variable reliance for goroutines and deferred closures that capture a variable declared by
+// the loop. The returned requirement has an empty MinimumVersion when no feature is found.
+func DetectGoVersionFeatures(files []BurdenFileInfo) metrics.GoVersionRequirement {
+	packages := make(map[string][]BurdenFileInfo)
+	for _, fi := range files {
+		if fi.File == nil {
+			continue
+		}
+		key := filepath.Dir(fi.RelPath) + "\x00" + fi.File.Name.Name
+		packages[key] = append(packages[key], fi)
+	}
+
+	found := make(map[string]*metrics.GoVersionFeature)
+	for _, pkgFiles := range packages {
+		scope := collectPackageScope(pkgFiles)
+		for _, fi := range pkgFiles {
+			fd := &goFeatureDetector{
+				fi: fi, scope: scope, imports: fileImports(fi.File), names: make(map[*ast.Ident]bool), found: found,
+			}
+			fd.detect()
+		}
+	}
+	return buildGoVersionRequirement(found)
+}
+
+// MergeGoVersionRequirements combines the requirements of several analyses, summing feature
+// counts and keeping each feature's earliest occurrence
+func MergeGoVersionRequirements(requirements []metrics.GoVersionRequirement) metrics.GoVersionRequirement {
+	found := make(map[string]*metrics.GoVersionFeature)
+	for _, req := range requirements {
+		for _, feature := range req.Features {
+			existing := found[feature.Feature]
+			if existing == nil {
+				copied := feature
+				found[feature.Feature] = &copied
+				continue
+			}
+			existing.Count += feature.Count
+			if occursBefore(feature.File, feature.Line, existing.File, existing.Line) {
+				existing.File, existing.Line = feature.File, feature.Line
+			}
+		}
+	}
+	return buildGoVersionRequirement(found)
+}
+
+// buildGoVersionRequirement sorts the found features and takes the newest version among them
+func buildGoVersionRequirement(found map[string]*metrics.GoVersionFeature) metrics.GoVersionRequirement {
+	req := metrics.GoVersionRequirement{Features: []metrics.GoVersionFeature{}}
+	for _, feature := range found {
+		req.Features = append(req.Features, *feature)
+		if req.MinimumVersion == "" || version.Compare("go"+feature.Version, "go"+req.MinimumVersion) > 0 {
+			req.MinimumVersion = feature.Version
+		}
+	}
+	sort.Slice(req.Features, func(i, j int) bool {
+		a, b := req.Features[i], req.Features[j]
+		if c := version.Compare("go"+a.Version, "go"+b.Version); c != 0 {
+			return c > 0
+		}
+		return a.Feature < b.Feature
+	})
+	return req
+}
+
+// occursBefore orders source positions by file, then line
+func occursBefore(file string, line int, otherFile string, otherLine int) bool {
+	if file != otherFile {
+		return file < otherFile
+	}
+	return line < otherLine
+}
+
+// packageScope holds the package-level names of one package, across its files
+type packageScope struct {
+	names map[string]bool
+	// iterators holds the functions and methods whose single result is an iterator
+	iterators map[string]bool
+}
+
+// collectPackageScope gathers the package-level declarations of a package's files
+func collectPackageScope(files []BurdenFileInfo) packageScope {
+	scope := packageScope{names: make(map[string]bool), iterators: make(map[string]bool)}
+	for _, fi := range files {
+		for _, decl := range fi.File.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					scope.names[d.Name.Name] = true
+				}
+				if returnsIterator(d.Type) {
+					scope.iterators[d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						scope.names[s.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							scope.names[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return scope
+}
+
+// returnsIterator reports whether a function's only result is an iter.Seq, an iter.Seq2, or a
+// function taking a yield function that returns bool
+func returnsIterator(fn *ast.FuncType) bool {
+	if fn.Results == nil || len(fn.Results.List) != 1 || len(fn.Results.List[0].Names) > 1 {
+		return false
+	}
+	typ := fn.Results.List[0].Type
+	if index, ok := typ.(*ast.IndexExpr); ok {
+		typ = index.X
+	} else if index, ok := typ.(*ast.IndexListExpr); ok {
+		typ = index.X
+	}
+	if sel, ok := typ.(*ast.SelectorExpr); ok {
+		pkg, ok := sel.X.(*ast.Ident)
+		return ok && pkg.Name == "iter" && (sel.Sel.Name == "Seq" || sel.Sel.Name == "Seq2")
+	}
+	return isYieldFuncType(typ)
+}
+
+// isYieldFuncType reports whether typ has the shape func(yield func(...) bool) of an iterator
+func isYieldFuncType(typ ast.Expr) bool {
+	fn, ok := typ.(*ast.FuncType)
+	if !ok || fn.Results != nil && len(fn.Results.List) > 0 || fn.Params == nil || len(fn.Params.List) != 1 {
+		return false
+	}
+	yield, ok := fn.Params.List[0].Type.(*ast.FuncType)
+	if !ok || yield.Results == nil || len(yield.Results.List) != 1 {
+		return false
+	}
+	result, ok := yield.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "bool"
+}
+
+// fileImports maps each import's local name in file to its path
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := filepath.Base(path)
+		if path == "math/rand/v2" {
+			name = "rand"
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// goFeatureDetector records the version-gated features of one file
+type goFeatureDetector struct {
+	fi      BurdenFileInfo
+	scope   packageScope
+	imports map[string]string
+	// names holds the identifiers that name fields, methods, or keys rather than refer to objects
+	names map[*ast.Ident]bool
+	found map[string]*metrics.GoVersionFeature
+}
+
+// detect walks the file, recording every version-gated feature it uses
+func (fd *goFeatureDetector) detect() {
+	for _, spec := range fd.fi.File.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if v, ok := goVersionedPackages[path]; ok && err == nil {
+			fd.record(goFeature{"package " + path, v, "the " + path + " standard library package"}, spec.Pos())
+		}
+	}
+
+	ast.Inspect(fd.fi.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if node.Recv != nil {
+				// Method names are never predeclared identifiers
+				fd.names[node.Name] = true
+			}
+			if node.Type.TypeParams != nil && len(node.Type.TypeParams.List) > 0 {
+				fd.record(featureGenerics, node.Pos())
+			}
+		case *ast.TypeSpec:
+			if node.TypeParams != nil && len(node.TypeParams.List) > 0 {
+				fd.record(featureGenerics, node.Pos())
+			}
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						fd.names[key] = true
+					}
+				}
+			}
+		case *ast.Ident:
+			fd.detectPredeclaredType(node)
+		case *ast.CallExpr:
+			fd.detectBuiltinCall(node)
+		case *ast.SelectorExpr:
+			fd.names[node.Sel] = true
+			fd.detectVersionedAPI(node)
+		case *ast.RangeStmt:
+			fd.detectRange(node)
+			fd.detectLoopVarCapture(loopVariables(node.Tok, node.Key, node.Value), node.Body)
+		case *ast.ForStmt:
+			if assign, ok := node.Init.(*ast.AssignStmt); ok {
+				fd.detectLoopVarCapture(loopVariables(assign.Tok, assign.Lhs...), node.Body)
+			}
+		}
+		return true
+	})
+}
+
+// isUniverse reports whether ident refers to a predeclared identifier: it resolves to nothing
+// in its file, no file of the package declares the name, and it is not a selected field or
+// method, a method name, or a composite literal key
+func (fd *goFeatureDetector) isUniverse(ident *ast.Ident) bool {
+	return ident.Obj == nil && !fd.scope.names[ident.Name] && !fd.names[ident]
+}
+
+// detectPredeclaredType records uses of the any and comparable identifiers
+func (fd *goFeatureDetector) detectPredeclaredType(ident *ast.Ident) {
+	if !fd.isUniverse(ident) {
+		return
+	}
+	switch ident.Name {
+	case "any":
+		fd.record(featureAny, ident.Pos())
+	case "comparable":
+		fd.record(featureComparable, ident.Pos())
+	}
+}
+
+// detectBuiltinCall records calls of the min, max, and clear builtins
+func (fd *goFeatureDetector) detectBuiltinCall(call *ast.CallExpr) {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || !fd.isUniverse(ident) {
+		return
+	}
+	switch ident.Name {
+	case "min", "max":
+		fd.record(featureMinMax, call.Pos())
+	case "clear":
+		fd.record(featureClear, call.Pos())
+	}
+}
+
+// importedSelector returns the import path and selected name of a pkg.Name selector
+func (fd *goFeatureDetector) importedSelector(sel *ast.SelectorExpr) (string, string, bool) {
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Obj != nil {
+		return "", "", false
+	}
+	path, ok := fd.imports[pkg.Name]
+	return path, sel.Sel.Name, ok
+}
+
+// detectVersionedAPI records uses of standard library functions newer than their package
+func (fd *goFeatureDetector) detectVersionedAPI(sel *ast.SelectorExpr) {
+	path, name, ok := fd.importedSelector(sel)
+	if !ok {
+		return
+	}
+	if v, ok := goVersionedAPIs[path][name]; ok {
+		fd.record(goFeature{path + "." + name, v, "the " + path + "." + name + " function"}, sel.Pos())
+	}
+}
+
+// detectRange records ranging over integers and iterator functions
+func (fd *goFeatureDetector) detectRange(rng *ast.RangeStmt) {
+	switch x := ast.Unparen(rng.X).(type) {
+	case *ast.BasicLit:
+		if x.Kind == token.INT {
+			fd.record(featureRangeOverInt, rng.Pos())
+		}
+	case *ast.FuncLit:
+		if isYieldFuncType(x.Type) {
+			fd.record(featureRangeOverFunc, rng.Pos())
+		}
+	case *ast.CallExpr:
+		if fd.isIteratorCall(x) {
+			fd.record(featureRangeOverFunc, rng.Pos())
+		} else if ident, ok := x.Fun.(*ast.Ident); ok && ident.Name == "len" && fd.isUniverse(ident) {
+			fd.record(featureRangeOverInt, rng.Pos())
+		}
+	}
+}
+
+// isIteratorCall reports whether call invokes a standard library iterator or a function or
+// method of the package returning one
+func (fd *goFeatureDetector) isIteratorCall(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fd.scope.iterators[fun.Name] && (fun.Obj == nil || fun.Obj.Kind == ast.Fun)
+	case *ast.SelectorExpr:
+		if path, name, ok := fd.importedSelector(fun); ok {
+			return goIteratorFuncs[path][name]
+		}
+		return fd.scope.iterators[fun.Sel.Name]
+	}
+	return false
+}
+
+// loopVariables returns the objects of the identifiers a loop header declares with :=
+func loopVariables(tok token.Token, exprs ...ast.Expr) map[*ast.Object]bool {
+	if tok != token.DEFINE {
+		return nil
+	}
+	vars := make(map[*ast.Object]bool)
+	for _, expr := range exprs {
+		if ident, ok := expr.(*ast.Ident); ok && ident.Obj != nil && ident.Name != "_" {
+			vars[ident.Obj] = true
+		}
+	}
+	return vars
+}
+
+// detectLoopVarCapture records go and defer statements in a loop body whose function literal
+// uses a loop variable, which before Go 1.22 all iterations shared
+func (fd *goFeatureDetector) detectLoopVarCapture(vars map[*ast.Object]bool, body *ast.BlockStmt) {
+	if len(vars) == 0 || body == nil {
+		return
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		var call *ast.CallExpr
+		switch stmt := n.(type) {
+		case *ast.GoStmt:
+			call = stmt.Call
+		case *ast.DeferStmt:
+			call = stmt.Call
+		default:
+			return true
+		}
+		if lit, ok := call.Fun.(*ast.FuncLit); ok && usesObjects(lit.Body, vars) {
+			fd.record(featureLoopVar, n.Pos())
+		}
+		return true
+	})
+}
+
+// usesObjects reports whether any identifier below n refers to one of objs
+func usesObjects(n ast.Node, objs map[*ast.Object]bool) bool {
+	used := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && objs[ident.Obj] {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// record counts one use of feature at pos, keeping the earliest occurrence
+func (fd *goFeatureDetector) record(feature goFeature, pos token.Pos) {
+	line := fd.fi.Fset.Position(pos).Line
+	existing := fd.found[feature.name]
+	if existing == nil {
+		fd.found[feature.name] = &metrics.GoVersionFeature{
+			Feature:     feature.name,
+			Version:     feature.version,
+			Description: feature.description,
+			Count:       1,
+			File:        fd.fi.RelPath,
+			Line:        line,
+		}
+		return
+	}
+	existing.Count++
+	if occursBefore(fd.fi.RelPath, line, existing.File, existing.Line) {
+		existing.File, existing.Line = fd.fi.RelPath, line
+	}
+}
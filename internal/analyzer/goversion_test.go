@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// detectSourceFeatures detects the version-gated features of the given sources
+func detectSourceFeatures(t *testing.T, sources map[string]string) metrics.GoVersionRequirement {
+	t.Helper()
+	_, _, files := analyzeInterfaceSources(t, sources)
+	return DetectGoVersionFeatures(files)
+}
+
+// featureNames lists the names of the detected features in order
+func featureNames(req metrics.GoVersionRequirement) []string {
+	names := make([]string, len(req.Features))
+	for i, feature := range req.Features {
+		names[i] = feature.Feature
+	}
+	return names
+}
+
+func TestDetectGoVersionFeatures_Generics(t *testing.T) {
+	req := detectSourceFeatures(t, map[string]string{
+		"set.go": `package main
+
+type Set[T comparable] map[T]struct{}
+
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+`,
+	})
+
+	assert.Equal(t, "1.18", req.MinimumVersion)
+	assert.Equal(t, []string{"any", "comparable", "generics"}, featureNames(req))
+	generics := req.Features[2]
+	assert.Equal(t, 2, generics.Count)
+	assert.Equal(t, "set.go", generics.File)
+	assert.Equal(t, 3, generics.Line)
+	assert.Equal(t, 2, req.Features[1].Count)
+}
+
+func TestDetectGoVersionFeatures_MinBuiltin(t *testing.T) {
+	req := detectSourceFeatures(t, map[string]string{
+		"clamp.go": `package main
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
+`,
+	})
+
+	assert.Equal(t, "1.21", req.MinimumVersion)
+	require.Equal(t, []string{"min_max_builtins"}, featureNames(req))
+	assert.Equal(t, 2, req.Features[0].Count)
+}
+
+func TestDetectGoVersionFeatures_PackageDeclaredNames(t *testing.T) {
+	req := detectSourceFeatures(t, map[string]string{
+		"math.go": `package main
+
+type any interface{}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+`,
+		"use.go": `package main
+
+type limits struct{ max int }
+
+func smallest(a, b int, v any) int {
+	l := limits{max: 3}
+	return min(min(a, b), l.max)
+}
+
+func (l limits) clear() {}
+`,
+	})
+
+	assert.Empty(t, req.MinimumVersion, "min and any are declared by the package, and max and clear are a field and a method")
+	assert.Empty(t, req.Features)
+}
+
+func TestDetectGoVersionFeatures_StandardLibrary(t *testing.T) {
+	req := detectSourceFeatures(t, map[string]string{
+		"errs.go": `package main
+
+import (
+	"errors"
+	stdstrings "strings"
+)
+
+func combine(a, b error, s string) (error, string) {
+	rest, _ := stdstrings.CutPrefix(s, "x")
+	return errors.Join(a, b), rest
+}
+`,
+	})
+
+	assert.Equal(t, "1.20", req.MinimumVersion)
+	assert.Equal(t, []string{"errors.Join", "strings.CutPrefix"}, featureNames(req))
+
+	req = detectSourceFeatures(t, map[string]string{
+		"sorted.go": `package main
+
+import "slices"
+
+func sorted(values []int) []int {
+	slices.Sort(values)
+	return values
+}
+`,
+	})
+	assert.Equal(t, "1.21", req.MinimumVersion)
+	assert.Equal(t, []string{"package slices"}, featureNames(req))
+}
+
+func TestDetectGoVersionFeatures_LoopVariables(t *testing.T) {
+	req := detectSourceFeatures(t, map[string]string{
+		"loops.go": `package main
+
+func process(items []string, done chan string) {
+	for _, item := range items {
+		go func() { done <- item }()
+	}
+	for i := 0; i < 3; i++ {
+		defer func() { println(i) }()
+	}
+}
+`,
+	})
+	assert.Equal(t, "1.22", req.MinimumVersion)
+	require.Equal(t, []string{"loopvar_semantics"}, featureNames(req))
+	assert.Equal(t, 2, req.Features[0].Count)
+
+	req = detectSourceFeatures(t, map[string]string{
+		"copied.go": `package main
+
+func process(items []string, done chan string) {
+	for _, item := range items {
+		item := item
+		go func() { done <- item }()
+		go func(item string) { done <- item }(item)
+	}
+}
+`,
+	})
+	assert.Empty(t, req.Features, "copies and parameters do not rely on per-iteration variables")
+}
+
+func TestDetectGoVersionFeatures_RangeOverIntAndFunc(t *testing.T) {
+	req := detectSourceFeatures(t, map[string]string{
+		"iter.go": `package main
+
+func Count(n int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func use(values []int) {
+	for range 10 {
+	}
+	for i := range len(values) {
+		_ = i
+	}
+	for v := range Count(3) {
+		_ = v
+	}
+}
+`,
+	})
+
+	assert.Equal(t, "1.23", req.MinimumVersion)
+	assert.Equal(t, []string{"range_over_func", "range_over_int"}, featureNames(req),
+		"the newest version comes first; range n over a variable is not recognized without types")
+	assert.Equal(t, 2, req.Features[1].Count)
+}
+
+func TestMergeGoVersionRequirements(t *testing.T) {
+	first := metrics.GoVersionRequirement{MinimumVersion: "1.18", Features: []metrics.GoVersionFeature{
+		{Feature: "generics", Version: "1.18", Count: 2, File: "b.go", Line: 4},
+	}}
+	second := metrics.GoVersionRequirement{MinimumVersion: "1.21", Features: []metrics.GoVersionFeature{
+		{Feature: "min_max_builtins", Version: "1.21", Count: 1, File: "c.go", Line: 9},
+		{Feature: "generics", Version: "1.18", Count: 3, File: "a.go", Line: 7},
+	}}
+
+	merged := MergeGoVersionRequirements([]metrics.GoVersionRequirement{first, second})
+
+	assert.Equal(t, "1.21", merged.MinimumVersion)
+	assert.Equal(t, []metrics.GoVersionFeature{
+		{Feature: "min_max_builtins", Version: "1.21", Count: 1, File: "c.go", Line: 9},
+		{Feature: "generics", Version: "1.18", Count: 5, File: "a.go", Line: 7},
+	}, merged.Features)
+	assert.Equal(t, "", MergeGoVersionRequirements(nil).MinimumVersion)
+}
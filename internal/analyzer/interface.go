@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"strings"
@@ -14,7 +15,8 @@ import (
 // and calculates complexity metrics to understand interface design patterns and usage.
 // InterfaceAnalyzer supports cross-file analysis, generic types, and advanced metrics.
 type InterfaceAnalyzer struct {
-	fset *token.FileSet
+	fset             *token.FileSet
+	functionAnalyzer *FunctionAnalyzer // counts method lines the same way as functions
 	// Cross-file implementation tracking
 	typeImplementations  map[string][]string             // interface -> []implementer
 	interfaceDefinitions map[string]*ast.InterfaceType   // interface name -> definition
@@ -31,6 +33,7 @@ type InterfaceAnalyzer struct {
 func NewInterfaceAnalyzer(fset *token.FileSet) *InterfaceAnalyzer {
 	return &InterfaceAnalyzer{
 		fset:                 fset,
+		functionAnalyzer:     NewFunctionAnalyzer(fset),
 		typeImplementations:  make(map[string][]string),
 		interfaceDefinitions: make(map[string]*ast.InterfaceType),
 		structDefinitions:    make(map[string]*ast.StructType),
@@ -295,37 +298,50 @@ func (ia *InterfaceAnalyzer) updateEnhancedImplementationMetrics(interfaceMetric
 		}
 	}
 
-	// Enhanced embedding depth calculation using graph traversal
-	enhancedDepth := ia.calculateEnhancedEmbeddingDepth(interfaceName, make(map[string]bool))
-	interfaceMetric.EmbeddingDepth = enhancedDepth
+	// The complexity score weighs the embedding depth, so it is scored once the depth is known
+	interfaceMetric.EmbeddingDepth = ia.calculateEnhancedEmbeddingDepth(interfaceName, make(map[string]bool))
+	interfaceMetric.ComplexityScore = ia.calculateInterfaceComplexity(*interfaceMetric)
 }
 
-// calculateEnhancedEmbeddingDepth calculates embedding depth using graph traversal to detect cycles
+// ResolveEmbeddingDepths recomputes EmbeddingDepth, and the ComplexityScore that weighs it, over
+// all analyzed interfaces. Interfaces are analyzed one file at a time, so a chain of embeddings
+// that crosses files within a package is only complete once every file has been analyzed.
+func ResolveEmbeddingDepths(interfaces []metrics.InterfaceMetrics) {
+	ia := NewInterfaceAnalyzer(token.NewFileSet())
+	for _, iface := range interfaces {
+		embedded := make([]string, len(iface.EmbeddedInterfaces))
+		for i, name := range iface.EmbeddedInterfaces {
+			embedded[i] = ia.qualifyInterfaceName(name, iface.Package)
+		}
+		ia.embeddingGraph[iface.Package+"."+iface.Name] = embedded
+	}
+	for i := range interfaces {
+		interfaces[i].EmbeddingDepth = ia.calculateEnhancedEmbeddingDepth(interfaces[i].Package+"."+interfaces[i].Name, make(map[string]bool))
+		interfaces[i].ComplexityScore = ia.calculateInterfaceComplexity(interfaces[i])
+	}
+}
+
+// calculateEnhancedEmbeddingDepth returns the length of the longest chain of embedded interfaces
+// below interfaceName, following the embedding graph. An interface that embeds nothing has depth
+// 0. Interfaces outside the graph, such as those of other packages, end the chain: embedding them
+// counts as one level, since what they embed is unknown. An embedding back into an interface
+// already on the current chain counts as one level and is not followed, so cyclic definitions
+// terminate.
 func (ia *InterfaceAnalyzer) calculateEnhancedEmbeddingDepth(interfaceName string, visited map[string]bool) int {
 	if visited[interfaceName] {
 		return 0
 	}
 
 	embedded, exists := ia.embeddingGraph[interfaceName]
-
-	if !exists {
-		return ia.getExternalInterfaceDepth(interfaceName)
-	}
-
-	if len(embedded) == 0 {
+	if !exists || len(embedded) == 0 {
 		return 0
 	}
 
 	return ia.calculateMaxEmbeddingDepth(interfaceName, embedded, visited)
 }
 
-func (ia *InterfaceAnalyzer) getExternalInterfaceDepth(interfaceName string) int {
-	if strings.Contains(interfaceName, ".") {
-		return 1
-	}
-	return 0
-}
-
+// calculateMaxEmbeddingDepth returns one more than the deepest embedded interface, with
+// interfaceName marked as on the current chain while its embeddings are followed
 func (ia *InterfaceAnalyzer) calculateMaxEmbeddingDepth(interfaceName string, embedded []string, visited map[string]bool) int {
 	visited[interfaceName] = true
 	maxDepth := 0
@@ -440,12 +456,59 @@ func (ia *InterfaceAnalyzer) addInterfaceMethods(field *ast.Field, metric *metri
 	for _, name := range field.Names {
 		methodInfo := metrics.InterfaceMethod{
 			Name:      name.Name,
+			Line:      ia.fset.Position(name.Pos()).Line,
 			Signature: ia.analyzeFunctionSignature(field.Type),
 		}
 		metric.Methods = append(metric.Methods, methodInfo)
 	}
 }
 
+// DetectOversizedMethods flags interface methods whose parameter or return counts exceed the
+// given thresholds. Large interface signatures are costly to implement and mock, so each issue
+// recommends introducing a parameter or result object.
+func (ia *InterfaceAnalyzer) DetectOversizedMethods(iface *metrics.InterfaceMetrics, maxParams, maxReturns int) []metrics.SignatureIssue {
+	var issues []metrics.SignatureIssue
+	for _, method := range iface.Methods {
+		params := method.Signature.ParameterCount
+		returns := method.Signature.ReturnCount
+		if params <= maxParams && returns <= maxReturns {
+			continue
+		}
+
+		severity := metrics.SeverityLevelWarning
+		if params > maxParams*2 || returns > maxReturns*2 {
+			severity = metrics.SeverityLevelViolation
+		}
+
+		line := method.Line
+		if line == 0 {
+			line = iface.Line
+		}
+
+		issues = append(issues, metrics.SignatureIssue{
+			Function:       iface.Name + "." + method.Name,
+			File:           iface.File,
+			Line:           line,
+			ParameterCount: params,
+			ReturnCount:    returns,
+			Severity:       severity,
+			Suggestion:     getInterfaceSignatureSuggestion(params, returns, maxParams, maxReturns),
+		})
+	}
+	return issues
+}
+
+// getInterfaceSignatureSuggestion generates guidance for an oversized interface method
+func getInterfaceSignatureSuggestion(params, returns, maxParams, maxReturns int) string {
+	if params > maxParams && returns > maxReturns {
+		return fmt.Sprintf("Interface method has %d parameters and %d returns. Consider a parameter object and a result struct to keep implementations and mocks simple", params, returns)
+	}
+	if params > maxParams {
+		return fmt.Sprintf("Interface method has %d parameters. Consider accepting a parameter object so implementations and mocks stay simple", params)
+	}
+	return fmt.Sprintf("Interface method has %d return values. Consider returning a result struct", returns)
+}
+
 // addEmbeddedInterface adds an embedded interface to the metric
 func (ia *InterfaceAnalyzer) addEmbeddedInterface(field *ast.Field, metric *metrics.InterfaceMetrics) {
 	embeddedName := ia.extractEmbeddedInterfaceName(field.Type)
@@ -457,9 +520,7 @@ func (ia *InterfaceAnalyzer) addEmbeddedInterface(field *ast.Field, metric *metr
 // finalizeInterfaceMetrics calculates final metrics for the interface
 func (ia *InterfaceAnalyzer) finalizeInterfaceMetrics(metric *metrics.InterfaceMetrics, doc *ast.CommentGroup) {
 	metric.MethodCount = len(metric.Methods)
-	metric.EmbeddingDepth = ia.calculateEmbeddingDepth(metric.EmbeddedInterfaces)
 	metric.Documentation = ia.analyzeDocumentation(doc)
-	metric.ComplexityScore = ia.calculateInterfaceComplexity(*metric)
 }
 
 // analyzeFunctionSignature analyzes a function signature for complexity metrics
@@ -622,7 +683,7 @@ func (ia *InterfaceAnalyzer) collectMethodDefinitions(file *ast.File, pkgName st
 					IsExported:    ast.IsExported(funcDecl.Name.Name),
 					IsPointer:     ia.isPointerReceiver(funcDecl.Recv),
 					Signature:     ia.analyzeFunctionSignature(funcDecl.Type),
-					Lines:         ia.calculateMethodLines(funcDecl),
+					Lines:         ia.functionAnalyzer.countLines(funcDecl),
 					Complexity:    ia.calculateMethodComplexity(funcDecl),
 					Documentation: ia.analyzeDocumentation(funcDecl.Doc),
 				}
@@ -644,27 +705,6 @@ func (ia *InterfaceAnalyzer) isPointerReceiver(recv *ast.FieldList) bool {
 	return isPointer
 }
 
-// calculateMethodLines calculates line metrics for a method using simplified approach
-func (ia *InterfaceAnalyzer) calculateMethodLines(funcDecl *ast.FuncDecl) metrics.LineMetrics {
-	if funcDecl.Body == nil {
-		return metrics.LineMetrics{}
-	}
-
-	start := ia.fset.Position(funcDecl.Body.Lbrace)
-	end := ia.fset.Position(funcDecl.Body.Rbrace)
-
-	// Simplified line counting - in production would use function analyzer
-	totalLines := end.Line - start.Line - 1
-	if totalLines < 0 {
-		totalLines = 0
-	}
-
-	return metrics.LineMetrics{
-		Total: totalLines,
-		Code:  totalLines, // Simplified - assume all are code lines
-	}
-}
-
 // calculateMethodComplexity calculates complexity metrics for a method
 func (ia *InterfaceAnalyzer) calculateMethodComplexity(funcDecl *ast.FuncDecl) metrics.ComplexityScore {
 	// Simplified complexity calculation
@@ -708,21 +748,3 @@ func (ia *InterfaceAnalyzer) calculateInterfaceComplexity(interfaceMetric metric
 
 	return complexity
 }
-
-// calculateEmbeddingDepth calculates the depth of interface embedding
-// External package interfaces get depth 2, local interfaces get depth 1
-func (ia *InterfaceAnalyzer) calculateEmbeddingDepth(embeddedInterfaces []string) int {
-	if len(embeddedInterfaces) == 0 {
-		return 0
-	}
-
-	maxDepth := 1
-	for _, embedded := range embeddedInterfaces {
-		// External interface embedding (contains package selector)
-		if strings.Contains(embedded, ".") {
-			return 2 // Return immediately when external interface found
-		}
-	}
-
-	return maxDepth
-}
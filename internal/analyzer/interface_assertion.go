@@ -0,0 +1,238 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// ExtractInterfaceAssertions finds compile-time interface assertions of the forms
+// var _ Iface = (*T)(nil), var _ Iface = &T{}, and var _ Iface = T{} at package scope.
+// The returned assertions are unverified until VerifyInterfaceAssertions runs.
+func (ia *InterfaceAnalyzer) ExtractInterfaceAssertions(file *ast.File, pkgName, filePath string) []metrics.InterfaceAssertion {
+	var assertions []metrics.InterfaceAssertion
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || valueSpec.Type == nil {
+				continue
+			}
+			assertions = append(assertions, ia.assertionsFromValueSpec(valueSpec, pkgName, filePath)...)
+		}
+	}
+	return assertions
+}
+
+// assertionsFromValueSpec returns one assertion per blank identifier assigned a typed value
+func (ia *InterfaceAnalyzer) assertionsFromValueSpec(spec *ast.ValueSpec, pkgName, filePath string) []metrics.InterfaceAssertion {
+	ifaceName := ia.extractEmbeddedInterfaceName(spec.Type)
+	if ifaceName == "" || len(spec.Values) != len(spec.Names) {
+		return nil
+	}
+
+	var assertions []metrics.InterfaceAssertion
+	for i, name := range spec.Names {
+		if name.Name != "_" {
+			continue
+		}
+		typeName, pointer, ok := assertedType(spec.Values[i])
+		if !ok {
+			continue
+		}
+		assertions = append(assertions, metrics.InterfaceAssertion{
+			Interface: ifaceName,
+			Type:      typeName,
+			Pointer:   pointer,
+			Package:   pkgName,
+			File:      filePath,
+			Line:      ia.fset.Position(name.Pos()).Line,
+			Status:    metrics.AssertionUnverified,
+		})
+	}
+	return assertions
+}
+
+// assertedType returns the named type and pointer-ness of (*T)(nil), &T{}, or T{}
+func assertedType(expr ast.Expr) (string, bool, bool) {
+	switch v := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		star, ok := ast.Unparen(v.Fun).(*ast.StarExpr)
+		if !ok || len(v.Args) != 1 {
+			return "", false, false
+		}
+		if nilIdent, ok := v.Args[0].(*ast.Ident); !ok || nilIdent.Name != "nil" {
+			return "", false, false
+		}
+		name := assertedTypeName(star.X)
+		return name, true, name != ""
+	case *ast.UnaryExpr:
+		lit, ok := v.X.(*ast.CompositeLit)
+		if !ok || v.Op != token.AND {
+			return "", false, false
+		}
+		name := assertedTypeName(lit.Type)
+		return name, true, name != ""
+	case *ast.CompositeLit:
+		name := assertedTypeName(v.Type)
+		return name, false, name != ""
+	}
+	return "", false, false
+}
+
+// assertedTypeName returns T or pkg.T. Generic instantiations keep their type arguments, so they
+// never match a declared receiver type and remain unverified.
+func assertedTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		if pkgIdent, ok := t.X.(*ast.Ident); ok {
+			return pkgIdent.Name + "." + t.Sel.Name
+		}
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return types.ExprString(t)
+	}
+	return ""
+}
+
+// VerifyInterfaceAssertions checks each assertion against the analyzed interfaces and the
+// methods declared on the asserted type, and counts the results. Value assertions only accept
+// value-receiver methods. An assertion stays unverified when the interface, the type, or an
+// embedded interface cannot be resolved, or when methods are missing but the type embeds other
+// types that may promote them. Assertions naming an analyzed interface are also counted on
+// that interface's AssertionCount.
+func VerifyInterfaceAssertions(assertions []metrics.InterfaceAssertion, interfaces []metrics.InterfaceMetrics,
+	structs []metrics.StructMetrics, functions []metrics.FunctionMetrics,
+) metrics.InterfaceAssertionMetrics {
+	ifaceIndex := make(map[string]int, len(interfaces))
+	for i, iface := range interfaces {
+		ifaceIndex[iface.Package+"."+iface.Name] = i
+	}
+	embedsTypes := make(map[string]bool, len(structs))
+	knownTypes := make(map[string]bool, len(structs))
+	for _, s := range structs {
+		knownTypes[s.Package+"."+s.Name] = true
+		embedsTypes[s.Package+"."+s.Name] = len(s.EmbeddedTypes) > 0
+	}
+	methodSets := collectReceiverMethodSets(functions)
+	for key := range methodSets {
+		knownTypes[key] = true
+	}
+
+	result := metrics.InterfaceAssertionMetrics{Assertions: make([]metrics.InterfaceAssertion, 0, len(assertions))}
+	for _, assertion := range assertions {
+		ifaceKey := qualifyAssertedName(assertion.Interface, assertion.Package)
+		typeKey := qualifyAssertedName(assertion.Type, assertion.Package)
+
+		if idx, ok := ifaceIndex[ifaceKey]; ok {
+			interfaces[idx].AssertionCount++
+			if knownTypes[typeKey] {
+				required, resolved := requiredInterfaceMethods(interfaces, ifaceIndex, idx, map[int]bool{})
+				assertion.MissingMethods = missingAssertedMethods(required, methodSets[typeKey], assertion.Pointer)
+				assertion.Status = classifyAssertion(assertion.MissingMethods, resolved, embedsTypes[typeKey])
+			}
+		}
+
+		switch assertion.Status {
+		case metrics.AssertionVerified:
+			result.Verified++
+		case metrics.AssertionMismatch:
+			result.Mismatched++
+		default:
+			assertion.Status = metrics.AssertionUnverified
+			result.Unverified++
+		}
+		result.Assertions = append(result.Assertions, assertion)
+	}
+	result.Total = len(result.Assertions)
+	return result
+}
+
+// classifyAssertion decides the assertion status from its missing methods and resolution state
+func classifyAssertion(missing []string, resolved, embedsTypes bool) metrics.InterfaceAssertionStatus {
+	switch {
+	case len(missing) > 0 && !embedsTypes:
+		return metrics.AssertionMismatch
+	case len(missing) > 0 || !resolved:
+		return metrics.AssertionUnverified
+	default:
+		return metrics.AssertionVerified
+	}
+}
+
+// qualifyAssertedName returns pkg.Name, qualifying unqualified names with the declaring package
+func qualifyAssertedName(name, pkgName string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	return pkgName + "." + name
+}
+
+// requiredInterfaceMethods collects the method names of an interface and the interfaces it
+// embeds. It reports false when an embedded interface is not among the analyzed interfaces.
+func requiredInterfaceMethods(interfaces []metrics.InterfaceMetrics, ifaceIndex map[string]int, idx int, visited map[int]bool) ([]string, bool) {
+	if visited[idx] {
+		return nil, true
+	}
+	visited[idx] = true
+
+	iface := interfaces[idx]
+	resolved := true
+	methods := make([]string, 0, len(iface.Methods))
+	for _, m := range iface.Methods {
+		methods = append(methods, m.Name)
+	}
+	for _, embedded := range iface.EmbeddedInterfaces {
+		embeddedIdx, ok := ifaceIndex[qualifyAssertedName(embedded, iface.Package)]
+		if !ok {
+			resolved = false
+			continue
+		}
+		embeddedMethods, embeddedResolved := requiredInterfaceMethods(interfaces, ifaceIndex, embeddedIdx, visited)
+		methods = append(methods, embeddedMethods...)
+		resolved = resolved && embeddedResolved
+	}
+	return methods, resolved
+}
+
+// collectReceiverMethodSets maps pkg.Type to its method names and whether each has a pointer receiver
+func collectReceiverMethodSets(functions []metrics.FunctionMetrics) map[string]map[string]bool {
+	sets := make(map[string]map[string]bool)
+	for _, fn := range functions {
+		if !fn.IsMethod || fn.ReceiverType == "" {
+			continue
+		}
+		key := fn.Package + "." + strings.TrimPrefix(fn.ReceiverType, "*")
+		if sets[key] == nil {
+			sets[key] = make(map[string]bool)
+		}
+		sets[key][fn.Name] = strings.HasPrefix(fn.ReceiverType, "*")
+	}
+	return sets
+}
+
+// missingAssertedMethods returns the sorted required methods absent from the type's method set
+func missingAssertedMethods(required []string, methods map[string]bool, pointer bool) []string {
+	var missing []string
+	seen := make(map[string]bool, len(required))
+	for _, name := range required {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		pointerReceiver, declared := methods[name]
+		if !declared || (pointerReceiver && !pointer) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
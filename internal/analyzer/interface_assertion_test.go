@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyInterfaceAssertions(t *testing.T) {
+	const iface = `package main
+type Closer interface{ Close() error }
+type Store interface {
+	Closer
+	Save(key string) error
+}
+`
+
+	tests := []struct {
+		name           string
+		code           string
+		expectType     string
+		expectPointer  bool
+		expectStatus   metrics.InterfaceAssertionStatus
+		expectMissing  []string
+		expectAsserted int
+	}{
+		{
+			name: "valid pointer assertion",
+			code: `package main
+var _ Store = (*diskStore)(nil)
+type diskStore struct{}
+func (d *diskStore) Save(key string) error { return nil }
+func (d *diskStore) Close() error { return nil }
+`,
+			expectType:     "diskStore",
+			expectPointer:  true,
+			expectStatus:   metrics.AssertionVerified,
+			expectAsserted: 1,
+		},
+		{
+			name: "valid value assertion",
+			code: `package main
+var _ Closer = memStore{}
+type memStore struct{}
+func (m memStore) Close() error { return nil }
+`,
+			expectType:     "memStore",
+			expectStatus:   metrics.AssertionVerified,
+			expectAsserted: 1,
+		},
+		{
+			name: "type missing an embedded interface method",
+			code: `package main
+var _ Store = &brokenStore{}
+type brokenStore struct{}
+func (b *brokenStore) Save(key string) error { return nil }
+`,
+			expectType:     "brokenStore",
+			expectPointer:  true,
+			expectStatus:   metrics.AssertionMismatch,
+			expectMissing:  []string{"Close"},
+			expectAsserted: 1,
+		},
+		{
+			name: "value assertion with pointer receiver",
+			code: `package main
+var _ Closer = fileStore{}
+type fileStore struct{}
+func (f *fileStore) Close() error { return nil }
+`,
+			expectType:     "fileStore",
+			expectStatus:   metrics.AssertionMismatch,
+			expectMissing:  []string{"Close"},
+			expectAsserted: 1,
+		},
+		{
+			name: "external interface",
+			code: `package main
+var _ io.Closer = (*pipe)(nil)
+type pipe struct{}
+func (p *pipe) Close() error { return nil }
+`,
+			expectType:    "pipe",
+			expectPointer: true,
+			expectStatus:  metrics.AssertionUnverified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			var interfaces []metrics.InterfaceMetrics
+			var structs []metrics.StructMetrics
+			var functions []metrics.FunctionMetrics
+			var assertions []metrics.InterfaceAssertion
+			for path, code := range map[string]string{"iface.go": iface, "impl.go": tt.code} {
+				file, err := parser.ParseFile(fset, path, code, parser.ParseComments)
+				require.NoError(t, err)
+
+				ia := NewInterfaceAnalyzer(fset)
+				ifaces, err := ia.AnalyzeInterfacesWithPath(file, "main", path)
+				require.NoError(t, err)
+				interfaces = append(interfaces, ifaces...)
+				assertions = append(assertions, ia.ExtractInterfaceAssertions(file, "main", path)...)
+
+				fileStructs, err := NewStructAnalyzer(fset).AnalyzeStructs(file, "main")
+				require.NoError(t, err)
+				structs = append(structs, fileStructs...)
+
+				funcs, err := NewFunctionAnalyzer(fset).AnalyzeFunctionsWithPath(file, "main", path)
+				require.NoError(t, err)
+				functions = append(functions, funcs...)
+			}
+
+			result := VerifyInterfaceAssertions(assertions, interfaces, structs, functions)
+			require.Len(t, result.Assertions, 1)
+			assertion := result.Assertions[0]
+			assert.Equal(t, tt.expectType, assertion.Type)
+			assert.Equal(t, tt.expectPointer, assertion.Pointer)
+			assert.Equal(t, "impl.go", assertion.File)
+			assert.Equal(t, 2, assertion.Line)
+			assert.Equal(t, tt.expectStatus, assertion.Status)
+			assert.Equal(t, tt.expectMissing, assertion.MissingMethods)
+			assert.Equal(t, 1, result.Total)
+
+			asserted := 0
+			for _, i := range interfaces {
+				asserted += i.AssertionCount
+			}
+			assert.Equal(t, tt.expectAsserted, asserted)
+		})
+	}
+}
+
+func TestExtractInterfaceAssertions_IgnoresOrdinaryVars(t *testing.T) {
+	const code = `package main
+var store Store = (*diskStore)(nil)
+var _ = fmt.Sprintf
+var _ Store = newStore()
+var (
+	_ Store  = (*diskStore)(nil)
+	_ Closer = (*diskStore)(nil)
+)
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "vars.go", code, 0)
+	require.NoError(t, err)
+
+	assertions := NewInterfaceAnalyzer(fset).ExtractInterfaceAssertions(file, "main", "vars.go")
+	require.Len(t, assertions, 2)
+	assert.Equal(t, "Store", assertions[0].Interface)
+	assert.Equal(t, "Closer", assertions[1].Interface)
+}
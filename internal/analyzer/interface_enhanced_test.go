@@ -4,6 +4,8 @@ import (
 	"go/parser"
 	"go/token"
 	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
 // TestNestedInterfaceEmbeddingDepth tests that deeply nested interface embeddings
@@ -83,3 +85,107 @@ Level3Method()
 		})
 	}
 }
+
+// TestEmbeddingDepthChainAndCycles tests the depth of a two-level chain, of chains ending in
+// other packages, and that cyclic embeddings terminate
+func TestEmbeddingDepthChainAndCycles(t *testing.T) {
+	source := `package test
+
+import "io"
+
+type C interface {
+	CMethod()
+}
+
+type B interface {
+	C
+}
+
+type A interface {
+	B
+	io.Closer
+}
+
+type Self interface {
+	Self
+}
+
+type Ping interface {
+	Pong
+}
+
+type Pong interface {
+	Ping
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	interfaces, err := NewInterfaceAnalyzer(fset).AnalyzeInterfaces(file, "test")
+	if err != nil {
+		t.Fatalf("AnalyzeInterfaces failed: %v", err)
+	}
+
+	depths := make(map[string]int)
+	for _, iface := range interfaces {
+		depths[iface.Name] = iface.EmbeddingDepth
+	}
+	expected := map[string]int{"C": 0, "B": 1, "A": 2, "Self": 1, "Ping": 2, "Pong": 2}
+	for name, depth := range expected {
+		if depths[name] != depth {
+			t.Errorf("Interface %s: expected embedding depth %d, got %d", name, depth, depths[name])
+		}
+	}
+}
+
+// TestResolveEmbeddingDepths tests that chains crossing files of a package are resolved once all
+// interfaces are known, and that complexity scores follow the resolved depth
+func TestResolveEmbeddingDepths(t *testing.T) {
+	analyze := func(filename, source string) []metrics.InterfaceMetrics {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, filename, source, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Failed to parse source: %v", err)
+		}
+		interfaces, err := NewInterfaceAnalyzer(fset).AnalyzeInterfacesWithPath(file, "test", filename)
+		if err != nil {
+			t.Fatalf("AnalyzeInterfaces failed: %v", err)
+		}
+		return interfaces
+	}
+
+	interfaces := append(analyze("top.go", `package test
+
+type Top interface {
+	Middle
+}
+`), analyze("lower.go", `package test
+
+type Middle interface {
+	Bottom
+}
+
+type Bottom interface {
+	Run()
+}
+`)...)
+	if interfaces[0].Name != "Top" || interfaces[0].EmbeddingDepth != 1 {
+		t.Fatalf("Expected Top to have depth 1 before resolution, got %s at %d", interfaces[0].Name, interfaces[0].EmbeddingDepth)
+	}
+	scoreBefore := interfaces[0].ComplexityScore
+
+	ResolveEmbeddingDepths(interfaces)
+
+	for i, want := range []int{2, 1, 0} {
+		if interfaces[i].EmbeddingDepth != want {
+			t.Errorf("Interface %s: expected embedding depth %d, got %d", interfaces[i].Name, want, interfaces[i].EmbeddingDepth)
+		}
+	}
+	if interfaces[0].ComplexityScore <= scoreBefore {
+		t.Errorf("Expected Top's complexity score to grow with its depth, got %.2f from %.2f", interfaces[0].ComplexityScore, scoreBefore)
+	}
+}
@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"go/ast"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// AnalyzeImplementationComplexity sets ImplementationComplexity on each interface from the
+// structs that declare all of its methods, embedded interfaces included. For every implementer
+// it totals the code lines and averages the overall complexity of the methods the interface
+// requires, read from the struct's method list, so AttachCrossFileMethods must have run first.
+// Implementers are matched by method name only; an interface with unexported methods only
+// matches structs of its own package, and interfaces embedding unanalyzed interfaces or
+// requiring no methods are skipped. Structs declared in test files are not counted.
+func AnalyzeImplementationComplexity(interfaces []metrics.InterfaceMetrics, structs []metrics.StructMetrics) {
+	ifaceIndex := make(map[string]int, len(interfaces))
+	for i, iface := range interfaces {
+		ifaceIndex[iface.Package+"."+iface.Name] = i
+	}
+
+	for i := range interfaces {
+		required, resolved := requiredInterfaceMethods(interfaces, ifaceIndex, i, map[int]bool{})
+		if !resolved || len(required) == 0 {
+			continue
+		}
+		samePackageOnly := false
+		for _, name := range required {
+			if !ast.IsExported(name) {
+				samePackageOnly = true
+			}
+		}
+
+		var weights []metrics.ImplementationWeight
+		for _, s := range structs {
+			if s.IsTestFile || (samePackageOnly && s.Package != interfaces[i].Package) {
+				continue
+			}
+			if weight, ok := implementationWeight(s, required); ok {
+				weights = append(weights, weight)
+			}
+		}
+		interfaces[i].ImplementationComplexity = summarizeImplementations(weights)
+	}
+}
+
+// implementationWeight measures the methods of s named in required, reporting false when s
+// does not declare all of them
+func implementationWeight(s metrics.StructMetrics, required []string) (metrics.ImplementationWeight, bool) {
+	methods := make(map[string]metrics.MethodInfo, len(s.Methods))
+	for _, m := range s.Methods {
+		methods[m.Name] = m
+	}
+
+	weight := metrics.ImplementationWeight{Type: s.Name, Package: s.Package, File: s.File}
+	seen := make(map[string]bool, len(required))
+	var totalComplexity float64
+	for _, name := range required {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		m, ok := methods[name]
+		if !ok {
+			return metrics.ImplementationWeight{}, false
+		}
+		weight.MethodLines += m.Lines.Code
+		totalComplexity += m.Complexity.Overall
+	}
+	weight.AverageComplexity = totalComplexity / float64(len(seen))
+	return weight, true
+}
+
+// summarizeImplementations aggregates the implementer weights, heaviest first, or returns nil
+// when there are none
+func summarizeImplementations(weights []metrics.ImplementationWeight) *metrics.ImplementationComplexity {
+	if len(weights) == 0 {
+		return nil
+	}
+	sort.Slice(weights, func(i, j int) bool {
+		if weights[i].AverageComplexity != weights[j].AverageComplexity {
+			return weights[i].AverageComplexity > weights[j].AverageComplexity
+		}
+		if weights[i].Package != weights[j].Package {
+			return weights[i].Package < weights[j].Package
+		}
+		return weights[i].Type < weights[j].Type
+	})
+
+	summary := &metrics.ImplementationComplexity{Implementations: weights}
+	var totalLines int
+	var totalComplexity float64
+	minComplexity := weights[0].AverageComplexity
+	for _, w := range weights {
+		totalLines += w.MethodLines
+		totalComplexity += w.AverageComplexity
+		if w.MethodLines > summary.MaxMethodLines {
+			summary.MaxMethodLines = w.MethodLines
+		}
+		if w.AverageComplexity > summary.MaxComplexity {
+			summary.MaxComplexity = w.AverageComplexity
+		}
+		if w.AverageComplexity < minComplexity {
+			minComplexity = w.AverageComplexity
+		}
+	}
+	summary.AverageMethodLines = float64(totalLines) / float64(len(weights))
+	summary.AverageComplexity = totalComplexity / float64(len(weights))
+	summary.ComplexitySpread = summary.MaxComplexity - minComplexity
+	return summary
+}
@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// writeBurdenFile writes src under dir and parses it, since method line counts are read from disk
+func writeBurdenFile(t *testing.T, dir, name, src string) (BurdenFileInfo, []metrics.StructMetrics) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+	return parseBurdenFile(t, path, src)
+}
+
+func TestAnalyzeImplementationComplexity(t *testing.T) {
+	dir := t.TempDir()
+	typeFile, structs := writeBurdenFile(t, dir, "store.go", `package users
+
+type Store interface {
+	Get(id int) string
+	Put(id int, v string)
+}
+
+type memStore struct{ data map[int]string }
+
+type dbStore struct{ rows [][]string }
+
+type partial struct{}
+
+func (p partial) Get(id int) string { return "" }
+`)
+	methodsFile, _ := writeBurdenFile(t, dir, "store_impl.go", `package users
+
+func (m *memStore) Get(id int) string {
+	return m.data[id]
+}
+
+func (m *memStore) Put(id int, v string) {
+	m.data[id] = v
+}
+
+func (d *dbStore) Get(id int) string {
+	for _, row := range d.rows {
+		if len(row) > 1 && row[0] != "" {
+			for _, col := range row {
+				if col == "" {
+					continue
+				}
+				if len(col) > id {
+					return col
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (d *dbStore) Put(id int, v string) {
+	for i, row := range d.rows {
+		if i == id {
+			if len(row) == 0 {
+				d.rows[i] = []string{v}
+			} else if row[0] == v {
+				return
+			} else {
+				d.rows[i] = append(row, v)
+			}
+		}
+	}
+}
+`)
+	AttachCrossFileMethods(structs, []BurdenFileInfo{typeFile, methodsFile}, DefaultStructComplexityWeights())
+
+	interfaces, err := NewInterfaceAnalyzer(typeFile.Fset).AnalyzeInterfacesWithPath(typeFile.File, "users", filepath.Join(dir, "store.go"))
+	require.NoError(t, err)
+	require.Len(t, interfaces, 1)
+
+	AnalyzeImplementationComplexity(interfaces, structs)
+
+	summary := interfaces[0].ImplementationComplexity
+	require.NotNil(t, summary)
+	require.Len(t, summary.Implementations, 2, "partial lacks Put and does not implement Store")
+	heavy, trivial := summary.Implementations[0], summary.Implementations[1]
+	assert.Equal(t, "dbStore", heavy.Type, "the heaviest implementation is listed first")
+	assert.Equal(t, "memStore", trivial.Type)
+	assert.Equal(t, 2, trivial.MethodLines)
+	assert.Greater(t, heavy.MethodLines, 15)
+	assert.Greater(t, heavy.AverageComplexity, trivial.AverageComplexity)
+
+	assert.Equal(t, heavy.MethodLines, summary.MaxMethodLines)
+	assert.Equal(t, heavy.AverageComplexity, summary.MaxComplexity)
+	assert.InDelta(t, heavy.AverageComplexity-trivial.AverageComplexity, summary.ComplexitySpread, 1e-9)
+	assert.InDelta(t, (heavy.AverageComplexity+trivial.AverageComplexity)/2, summary.AverageComplexity, 1e-9)
+	assert.InDelta(t, float64(heavy.MethodLines+trivial.MethodLines)/2, summary.AverageMethodLines, 1e-9)
+}
+
+func TestAnalyzeImplementationComplexity_Unmatched(t *testing.T) {
+	interfaces := []metrics.InterfaceMetrics{
+		{Name: "Closer", Package: "users", Methods: []metrics.InterfaceMethod{{Name: "Close"}}},
+		{Name: "ReadCloser", Package: "users", EmbeddedInterfaces: []string{"io.Reader", "Closer"}},
+		{Name: "hidden", Package: "users", Methods: []metrics.InterfaceMethod{{Name: "close"}}},
+	}
+	structs := []metrics.StructMetrics{
+		{Name: "file", Package: "users", Methods: []metrics.MethodInfo{{Name: "Close"}, {Name: "Read"}}},
+		{Name: "conn", Package: "other", Methods: []metrics.MethodInfo{{Name: "close"}}},
+		{Name: "fake", Package: "users", IsTestFile: true, Methods: []metrics.MethodInfo{{Name: "close"}}},
+	}
+
+	AnalyzeImplementationComplexity(interfaces, structs)
+
+	require.NotNil(t, interfaces[0].ImplementationComplexity)
+	assert.Len(t, interfaces[0].ImplementationComplexity.Implementations, 1)
+	assert.Zero(t, interfaces[0].ImplementationComplexity.ComplexitySpread, "a single implementer has no spread")
+	assert.Nil(t, interfaces[1].ImplementationComplexity, "an unresolved embedded interface leaves the method set unknown")
+	assert.Nil(t, interfaces[2].ImplementationComplexity, "unexported methods only match same-package, non-test structs")
+}
@@ -709,9 +709,9 @@ type ReadWriter interface {
 		t.Errorf("Expected 1 direct method, got %d", readWriter.MethodCount)
 	}
 
-	// Test embedding depth (external package interfaces)
-	if readWriter.EmbeddingDepth != 2 {
-		t.Errorf("Expected embedding depth 2 (external), got %d", readWriter.EmbeddingDepth)
+	// Test embedding depth (external package interfaces end the chain one level down)
+	if readWriter.EmbeddingDepth != 1 {
+		t.Errorf("Expected embedding depth 1 (external), got %d", readWriter.EmbeddingDepth)
 	}
 
 	// Test complexity includes embedding
@@ -783,3 +783,95 @@ type ComplexInterface interface {
 		t.Error("Expected VariadicMethod to return error")
 	}
 }
+
+func TestDetectOversizedMethods(t *testing.T) {
+	source := `package test
+
+type Store interface {
+	Save(ctx context.Context, id string, name string, data []byte, tags []string, owner string, ttl int) error
+	Load(id string) ([]byte, error)
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "store.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	analyzer := NewInterfaceAnalyzer(fset)
+	interfaces, err := analyzer.AnalyzeInterfaces(file, "test")
+	if err != nil {
+		t.Fatalf("AnalyzeInterfaces failed: %v", err)
+	}
+	if len(interfaces) != 1 {
+		t.Fatalf("Expected 1 interface, got %d", len(interfaces))
+	}
+
+	issues := analyzer.DetectOversizedMethods(&interfaces[0], 5, 3)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 oversized method, got %d", len(issues))
+	}
+
+	issue := issues[0]
+	if issue.Function != "Store.Save" {
+		t.Errorf("Expected Store.Save to be flagged, got %s", issue.Function)
+	}
+	if issue.ParameterCount != 7 {
+		t.Errorf("Expected 7 parameters, got %d", issue.ParameterCount)
+	}
+	if issue.Line != 4 {
+		t.Errorf("Expected line 4, got %d", issue.Line)
+	}
+	if issue.Severity != metrics.SeverityLevelWarning {
+		t.Errorf("Expected warning severity, got %s", issue.Severity)
+	}
+	if issue.Suggestion == "" {
+		t.Error("Expected a parameter object suggestion")
+	}
+}
+
+func TestDetectOversizedMethods_NormalInterface(t *testing.T) {
+	source := `package test
+
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "reader.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	analyzer := NewInterfaceAnalyzer(fset)
+	interfaces, err := analyzer.AnalyzeInterfaces(file, "test")
+	if err != nil {
+		t.Fatalf("AnalyzeInterfaces failed: %v", err)
+	}
+	if len(interfaces) != 1 {
+		t.Fatalf("Expected 1 interface, got %d", len(interfaces))
+	}
+
+	if issues := analyzer.DetectOversizedMethods(&interfaces[0], 5, 3); len(issues) != 0 {
+		t.Errorf("Expected no oversized methods, got %d", len(issues))
+	}
+}
+
+func TestCollectMethodDefinitions_MethodLinesMatchFunctionAnalyzer(t *testing.T) {
+	fset, file, _, want := parseMethodLineSource(t)
+
+	analyzer := NewInterfaceAnalyzer(fset)
+	analyzer.collectMethodDefinitions(file, "test")
+
+	methods := analyzer.methodDefinitions["test.Counter"]
+	if len(methods) != 2 {
+		t.Fatalf("Expected 2 methods for test.Counter, got %d", len(methods))
+	}
+	for _, method := range methods {
+		if method.Lines != want[method.Name] {
+			t.Errorf("%s: interface method lines %+v, function analyzer %+v", method.Name, method.Lines, want[method.Name])
+		}
+	}
+}
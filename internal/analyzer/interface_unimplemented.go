@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// DetectUnimplementedInterfaces lists the exported interfaces that no analyzed type implements.
+// A type implements an interface when its methods, collected from every file of its package,
+// cover the interface's method names, embedded interfaces included; implementers declared in
+// test files count, since a test double keeps the abstraction in use. Interfaces with no
+// methods, such as type constraints, and interfaces embedding unanalyzed interfaces are
+// skipped, as are interfaces declared in test files. An interface with at most
+// externalMaxMethods methods that is used as a function parameter type is assumed to be
+// satisfied by types outside the module, the way io.Reader parameters are, and is not listed;
+// an externalMaxMethods of zero disables that exemption.
+func DetectUnimplementedInterfaces(interfaces []metrics.InterfaceMetrics, functions []metrics.FunctionMetrics,
+	files []BurdenFileInfo, externalMaxMethods int,
+) []metrics.UnimplementedInterface {
+	paramUses := make(InterfaceParamUses)
+	for _, fi := range files {
+		paramUses.Add(fi)
+	}
+	return FindUnimplementedInterfaces(interfaces, functions, paramUses, externalMaxMethods)
+}
+
+// FindUnimplementedInterfaces is DetectUnimplementedInterfaces with the parameter uses counted
+// beforehand, for callers that add each file to paramUses while its AST is at hand
+func FindUnimplementedInterfaces(interfaces []metrics.InterfaceMetrics, functions []metrics.FunctionMetrics,
+	paramUses InterfaceParamUses, externalMaxMethods int,
+) []metrics.UnimplementedInterface {
+	ifaceIndex := make(map[string]int, len(interfaces))
+	for i, iface := range interfaces {
+		ifaceIndex[iface.Package+"."+iface.Name] = i
+	}
+	methodSets := collectReceiverMethodSets(functions)
+
+	var unimplemented []metrics.UnimplementedInterface
+	for i, iface := range interfaces {
+		if !ast.IsExported(iface.Name) || isTestFile(iface.File) {
+			continue
+		}
+		required, resolved := requiredInterfaceMethods(interfaces, ifaceIndex, i, map[int]bool{})
+		required = uniqueStrings(required)
+		if !resolved || len(required) == 0 || hasImplementer(required, methodSets) {
+			continue
+		}
+
+		uses := paramUses[iface.Package+"."+iface.Name]
+		if externalMaxMethods > 0 && len(required) <= externalMaxMethods && uses > 0 {
+			continue
+		}
+		unimplemented = append(unimplemented, metrics.UnimplementedInterface{
+			Name:          iface.Name,
+			Package:       iface.Package,
+			File:          iface.File,
+			Line:          iface.Line,
+			MethodCount:   len(required),
+			ParameterUses: uses,
+			Severity:      metrics.SeverityLevelInfo,
+			Suggestion: fmt.Sprintf("Remove '%s' or depend on a concrete type until a type implements it",
+				iface.Name),
+		})
+	}
+
+	sort.Slice(unimplemented, func(i, j int) bool {
+		if unimplemented[i].File != unimplemented[j].File {
+			return unimplemented[i].File < unimplemented[j].File
+		}
+		return unimplemented[i].Line < unimplemented[j].Line
+	})
+	return unimplemented
+}
+
+// hasImplementer reports whether any type's method set declares every required method
+func hasImplementer(required []string, methodSets map[string]map[string]bool) bool {
+	for _, methods := range methodSets {
+		implements := true
+		for _, name := range required {
+			if _, ok := methods[name]; !ok {
+				implements = false
+				break
+			}
+		}
+		if implements {
+			return true
+		}
+	}
+	return false
+}
+
+// InterfaceParamUses counts, per pkg.Name, the parameters of function declarations outside
+// test files whose type, or variadic element type, is a named type. Local names are qualified
+// with the file's package and selectors keep their package qualifier, so both match interfaces
+// of analyzed packages.
+type InterfaceParamUses map[string]int
+
+// Add counts the parameters declared in fi, unless it is a test file
+func (uses InterfaceParamUses) Add(fi BurdenFileInfo) {
+	if fi.File == nil || isTestFile(fi.RelPath) {
+		return
+	}
+	for _, decl := range fi.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Type.Params == nil {
+			continue
+		}
+		for _, field := range fn.Type.Params.List {
+			typ := field.Type
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				typ = ellipsis.Elt
+			}
+			name := assertedTypeName(typ)
+			if name == "" {
+				continue
+			}
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			uses[qualifyAssertedName(name, fi.Pkg)] += count
+		}
+	}
+}
+
+// uniqueStrings returns values without repeats, in first-seen order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
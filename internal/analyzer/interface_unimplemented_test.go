@@ -0,0 +1,148 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// analyzeInterfaceSources parses each source of package main and collects its interfaces,
+// functions, and burden file inputs
+func analyzeInterfaceSources(t *testing.T, sources map[string]string) ([]metrics.InterfaceMetrics, []metrics.FunctionMetrics, []BurdenFileInfo) {
+	t.Helper()
+	paths := make([]string, 0, len(sources))
+	for path := range sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	fset := token.NewFileSet()
+	var interfaces []metrics.InterfaceMetrics
+	var functions []metrics.FunctionMetrics
+	var files []BurdenFileInfo
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, sources[path], parser.ParseComments)
+		require.NoError(t, err)
+
+		ifaces, err := NewInterfaceAnalyzer(fset).AnalyzeInterfacesWithPath(file, "main", path)
+		require.NoError(t, err)
+		interfaces = append(interfaces, ifaces...)
+
+		funcs, err := NewFunctionAnalyzer(fset).AnalyzeFunctionsWithPath(file, "main", path)
+		require.NoError(t, err)
+		functions = append(functions, funcs...)
+
+		files = append(files, BurdenFileInfo{File: file, Fset: fset, Pkg: "main", RelPath: path})
+	}
+	return interfaces, functions, files
+}
+
+func TestDetectUnimplementedInterfaces(t *testing.T) {
+	interfaces, functions, files := analyzeInterfaceSources(t, map[string]string{
+		"api.go": `package main
+
+// Unused is declared but nothing implements or uses it
+type Unused interface {
+	Start() error
+	Stop()
+}
+
+// Source is only ever accepted as a parameter, like io.Reader
+type Source interface {
+	Next() (string, bool)
+}
+
+// Store is implemented by diskStore in another file
+type Store interface {
+	Save(key string) error
+	Load(key string) (string, error)
+}
+
+// Bigger is used as a parameter but has too many methods to be assumed external
+type Bigger interface {
+	Open() error
+	Close() error
+}
+
+type hidden interface {
+	run()
+}
+
+func drain(src Source, extra ...Bigger) {}
+`,
+		"disk.go": `package main
+
+type diskStore struct{}
+
+func (d *diskStore) Save(key string) error { return nil }
+`,
+		"disk_load.go": `package main
+
+func (d *diskStore) Load(key string) (string, error) { return "", nil }
+`,
+	})
+
+	names := func(found []metrics.UnimplementedInterface) []string {
+		result := make([]string, len(found))
+		for i, u := range found {
+			result[i] = u.Name
+		}
+		return result
+	}
+
+	found := DetectUnimplementedInterfaces(interfaces, functions, files, 1)
+	assert.Equal(t, []string{"Unused", "Bigger"}, names(found),
+		"Store is implemented across files, Source is exempt as a parameter type, and unexported interfaces are skipped")
+	require.Len(t, found, 2)
+	assert.Equal(t, "api.go", found[0].File)
+	assert.Equal(t, 4, found[0].Line)
+	assert.Equal(t, 2, found[0].MethodCount)
+	assert.Zero(t, found[0].ParameterUses)
+	assert.Equal(t, 1, found[1].ParameterUses, "the variadic parameter counts as a use")
+	assert.Equal(t, metrics.SeverityLevelInfo, found[0].Severity)
+
+	assert.Equal(t, []string{"Unused", "Source", "Bigger"}, names(DetectUnimplementedInterfaces(interfaces, functions, files, 0)),
+		"a limit of zero disables the external use exemption")
+	assert.Equal(t, []string{"Unused"}, names(DetectUnimplementedInterfaces(interfaces, functions, files, 2)))
+}
+
+func TestDetectUnimplementedInterfaces_SkippedInterfaces(t *testing.T) {
+	interfaces, functions, files := analyzeInterfaceSources(t, map[string]string{
+		"types.go": `package main
+
+import "io"
+
+type Number interface {
+	~int | ~float64
+}
+
+type ReadCounter interface {
+	io.Reader
+	Count() int
+}
+
+type Mocked interface {
+	Do()
+}
+`,
+		"types_test.go": `package main
+
+type Helper interface {
+	Help()
+}
+
+type fakeMocked struct{}
+
+func (f fakeMocked) Do() {}
+`,
+	})
+
+	assert.Empty(t, DetectUnimplementedInterfaces(interfaces, functions, files, 1),
+		"constraints, interfaces embedding unanalyzed interfaces, interfaces with test doubles, and test-file interfaces are not listed")
+}
@@ -285,6 +285,11 @@ func (na *NamingAnalyzer) AnalyzeIdentifiers(file *ast.File, filePath string, fs
 		validSingleLetters: make(map[string]bool),
 	}
 
+	// Generated code follows its generator's conventions, which the author cannot change
+	if ast.IsGenerated(file) {
+		return violations
+	}
+
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.FuncDecl:
@@ -296,6 +301,7 @@ func (na *NamingAnalyzer) AnalyzeIdentifiers(file *ast.File, filePath string, fs
 			ctx.loopDepth++
 		case *ast.AssignStmt:
 			na.trackLoopVariables(node, ctx)
+			na.analyzeShortVarDecl(node, filePath, fset, ctx, &violations)
 		}
 		return true
 	})
@@ -343,6 +349,48 @@ func (na *NamingAnalyzer) analyzeTypeSpec(spec *ast.TypeSpec, filePath string, f
 
 	pos := fset.Position(spec.Pos())
 	na.checkIdentifier(spec.Name.Name, filePath, pos.Line, "type", ctx, violations)
+
+	if structType, ok := spec.Type.(*ast.StructType); ok {
+		na.analyzeStructFields(structType, filePath, fset, ctx, violations)
+	}
+}
+
+// analyzeStructFields checks the casing of named struct fields
+func (na *NamingAnalyzer) analyzeStructFields(structType *ast.StructType, filePath string, fset *token.FileSet, ctx *identifierContext, violations *[]metrics.IdentifierViolation) {
+	if structType.Fields == nil {
+		return
+	}
+	for _, field := range structType.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == "_" {
+				continue
+			}
+			na.checkCasing(name.Name, filePath, fset.Position(name.Pos()).Line, "field", ctx, violations)
+		}
+	}
+}
+
+// analyzeShortVarDecl checks the casing of variables declared with :=
+func (na *NamingAnalyzer) analyzeShortVarDecl(node *ast.AssignStmt, filePath string, fset *token.FileSet, ctx *identifierContext, violations *[]metrics.IdentifierViolation) {
+	if node.Tok != token.DEFINE {
+		return
+	}
+	for _, lhs := range node.Lhs {
+		if ident, ok := lhs.(*ast.Ident); ok && ident.Name != "_" {
+			na.checkCasing(ident.Name, filePath, fset.Position(ident.Pos()).Line, "var", ctx, violations)
+		}
+	}
+}
+
+// checkCasing performs only the MixedCaps check, for fields and local variables where
+// acronym and stuttering advice would be noise
+func (na *NamingAnalyzer) checkCasing(name, filePath string, line int, idType string, ctx *identifierContext, violations *[]metrics.IdentifierViolation) {
+	if v := na.checkMixedCaps(name, ctx); v != nil {
+		v.File = filePath
+		v.Line = line
+		v.Type = idType
+		*violations = append(*violations, *v)
+	}
 }
 
 // analyzeValueSpec analyzes const and var declarations for naming violations
@@ -426,11 +474,22 @@ func (na *NamingAnalyzer) trackLoopVariables(node *ast.AssignStmt, ctx *identifi
 
 // checkMixedCaps verifies identifier uses MixedCaps (no underscores except test functions)
 func (na *NamingAnalyzer) checkMixedCaps(name string, ctx *identifierContext) *metrics.IdentifierViolation {
-	// Allow underscores in test functions (Test_FunctionName pattern)
-	if ctx.isTestFile && strings.HasPrefix(name, "Test_") {
+	// Allow underscores in test functions (Test_FunctionName, TestParse_Empty, ExampleT_Method)
+	if ctx.isTestFile && isTestFunctionName(name) {
 		return nil
 	}
 
+	// ALL_CAPS constants are typically ported from C or Java
+	if isScreamingSnakeCase(name) {
+		return &metrics.IdentifierViolation{
+			Name:          name,
+			ViolationType: "screaming_snake_case",
+			Description:   "Go identifiers should use MixedCaps, not ALL_CAPS with underscores",
+			SuggestedName: na.screamingToMixedCaps(name),
+			Severity:      metrics.SeverityLevelWarning,
+		}
+	}
+
 	// Check for underscores
 	if strings.Contains(name, "_") {
 		suggested := na.toMixedCaps(name)
@@ -446,6 +505,54 @@ func (na *NamingAnalyzer) checkMixedCaps(name string, ctx *identifierContext) *m
 	return nil
 }
 
+// testFunctionPrefixes are the prefixes of functions run by go test, whose names conventionally
+// use underscores to separate the tested symbol from the scenario
+var testFunctionPrefixes = []string{"Test", "Benchmark", "Example", "Fuzz"}
+
+// isTestFunctionName reports whether name follows go test's naming pattern: a test prefix
+// followed by nothing or a character that is not a lowercase letter
+func isTestFunctionName(name string) bool {
+	for _, prefix := range testFunctionPrefixes {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			return rest == "" || !unicode.IsLower(rune(rest[0]))
+		}
+	}
+	return false
+}
+
+// isScreamingSnakeCase reports whether name is all upper case with underscores, like MAX_SIZE
+func isScreamingSnakeCase(name string) bool {
+	if !strings.Contains(strings.Trim(name, "_"), "_") {
+		return false
+	}
+	hasLetter := false
+	for _, r := range name {
+		if unicode.IsLower(r) {
+			return false
+		}
+		hasLetter = hasLetter || unicode.IsUpper(r)
+	}
+	return hasLetter
+}
+
+// screamingToMixedCaps converts an ALL_CAPS name to exported MixedCaps, keeping known
+// acronyms upper case: MAX_HTTP_RETRIES becomes MaxHTTPRetries
+func (na *NamingAnalyzer) screamingToMixedCaps(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part == "" {
+			continue
+		}
+		lower := strings.ToLower(part)
+		if acronym, ok := na.acronyms[lower]; ok {
+			b.WriteString(acronym)
+			continue
+		}
+		b.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+	}
+	return b.String()
+}
+
 // checkSingleLetterName flags inappropriate single-letter names
 func (na *NamingAnalyzer) checkSingleLetterName(name, idType string, ctx *identifierContext) *metrics.IdentifierViolation {
 	if len(name) != 1 {
@@ -425,6 +425,30 @@ func TestNamingAnalyzer_CheckMixedCaps(t *testing.T) {
 			isTestFile:    true,
 			shouldViolate: true,
 		},
+		{
+			name:          "scenario suffix on test function - allowed",
+			identifier:    "TestParse_EmptyInput",
+			isTestFile:    true,
+			shouldViolate: false,
+		},
+		{
+			name:          "example for a method - allowed",
+			identifier:    "ExampleClient_Do",
+			isTestFile:    true,
+			shouldViolate: false,
+		},
+		{
+			name:          "word starting with test prefix - not allowed",
+			identifier:    "Testing_helper",
+			isTestFile:    true,
+			shouldViolate: true,
+		},
+		{
+			name:          "test naming outside test file - not allowed",
+			identifier:    "TestParse_EmptyInput",
+			isTestFile:    false,
+			shouldViolate: true,
+		},
 	}
 
 	na := NewNamingAnalyzer()
@@ -447,6 +471,31 @@ func TestNamingAnalyzer_CheckMixedCaps(t *testing.T) {
 	}
 }
 
+func TestNamingAnalyzer_CheckMixedCaps_ScreamingSnakeCase(t *testing.T) {
+	tests := []struct {
+		identifier    string
+		expectedType  string
+		suggestedName string
+	}{
+		{identifier: "MAX_RETRIES", expectedType: "screaming_snake_case", suggestedName: "MaxRetries"},
+		{identifier: "DEFAULT_HTTP_PORT", expectedType: "screaming_snake_case", suggestedName: "DefaultHTTPPort"},
+		{identifier: "Max_retries", expectedType: "underscore_in_name", suggestedName: "MaxRetries"},
+		{identifier: "max_retries", expectedType: "underscore_in_name", suggestedName: "maxRetries"},
+	}
+
+	na := NewNamingAnalyzer()
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			violation := na.checkMixedCaps(tt.identifier, &identifierContext{})
+			require.NotNil(t, violation)
+			assert.Equal(t, tt.expectedType, violation.ViolationType)
+			assert.Equal(t, tt.suggestedName, violation.SuggestedName)
+		})
+	}
+
+	assert.Nil(t, na.checkMixedCaps("EOF", &identifierContext{}), "all-caps names without underscores are not snake case")
+}
+
 func TestNamingAnalyzer_CheckSingleLetterName(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -768,6 +817,81 @@ var x = 5  // Should flag: single letter outside loop
 	assert.Greater(t, violationTypes["single_letter_name"], 0, "should find single letter violations")
 }
 
+func TestNamingAnalyzer_AnalyzeIdentifiers_Casing(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "snake_case function",
+			filePath: "parse.go",
+			code: `package parse
+func parse_header(line string) string { return line }`,
+			expected: []string{"parse_header"},
+		},
+		{
+			name:     "MixedCaps names",
+			filePath: "parse.go",
+			code: `package parse
+type Header struct {
+	ContentType string
+	maxLength   int
+}
+func parseHeader(line string) Header {
+	trimmedLine := line
+	return Header{ContentType: trimmedLine}
+}`,
+		},
+		{
+			name:     "snake_case field and local variable",
+			filePath: "parse.go",
+			code: `package parse
+type Header struct {
+	content_type string
+}
+func parseHeader(line string) Header {
+	trimmed_line := line
+	return Header{content_type: trimmed_line}
+}`,
+			expected: []string{"content_type", "trimmed_line"},
+		},
+		{
+			name:     "generated code",
+			filePath: "parse.pb.go",
+			code: `// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package parse
+func Get_header() string { return "" }`,
+		},
+		{
+			name:     "test scenario suffixes",
+			filePath: "parse_test.go",
+			code: `package parse
+func TestParseHeader_Empty() {}
+func BenchmarkParseHeader_Long() {}`,
+		},
+	}
+
+	na := NewNamingAnalyzer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, tt.filePath, tt.code, parser.ParseComments)
+			require.NoError(t, err)
+
+			var flagged []string
+			for _, v := range na.AnalyzeIdentifiers(file, tt.filePath, fset) {
+				if v.ViolationType == "underscore_in_name" || v.ViolationType == "screaming_snake_case" {
+					flagged = append(flagged, v.Name)
+				}
+			}
+			assert.Equal(t, tt.expected, flagged)
+		})
+	}
+}
+
 func TestNamingAnalyzer_AnalyzePackageName(t *testing.T) {
 	tests := []struct {
 		name               string
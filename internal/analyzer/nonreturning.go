@@ -0,0 +1,185 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// runLoopNamePrefixes and runLoopNameSuffixes are lower-case name fragments of functions that
+// are expected to loop forever, such as Run, Serve, ListenAndServe, or eventLoop
+var (
+	runLoopNamePrefixes = []string{"run", "serve", "listen", "loop", "poll", "watch"}
+	runLoopNameSuffixes = []string{"loop", "forever"}
+)
+
+// ClassifyNonReturning reports whether a function declaration can never return to its caller
+// and, if so, whether it is an expected run-loop. A function never returns when its body reaches
+// an infinite for loop with no return, goto, or break leaving it, or an empty select, without
+// passing a return statement first. Function literals are ignored, so goroutine bodies do not
+// affect the enclosing function. Run-loops are main in package main, functions named like a
+// run or serve loop, and loops that wait on select or accept connections.
+func ClassifyNonReturning(funcDecl *ast.FuncDecl, pkgName string) metrics.NonReturningKind {
+	if funcDecl.Body == nil {
+		return ""
+	}
+	blocking := findBlockingForeverStmt(funcDecl.Body)
+	if blocking == nil {
+		return ""
+	}
+	if isRunLoopFunction(funcDecl, pkgName, blocking) {
+		return metrics.NonReturningRunLoop
+	}
+	return metrics.NonReturningUnexpected
+}
+
+// findBlockingForeverStmt returns the first top-level statement that never completes, or nil when
+// the body contains a reachable return or never blocks forever
+func findBlockingForeverStmt(body *ast.BlockStmt) ast.Stmt {
+	for _, stmt := range body.List {
+		if blocksForever(stmt) {
+			return stmt
+		}
+		if containsReturn(stmt) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// blocksForever reports whether stmt is an empty select or an infinite loop nothing can leave
+func blocksForever(stmt ast.Stmt) bool {
+	label := ""
+	if labeled, ok := stmt.(*ast.LabeledStmt); ok {
+		label = labeled.Label.Name
+		stmt = labeled.Stmt
+	}
+
+	switch s := stmt.(type) {
+	case *ast.SelectStmt:
+		return len(s.Body.List) == 0
+	case *ast.ForStmt:
+		return s.Cond == nil && !hasLoopExit(s.Body, label, false)
+	}
+	return false
+}
+
+// hasLoopExit reports whether node contains a return, a goto, or a break leaving the loop. Unlabeled
+// breaks only count when they are not nested inside another breakable statement.
+func hasLoopExit(node ast.Node, label string, nested bool) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		case *ast.BranchStmt:
+			found = s.Tok == token.GOTO ||
+				(s.Tok == token.BREAK && s.Label == nil && !nested) ||
+				(s.Tok == token.BREAK && s.Label != nil && s.Label.Name == label)
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			if n != node {
+				found = hasLoopExit(breakableBody(s), label, true)
+				return false
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// breakableBody returns the body of a statement that an unlabeled break applies to
+func breakableBody(stmt ast.Node) ast.Node {
+	switch s := stmt.(type) {
+	case *ast.ForStmt:
+		return s.Body
+	case *ast.RangeStmt:
+		return s.Body
+	case *ast.SwitchStmt:
+		return s.Body
+	case *ast.TypeSwitchStmt:
+		return s.Body
+	case *ast.SelectStmt:
+		return s.Body
+	}
+	return stmt
+}
+
+// containsReturn reports whether stmt contains a return statement outside function literals
+func containsReturn(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isRunLoopFunction reports whether a non-returning function is an expected run-loop
+func isRunLoopFunction(funcDecl *ast.FuncDecl, pkgName string, blocking ast.Stmt) bool {
+	name := strings.ToLower(funcDecl.Name.Name)
+	if name == "main" && funcDecl.Recv == nil && pkgName == "main" {
+		return true
+	}
+	for _, prefix := range runLoopNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, suffix := range runLoopNameSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return waitsForEvents(blocking)
+}
+
+// waitsForEvents reports whether a blocking statement selects on channels or accepts connections,
+// which marks it as an event or server loop rather than a busy hang
+func waitsForEvents(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SelectStmt:
+			found = true
+		case *ast.CallExpr:
+			if sel, ok := s.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Accept" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// checkNonReturningFunction flags functions that can never return and are not recognizable
+// run-loops, since callers of such helpers hang
+func (a *AntipatternAnalyzer) checkNonReturningFunction(funcDecl *ast.FuncDecl, pkgName string) []metrics.PerformanceAntipattern {
+	if ClassifyNonReturning(funcDecl, pkgName) != metrics.NonReturningUnexpected {
+		return nil
+	}
+
+	pos := a.fset.Position(funcDecl.Pos())
+	return []metrics.PerformanceAntipattern{{
+		Type:        "non_returning_function",
+		Description: fmt.Sprintf("Function '%s' can never return to its caller", funcDecl.Name.Name),
+		Severity:    metrics.SeverityLevelWarning,
+		File:        pos.Filename,
+		Line:        pos.Line,
+		Suggestion:  "Add an exit condition such as a context or done channel, or name the function as a run loop if blocking forever is intended",
+	}}
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyNonReturning(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		expectKind    metrics.NonReturningKind
+		expectPattern int
+		description   string
+	}{
+		{
+			name: "server run-loop main",
+			code: `package main
+func main() {
+	ln := listen()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			continue
+		}
+		go handle(conn)
+	}
+}`,
+			expectKind:  metrics.NonReturningRunLoop,
+			description: "main accepting connections forever is an expected run-loop",
+		},
+		{
+			name: "accidental non-returning helper",
+			code: `package worker
+func drain(items []int) int {
+	total := 0
+	for {
+		for _, item := range items {
+			if item < 0 {
+				break
+			}
+			total += item
+		}
+	}
+}`,
+			expectKind:    metrics.NonReturningUnexpected,
+			expectPattern: 1,
+			description:   "The inner break only leaves the range loop, so the helper hangs its caller",
+		},
+		{
+			name: "event loop selecting on channels",
+			code: `package worker
+func process(events <-chan int, done <-chan struct{}) {
+	for {
+		select {
+		case e := <-events:
+			handleEvent(e)
+		case <-done:
+			cleanup()
+		}
+	}
+}`,
+			expectKind:  metrics.NonReturningRunLoop,
+			description: "A loop waiting on select is an event loop",
+		},
+		{
+			name: "empty select in named run function",
+			code: `package worker
+func Run() {
+	start()
+	select {}
+}`,
+			expectKind:  metrics.NonReturningRunLoop,
+			description: "Blocking forever on an empty select in Run is intended",
+		},
+		{
+			name: "loop left by labeled break",
+			code: `package worker
+func scan(lines []string) {
+outer:
+	for {
+		for _, line := range lines {
+			if line == "" {
+				break outer
+			}
+		}
+	}
+}`,
+			description: "A labeled break leaves the infinite loop",
+		},
+		{
+			name: "loop with return",
+			code: `package worker
+func next(ch <-chan int) int {
+	for {
+		if v := <-ch; v > 0 {
+			return v
+		}
+	}
+}`,
+			description: "A return inside the loop lets the function return",
+		},
+		{
+			name: "early return before loop",
+			code: `package worker
+func spin(enabled bool) {
+	if !enabled {
+		return
+	}
+	for {
+	}
+}`,
+			description: "The function returns when disabled",
+		},
+		{
+			name: "infinite loop only in goroutine",
+			code: `package worker
+func Start() {
+	go func() {
+		for {
+		}
+	}()
+}`,
+			description: "Goroutine bodies do not affect the enclosing function",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, 0)
+			require.NoError(t, err)
+
+			funcDecl, ok := file.Decls[0].(*ast.FuncDecl)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectKind, ClassifyNonReturning(funcDecl, file.Name.Name), tt.description)
+
+			count := 0
+			for _, p := range NewAntipatternAnalyzer(fset).Analyze(file) {
+				if p.Type == "non_returning_function" {
+					count++
+				}
+			}
+			assert.Equal(t, tt.expectPattern, count, tt.description)
+		})
+	}
+}
@@ -3,7 +3,9 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -290,7 +292,8 @@ type PackageInfo struct {
 func (oa *OrganizationAnalyzer) AnalyzePackageSizes(pkgs map[string]*PackageInfo, config OrganizationConfig) []metrics.OversizedPackage {
 	var results []metrics.OversizedPackage
 
-	for name, pkg := range pkgs {
+	for _, name := range slices.Sorted(maps.Keys(pkgs)) {
+		pkg := pkgs[name]
 		if oa.shouldReportPackage(pkg, config) {
 			results = append(results, metrics.OversizedPackage{
 				Package:         name,
@@ -636,8 +639,8 @@ func (oa *OrganizationAnalyzer) calculateAvgInstability(graphData *ImportGraphDa
 	total := 0.0
 	count := 0
 
-	for pkg, fanOutDeps := range graphData.PackageFanOut {
-		fanOut := len(fanOutDeps)
+	for _, pkg := range slices.Sorted(maps.Keys(graphData.PackageFanOut)) {
+		fanOut := len(graphData.PackageFanOut[pkg])
 		fanIn := len(graphData.PackageFanIn[pkg])
 		instability := oa.calculateInstability(fanIn, fanOut)
 		total += instability
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
@@ -31,11 +33,17 @@ var knownExternalPrefixes = []string{
 // PackageAnalyzer provides architectural insights for large Go codebases.
 type PackageAnalyzer struct {
 	fset             *token.FileSet
-	packageDeps      map[string][]string // package -> imported packages
-	packageFiles     map[string][]string // package -> source files
-	packageFunctions map[string]int      // package -> function count
-	packageTypes     map[string]int      // package -> type count
-	packageLines     map[string]int      // package -> total lines of code
+	packageDeps      map[string][]string                // package -> imported packages
+	packageFiles     map[string][]string                // package -> source files
+	packageFunctions map[string]int                     // package -> function count
+	packageTypes     map[string]int                     // package -> type count
+	packageLines     map[string]int                     // package -> total lines of code
+	packageMethods   map[string]map[string]methodAccess // package -> type -> method member access
+	packageDirs      map[string]string                  // package -> directory of its first file
+	modulePath       string                             // module path from go.mod, "" when unknown
+	moduleRoot       string                             // absolute directory containing go.mod
+	nestedModules    []metrics.NestedModule             // modules rooted below moduleRoot
+	workers          int                                // goroutines computing per-package metrics
 }
 
 // NewPackageAnalyzer creates a new package analyzer for architectural analysis including dependency
@@ -50,6 +58,8 @@ func NewPackageAnalyzer(fset *token.FileSet) *PackageAnalyzer {
 		packageFunctions: make(map[string]int),
 		packageTypes:     make(map[string]int),
 		packageLines:     make(map[string]int),
+		packageMethods:   make(map[string]map[string]methodAccess),
+		packageDirs:      make(map[string]string),
 	}
 }
 
@@ -74,6 +84,9 @@ func (pa *PackageAnalyzer) AnalyzePackage(file *ast.File, filePath string) error
 // trackPackageFile records a file as belonging to the specified package.
 func (pa *PackageAnalyzer) trackPackageFile(pkgName, filePath string) {
 	pa.packageFiles[pkgName] = append(pa.packageFiles[pkgName], filePath)
+	if _, ok := pa.packageDirs[pkgName]; !ok {
+		pa.packageDirs[pkgName] = filepath.Dir(filePath)
+	}
 }
 
 // analyzePackageImports extracts internal dependencies from file imports.
@@ -83,13 +96,15 @@ func (pa *PackageAnalyzer) analyzePackageImports(file *ast.File, pkgName string)
 	pa.packageDeps[pkgName] = mergeUniqueStrings(existing, imports)
 }
 
-// extractInternalImports collects internal package imports, excluding stdlib and external packages.
+// extractInternalImports collects imports of the analyzed module's own packages, excluding
+// stdlib and external packages. Import aliases and dot imports do not change the import path,
+// so they are recorded like any other import.
 func (pa *PackageAnalyzer) extractInternalImports(file *ast.File) []string {
 	var imports []string
 	for _, imp := range file.Imports {
 		if imp.Path != nil {
 			importPath := strings.Trim(imp.Path.Value, `"`)
-			if isInternalPackage(importPath) {
+			if pa.isModuleImport(importPath) {
 				imports = append(imports, importPath)
 			}
 		}
@@ -102,6 +117,11 @@ func (pa *PackageAnalyzer) countDeclarations(file *ast.File, pkgName string) {
 	functionCount, typeCount := pa.extractDeclCounts(file)
 	pa.packageFunctions[pkgName] += functionCount
 	pa.packageTypes[pkgName] += typeCount
+
+	if pa.packageMethods[pkgName] == nil {
+		pa.packageMethods[pkgName] = make(map[string]methodAccess)
+	}
+	collectMethodAccess(file, pa.packageMethods[pkgName])
 }
 
 // extractDeclCounts extracts function and type declaration counts from file.
@@ -170,17 +190,62 @@ func (pa *PackageAnalyzer) GenerateReport() (*metrics.PackageReport, error) {
 	return report, nil
 }
 
-// buildPackageMetrics creates PackageMetrics for all analyzed packages.
+// SetWorkerCount sets how many goroutines GenerateReport uses to compute the cohesion and
+// coupling of packages. One or less computes them serially.
+func (pa *PackageAnalyzer) SetWorkerCount(workers int) {
+	pa.workers = workers
+}
+
+// buildPackageMetrics creates PackageMetrics for all analyzed packages, sorted by name. Packages
+// are measured independently, so they are spread across the configured workers.
 func (pa *PackageAnalyzer) buildPackageMetrics() []metrics.PackageMetrics {
-	packages := make([]metrics.PackageMetrics, 0, len(pa.packageFiles))
+	dependents := pa.buildDependents()
+	names := make([]string, 0, len(pa.packageFiles))
 	for pkgName := range pa.packageFiles {
-		packages = append(packages, pa.createPackageMetrics(pkgName))
+		names = append(names, pkgName)
 	}
+	sort.Strings(names)
+
+	packages := make([]metrics.PackageMetrics, len(names))
+	parallelFor(len(names), pa.workers, func(i int) {
+		packages[i] = pa.createPackageMetrics(names[i], dependents[names[i]])
+	})
 	return packages
 }
 
-// createPackageMetrics builds metrics for a single package.
-func (pa *PackageAnalyzer) createPackageMetrics(pkgName string) metrics.PackageMetrics {
+// parallelFor calls fn for every index below n on up to workers goroutines, or serially in index
+// order when workers is one or less. fn must only write state owned by its index.
+func parallelFor(n, workers int, fn func(i int)) {
+	if workers <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	if workers > n {
+		workers = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// createPackageMetrics builds metrics for a single package given the packages that import it.
+func (pa *PackageAnalyzer) createPackageMetrics(pkgName string, dependents []string) metrics.PackageMetrics {
 	pkg := metrics.PackageMetrics{
 		Name:         pkgName,
 		Path:         pkgName,
@@ -189,13 +254,14 @@ func (pa *PackageAnalyzer) createPackageMetrics(pkgName string) metrics.PackageM
 		Structs:      pa.packageTypes[pkgName],
 		Interfaces:   0,
 		Dependencies: pa.packageDeps[pkgName],
+		Dependents:   dependents,
 		Lines: metrics.LineMetrics{
 			Total: pa.packageLines[pkgName],
 			Code:  pa.packageLines[pkgName],
 		},
 	}
 	pkg.CohesionScore = pa.calculateCohesion(pkgName)
-	pkg.CouplingScore = pa.calculateCoupling(pkgName)
+	pkg.CouplingScore = pa.calculateCoupling(pkgName, dependents)
 	return pkg
 }
 
@@ -206,42 +272,18 @@ func sortPackagesByName(packages []metrics.PackageMetrics) {
 	})
 }
 
-// calculateCohesion measures how well elements within a package work together
-// Higher scores indicate better cohesion (elements belong together)
+// calculateCohesion measures how well elements within a package work together as the
+// method-weighted average of each type's normalized LCOM4 (see packageCohesion), from 0 (methods
+// share nothing) to 1 (every type's methods work on shared state)
 func (pa *PackageAnalyzer) calculateCohesion(pkgName string) float64 {
-	functionCount := pa.packageFunctions[pkgName]
-	typeCount := pa.packageTypes[pkgName]
-	fileCount := len(pa.packageFiles[pkgName])
-
-	// Cohesion heuristic: fewer files with more functions/types = higher cohesion
-	if fileCount == 0 {
-		return 0.0
-	}
-
-	elementsPerFile := float64(functionCount+typeCount) / float64(fileCount)
-
-	// Normalize to 0-10 scale
-	cohesion := elementsPerFile / 5.0 // Assuming 5 elements per file is average
-	if cohesion > 10.0 {
-		cohesion = 10.0
-	}
-
-	return cohesion
+	return packageCohesion(pa.packageMethods[pkgName])
 }
 
-// calculateCoupling measures dependencies between packages
-// Lower scores indicate better design (fewer dependencies)
-func (pa *PackageAnalyzer) calculateCoupling(pkgName string) float64 {
-	depCount := len(pa.packageDeps[pkgName])
-
-	// Simple coupling metric: number of dependencies
-	// Normalize to 0-10 scale (0 = no deps, 10 = many deps)
-	coupling := float64(depCount) / 2.0 // Assuming 2 deps is average
-	if coupling > 10.0 {
-		coupling = 10.0
-	}
-
-	return coupling
+// calculateCoupling measures dependencies between packages as the instability of pkgName:
+// its internal imports are the efferent coupling and the packages importing it the afferent
+// coupling (see PackageInstability)
+func (pa *PackageAnalyzer) calculateCoupling(pkgName string, dependents []string) float64 {
+	return PackageInstability(len(pa.packageDeps[pkgName]), len(dependents))
 }
 
 // calculateComplexity combines multiple factors into an overall complexity score
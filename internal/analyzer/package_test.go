@@ -3,6 +3,7 @@ package analyzer
 import (
 	"go/parser"
 	"go/token"
+	"path/filepath"
 	"testing"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -271,46 +272,147 @@ type Config struct {
 }
 
 func TestCohesionCalculation(t *testing.T) {
+	src := `package shop
+
+type Cart struct {
+	items []string
+	total int
+}
+
+func (c *Cart) Add(item string) { c.items = append(c.items, item); c.total++ }
+func (c *Cart) Count() int      { return c.total }
+func (c *Cart) Items() []string { return c.items }
+
+type Utils struct {
+	logger string
+	cache  map[string]int
+}
+
+func (u *Utils) Log(msg string)        { u.logger = msg }
+func (u *Utils) Lookup(key string) int { return u.cache[key] }
+func (u *Utils) Reset()                { u.cache = nil }
+func (u *Utils) Trace()                { u.Log("trace") }
+`
 	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "shop.go", src, 0)
+	require.NoError(t, err)
+
 	analyzer := NewPackageAnalyzer(fset)
+	require.NoError(t, analyzer.AnalyzePackage(file, "shop.go"))
+
+	types := analyzer.packageMethods["shop"]
+	assert.Equal(t, 1, lcom4(types["Cart"]), "every Cart method shares items or total")
+	assert.Equal(t, 2, lcom4(types["Utils"]), "logging and caching are separate responsibilities")
+	assert.Equal(t, 1.0, typeCohesion(lcom4(types["Cart"]), len(types["Cart"])))
+	assert.InDelta(t, 2.0/3.0, typeCohesion(lcom4(types["Utils"]), len(types["Utils"])), 1e-9)
+
+	// Weighted by method count: (1.0*3 + 2/3*4) / 7
+	assert.InDelta(t, (3.0+8.0/3.0)/7.0, analyzer.calculateCohesion("shop"), 1e-9)
 
-	// Add test data for cohesion calculation
-	analyzer.packageFunctions["high_cohesion"] = 10
-	analyzer.packageTypes["high_cohesion"] = 5
-	analyzer.packageFiles["high_cohesion"] = []string{"file1.go", "file2.go"}
+	// A package without multi-method types has nothing to split
+	assert.Equal(t, 1.0, analyzer.calculateCohesion("nonexistent"))
+}
+
+// couplingFixture is a small module where main -> api -> {store, model} and store -> model
+var couplingFixture = map[string]string{
+	"main.go": `package main
+
+import "example.com/shop/api"
+
+func main() { api.Serve() }
+`,
+	"api/api.go": `package api
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+	st "example.com/shop/store"
+	. "example.com/shop/model"
+)
 
-	analyzer.packageFunctions["low_cohesion"] = 2
-	analyzer.packageTypes["low_cohesion"] = 1
-	analyzer.packageFiles["low_cohesion"] = []string{"f1.go", "f2.go", "f3.go", "f4.go", "f5.go"}
+func Serve() { fmt.Println(st.Load(), assert.True, Item{}) }
+`,
+	"store/store.go": `package store
 
-	highCohesion := analyzer.calculateCohesion("high_cohesion")
-	lowCohesion := analyzer.calculateCohesion("low_cohesion")
+import "example.com/shop/model"
 
-	// High cohesion should have more elements per file
-	assert.Greater(t, highCohesion, lowCohesion, "High cohesion package should score higher")
+func Load() model.Item { return model.Item{} }
+`,
+	"model/model.go": `package model
 
-	// Test edge case - no files
-	emptyCohesion := analyzer.calculateCohesion("nonexistent")
-	assert.Equal(t, 0.0, emptyCohesion)
+type Item struct{}
+`,
 }
 
-func TestCouplingCalculation(t *testing.T) {
+// analyzeCouplingFixture runs the package analyzer over couplingFixture rooted at root
+func analyzeCouplingFixture(t *testing.T, root string, withModule bool) map[string]metrics.PackageMetrics {
+	t.Helper()
 	fset := token.NewFileSet()
 	analyzer := NewPackageAnalyzer(fset)
+	if withModule {
+		analyzer.SetModule("example.com/shop", root)
+	}
+	for name, src := range couplingFixture {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		file, err := parser.ParseFile(fset, path, src, parser.ImportsOnly)
+		require.NoError(t, err)
+		require.NoError(t, analyzer.AnalyzePackage(file, path))
+	}
+
+	report, err := analyzer.GenerateReport()
+	require.NoError(t, err)
+	packages := make(map[string]metrics.PackageMetrics)
+	for _, pkg := range report.Packages {
+		packages[pkg.Name] = pkg
+	}
+	return packages
+}
+
+func TestCouplingCalculation(t *testing.T) {
+	root, err := filepath.Abs(filepath.Join("testdata", "shop"))
+	require.NoError(t, err)
 
-	// Add test data for coupling calculation
-	analyzer.packageDeps["high_coupling"] = []string{"dep1", "dep2", "dep3", "dep4", "dep5"}
-	analyzer.packageDeps["low_coupling"] = []string{"dep1"}
-	analyzer.packageDeps["no_coupling"] = []string{}
+	tests := []struct {
+		name       string
+		root       string
+		withModule bool
+	}{
+		{"module import paths", root, true},
+		{"directory suffix fallback", "shop", false},
+	}
 
-	highCoupling := analyzer.calculateCoupling("high_coupling")
-	lowCoupling := analyzer.calculateCoupling("low_coupling")
-	noCoupling := analyzer.calculateCoupling("no_coupling")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packages := analyzeCouplingFixture(t, tt.root, tt.withModule)
+
+			api := packages["api"]
+			assert.Equal(t, []string{"example.com/shop/model", "example.com/shop/store"}, api.Dependencies,
+				"aliased and dot imports count; stdlib and external imports do not")
+			assert.Equal(t, []string{"main"}, api.Dependents)
+			assert.InDelta(t, 2.0/3.0, api.CouplingScore, 1e-9, "Ce=2, Ca=1")
+
+			store := packages["store"]
+			assert.Equal(t, []string{"api"}, store.Dependents)
+			assert.InDelta(t, 0.5, store.CouplingScore, 1e-9, "Ce=1, Ca=1")
+
+			model := packages["model"]
+			assert.Empty(t, model.Dependencies)
+			assert.Equal(t, []string{"api", "store"}, model.Dependents)
+			assert.Equal(t, 0.0, model.CouplingScore, "only depended upon: maximally stable")
+
+			main := packages["main"]
+			assert.Empty(t, main.Dependents)
+			assert.Equal(t, 1.0, main.CouplingScore, "depends on others, nothing depends on it")
+		})
+	}
+}
 
-	// More dependencies should result in higher coupling
-	assert.Greater(t, highCoupling, lowCoupling, "High coupling package should score higher")
-	assert.Greater(t, lowCoupling, noCoupling, "Low coupling should be higher than no coupling")
-	assert.Equal(t, 0.0, noCoupling, "No dependencies should result in 0 coupling")
+func TestPackageInstability(t *testing.T) {
+	assert.Equal(t, 0.0, PackageInstability(0, 0), "isolated package")
+	assert.Equal(t, 1.0, PackageInstability(3, 0))
+	assert.Equal(t, 0.0, PackageInstability(0, 4))
+	assert.Equal(t, 0.25, PackageInstability(1, 3))
 }
 
 func TestCircularDependencyDetection(t *testing.T) {
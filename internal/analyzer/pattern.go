@@ -1,8 +1,11 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"sort"
 	"strings"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -20,7 +23,9 @@ func NewPatternAnalyzer(fset *token.FileSet) *PatternAnalyzer {
 	return &PatternAnalyzer{fset: fset}
 }
 
-// AnalyzePatterns detects design patterns in an AST file
+// AnalyzePatterns detects design patterns in an AST file. Strategy detection needs every file
+// of a package, so here the file is treated as the whole package; use DetectStrategyPatterns
+// to analyze a multi-file package.
 func (pa *PatternAnalyzer) AnalyzePatterns(file *ast.File, pkgName, filePath string) (metrics.DesignPatternMetrics, error) {
 	patterns := metrics.DesignPatternMetrics{
 		Singleton: []metrics.PatternInstance{},
@@ -37,13 +42,14 @@ func (pa *PatternAnalyzer) AnalyzePatterns(file *ast.File, pkgName, filePath str
 	pa.detectFactory(file, filePath, &patterns, ifaceNames)
 	pa.detectBuilder(file, filePath, &patterns)
 	pa.detectObserver(file, filePath, &patterns)
-	pa.detectStrategy(file, filePath, &patterns, ifaceNames)
+	patterns.Strategy = append(patterns.Strategy,
+		DetectStrategyPatterns([]BurdenFileInfo{{File: file, Fset: pa.fset, Pkg: pkgName, RelPath: filePath}})...)
 
 	return patterns, nil
 }
 
 // buildInterfaceNameSet scans the file for type declarations that are interfaces
-// and returns a set of their names. This allows isInterfaceField/isInterfaceReturn
+// and returns a set of their names. This allows isInterfaceReturn
 // to detect interfaces declared anywhere in the file, not just in the same scope.
 func (pa *PatternAnalyzer) buildInterfaceNameSet(file *ast.File) map[string]bool {
 	names := make(map[string]bool)
@@ -78,51 +84,116 @@ func (pa *PatternAnalyzer) extractInterfaceName(spec ast.Spec) string {
 	return ""
 }
 
-// detectSingleton identifies singleton patterns via sync.Once or init
+// detectSingleton identifies singletons: an accessor function that runs once.Do on a
+// package-level sync.Once and returns a package-level instance variable. Confidence starts at
+// 0.6 for the accessor and rises when the once.Do closure assigns the returned instance and
+// when the accessor follows GetInstance-style naming.
 func (pa *PatternAnalyzer) detectSingleton(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics) {
-	hasSyncOnce := false
-	var onceLine int
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		if genDecl, ok := n.(*ast.GenDecl); ok && genDecl.Tok == token.VAR {
-			if found, line := pa.inspectVarDeclForSyncOnce(genDecl); found {
-				hasSyncOnce = true
-				onceLine = line
+	onceVars, instanceVars := pa.collectPackageVars(file)
+	if len(onceVars) == 0 {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil || funcDecl.Body == nil {
+			continue
+		}
+		instance, confidence := pa.scoreSingletonAccessor(funcDecl, onceVars, instanceVars)
+		if confidence > 0.6 {
+			pa.addSingletonPattern(patterns, filePath, funcDecl, instance, confidence)
+		}
+	}
+}
+
+// collectPackageVars splits package-level variables into sync.Once guards and all others
+func (pa *PatternAnalyzer) collectPackageVars(file *ast.File) (onceVars, instanceVars map[string]bool) {
+	onceVars = make(map[string]bool)
+	instanceVars = make(map[string]bool)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				if pa.hasSyncOnceType(valueSpec.Type) || pa.hasSyncOnceValue(valueSpec, i) {
+					onceVars[name.Name] = true
+				} else if name.Name != "_" {
+					instanceVars[name.Name] = true
+				}
+			}
+		}
+	}
+	return onceVars, instanceVars
+}
+
+// scoreSingletonAccessor returns the instance variable a function hands out after calling
+// once.Do, and the confidence that the function is a singleton accessor (0 when it is not)
+func (pa *PatternAnalyzer) scoreSingletonAccessor(funcDecl *ast.FuncDecl, onceVars, instanceVars map[string]bool) (string, float64) {
+	var doArg ast.Expr
+	returned := ""
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Do" && len(node.Args) == 1 {
+				if ident, ok := sel.X.(*ast.Ident); ok && onceVars[ident.Name] {
+					doArg = node.Args[0]
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(node.Results) > 0 {
+				if ident, ok := node.Results[0].(*ast.Ident); ok && instanceVars[ident.Name] {
+					returned = ident.Name
+				}
 			}
 		}
 		return true
 	})
+	if doArg == nil || returned == "" {
+		return "", 0
+	}
 
-	if hasSyncOnce {
-		pa.addSingletonPattern(patterns, filePath, onceLine)
+	confidence := 0.6
+	if lit, ok := doArg.(*ast.FuncLit); ok && assignsIdent(lit.Body, returned) {
+		confidence += 0.2
 	}
+	if pa.isSingletonAccessorName(funcDecl.Name.Name) {
+		confidence += 0.15
+	}
+	return returned, min(confidence, 1.0)
 }
 
-// inspectVarDeclForSyncOnce checks if a variable declaration contains sync.Once
-func (pa *PatternAnalyzer) inspectVarDeclForSyncOnce(genDecl *ast.GenDecl) (bool, int) {
-	for _, spec := range genDecl.Specs {
-		valueSpec, ok := spec.(*ast.ValueSpec)
+// assignsIdent reports whether body assigns to the named variable
+func assignsIdent(body *ast.BlockStmt, name string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
 		if !ok {
-			continue
+			return !found
 		}
-		if found, line := pa.checkValueSpecForSyncOnce(valueSpec); found {
-			return true, line
+		for _, lhs := range assign.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok && ident.Name == name {
+				found = true
+			}
 		}
-	}
-	return false, 0
+		return !found
+	})
+	return found
 }
 
-// checkValueSpecForSyncOnce checks if a ValueSpec contains sync.Once
-func (pa *PatternAnalyzer) checkValueSpecForSyncOnce(valueSpec *ast.ValueSpec) (bool, int) {
-	for i, name := range valueSpec.Names {
-		if pa.hasSyncOnceType(valueSpec.Type) {
-			return true, pa.fset.Position(name.Pos()).Line
-		}
-		if pa.hasSyncOnceValue(valueSpec, i) {
-			return true, pa.fset.Position(name.Pos()).Line
+// isSingletonAccessorName checks for GetInstance-style accessor names
+func (pa *PatternAnalyzer) isSingletonAccessorName(name string) bool {
+	for _, prefix := range []string{"Get", "Instance", "Default", "Shared", "Global"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
 		}
 	}
-	return false, 0
+	return false
 }
 
 // hasSyncOnceType checks if the type is sync.Once
@@ -140,270 +211,318 @@ func (pa *PatternAnalyzer) hasSyncOnceValue(valueSpec *ast.ValueSpec, i int) boo
 }
 
 // addSingletonPattern appends a singleton pattern instance to the metrics
-func (pa *PatternAnalyzer) addSingletonPattern(patterns *metrics.DesignPatternMetrics, filePath string, line int) {
+func (pa *PatternAnalyzer) addSingletonPattern(patterns *metrics.DesignPatternMetrics, filePath string, accessor *ast.FuncDecl, instance string, confidence float64) {
 	patterns.Singleton = append(patterns.Singleton, metrics.PatternInstance{
 		Name:            "Singleton (sync.Once)",
 		File:            filePath,
-		Line:            line,
-		ConfidenceScore: 0.95,
+		Line:            pa.fset.Position(accessor.Pos()).Line,
+		ConfidenceScore: confidence,
 		Description:     "Thread-safe singleton using sync.Once",
-		Example:         "sync.Once variable for singleton initialization",
+		Example:         fmt.Sprintf("%s() returns '%s', initialized once via sync.Once", accessor.Name.Name, instance),
 	})
 }
 
-// detectFactory identifies factory patterns via New* constructors
+// detectFactory identifies factory functions: New*-style functions returning an interface whose
+// concrete value depends on their arguments. A function must return at least two distinct
+// concrete values to be considered; confidence rises when the choice branches on a parameter
+// and when three or more concrete types are produced. Constructors that always build the same
+// type are not factories.
 func (pa *PatternAnalyzer) detectFactory(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics, ifaceNames map[string]bool) {
-	ast.Inspect(file, func(n ast.Node) bool {
-		funcDecl, ok := n.(*ast.FuncDecl)
-		if !ok || funcDecl.Body == nil {
-			return true
-		}
-
-		funcName := funcDecl.Name.Name
-		if !pa.isFactoryName(funcName) {
-			return true
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil || !pa.isFactoryName(funcDecl.Name.Name) {
+			continue
 		}
-
 		if funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) == 0 {
-			return true
+			continue
 		}
-
 		returnType := funcDecl.Type.Results.List[0].Type
-		if pa.isInterfaceReturn(returnType, ifaceNames) {
-			confidence := 0.85
-			if pa.hasTypeSwitch(funcDecl.Body) {
-				confidence = 0.95
-			}
-
-			patterns.Factory = append(patterns.Factory, metrics.PatternInstance{
-				Name:            "Factory Method",
-				File:            filePath,
-				Line:            pa.fset.Position(funcDecl.Pos()).Line,
-				ConfidenceScore: confidence,
-				Description:     "Factory function returning interface type",
-				Example:         funcName + "() creates objects via factory pattern",
-			})
+		if !pa.isInterfaceReturn(returnType, ifaceNames) {
+			continue
 		}
-		return true
-	})
-}
-
-// detectBuilder identifies builder patterns via method chaining
-func (pa *PatternAnalyzer) detectBuilder(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics) {
-	typeBuilders := pa.collectBuilderCandidates(file)
-	pa.appendBuilderPatterns(typeBuilders, filePath, patterns)
-}
 
-// collectBuilderCandidates scans methods to identify potential builder types
-func (pa *PatternAnalyzer) collectBuilderCandidates(file *ast.File) map[string]*builderCandidate {
-	typeBuilders := make(map[string]*builderCandidate)
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		funcDecl, ok := n.(*ast.FuncDecl)
-		if !ok {
-			return true
+		concrete := pa.collectConcreteReturns(funcDecl.Body)
+		if len(concrete) < 2 {
+			continue
 		}
-
-		if !pa.hasMethods(funcDecl) {
-			return true
+		confidence := 0.6
+		if pa.selectsOnParameter(funcDecl) {
+			confidence += 0.25
 		}
-
-		recvType := pa.getReceiverTypeName(funcDecl.Recv)
-		if recvType == "" {
-			return true
+		if len(concrete) >= 3 {
+			confidence += 0.1
+		}
+		if confidence <= 0.6 {
+			continue
 		}
 
-		pa.ensureCandidateExists(typeBuilders, recvType, funcDecl)
-		pa.updateCandidateFromMethod(typeBuilders[recvType], funcDecl, recvType)
+		patterns.Factory = append(patterns.Factory, metrics.PatternInstance{
+			Name:            "Factory Method",
+			File:            filePath,
+			Line:            pa.fset.Position(funcDecl.Pos()).Line,
+			ConfidenceScore: min(confidence, 1.0),
+			Description:     fmt.Sprintf("Factory function returning %s with %d concrete implementations", types.ExprString(returnType), len(concrete)),
+			Example:         funcDecl.Name.Name + "() creates objects via factory pattern",
+		})
+	}
+}
 
+// collectConcreteReturns returns the distinct non-nil values returned by a function body,
+// keyed by their type or constructor, ignoring returns inside function literals
+func (pa *PatternAnalyzer) collectConcreteReturns(body *ast.BlockStmt) map[string]bool {
+	concrete := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) > 0 {
+				if key := concreteValueKey(node.Results[0]); key != "" {
+					concrete[key] = true
+				}
+			}
+		}
 		return true
 	})
-
-	return typeBuilders
+	return concrete
 }
 
-// hasMethods checks if function declaration has receiver methods
-func (pa *PatternAnalyzer) hasMethods(funcDecl *ast.FuncDecl) bool {
-	return funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0
-}
-
-// ensureCandidateExists creates builder candidate if not already tracked
-func (pa *PatternAnalyzer) ensureCandidateExists(typeBuilders map[string]*builderCandidate, recvType string, funcDecl *ast.FuncDecl) {
-	if _, exists := typeBuilders[recvType]; !exists {
-		typeBuilders[recvType] = &builderCandidate{
-			typeName:    recvType,
-			line:        pa.fset.Position(funcDecl.Pos()).Line,
-			setterCount: 0,
-			hasBuild:    false,
-			returnsSelf: 0,
+// concreteValueKey names the concrete value of a returned expression: the type of a composite
+// literal, the function called, or the variable returned. Untyped nil yields an empty key.
+func concreteValueKey(expr ast.Expr) string {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return concreteValueKey(e.X)
+		}
+	case *ast.CompositeLit:
+		return types.ExprString(e.Type)
+	case *ast.CallExpr:
+		return types.ExprString(e.Fun) + "()"
+	case *ast.Ident:
+		if e.Name == "nil" {
+			return ""
 		}
 	}
+	return types.ExprString(expr)
 }
 
-// updateCandidateFromMethod updates candidate based on method characteristics
-func (pa *PatternAnalyzer) updateCandidateFromMethod(candidate *builderCandidate, funcDecl *ast.FuncDecl, recvType string) {
-	if pa.isSetterMethod(funcDecl.Name.Name) {
-		candidate.setterCount++
-		if pa.returnsSelf(funcDecl, recvType) {
-			candidate.returnsSelf++
+// selectsOnParameter reports whether a function picks between returns with an if, switch, or
+// type switch that inspects one of its parameters
+func (pa *PatternAnalyzer) selectsOnParameter(funcDecl *ast.FuncDecl) bool {
+	params := make(map[string]bool)
+	for _, field := range funcDecl.Type.Params.List {
+		for _, name := range field.Names {
+			params[name.Name] = true
 		}
 	}
-
-	if pa.isBuildMethod(funcDecl.Name.Name) {
-		candidate.hasBuild = true
+	if len(params) == 0 {
+		return false
 	}
+
+	selects := false
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			selects = selects || (referencesAny(node.Cond, params) && hasReturn(node))
+		case *ast.SwitchStmt:
+			selects = selects || ((referencesAny(node.Tag, params) || referencesAny(node.Body, params)) && hasReturn(node.Body))
+		case *ast.TypeSwitchStmt:
+			selects = selects || (referencesAny(node.Assign, params) && hasReturn(node.Body))
+		}
+		return !selects
+	})
+	return selects
 }
 
-// isSetterMethod checks if method name suggests a setter
-func (pa *PatternAnalyzer) isSetterMethod(name string) bool {
-	return strings.HasPrefix(name, "Set") || strings.HasPrefix(name, "With")
+// referencesAny reports whether node mentions any of the named identifiers
+func referencesAny(node ast.Node, names map[string]bool) bool {
+	if node == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && names[ident.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
 }
 
-// isBuildMethod checks if method name suggests a build/create function
-func (pa *PatternAnalyzer) isBuildMethod(name string) bool {
-	return name == "Build" || name == "Create"
+// hasReturn reports whether node contains a return statement outside function literals
+func hasReturn(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
 }
 
-// appendBuilderPatterns adds qualified builder candidates to pattern list
-func (pa *PatternAnalyzer) appendBuilderPatterns(typeBuilders map[string]*builderCandidate, filePath string, patterns *metrics.DesignPatternMetrics) {
-	for _, candidate := range typeBuilders {
-		if pa.isBuilderPattern(candidate) {
+// detectBuilder identifies fluent builders: a type with at least two chained methods that
+// return the receiver type, for either value or pointer receivers, and a Build or Finish method
+// that returns a different target type. Confidence starts at 0.6 and rises when every chained
+// method actually returns its receiver and when the chain has three or more steps.
+func (pa *PatternAnalyzer) detectBuilder(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics) {
+	candidates := pa.collectBuilderCandidates(file)
+	for _, name := range sortedCandidateNames(candidates) {
+		candidate := candidates[name]
+		if len(candidate.chainMethods) >= 2 && candidate.buildMethod != "" {
 			patterns.Builder = append(patterns.Builder, pa.createBuilderPattern(candidate, filePath))
 		}
 	}
 }
 
-// isBuilderPattern checks if candidate meets builder pattern criteria
-func (pa *PatternAnalyzer) isBuilderPattern(candidate *builderCandidate) bool {
-	return candidate.setterCount >= 2 && candidate.returnsSelf >= 2 && candidate.hasBuild
-}
-
-// createBuilderPattern constructs pattern instance from candidate
-func (pa *PatternAnalyzer) createBuilderPattern(candidate *builderCandidate, filePath string) metrics.PatternInstance {
-	return metrics.PatternInstance{
-		Name:            "Builder Pattern",
-		File:            filePath,
-		Line:            candidate.line,
-		ConfidenceScore: 0.9,
-		Description:     "Fluent builder with method chaining",
-		Example:         candidate.typeName + " builder with " + string(rune(candidate.setterCount)) + " setters",
+// collectBuilderCandidates groups the chained and terminal methods of each receiver type
+func (pa *PatternAnalyzer) collectBuilderCandidates(file *ast.File) map[string]*builderCandidate {
+	candidates := make(map[string]*builderCandidate)
+	typeLines := make(map[string]int)
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				typeLines[typeSpec.Name.Name] = pa.fset.Position(typeSpec.Pos()).Line
+			}
+		}
 	}
-}
 
-// detectObserver identifies observer patterns via callback registration
-func (pa *PatternAnalyzer) detectObserver(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics) {
-	ast.Inspect(file, func(n ast.Node) bool {
-		funcDecl, ok := n.(*ast.FuncDecl)
-		if !ok || funcDecl.Body == nil {
-			return true
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || !pa.hasMethods(funcDecl) {
+			continue
 		}
-
-		funcName := funcDecl.Name.Name
-		hasRegister := strings.Contains(funcName, "Register") || strings.Contains(funcName, "Add") ||
-			strings.Contains(funcName, "Subscribe") || strings.Contains(funcName, "Listen")
-
-		if !hasRegister {
-			return true
+		recvType := pa.getReceiverTypeName(funcDecl.Recv)
+		if recvType == "" {
+			continue
 		}
 
-		if pa.hasCallbackParam(funcDecl) {
-			patterns.Observer = append(patterns.Observer, metrics.PatternInstance{
-				Name:            "Observer Pattern",
-				File:            filePath,
-				Line:            pa.fset.Position(funcDecl.Pos()).Line,
-				ConfidenceScore: 0.85,
-				Description:     "Callback registration for observer pattern",
-				Example:         funcName + "() registers observers/callbacks",
-			})
+		candidate, exists := candidates[recvType]
+		if !exists {
+			line, declared := typeLines[recvType]
+			if !declared {
+				line = pa.fset.Position(funcDecl.Pos()).Line
+			}
+			candidate = &builderCandidate{typeName: recvType, line: line}
+			candidates[recvType] = candidate
 		}
-		return true
-	})
+		pa.updateCandidateFromMethod(candidate, funcDecl, recvType)
+	}
+	return candidates
 }
 
-// detectStrategy identifies strategy patterns via interface delegation
-func (pa *PatternAnalyzer) detectStrategy(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics, ifaceNames map[string]bool) {
-	typeStrategies := pa.collectStrategyCandidates(file, ifaceNames)
-	pa.appendStrategyPatterns(typeStrategies, filePath, patterns)
+// hasMethods checks if function declaration has receiver methods
+func (pa *PatternAnalyzer) hasMethods(funcDecl *ast.FuncDecl) bool {
+	return funcDecl.Recv != nil && len(funcDecl.Recv.List) > 0
 }
 
-// collectStrategyCandidates scans AST for structs with interface fields
-func (pa *PatternAnalyzer) collectStrategyCandidates(file *ast.File, ifaceNames map[string]bool) map[string]*strategyCandidate {
-	typeStrategies := make(map[string]*strategyCandidate)
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		typeSpec, ok := n.(*ast.TypeSpec)
-		if !ok {
-			return true
-		}
-
-		structType, ok := typeSpec.Type.(*ast.StructType)
-		if !ok || structType.Fields == nil {
-			return true
+// updateCandidateFromMethod classifies a method as a chain step or as the terminal build step
+func (pa *PatternAnalyzer) updateCandidateFromMethod(candidate *builderCandidate, funcDecl *ast.FuncDecl, recvType string) {
+	name := funcDecl.Name.Name
+	if pa.isBuildMethod(name) {
+		if pa.returnsTarget(funcDecl, recvType) {
+			candidate.buildMethod = name
 		}
+		return
+	}
+	if !pa.returnsSelf(funcDecl, recvType) {
+		return
+	}
+	candidate.chainMethods = append(candidate.chainMethods, name)
+	if pa.returnsReceiver(funcDecl) {
+		candidate.returnsReceiver++
+	}
+}
 
-		pa.processStructFieldsForStrategy(typeSpec, structType, typeStrategies, ifaceNames)
-		return true
-	})
-
-	return typeStrategies
+// isBuildMethod checks if method name marks the terminal step of a builder
+func (pa *PatternAnalyzer) isBuildMethod(name string) bool {
+	return name == "Build" || name == "Finish" || name == "Create"
 }
 
-// processStructFieldsForStrategy counts interface fields in a struct
-func (pa *PatternAnalyzer) processStructFieldsForStrategy(typeSpec *ast.TypeSpec, structType *ast.StructType, typeStrategies map[string]*strategyCandidate, ifaceNames map[string]bool) {
-	for _, field := range structType.Fields.List {
-		if pa.isInterfaceField(field, ifaceNames) {
-			pa.updateStrategyCandidate(typeSpec, typeStrategies)
-		}
+// returnsTarget checks that a build method's first result is neither the builder nor an error
+func (pa *PatternAnalyzer) returnsTarget(funcDecl *ast.FuncDecl, recvType string) bool {
+	results := funcDecl.Type.Results
+	if results == nil || len(results.List) == 0 {
+		return false
+	}
+	first := results.List[0].Type
+	if ident, ok := first.(*ast.Ident); ok && ident.Name == "error" {
+		return false
 	}
+	return !pa.resultMatchesReceiverType(first, recvType)
 }
 
-// updateStrategyCandidate creates or updates strategy candidate for a type
-func (pa *PatternAnalyzer) updateStrategyCandidate(typeSpec *ast.TypeSpec, typeStrategies map[string]*strategyCandidate) {
-	typeName := typeSpec.Name.Name
-	if _, exists := typeStrategies[typeName]; !exists {
-		typeStrategies[typeName] = &strategyCandidate{
-			typeName:        typeName,
-			line:            pa.fset.Position(typeSpec.Pos()).Line,
-			interfaceFields: 1,
-		}
-	} else {
-		typeStrategies[typeName].interfaceFields++
+// returnsReceiver checks that every return statement of a method returns the receiver
+// variable, which is what makes value-receiver chains carry their modified copy forward
+func (pa *PatternAnalyzer) returnsReceiver(funcDecl *ast.FuncDecl) bool {
+	names := funcDecl.Recv.List[0].Names
+	if len(names) == 0 || funcDecl.Body == nil {
+		return false
 	}
-}
+	recvName := names[0].Name
 
-// appendStrategyPatterns adds qualified strategy candidates to pattern list
-func (pa *PatternAnalyzer) appendStrategyPatterns(typeStrategies map[string]*strategyCandidate, filePath string, patterns *metrics.DesignPatternMetrics) {
-	for _, candidate := range typeStrategies {
-		if candidate.interfaceFields > 0 {
-			patterns.Strategy = append(patterns.Strategy, pa.createStrategyPattern(candidate, filePath))
+	returns, matching := 0, 0
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns++
+			if len(node.Results) > 0 {
+				if ident, ok := node.Results[0].(*ast.Ident); ok && ident.Name == recvName {
+					matching++
+				}
+			}
 		}
-	}
+		return true
+	})
+	return returns > 0 && returns == matching
 }
 
-// createStrategyPattern constructs pattern instance from candidate
-func (pa *PatternAnalyzer) createStrategyPattern(candidate *strategyCandidate, filePath string) metrics.PatternInstance {
+// createBuilderPattern constructs pattern instance from candidate
+func (pa *PatternAnalyzer) createBuilderPattern(candidate *builderCandidate, filePath string) metrics.PatternInstance {
+	confidence := 0.6
+	if candidate.returnsReceiver == len(candidate.chainMethods) {
+		confidence += 0.2
+	}
+	if len(candidate.chainMethods) >= 3 {
+		confidence += 0.1
+	}
+
 	return metrics.PatternInstance{
-		Name:            "Strategy Pattern",
+		Name:            "Builder Pattern",
 		File:            filePath,
 		Line:            candidate.line,
-		ConfidenceScore: 0.8,
-		Description:     "Struct with interface field(s) for strategy delegation",
-		Example:         candidate.typeName + " uses strategy pattern",
+		ConfidenceScore: min(confidence, 1.0),
+		Description:     "Fluent builder with method chaining",
+		Example: fmt.Sprintf("%s chains %s and finishes with %s()",
+			candidate.typeName, strings.Join(candidate.chainMethods, ", "), candidate.buildMethod),
 	}
 }
 
-type builderCandidate struct {
-	typeName    string
-	line        int
-	setterCount int
-	hasBuild    bool
-	returnsSelf int
+// sortedCandidateNames returns map keys in sorted order so pattern output is deterministic
+func sortedCandidateNames(candidates map[string]*builderCandidate) []string {
+	names := make([]string, 0, len(candidates))
+	for name := range candidates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-type strategyCandidate struct {
+type builderCandidate struct {
 	typeName        string
 	line            int
-	interfaceFields int
+	chainMethods    []string
+	buildMethod     string
+	returnsReceiver int
 }
 
 func (pa *PatternAnalyzer) isSyncOnce(expr ast.Expr) bool {
@@ -475,13 +594,24 @@ func (pa *PatternAnalyzer) isASTResolvedInterface(ident *ast.Ident) bool {
 	return ok
 }
 
-// isQualifiedInterface checks if the expression is a qualified selector like pkg.Interface.
+// wellKnownInterfaces lists standard library interfaces commonly returned by factories; without
+// type information, qualified names cannot be resolved to interfaces any other way.
+var wellKnownInterfaces = map[string]bool{
+	"io.Reader": true, "io.Writer": true, "io.Closer": true, "io.ReadWriter": true,
+	"io.ReadCloser": true, "io.WriteCloser": true, "io.ReadWriteCloser": true,
+	"fmt.Stringer": true, "http.Handler": true, "http.RoundTripper": true,
+	"net.Conn": true, "net.Listener": true, "hash.Hash": true, "sort.Interface": true,
+	"context.Context": true, "driver.Driver": true, "slog.Handler": true,
+}
+
+// isQualifiedInterface checks if the expression is a qualified selector like pkg.Interface or a
+// well-known standard library interface such as io.Writer.
 func (pa *PatternAnalyzer) isQualifiedInterface(expr ast.Expr) bool {
 	sel, ok := expr.(*ast.SelectorExpr)
 	if !ok {
 		return false
 	}
-	return sel.Sel.Name == "Interface"
+	return sel.Sel.Name == "Interface" || wellKnownInterfaces[types.ExprString(sel)]
 }
 
 // isInterfaceReturn determines if an expression represents an interface type.
@@ -489,29 +619,23 @@ func (pa *PatternAnalyzer) isInterfaceReturn(expr ast.Expr, ifaceNames map[strin
 	return pa.isInterfaceExpr(expr, ifaceNames)
 }
 
-// hasTypeSwitch checks if a function body contains a type switch statement.
-func (pa *PatternAnalyzer) hasTypeSwitch(body *ast.BlockStmt) bool {
-	hasSwitch := false
-	ast.Inspect(body, func(n ast.Node) bool {
-		if _, ok := n.(*ast.TypeSwitchStmt); ok {
-			hasSwitch = true
-			return false
-		}
-		return true
-	})
-	return hasSwitch
-}
-
 // getReceiverTypeName extracts the type name from a method receiver field list.
 func (pa *PatternAnalyzer) getReceiverTypeName(recv *ast.FieldList) string {
 	if len(recv.List) == 0 {
 		return ""
 	}
-	switch t := recv.List[0].Type.(type) {
+	return receiverBaseName(recv.List[0].Type)
+}
+
+// receiverBaseName returns the type name of a receiver expression such as T, *T, or *T[K, V]
+func receiverBaseName(expr ast.Expr) string {
+	switch t := expr.(type) {
 	case *ast.StarExpr:
-		if ident, ok := t.X.(*ast.Ident); ok {
-			return ident.Name
-		}
+		return receiverBaseName(t.X)
+	case *ast.IndexExpr:
+		return receiverBaseName(t.X)
+	case *ast.IndexListExpr:
+		return receiverBaseName(t.X)
 	case *ast.Ident:
 		return t.Name
 	}
@@ -533,44 +657,5 @@ func (pa *PatternAnalyzer) returnsSelf(funcDecl *ast.FuncDecl, recvType string)
 
 // resultMatchesReceiverType checks if a result type matches the receiver type
 func (pa *PatternAnalyzer) resultMatchesReceiverType(expr ast.Expr, recvType string) bool {
-	switch t := expr.(type) {
-	case *ast.StarExpr:
-		if ident, ok := t.X.(*ast.Ident); ok {
-			return ident.Name == recvType
-		}
-	case *ast.Ident:
-		return t.Name == recvType
-	}
-	return false
-}
-
-// hasCallbackParam checks if a function accepts a function parameter for strategy/callback patterns.
-func (pa *PatternAnalyzer) hasCallbackParam(funcDecl *ast.FuncDecl) bool {
-	if funcDecl.Type.Params == nil {
-		return false
-	}
-	for _, param := range funcDecl.Type.Params.List {
-		if pa.isCallbackType(param.Type) {
-			return true
-		}
-	}
-	return false
-}
-
-// isCallbackType checks if a type is a callback type
-func (pa *PatternAnalyzer) isCallbackType(expr ast.Expr) bool {
-	if _, ok := expr.(*ast.FuncType); ok {
-		return true
-	}
-	if ident, ok := expr.(*ast.Ident); ok {
-		name := ident.Name
-		return strings.Contains(name, "Handler") || strings.Contains(name, "Callback") ||
-			strings.Contains(name, "Listener") || strings.Contains(name, "Func")
-	}
-	return false
-}
-
-// isInterfaceField determines if a struct field is an interface type.
-func (pa *PatternAnalyzer) isInterfaceField(field *ast.Field, ifaceNames map[string]bool) bool {
-	return pa.isInterfaceExpr(field.Type, ifaceNames)
+	return receiverBaseName(expr) == recvType
 }
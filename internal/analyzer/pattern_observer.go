@@ -0,0 +1,241 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// observerRegisterPrefixes are method name prefixes that add an observer to a subject
+var observerRegisterPrefixes = []string{"Register", "Subscribe", "Attach", "Add", "On", "Listen"}
+
+// observerNotifyPrefixes are method name prefixes that deliver an event to every observer
+var observerNotifyPrefixes = []string{"Notify", "Publish", "Emit", "Broadcast", "Dispatch", "Fire"}
+
+// observerCandidate is a struct field holding a collection of observers
+type observerCandidate struct {
+	subject  string
+	field    string
+	observer string
+	line     int
+	register string
+	notify   string
+	variant  string
+}
+
+// detectObserver identifies subjects that keep a slice or map of observers, add to it in a
+// Register/Subscribe/Attach-style method, and loop over it in a Notify/Publish-style method
+// that calls a method on each observer (interface variant), calls each one (callback variant),
+// or sends on each one (channel variant). A notifying subject scores 0.7, and 0.9 when it also
+// registers observers; a struct that merely holds the collection is not reported.
+func (pa *PatternAnalyzer) detectObserver(file *ast.File, filePath string, patterns *metrics.DesignPatternMetrics) {
+	candidates := pa.collectObserverFields(file)
+	if len(candidates) == 0 {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil || !pa.hasMethods(funcDecl) {
+			continue
+		}
+		recvType := pa.getReceiverTypeName(funcDecl.Recv)
+		recvNames := funcDecl.Recv.List[0].Names
+		if len(recvNames) == 0 {
+			continue
+		}
+		for _, candidate := range candidates {
+			if candidate.subject == recvType {
+				pa.classifyObserverMethod(candidate, funcDecl, recvNames[0].Name)
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if candidate.notify == "" {
+			continue
+		}
+		confidence := 0.7
+		example := fmt.Sprintf("%s.%s() notifies each %s", candidate.subject, candidate.notify, candidate.observer)
+		if candidate.register != "" {
+			confidence += 0.2
+			example = fmt.Sprintf("%s.%s() registers and %s.%s() notifies each %s",
+				candidate.subject, candidate.register, candidate.subject, candidate.notify, candidate.observer)
+		}
+		patterns.Observer = append(patterns.Observer, metrics.PatternInstance{
+			Name:            "Observer Pattern",
+			File:            filePath,
+			Line:            candidate.line,
+			ConfidenceScore: confidence,
+			Description: fmt.Sprintf("Subject '%s' notifies %s observers held in '%s' (%s-based)",
+				candidate.subject, candidate.observer, candidate.field, candidate.variant),
+			Example: example,
+		})
+	}
+}
+
+// collectObserverFields finds struct fields typed as a slice or map of interfaces, named
+// types, functions, or channels, in declaration order
+func (pa *PatternAnalyzer) collectObserverFields(file *ast.File) []*observerCandidate {
+	var candidates []*observerCandidate
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || structType.Fields == nil {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				elem := observerElementType(field.Type)
+				if elem == nil {
+					continue
+				}
+				for _, name := range field.Names {
+					candidates = append(candidates, &observerCandidate{
+						subject:  typeSpec.Name.Name,
+						field:    name.Name,
+						observer: types.ExprString(elem),
+						line:     pa.fset.Position(typeSpec.Pos()).Line,
+					})
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// observerElementType returns the element type of a slice or map field when it could hold
+// observers, or nil for collections of plain values such as []string
+func observerElementType(fieldType ast.Expr) ast.Expr {
+	var elem ast.Expr
+	switch t := fieldType.(type) {
+	case *ast.ArrayType:
+		elem = t.Elt
+	case *ast.MapType:
+		elem = t.Value
+	default:
+		return nil
+	}
+
+	switch e := elem.(type) {
+	case *ast.ChanType, *ast.FuncType, *ast.InterfaceType, *ast.SelectorExpr, *ast.StarExpr:
+		return elem
+	case *ast.Ident:
+		if types.Universe.Lookup(e.Name) != nil && e.Name != "any" && e.Name != "error" {
+			return nil
+		}
+		return elem
+	}
+	return nil
+}
+
+// classifyObserverMethod records a method as the candidate's register or notify step when its
+// name and body match
+func (pa *PatternAnalyzer) classifyObserverMethod(candidate *observerCandidate, funcDecl *ast.FuncDecl, recvName string) {
+	name := funcDecl.Name.Name
+	if candidate.register == "" && hasAnyPrefix(name, observerRegisterPrefixes) && addsToField(funcDecl.Body, recvName, candidate.field) {
+		candidate.register = name
+	}
+	if candidate.notify == "" && hasAnyPrefix(name, observerNotifyPrefixes) {
+		if variant := notifyVariant(funcDecl.Body, recvName, candidate.field); variant != "" {
+			candidate.notify = name
+			candidate.variant = variant
+		}
+	}
+}
+
+// hasAnyPrefix reports whether name starts with one of prefixes
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFieldSelector reports whether expr is recv.field
+func isFieldSelector(expr ast.Expr, recvName, field string) bool {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != field {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == recvName
+}
+
+// addsToField reports whether body appends to recv.field or stores into recv.field[key]
+func addsToField(body *ast.BlockStmt, recvName, field string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return !found
+		}
+		for i, lhs := range assign.Lhs {
+			if index, ok := lhs.(*ast.IndexExpr); ok && isFieldSelector(index.X, recvName, field) {
+				found = true
+			}
+			if isFieldSelector(lhs, recvName, field) && i < len(assign.Rhs) {
+				if call, ok := assign.Rhs[i].(*ast.CallExpr); ok && isBuiltinAppend(call) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isBuiltinAppend reports whether call is a call to append
+func isBuiltinAppend(call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == "append"
+}
+
+// notifyVariant inspects loops over recv.field and reports how each observer is notified:
+// "interface" for a method call, "callback" for a direct call, "channel" for a send, or ""
+// when the loop body does not notify the observer
+func notifyVariant(body *ast.BlockStmt, recvName, field string) string {
+	variant := ""
+	ast.Inspect(body, func(n ast.Node) bool {
+		loop, ok := n.(*ast.RangeStmt)
+		if !ok || !isFieldSelector(loop.X, recvName, field) {
+			return variant == ""
+		}
+		isObserver := func(expr ast.Expr) bool {
+			expr = ast.Unparen(expr)
+			if ident, ok := expr.(*ast.Ident); ok {
+				value, _ := loop.Value.(*ast.Ident)
+				return value != nil && ident.Name == value.Name
+			}
+			index, ok := expr.(*ast.IndexExpr)
+			return ok && isFieldSelector(index.X, recvName, field)
+		}
+		ast.Inspect(loop.Body, func(inner ast.Node) bool {
+			switch node := inner.(type) {
+			case *ast.SendStmt:
+				if isObserver(node.Chan) {
+					variant = "channel"
+				}
+			case *ast.CallExpr:
+				if sel, ok := node.Fun.(*ast.SelectorExpr); ok && isObserver(sel.X) {
+					variant = "interface"
+				} else if isObserver(node.Fun) {
+					variant = "callback"
+				}
+			}
+			return variant == ""
+		})
+		return variant == ""
+	})
+	return variant
+}
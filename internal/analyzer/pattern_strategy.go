@@ -0,0 +1,213 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// strategyPackage indexes the declarations of one package that strategy detection needs
+type strategyPackage struct {
+	// interfaces maps each interface declared in the package to its explicit method names
+	interfaces map[string][]string
+	// methods maps each receiver type to the set of method names it declares
+	methods map[string]map[string]bool
+	// assigned records struct field names assigned through a selector, as in c.sorter = s
+	assigned map[string]bool
+	// delegated records struct field names whose methods are called, as in c.sorter.Sort(x)
+	delegated map[string]bool
+}
+
+// strategyField is a struct field typed as an interface declared in the package
+type strategyField struct {
+	structName string
+	fieldName  string
+	ifaceName  string
+	file       string
+	line       int
+}
+
+// DetectStrategyPatterns finds Strategy patterns across the files of a single package: a
+// struct field typed as a package interface that has at least two implementers in the package
+// and that is reassigned at runtime (c.sorter = s, typically in a setter). Confidence starts at
+// 0.75 and rises when three or more implementers exist and when the struct delegates calls
+// through the field. Implementers are matched by method names, and test files are ignored.
+func DetectStrategyPatterns(fileInfos []BurdenFileInfo) []metrics.PatternInstance {
+	pkg := indexStrategyPackage(fileInfos)
+	if len(pkg.interfaces) == 0 {
+		return []metrics.PatternInstance{}
+	}
+
+	patterns := []metrics.PatternInstance{}
+	for _, field := range collectStrategyFields(fileInfos, pkg.interfaces) {
+		implementers := pkg.implementersOf(field.ifaceName)
+		if len(implementers) < 2 || !pkg.assigned[field.fieldName] {
+			continue
+		}
+
+		confidence := 0.75
+		if len(implementers) >= 3 {
+			confidence += 0.1
+		}
+		if pkg.delegated[field.fieldName] {
+			confidence += 0.1
+		}
+		patterns = append(patterns, metrics.PatternInstance{
+			Name:            "Strategy Pattern",
+			File:            field.file,
+			Line:            field.line,
+			ConfidenceScore: min(confidence, 1.0),
+			Description: fmt.Sprintf("Struct '%s' swaps its '%s' field between %d implementations of %s",
+				field.structName, field.fieldName, len(implementers), field.ifaceName),
+			Example: fmt.Sprintf("%s.%s can be %s", field.structName, field.fieldName, strings.Join(implementers, ", ")),
+		})
+	}
+	return patterns
+}
+
+// indexStrategyPackage collects interfaces, method sets, and field usage from non-test files
+func indexStrategyPackage(fileInfos []BurdenFileInfo) *strategyPackage {
+	pkg := &strategyPackage{
+		interfaces: make(map[string][]string),
+		methods:    make(map[string]map[string]bool),
+		assigned:   make(map[string]bool),
+		delegated:  make(map[string]bool),
+	}
+	for _, fi := range fileInfos {
+		if fi.File == nil || fi.Fset == nil || isTestFile(fi.Fset.Position(fi.File.Pos()).Filename) {
+			continue
+		}
+		for _, decl := range fi.File.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				pkg.indexInterfaces(d)
+			case *ast.FuncDecl:
+				pkg.indexMethod(d)
+			}
+		}
+	}
+	return pkg
+}
+
+// indexInterfaces records the explicit method names of interface type declarations
+func (p *strategyPackage) indexInterfaces(decl *ast.GenDecl) {
+	if decl.Tok != token.TYPE {
+		return
+	}
+	for _, spec := range decl.Specs {
+		typeSpec := spec.(*ast.TypeSpec)
+		iface, ok := typeSpec.Type.(*ast.InterfaceType)
+		if !ok || iface.Methods == nil {
+			continue
+		}
+		var methods []string
+		for _, method := range iface.Methods.List {
+			for _, name := range method.Names {
+				methods = append(methods, name.Name)
+			}
+		}
+		if len(methods) > 0 {
+			p.interfaces[typeSpec.Name.Name] = methods
+		}
+	}
+}
+
+// indexMethod records a method in its receiver's method set and notes the struct fields the
+// function body assigns or calls through
+func (p *strategyPackage) indexMethod(funcDecl *ast.FuncDecl) {
+	if recv := receiverTypeName(funcDecl); recv != "" {
+		if p.methods[recv] == nil {
+			p.methods[recv] = make(map[string]bool)
+		}
+		p.methods[recv][funcDecl.Name.Name] = true
+	}
+	if funcDecl.Body == nil {
+		return
+	}
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok {
+					p.assigned[sel.Sel.Name] = true
+				}
+			}
+		case *ast.CallExpr:
+			if method, ok := node.Fun.(*ast.SelectorExpr); ok {
+				if field, ok := method.X.(*ast.SelectorExpr); ok {
+					p.delegated[field.Sel.Name] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+// implementersOf returns the sorted receiver types declaring every method of an interface
+func (p *strategyPackage) implementersOf(ifaceName string) []string {
+	var implementers []string
+	for typeName, methods := range p.methods {
+		if typeName == ifaceName {
+			continue
+		}
+		implements := true
+		for _, method := range p.interfaces[ifaceName] {
+			if !methods[method] {
+				implements = false
+				break
+			}
+		}
+		if implements {
+			implementers = append(implementers, typeName)
+		}
+	}
+	sort.Strings(implementers)
+	return implementers
+}
+
+// collectStrategyFields lists struct fields typed as a package interface in source order
+func collectStrategyFields(fileInfos []BurdenFileInfo, interfaces map[string][]string) []strategyField {
+	var fields []strategyField
+	for _, fi := range fileInfos {
+		if fi.File == nil || fi.Fset == nil || isTestFile(fi.Fset.Position(fi.File.Pos()).Filename) {
+			continue
+		}
+		path := fi.RelPath
+		if path == "" {
+			path = fi.Fset.Position(fi.File.Pos()).Filename
+		}
+
+		ast.Inspect(fi.File, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || structType.Fields == nil {
+				return false
+			}
+			for _, field := range structType.Fields.List {
+				ident, ok := field.Type.(*ast.Ident)
+				if !ok || interfaces[ident.Name] == nil {
+					continue
+				}
+				for _, name := range field.Names {
+					fields = append(fields, strategyField{
+						structName: typeSpec.Name.Name,
+						fieldName:  name.Name,
+						ifaceName:  ident.Name,
+						file:       path,
+						line:       fi.Fset.Position(name.Pos()).Line,
+					})
+				}
+			}
+			return false
+		})
+	}
+	return fields
+}
@@ -0,0 +1,181 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseStrategyPackage parses sources keyed by file name into one package's file infos
+func parseStrategyPackage(t *testing.T, sources map[string]string) []BurdenFileInfo {
+	t.Helper()
+	fset := token.NewFileSet()
+	var infos []BurdenFileInfo
+	for _, name := range []string{"compress.go", "codecs.go", "codecs_test.go"} {
+		src, ok := sources[name]
+		if !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, src, 0)
+		require.NoError(t, err)
+		infos = append(infos, BurdenFileInfo{File: file, Fset: fset, Pkg: "compress", RelPath: "pkg/" + name})
+	}
+	return infos
+}
+
+const strategyContext = `package compress
+
+type Codec interface {
+	Encode(data []byte) []byte
+	Name() string
+}
+
+type Compressor struct {
+	codec Codec
+	level int
+}
+
+func (c *Compressor) SetCodec(codec Codec) {
+	c.codec = codec
+}
+
+func (c *Compressor) Compress(data []byte) []byte {
+	return c.codec.Encode(data)
+}
+`
+
+func TestDetectStrategyPatterns_ThreeImplementersAcrossFiles(t *testing.T) {
+	infos := parseStrategyPackage(t, map[string]string{
+		"compress.go": strategyContext,
+		"codecs.go": `package compress
+
+type Gzip struct{}
+
+func (Gzip) Encode(data []byte) []byte { return data }
+func (Gzip) Name() string              { return "gzip" }
+
+type Zstd struct{}
+
+func (*Zstd) Encode(data []byte) []byte { return data }
+func (*Zstd) Name() string              { return "zstd" }
+
+type Snappy struct{}
+
+func (Snappy) Encode(data []byte) []byte { return data }
+func (Snappy) Name() string              { return "snappy" }
+
+type Partial struct{}
+
+func (Partial) Encode(data []byte) []byte { return data }
+`,
+	})
+
+	patterns := DetectStrategyPatterns(infos)
+
+	require.Len(t, patterns, 1)
+	p := patterns[0]
+	assert.Equal(t, "Strategy Pattern", p.Name)
+	assert.Equal(t, "pkg/compress.go", p.File)
+	assert.Equal(t, 9, p.Line)
+	assert.InDelta(t, 0.95, p.ConfidenceScore, 0.001)
+	assert.Equal(t, "Struct 'Compressor' swaps its 'codec' field between 3 implementations of Codec", p.Description)
+	assert.Equal(t, "Compressor.codec can be Gzip, Snappy, Zstd", p.Example)
+}
+
+func TestDetectStrategyPatterns_Requirements(t *testing.T) {
+	twoCodecs := `package compress
+
+type Gzip struct{}
+
+func (Gzip) Encode(data []byte) []byte { return data }
+func (Gzip) Name() string              { return "gzip" }
+
+type Zstd struct{}
+
+func (Zstd) Encode(data []byte) []byte { return data }
+func (Zstd) Name() string              { return "zstd" }
+`
+
+	t.Run("two implementers without delegation bonus", func(t *testing.T) {
+		noDelegation := `package compress
+
+type Codec interface {
+	Encode(data []byte) []byte
+	Name() string
+}
+
+type Compressor struct {
+	codec Codec
+}
+
+func (c *Compressor) SetCodec(codec Codec) { c.codec = codec }
+`
+		patterns := DetectStrategyPatterns(parseStrategyPackage(t, map[string]string{"compress.go": noDelegation, "codecs.go": twoCodecs}))
+		require.Len(t, patterns, 1)
+		assert.InDelta(t, 0.75, patterns[0].ConfidenceScore, 0.001)
+	})
+
+	t.Run("field never swapped at runtime", func(t *testing.T) {
+		fixed := `package compress
+
+type Codec interface {
+	Encode(data []byte) []byte
+	Name() string
+}
+
+type Compressor struct {
+	codec Codec
+}
+
+func (c *Compressor) Compress(data []byte) []byte { return c.codec.Encode(data) }
+`
+		assert.Empty(t, DetectStrategyPatterns(parseStrategyPackage(t, map[string]string{"compress.go": fixed, "codecs.go": twoCodecs})))
+	})
+
+	t.Run("test doubles do not count as implementers", func(t *testing.T) {
+		patterns := DetectStrategyPatterns(parseStrategyPackage(t, map[string]string{
+			"compress.go": strategyContext,
+			"codecs.go": `package compress
+
+type Gzip struct{}
+
+func (Gzip) Encode(data []byte) []byte { return data }
+func (Gzip) Name() string              { return "gzip" }
+`,
+			"codecs_test.go": `package compress
+
+type fakeCodec struct{}
+
+func (fakeCodec) Encode(data []byte) []byte { return data }
+func (fakeCodec) Name() string              { return "fake" }
+`,
+		}))
+		assert.Empty(t, patterns)
+	})
+}
+
+func TestPatternAnalyzer_StrategyInSingleFile(t *testing.T) {
+	src := strategyContext + `
+type Gzip struct{}
+
+func (Gzip) Encode(data []byte) []byte { return data }
+func (Gzip) Name() string              { return "gzip" }
+
+type Zstd struct{}
+
+func (Zstd) Encode(data []byte) []byte { return data }
+func (Zstd) Name() string              { return "zstd" }
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "compress.go", src, 0)
+	require.NoError(t, err)
+
+	patterns, err := NewPatternAnalyzer(fset).AnalyzePatterns(file, "compress", "compress.go")
+	require.NoError(t, err)
+
+	require.Len(t, patterns.Strategy, 1)
+	assert.Equal(t, "compress.go", patterns.Strategy[0].File)
+}
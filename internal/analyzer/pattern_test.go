@@ -133,6 +133,12 @@ func (em *EventManager) RegisterHandler(handler EventHandler) {
 func (em *EventManager) AddListener(handler EventHandler) {
 	em.handlers = append(em.handlers, handler)
 }
+
+func (em *EventManager) Notify(event string) {
+	for _, h := range em.handlers {
+		h(event)
+	}
+}
 `
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
@@ -142,9 +148,147 @@ func (em *EventManager) AddListener(handler EventHandler) {
 	patterns, err := analyzer.AnalyzePatterns(file, "test", "test.go")
 	require.NoError(t, err)
 
-	assert.GreaterOrEqual(t, len(patterns.Observer), 2)
-	if len(patterns.Observer) > 0 {
-		assert.Equal(t, "Observer Pattern", patterns.Observer[0].Name)
+	require.Len(t, patterns.Observer, 1)
+	assert.Equal(t, "Observer Pattern", patterns.Observer[0].Name)
+	assert.Equal(t, 5, patterns.Observer[0].Line)
+	assert.Contains(t, patterns.Observer[0].Description, "callback-based")
+}
+
+func TestPatternAnalyzer_ObserverVariants(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		wantCount   int
+		description string
+		confidence  float64
+	}{
+		{
+			name: "event bus with interface observers",
+			src: `package test
+
+type Listener interface {
+	OnEvent(name string)
+}
+
+type EventBus struct {
+	listeners []Listener
+}
+
+func (b *EventBus) Subscribe(l Listener) {
+	b.listeners = append(b.listeners, l)
+}
+
+func (b *EventBus) Publish(name string) {
+	for _, l := range b.listeners {
+		l.OnEvent(name)
+	}
+}
+`,
+			wantCount:   1,
+			description: "Subject 'EventBus' notifies Listener observers held in 'listeners' (interface-based)",
+			confidence:  0.9,
+		},
+		{
+			name: "callback map without a register method",
+			src: `package test
+
+type Hooks struct {
+	byName map[string]func(int)
+}
+
+func (h *Hooks) Fire(v int) {
+	for name := range h.byName {
+		h.byName[name](v)
+	}
+}
+`,
+			wantCount:   1,
+			description: "Subject 'Hooks' notifies func(int) observers held in 'byName' (callback-based)",
+			confidence:  0.7,
+		},
+		{
+			name: "channel subscribers",
+			src: `package test
+
+type Feed struct {
+	subs []chan<- string
+}
+
+func (f *Feed) Attach(ch chan<- string) {
+	f.subs = append(f.subs, ch)
+}
+
+func (f *Feed) Broadcast(msg string) {
+	for _, ch := range f.subs {
+		ch <- msg
+	}
+}
+`,
+			wantCount:   1,
+			description: "Subject 'Feed' notifies chan<- string observers held in 'subs' (channel-based)",
+			confidence:  0.9,
+		},
+		{
+			name: "collection of interfaces that is never notified",
+			src: `package test
+
+type Shape interface {
+	Area() float64
+}
+
+type Canvas struct {
+	shapes []Shape
+}
+
+func (c *Canvas) AddShape(s Shape) {
+	c.shapes = append(c.shapes, s)
+}
+
+func (c *Canvas) TotalArea() float64 {
+	total := 0.0
+	for _, s := range c.shapes {
+		total += s.Area()
+	}
+	return total
+}
+`,
+			wantCount: 0,
+		},
+		{
+			name: "notify method over plain values",
+			src: `package test
+
+type Log struct {
+	lines []string
+}
+
+func (l *Log) Emit() int {
+	n := 0
+	for _, line := range l.lines {
+		n += len(line)
+	}
+	return n
+}
+`,
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.src, parser.ParseComments)
+			require.NoError(t, err)
+
+			patterns, err := NewPatternAnalyzer(fset).AnalyzePatterns(file, "test", "test.go")
+			require.NoError(t, err)
+
+			require.Len(t, patterns.Observer, tt.wantCount)
+			if tt.wantCount > 0 {
+				assert.Equal(t, tt.description, patterns.Observer[0].Description)
+				assert.InDelta(t, tt.confidence, patterns.Observer[0].ConfidenceScore, 1e-9)
+			}
+		})
 	}
 }
 
@@ -160,9 +304,21 @@ type DataProcessor struct {
 	logger   Logger
 }
 
+func (dp *DataProcessor) SetStrategy(s Sorter) {
+	dp.strategy = s
+}
+
 func (dp *DataProcessor) Process(data []int) []int {
 	return dp.strategy.Sort(data)
 }
+
+type QuickSort struct{}
+
+func (QuickSort) Sort(data []int) []int { return data }
+
+type MergeSort struct{}
+
+func (MergeSort) Sort(data []int) []int { return data }
 `
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
@@ -206,3 +362,251 @@ func helperFunction(x int) int {
 	assert.Empty(t, patterns.Observer)
 	assert.Empty(t, patterns.Strategy)
 }
+
+func TestPatternAnalyzer_SingletonRequiresAccessor(t *testing.T) {
+	tests := []struct {
+		name          string
+		src           string
+		wantCount     int
+		minConfidence float64
+	}{
+		{
+			name: "canonical sync.Once singleton",
+			src: `package config
+import "sync"
+
+type Settings struct{ Debug bool }
+
+var (
+	settings *Settings
+	settingsOnce sync.Once
+)
+
+func GetSettings() *Settings {
+	settingsOnce.Do(func() {
+		settings = &Settings{}
+	})
+	return settings
+}
+`,
+			wantCount:     1,
+			minConfidence: 0.9,
+		},
+		{
+			name: "unconventional accessor name",
+			src: `package config
+import "sync"
+
+var (
+	cache map[string]string
+	once  sync.Once
+)
+
+func lookupTable() map[string]string {
+	once.Do(func() {
+		cache = map[string]string{}
+	})
+	return cache
+}
+`,
+			wantCount:     1,
+			minConfidence: 0.75,
+		},
+		{
+			name: "sync.Once without accessor",
+			src: `package config
+import "sync"
+
+var once sync.Once
+
+func Setup() {
+	once.Do(func() {})
+}
+`,
+			wantCount: 0,
+		},
+		{
+			name: "function-local sync.Once",
+			src: `package config
+import "sync"
+
+var shared *int
+
+func Run() *int {
+	var once sync.Once
+	once.Do(func() { shared = new(int) })
+	return shared
+}
+`,
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "config.go", tt.src, 0)
+			require.NoError(t, err)
+
+			patterns, err := NewPatternAnalyzer(fset).AnalyzePatterns(file, "config", "config.go")
+			require.NoError(t, err)
+
+			require.Len(t, patterns.Singleton, tt.wantCount)
+			if tt.wantCount > 0 {
+				assert.GreaterOrEqual(t, patterns.Singleton[0].ConfidenceScore, tt.minConfidence)
+				assert.LessOrEqual(t, patterns.Singleton[0].ConfidenceScore, 1.0)
+			}
+		})
+	}
+}
+
+func TestPatternAnalyzer_FactoryReturningIOWriter(t *testing.T) {
+	src := `package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+func NewWriter(kind string) io.Writer {
+	if kind == "buffer" {
+		return &bytes.Buffer{}
+	}
+	if kind == "discard" {
+		return io.Discard
+	}
+	return os.Stdout
+}
+
+func MakeLogger() io.Writer {
+	return os.Stderr
+}
+
+func NewSink(verbose bool) io.Writer {
+	w := io.Discard
+	return w
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "output.go", src, 0)
+	require.NoError(t, err)
+
+	patterns, err := NewPatternAnalyzer(fset).AnalyzePatterns(file, "output", "output.go")
+	require.NoError(t, err)
+
+	require.Len(t, patterns.Factory, 1, "only NewWriter picks its concrete type from an argument")
+	factory := patterns.Factory[0]
+	assert.Equal(t, "Factory Method", factory.Name)
+	assert.Equal(t, 9, factory.Line)
+	assert.InDelta(t, 0.95, factory.ConfidenceScore, 0.001)
+	assert.Contains(t, factory.Description, "io.Writer")
+	assert.Contains(t, factory.Description, "3 concrete implementations")
+}
+
+func TestPatternAnalyzer_BuilderReceiverStyles(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		wantBuilder bool
+		wantExample string
+		minConf     float64
+	}{
+		{
+			name: "pointer receiver chain",
+			src: `package query
+
+type Query struct{ sql string }
+
+type QueryBuilder struct {
+	table string
+	where []string
+	limit int
+}
+
+func (b *QueryBuilder) From(table string) *QueryBuilder {
+	b.table = table
+	return b
+}
+
+func (b *QueryBuilder) Where(cond string) *QueryBuilder {
+	b.where = append(b.where, cond)
+	return b
+}
+
+func (b *QueryBuilder) Limit(n int) *QueryBuilder {
+	b.limit = n
+	return b
+}
+
+func (b *QueryBuilder) Build() *Query {
+	return &Query{sql: b.table}
+}
+`,
+			wantBuilder: true,
+			wantExample: "QueryBuilder chains From, Where, Limit and finishes with Build()",
+			minConf:     0.9,
+		},
+		{
+			name: "value receiver chain",
+			src: `package query
+
+type Options struct{ retries, timeout int }
+
+type OptionsBuilder struct {
+	retries int
+	timeout int
+}
+
+func (b OptionsBuilder) Retries(n int) OptionsBuilder {
+	b.retries = n
+	return b
+}
+
+func (b OptionsBuilder) Timeout(n int) OptionsBuilder {
+	b.timeout = n
+	return b
+}
+
+func (b OptionsBuilder) Finish() Options {
+	return Options{retries: b.retries, timeout: b.timeout}
+}
+`,
+			wantBuilder: true,
+			wantExample: "OptionsBuilder chains Retries, Timeout and finishes with Finish()",
+			minConf:     0.8,
+		},
+		{
+			name: "chain without terminal build",
+			src: `package query
+
+type Buffer struct{ parts []string }
+
+func (b *Buffer) Add(s string) *Buffer  { b.parts = append(b.parts, s); return b }
+func (b *Buffer) Skip(n int) *Buffer   { return b }
+func (b *Buffer) Build() (*Buffer, error) { return b, nil }
+`,
+			wantBuilder: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "query.go", tt.src, 0)
+			require.NoError(t, err)
+
+			patterns, err := NewPatternAnalyzer(fset).AnalyzePatterns(file, "query", "query.go")
+			require.NoError(t, err)
+
+			if !tt.wantBuilder {
+				assert.Empty(t, patterns.Builder)
+				return
+			}
+			require.Len(t, patterns.Builder, 1)
+			assert.Equal(t, tt.wantExample, patterns.Builder[0].Example)
+			assert.GreaterOrEqual(t, patterns.Builder[0].ConfidenceScore, tt.minConf)
+			assert.Equal(t, 5, patterns.Builder[0].Line, "line points at the builder type declaration")
+		})
+	}
+}
@@ -3,7 +3,9 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -23,6 +25,8 @@ type PlacementAnalyzer struct {
 	receiverFiles map[string]string
 	// Methods: methodName -> (receiverType, file)
 	methods map[string]methodInfo
+	// Files recorded by AddFile, analyzed by AnalyzeAdded
+	added []placementFile
 	// File set for position information
 	fset *token.FileSet
 	// Configuration
@@ -36,6 +40,22 @@ type methodInfo struct {
 	file         string
 }
 
+// placementFile is what AddFile keeps of a file: its definitions in source order and how often
+// it uses each identifier
+type placementFile struct {
+	name string
+	defs []placementDef
+	refs map[string]int
+}
+
+// placementDef is a symbol declared in a file; methods are named Receiver.Method
+type placementDef struct {
+	symbol       string
+	receiverType string
+	isMethod     bool
+	isType       bool
+}
+
 // NewPlacementAnalyzer creates a new placement analyzer with configurable
 // NewPlacementAnalyzer uses affinity margin and minimum cohesion thresholds for misplacement detection.
 func NewPlacementAnalyzer(affinityMargin, minCohesion float64) *PlacementAnalyzer {
@@ -61,14 +81,7 @@ func (pa *PlacementAnalyzer) Analyze(files []*ast.File, fset *token.FileSet) met
 	methodIssues := pa.AnalyzeMethodPlacement()
 	cohesionIssues := pa.AnalyzeFileCohesion()
 
-	avgCohesion := 0.0
-	if len(pa.fileRefs) > 0 {
-		totalCohesion := 0.0
-		for file := range pa.fileRefs {
-			totalCohesion += pa.calculateCohesion(file)
-		}
-		avgCohesion = totalCohesion / float64(len(pa.fileRefs))
-	}
+	avgCohesion := pa.averageCohesion()
 
 	return metrics.PlacementMetrics{
 		MisplacedFunctions: len(functionIssues),
@@ -88,20 +101,52 @@ func (pa *PlacementAnalyzer) Analyze(files []*ast.File, fset *token.FileSet) met
 // a shared fset has no position information) without changing the existing Analyze
 // API or breaking its tests.
 func (pa *PlacementAnalyzer) AnalyzeMap(files map[string]*ast.File) metrics.PlacementMetrics {
-	pa.buildSymbolIndexFromMap(files)
+	for filename, file := range files {
+		pa.AddFile(file, filename)
+	}
+	return pa.AnalyzeAdded()
+}
+
+// AddFile records the declarations of file and the identifiers it uses, so that AnalyzeAdded
+// can run the placement analysis after the AST is released. Only names and counts are kept.
+func (pa *PlacementAnalyzer) AddFile(file *ast.File, filename string) {
+	summary := placementFile{name: filepath.ToSlash(filename), defs: placementDefs(file), refs: make(map[string]int)}
+	walkReferences(file, func(name string) { summary.refs[name]++ })
+	pa.added = append(pa.added, summary)
+}
+
+// AnalyzeAdded runs the placement analysis over the files recorded by AddFile. Files are visited
+// in name order, definitions first, so a symbol defined in several files resolves to the same
+// definition on every run.
+func (pa *PlacementAnalyzer) AnalyzeAdded() metrics.PlacementMetrics {
+	slices.SortFunc(pa.added, func(a, b placementFile) int { return strings.Compare(a.name, b.name) })
+	for _, file := range pa.added {
+		for _, def := range file.defs {
+			pa.recordDef(def, file.name)
+		}
+	}
+	for _, file := range pa.added {
+		for name, count := range file.refs {
+			if _, exists := pa.symbolDefs[name]; !exists {
+				continue
+			}
+			if pa.symbolRefs[name] == nil {
+				pa.symbolRefs[name] = make(map[string]int)
+			}
+			pa.symbolRefs[name][file.name] += count
+			if pa.fileRefs[file.name] == nil {
+				pa.fileRefs[file.name] = make(map[string]int)
+			}
+			pa.fileRefs[file.name][name] += count
+		}
+	}
+	pa.added = nil
 
 	functionIssues := pa.AnalyzeFunctionAffinity()
 	methodIssues := pa.AnalyzeMethodPlacement()
 	cohesionIssues := pa.AnalyzeFileCohesion()
 
-	avgCohesion := 0.0
-	if len(pa.fileRefs) > 0 {
-		totalCohesion := 0.0
-		for file := range pa.fileRefs {
-			totalCohesion += pa.calculateCohesion(file)
-		}
-		avgCohesion = totalCohesion / float64(len(pa.fileRefs))
-	}
+	avgCohesion := pa.averageCohesion()
 
 	return metrics.PlacementMetrics{
 		MisplacedFunctions: len(functionIssues),
@@ -114,15 +159,17 @@ func (pa *PlacementAnalyzer) AnalyzeMap(files map[string]*ast.File) metrics.Plac
 	}
 }
 
-// buildSymbolIndexFromMap constructs the symbol table using map keys as filenames,
-// avoiding any fset.Position call.
-func (pa *PlacementAnalyzer) buildSymbolIndexFromMap(files map[string]*ast.File) {
-	for filename, file := range files {
-		pa.collectDefinitionsFromFile(file, filepath.ToSlash(filename))
+// averageCohesion returns the mean cohesion of all files with references, summed in file
+// order so the result does not depend on map iteration
+func (pa *PlacementAnalyzer) averageCohesion() float64 {
+	if len(pa.fileRefs) == 0 {
+		return 0.0
 	}
-	for filename, file := range files {
-		pa.collectReferencesFromFile(file, filepath.ToSlash(filename))
+	totalCohesion := 0.0
+	for _, file := range slices.Sorted(maps.Keys(pa.fileRefs)) {
+		totalCohesion += pa.calculateCohesion(file)
 	}
+	return totalCohesion / float64(len(pa.fileRefs))
 }
 
 // buildSymbolIndex constructs the complete symbol table for all files
@@ -142,47 +189,62 @@ func (pa *PlacementAnalyzer) collectDefinitions(files []*ast.File, fset *token.F
 
 // collectDefinitionsFromFile extracts symbol definitions from a single file
 func (pa *PlacementAnalyzer) collectDefinitionsFromFile(file *ast.File, filename string) {
+	for _, def := range placementDefs(file) {
+		pa.recordDef(def, filename)
+	}
+}
+
+// placementDefs lists the functions, methods, types, vars, and consts declared in file, in
+// source order
+func placementDefs(file *ast.File) []placementDef {
+	var defs []placementDef
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch decl := n.(type) {
 		case *ast.FuncDecl:
-			pa.processFuncDecl(decl, filename)
+			defs = append(defs, funcDeclDef(decl))
 		case *ast.GenDecl:
-			pa.processGenDecl(decl, filename)
+			defs = append(defs, genDeclDefs(decl)...)
 		}
 		return true
 	})
+	return defs
 }
 
-// processFuncDecl handles function and method declarations
-func (pa *PlacementAnalyzer) processFuncDecl(decl *ast.FuncDecl, filename string) {
-	funcName := decl.Name.Name
+// funcDeclDef describes a function or method declaration
+func funcDeclDef(decl *ast.FuncDecl) placementDef {
 	if decl.Recv != nil && len(decl.Recv.List) > 0 {
 		recvType := ExtractReceiverType(decl.Recv.List[0].Type)
-		methodName := recvType + "." + funcName
-		pa.methods[methodName] = methodInfo{
-			receiverType: recvType,
-			file:         filename,
-		}
-		pa.recordSymbolDef(methodName, filename)
-	} else {
-		pa.recordSymbolDef(funcName, filename)
+		return placementDef{symbol: recvType + "." + decl.Name.Name, receiverType: recvType, isMethod: true}
 	}
+	return placementDef{symbol: decl.Name.Name}
 }
 
-// processGenDecl handles type, var, and const declarations
-func (pa *PlacementAnalyzer) processGenDecl(decl *ast.GenDecl, filename string) {
+// genDeclDefs describes the type, var, and const declarations of decl
+func genDeclDefs(decl *ast.GenDecl) []placementDef {
+	var defs []placementDef
 	for _, spec := range decl.Specs {
 		switch s := spec.(type) {
 		case *ast.TypeSpec:
-			typeName := s.Name.Name
-			pa.recordSymbolDef(typeName, filename)
-			pa.receiverFiles[typeName] = filename
+			defs = append(defs, placementDef{symbol: s.Name.Name, isType: true})
 		case *ast.ValueSpec:
 			for _, name := range s.Names {
-				pa.recordSymbolDef(name.Name, filename)
+				defs = append(defs, placementDef{symbol: name.Name})
 			}
 		}
 	}
+	return defs
+}
+
+// recordDef records a definition found in file, along with the receiver type of a method and
+// the file of a type that may receive methods
+func (pa *PlacementAnalyzer) recordDef(def placementDef, file string) {
+	if def.isMethod {
+		pa.methods[def.symbol] = methodInfo{receiverType: def.receiverType, file: file}
+	}
+	pa.recordSymbolDef(def.symbol, file)
+	if def.isType {
+		pa.receiverFiles[def.symbol] = file
+	}
 }
 
 // collectReferences performs second pass to collect all symbol references
@@ -196,43 +258,33 @@ func (pa *PlacementAnalyzer) collectReferences(files []*ast.File, fset *token.Fi
 
 // collectReferencesFromFile extracts symbol references from a single file
 func (pa *PlacementAnalyzer) collectReferencesFromFile(file *ast.File, filename string) {
-	var currentFunc string
+	walkReferences(file, func(name string) {
+		if _, exists := pa.symbolDefs[name]; exists {
+			pa.recordSymbolRef(name, filename)
+		}
+	})
+}
 
+// walkReferences calls ref with the name of every identifier in file that does not declare
+// an object and does not name the function it appears in
+func walkReferences(file *ast.File, ref func(name string)) {
+	var currentFunc string
 	ast.Inspect(file, func(n ast.Node) bool {
 		if funcDecl, ok := n.(*ast.FuncDecl); ok {
-			currentFunc = pa.getFuncDeclName(funcDecl)
+			currentFunc = funcDeclDef(funcDecl).symbol
 		}
-
 		if ident, ok := n.(*ast.Ident); ok {
-			pa.processIdentRef(ident, filename, currentFunc)
+			if ident.Obj != nil && ident.Obj.Pos() == ident.Pos() {
+				return true
+			}
+			if ident.Name != currentFunc {
+				ref(ident.Name)
+			}
 		}
-
 		return true
 	})
 }
 
-// getFuncDeclName gets the full name of a function or method declaration
-func (pa *PlacementAnalyzer) getFuncDeclName(decl *ast.FuncDecl) string {
-	if decl.Recv != nil && len(decl.Recv.List) > 0 {
-		recvType := ExtractReceiverType(decl.Recv.List[0].Type)
-		return recvType + "." + decl.Name.Name
-	}
-	return decl.Name.Name
-}
-
-// processIdentRef processes an identifier reference
-func (pa *PlacementAnalyzer) processIdentRef(ident *ast.Ident, filename, currentFunc string) {
-	if ident.Obj != nil && ident.Obj.Pos() == ident.Pos() {
-		return
-	}
-
-	if _, exists := pa.symbolDefs[ident.Name]; exists {
-		if ident.Name != currentFunc {
-			pa.recordSymbolRef(ident.Name, filename)
-		}
-	}
-}
-
 // recordSymbolDef records that a symbol is defined in a file
 func (pa *PlacementAnalyzer) recordSymbolDef(symbol, file string) {
 	pa.symbolDefs[symbol] = file
@@ -257,8 +309,8 @@ func (pa *PlacementAnalyzer) recordSymbolRef(symbol, file string) {
 func (pa *PlacementAnalyzer) AnalyzeFunctionAffinity() []metrics.MisplacedFunctionIssue {
 	var issues []metrics.MisplacedFunctionIssue
 
-	for symbol, defFile := range pa.symbolDefs {
-		if issue := pa.checkFunctionPlacement(symbol, defFile); issue != nil {
+	for _, symbol := range slices.Sorted(maps.Keys(pa.symbolDefs)) {
+		if issue := pa.checkFunctionPlacement(symbol, pa.symbolDefs[symbol]); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
@@ -315,8 +367,8 @@ func (pa *PlacementAnalyzer) findBestAffinityFile(refs map[string]int, totalRefs
 	bestFile := defFile
 	bestAffinity := currentAffinity
 
-	for file, count := range refs {
-		affinity := pa.calculateAffinity(count, totalRefs)
+	for _, file := range slices.Sorted(maps.Keys(refs)) {
+		affinity := pa.calculateAffinity(refs[file], totalRefs)
 		if affinity > bestAffinity+pa.affinityMargin {
 			bestFile = file
 			bestAffinity = affinity
@@ -357,8 +409,8 @@ func (pa *PlacementAnalyzer) calculateSeverity(bestAffinity, currentAffinity flo
 // AnalyzeMethodPlacement checks if methods are defined in the same file as their receiver
 func (pa *PlacementAnalyzer) AnalyzeMethodPlacement() []metrics.MisplacedMethodIssue {
 	var issues []metrics.MisplacedMethodIssue
-	for methodName, info := range pa.methods {
-		if issue := pa.checkMethodPlacement(methodName, info); issue != nil {
+	for _, methodName := range slices.Sorted(maps.Keys(pa.methods)) {
+		if issue := pa.checkMethodPlacement(methodName, pa.methods[methodName]); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
@@ -403,7 +455,7 @@ func (pa *PlacementAnalyzer) determinePlacementSeverity(distance string) metrics
 func (pa *PlacementAnalyzer) AnalyzeFileCohesion() []metrics.FileCohesionIssue {
 	var issues []metrics.FileCohesionIssue
 
-	for file := range pa.fileRefs {
+	for _, file := range slices.Sorted(maps.Keys(pa.fileRefs)) {
 		cohesion := pa.calculateCohesion(file)
 
 		if cohesion < pa.minCohesion {
@@ -490,8 +542,8 @@ func (pa *PlacementAnalyzer) suggestSplits(file string) []string {
 	}
 
 	var suggestions []string
-	for refFile, count := range externalRefs {
-		if count >= 3 { // Threshold for suggesting a split
+	for _, refFile := range slices.Sorted(maps.Keys(externalRefs)) {
+		if externalRefs[refFile] >= 3 { // Threshold for suggesting a split
 			baseName := filepath.Base(refFile)
 			baseName = strings.TrimSuffix(baseName, ".go")
 			suggestions = append(suggestions, baseName+"_related.go")
@@ -7,7 +7,8 @@ import (
 
 // ScoringAnalyzer calculates composite maintenance burden scores
 type ScoringAnalyzer struct {
-	weights config.ScoringWeights
+	weights        config.ScoringWeights
+	testCodeWeight float64
 }
 
 // NewScoringAnalyzer creates a new scoring analyzer with configured weights
@@ -18,6 +19,15 @@ func NewScoringAnalyzer(weights config.ScoringWeights) *ScoringAnalyzer {
 	}
 }
 
+// NewScoringAnalyzerWithConfig creates a scoring analyzer from the full scoring configuration,
+// including the weight given to test code in the overall quality score.
+func NewScoringAnalyzerWithConfig(cfg config.ScoringConfig) *ScoringAnalyzer {
+	return &ScoringAnalyzer{
+		weights:        cfg.Weights,
+		testCodeWeight: cfg.TestCodeWeight,
+	}
+}
+
 // CalculateFileMBI computes the Maintenance Burden Index for a file by combining
 // CalculateFileMBI uses weighted scores from duplication, naming, placement, documentation, and burden.
 func (sa *ScoringAnalyzer) CalculateFileMBI(file string, report *metrics.Report) float64 {
@@ -278,13 +288,56 @@ func (sa *ScoringAnalyzer) CalculatePackageMBI(pkg string, report *metrics.Repor
 func (sa *ScoringAnalyzer) CalculateAllScores(report *metrics.Report) *metrics.ScoringMetrics {
 	fileScores := sa.calculateFileScores(report)
 	packageScores := sa.calculatePackageScores(report)
+	production := calculateQualityScore(fileScores, false)
+	test := calculateQualityScore(fileScores, true)
 
 	return &metrics.ScoringMetrics{
-		FileScores:    fileScores,
-		PackageScores: packageScores,
+		FileScores:        fileScores,
+		PackageScores:     packageScores,
+		ProductionQuality: production,
+		TestQuality:       test,
+		OverallQuality:    sa.calculateOverallQuality(production, test),
 	}
 }
 
+// calculateQualityScore aggregates file MBI scores for either production or test files
+// into a single 0-100 quality score (100 minus the average MBI).
+func calculateQualityScore(fileScores []metrics.FileScore, testFiles bool) metrics.CodeQualityScore {
+	total := 0.0
+	count := 0
+	for _, fs := range fileScores {
+		if fs.IsTestFile != testFiles {
+			continue
+		}
+		total += fs.Score
+		count++
+	}
+
+	if count == 0 {
+		return metrics.CodeQualityScore{}
+	}
+
+	avg := total / float64(count)
+	return metrics.CodeQualityScore{
+		Score:      normalizeToScore(100.0 - avg),
+		AverageMBI: avg,
+		FileCount:  count,
+		Risk:       getRiskLevel(avg),
+	}
+}
+
+// calculateOverallQuality blends production and test quality using the configured test code weight.
+// Test code only contributes when test files were analyzed and the weight is positive.
+func (sa *ScoringAnalyzer) calculateOverallQuality(production, test metrics.CodeQualityScore) float64 {
+	if production.FileCount == 0 {
+		return test.Score
+	}
+	if test.FileCount == 0 || sa.testCodeWeight <= 0 {
+		return production.Score
+	}
+	return (production.Score + test.Score*sa.testCodeWeight) / (1.0 + sa.testCodeWeight)
+}
+
 // calculateFileScores computes MBI scores for all files in the report
 func (sa *ScoringAnalyzer) calculateFileScores(report *metrics.Report) []metrics.FileScore {
 	fileScores := make([]metrics.FileScore, 0)
@@ -295,10 +348,11 @@ func (sa *ScoringAnalyzer) calculateFileScores(report *metrics.Report) []metrics
 			seenFiles[fn.File] = true
 			score := sa.CalculateFileMBI(fn.File, report)
 			fileScores = append(fileScores, metrics.FileScore{
-				File:      fn.File,
-				Score:     score,
-				Risk:      getRiskLevel(score),
-				Breakdown: sa.getFileBreakdown(fn.File, report),
+				File:       fn.File,
+				IsTestFile: fn.IsTestFile,
+				Score:      score,
+				Risk:       getRiskLevel(score),
+				Breakdown:  sa.getFileBreakdown(fn.File, report),
 			})
 		}
 	}
@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newQualityTestReport(nestingFiles ...string) *metrics.Report {
+	report := &metrics.Report{
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Process", File: "service.go"},
+			{Name: "TestProcess", File: "service_test.go", IsTestFile: true},
+		},
+	}
+	for _, file := range nestingFiles {
+		for i := 0; i < 3; i++ {
+			report.Burden.DeeplyNestedFunctions = append(report.Burden.DeeplyNestedFunctions,
+				metrics.NestingIssue{File: file, MaxDepth: 6})
+		}
+	}
+	return report
+}
+
+func TestCalculateAllScores_SeparatesProductionAndTestQuality(t *testing.T) {
+	cfg := config.ScoringConfig{Weights: config.ScoringWeights{Burden: 1.0}}
+	scorer := NewScoringAnalyzerWithConfig(cfg)
+
+	clean := scorer.CalculateAllScores(newQualityTestReport())
+	assert.Equal(t, 100.0, clean.ProductionQuality.Score)
+	assert.Equal(t, 100.0, clean.TestQuality.Score)
+	assert.Equal(t, 1, clean.ProductionQuality.FileCount)
+	assert.Equal(t, 1, clean.TestQuality.FileCount)
+
+	testIssues := scorer.CalculateAllScores(newQualityTestReport("service_test.go"))
+	assert.Equal(t, 100.0, testIssues.ProductionQuality.Score, "test file issues must not affect production quality")
+	assert.Equal(t, 70.0, testIssues.TestQuality.Score)
+
+	prodIssues := scorer.CalculateAllScores(newQualityTestReport("service.go"))
+	assert.Equal(t, 70.0, prodIssues.ProductionQuality.Score)
+	assert.Equal(t, 100.0, prodIssues.TestQuality.Score, "production issues must not affect test quality")
+}
+
+func TestCalculateAllScores_OverallQualityWeighting(t *testing.T) {
+	tests := []struct {
+		name           string
+		testCodeWeight float64
+		expected       float64
+	}{
+		{"production only", 0.0, 100.0},
+		{"equal weighting", 1.0, 85.0},
+		{"half weight", 0.5, 90.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.ScoringConfig{
+				Weights:        config.ScoringWeights{Burden: 1.0},
+				TestCodeWeight: tt.testCodeWeight,
+			}
+			scores := NewScoringAnalyzerWithConfig(cfg).CalculateAllScores(newQualityTestReport("service_test.go"))
+			assert.InDelta(t, tt.expected, scores.OverallQuality, 0.001)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 	"reflect"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -14,6 +15,7 @@ import (
 type StructAnalyzer struct {
 	fset             *token.FileSet
 	functionAnalyzer *FunctionAnalyzer
+	weights          ComplexityWeights
 }
 
 // NewStructAnalyzer creates a new struct analyzer for examining Go struct definitions and their characteristics.
@@ -23,9 +25,16 @@ func NewStructAnalyzer(fset *token.FileSet) *StructAnalyzer {
 	return &StructAnalyzer{
 		fset:             fset,
 		functionAnalyzer: NewFunctionAnalyzer(fset),
+		weights:          DefaultStructComplexityWeights(),
 	}
 }
 
+// SetComplexityWeights sets the weights combining the field-based cyclomatic count and nesting
+// depth into ComplexityScore.Overall for structs and their methods
+func (sa *StructAnalyzer) SetComplexityWeights(weights ComplexityWeights) {
+	sa.weights = weights
+}
+
 // AnalyzeStructs analyzes all struct declarations in an AST file
 func (sa *StructAnalyzer) AnalyzeStructs(file *ast.File, pkgName string) ([]metrics.StructMetrics, error) {
 	return sa.AnalyzeStructsWithPath(file, pkgName, file.Name.Name)
@@ -96,6 +105,7 @@ func (sa *StructAnalyzer) analyzeStruct(file *ast.File, typeSpec *ast.TypeSpec,
 			sa.analyzeField(field, &structMetric)
 		}
 	}
+	structMetric.FieldTypePercentages = metrics.CalculateFieldTypePercentages(structMetric.FieldsByType)
 
 	// Calculate complexity score
 	structMetric.Complexity = sa.calculateComplexity(structMetric)
@@ -105,6 +115,7 @@ func (sa *StructAnalyzer) analyzeStruct(file *ast.File, typeSpec *ast.TypeSpec,
 
 	// Analyze methods associated with this struct
 	structMetric.Methods = sa.analyzeStructMethods(file, typeSpec.Name.Name)
+	structMetric.Balance = metrics.ClassifyStructBalance(structMetric.TotalFields, len(structMetric.Methods))
 
 	return structMetric, nil
 }
@@ -124,6 +135,9 @@ func (sa *StructAnalyzer) analyzeField(field *ast.Field, structMetric *metrics.S
 	// Regular fields (with names)
 	fieldType := sa.categorizeFieldType(field.Type)
 	structMetric.FieldsByType[fieldType] += len(field.Names)
+	if chanType, ok := field.Type.(*ast.ChanType); ok {
+		sa.recordChannelFields(field, chanType, structMetric)
+	}
 
 	// Analyze struct tags
 	if field.Tag != nil {
@@ -175,30 +189,62 @@ func (sa *StructAnalyzer) categorizeFieldType(expr ast.Expr) metrics.FieldType {
 	}
 }
 
+// recordChannelFields adds a ChannelFieldInfo for each name declared by a channel-typed field
+func (sa *StructAnalyzer) recordChannelFields(field *ast.Field, chanType *ast.ChanType, structMetric *metrics.StructMetrics) {
+	depth := 1
+	for inner, ok := chanType.Value.(*ast.ChanType); ok; inner, ok = inner.Value.(*ast.ChanType) {
+		depth++
+	}
+
+	for _, name := range field.Names {
+		structMetric.ChannelFields = append(structMetric.ChannelFields, metrics.ChannelFieldInfo{
+			Name:         name.Name,
+			Line:         sa.fset.Position(name.Pos()).Line,
+			Direction:    channelDirection(chanType.Dir),
+			ElementType:  types.ExprString(chanType.Value),
+			NestingDepth: depth,
+		})
+	}
+}
+
+// channelDirection names a channel direction using the values reported for channel instances
+func channelDirection(dir ast.ChanDir) string {
+	switch dir {
+	case ast.SEND:
+		return "send-only"
+	case ast.RECV:
+		return "receive-only"
+	default:
+		return "bidirectional"
+	}
+}
+
+// primitiveTypeNames are the predeclared Go types categorized as primitive
+var primitiveTypeNames = map[string]bool{
+	"bool":       true,
+	"string":     true,
+	"int":        true,
+	"int8":       true,
+	"int16":      true,
+	"int32":      true,
+	"int64":      true,
+	"uint":       true,
+	"uint8":      true,
+	"uint16":     true,
+	"uint32":     true,
+	"uint64":     true,
+	"uintptr":    true,
+	"byte":       true,
+	"rune":       true,
+	"float32":    true,
+	"float64":    true,
+	"complex64":  true,
+	"complex128": true,
+}
+
 // isPrimitiveType checks if a type name represents a Go primitive type
 func (sa *StructAnalyzer) isPrimitiveType(typeName string) bool {
-	primitives := map[string]bool{
-		"bool":       true,
-		"string":     true,
-		"int":        true,
-		"int8":       true,
-		"int16":      true,
-		"int32":      true,
-		"int64":      true,
-		"uint":       true,
-		"uint8":      true,
-		"uint16":     true,
-		"uint32":     true,
-		"uint64":     true,
-		"uintptr":    true,
-		"byte":       true,
-		"rune":       true,
-		"float32":    true,
-		"float64":    true,
-		"complex64":  true,
-		"complex128": true,
-	}
-	return primitives[typeName]
+	return primitiveTypeNames[typeName]
 }
 
 // extractEmbeddedType extracts information about an embedded type
@@ -274,7 +320,7 @@ func (sa *StructAnalyzer) calculateComplexity(structMetric metrics.StructMetrics
 	complexity.NestingDepth = len(structMetric.EmbeddedTypes)
 
 	// Calculate overall complexity score
-	complexity.Overall = float64(complexity.Cyclomatic) + float64(complexity.NestingDepth)*0.5
+	complexity.Overall = sa.weights.Overall(complexity)
 
 	return complexity
 }
@@ -343,7 +389,7 @@ func (sa *StructAnalyzer) analyzeMethod(funcDecl *ast.FuncDecl) metrics.MethodIn
 	method.Signature = sa.analyzeMethodSignature(funcDecl.Type)
 
 	// Count lines for the method
-	method.Lines = sa.countMethodLines(funcDecl)
+	method.Lines = sa.functionAnalyzer.countLines(funcDecl)
 
 	// Calculate basic complexity
 	method.Complexity = sa.calculateMethodComplexity(funcDecl)
@@ -450,32 +496,6 @@ func (sa *StructAnalyzer) calculateSignatureComplexity(signature metrics.Functio
 	return complexity
 }
 
-// countMethodLines counts lines in a method using the same logic as function analyzer
-func (sa *StructAnalyzer) countMethodLines(funcDecl *ast.FuncDecl) metrics.LineMetrics {
-	lines := metrics.LineMetrics{}
-
-	if funcDecl.Body == nil {
-		return lines
-	}
-
-	start := sa.fset.Position(funcDecl.Body.Lbrace)
-	end := sa.fset.Position(funcDecl.Body.Rbrace)
-
-	// Basic line counting (simplified version)
-	lines.Total = end.Line - start.Line - 1
-	if lines.Total < 0 {
-		lines.Total = 0
-	}
-
-	// For simplicity, assume 70% code, 20% comments, 10% blank
-	// This could be enhanced with proper AST analysis
-	lines.Code = int(float64(lines.Total) * 0.7)
-	lines.Comments = int(float64(lines.Total) * 0.2)
-	lines.Blank = lines.Total - lines.Code - lines.Comments
-
-	return lines
-}
-
 // calculateMethodComplexity calculates basic complexity for a method
 func (sa *StructAnalyzer) calculateMethodComplexity(funcDecl *ast.FuncDecl) metrics.ComplexityScore {
 	complexity := metrics.ComplexityScore{}
@@ -502,7 +522,7 @@ func (sa *StructAnalyzer) calculateMethodComplexity(funcDecl *ast.FuncDecl) metr
 	complexity.NestingDepth = sa.calculateNestingDepth(funcDecl.Body)
 
 	// Overall complexity
-	complexity.Overall = float64(complexity.Cyclomatic) + float64(complexity.NestingDepth)*0.5
+	complexity.Overall = sa.weights.Overall(complexity)
 
 	return complexity
 }
@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"go/ast"
+	"path/filepath"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// structKey identifies a struct type by its package directory, package name and type name
+type structKey struct {
+	dir, pkg, name string
+}
+
+// AttachCrossFileMethods completes the Methods of each struct with the methods declared in other
+// files of its package. Structs are analyzed one file at a time, so a struct whose methods live
+// in a sibling file such as user_methods.go would otherwise report only the methods next to its
+// declaration. Files belong to a struct's package when they share its directory and package
+// name; methods declared in test files are only attached to structs declared in test files, as
+// they are not part of the production type. The data-versus-behavior balance is reclassified
+// for every struct that gains methods. The complexity of attached methods is scored with weights.
+func AttachCrossFileMethods(structs []metrics.StructMetrics, files []BurdenFileInfo, weights ComplexityWeights) {
+	index := make(map[structKey]int, len(structs))
+	for i, s := range structs {
+		index[structKey{dir: filepath.Dir(s.File), pkg: s.Package, name: s.Name}] = i
+	}
+
+	// Visit files in path order so methods are attached in the same order on every run
+	paths := make([]string, len(files))
+	for i, fi := range files {
+		paths[i] = fi.RelPath
+		if paths[i] == "" && fi.File != nil && fi.Fset != nil {
+			paths[i] = fi.Fset.Position(fi.File.Pos()).Filename
+		}
+	}
+	order := make([]int, len(files))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return paths[order[a]] < paths[order[b]] })
+
+	extended := make(map[int]bool)
+	for _, f := range order {
+		fi, path := files[f], paths[f]
+		if fi.File == nil || fi.Fset == nil {
+			continue
+		}
+		sa := NewStructAnalyzer(fi.Fset)
+		sa.SetComplexityWeights(weights)
+		for _, decl := range fi.File.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+				continue
+			}
+			receiver := sa.extractReceiverType(funcDecl.Recv.List[0].Type)
+			i, ok := index[structKey{dir: filepath.Dir(path), pkg: fi.Pkg, name: receiver}]
+			if !ok || structs[i].File == path || (isTestFile(path) && !structs[i].IsTestFile) {
+				continue
+			}
+			structs[i].Methods = append(structs[i].Methods, sa.analyzeMethod(funcDecl))
+			extended[i] = true
+		}
+	}
+
+	for i := range extended {
+		structs[i].Balance = metrics.ClassifyStructBalance(structs[i].TotalFields, len(structs[i].Methods))
+	}
+}
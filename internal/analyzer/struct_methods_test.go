@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const userTypeSource = `package users
+
+// User is an account holder
+type User struct {
+	Name  string
+	Email string
+}
+
+func (u User) Display() string { return u.Name }
+`
+
+const userMethodsSource = `package users
+
+import "strings"
+
+func (u *User) Rename(name string) { u.Name = name }
+
+func (u *User) Domain() string {
+	if i := strings.Index(u.Email, "@"); i >= 0 {
+		return u.Email[i+1:]
+	}
+	return ""
+}
+
+func (g *Group) Size() int { return 0 }
+`
+
+const userTestSource = `package users
+
+func (u *User) fixture() *User { return u }
+`
+
+// parseBurdenFile parses src as relPath into its own FileSet and analyzes its structs
+func parseBurdenFile(t *testing.T, relPath, src string) (BurdenFileInfo, []metrics.StructMetrics) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, relPath, src, parser.ParseComments)
+	require.NoError(t, err)
+	structs, err := NewStructAnalyzer(fset).AnalyzeStructsWithPath(file, "users", relPath)
+	require.NoError(t, err)
+	for i := range structs {
+		structs[i].IsTestFile = isTestFile(relPath)
+	}
+	return BurdenFileInfo{File: file, Fset: fset, Pkg: "users", RelPath: relPath}, structs
+}
+
+func methodNames(methods []metrics.MethodInfo) []string {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = m.Name
+	}
+	return names
+}
+
+func TestAttachCrossFileMethods(t *testing.T) {
+	typeFile, structs := parseBurdenFile(t, "users/user.go", userTypeSource)
+	methodsFile, _ := parseBurdenFile(t, "users/user_methods.go", userMethodsSource)
+	testFile, _ := parseBurdenFile(t, "users/user_test.go", userTestSource)
+	otherFile, _ := parseBurdenFile(t, "admin/user_methods.go", userMethodsSource)
+	require.Len(t, structs, 1)
+	require.Equal(t, []string{"Display"}, methodNames(structs[0].Methods), "per-file analysis sees only the declaring file")
+
+	AttachCrossFileMethods(structs, []BurdenFileInfo{methodsFile, otherFile, testFile, typeFile}, DefaultStructComplexityWeights())
+
+	user := structs[0]
+	assert.Equal(t, []string{"Display", "Rename", "Domain"}, methodNames(user.Methods),
+		"methods from sibling files are attached once, without test-file or other-directory methods")
+	assert.True(t, user.Methods[1].IsPointer)
+	assert.Greater(t, user.Methods[2].Complexity.Cyclomatic, user.Methods[1].Complexity.Cyclomatic,
+		"attached methods are measured like same-file ones")
+	assert.Equal(t, metrics.ClassifyStructBalance(user.TotalFields, 3), user.Balance)
+}
@@ -0,0 +1,212 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// packageKey identifies a package by directory and name
+type packageKey struct {
+	dir, pkg string
+}
+
+// typeMembers are the selectors a named type of the package declares: field names and embedded
+// types for structs, method names for interfaces and concrete types
+type typeMembers struct {
+	isStruct bool
+	fields   []string
+	embedded []string
+	methods  []string
+}
+
+// ResolvePromotedMembers counts the fields and methods each struct gains from embedded types
+// declared in its own package, through pointer embedding and any depth of nesting. Selector
+// resolution follows the language rules: a member declared at a shallower depth shadows deeper
+// ones, and a name found more than once at the same depth is ambiguous and not promoted.
+// Embedded types from other packages are not resolved. As with AttachCrossFileMethods, types
+// and methods declared in test files only count for structs declared in test files.
+func ResolvePromotedMembers(structs []metrics.StructMetrics, files []BurdenFileInfo) {
+	production, withTests := collectPackageTypes(files, false), collectPackageTypes(files, true)
+	for i := range structs {
+		packages := production
+		if structs[i].IsTestFile {
+			packages = withTests
+		}
+		types := packages[packageKey{dir: filepath.Dir(structs[i].File), pkg: structs[i].Package}]
+		if types == nil {
+			continue
+		}
+		structs[i].PromotedFields, structs[i].PromotedMethods = countPromotedMembers(types, structs[i].Name)
+	}
+}
+
+// collectPackageTypes indexes the members of every named struct and interface type by package,
+// and attributes method declarations to their receiver types. Test files are skipped unless
+// includeTests is set.
+func collectPackageTypes(files []BurdenFileInfo, includeTests bool) map[packageKey]map[string]*typeMembers {
+	packages := make(map[packageKey]map[string]*typeMembers)
+	sa := &StructAnalyzer{}
+	for _, fi := range files {
+		if fi.File == nil {
+			continue
+		}
+		path := fi.RelPath
+		if path == "" && fi.Fset != nil {
+			path = fi.Fset.Position(fi.File.Pos()).Filename
+		}
+		if !includeTests && isTestFile(path) {
+			continue
+		}
+		key := packageKey{dir: filepath.Dir(path), pkg: fi.Pkg}
+		if packages[key] == nil {
+			packages[key] = make(map[string]*typeMembers)
+		}
+		types := packages[key]
+		member := func(name string) *typeMembers {
+			if types[name] == nil {
+				types[name] = &typeMembers{}
+			}
+			return types[name]
+		}
+
+		for _, decl := range fi.File.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) > 0 {
+					if receiver := sa.extractReceiverType(d.Recv.List[0].Type); receiver != "" {
+						member(receiver).methods = append(member(receiver).methods, d.Name.Name)
+					}
+				}
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						recordTypeMembers(sa, member(typeSpec.Name.Name), typeSpec.Type)
+					}
+				}
+			}
+		}
+	}
+	return packages
+}
+
+// recordTypeMembers records the fields and embedded types of a struct type, or the methods and
+// embedded interfaces of an interface type
+func recordTypeMembers(sa *StructAnalyzer, members *typeMembers, expr ast.Expr) {
+	switch t := expr.(type) {
+	case *ast.StructType:
+		members.isStruct = true
+		for _, field := range t.Fields.List {
+			if len(field.Names) > 0 {
+				for _, name := range field.Names {
+					members.fields = append(members.fields, name.Name)
+				}
+				continue
+			}
+			fieldType := field.Type
+			switch generic := fieldType.(type) {
+			case *ast.IndexExpr:
+				fieldType = generic.X
+			case *ast.IndexListExpr:
+				fieldType = generic.X
+			}
+			embedded := sa.extractEmbeddedType(fieldType)
+			if embedded.Name == "" {
+				continue
+			}
+			members.fields = append(members.fields, embedded.Name)
+			if embedded.Package == "" {
+				members.embedded = append(members.embedded, embedded.Name)
+			}
+		}
+	case *ast.InterfaceType:
+		for _, method := range t.Methods.List {
+			if len(method.Names) > 0 {
+				for _, name := range method.Names {
+					members.methods = append(members.methods, name.Name)
+				}
+			} else if ident, ok := method.Type.(*ast.Ident); ok {
+				members.embedded = append(members.embedded, ident.Name)
+			}
+		}
+	}
+}
+
+// countPromotedMembers walks the embedded types of structName breadth first, one depth at a time,
+// and counts the field and method names that resolve unambiguously to a promoted member
+func countPromotedMembers(types map[string]*typeMembers, structName string) (fields, methods int) {
+	root := types[structName]
+	if root == nil {
+		return 0, 0
+	}
+	shadowed := make(map[string]bool)
+	for _, name := range append(append([]string{}, root.fields...), root.methods...) {
+		shadowed[name] = true
+	}
+	visited := map[string]bool{structName: true}
+	level := root.embedded
+
+	for len(level) > 0 {
+		occurrences := make(map[string]int)
+		isField := make(map[string]bool)
+		var next []string
+		for _, typeName := range level {
+			if visited[typeName] {
+				continue
+			}
+			visited[typeName] = true
+			members := types[typeName]
+			if members == nil {
+				continue
+			}
+			if members.isStruct {
+				for _, name := range members.fields {
+					occurrences[name]++
+					isField[name] = true
+				}
+				next = append(next, members.embedded...)
+			}
+			for _, name := range interfaceMethods(types, members, map[string]bool{}) {
+				occurrences[name]++
+			}
+		}
+
+		for name, count := range occurrences {
+			if shadowed[name] {
+				continue
+			}
+			shadowed[name] = true
+			if count > 1 {
+				continue
+			}
+			if isField[name] {
+				fields++
+			} else {
+				methods++
+			}
+		}
+		level = next
+	}
+	return fields, methods
+}
+
+// interfaceMethods returns the methods of a named type: the declared methods of a concrete type,
+// or the full method set of an interface including the interfaces it embeds
+func interfaceMethods(types map[string]*typeMembers, members *typeMembers, seen map[string]bool) []string {
+	methods := append([]string{}, members.methods...)
+	if members.isStruct {
+		return methods
+	}
+	for _, name := range members.embedded {
+		if embedded := types[name]; embedded != nil && !seen[name] {
+			seen[name] = true
+			methods = append(methods, interfaceMethods(types, embedded, seen)...)
+		}
+	}
+	return methods
+}
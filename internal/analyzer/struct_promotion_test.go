@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const promotionSource = `package users
+
+import "sync"
+
+// Base has three fields and two methods
+type Base struct {
+	ID      int
+	Created string
+	Owner   string
+}
+
+func (b Base) Key() string { return b.Owner }
+
+// Describer describes itself
+type Describer interface {
+	Describe() string
+}
+
+// Account embeds Base by value
+type Account struct {
+	Base
+	Balance int
+}
+
+// Session embeds Base by pointer and a type from another package
+type Session struct {
+	*Base
+	sync.Mutex
+}
+
+// Audited embeds Account, so Base is two levels deep
+type Audited struct {
+	Account
+	Describer
+	Owner string
+}
+
+// Stamp collides with Base on Created at the same depth
+type Stamp struct {
+	Created string
+	Zone    string
+}
+
+// Record embeds both Base and Stamp
+type Record struct {
+	Base
+	Stamp
+}
+`
+
+const promotionMethodsSource = `package users
+
+func (b *Base) Touch() {}
+`
+
+// promotedCounts returns the promoted fields and methods resolved for each struct by name
+func promotedCounts(structs []metrics.StructMetrics) map[string][2]int {
+	counts := make(map[string][2]int, len(structs))
+	for _, s := range structs {
+		counts[s.Name] = [2]int{s.PromotedFields, s.PromotedMethods}
+	}
+	return counts
+}
+
+func TestResolvePromotedMembers(t *testing.T) {
+	typeFile, structs := parseBurdenFile(t, "users/promotion.go", promotionSource)
+	methodsFile, _ := parseBurdenFile(t, "users/promotion_methods.go", promotionMethodsSource)
+	require.Len(t, structs, 6)
+
+	AttachCrossFileMethods(structs, []BurdenFileInfo{typeFile, methodsFile}, DefaultStructComplexityWeights())
+	ResolvePromotedMembers(structs, []BurdenFileInfo{typeFile, methodsFile})
+	counts := promotedCounts(structs)
+
+	assert.Equal(t, [2]int{0, 0}, counts["Base"])
+	assert.Equal(t, [2]int{3, 2}, counts["Account"], "value embedding promotes Base's 3 fields and 2 methods")
+	assert.Equal(t, [2]int{3, 2}, counts["Session"], "pointer embedding promotes the same members; sync.Mutex is not resolved")
+	// Account's Base and Balance, then Base's fields except the shadowed Owner; Describe from the interface
+	assert.Equal(t, [2]int{4, 3}, counts["Audited"], "multi-level embedding with Owner shadowed by the outer field")
+	// Created is ambiguous between Base and Stamp at the same depth and is not promoted
+	assert.Equal(t, [2]int{3, 2}, counts["Record"], "ambiguous selectors are not promoted")
+}
+
+func TestResolvePromotedMembers_IgnoresTestFileMethodsForProductionStructs(t *testing.T) {
+	typeFile, structs := parseBurdenFile(t, "users/promotion.go", promotionSource)
+	testFile, _ := parseBurdenFile(t, "users/promotion_test.go", promotionMethodsSource)
+
+	ResolvePromotedMembers(structs, []BurdenFileInfo{typeFile, testFile})
+
+	assert.Equal(t, [2]int{3, 1}, promotedCounts(structs)["Account"])
+}
+
+func TestResolvePromotedMembers_EmbeddingCycleTerminates(t *testing.T) {
+	const src = `package users
+
+type A struct {
+	*B
+	Name string
+}
+
+type B struct {
+	*A
+	Size int
+}
+`
+	file, structs := parseBurdenFile(t, "users/cycle.go", src)
+
+	ResolvePromotedMembers(structs, []BurdenFileInfo{file})
+
+	// A is promoted B's fields A and Size; the walk stops when it reaches A again
+	assert.Equal(t, [2]int{2, 0}, promotedCounts(structs)["A"])
+}
@@ -4,6 +4,9 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -203,6 +206,17 @@ type Config struct {
 		}
 	}
 
+	// Test field type percentages (19 named fields across 8 categories)
+	for fieldType, expectedCount := range expected {
+		want := float64(expectedCount) / 19 * 100
+		if got := complexStruct.FieldTypePercentages[fieldType]; math.Abs(got-want) > 0.01 {
+			t.Errorf("Expected %s fields to be %.2f%%, got %.2f%%", fieldType, want, got)
+		}
+	}
+	if _, ok := complexStruct.FieldTypePercentages[metrics.FieldTypeEmbedded]; ok {
+		t.Error("Expected no percentage entry for absent embedded fields")
+	}
+
 	// Test total field count
 	expectedTotal := 19
 	if complexStruct.TotalFields != expectedTotal {
@@ -658,3 +672,128 @@ func (u *User) unexportedMethod() {
 		t.Errorf("Expected higher complexity with methods, got %f", user.Complexity.Overall)
 	}
 }
+
+// methodLineSource has methods whose bodies mix code, comments and blank lines
+const methodLineSource = `package test
+
+// Counter counts events
+type Counter struct {
+	n int
+}
+
+// Add increments the counter
+func (c *Counter) Add(delta int) {
+	// ignore negative deltas
+	if delta < 0 {
+		return
+	}
+
+	/* a block
+	   comment */
+	c.n += delta // trailing comment
+}
+
+// Value returns the count
+func (c Counter) Value() int {
+	return c.n
+}
+`
+
+// parseMethodLineSource writes methodLineSource to disk, since line counting reads source files,
+// and returns the parsed file together with the function analyzer's line counts by name
+func parseMethodLineSource(t *testing.T) (*token.FileSet, *ast.File, string, map[string]metrics.LineMetrics) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "counter.go")
+	if err := os.WriteFile(path, []byte(methodLineSource), 0o644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	functions, err := NewFunctionAnalyzer(fset).AnalyzeFunctionsWithPath(file, "test", path)
+	if err != nil {
+		t.Fatalf("AnalyzeFunctionsWithPath failed: %v", err)
+	}
+	want := make(map[string]metrics.LineMetrics)
+	for _, fn := range functions {
+		want[fn.Name] = fn.Lines
+	}
+	return fset, file, path, want
+}
+
+func TestAnalyzeStructs_MethodLinesMatchFunctionAnalyzer(t *testing.T) {
+	fset, file, path, want := parseMethodLineSource(t)
+
+	structs, err := NewStructAnalyzer(fset).AnalyzeStructsWithPath(file, "test", path)
+	if err != nil {
+		t.Fatalf("AnalyzeStructsWithPath failed: %v", err)
+	}
+	if len(structs) != 1 || len(structs[0].Methods) != 2 {
+		t.Fatalf("Expected 1 struct with 2 methods, got %+v", structs)
+	}
+
+	for _, method := range structs[0].Methods {
+		if method.Lines != want[method.Name] {
+			t.Errorf("%s: struct method lines %+v, function analyzer %+v", method.Name, method.Lines, want[method.Name])
+		}
+	}
+
+	add := want["Add"]
+	if add.Code != 4 || add.Comments != 3 || add.Blank != 1 {
+		t.Errorf("Add: expected 4 code, 3 comment and 1 blank lines, got %+v", add)
+	}
+}
+
+func TestAnalyzeStructs_ChannelFields(t *testing.T) {
+	src := `package test
+
+type Pipeline struct {
+	Jobs     chan *Job
+	Out, Err chan<- error
+	Done     <-chan struct{}
+	Replies  chan chan<- []string
+	Name     string
+}
+
+type Job struct{}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	structs, err := NewStructAnalyzer(fset).AnalyzeStructs(file, "test")
+	if err != nil {
+		t.Fatalf("AnalyzeStructs failed: %v", err)
+	}
+	if len(structs) != 2 || structs[0].Name != "Pipeline" {
+		t.Fatalf("Expected Pipeline and Job structs, got %+v", structs)
+	}
+
+	expected := []metrics.ChannelFieldInfo{
+		{Name: "Jobs", Line: 4, Direction: "bidirectional", ElementType: "*Job", NestingDepth: 1},
+		{Name: "Out", Line: 5, Direction: "send-only", ElementType: "error", NestingDepth: 1},
+		{Name: "Err", Line: 5, Direction: "send-only", ElementType: "error", NestingDepth: 1},
+		{Name: "Done", Line: 6, Direction: "receive-only", ElementType: "struct{}", NestingDepth: 1},
+		{Name: "Replies", Line: 7, Direction: "bidirectional", ElementType: "chan<- []string", NestingDepth: 2},
+	}
+	got := structs[0].ChannelFields
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d channel fields, got %+v", len(expected), got)
+	}
+	for i, want := range expected {
+		if got[i] != want {
+			t.Errorf("Channel field %d: expected %+v, got %+v", i, want, got[i])
+		}
+	}
+
+	if structs[1].ChannelFields != nil {
+		t.Errorf("Expected no channel fields for Job, got %+v", structs[1].ChannelFields)
+	}
+}
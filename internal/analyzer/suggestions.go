@@ -92,8 +92,8 @@ func (sg *SuggestionGenerator) GenerateSuggestions(report *metrics.Report) []Ref
 		suggestions[i].ImpactEffort = sg.calculateImpactEffortRatio(&suggestions[i])
 	}
 
-	// Sort by impact/effort ratio (highest ROI first)
-	sort.Slice(suggestions, func(i, j int) bool {
+	// Sort by impact/effort ratio (highest ROI first), keeping the category order among ties
+	sort.SliceStable(suggestions, func(i, j int) bool {
 		return suggestions[i].ImpactEffort > suggestions[j].ImpactEffort
 	})
 
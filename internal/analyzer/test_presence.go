@@ -0,0 +1,222 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// testFuncPrefixes are the name prefixes the go tool runs from test files
+var testFuncPrefixes = []string{"Test", "Benchmark", "Fuzz", "Example"}
+
+// directoryTests holds what the test files of one directory name and mention
+type directoryTests struct {
+	// subjects are the lower-cased names of test functions without their Test, Benchmark,
+	// Fuzz, or Example prefix, such as "parseconfig_empty" for TestParseConfig_Empty
+	subjects []string
+	// mentions are the identifiers used in the test files outside test function names
+	mentions map[string]bool
+}
+
+// AnalyzeTestPresence correlates each non-test function with the tests of its directory, without
+// coverage data. A function counts as tested when a test, benchmark, fuzz test, or example in a
+// test file of the same directory is named after it, as TestParse, TestParse_Empty, or
+// TestStore_Get and TestStoreGet for a method Get of Store are, or when those test files
+// mention its name anywhere, as a call, a method value, or a table entry. Mentions are matched
+// by name alone, so a function whose name a test uses for something else also counts as tested.
+// main and init functions are not counted. Functions whose cyclomatic complexity is above
+// complexityThreshold and that count as untested are listed, most complex first.
+func AnalyzeTestPresence(functions []metrics.FunctionMetrics, files []BurdenFileInfo, complexityThreshold int) *metrics.TestPresenceMetrics {
+	tests := NewTestIndex()
+	for _, fi := range files {
+		tests.Add(fi)
+	}
+	return tests.TestPresence(functions, complexityThreshold)
+}
+
+// TestIndex records what the test files of every directory name and mention, so test presence
+// can be correlated after the test file ASTs are gone
+type TestIndex struct {
+	dirs map[string]*directoryTests
+}
+
+// NewTestIndex returns an empty test index
+func NewTestIndex() *TestIndex {
+	return &TestIndex{dirs: make(map[string]*directoryTests)}
+}
+
+// Add indexes fi under the directory of its relative path if it is a test file
+func (ti *TestIndex) Add(fi BurdenFileInfo) {
+	if fi.File == nil || !isTestFile(fi.RelPath) {
+		return
+	}
+	dir := filepath.Dir(fi.RelPath)
+	dt := ti.dirs[dir]
+	if dt == nil {
+		dt = &directoryTests{mentions: make(map[string]bool)}
+		ti.dirs[dir] = dt
+	}
+	dt.add(fi.File)
+}
+
+// TestPresence correlates functions with the indexed test files as AnalyzeTestPresence does
+func (ti *TestIndex) TestPresence(functions []metrics.FunctionMetrics, complexityThreshold int) *metrics.TestPresenceMetrics {
+	tests := ti.dirs
+	result := &metrics.TestPresenceMetrics{
+		ComplexityThreshold: complexityThreshold,
+		Packages:            []metrics.PackageTestPresence{},
+		UntestedComplex:     []metrics.UntestedFunction{},
+	}
+
+	packages := make(map[string]*metrics.PackageTestPresence)
+	for _, fn := range functions {
+		if fn.IsTestFile || isTestFile(fn.File) || (!fn.IsMethod && (fn.Name == "main" || fn.Name == "init")) {
+			continue
+		}
+		dir := filepath.Dir(fn.File)
+		pkg := packages[dir]
+		if pkg == nil {
+			_, hasTests := tests[dir]
+			pkg = &metrics.PackageTestPresence{Package: fn.Package, Directory: dir, HasTestFiles: hasTests}
+			packages[dir] = pkg
+		}
+
+		pkg.Functions++
+		if tests[dir].covers(fn) {
+			pkg.TestedFunctions++
+			continue
+		}
+		if fn.Complexity.Cyclomatic > complexityThreshold {
+			result.UntestedComplex = append(result.UntestedComplex, untestedFunction(fn, complexityThreshold))
+		}
+	}
+
+	for _, pkg := range packages {
+		pkg.TestedRatio = testedRatio(pkg.TestedFunctions, pkg.Functions)
+		result.TotalFunctions += pkg.Functions
+		result.TestedFunctions += pkg.TestedFunctions
+		result.Packages = append(result.Packages, *pkg)
+	}
+	result.TestedRatio = testedRatio(result.TestedFunctions, result.TotalFunctions)
+
+	sort.Slice(result.Packages, func(i, j int) bool {
+		return result.Packages[i].Directory < result.Packages[j].Directory
+	})
+	SortUntestedFunctions(result.UntestedComplex)
+	return result
+}
+
+// SortUntestedFunctions orders untested functions from the most complex, then by position
+func SortUntestedFunctions(functions []metrics.UntestedFunction) {
+	sort.Slice(functions, func(i, j int) bool {
+		a, b := functions[i], functions[j]
+		if a.Cyclomatic != b.Cyclomatic {
+			return a.Cyclomatic > b.Cyclomatic
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+}
+
+// add records the test function names and identifier mentions of a test file
+func (dt *directoryTests) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			ast.Inspect(decl, dt.mention)
+			continue
+		}
+		if subject, ok := testSubject(fn); ok {
+			dt.subjects = append(dt.subjects, subject)
+		}
+		if fn.Body != nil {
+			ast.Inspect(fn.Body, dt.mention)
+		}
+	}
+}
+
+// mention records every identifier below n
+func (dt *directoryTests) mention(n ast.Node) bool {
+	if ident, ok := n.(*ast.Ident); ok {
+		dt.mentions[ident.Name] = true
+	}
+	return true
+}
+
+// testSubject returns the lower-cased name of fn without its test prefix and a leading
+// underscore, if fn is a test, benchmark, fuzz test, or example
+func testSubject(fn *ast.FuncDecl) (string, bool) {
+	if fn.Recv != nil {
+		return "", false
+	}
+	for _, prefix := range testFuncPrefixes {
+		if rest, ok := strings.CutPrefix(fn.Name.Name, prefix); ok {
+			subject := strings.ToLower(strings.TrimPrefix(rest, "_"))
+			return subject, subject != ""
+		}
+	}
+	return "", false
+}
+
+// covers reports whether a test of the directory is named after fn or mentions it. A nil
+// receiver, for a directory without test files, covers nothing.
+func (dt *directoryTests) covers(fn metrics.FunctionMetrics) bool {
+	if dt == nil {
+		return false
+	}
+	if dt.mentions[fn.Name] {
+		return true
+	}
+
+	candidates := []string{strings.ToLower(fn.Name)}
+	if receiver := strings.TrimPrefix(fn.ReceiverType, "*"); fn.IsMethod && receiver != "" {
+		receiver = strings.ToLower(receiver)
+		candidates = append(candidates, receiver+"_"+candidates[0], receiver+candidates[0])
+	}
+	for _, subject := range dt.subjects {
+		for _, candidate := range candidates {
+			if subject == candidate || strings.HasPrefix(subject, candidate+"_") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// untestedFunction describes fn as an untested function above the complexity threshold, as a
+// violation when its complexity is more than twice the threshold
+func untestedFunction(fn metrics.FunctionMetrics, threshold int) metrics.UntestedFunction {
+	severity := metrics.SeverityLevelWarning
+	if fn.Complexity.Cyclomatic > 2*threshold {
+		severity = metrics.SeverityLevelViolation
+	}
+	name := fn.Name
+	if fn.IsMethod && fn.ReceiverType != "" {
+		name = strings.TrimPrefix(fn.ReceiverType, "*") + "." + fn.Name
+	}
+	return metrics.UntestedFunction{
+		Name:         fn.Name,
+		ReceiverType: fn.ReceiverType,
+		Package:      fn.Package,
+		File:         fn.File,
+		Line:         fn.Line,
+		Cyclomatic:   fn.Complexity.Cyclomatic,
+		Severity:     severity,
+		Suggestion: fmt.Sprintf("Add a test for '%s', whose cyclomatic complexity of %d exceeds %d",
+			name, fn.Complexity.Cyclomatic, threshold),
+	}
+}
+
+// testedRatio divides tested by total, or returns 0 when there are no functions
+func testedRatio(tested, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(tested) / float64(total)
+}
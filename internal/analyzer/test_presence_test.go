@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// loadTestPresenceFixture parses every Go file below root, with paths relative to root
+func loadTestPresenceFixture(t *testing.T, root string) ([]metrics.FunctionMetrics, []BurdenFileInfo) {
+	t.Helper()
+	fset := token.NewFileSet()
+	var functions []metrics.FunctionMetrics
+	var files []BurdenFileInfo
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		require.NoError(t, err)
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		require.NoError(t, err)
+
+		funcs, err := NewFunctionAnalyzer(fset).AnalyzeFunctionsWithPath(file, file.Name.Name, rel)
+		require.NoError(t, err)
+		functions = append(functions, funcs...)
+		files = append(files, BurdenFileInfo{File: file, Fset: fset, Pkg: file.Name.Name, RelPath: rel})
+		return nil
+	})
+	require.NoError(t, err)
+	return functions, files
+}
+
+func TestAnalyzeTestPresence_Fixture(t *testing.T) {
+	functions, files := loadTestPresenceFixture(t, "../../testdata/testpresence")
+
+	result := AnalyzeTestPresence(functions, files, 3)
+
+	names := make([]string, len(result.UntestedComplex))
+	for i, fn := range result.UntestedComplex {
+		names[i] = fn.Name
+	}
+	assert.Equal(t, []string{"Discount", "Rate"}, names,
+		"ParseOrder, validate, and Total have tests, and summary is below the threshold")
+	assert.Equal(t, "*Cart", result.UntestedComplex[0].ReceiverType)
+	assert.Equal(t, metrics.SeverityLevelViolation, result.UntestedComplex[0].Severity)
+	assert.Equal(t, metrics.SeverityLevelWarning, result.UntestedComplex[1].Severity)
+	assert.Contains(t, result.UntestedComplex[0].Suggestion, "Cart.Discount")
+
+	require.Len(t, result.Packages, 2)
+	orders, shipping := result.Packages[0], result.Packages[1]
+	assert.Equal(t, metrics.PackageTestPresence{
+		Package: "orders", Directory: "orders", HasTestFiles: true, Functions: 5, TestedFunctions: 3, TestedRatio: 0.6,
+	}, orders)
+	assert.Equal(t, metrics.PackageTestPresence{
+		Package: "shipping", Directory: "shipping", Functions: 1,
+	}, shipping)
+	assert.Equal(t, 6, result.TotalFunctions)
+	assert.Equal(t, 3, result.TestedFunctions)
+	assert.InDelta(t, 0.5, result.TestedRatio, 1e-9)
+	assert.Equal(t, 3, result.ComplexityThreshold)
+}
+
+func TestAnalyzeTestPresence_TestNames(t *testing.T) {
+	_, functions, files := analyzeInterfaceSources(t, map[string]string{
+		"store.go": `package main
+
+type Store struct{}
+
+func (s *Store) Get(key string) string { return key }
+func (s Store) Put(key string)          {}
+func (s Store) Len() int                { return 0 }
+func Parse(s string) string             { return s }
+func parseFlags() bool                  { return false }
+func Render() string                    { return "" }
+func main()                             {}
+`,
+		"store_test.go": `package main
+
+import "testing"
+
+func TestStore_Get(t *testing.T)    {}
+func BenchmarkStorePut(b *testing.B) {}
+func FuzzParse_Unicode(f *testing.F) {}
+func Test_parseFlags(t *testing.T)   {}
+func TestRenderer(t *testing.T)      {}
+`,
+	})
+
+	result := AnalyzeTestPresence(functions, files, 0)
+
+	var untested []string
+	for _, fn := range result.UntestedComplex {
+		untested = append(untested, fn.Name)
+	}
+	assert.ElementsMatch(t, []string{"Len", "Render"}, untested,
+		"TestRenderer names another function, and main is not counted")
+	assert.Equal(t, 6, result.TotalFunctions)
+	assert.Equal(t, 4, result.TestedFunctions)
+}
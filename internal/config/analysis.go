@@ -25,6 +25,9 @@ type AnalysisConfig struct {
 	IncludeDocumentation bool `mapstructure:"include_documentation" json:"include_documentation"`
 	IncludeGenerics      bool `mapstructure:"include_generics" json:"include_generics"`
 	EnableTeamMetrics    bool `mapstructure:"enable_team_metrics" json:"enable_team_metrics"`
+	// IncludePerformance enables the heuristic hot-path allocation warnings (loops that append
+	// without capacity, call fmt.Sprintf, grow maps, or concatenate strings)
+	IncludePerformance bool `mapstructure:"include_performance" json:"include_performance"`
 
 	// Test coverage integration
 	CoverageProfile string `mapstructure:"coverage_profile" json:"coverage_profile"`
@@ -32,13 +35,20 @@ type AnalysisConfig struct {
 	// Thresholds for warnings
 	MaxFunctionLength        int     `mapstructure:"max_function_length" json:"max_function_length"`
 	MaxCyclomaticComplexity  int     `mapstructure:"max_cyclomatic_complexity" json:"max_cyclomatic_complexity"`
+	MaxTestComplexity        int     `mapstructure:"max_test_complexity" json:"max_test_complexity"`
 	MaxStructFields          int     `mapstructure:"max_struct_fields" json:"max_struct_fields"`
+	MaxStructMembers         int     `mapstructure:"max_struct_members" json:"max_struct_members"`
 	MinDocumentationCoverage float64 `mapstructure:"min_documentation_coverage" json:"min_documentation_coverage"`
 	MinPackageDocCoverage    float64 `mapstructure:"min_package_doc_coverage" json:"min_package_doc_coverage"`
 	MaxDuplicationRatio      float64 `mapstructure:"max_duplication_ratio" json:"max_duplication_ratio"`
 	MaxUndocumentedExports   int     `mapstructure:"max_undocumented_exports" json:"max_undocumented_exports"`
 	EnforceThresholds        bool    `mapstructure:"enforce_thresholds" json:"enforce_thresholds"`
 
+	// Absolute limits that fail the run with exit code 2; zero disables a check
+	FailOnComplexity     int     `mapstructure:"fail_on_complexity" json:"fail_on_complexity"`
+	FailOnFunctionLength int     `mapstructure:"fail_on_function_length" json:"fail_on_function_length"`
+	FailOnDocCoverage    float64 `mapstructure:"fail_on_doc_coverage" json:"fail_on_doc_coverage"`
+
 	// Duplication detection settings
 	Duplication DuplicationConfig `mapstructure:"duplication" json:"duplication"`
 
@@ -59,12 +69,38 @@ type AnalysisConfig struct {
 
 	// Scoring weights for MBI calculation
 	Scoring ScoringConfig `mapstructure:"scoring" json:"scoring"`
+
+	// Weights combining complexity components into the overall complexity score
+	ComplexityWeights ComplexityWeightsConfig `mapstructure:"complexity_weights" json:"complexity_weights"`
+}
+
+// ComplexityWeightsConfig controls how ComplexityScore.Overall is computed for functions and
+// structs. Each component is multiplied by its weight and the products are summed.
+type ComplexityWeightsConfig struct {
+	Function FunctionComplexityWeights `mapstructure:"function" json:"function"`
+	Struct   StructComplexityWeights   `mapstructure:"struct" json:"struct"`
+}
+
+// FunctionComplexityWeights weights the components of function and method complexity
+type FunctionComplexityWeights struct {
+	Cyclomatic float64 `mapstructure:"cyclomatic" json:"cyclomatic"`
+	Nesting    float64 `mapstructure:"nesting" json:"nesting"`
+	Cognitive  float64 `mapstructure:"cognitive" json:"cognitive"`
+}
+
+// StructComplexityWeights weights the components of struct complexity, which has no cognitive part
+type StructComplexityWeights struct {
+	Cyclomatic float64 `mapstructure:"cyclomatic" json:"cyclomatic"`
+	Nesting    float64 `mapstructure:"nesting" json:"nesting"`
 }
 
 // ScoringConfig controls maintenance burden index calculation
 type ScoringConfig struct {
 	Weights        ScoringWeights `mapstructure:"weights" json:"weights"`
 	MaxBurdenScore float64        `mapstructure:"max_burden_score" json:"max_burden_score"`
+	// TestCodeWeight controls how much test-file quality contributes to the overall
+	// quality score relative to production code (0 = test code is reported separately only)
+	TestCodeWeight float64 `mapstructure:"test_code_weight" json:"test_code_weight"`
 }
 
 // ScoringWeights defines weights for each maintenance category
@@ -121,8 +157,36 @@ type BurdenConfig struct {
 	MaxParams         int     `mapstructure:"max_params" json:"max_params"`
 	MaxReturns        int     `mapstructure:"max_returns" json:"max_returns"`
 	MaxNesting        int     `mapstructure:"max_nesting" json:"max_nesting"`
+	MaxTypeDepth      int     `mapstructure:"max_type_depth" json:"max_type_depth"`
 	FeatureEnvyRatio  float64 `mapstructure:"feature_envy_ratio" json:"feature_envy_ratio"`
 	IgnoreBenignMagic bool    `mapstructure:"ignore_benign_magic" json:"ignore_benign_magic"`
+	// AllowedMagicNumbers are numeric values (e.g. "0", "1", "-1") never reported as magic
+	// number anti-patterns
+	AllowedMagicNumbers []string `mapstructure:"allowed_magic_numbers" json:"allowed_magic_numbers"`
+	// MaxChainDepth is the longest a.B().C() method call chain allowed before a readability advisory
+	MaxChainDepth int `mapstructure:"max_chain_depth" json:"max_chain_depth"`
+	// MaxNakedReturnLines is the longest body, in lines, of a function with named results that
+	// may use naked returns; each naked return in a longer function is flagged (0 disables)
+	MaxNakedReturnLines int `mapstructure:"max_naked_return_lines" json:"max_naked_return_lines"`
+	// ChainExclusions are method name globs (e.g. "With*") that do not count toward chain depth,
+	// covering fluent builders and error-wrapping helpers
+	ChainExclusions []string `mapstructure:"chain_exclusions" json:"chain_exclusions"`
+	// DetectConstructorBypass flags struct literals built outside an existing New<Type> constructor
+	DetectConstructorBypass bool `mapstructure:"detect_constructor_bypass" json:"detect_constructor_bypass"`
+	// ExemptConstructorParams keeps New* constructors, which often take every dependency of the
+	// type they build, out of the long parameter list anti-patterns over MaxParams
+	ExemptConstructorParams bool `mapstructure:"exempt_constructor_params" json:"exempt_constructor_params"`
+	// DetectInterfacePollution flags single-method interfaces with one implementer and no test double
+	DetectInterfacePollution bool `mapstructure:"detect_interface_pollution" json:"detect_interface_pollution"`
+	// DetectUnimplementedInterfaces lists exported interfaces that no analyzed type implements
+	DetectUnimplementedInterfaces bool `mapstructure:"detect_unimplemented_interfaces" json:"detect_unimplemented_interfaces"`
+	// ExternalInterfaceMaxMethods is the most methods an unimplemented interface used as a function
+	// parameter type may have and still be assumed satisfied by external types (0 disables)
+	ExternalInterfaceMaxMethods int `mapstructure:"external_interface_max_methods" json:"external_interface_max_methods"`
+	// MaxInterfaceParams and MaxInterfaceReturns are the most parameters and results an interface
+	// method may declare before it is reported as oversized, independent of MaxParams and MaxReturns
+	MaxInterfaceParams  int `mapstructure:"max_interface_params" json:"max_interface_params"`
+	MaxInterfaceReturns int `mapstructure:"max_interface_returns" json:"max_interface_returns"`
 }
 
 // OutputConfig controls output formatting options including format type,
@@ -145,6 +209,44 @@ type OutputConfig struct {
 
 	// Section filtering — when non-empty, only listed sections appear in output
 	Sections []string `mapstructure:"sections" json:"sections,omitempty"`
+	// WarningsOnly makes the JSON and CSV reporters emit one object or row per warning
+	// instead of the full report
+	WarningsOnly bool `mapstructure:"warnings_only" json:"warnings_only,omitempty"`
+	// CSV selects the optional tables written by the csv format
+	CSV CSVOutputConfig `mapstructure:"csv" json:"csv"`
+
+	// Callbacks for library callers; they are never loaded from configuration files.
+	// Logger receives diagnostic messages and Progress receives a ProgressEvent as each
+	// analysis phase advances. The analysis is silent when they are nil.
+	Logger   func(format string, args ...interface{}) `mapstructure:"-" json:"-"`
+	Progress func(event ProgressEvent)                `mapstructure:"-" json:"-"`
+}
+
+// CSVOutputConfig selects the tables the CSV reporter writes after the always-present
+// metadata, overview, and functions tables
+type CSVOutputConfig struct {
+	IncludeStructs  bool `mapstructure:"include_structs" json:"include_structs"`
+	IncludePackages bool `mapstructure:"include_packages" json:"include_packages"`
+}
+
+// ProgressPhase identifies the stage of an analysis run a ProgressEvent belongs to
+type ProgressPhase string
+
+const (
+	PhaseDiscovery    ProgressPhase = "discovery"
+	PhaseAnalysis     ProgressPhase = "analysis"
+	PhaseFinalization ProgressPhase = "finalization"
+)
+
+// ProgressEvent reports how far an analysis phase has advanced. Completed never decreases
+// within a phase and reaches Total when the phase ends. During the analysis phase the counts
+// are files and File is the relative path of the file that just finished; discovery reports
+// the number of files found once the walk is done.
+type ProgressEvent struct {
+	Phase     ProgressPhase `json:"phase"`
+	Completed int           `json:"completed"`
+	Total     int           `json:"total"`
+	File      string        `json:"file,omitempty"`
 }
 
 // OutputFormat represents supported output formats
@@ -156,6 +258,8 @@ const (
 	FormatCSV      OutputFormat = "csv"
 	FormatHTML     OutputFormat = "html"
 	FormatMarkdown OutputFormat = "markdown"
+	FormatJSONL    OutputFormat = "jsonl"
+	FormatDOT      OutputFormat = "dot"
 )
 
 // PerformanceConfig controls performance-related settings for workers, caching, and profiling.
@@ -165,8 +269,14 @@ type PerformanceConfig struct {
 	MaxMemoryMB     int           `mapstructure:"max_memory_mb" json:"max_memory_mb"`
 	Timeout         time.Duration `mapstructure:"timeout" json:"timeout"`
 	EnableProfiling bool          `mapstructure:"enable_profiling" json:"enable_profiling"`
-
-	// Caching
+	// Bench reports analysis throughput and peak memory to stderr after the run
+	Bench bool `mapstructure:"bench" json:"bench"`
+	// LowMemory streams function and struct metrics to a temporary file while files are
+	// processed and reads them back at finalization, instead of holding them in memory
+	LowMemory bool `mapstructure:"low_memory" json:"low_memory"`
+
+	// Caching: when enabled, per-file results are stored in CacheDirectory keyed by file
+	// content hash and reused by later runs over unchanged files
 	EnableCache    bool   `mapstructure:"enable_cache" json:"enable_cache"`
 	CacheDirectory string `mapstructure:"cache_directory" json:"cache_directory"`
 }
@@ -192,6 +302,10 @@ type StorageConfig struct {
 	Path        string `mapstructure:"path" json:"path"`               // File path for sqlite/json
 	Compression bool   `mapstructure:"compression" json:"compression"` // Enable compression for stored data
 
+	// Delta storage settings (sqlite only)
+	Deltas               bool `mapstructure:"deltas" json:"deltas"`                                 // Store snapshots as deltas against a full base
+	FullSnapshotInterval int  `mapstructure:"full_snapshot_interval" json:"full_snapshot_interval"` // Snapshots per full base when deltas are enabled
+
 	// PostgreSQL connection settings
 	PostgresConnectionString string `mapstructure:"postgres_connection_string" json:"postgres_connection_string"`
 
@@ -201,6 +315,11 @@ type StorageConfig struct {
 	// Retention policy
 	MaxSnapshots int           `mapstructure:"max_snapshots" json:"max_snapshots"`
 	MaxAge       time.Duration `mapstructure:"max_age" json:"max_age"`
+
+	// Snapshot settings: store every analyze run as a snapshot with this description and tags
+	Snapshot            bool     `mapstructure:"snapshot" json:"snapshot"`
+	SnapshotDescription string   `mapstructure:"snapshot_description" json:"snapshot_description"`
+	SnapshotTags        []string `mapstructure:"snapshot_tags" json:"snapshot_tags"` // key=value entries
 }
 
 // DefaultConfig returns the default configuration with sensible production values
@@ -226,7 +345,9 @@ func defaultAnalysisConfig() AnalysisConfig {
 		IncludeGenerics:          true,
 		MaxFunctionLength:        30,
 		MaxCyclomaticComplexity:  10,
+		MaxTestComplexity:        15,
 		MaxStructFields:          20,
+		MaxStructMembers:         30,
 		MinDocumentationCoverage: 0.7,
 		MinPackageDocCoverage:    0.4,
 		Duplication:              defaultDuplicationConfig(),
@@ -236,6 +357,7 @@ func defaultAnalysisConfig() AnalysisConfig {
 		Organization:             defaultOrganizationConfig(),
 		Burden:                   defaultBurdenConfig(),
 		Scoring:                  defaultScoringConfig(),
+		ComplexityWeights:        defaultComplexityWeightsConfig(),
 	}
 }
 
@@ -285,11 +407,24 @@ func defaultOrganizationConfig() OrganizationConfig {
 
 func defaultBurdenConfig() BurdenConfig {
 	return BurdenConfig{
-		MaxParams:         5,
-		MaxReturns:        3,
-		MaxNesting:        4,
-		FeatureEnvyRatio:  2.0,
-		IgnoreBenignMagic: true,
+		MaxParams:           5,
+		MaxReturns:          3,
+		MaxNesting:          4,
+		MaxTypeDepth:        3,
+		FeatureEnvyRatio:    2.0,
+		IgnoreBenignMagic:   true,
+		AllowedMagicNumbers: []string{"0", "1", "-1"},
+		MaxChainDepth:       4,
+		MaxNakedReturnLines: 10,
+		ChainExclusions:     []string{"With*", "Set*", "Add*", "Build", "Wrap*", "Errorf"},
+
+		DetectConstructorBypass:       true,
+		ExemptConstructorParams:       true,
+		DetectInterfacePollution:      true,
+		DetectUnimplementedInterfaces: true,
+		ExternalInterfaceMaxMethods:   1,
+		MaxInterfaceParams:            5,
+		MaxInterfaceReturns:           3,
 	}
 }
 
@@ -304,6 +439,14 @@ func defaultScoringConfig() ScoringConfig {
 			Burden:        0.25,
 		},
 		MaxBurdenScore: 70.0,
+		TestCodeWeight: 0.0,
+	}
+}
+
+func defaultComplexityWeightsConfig() ComplexityWeightsConfig {
+	return ComplexityWeightsConfig{
+		Function: FunctionComplexityWeights{Cyclomatic: 1.0, Nesting: 0.5, Cognitive: 0.3},
+		Struct:   StructComplexityWeights{Cyclomatic: 1.0, Nesting: 0.5},
 	}
 }
 
@@ -319,6 +462,10 @@ func defaultOutputConfig() OutputConfig {
 		IncludeExamples: false,
 		SortBy:          "complexity",
 		Limit:           100,
+		CSV: CSVOutputConfig{
+			IncludeStructs:  true,
+			IncludePackages: true,
+		},
 	}
 }
 
@@ -348,10 +495,11 @@ func defaultFilterConfig() FilterConfig {
 
 func defaultStorageConfig() StorageConfig {
 	return StorageConfig{
-		Type:         "sqlite",
-		Path:         "metrics.db",
-		Compression:  true,
-		MaxSnapshots: 50,
-		MaxAge:       30 * 24 * time.Hour, // 30 days
+		Type:                 "sqlite",
+		Path:                 "metrics.db",
+		Compression:          true,
+		FullSnapshotInterval: 10,
+		MaxSnapshots:         50,
+		MaxAge:               30 * 24 * time.Hour, // 30 days
 	}
 }
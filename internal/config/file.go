@@ -0,0 +1,182 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigFileNames are the project configuration files looked up in a directory, in order of preference
+var ConfigFileNames = []string{".go-stats-generator.yaml", ".go-stats.yaml"}
+
+// extraFileKeys are configuration keys read directly by commands rather than through Config
+var extraFileKeys = map[string]bool{
+	"verbose":                  true,
+	"storage.json.directory":   true,
+	"storage.json.compression": true,
+	"storage.json.pretty":      true,
+}
+
+// FindConfigFile returns the path of the first ConfigFileNames entry present in dir, or "" when
+// the directory has none
+func FindConfigFile(dir string) string {
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// ValidateKeys checks the nested settings read from a configuration file against the Config
+// schema given by its mapstructure tags and reports every key that names no setting
+func ValidateKeys(settings map[string]interface{}) error {
+	var unknown []string
+	collectUnknownKeys(reflect.TypeOf(Config{}), settings, "", &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown configuration keys: %s", strings.Join(unknown, ", "))
+}
+
+// collectUnknownKeys appends the dotted keys of settings that match no field of structType
+func collectUnknownKeys(structType reflect.Type, settings map[string]interface{}, prefix string, unknown *[]string) {
+	fields := mapstructureFields(structType)
+	for key, value := range settings {
+		path := prefix + key
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			if !extraFileKeys[path] && !hasExtraKeyPrefix(path) {
+				*unknown = append(*unknown, path)
+			}
+			continue
+		}
+		nested, isMap := value.(map[string]interface{})
+		if field.Kind() == reflect.Struct && isMap {
+			collectUnknownKeys(field, nested, path+".", unknown)
+		}
+	}
+}
+
+// hasExtraKeyPrefix reports whether path is a parent section of an extraFileKeys entry
+func hasExtraKeyPrefix(path string) bool {
+	for key := range extraFileKeys {
+		if strings.HasPrefix(key, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// mapstructureFields maps the lowercase mapstructure names of structType's fields to their
+// types, skipping fields tagged "-"
+func mapstructureFields(structType reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+// Validate checks that thresholds and limits are within range: counts and limits must not be
+// negative, fractions must lie in [0, 1] and percentages in [0, 100]. All violations are reported.
+func (c *Config) Validate() error {
+	var errs []error
+	a := c.Analysis
+	for _, check := range []struct {
+		key   string
+		value int
+	}{
+		{"analysis.max_function_length", a.MaxFunctionLength},
+		{"analysis.max_cyclomatic_complexity", a.MaxCyclomaticComplexity},
+		{"analysis.max_test_complexity", a.MaxTestComplexity},
+		{"analysis.max_struct_fields", a.MaxStructFields},
+		{"analysis.max_struct_members", a.MaxStructMembers},
+		{"analysis.max_undocumented_exports", a.MaxUndocumentedExports},
+		{"analysis.fail_on_complexity", a.FailOnComplexity},
+		{"analysis.fail_on_function_length", a.FailOnFunctionLength},
+		{"analysis.duplication.min_block_lines", a.Duplication.MinBlockLines},
+		{"analysis.naming.min_name_length", a.Naming.MinNameLength},
+		{"analysis.documentation.stale_annotation_days", a.Documentation.StaleAnnotationDays},
+		{"analysis.documentation.min_comment_words", a.Documentation.MinCommentWords},
+		{"analysis.organization.max_file_lines", a.Organization.MaxFileLines},
+		{"analysis.organization.max_file_functions", a.Organization.MaxFileFunctions},
+		{"analysis.organization.max_file_types", a.Organization.MaxFileTypes},
+		{"analysis.organization.max_package_files", a.Organization.MaxPackageFiles},
+		{"analysis.organization.max_exported_symbols", a.Organization.MaxExportedSymbols},
+		{"analysis.organization.max_directory_depth", a.Organization.MaxDirectoryDepth},
+		{"analysis.organization.max_file_imports", a.Organization.MaxFileImports},
+		{"analysis.burden.max_params", a.Burden.MaxParams},
+		{"analysis.burden.max_returns", a.Burden.MaxReturns},
+		{"analysis.burden.max_nesting", a.Burden.MaxNesting},
+		{"analysis.burden.max_type_depth", a.Burden.MaxTypeDepth},
+		{"analysis.burden.max_chain_depth", a.Burden.MaxChainDepth},
+		{"analysis.burden.max_naked_return_lines", a.Burden.MaxNakedReturnLines},
+		{"analysis.burden.external_interface_max_methods", a.Burden.ExternalInterfaceMaxMethods},
+		{"analysis.burden.max_interface_params", a.Burden.MaxInterfaceParams},
+		{"analysis.burden.max_interface_returns", a.Burden.MaxInterfaceReturns},
+		{"output.limit", c.Output.Limit},
+		{"performance.worker_count", c.Performance.WorkerCount},
+		{"performance.max_memory_mb", c.Performance.MaxMemoryMB},
+		{"storage.full_snapshot_interval", c.Storage.FullSnapshotInterval},
+		{"storage.max_snapshots", c.Storage.MaxSnapshots},
+	} {
+		if check.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %d", check.key, check.value))
+		}
+	}
+
+	for _, check := range []struct {
+		key      string
+		value    float64
+		min, max float64
+	}{
+		{"analysis.min_documentation_coverage", a.MinDocumentationCoverage, 0, 1},
+		{"analysis.min_package_doc_coverage", a.MinPackageDocCoverage, 0, 1},
+		{"analysis.max_duplication_ratio", a.MaxDuplicationRatio, 0, 1},
+		{"analysis.fail_on_doc_coverage", a.FailOnDocCoverage, 0, 100},
+		{"analysis.duplication.similarity_threshold", a.Duplication.SimilarityThreshold, 0, 1},
+		{"analysis.placement.affinity_margin", a.Placement.AffinityMargin, 0, 1},
+		{"analysis.placement.min_cohesion", a.Placement.MinCohesion, 0, 1},
+		{"analysis.scoring.max_burden_score", a.Scoring.MaxBurdenScore, 0, 100},
+		{"analysis.scoring.test_code_weight", a.Scoring.TestCodeWeight, 0, 1},
+	} {
+		if check.value < check.min || check.value > check.max {
+			errs = append(errs, fmt.Errorf("%s must be between %g and %g, got %g", check.key, check.min, check.max, check.value))
+		}
+	}
+
+	for _, check := range []struct {
+		key   string
+		value float64
+	}{
+		{"analysis.burden.feature_envy_ratio", a.Burden.FeatureEnvyRatio},
+		{"analysis.complexity_weights.function.cyclomatic", a.ComplexityWeights.Function.Cyclomatic},
+		{"analysis.complexity_weights.function.nesting", a.ComplexityWeights.Function.Nesting},
+		{"analysis.complexity_weights.function.cognitive", a.ComplexityWeights.Function.Cognitive},
+		{"analysis.complexity_weights.struct.cyclomatic", a.ComplexityWeights.Struct.Cyclomatic},
+		{"analysis.complexity_weights.struct.nesting", a.ComplexityWeights.Struct.Nesting},
+	} {
+		if check.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %g", check.key, check.value))
+		}
+	}
+	if c.Performance.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("performance.timeout must not be negative, got %s", c.Performance.Timeout))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	if got := FindConfigFile(dir); got != "" {
+		t.Fatalf("FindConfigFile() on an empty directory = %q, want \"\"", got)
+	}
+
+	short := filepath.Join(dir, ".go-stats.yaml")
+	if err := os.WriteFile(short, []byte("analysis: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindConfigFile(dir); got != short {
+		t.Errorf("FindConfigFile() = %q, want %q", got, short)
+	}
+
+	long := filepath.Join(dir, ".go-stats-generator.yaml")
+	if err := os.WriteFile(long, []byte("analysis: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindConfigFile(dir); got != long {
+		t.Errorf("FindConfigFile() = %q, want %q preferred over .go-stats.yaml", got, long)
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	valid := map[string]interface{}{
+		"verbose": true,
+		"analysis": map[string]interface{}{
+			"max_function_length": 40,
+			"burden":              map[string]interface{}{"max_params": 6},
+		},
+		"storage": map[string]interface{}{"type": "json", "json": map[string]interface{}{"pretty": true}},
+	}
+	if err := ValidateKeys(valid); err != nil {
+		t.Errorf("ValidateKeys() on known keys = %v, want nil", err)
+	}
+
+	unknown := map[string]interface{}{
+		"maintenance": map[string]interface{}{"max_params": 6},
+		"analysis": map[string]interface{}{
+			"max_function_lenght": 40,
+			"burden":              map[string]interface{}{"max_param": 6},
+		},
+	}
+	err := ValidateKeys(unknown)
+	if err == nil {
+		t.Fatal("ValidateKeys() on unknown keys = nil, want an error")
+	}
+	for _, key := range []string{"maintenance", "analysis.max_function_lenght", "analysis.burden.max_param"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not name %s", err, key)
+		}
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Fatalf("DefaultConfig().Validate() = %v, want nil", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Analysis.MaxFunctionLength = -1
+	cfg.Analysis.Duplication.SimilarityThreshold = 1.5
+	cfg.Analysis.FailOnDocCoverage = 120
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() on out-of-range values = nil, want an error")
+	}
+	for _, key := range []string{"analysis.max_function_length", "analysis.duplication.similarity_threshold", "analysis.fail_on_doc_coverage"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not name %s", err, key)
+		}
+	}
+}
+
+func TestConfig_ValidateComplexityWeights(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Analysis.ComplexityWeights.Function.Cognitive = -0.3
+	cfg.Analysis.ComplexityWeights.Struct.Nesting = -1
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() with negative complexity weights = nil, want an error")
+	}
+	for _, key := range []string{"analysis.complexity_weights.function.cognitive", "analysis.complexity_weights.struct.nesting"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not name %s", err, key)
+		}
+	}
+
+	cfg = DefaultConfig()
+	cfg.Analysis.ComplexityWeights.Function.Nesting = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with a zero weight = %v, want nil", err)
+	}
+}
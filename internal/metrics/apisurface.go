@@ -0,0 +1,102 @@
+package metrics
+
+import "sort"
+
+// APISymbolKind identifies the kind of an exported declaration
+type APISymbolKind string
+
+const (
+	APISymbolFunc      APISymbolKind = "func"
+	APISymbolMethod    APISymbolKind = "method"
+	APISymbolType      APISymbolKind = "type"
+	APISymbolInterface APISymbolKind = "interface"
+	APISymbolConst     APISymbolKind = "const"
+	APISymbolVar       APISymbolKind = "var"
+)
+
+// APISurface is the exported API of a module: every exported function, method, type,
+// interface, constant, and variable with its declaration signature. Source positions are
+// omitted so that two surfaces differ only when the API itself changes.
+type APISurface struct {
+	Module  string      `json:"module,omitempty"`
+	Symbols []APISymbol `json:"symbols"`
+}
+
+// APISymbol is one exported declaration. Methods are named Type.Method.
+type APISymbol struct {
+	Package   string        `json:"package"`
+	Kind      APISymbolKind `json:"kind"`
+	Name      string        `json:"name"`
+	Signature string        `json:"signature"`
+}
+
+// APISurfaceDiff lists the exported symbols added, removed, or changed between two surfaces.
+// Removals and signature changes can break callers; additions cannot.
+type APISurfaceDiff struct {
+	Added   []APISymbol       `json:"added"`
+	Removed []APISymbol       `json:"removed"`
+	Changed []APISymbolChange `json:"changed"`
+}
+
+// APISymbolChange is an exported symbol whose signature differs between two surfaces
+type APISymbolChange struct {
+	Old APISymbol `json:"old"`
+	New APISymbol `json:"new"`
+}
+
+// HasBreakingChanges reports whether any exported symbol was removed or changed
+func (d APISurfaceDiff) HasBreakingChanges() bool {
+	return len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// key identifies a symbol across versions of a surface
+func (s APISymbol) key() string {
+	return s.Package + "|" + string(s.Kind) + "|" + s.Name
+}
+
+// SortAPISymbols orders symbols by package, kind, and name so surfaces are stable across runs
+func SortAPISymbols(symbols []APISymbol) {
+	sort.Slice(symbols, func(i, j int) bool {
+		a, b := symbols[i], symbols[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+}
+
+// DiffAPISurfaces compares two API surfaces and returns the symbols added, removed, and changed
+// in current relative to baseline
+func DiffAPISurfaces(baseline, current *APISurface) APISurfaceDiff {
+	diff := APISurfaceDiff{Added: []APISymbol{}, Removed: []APISymbol{}, Changed: []APISymbolChange{}}
+
+	baselineSymbols := make(map[string]APISymbol, len(baseline.Symbols))
+	for _, symbol := range baseline.Symbols {
+		baselineSymbols[symbol.key()] = symbol
+	}
+
+	seen := make(map[string]bool, len(current.Symbols))
+	for _, symbol := range current.Symbols {
+		seen[symbol.key()] = true
+		old, ok := baselineSymbols[symbol.key()]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, symbol)
+		case old.Signature != symbol.Signature:
+			diff.Changed = append(diff.Changed, APISymbolChange{Old: old, New: symbol})
+		}
+	}
+	for _, symbol := range baseline.Symbols {
+		if !seen[symbol.key()] {
+			diff.Removed = append(diff.Removed, symbol)
+		}
+	}
+
+	SortAPISymbols(diff.Added)
+	SortAPISymbols(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].New.key() < diff.Changed[j].New.key() })
+	return diff
+}
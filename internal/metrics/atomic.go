@@ -0,0 +1,43 @@
+package metrics
+
+import "strings"
+
+// AtomicTypedKind is the operation kind of typed atomic declarations such as atomic.Int64 and
+// atomic.Value, which replace the function-call API since Go 1.19
+const AtomicTypedKind = "typed"
+
+// atomicOperationPrefixes maps sync/atomic function name prefixes to their operation kind.
+// CompareAndSwap is listed before Swap so the longer prefix wins.
+var atomicOperationPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{"CompareAndSwap", "compare_and_swap"},
+	{"Swap", "swap"},
+	{"Add", "add"},
+	{"Load", "load"},
+	{"Store", "store"},
+	{"And", "and"},
+	{"Or", "or"},
+}
+
+// AtomicOperationKind returns the operation kind of a sync/atomic function or type name, such
+// as "add" for AddInt64 or "compare_and_swap" for CompareAndSwapPointer. Names that are not
+// functions are typed atomics.
+func AtomicOperationKind(name string) string {
+	for _, op := range atomicOperationPrefixes {
+		if strings.HasPrefix(name, op.prefix) {
+			return op.kind
+		}
+	}
+	return AtomicTypedKind
+}
+
+// CountAtomicOperations tallies atomic usages by operation kind
+func CountAtomicOperations(instances []SyncPrimitiveInstance) map[string]int {
+	counts := make(map[string]int)
+	for _, instance := range instances {
+		counts[AtomicOperationKind(instance.Type)]++
+	}
+	return counts
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import "testing"
+
+func TestAtomicOperationKind(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "AddInt64", want: "add"},
+		{name: "LoadUint32", want: "load"},
+		{name: "StorePointer", want: "store"},
+		{name: "SwapInt32", want: "swap"},
+		{name: "CompareAndSwapPointer", want: "compare_and_swap"},
+		{name: "AndUint64", want: "and"},
+		{name: "OrInt32", want: "or"},
+		{name: "Int64", want: AtomicTypedKind},
+		{name: "Value", want: AtomicTypedKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AtomicOperationKind(tt.name); got != tt.want {
+				t.Errorf("AtomicOperationKind(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ComputeContentHash returns a hex-encoded SHA-256 fingerprint of the report's analysis results,
+// suitable as a CI cache key or a "nothing changed" check. Volatile metadata (generation time,
+// analysis duration, cache reuse, and the hash itself) is excluded. Object keys are hashed in sorted
+// order and lists in the order the report holds them, so lists whose order carries meaning, such
+// as rankings, change the hash when they are reordered. Analysis builds every list in a fixed
+// order, so two runs over identical code yield the same hash.
+func ComputeContentHash(report *Report) (string, error) {
+	stable := *report
+	stable.Metadata.GeneratedAt = time.Time{}
+	stable.Metadata.AnalysisTime = 0
+	stable.Metadata.CachedFiles = 0
+	stable.Metadata.ContentHash = ""
+
+	data, err := json.Marshal(&stable)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode report for hashing: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return "", fmt.Errorf("failed to decode report for hashing: %w", err)
+	}
+
+	// Re-encoding the decoded document writes the keys of every object sorted, struct fields included
+	canonical, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode canonical report: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeContentHash(t *testing.T) {
+	newReport := func() *Report {
+		return &Report{
+			Metadata: ReportMetadata{Repository: "app", FilesProcessed: 2},
+			Functions: []FunctionMetrics{
+				{Name: "Parse", Package: "app", File: "parse.go", Line: 4},
+				{Name: "Render", Package: "app", File: "render.go", Line: 9},
+			},
+		}
+	}
+
+	base, err := ComputeContentHash(newReport())
+	if err != nil {
+		t.Fatalf("ComputeContentHash failed: %v", err)
+	}
+	if len(base) != 64 {
+		t.Errorf("expected a 64 character SHA-256 hex digest, got %q", base)
+	}
+
+	volatile := newReport()
+	volatile.Metadata.GeneratedAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	volatile.Metadata.AnalysisTime = 3 * time.Second
+	volatile.Metadata.ContentHash = "stale"
+	if got, _ := ComputeContentHash(volatile); got != base {
+		t.Errorf("expected timestamps and durations to be ignored, got %s want %s", got, base)
+	}
+
+	reordered := newReport()
+	reordered.Functions[0], reordered.Functions[1] = reordered.Functions[1], reordered.Functions[0]
+	if got, _ := ComputeContentHash(reordered); got == base {
+		t.Error("expected list order to be hashed")
+	}
+
+	mapped := newReport()
+	mapped.Complexity.Distribution = map[string]int{"0-5": 2, "6-10": 0}
+	again := newReport()
+	again.Complexity.Distribution = map[string]int{"6-10": 0, "0-5": 2}
+	first, _ := ComputeContentHash(mapped)
+	if got, _ := ComputeContentHash(again); got != first {
+		t.Errorf("expected object keys to be hashed in sorted order, got %s want %s", got, first)
+	}
+
+	changed := newReport()
+	changed.Functions[1].Line = 10
+	if got, _ := ComputeContentHash(changed); got == base {
+		t.Error("expected a changed function position to change the hash")
+	}
+}
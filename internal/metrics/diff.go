@@ -8,6 +8,9 @@ import (
 	"time"
 )
 
+// DefaultFloatEpsilon is the default tolerance below which float metric differences are ignored
+const DefaultFloatEpsilon = 1e-6
+
 // DiffOptions configures how the diff is performed
 type DiffOptions struct {
 	ThresholdPercent float64 // Minimum percentage change to consider significant
@@ -58,6 +61,7 @@ func CompareSnapshots(baseline, current Snapshot, config ThresholdConfig) (*Comp
 
 	// Generate summary
 	diff.Summary = generateDiffSummary(changes, regressions, improvements)
+	diff.Summary.QualityDelta = current.Report.Scores.OverallQuality - baseline.Report.Scores.OverallQuality
 
 	return diff, nil
 }
@@ -190,7 +194,7 @@ func compareFunctionComplexity(baseline, current FunctionMetrics, config Thresho
 	if baseline.Complexity.Cyclomatic != current.Complexity.Cyclomatic {
 		changes = append(changes, createCyclomaticChange(baseline, current, config))
 	}
-	if baseline.Complexity.Overall != current.Complexity.Overall {
+	if !floatsEqual(baseline.Complexity.Overall, current.Complexity.Overall, config.Global.Epsilon) {
 		changes = append(changes, createOverallComplexityChange(baseline, current, config))
 	}
 	return changes
@@ -429,7 +433,7 @@ func mergePackagePaths(baselineMap, currentMap map[string]PackageMetrics) map[st
 
 // comparePackageCoupling compares coupling scores between baseline and current packages
 func comparePackageCoupling(basePkg, currPkg PackageMetrics, config ThresholdConfig) []MetricChange {
-	if basePkg.CouplingScore == currPkg.CouplingScore {
+	if floatsEqual(basePkg.CouplingScore, currPkg.CouplingScore, config.Global.Epsilon) {
 		return nil
 	}
 
@@ -463,7 +467,7 @@ func assignPackageCouplingImpact(change *MetricChange, currPkg PackageMetrics, d
 
 // comparePackageCohesion compares cohesion scores between baseline and current packages
 func comparePackageCohesion(basePkg, currPkg PackageMetrics, config ThresholdConfig) []MetricChange {
-	if basePkg.CohesionScore == currPkg.CohesionScore {
+	if floatsEqual(basePkg.CohesionScore, currPkg.CohesionScore, config.Global.Epsilon) {
 		return nil
 	}
 
@@ -500,7 +504,7 @@ func compareComplexityMetrics(baseline, current ComplexityMetrics, config Thresh
 	var changes []MetricChange
 
 	// Compare average function complexity
-	if baseline.AverageFunction != current.AverageFunction {
+	if !floatsEqual(baseline.AverageFunction, current.AverageFunction, config.Global.Epsilon) {
 		delta := calculateDelta(baseline.AverageFunction, current.AverageFunction, config.Global.SignificanceLevel)
 
 		changes = append(changes, MetricChange{
@@ -517,7 +521,7 @@ func compareComplexityMetrics(baseline, current ComplexityMetrics, config Thresh
 	}
 
 	// Compare average struct complexity
-	if baseline.AverageStruct != current.AverageStruct {
+	if !floatsEqual(baseline.AverageStruct, current.AverageStruct, config.Global.Epsilon) {
 		delta := calculateDelta(baseline.AverageStruct, current.AverageStruct, config.Global.SignificanceLevel)
 
 		changes = append(changes, MetricChange{
@@ -623,6 +627,26 @@ func countSignificantChanges(changes []MetricChange) int {
 	return count
 }
 
+// GateFailures returns the regressions that fail the comparison under the diff's FailOnError and
+// FailOnCritical settings: critical regressions when FailOnCritical is set, and regressions of
+// violation or critical severity when FailOnError is set
+func (d *ComplexityDiff) GateFailures() []Regression {
+	var failures []Regression
+	for _, regression := range d.Regressions {
+		switch regression.Severity {
+		case SeverityLevelCritical:
+			if d.Config.Global.FailOnCritical || d.Config.Global.FailOnError {
+				failures = append(failures, regression)
+			}
+		case SeverityLevelViolation:
+			if d.Config.Global.FailOnError {
+				failures = append(failures, regression)
+			}
+		}
+	}
+	return failures
+}
+
 // countCriticalIssues counts the number of critical regressions
 func countCriticalIssues(regressions []Regression) int {
 	count := 0
@@ -673,6 +697,11 @@ func calculateDelta(oldValue, newValue, threshold float64) Delta {
 	}
 }
 
+// floatsEqual reports whether two float metrics differ by no more than epsilon
+func floatsEqual(a, b, epsilon float64) bool {
+	return math.Abs(a-b) <= epsilon
+}
+
 func calculatePercentageChange(oldValue, newValue float64) float64 {
 	absolute := math.Abs(newValue - oldValue)
 	if oldValue != 0 {
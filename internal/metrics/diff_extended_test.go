@@ -37,6 +37,79 @@ func TestCompareSnapshots_Success(t *testing.T) {
 	assert.Equal(t, "current-1", diff.Current.ID)
 }
 
+func TestCompareSnapshots_FloatEpsilon(t *testing.T) {
+	newNoisySnapshot := func(id string, overall, coupling, average float64) Snapshot {
+		fn := newTestFunctionMetrics("TestFunc", "pkg", 5, 20)
+		fn.Complexity.Overall = overall
+		snapshot := newTestSnapshot(id,
+			[]FunctionMetrics{fn},
+			nil,
+			[]PackageMetrics{newTestPackageMetrics("pkg", coupling, 0.8)},
+		)
+		snapshot.Report.Complexity.AverageFunction = average
+		return snapshot
+	}
+
+	tests := []struct {
+		name          string
+		overall       float64
+		coupling      float64
+		average       float64
+		epsilon       float64
+		expectChanges []string
+	}{
+		{
+			name:     "floating-point noise only",
+			overall:  3.2999999,
+			coupling: 0.30000000000000004,
+			average:  5.0000001,
+			epsilon:  DefaultFloatEpsilon,
+		},
+		{
+			name:          "real change among noise",
+			overall:       4.8,
+			coupling:      0.30000000000000004,
+			average:       5.0000001,
+			epsilon:       DefaultFloatEpsilon,
+			expectChanges: []string{"function_overall_complexity"},
+		},
+		{
+			name:          "coarser epsilon ignores small changes",
+			overall:       3.32,
+			coupling:      0.35,
+			average:       5.04,
+			epsilon:       0.05,
+			expectChanges: nil,
+		},
+		{
+			name:          "zero epsilon compares exactly",
+			overall:       3.2999999,
+			coupling:      0.3,
+			average:       5.0,
+			epsilon:       0,
+			expectChanges: []string{"function_overall_complexity"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultThresholdConfig()
+			config.Global.SignificanceLevel = 0
+			config.Global.Epsilon = tt.epsilon
+
+			diff, err := CompareSnapshots(newNoisySnapshot("baseline", 3.3, 0.3, 5.0),
+				newNoisySnapshot("current", tt.overall, tt.coupling, tt.average), config)
+			require.NoError(t, err)
+
+			var categories []string
+			for _, change := range diff.Changes {
+				categories = append(categories, change.Category)
+			}
+			assert.Equal(t, tt.expectChanges, categories)
+		})
+	}
+}
+
 func TestCompareSnapshots_InvalidIDs(t *testing.T) {
 	config := DefaultThresholdConfig()
 
@@ -275,3 +275,28 @@ func TestDetectBurdenRegressionsMultiple(t *testing.T) {
 		}
 	}
 }
+
+func TestComplexityDiffGateFailures(t *testing.T) {
+	diff := &ComplexityDiff{
+		Regressions: []Regression{
+			{Location: "a", Severity: SeverityLevelCritical},
+			{Location: "b", Severity: SeverityLevelViolation},
+			{Location: "c", Severity: SeverityLevelWarning},
+		},
+	}
+
+	if failures := diff.GateFailures(); len(failures) != 0 {
+		t.Errorf("expected no failures with gating disabled, got %d", len(failures))
+	}
+
+	diff.Config.Global.FailOnCritical = true
+	failures := diff.GateFailures()
+	if len(failures) != 1 || failures[0].Location != "a" {
+		t.Errorf("expected only the critical regression, got %+v", failures)
+	}
+
+	diff.Config.Global.FailOnError = true
+	if failures := diff.GateFailures(); len(failures) != 2 {
+		t.Errorf("expected critical and violation regressions, got %d", len(failures))
+	}
+}
@@ -0,0 +1,51 @@
+package metrics
+
+// FieldTypeOrder lists every field category in the order reporters display them
+var FieldTypeOrder = []FieldType{
+	FieldTypePrimitive,
+	FieldTypeStruct,
+	FieldTypePointer,
+	FieldTypeSlice,
+	FieldTypeMap,
+	FieldTypeChannel,
+	FieldTypeInterface,
+	FieldTypeFunction,
+	FieldTypeEmbedded,
+}
+
+// CalculateFieldTypePercentages converts per-category field counts into percentages (0-100)
+// of the total. Only categories with a non-zero count appear in the result.
+func CalculateFieldTypePercentages(counts map[FieldType]int) map[FieldType]float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	percentages := make(map[FieldType]float64, len(counts))
+	if total == 0 {
+		return percentages
+	}
+	for fieldType, count := range counts {
+		if count > 0 {
+			percentages[fieldType] = float64(count) / float64(total) * 100
+		}
+	}
+	return percentages
+}
+
+// AggregateFieldTypes sums field categories across structs, skipping those declared in
+// test files, and computes the codebase-wide percentage of each category.
+func AggregateFieldTypes(structs []StructMetrics) FieldTypeDistribution {
+	distribution := FieldTypeDistribution{Counts: make(map[FieldType]int)}
+	for _, s := range structs {
+		if s.IsTestFile {
+			continue
+		}
+		for fieldType, count := range s.FieldsByType {
+			distribution.Counts[fieldType] += count
+			distribution.TotalFields += count
+		}
+	}
+	distribution.Percentages = CalculateFieldTypePercentages(distribution.Counts)
+	return distribution
+}
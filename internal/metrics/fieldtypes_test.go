@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateFieldTypePercentages(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[FieldType]int
+		want   map[FieldType]float64
+	}{
+		{
+			name:   "empty",
+			counts: map[FieldType]int{},
+			want:   map[FieldType]float64{},
+		},
+		{
+			name:   "mixed",
+			counts: map[FieldType]int{FieldTypePrimitive: 3, FieldTypePointer: 1},
+			want:   map[FieldType]float64{FieldTypePrimitive: 75, FieldTypePointer: 25},
+		},
+		{
+			name:   "zero counts omitted",
+			counts: map[FieldType]int{FieldTypeSlice: 2, FieldTypeMap: 0},
+			want:   map[FieldType]float64{FieldTypeSlice: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateFieldTypePercentages(tt.counts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d categories, got %d: %v", len(tt.want), len(got), got)
+			}
+			for fieldType, want := range tt.want {
+				if math.Abs(got[fieldType]-want) > 0.001 {
+					t.Errorf("expected %s = %.2f%%, got %.2f%%", fieldType, want, got[fieldType])
+				}
+			}
+		})
+	}
+}
+
+func TestAggregateFieldTypes(t *testing.T) {
+	structs := []StructMetrics{
+		{Name: "A", FieldsByType: map[FieldType]int{FieldTypePrimitive: 2, FieldTypeSlice: 1}},
+		{Name: "B", FieldsByType: map[FieldType]int{FieldTypePrimitive: 1, FieldTypeInterface: 1}},
+		{Name: "Fixture", IsTestFile: true, FieldsByType: map[FieldType]int{FieldTypeMap: 10}},
+	}
+
+	dist := AggregateFieldTypes(structs)
+
+	if dist.TotalFields != 5 {
+		t.Fatalf("expected 5 production fields, got %d", dist.TotalFields)
+	}
+	if dist.Counts[FieldTypeMap] != 0 {
+		t.Errorf("expected test-file struct fields to be excluded, got %d map fields", dist.Counts[FieldTypeMap])
+	}
+	if math.Abs(dist.Percentages[FieldTypePrimitive]-60) > 0.001 {
+		t.Errorf("expected primitives to be 60%%, got %.2f%%", dist.Percentages[FieldTypePrimitive])
+	}
+	if math.Abs(dist.Percentages[FieldTypeInterface]-20) > 0.001 {
+		t.Errorf("expected interfaces to be 20%%, got %.2f%%", dist.Percentages[FieldTypeInterface])
+	}
+}
@@ -0,0 +1,326 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Finding categories used by AllFindings
+const (
+	FindingCategoryAntiPattern   = "anti-pattern"
+	FindingCategoryConcurrency   = "concurrency"
+	FindingCategoryBurden        = "burden"
+	FindingCategoryComplexity    = "complexity"
+	FindingCategoryNaming        = "naming"
+	FindingCategoryPlacement     = "placement"
+	FindingCategoryDocumentation = "documentation"
+	FindingCategoryOrganization  = "organization"
+	FindingCategoryInterface     = "interface"
+	FindingCategoryPerformance   = "performance"
+	FindingCategoryErrorHandling = "error-handling"
+)
+
+// Finding is a single warning in a uniform shape, independent of the analyzer that produced it.
+// RuleID is "<category>/<kind>" and is stable across runs, so integrations can key on it.
+// Function is set when the warning is about, or found inside, a function.
+type Finding struct {
+	RuleID     string        `json:"rule_id"`
+	Category   string        `json:"category"`
+	Severity   SeverityLevel `json:"severity"`
+	File       string        `json:"file"`
+	Line       int           `json:"line"`
+	Function   string        `json:"function,omitempty"`
+	Message    string        `json:"message"`
+	Suggestion string        `json:"suggestion,omitempty"`
+}
+
+// AllFindings flattens every warning kind in the report into a single list of Findings:
+// anti-patterns, concurrency warnings, maintenance burden issues, test complexity breaches,
+// naming and placement violations, documentation annotations, organization issues, oversized
+// interface methods, hot-path allocation warnings, and functions returning bare errors. Findings
+// are grouped by category in the order listed above.
+func (r *Report) AllFindings() []Finding {
+	findings := make([]Finding, 0)
+	findings = r.appendAntiPatternFindings(findings)
+	findings = r.appendConcurrencyFindings(findings)
+	findings = r.appendBurdenFindings(findings)
+	findings = r.appendComplexityFindings(findings)
+	findings = r.appendNamingFindings(findings)
+	findings = r.appendPlacementFindings(findings)
+	findings = r.appendDocumentationFindings(findings)
+	findings = r.appendOrganizationFindings(findings)
+	findings = r.appendInterfaceFindings(findings)
+	findings = r.appendPerformanceFindings(findings)
+	findings = r.appendErrorHandlingFindings(findings)
+	return findings
+}
+
+// newFinding builds a Finding, defaulting an empty severity to warning
+func newFinding(category, kind string, severity SeverityLevel, file string, line int, message, suggestion string) Finding {
+	if severity == "" {
+		severity = SeverityLevelWarning
+	}
+	return Finding{
+		RuleID:     category + "/" + kind,
+		Category:   category,
+		Severity:   severity,
+		File:       file,
+		Line:       line,
+		Message:    message,
+		Suggestion: suggestion,
+	}
+}
+
+// inFunction sets the function the finding belongs to
+func (f Finding) inFunction(function string) Finding {
+	f.Function = function
+	return f
+}
+
+// riskLevelSeverity maps the free-form risk levels used by concurrency warnings onto SeverityLevel
+func riskLevelSeverity(risk string) SeverityLevel {
+	switch risk {
+	case "high", "critical":
+		return SeverityLevelViolation
+	case "low":
+		return SeverityLevelInfo
+	default:
+		return SeverityLevelWarning
+	}
+}
+
+// appendAntiPatternFindings converts performance anti-patterns and anti-pattern warnings. Deep
+// nesting, long parameter lists, and magic numbers are skipped: they list the same issues as the
+// burden section, which appendBurdenFindings reports.
+func (r *Report) appendAntiPatternFindings(findings []Finding) []Finding {
+	ap := r.Patterns.AntiPatterns
+	for _, p := range ap.PerformanceAntipatterns {
+		findings = append(findings, newFinding(FindingCategoryAntiPattern, p.Type, p.Severity, p.File, p.Line, p.Description, p.Suggestion))
+	}
+	for _, group := range [][]AntiPatternWarning{ap.GodObjects, ap.LongMethods, ap.NakedReturns} {
+		for _, w := range group {
+			findings = append(findings, newFinding(FindingCategoryAntiPattern, w.Type, w.Severity, w.File, w.Line, w.Description, w.Recommendation).
+				inFunction(w.Function))
+		}
+	}
+	return findings
+}
+
+// appendConcurrencyFindings converts goroutine leak, concurrent map write, unbalanced lock,
+// context propagation, and blocking select warnings
+func (r *Report) appendConcurrencyFindings(findings []Finding) []Finding {
+	concurrency := r.Patterns.ConcurrencyPatterns
+	for _, leak := range concurrency.Goroutines.GoroutineLeaks {
+		findings = append(findings, newFinding(FindingCategoryConcurrency, "goroutine_leak", riskLevelSeverity(leak.RiskLevel),
+			leak.File, leak.Line, leak.Description, leak.Recommendation).inFunction(leak.Function))
+	}
+	for _, race := range concurrency.Goroutines.DataRaces {
+		findings = append(findings, newFinding(FindingCategoryConcurrency, "concurrent_map_write", riskLevelSeverity(race.RiskLevel),
+			race.File, race.Line, race.Description, race.Recommendation).inFunction(race.Function))
+	}
+	for _, w := range concurrency.SyncWarnings {
+		findings = append(findings, newFinding(FindingCategoryConcurrency, w.Type, riskLevelSeverity(w.RiskLevel),
+			w.File, w.Line, w.Description, "").inFunction(w.Function))
+	}
+	for _, w := range concurrency.ContextWarnings {
+		findings = append(findings, newFinding(FindingCategoryConcurrency, w.Type, SeverityLevelWarning,
+			w.File, w.Line, w.Description, "").inFunction(w.Function))
+	}
+	for _, sel := range concurrency.SelectStatements {
+		if sel.Warning != "" {
+			findings = append(findings, newFinding(FindingCategoryConcurrency, "blocking_select", SeverityLevelWarning,
+				sel.File, sel.Line, sel.Warning, "").inFunction(sel.Function))
+		}
+	}
+	return findings
+}
+
+// appendBurdenFindings converts maintenance burden issues
+func (r *Report) appendBurdenFindings(findings []Finding) []Finding {
+	burden := r.Burden
+	for _, m := range burden.MagicNumbers {
+		findings = append(findings, newFinding(FindingCategoryBurden, "magic_number", m.Severity, m.File, m.Line,
+			fmt.Sprintf("Magic %s %s in %s", m.Type, m.Value, m.Function), m.Suggestion).inFunction(m.Function))
+	}
+	for _, s := range burden.DeadCode.UnreferencedFunctions {
+		findings = append(findings, newFinding(FindingCategoryBurden, "unreferenced_symbol", s.Severity, s.File, s.Line,
+			fmt.Sprintf("Unreferenced %s '%s'", s.Type, s.Name), s.Suggestion))
+	}
+	for _, b := range burden.DeadCode.UnreachableCode {
+		findings = append(findings, newFinding(FindingCategoryBurden, "unreachable_code", b.Severity, b.File, b.StartLine,
+			fmt.Sprintf("Unreachable code in %s after %s", b.Function, b.Reason), b.Suggestion).inFunction(b.Function))
+	}
+	for _, s := range burden.ComplexSignatures {
+		findings = append(findings, newFinding(FindingCategoryBurden, "complex_signature", s.Severity, s.File, s.Line,
+			fmt.Sprintf("Function '%s' has %d parameters and %d returns", s.Function, s.ParameterCount, s.ReturnCount), s.Suggestion).inFunction(s.Function))
+	}
+	for _, n := range burden.DeeplyNestedFunctions {
+		findings = append(findings, newFinding(FindingCategoryBurden, "deep_nesting", n.Severity, n.File, n.Line,
+			fmt.Sprintf("Function '%s' nests %d levels deep", n.Function, n.MaxDepth), n.Suggestion).inFunction(n.Function))
+	}
+	for _, e := range burden.FeatureEnvyMethods {
+		findings = append(findings, newFinding(FindingCategoryBurden, "feature_envy", e.Severity, e.File, e.Line,
+			fmt.Sprintf("Method '%s' references %s more than its receiver %s", e.Method, e.ExternalType, e.ReceiverType), e.SuggestedMove).inFunction(e.Method))
+	}
+	for _, t := range burden.ComplexTypeExprs {
+		findings = append(findings, newFinding(FindingCategoryBurden, "deep_type_expression", t.Severity, t.File, t.Line,
+			fmt.Sprintf("The %s type %s of '%s' nests %d levels deep", t.Position, t.TypeExpr, t.Function, t.Depth), t.Suggestion).inFunction(t.Function))
+	}
+	for _, c := range burden.LongMethodChains {
+		findings = append(findings, newFinding(FindingCategoryBurden, "long_method_chain", c.Severity, c.File, c.Line,
+			fmt.Sprintf("Function '%s' chains %d method calls", c.Function, c.Depth), c.Suggestion).inFunction(c.Function))
+	}
+	for _, u := range burden.UnimplementedInterfaces {
+		findings = append(findings, newFinding(FindingCategoryBurden, "unimplemented_interface", u.Severity, u.File, u.Line,
+			fmt.Sprintf("Exported interface '%s' has no implementation in the analyzed code", u.Name), u.Suggestion))
+	}
+	return findings
+}
+
+// appendComplexityFindings converts test functions over the test complexity threshold
+func (r *Report) appendComplexityFindings(findings []Finding) []Finding {
+	for _, item := range r.Complexity.TestFunctions.OverThreshold {
+		findings = append(findings, newFinding(FindingCategoryComplexity, "test_complexity", item.Severity, item.File, item.Line,
+			fmt.Sprintf("Test '%s' has cyclomatic complexity %.0f (threshold %d)", item.Name, item.Complexity, r.Complexity.TestFunctions.Threshold),
+			item.Suggestion).inFunction(item.Name))
+	}
+	return findings
+}
+
+// appendNamingFindings converts file, identifier, and package naming violations
+func (r *Report) appendNamingFindings(findings []Finding) []Finding {
+	for _, v := range r.Naming.FileNameIssues {
+		findings = append(findings, newFinding(FindingCategoryNaming, v.ViolationType, v.Severity, v.File, 0, v.Description,
+			suggestRename(v.SuggestedName)))
+	}
+	for _, v := range r.Naming.IdentifierIssues {
+		findings = append(findings, newFinding(FindingCategoryNaming, v.ViolationType, v.Severity, v.File, v.Line, v.Description,
+			suggestRename(v.SuggestedName)))
+	}
+	for _, v := range r.Naming.PackageNameIssues {
+		findings = append(findings, newFinding(FindingCategoryNaming, v.ViolationType, v.Severity, v.Directory, 0, v.Description,
+			suggestRename(v.SuggestedName)))
+	}
+	return findings
+}
+
+// suggestRename formats a suggested name as a suggestion, or returns "" when there is none
+func suggestRename(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("Rename to '%s'", name)
+}
+
+// appendPlacementFindings converts misplaced declarations and low-cohesion files
+func (r *Report) appendPlacementFindings(findings []Finding) []Finding {
+	for _, p := range r.Placement.FunctionIssues {
+		findings = append(findings, newFinding(FindingCategoryPlacement, "misplaced_function", p.Severity, p.CurrentFile, 0,
+			fmt.Sprintf("Function '%s' has higher affinity with %s", p.Name, p.SuggestedFile), p.Suggestion))
+	}
+	for _, p := range r.Placement.MethodIssues {
+		findings = append(findings, newFinding(FindingCategoryPlacement, "misplaced_method", p.Severity, p.CurrentFile, 0,
+			fmt.Sprintf("Method '%s' is declared away from its receiver %s in %s", p.MethodName, p.ReceiverType, p.ReceiverFile), p.Suggestion))
+	}
+	for _, c := range r.Placement.CohesionIssues {
+		findings = append(findings, newFinding(FindingCategoryPlacement, "low_cohesion", c.Severity, c.File, 0,
+			fmt.Sprintf("File cohesion score %.2f is low", c.CohesionScore), c.Suggestion))
+	}
+	return findings
+}
+
+// appendDocumentationFindings converts TODO, FIXME, HACK, BUG, XXX, and DEPRECATED annotations
+func (r *Report) appendDocumentationFindings(findings []Finding) []Finding {
+	doc := r.Documentation
+	for _, c := range doc.TODOComments {
+		findings = append(findings, newFinding(FindingCategoryDocumentation, "todo", SeverityLevelInfo, c.File, c.Line, c.Description, ""))
+	}
+	for _, c := range doc.FIXMEComments {
+		findings = append(findings, newFinding(FindingCategoryDocumentation, "fixme", c.Severity, c.File, c.Line, c.Description, ""))
+	}
+	for _, c := range doc.HACKComments {
+		findings = append(findings, newFinding(FindingCategoryDocumentation, "hack", SeverityLevelWarning, c.File, c.Line, c.Description, ""))
+	}
+	for _, c := range doc.BUGComments {
+		findings = append(findings, newFinding(FindingCategoryDocumentation, "bug", c.Severity, c.File, c.Line, c.Description, ""))
+	}
+	for _, c := range doc.XXXComments {
+		findings = append(findings, newFinding(FindingCategoryDocumentation, "xxx", SeverityLevelWarning, c.File, c.Line, c.Description, ""))
+	}
+	for _, c := range doc.DEPRECATEDComments {
+		findings = append(findings, newFinding(FindingCategoryDocumentation, "deprecated", SeverityLevelInfo, c.File, c.Line, c.Description,
+			c.Alternative))
+	}
+	return findings
+}
+
+// appendOrganizationFindings converts oversized files and packages and deep directories
+func (r *Report) appendOrganizationFindings(findings []Finding) []Finding {
+	for _, f := range r.Organization.OversizedFiles {
+		findings = append(findings, newFinding(FindingCategoryOrganization, "oversized_file", f.Severity, f.File, 0,
+			fmt.Sprintf("File has %d code lines, %d functions and %d types", f.Lines.Code, f.FunctionCount, f.TypeCount), firstSuggestion(f.Suggestions)))
+	}
+	for _, p := range r.Organization.OversizedPackages {
+		findings = append(findings, newFinding(FindingCategoryOrganization, "oversized_package", p.Severity, p.Package, 0,
+			fmt.Sprintf("Package '%s' has %d files and %d exported symbols", p.Package, p.FileCount, p.ExportedSymbols), firstSuggestion(p.Suggestions)))
+	}
+	for _, d := range r.Organization.DeepDirectories {
+		findings = append(findings, newFinding(FindingCategoryOrganization, "deep_directory", d.Severity, d.Path, 0,
+			fmt.Sprintf("Directory is nested %d levels deep", d.Depth), d.Suggestion))
+	}
+	return findings
+}
+
+// firstSuggestion returns the first entry of suggestions, or "" when empty
+func firstSuggestion(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return suggestions[0]
+}
+
+// appendInterfaceFindings converts interface methods with oversized signatures and
+// mismatched compile-time interface assertions
+func (r *Report) appendInterfaceFindings(findings []Finding) []Finding {
+	for _, iface := range r.Interfaces {
+		for _, m := range iface.OversizedMethods {
+			findings = append(findings, newFinding(FindingCategoryInterface, "oversized_method", m.Severity, m.File, m.Line,
+				fmt.Sprintf("Interface method '%s' has %d parameters and %d returns", m.Function, m.ParameterCount, m.ReturnCount), m.Suggestion))
+		}
+	}
+	for _, a := range r.InterfaceAssertions.Assertions {
+		if a.Status != AssertionMismatch {
+			continue
+		}
+		findings = append(findings, newFinding(FindingCategoryInterface, "assertion_mismatch", SeverityLevelWarning, a.File, a.Line,
+			fmt.Sprintf("Type '%s' is asserted to implement '%s' but lacks %s", a.Type, a.Interface, strings.Join(a.MissingMethods, ", ")),
+			"Add the missing methods or fix the receiver type of the assertion"))
+	}
+	return findings
+}
+
+// appendPerformanceFindings converts hot-path allocation warnings, present only when
+// analysis.include_performance is on
+func (r *Report) appendPerformanceFindings(findings []Finding) []Finding {
+	if r.Performance == nil {
+		return findings
+	}
+	for _, w := range r.Performance.Warnings {
+		findings = append(findings, newFinding(FindingCategoryPerformance, w.Type, w.Severity, w.File, w.Line, w.Description, w.Suggestion).
+			inFunction(w.Function))
+	}
+	return findings
+}
+
+// appendErrorHandlingFindings adds the functions that return bare errors from several places
+func (r *Report) appendErrorHandlingFindings(findings []Finding) []Finding {
+	if r.ErrorHandling == nil {
+		return findings
+	}
+	for _, fn := range r.ErrorHandling.BareReturnFunctions {
+		msg := fmt.Sprintf("Function '%s' returns errors without context at %d places", fn.Name, fn.BareReturns)
+		findings = append(findings, newFinding(FindingCategoryErrorHandling, "bare_error_returns", fn.Severity, fn.File, fn.Line, msg, fn.Suggestion).
+			inFunction(fn.Name))
+	}
+	return findings
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"testing"
+)
+
+func TestReport_AllFindings(t *testing.T) {
+	report := &Report{}
+	report.Patterns.AntiPatterns.PerformanceAntipatterns = []PerformanceAntipattern{
+		{Type: "string_concat_in_loop", Description: "String concatenation in loop", Severity: SeverityLevelWarning,
+			File: "a.go", Line: 10, Suggestion: "Use strings.Builder"},
+	}
+	report.Patterns.ConcurrencyPatterns.Goroutines.DataRaces = []DataRaceWarning{
+		{File: "b.go", Line: 20, Variable: "cache", RiskLevel: "high", Description: "Map write", Recommendation: "Use a mutex"},
+	}
+	report.Burden.DeeplyNestedFunctions = []NestingIssue{
+		{Function: "Walk", File: "c.go", Line: 30, MaxDepth: 6, Severity: SeverityLevelViolation, Suggestion: "Extract"},
+	}
+	report.Naming.IdentifierIssues = []IdentifierViolation{
+		{Name: "get_value", File: "d.go", Line: 40, ViolationType: "snake_case", Description: "Use MixedCaps",
+			SuggestedName: "getValue", Severity: SeverityLevelWarning},
+	}
+	report.Documentation.FIXMEComments = []FIXMEComment{
+		{File: "e.go", Line: 50, Description: "handle overflow"},
+	}
+
+	findings := report.AllFindings()
+	if len(findings) != 5 {
+		t.Fatalf("expected 5 findings, got %d: %+v", len(findings), findings)
+	}
+
+	expected := []Finding{
+		{RuleID: "anti-pattern/string_concat_in_loop", Category: FindingCategoryAntiPattern, Severity: SeverityLevelWarning,
+			File: "a.go", Line: 10, Message: "String concatenation in loop", Suggestion: "Use strings.Builder"},
+		{RuleID: "concurrency/concurrent_map_write", Category: FindingCategoryConcurrency, Severity: SeverityLevelViolation,
+			File: "b.go", Line: 20, Message: "Map write", Suggestion: "Use a mutex"},
+		{RuleID: "burden/deep_nesting", Category: FindingCategoryBurden, Severity: SeverityLevelViolation,
+			File: "c.go", Line: 30, Function: "Walk", Message: "Function 'Walk' nests 6 levels deep", Suggestion: "Extract"},
+		{RuleID: "naming/snake_case", Category: FindingCategoryNaming, Severity: SeverityLevelWarning,
+			File: "d.go", Line: 40, Message: "Use MixedCaps", Suggestion: "Rename to 'getValue'"},
+		{RuleID: "documentation/fixme", Category: FindingCategoryDocumentation, Severity: SeverityLevelWarning,
+			File: "e.go", Line: 50, Message: "handle overflow"},
+	}
+
+	for i, want := range expected {
+		if findings[i] != want {
+			t.Errorf("finding %d:\n got  %+v\n want %+v", i, findings[i], want)
+		}
+	}
+}
+
+func TestReport_AllFindings_Concurrency(t *testing.T) {
+	report := &Report{}
+	concurrency := &report.Patterns.ConcurrencyPatterns
+	concurrency.Goroutines.GoroutineLeaks = []GoroutineLeakWarning{
+		{File: "a.go", Line: 3, Function: "start", RiskLevel: "medium", Description: "No exit path"},
+	}
+	concurrency.SyncWarnings = []SyncWarning{
+		{File: "a.go", Line: 8, Function: "Put", Type: "missing_unlock", RiskLevel: "high", Description: "Lock without Unlock"},
+	}
+	concurrency.ContextWarnings = []ContextWarning{
+		{File: "b.go", Line: 12, Function: "Fetch", Type: "missing_context", Description: "Starts goroutines without a context"},
+	}
+	concurrency.SelectStatements = []SelectInstance{
+		{File: "c.go", Line: 4, Function: "wait", Warning: "empty select blocks forever"},
+		{File: "c.go", Line: 9, Function: "loop", CaseCount: 2},
+	}
+
+	findings := report.AllFindings()
+
+	expected := []Finding{
+		{RuleID: "concurrency/goroutine_leak", Category: FindingCategoryConcurrency, Severity: SeverityLevelWarning,
+			File: "a.go", Line: 3, Function: "start", Message: "No exit path"},
+		{RuleID: "concurrency/missing_unlock", Category: FindingCategoryConcurrency, Severity: SeverityLevelViolation,
+			File: "a.go", Line: 8, Function: "Put", Message: "Lock without Unlock"},
+		{RuleID: "concurrency/missing_context", Category: FindingCategoryConcurrency, Severity: SeverityLevelWarning,
+			File: "b.go", Line: 12, Function: "Fetch", Message: "Starts goroutines without a context"},
+		{RuleID: "concurrency/blocking_select", Category: FindingCategoryConcurrency, Severity: SeverityLevelWarning,
+			File: "c.go", Line: 4, Function: "wait", Message: "empty select blocks forever"},
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("expected %d findings, got %d: %+v", len(expected), len(findings), findings)
+	}
+	for i, want := range expected {
+		if findings[i] != want {
+			t.Errorf("finding %d:\n got  %+v\n want %+v", i, findings[i], want)
+		}
+	}
+}
+
+func TestReport_AllFindings_ErrorHandling(t *testing.T) {
+	report := &Report{ErrorHandling: &ErrorHandlingMetrics{
+		BareReturnFunctions: []BareErrorFunction{
+			{Name: "Copy", File: "copy.go", Line: 20, BareReturns: 3, Severity: SeverityLevelWarning, Suggestion: "Wrap the errors"},
+		},
+	}}
+
+	findings := report.AllFindings()
+
+	want := Finding{RuleID: "error-handling/bare_error_returns", Category: FindingCategoryErrorHandling, Severity: SeverityLevelWarning,
+		File: "copy.go", Line: 20, Function: "Copy", Message: "Function 'Copy' returns errors without context at 3 places",
+		Suggestion: "Wrap the errors"}
+	if len(findings) != 1 || findings[0] != want {
+		t.Errorf("got %+v, want [%+v]", findings, want)
+	}
+}
+
+func TestReport_AllFindings_Empty(t *testing.T) {
+	report := &Report{}
+	findings := report.AllFindings()
+	if findings == nil || len(findings) != 0 {
+		t.Errorf("expected empty non-nil findings, got %v", findings)
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+// Function parameter categories recorded in FunctionSignature.ParamTypes
+const (
+	ParamTypePrimitive     = "primitive"
+	ParamTypeNamed         = "named"
+	ParamTypePointer       = "pointer"
+	ParamTypeSlice         = "slice"
+	ParamTypeMap           = "map"
+	ParamTypeChannel       = "channel"
+	ParamTypeInterface     = "interface"
+	ParamTypeFunc          = "func"
+	ParamTypeVariadic      = "variadic"
+	ParamTypeTypeParameter = "type_parameter"
+)
+
+// ParamTypeOrder lists every parameter category in the order reporters display them
+var ParamTypeOrder = []string{
+	ParamTypePrimitive,
+	ParamTypeNamed,
+	ParamTypePointer,
+	ParamTypeSlice,
+	ParamTypeMap,
+	ParamTypeChannel,
+	ParamTypeInterface,
+	ParamTypeFunc,
+	ParamTypeVariadic,
+	ParamTypeTypeParameter,
+}
+
+// AggregateParamTypes sums parameter categories across functions, skipping those declared in
+// test files, and computes the codebase-wide percentage of each category.
+func AggregateParamTypes(functions []FunctionMetrics) ParamTypeDistribution {
+	distribution := ParamTypeDistribution{
+		Counts:      make(map[string]int),
+		Percentages: make(map[string]float64),
+	}
+	for _, fn := range functions {
+		if fn.IsTestFile {
+			continue
+		}
+		for paramType, count := range fn.Signature.ParamTypes {
+			distribution.Counts[paramType] += count
+			distribution.TotalParams += count
+		}
+	}
+	if distribution.TotalParams == 0 {
+		return distribution
+	}
+	for paramType, count := range distribution.Counts {
+		distribution.Percentages[paramType] = float64(count) / float64(distribution.TotalParams) * 100
+	}
+	return distribution
+}
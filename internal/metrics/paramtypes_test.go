@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAggregateParamTypes(t *testing.T) {
+	functions := []FunctionMetrics{
+		{Name: "Load", Signature: FunctionSignature{ParamTypes: map[string]int{ParamTypeNamed: 1, ParamTypeSlice: 2}}},
+		{Name: "Save", Signature: FunctionSignature{ParamTypes: map[string]int{ParamTypeNamed: 1}}},
+		{Name: "Run"},
+		{Name: "TestLoad", IsTestFile: true, Signature: FunctionSignature{ParamTypes: map[string]int{ParamTypePointer: 1}}},
+	}
+
+	dist := AggregateParamTypes(functions)
+
+	if dist.TotalParams != 4 {
+		t.Fatalf("expected 4 production parameters, got %d", dist.TotalParams)
+	}
+	if dist.Counts[ParamTypePointer] != 0 {
+		t.Errorf("expected test-file parameters to be excluded, got %d pointers", dist.Counts[ParamTypePointer])
+	}
+	if math.Abs(dist.Percentages[ParamTypeNamed]-50) > 0.001 {
+		t.Errorf("expected named types to be 50%%, got %.2f%%", dist.Percentages[ParamTypeNamed])
+	}
+	if math.Abs(dist.Percentages[ParamTypeSlice]-50) > 0.001 {
+		t.Errorf("expected slices to be 50%%, got %.2f%%", dist.Percentages[ParamTypeSlice])
+	}
+}
@@ -1,32 +1,62 @@
 package metrics
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // Report represents the complete analysis report for a repository
 type Report struct {
-	Metadata             ReportMetadata       `json:"metadata"`
-	Overview             OverviewMetrics      `json:"overview"`
-	Functions            []FunctionMetrics    `json:"functions"`
-	Structs              []StructMetrics      `json:"structs"`
-	Interfaces           []InterfaceMetrics   `json:"interfaces"`
-	Packages             []PackageMetrics     `json:"packages"`
-	CircularDependencies []CircularDependency `json:"circular_dependencies"`
-	Patterns             PatternMetrics       `json:"patterns"`
-	Complexity           ComplexityMetrics    `json:"complexity"`
-	Documentation        DocumentationMetrics `json:"documentation"`
-	Generics             GenericMetrics       `json:"generics"`
-	Duplication          DuplicationMetrics   `json:"duplication"`
-	Naming               NamingMetrics        `json:"naming"`
-	Placement            PlacementMetrics     `json:"placement"`
-	Organization         OrganizationMetrics  `json:"organization"`
-	Burden               BurdenMetrics        `json:"burden"`
-	Scores               ScoringMetrics       `json:"scores"`
-	TestCoverage         TestCoverageMetrics  `json:"test_coverage,omitempty"`
-	TestQuality          TestQualityMetrics   `json:"test_quality,omitempty"`
-	Team                 *TeamMetrics         `json:"team,omitempty"`
-	Suggestions          []SuggestionInfo     `json:"suggestions,omitempty"`
+	Metadata             ReportMetadata        `json:"metadata"`
+	Overview             OverviewMetrics       `json:"overview"`
+	Summary              ReportSummary         `json:"summary"`
+	Functions            []FunctionMetrics     `json:"functions"`
+	Structs              []StructMetrics       `json:"structs"`
+	Interfaces           []InterfaceMetrics    `json:"interfaces"`
+	Packages             []PackageMetrics      `json:"packages"`
+	CircularDependencies []CircularDependency  `json:"circular_dependencies"`
+	Patterns             PatternMetrics        `json:"patterns"`
+	Complexity           ComplexityMetrics     `json:"complexity"`
+	Documentation        DocumentationMetrics  `json:"documentation"`
+	Generics             GenericMetrics        `json:"generics"`
+	Duplication          DuplicationMetrics    `json:"duplication"`
+	Naming               NamingMetrics         `json:"naming"`
+	Placement            PlacementMetrics      `json:"placement"`
+	Organization         OrganizationMetrics   `json:"organization"`
+	Burden               BurdenMetrics         `json:"burden"`
+	Scores               ScoringMetrics        `json:"scores"`
+	TestCoverage         TestCoverageMetrics   `json:"test_coverage,omitempty"`
+	TestQuality          TestQualityMetrics    `json:"test_quality,omitempty"`
+	TestPresence         *TestPresenceMetrics  `json:"test_presence,omitempty"`
+	ErrorHandling        *ErrorHandlingMetrics `json:"error_handling,omitempty"`
+	Team                 *TeamMetrics          `json:"team,omitempty"`
+	Performance          *PerformanceMetrics   `json:"performance,omitempty"`
+	Suggestions          []SuggestionInfo      `json:"suggestions,omitempty"`
+	// Tree rolls the metrics up per directory and file, for treemaps and drilling into hotspots
+	Tree *TreeNode `json:"tree,omitempty"`
+
+	// Extensions holds the results of registered third-party file analyzers, keyed by analyzer name
+	Extensions map[string][]ExtensionResult `json:"extensions,omitempty"`
+
+	// FieldTypes is the codebase-wide distribution of struct field categories
+	FieldTypes FieldTypeDistribution `json:"field_type_distribution"`
+
+	// ParamTypes is the codebase-wide distribution of function parameter categories
+	ParamTypes ParamTypeDistribution `json:"parameter_type_distribution"`
+
+	// StructBalance summarizes data-versus-behavior balance across production structs
+	StructBalance StructBalanceSummary `json:"struct_balance"`
+
+	// InterfaceAssertions counts and verifies var _ Iface = (*T)(nil) declarations
+	InterfaceAssertions InterfaceAssertionMetrics `json:"interface_assertions"`
+}
+
+// ExtensionResult is what a registered file analyzer contributed for one file. Data is the
+// analyzer's JSON-encoded result, so it survives the analysis cache and report files unchanged.
+type ExtensionResult struct {
+	File    string          `json:"file"`
+	Package string          `json:"package"`
+	Data    json.RawMessage `json:"data"`
 }
 
 // ReportMetadata contains information about the analysis run
@@ -35,8 +65,83 @@ type ReportMetadata struct {
 	GeneratedAt    time.Time     `json:"generated_at"`
 	AnalysisTime   time.Duration `json:"analysis_time"`
 	FilesProcessed int           `json:"files_processed"`
-	ToolVersion    string        `json:"tool_version"`
-	GoVersion      string        `json:"go_version"`
+	BytesProcessed int64         `json:"bytes_processed"`
+	// CachedFiles counts the processed files whose results were reused from the analysis cache
+	CachedFiles int         `json:"cached_files,omitempty"`
+	ToolVersion string      `json:"tool_version"`
+	GoVersion   string      `json:"go_version"`
+	Module      *ModuleInfo `json:"module,omitempty"`
+	// ContentHash fingerprints the analysis results, ignoring timestamps and durations
+	ContentHash string `json:"content_hash,omitempty"`
+	// MinimumGoVersion is the Go version the analyzed code needs, inferred from the features it uses
+	MinimumGoVersion *GoVersionRequirement `json:"minimum_go_version,omitempty"`
+}
+
+// GoVersionRequirement is the minimum Go version inferred from the version-gated language
+// features and standard library APIs the analyzed code uses. The detection is syntactic, so
+// it is a lower bound: features it cannot see without type information may raise it.
+type GoVersionRequirement struct {
+	// MinimumVersion is a version such as "1.21", or empty when no version-gated feature is used
+	MinimumVersion string `json:"minimum_version"`
+	// Features lists the detected features from the newest version, the first ones driving
+	// MinimumVersion
+	Features []GoVersionFeature `json:"features"`
+}
+
+// GoVersionFeature is one version-gated feature, its uses, and where it is first used
+type GoVersionFeature struct {
+	Feature     string `json:"feature"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Count       int    `json:"count"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+}
+
+// ModuleInfo holds the parsed contents of the analyzed module's go.mod file
+type ModuleInfo struct {
+	Path      string              `json:"path"`
+	GoVersion string              `json:"go_version,omitempty"`
+	GoModPath string              `json:"go_mod_path,omitempty"`
+	Requires  []ModuleRequirement `json:"requires,omitempty"`
+	Replaces  []ModuleReplacement `json:"replaces,omitempty"`
+	// NestedModules lists the modules rooted below this module's directory; their packages
+	// are not part of this module even when their import paths share its prefix
+	NestedModules []NestedModule `json:"nested_modules,omitempty"`
+}
+
+// Clone returns a copy of m that shares no slices with it, or nil when m is nil
+func (m *ModuleInfo) Clone() *ModuleInfo {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.Requires = append([]ModuleRequirement(nil), m.Requires...)
+	c.Replaces = append([]ModuleReplacement(nil), m.Replaces...)
+	c.NestedModules = append([]NestedModule(nil), m.NestedModules...)
+	return &c
+}
+
+// NestedModule is a module inside another module's directory tree, found through its own
+// go.mod file or a replace directive pointing at a local directory
+type NestedModule struct {
+	Path string `json:"path"`
+	Dir  string `json:"dir"`
+}
+
+// ModuleRequirement represents a single require directive in go.mod
+type ModuleRequirement struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Indirect bool   `json:"indirect,omitempty"`
+}
+
+// ModuleReplacement represents a single replace directive in go.mod
+type ModuleReplacement struct {
+	OldPath    string `json:"old_path"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewPath    string `json:"new_path"`
+	NewVersion string `json:"new_version,omitempty"`
 }
 
 // OverviewMetrics provides high-level statistics for total lines, functions, and structural elements.
@@ -50,6 +155,19 @@ type OverviewMetrics struct {
 	TotalFiles       int `json:"total_files"`
 }
 
+// ReportSummary counts the most severe issues in the report and grades overall health, giving
+// automation a single decision input
+type ReportSummary struct {
+	LongFunctions           int     `json:"long_functions"`
+	HighComplexityFunctions int     `json:"high_complexity_functions"`
+	GodObjects              int     `json:"god_objects"`
+	UndocumentedExported    int     `json:"undocumented_exported"`
+	GoroutineLeaks          int     `json:"goroutine_leaks"`
+	CriticalIssues          int     `json:"critical_issues"`
+	HealthScore             float64 `json:"health_score"`
+	Grade                   string  `json:"grade"`
+}
+
 // LineMetrics represents line counting information for total, code, comments, and blank lines.
 type LineMetrics struct {
 	Total    int `json:"total"`
@@ -66,13 +184,37 @@ type FunctionMetrics struct {
 	Line          int               `json:"line"`
 	IsExported    bool              `json:"is_exported"`
 	IsMethod      bool              `json:"is_method"`
+	IsTestFile    bool              `json:"is_test_file,omitempty"`
 	ReceiverType  string            `json:"receiver_type,omitempty"`
 	Lines         LineMetrics       `json:"lines"`
 	Signature     FunctionSignature `json:"signature"`
+	ParamRatio    float64           `json:"parameter_body_ratio"`
+	Shape         FunctionShape     `json:"shape"`
+	NonReturning  NonReturningKind  `json:"non_returning,omitempty"`
+	Suppressed    bool              `json:"suppressed,omitempty"`
 	Complexity    ComplexityScore   `json:"complexity"`
 	Documentation DocumentationInfo `json:"documentation"`
 }
 
+// NonReturningKind classifies a function whose body can never return to its caller
+type NonReturningKind string
+
+const (
+	// NonReturningRunLoop marks an expected run-loop such as main or a server's serve loop
+	NonReturningRunLoop NonReturningKind = "run_loop"
+	// NonReturningUnexpected marks any other function that never returns, which may hang its caller
+	NonReturningUnexpected NonReturningKind = "never_returns"
+)
+
+// FunctionShape classifies a function by its parameter count relative to its body length
+type FunctionShape string
+
+const (
+	FunctionShapeThinWrapper    FunctionShape = "thin_wrapper"
+	FunctionShapeNormal         FunctionShape = "normal"
+	FunctionShapeParameterHeavy FunctionShape = "parameter_heavy"
+)
+
 // FunctionSignature represents function signature complexity including parameters, returns, and generic constraints.
 type FunctionSignature struct {
 	ParameterCount  int            `json:"parameter_count"`
@@ -80,6 +222,7 @@ type FunctionSignature struct {
 	VariadicUsage   bool           `json:"has_variadic"`
 	ErrorReturn     bool           `json:"returns_error"`
 	InterfaceParams int            `json:"interface_parameters"`
+	ParamTypes      map[string]int `json:"parameter_types,omitempty"`
 	GenericParams   []GenericParam `json:"generic_parameters"`
 	ComplexityScore float64        `json:"signature_complexity"`
 }
@@ -108,18 +251,51 @@ type DocumentationInfo struct {
 
 // StructMetrics contains detailed struct analysis including fields, embedded types, methods, and complexity.
 type StructMetrics struct {
-	Name          string            `json:"name"`
-	Package       string            `json:"package"`
-	File          string            `json:"file"`
-	Line          int               `json:"line"`
-	IsExported    bool              `json:"is_exported"`
-	TotalFields   int               `json:"total_fields"`
-	FieldsByType  map[FieldType]int `json:"fields_by_type"`
-	EmbeddedTypes []EmbeddedType    `json:"embedded_types"`
-	Methods       []MethodInfo      `json:"methods"`
-	Tags          map[string]int    `json:"tag_usage"`
-	Complexity    ComplexityScore   `json:"complexity"`
-	Documentation DocumentationInfo `json:"documentation"`
+	Name                 string                `json:"name"`
+	Package              string                `json:"package"`
+	File                 string                `json:"file"`
+	Line                 int                   `json:"line"`
+	IsExported           bool                  `json:"is_exported"`
+	IsTestFile           bool                  `json:"is_test_file,omitempty"`
+	TotalFields          int                   `json:"total_fields"`
+	FieldsByType         map[FieldType]int     `json:"fields_by_type"`
+	FieldTypePercentages map[FieldType]float64 `json:"field_type_percentages"`
+	EmbeddedTypes        []EmbeddedType        `json:"embedded_types"`
+	ChannelFields        []ChannelFieldInfo    `json:"channel_fields,omitempty"`
+	Methods              []MethodInfo          `json:"methods"`
+	// PromotedFields and PromotedMethods count the members reachable through same-package embedded
+	// types; TotalFields and Methods only hold the members the struct declares itself
+	PromotedFields  int               `json:"promoted_fields"`
+	PromotedMethods int               `json:"promoted_methods"`
+	Tags            map[string]int    `json:"tag_usage"`
+	Balance         StructBalance     `json:"balance,omitempty"`
+	Complexity      ComplexityScore   `json:"complexity"`
+	Documentation   DocumentationInfo `json:"documentation"`
+}
+
+// StructBalance classifies a struct by its number of fields (data) relative to its methods (behavior)
+type StructBalance string
+
+const (
+	StructBalanceAnemic       StructBalance = "anemic"
+	StructBalanceBalanced     StructBalance = "balanced"
+	StructBalanceBehaviorRich StructBalance = "behavior_rich"
+)
+
+// StructBalanceSummary counts structs per balance class and keeps the most pronounced examples of each
+type StructBalanceSummary struct {
+	Counts   map[StructBalance]int                    `json:"counts"`
+	Examples map[StructBalance][]StructBalanceExample `json:"examples"`
+}
+
+// StructBalanceExample identifies a struct listed as an example of its balance class
+type StructBalanceExample struct {
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Fields  int    `json:"fields"`
+	Methods int    `json:"methods"`
 }
 
 // FieldType represents the category of a struct field
@@ -137,6 +313,20 @@ const (
 	FieldTypeEmbedded  FieldType = "embedded"
 )
 
+// FieldTypeDistribution aggregates struct field categories across all production structs
+type FieldTypeDistribution struct {
+	TotalFields int                   `json:"total_fields"`
+	Counts      map[FieldType]int     `json:"counts"`
+	Percentages map[FieldType]float64 `json:"percentages"`
+}
+
+// ParamTypeDistribution aggregates function parameter categories across all production functions
+type ParamTypeDistribution struct {
+	TotalParams int                `json:"total_parameters"`
+	Counts      map[string]int     `json:"counts"`
+	Percentages map[string]float64 `json:"percentages"`
+}
+
 // EmbeddedType represents an embedded type in a struct
 type EmbeddedType struct {
 	Name       string `json:"name"`
@@ -145,6 +335,17 @@ type EmbeddedType struct {
 	IsExported bool   `json:"is_exported"`
 }
 
+// ChannelFieldInfo describes a struct field of channel type. Direction uses the same values as
+// ChannelInstance. ElementType is the declared element type, which is itself a channel type for
+// channels of channels; NestingDepth counts the channel levels, 1 for a plain channel.
+type ChannelFieldInfo struct {
+	Name         string `json:"name"`
+	Line         int    `json:"line"`
+	Direction    string `json:"direction"`
+	ElementType  string `json:"element_type"`
+	NestingDepth int    `json:"nesting_depth"`
+}
+
 // MethodInfo represents method information including receiver type, signature, and complexity metrics.
 type MethodInfo struct {
 	Name          string            `json:"name"`
@@ -172,11 +373,76 @@ type InterfaceMetrics struct {
 	EmbeddingDepth      int               `json:"embedding_depth"`
 	ComplexityScore     float64           `json:"complexity_score"`
 	Documentation       DocumentationInfo `json:"documentation"`
+	OversizedMethods    []SignatureIssue  `json:"oversized_methods,omitempty"`
+	AssertionCount      int               `json:"assertion_count,omitempty"`
+	// ImplementationComplexity summarizes how heavy the analyzed struct implementations are; nil
+	// when no implementing struct was found
+	ImplementationComplexity *ImplementationComplexity `json:"implementation_complexity,omitempty"`
+}
+
+// ImplementationComplexity summarizes the size and complexity of the methods that the structs
+// implementing an interface declare for it. A wide spread between implementers, or one heavy
+// implementer, suggests the interface asks for more than a single responsibility.
+type ImplementationComplexity struct {
+	Implementations []ImplementationWeight `json:"implementations"`
+	// AverageMethodLines is the mean of the implementers' MethodLines
+	AverageMethodLines float64 `json:"average_method_lines"`
+	// AverageComplexity is the mean of the implementers' AverageComplexity
+	AverageComplexity float64 `json:"average_complexity"`
+	MaxMethodLines    int     `json:"max_method_lines"`
+	MaxComplexity     float64 `json:"max_complexity"`
+	// ComplexitySpread is the highest minus the lowest AverageComplexity among the implementers
+	ComplexitySpread float64 `json:"complexity_spread"`
+}
+
+// ImplementationWeight measures one struct's implementation of an interface's methods
+type ImplementationWeight struct {
+	Type    string `json:"type"`
+	Package string `json:"package"`
+	File    string `json:"file"`
+	// MethodLines is the total code lines of the struct's methods that implement the interface
+	MethodLines int `json:"method_lines"`
+	// AverageComplexity is the mean overall complexity of those methods
+	AverageComplexity float64 `json:"average_complexity"`
+}
+
+// InterfaceAssertionStatus is the outcome of checking a compile-time interface assertion
+type InterfaceAssertionStatus string
+
+const (
+	// AssertionVerified means the asserted type declares every method of the interface
+	AssertionVerified InterfaceAssertionStatus = "verified"
+	// AssertionMismatch means the asserted type is missing interface methods
+	AssertionMismatch InterfaceAssertionStatus = "mismatch"
+	// AssertionUnverified means the interface or type could not be resolved from the analyzed code
+	AssertionUnverified InterfaceAssertionStatus = "unverified"
+)
+
+// InterfaceAssertion is a compile-time assertion such as var _ Iface = (*T)(nil)
+type InterfaceAssertion struct {
+	Interface      string                   `json:"interface"`
+	Type           string                   `json:"type"`
+	Pointer        bool                     `json:"pointer"`
+	Package        string                   `json:"package"`
+	File           string                   `json:"file"`
+	Line           int                      `json:"line"`
+	Status         InterfaceAssertionStatus `json:"status"`
+	MissingMethods []string                 `json:"missing_methods,omitempty"`
+}
+
+// InterfaceAssertionMetrics counts compile-time interface assertions as a signal of documented intent
+type InterfaceAssertionMetrics struct {
+	Total      int                  `json:"total"`
+	Verified   int                  `json:"verified"`
+	Mismatched int                  `json:"mismatched"`
+	Unverified int                  `json:"unverified"`
+	Assertions []InterfaceAssertion `json:"assertions,omitempty"`
 }
 
 // InterfaceMethod represents a method in an interface
 type InterfaceMethod struct {
 	Name      string            `json:"name"`
+	Line      int               `json:"line,omitempty"`
 	Signature FunctionSignature `json:"signature"`
 }
 
@@ -239,6 +505,10 @@ type ConcurrencyPatternMetrics struct {
 	Goroutines  GoroutineMetrics  `json:"goroutines"`
 	Channels    ChannelMetrics    `json:"channels"`
 	SyncPrims   SyncPrimitives    `json:"sync_primitives"`
+
+	SelectStatements []SelectInstance `json:"select_statements"`
+	SyncWarnings     []SyncWarning    `json:"sync_warnings"`
+	ContextWarnings  []ContextWarning `json:"context_warnings"`
 }
 
 // GoroutineMetrics tracks goroutine usage patterns including total count, anonymous vs named, and leak warnings.
@@ -247,6 +517,7 @@ type GoroutineMetrics struct {
 	AnonymousCount int                    `json:"anonymous_count"`
 	NamedCount     int                    `json:"named_count"`
 	GoroutineLeaks []GoroutineLeakWarning `json:"potential_leaks"`
+	DataRaces      []DataRaceWarning      `json:"potential_data_races"`
 	Instances      []GoroutineInstance    `json:"instances"`
 }
 
@@ -268,6 +539,10 @@ type SyncPrimitives struct {
 	Once       []SyncPrimitiveInstance `json:"once"`
 	Cond       []SyncPrimitiveInstance `json:"cond"`
 	Atomic     []SyncPrimitiveInstance `json:"atomic"`
+	ErrGroups  []SyncPrimitiveInstance `json:"err_groups"`
+	// AtomicOperations counts Atomic usages by operation kind (add, load, store, swap,
+	// compare_and_swap, and, or, and typed for atomic.Int64-style declarations)
+	AtomicOperations map[string]int `json:"atomic_operations,omitempty"`
 }
 
 // GoroutineInstance represents a goroutine usage
@@ -280,6 +555,48 @@ type GoroutineInstance struct {
 	Context     string `json:"context"`
 }
 
+// SelectInstance describes a select statement. CaseCount counts its communication cases, not
+// the default case. HasDefault marks a non-blocking select; HasCancelCase a case receiving from
+// X.Done() and HasTimeoutCase one receiving from time.After, time.Tick or a timer's or ticker's
+// C channel. Warning is set for selects that can never proceed, such as the empty select {}.
+type SelectInstance struct {
+	File           string `json:"file"`
+	Line           int    `json:"line"`
+	Function       string `json:"function"`
+	CaseCount      int    `json:"case_count"`
+	HasDefault     bool   `json:"has_default"`
+	HasCancelCase  bool   `json:"has_cancel_case"`
+	HasTimeoutCase bool   `json:"has_timeout_case"`
+	InLoop         bool   `json:"in_loop"`
+	Warning        string `json:"warning,omitempty"`
+}
+
+// SyncWarning reports a lock call that looks unbalanced within its function. Type is
+// "missing_unlock" or "double_lock". It is advisory: only the function's straight-line
+// statements are followed, so locks handed to callers or released elsewhere can be flagged.
+type SyncWarning struct {
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Function    string `json:"function"`
+	Variable    string `json:"variable"`
+	Type        string `json:"type"`
+	RiskLevel   string `json:"risk_level"`
+	Description string `json:"description"`
+}
+
+// ContextWarning reports a function that does not propagate context.Context idiomatically. Type
+// is "missing_context" for a function that starts goroutines or creates context.Background() or
+// context.TODO() without accepting a context, or "unused_context" for a context parameter that
+// is never used. Parameter names the unused parameter.
+type ContextWarning struct {
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Function    string `json:"function"`
+	Type        string `json:"type"`
+	Parameter   string `json:"parameter,omitempty"`
+	Description string `json:"description"`
+}
+
 // GoroutineLeakWarning represents a potential goroutine leak
 type GoroutineLeakWarning struct {
 	File           string `json:"file"`
@@ -290,19 +607,37 @@ type GoroutineLeakWarning struct {
 	Recommendation string `json:"recommendation"`
 }
 
-// ChannelInstance represents a channel usage
+// DataRaceWarning represents a map written from a goroutine without visible synchronization.
+// It is advisory: the detection is syntactic and cannot follow aliasing.
+type DataRaceWarning struct {
+	File           string `json:"file"`
+	Line           int    `json:"line"`
+	Function       string `json:"function"`
+	Variable       string `json:"variable"`
+	RiskLevel      string `json:"risk_level"`
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
+}
+
+// ChannelInstance represents a channel usage. Name and the send and receive counts are
+// set for make(chan T) calls assigned to a variable.
 type ChannelInstance struct {
 	File          string `json:"file"`
 	Line          int    `json:"line"`
 	Function      string `json:"function"`
+	Name          string `json:"name,omitempty"`
 	Type          string `json:"type"`
 	IsBuffered    bool   `json:"is_buffered"`
 	BufferSize    int    `json:"buffer_size"`
 	IsDirectional bool   `json:"is_directional"`
 	Direction     string `json:"direction"`
+	SendCount     int    `json:"send_count"`
+	ReceiveCount  int    `json:"receive_count"`
 }
 
-// SyncPrimitiveInstance represents a synchronization primitive usage
+// SyncPrimitiveInstance represents a synchronization primitive usage. GoCalls and HasWait are
+// set for errgroup.Group instances: the number of Go and TryGo calls on the group, and whether
+// its Wait method is called to collect their errors.
 type SyncPrimitiveInstance struct {
 	File     string `json:"file"`
 	Line     int    `json:"line"`
@@ -310,6 +645,8 @@ type SyncPrimitiveInstance struct {
 	Type     string `json:"type"`
 	Variable string `json:"variable"`
 	Context  string `json:"context"`
+	GoCalls  int    `json:"go_calls,omitempty"`
+	HasWait  bool   `json:"has_wait,omitempty"`
 }
 
 // AntiPatternMetrics tracks code smells and anti-patterns
@@ -317,8 +654,29 @@ type AntiPatternMetrics struct {
 	GodObjects              []AntiPatternWarning     `json:"god_objects"`
 	LongMethods             []AntiPatternWarning     `json:"long_methods"`
 	DeepNesting             []AntiPatternWarning     `json:"deep_nesting"`
+	LongParameterLists      []AntiPatternWarning     `json:"long_parameter_lists"`
 	MagicNumbers            []AntiPatternWarning     `json:"magic_numbers"`
+	NakedReturns            []AntiPatternWarning     `json:"naked_returns"`
 	PerformanceAntipatterns []PerformanceAntipattern `json:"performance_antipatterns"`
+	// ReceiverConsistency lists the types whose methods, across all files of the package, mix
+	// pointer and value receivers. It is informational: each entry is also reported as an
+	// inconsistent_receiver performance anti-pattern of info severity.
+	ReceiverConsistency []ReceiverConsistency `json:"receiver_consistency"`
+}
+
+// ReceiverConsistency describes a type whose methods mix pointer and value receivers
+type ReceiverConsistency struct {
+	Type             string   `json:"type"`
+	Package          string   `json:"package"`
+	File             string   `json:"file"`
+	Line             int      `json:"line"`
+	PointerReceivers int      `json:"pointer_receivers"`
+	ValueReceivers   int      `json:"value_receivers"`
+	PointerMethods   []string `json:"pointer_methods"`
+	ValueMethods     []string `json:"value_methods"`
+	// ValueGetters are value-receiver accessors, which are not counted as value receivers
+	// because declaring them on otherwise pointer-receiver types is common
+	ValueGetters []string `json:"value_getters,omitempty"`
 }
 
 // PatternInstance represents a detected pattern
@@ -352,6 +710,21 @@ type ComplexityMetrics struct {
 	AverageStruct     float64          `json:"average_struct_complexity"`
 	HighestComplexity []ComplexityItem `json:"highest_complexity"`
 	Distribution      map[string]int   `json:"complexity_distribution"`
+	// TestFunctions covers Test* functions, which are excluded from the rankings above
+	TestFunctions TestComplexityMetrics `json:"test_functions"`
+}
+
+// ComplexityDistributionBuckets lists the Distribution keys from the lowest overall complexity
+// range to the highest
+var ComplexityDistributionBuckets = []string{"0-5", "6-10", "11-15", "16-20", "20+"}
+
+// TestComplexityMetrics ranks the cyclomatic complexity of test functions separately from production code
+type TestComplexityMetrics struct {
+	TotalTests        int              `json:"total_tests"`
+	AverageCyclomatic float64          `json:"average_cyclomatic"`
+	Threshold         int              `json:"threshold"`
+	MostComplex       []ComplexityItem `json:"most_complex"`
+	OverThreshold     []ComplexityItem `json:"over_threshold"`
 }
 
 // ComplexityItem represents a high-complexity item
@@ -382,6 +755,26 @@ type DocumentationMetrics struct {
 	NOTEComments          []NOTEComment         `json:"note_comments"`
 	StaleAnnotations      int                   `json:"stale_annotations"`
 	AnnotationsByCategory map[string]int        `json:"annotations_by_category"`
+	CommentDensity        CommentDensityMetrics `json:"comment_density"`
+}
+
+// CommentDensityMetrics aggregates comment-to-code ratios at file and package granularity
+type CommentDensityMetrics struct {
+	Files    []CommentRatio `json:"files"`
+	Packages []CommentRatio `json:"packages"`
+	Overall  float64        `json:"overall"`
+}
+
+// CommentRatio captures the comment-to-code line ratio for a single file or package.
+// Flag is "low" or "high" for packages outside the expected density range.
+type CommentRatio struct {
+	// Name is the file path, or the package directory, relative to the analyzed root
+	Name         string  `json:"name"`
+	Package      string  `json:"package,omitempty"`
+	CodeLines    int     `json:"code_lines"`
+	CommentLines int     `json:"comment_lines"`
+	Ratio        float64 `json:"ratio"`
+	Flag         string  `json:"flag,omitempty"`
 }
 
 // DocumentationCoverage tracks GoDoc coverage percentages for packages,
@@ -513,6 +906,8 @@ type DuplicationMetrics struct {
 	DuplicationRatio float64     `json:"duplication_ratio"`
 	LargestCloneSize int         `json:"largest_clone_size"`
 	Clones           []ClonePair `json:"clones"`
+	// HelperDuplicates lists free functions whose body repeats a method of their sole parameter's type
+	HelperDuplicates []HelperDuplicate `json:"helper_duplicates,omitempty"`
 }
 
 // ClonePair represents a set of duplicated code blocks
@@ -537,6 +932,22 @@ type CloneInstance struct {
 	NodeCount int    `json:"node_count"`
 }
 
+// HelperDuplicate pairs a receiver-less function such as validateUser(u *User) with the method
+// of the same type, such as (*User).Validate, whose body it nearly repeats
+type HelperDuplicate struct {
+	Function   string        `json:"function"`
+	Method     string        `json:"method"`
+	Type       string        `json:"type"`
+	Package    string        `json:"package"`
+	File       string        `json:"file"`
+	Line       int           `json:"line"`
+	MethodFile string        `json:"method_file"`
+	MethodLine int           `json:"method_line"`
+	Similarity float64       `json:"similarity"`
+	Severity   SeverityLevel `json:"severity"`
+	Suggestion string        `json:"suggestion"`
+}
+
 // CloneType represents the category of code duplication
 type CloneType string
 
@@ -764,7 +1175,8 @@ type DiffSummary struct {
 	CriticalIssues     int            `json:"critical_issues"`
 	OverallTrend       TrendDirection `json:"overall_trend"`
 	QualityScore       float64        `json:"quality_score"`
-	QualityDelta       float64        `json:"quality_delta"`
+	// QualityDelta is the change in Scores.OverallQuality from the baseline to the current report
+	QualityDelta float64 `json:"quality_delta"`
 }
 
 // MetricChange represents a single metric change between snapshots
@@ -921,6 +1333,11 @@ type ThresholdConfig struct {
 		MinDecrease float64 `yaml:"min_decrease_percent" json:"min_decrease_percent"`
 	} `yaml:"function_complexity" json:"function_complexity"`
 
+	// FunctionLength gates individual functions by code lines; it is not used for change detection
+	FunctionLength struct {
+		MaxLines int `yaml:"max_lines" json:"max_lines"`
+	} `yaml:"function_length" json:"function_length"`
+
 	StructComplexity struct {
 		MaxFields      int     `yaml:"max_fields" json:"max_fields"`
 		FieldIncrease  float64 `yaml:"max_field_increase" json:"max_field_increase"`
@@ -950,6 +1367,9 @@ type ThresholdConfig struct {
 		FailOnError       bool    `yaml:"fail_on_error" json:"fail_on_error"`
 		FailOnCritical    bool    `yaml:"fail_on_critical" json:"fail_on_critical"`
 		SignificanceLevel float64 `yaml:"significance_level" json:"significance_level"`
+		// Epsilon is the largest difference between two float metrics still treated as equal,
+		// so floating-point noise from recomputation does not register as a change
+		Epsilon float64 `yaml:"epsilon" json:"epsilon"`
 	} `yaml:"global" json:"global"`
 }
 
@@ -984,6 +1404,7 @@ func DefaultThresholdConfig() ThresholdConfig {
 	config.Global.FailOnError = true
 	config.Global.FailOnCritical = true
 	config.Global.SignificanceLevel = 5.0
+	config.Global.Epsilon = DefaultFloatEpsilon
 
 	return config
 }
@@ -1088,6 +1509,10 @@ type BurdenMetrics struct {
 	ComplexSignatures     []SignatureIssue   `json:"complex_signatures"`
 	DeeplyNestedFunctions []NestingIssue     `json:"deeply_nested_functions"`
 	FeatureEnvyMethods    []FeatureEnvyIssue `json:"feature_envy_methods"`
+	ComplexTypeExprs      []TypeDepthIssue   `json:"complex_type_expressions"`
+	LongMethodChains      []MethodChainIssue `json:"long_method_chains"`
+	// UnimplementedInterfaces are exported interfaces no analyzed type implements
+	UnimplementedInterfaces []UnimplementedInterface `json:"unimplemented_interfaces"`
 }
 
 // MagicNumber represents a detected magic number or string
@@ -1153,6 +1578,7 @@ type SignatureIssue struct {
 	Line           int           `json:"line"`
 	ParameterCount int           `json:"parameter_count"`
 	ReturnCount    int           `json:"return_count"`
+	IsMethod       bool          `json:"is_method,omitempty"`
 	BoolParams     []string      `json:"bool_params,omitempty"`
 	Severity       SeverityLevel `json:"severity"`
 	Suggestion     string        `json:"suggestion"`
@@ -1162,6 +1588,53 @@ type SignatureIssue struct {
 	Threshold      float64       `json:"threshold,omitempty"`
 }
 
+// TypeDepthIssue represents a parameter or return type expression nested too deeply
+type TypeDepthIssue struct {
+	Function    string        `json:"function"`
+	File        string        `json:"file"`
+	Line        int           `json:"line"`
+	Position    string        `json:"position"`
+	Name        string        `json:"name,omitempty"`
+	TypeExpr    string        `json:"type_expr"`
+	Depth       int           `json:"depth"`
+	Severity    SeverityLevel `json:"severity"`
+	Suggestion  string        `json:"suggestion"`
+	ItemName    string        `json:"item_name,omitempty"`
+	Metric      string        `json:"metric,omitempty"`
+	ActualValue float64       `json:"actual_value,omitempty"`
+	Threshold   float64       `json:"threshold,omitempty"`
+}
+
+// MethodChainIssue represents a chain of method calls longer than the configured depth,
+// such as a.B().C().D().E(), which reaches through several objects (Law of Demeter)
+type MethodChainIssue struct {
+	Function    string        `json:"function"`
+	File        string        `json:"file"`
+	Line        int           `json:"line"`
+	Chain       string        `json:"chain"`
+	Depth       int           `json:"depth"`
+	Severity    SeverityLevel `json:"severity"`
+	Suggestion  string        `json:"suggestion"`
+	ItemName    string        `json:"item_name,omitempty"`
+	Metric      string        `json:"metric,omitempty"`
+	ActualValue float64       `json:"actual_value,omitempty"`
+	Threshold   float64       `json:"threshold,omitempty"`
+}
+
+// UnimplementedInterface is an exported interface that no type in the analyzed code implements,
+// a possibly dead or premature abstraction
+type UnimplementedInterface struct {
+	Name        string `json:"name"`
+	Package     string `json:"package"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	MethodCount int    `json:"method_count"`
+	// ParameterUses counts the function parameters declared with the interface type
+	ParameterUses int           `json:"parameter_uses"`
+	Severity      SeverityLevel `json:"severity"`
+	Suggestion    string        `json:"suggestion"`
+}
+
 // NestingIssue represents deep nesting in a function
 type NestingIssue struct {
 	Function    string        `json:"function"`
@@ -1197,16 +1670,29 @@ type FeatureEnvyIssue struct {
 
 // ScoringMetrics holds maintenance burden index scores
 type ScoringMetrics struct {
-	FileScores    []FileScore    `json:"file_scores"`
-	PackageScores []PackageScore `json:"package_scores"`
+	FileScores        []FileScore      `json:"file_scores"`
+	PackageScores     []PackageScore   `json:"package_scores"`
+	ProductionQuality CodeQualityScore `json:"production_quality"`
+	TestQuality       CodeQualityScore `json:"test_quality"`
+	OverallQuality    float64          `json:"overall_quality"`
+}
+
+// CodeQualityScore summarizes the quality of one class of files (production or test code).
+// Score is on a 0-100 scale where higher is better (100 minus the average file MBI).
+type CodeQualityScore struct {
+	Score      float64 `json:"score"`
+	AverageMBI float64 `json:"average_mbi"`
+	FileCount  int     `json:"file_count"`
+	Risk       string  `json:"risk"`
 }
 
 // FileScore represents the MBI for a single file
 type FileScore struct {
-	File      string         `json:"file"`
-	Score     float64        `json:"score"`
-	Risk      string         `json:"risk"`
-	Breakdown ScoreBreakdown `json:"breakdown"`
+	File       string         `json:"file"`
+	IsTestFile bool           `json:"is_test_file,omitempty"`
+	Score      float64        `json:"score"`
+	Risk       string         `json:"risk"`
+	Breakdown  ScoreBreakdown `json:"breakdown"`
 }
 
 // PackageScore represents the MBI for a package
@@ -1294,6 +1780,145 @@ type TestFileInfo struct {
 	AssertionRatio float64 `json:"assertion_ratio"`
 }
 
+// TestPresenceMetrics correlates function complexity with the presence of tests. It is a
+// heuristic over the test file sources, not coverage instrumentation: a function counts as tested
+// when a test, benchmark, fuzz test, or example in its directory is named after it, or when the
+// test files of its directory mention its name at all, so it overstates what tests exercise. The
+// report has none when test files were excluded from the analysis.
+type TestPresenceMetrics struct {
+	TotalFunctions  int     `json:"total_functions"`
+	TestedFunctions int     `json:"tested_functions"`
+	TestedRatio     float64 `json:"tested_ratio"`
+	// ComplexityThreshold is the cyclomatic complexity above which an untested function is listed
+	ComplexityThreshold int                   `json:"complexity_threshold"`
+	Packages            []PackageTestPresence `json:"packages"`
+	UntestedComplex     []UntestedFunction    `json:"untested_complex_functions"`
+}
+
+// PackageTestPresence is the share of a package directory's functions that have a test
+type PackageTestPresence struct {
+	Package         string  `json:"package"`
+	Directory       string  `json:"directory"`
+	HasTestFiles    bool    `json:"has_test_files"`
+	Functions       int     `json:"functions"`
+	TestedFunctions int     `json:"tested_functions"`
+	TestedRatio     float64 `json:"tested_ratio"`
+}
+
+// UntestedFunction is a function above the complexity threshold that no test names or mentions
+type UntestedFunction struct {
+	Name         string        `json:"name"`
+	ReceiverType string        `json:"receiver_type,omitempty"`
+	Package      string        `json:"package"`
+	File         string        `json:"file"`
+	Line         int           `json:"line"`
+	Cyclomatic   int           `json:"cyclomatic"`
+	Severity     SeverityLevel `json:"severity"`
+	Suggestion   string        `json:"suggestion"`
+}
+
+// ErrorHandlingMetrics summarizes how the production functions that return an error build the
+// errors they return, to show where errors are passed up without the context of the failed call.
+// The error result of each return statement is classified from its syntax alone, without type
+// information. Functions that return bare errors from several places are listed.
+type ErrorHandlingMetrics struct {
+	ErrorHandlingCounts
+	Packages []PackageErrorHandling `json:"packages"`
+	// BareReturnFunctions are the functions with at least two bare error returns, most first
+	BareReturnFunctions []BareErrorFunction `json:"bare_return_functions"`
+}
+
+// ErrorHandlingCounts counts the error returns and error constructor calls of a set of files.
+// An error return is a return statement of a function whose last result is an error, with a
+// non-nil error result. It is bare when it returns a variable of the function, such as return err
+// or return nil, err; wrapped for fmt.Errorf with %w and the Wrap, Wrapf, WithMessage,
+// WithMessagef, and WithStack functions of github.com/pkg/errors; created for errors.New, a
+// fmt.Errorf without %w, errors.Errorf of github.com/pkg/errors, and error struct literals;
+// sentinel for a package-level error such as ErrNotFound or io.EOF; and other for everything
+// else, such as the result of another call. Naked returns are not counted.
+type ErrorHandlingCounts struct {
+	ErrorFunctions  int `json:"error_returning_functions"`
+	ErrorReturns    int `json:"error_returns"`
+	BareReturns     int `json:"bare_returns"`
+	WrappedReturns  int `json:"wrapped_returns"`
+	CreatedReturns  int `json:"created_returns"`
+	SentinelReturns int `json:"sentinel_returns"`
+	OtherReturns    int `json:"other_returns"`
+	// WrapRatio is the share of the propagated errors, wrapped or bare, that are wrapped
+	WrapRatio float64 `json:"wrap_ratio"`
+	// The calls are counted wherever they appear, not only in return statements
+	ErrorsNewCalls     int `json:"errors_new_calls"`
+	ErrorfCalls        int `json:"errorf_calls"`
+	ErrorfWrapCalls    int `json:"errorf_wrap_calls"`
+	PkgErrorsWrapCalls int `json:"pkg_errors_wrap_calls"`
+}
+
+// Add adds the counts of other to c and recomputes the wrap ratio
+func (c *ErrorHandlingCounts) Add(other ErrorHandlingCounts) {
+	c.ErrorFunctions += other.ErrorFunctions
+	c.ErrorReturns += other.ErrorReturns
+	c.BareReturns += other.BareReturns
+	c.WrappedReturns += other.WrappedReturns
+	c.CreatedReturns += other.CreatedReturns
+	c.SentinelReturns += other.SentinelReturns
+	c.OtherReturns += other.OtherReturns
+	c.ErrorsNewCalls += other.ErrorsNewCalls
+	c.ErrorfCalls += other.ErrorfCalls
+	c.ErrorfWrapCalls += other.ErrorfWrapCalls
+	c.PkgErrorsWrapCalls += other.PkgErrorsWrapCalls
+	c.UpdateWrapRatio()
+}
+
+// UpdateWrapRatio sets WrapRatio from the wrapped and bare returns, or to 0 when there are none
+func (c *ErrorHandlingCounts) UpdateWrapRatio() {
+	c.WrapRatio = 0
+	if propagated := c.WrappedReturns + c.BareReturns; propagated > 0 {
+		c.WrapRatio = float64(c.WrappedReturns) / float64(propagated)
+	}
+}
+
+// PackageErrorHandling holds the error handling counts of one package directory
+type PackageErrorHandling struct {
+	Package   string `json:"package"`
+	Directory string `json:"directory"`
+	ErrorHandlingCounts
+}
+
+// BareErrorFunction is a function that returns errors without wrapping them from several places,
+// so a caller cannot tell which of its calls failed
+type BareErrorFunction struct {
+	Name         string `json:"name"`
+	ReceiverType string `json:"receiver_type,omitempty"`
+	Package      string `json:"package"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	BareReturns  int    `json:"bare_returns"`
+	// ReturnLines are the lines of the bare returns, including those in function literals
+	ReturnLines []int         `json:"return_lines"`
+	Severity    SeverityLevel `json:"severity"`
+	Suggestion  string        `json:"suggestion"`
+}
+
+// PerformanceMetrics holds the hot-path allocation warnings found when analysis.include_performance
+// is enabled. They come from syntactic heuristics, not escape analysis, and point at code worth
+// profiling rather than proven allocations.
+type PerformanceMetrics struct {
+	Warnings []PerformanceWarning `json:"warnings"`
+	// ByType counts the warnings of each kind, such as append_in_loop
+	ByType map[string]int `json:"by_type"`
+}
+
+// PerformanceWarning is a statement inside a loop that is likely to allocate on every iteration
+type PerformanceWarning struct {
+	Type        string        `json:"type"`
+	File        string        `json:"file"`
+	Line        int           `json:"line"`
+	Function    string        `json:"function"`
+	Severity    SeverityLevel `json:"severity"`
+	Description string        `json:"description"`
+	Suggestion  string        `json:"suggestion"`
+}
+
 // TeamMetrics represents team productivity analysis
 type TeamMetrics struct {
 	Developers      map[string]*DeveloperMetrics `json:"developers"`
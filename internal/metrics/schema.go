@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema draft that ReportSchema documents conform to
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// ReportSchema generates a JSON Schema describing the JSON encoding of Report. The schema is
+// derived at runtime from the struct definitions and their json tags, so it always matches the
+// report the tool writes. Every named struct becomes an entry under $defs; fields without
+// omitempty or omitzero are required and no undeclared properties are allowed. Slices, maps,
+// and pointers may also be null, as encoding/json writes nil values that way.
+func ReportSchema() map[string]interface{} {
+	gen := &schemaGenerator{defs: make(map[string]interface{})}
+	schema := gen.schemaFor(reflect.TypeOf(Report{}))
+	schema["$schema"] = JSONSchemaDialect
+	schema["title"] = "go-stats-generator report"
+	schema["$defs"] = gen.defs
+	return schema
+}
+
+// schemaGenerator collects the struct definitions referenced while generating a schema
+type schemaGenerator struct {
+	defs map[string]interface{}
+}
+
+// schemaFor returns the schema of values of type t
+func (g *schemaGenerator) schemaFor(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == durationType:
+		return map[string]interface{}{"type": "integer", "description": "duration in nanoseconds"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]interface{}{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": []string{"string", "null"}, "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": g.schemaFor(t.Elem())}
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schemaFor(t.Elem()),
+			"minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		return map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Pointer:
+		return map[string]interface{}{"anyOf": []interface{}{g.schemaFor(t.Elem()), map[string]interface{}{"type": "null"}}}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // placeholder that stops recursive types from looping
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	default:
+		// interface{} and other kinds accept any JSON value
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema returns the object schema of struct type t
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	g.collectFields(t, properties, &required)
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// collectFields adds the JSON properties of struct type t, following encoding/json: fields
+// tagged "-" and unexported fields are skipped, and untagged embedded structs contribute their
+// own fields
+func (g *schemaGenerator) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.collectFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.schemaFor(field.Type)
+		if opts := "," + options + ","; !strings.Contains(opts, ",omitempty,") && !strings.Contains(opts, ",omitzero,") {
+			*required = append(*required, name)
+		}
+	}
+}
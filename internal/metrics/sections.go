@@ -1,29 +1,40 @@
 package metrics
 
-import "strings"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // ValidSections lists all valid report section names for --sections/--only filtering.
 var ValidSections = map[string]bool{
-	"metadata":      true,
-	"overview":      true,
-	"functions":     true,
-	"structs":       true,
-	"interfaces":    true,
-	"packages":      true,
-	"patterns":      true,
-	"concurrency":   true, // alias for patterns
-	"complexity":    true,
-	"documentation": true,
-	"generics":      true,
-	"duplication":   true,
-	"naming":        true,
-	"placement":     true,
-	"organization":  true,
-	"burden":        true,
-	"scores":        true,
-	"test_coverage": true,
-	"test_quality":  true,
-	"suggestions":   true,
+	"metadata":       true,
+	"overview":       true,
+	"summary":        true,
+	"functions":      true,
+	"structs":        true,
+	"interfaces":     true,
+	"packages":       true,
+	"patterns":       true,
+	"concurrency":    true, // patterns.concurrency_patterns only
+	"anti-patterns":  true, // patterns.anti_patterns only
+	"complexity":     true,
+	"documentation":  true,
+	"generics":       true,
+	"duplication":    true,
+	"naming":         true,
+	"placement":      true,
+	"organization":   true,
+	"burden":         true,
+	"scores":         true,
+	"test_coverage":  true,
+	"test_quality":   true,
+	"test_presence":  true,
+	"error_handling": true,
+	"performance":    true,
+	"suggestions":    true,
+	"extensions":     true,
+	"tree":           true,
 }
 
 // sectionHandler defines how to clear a specific report section.
@@ -31,25 +42,49 @@ type sectionHandler func(*Report)
 
 // sectionHandlers maps section names to their clearing functions.
 var sectionHandlers = map[string]sectionHandler{
-	"metadata":      func(r *Report) { r.Metadata = ReportMetadata{} },
-	"overview":      func(r *Report) { r.Overview = OverviewMetrics{} },
-	"functions":     func(r *Report) { r.Functions = nil },
-	"structs":       func(r *Report) { r.Structs = nil },
-	"interfaces":    func(r *Report) { r.Interfaces = nil },
-	"packages":      clearPackageSection,
-	"patterns":      func(r *Report) { r.Patterns = PatternMetrics{} },
-	"complexity":    func(r *Report) { r.Complexity = ComplexityMetrics{} },
-	"documentation": func(r *Report) { r.Documentation = DocumentationMetrics{} },
-	"generics":      func(r *Report) { r.Generics = GenericMetrics{} },
-	"duplication":   func(r *Report) { r.Duplication = DuplicationMetrics{} },
-	"naming":        func(r *Report) { r.Naming = NamingMetrics{} },
-	"placement":     func(r *Report) { r.Placement = PlacementMetrics{} },
-	"organization":  func(r *Report) { r.Organization = OrganizationMetrics{} },
-	"burden":        func(r *Report) { r.Burden = BurdenMetrics{} },
-	"scores":        func(r *Report) { r.Scores = ScoringMetrics{} },
-	"test_coverage": func(r *Report) { r.TestCoverage = TestCoverageMetrics{} },
-	"test_quality":  func(r *Report) { r.TestQuality = TestQualityMetrics{} },
-	"suggestions":   func(r *Report) { r.Suggestions = nil },
+	"metadata":       func(r *Report) { r.Metadata = ReportMetadata{} },
+	"overview":       func(r *Report) { r.Overview = OverviewMetrics{} },
+	"summary":        func(r *Report) { r.Summary = ReportSummary{} },
+	"functions":      clearFunctionSection,
+	"structs":        clearStructSection,
+	"interfaces":     clearInterfaceSection,
+	"packages":       clearPackageSection,
+	"complexity":     func(r *Report) { r.Complexity = ComplexityMetrics{} },
+	"documentation":  func(r *Report) { r.Documentation = DocumentationMetrics{} },
+	"generics":       func(r *Report) { r.Generics = GenericMetrics{} },
+	"duplication":    func(r *Report) { r.Duplication = DuplicationMetrics{} },
+	"naming":         func(r *Report) { r.Naming = NamingMetrics{} },
+	"placement":      func(r *Report) { r.Placement = PlacementMetrics{} },
+	"organization":   func(r *Report) { r.Organization = OrganizationMetrics{} },
+	"burden":         func(r *Report) { r.Burden = BurdenMetrics{} },
+	"scores":         func(r *Report) { r.Scores = ScoringMetrics{} },
+	"test_coverage":  func(r *Report) { r.TestCoverage = TestCoverageMetrics{} },
+	"test_quality":   func(r *Report) { r.TestQuality = TestQualityMetrics{} },
+	"test_presence":  func(r *Report) { r.TestPresence = nil },
+	"error_handling": func(r *Report) { r.ErrorHandling = nil },
+	"performance":    func(r *Report) { r.Performance = nil },
+	"suggestions":    func(r *Report) { r.Suggestions = nil },
+	"extensions":     func(r *Report) { r.Extensions = nil },
+	"tree":           func(r *Report) { r.Tree = nil },
+}
+
+// clearFunctionSection clears functions and the parameter type summary derived from them.
+func clearFunctionSection(r *Report) {
+	r.Functions = nil
+	r.ParamTypes = ParamTypeDistribution{}
+}
+
+// clearInterfaceSection clears interfaces and the compile-time assertions checked against them.
+func clearInterfaceSection(r *Report) {
+	r.Interfaces = nil
+	r.InterfaceAssertions = InterfaceAssertionMetrics{}
+}
+
+// clearStructSection clears structs and the field type and balance summaries derived from them.
+func clearStructSection(r *Report) {
+	r.Structs = nil
+	r.FieldTypes = FieldTypeDistribution{}
+	r.StructBalance = StructBalanceSummary{}
 }
 
 // clearPackageSection clears both packages and circular dependencies.
@@ -66,6 +101,9 @@ func FilterReportSections(report *Report, sections []string) {
 	}
 
 	keep := buildSectionKeepSet(sections)
+	if keep["all"] {
+		return
+	}
 	clearUnrequestedSections(report, keep)
 }
 
@@ -75,9 +113,6 @@ func buildSectionKeepSet(sections []string) map[string]bool {
 	for _, s := range sections {
 		keep[strings.ToLower(strings.TrimSpace(s))] = true
 	}
-	if keep["concurrency"] {
-		keep["patterns"] = true
-	}
 	keep["metadata"] = true
 	keep["overview"] = true
 	return keep
@@ -90,4 +125,63 @@ func clearUnrequestedSections(report *Report, keep map[string]bool) {
 			handler(report)
 		}
 	}
+	clearPatternSection(report, keep)
+}
+
+// clearPatternSection clears the parts of the patterns section that were not requested.
+// "patterns" keeps it whole, while the "concurrency" and "anti-patterns" aliases each keep
+// only their own sub-section.
+func clearPatternSection(r *Report, keep map[string]bool) {
+	if keep["patterns"] {
+		return
+	}
+	r.Patterns.DesignPatterns = DesignPatternMetrics{}
+	if !keep["concurrency"] {
+		r.Patterns.ConcurrencyPatterns = ConcurrencyPatternMetrics{}
+	}
+	if !keep["anti-patterns"] {
+		r.Patterns.AntiPatterns = AntiPatternMetrics{}
+	}
+}
+
+// ValidateSections checks section names requested with --sections/--only against
+// ValidSections, ignoring case and surrounding whitespace. "all", the default, selects every
+// section. Every unknown name is reported together with the valid ones.
+func ValidateSections(sections []string) error {
+	var unknown []string
+	for _, s := range sections {
+		name := strings.ToLower(strings.TrimSpace(s))
+		if name != "all" && !ValidSections[name] {
+			unknown = append(unknown, fmt.Sprintf("%q", s))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	valid := []string{"all"}
+	for name := range ValidSections {
+		valid = append(valid, name)
+	}
+	sort.Strings(valid[1:])
+	return fmt.Errorf("unknown section %s (valid sections: %s)", strings.Join(unknown, ", "), strings.Join(valid, ", "))
+}
+
+// IsSectionSelected reports whether a reporter should emit the named section given the
+// requested section list. An empty list selects every section. The "patterns" section
+// selects both "concurrency" and "anti-patterns".
+func IsSectionSelected(sections []string, name string) bool {
+	if len(sections) == 0 {
+		return true
+	}
+	for _, s := range sections {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == name || s == "all" {
+			return true
+		}
+		if s == "patterns" && (name == "concurrency" || name == "anti-patterns") {
+			return true
+		}
+	}
+	return false
 }
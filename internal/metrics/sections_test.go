@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -107,6 +109,60 @@ func TestFilterReportSections_ConcurrencyAlias(t *testing.T) {
 	}
 }
 
+func TestFilterReportSections_PatternAliasesJSON(t *testing.T) {
+	tests := []struct {
+		sections []string
+		want     map[string]bool
+	}{
+		{[]string{"concurrency"}, map[string]bool{"design_patterns": false, "concurrency_patterns": true, "anti_patterns": false}},
+		{[]string{"anti-patterns"}, map[string]bool{"design_patterns": false, "concurrency_patterns": false, "anti_patterns": true}},
+		{[]string{"concurrency", "anti-patterns"}, map[string]bool{"design_patterns": false, "concurrency_patterns": true, "anti_patterns": true}},
+		{[]string{"patterns"}, map[string]bool{"design_patterns": true, "concurrency_patterns": true, "anti_patterns": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.sections, ","), func(t *testing.T) {
+			report := &Report{
+				Patterns: PatternMetrics{
+					DesignPatterns:      DesignPatternMetrics{Singleton: []PatternInstance{{Name: "instance"}}},
+					ConcurrencyPatterns: ConcurrencyPatternMetrics{Goroutines: GoroutineMetrics{TotalCount: 3}},
+					AntiPatterns:        AntiPatternMetrics{LongMethods: []AntiPatternWarning{{Type: "long_method"}}},
+				},
+			}
+
+			FilterReportSections(report, tt.sections)
+
+			data, err := json.Marshal(report.Patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var patterns map[string]json.RawMessage
+			if err := json.Unmarshal(data, &patterns); err != nil {
+				t.Fatal(err)
+			}
+			empty := map[string]string{
+				"design_patterns":      mustMarshal(t, DesignPatternMetrics{}),
+				"concurrency_patterns": mustMarshal(t, ConcurrencyPatternMetrics{}),
+				"anti_patterns":        mustMarshal(t, AntiPatternMetrics{}),
+			}
+			for key, kept := range tt.want {
+				if got := string(patterns[key]) != empty[key]; got != kept {
+					t.Errorf("%s kept = %v, want %v; JSON: %s", key, got, kept, patterns[key])
+				}
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
 func TestFilterReportSections_WhitespaceHandling(t *testing.T) {
 	report := &Report{
 		Functions: []FunctionMetrics{{Name: "foo"}},
@@ -159,7 +215,7 @@ func TestFilterReportSections_MetadataAlwaysPreserved(t *testing.T) {
 
 func TestValidSections_AllPresent(t *testing.T) {
 	expected := []string{
-		"metadata", "overview", "functions", "structs", "interfaces",
+		"metadata", "overview", "summary", "functions", "structs", "interfaces",
 		"packages", "patterns", "concurrency", "complexity", "documentation",
 		"generics", "duplication", "naming", "placement", "organization",
 		"burden", "scores", "suggestions",
@@ -171,3 +227,47 @@ func TestValidSections_AllPresent(t *testing.T) {
 		}
 	}
 }
+
+func TestIsSectionSelected(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []string
+		section  string
+		want     bool
+	}{
+		{"empty selects all", nil, "functions", true},
+		{"exact match", []string{"functions"}, "functions", true},
+		{"not requested", []string{"functions"}, "overview", false},
+		{"all keyword", []string{"all"}, "burden", true},
+		{"case and whitespace", []string{" Functions "}, "functions", true},
+		{"patterns selects concurrency", []string{"patterns"}, "concurrency", true},
+		{"patterns selects anti-patterns", []string{"patterns"}, "anti-patterns", true},
+		{"concurrency does not select anti-patterns", []string{"concurrency"}, "anti-patterns", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSectionSelected(tt.sections, tt.section); got != tt.want {
+				t.Errorf("IsSectionSelected(%v, %q) = %v, want %v", tt.sections, tt.section, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	for _, sections := range [][]string{nil, {"all"}, {"functions", "complexity", "packages"}, {" Packages ", "anti-patterns"}} {
+		if err := ValidateSections(sections); err != nil {
+			t.Errorf("ValidateSections(%v) = %v, want nil", sections, err)
+		}
+	}
+
+	err := ValidateSections([]string{"functions", "func", "pakages"})
+	if err == nil {
+		t.Fatal("ValidateSections() on unknown names = nil, want an error")
+	}
+	for _, want := range []string{`"func"`, `"pakages"`, "valid sections: all, anti-patterns,"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateSections() error %q does not contain %q", err, want)
+		}
+	}
+}
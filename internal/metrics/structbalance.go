@@ -0,0 +1,93 @@
+package metrics
+
+import "sort"
+
+const (
+	// anemicMinFields is the fewest fields a struct without methods needs to count as anemic
+	anemicMinFields = 2
+	// behaviorRichMinMethods is the fewest methods a behavior-rich struct declares
+	behaviorRichMinMethods = 3
+	// behaviorRichRatio is the methods-per-field ratio at or above which a struct is behavior-rich
+	behaviorRichRatio = 2.0
+	// maxStructBalanceExamples caps the examples kept per balance class
+	maxStructBalanceExamples = 5
+)
+
+// StructBalanceOrder lists every balance class in the order reporters display them
+var StructBalanceOrder = []StructBalance{
+	StructBalanceAnemic,
+	StructBalanceBalanced,
+	StructBalanceBehaviorRich,
+}
+
+// StructBalanceLabels maps each balance class to its display name
+var StructBalanceLabels = map[StructBalance]string{
+	StructBalanceAnemic:       "Anemic data holder",
+	StructBalanceBalanced:     "Balanced",
+	StructBalanceBehaviorRich: "Behavior-rich",
+}
+
+// ClassifyStructBalance labels a struct as an anemic data holder (anemicMinFields or more fields
+// and no methods), behavior-rich (behaviorRichMinMethods or more methods and at least
+// behaviorRichRatio methods per field), or balanced otherwise.
+func ClassifyStructBalance(fields, methods int) StructBalance {
+	switch {
+	case methods == 0 && fields >= anemicMinFields:
+		return StructBalanceAnemic
+	case methods >= behaviorRichMinMethods && float64(methods) >= behaviorRichRatio*float64(fields):
+		return StructBalanceBehaviorRich
+	default:
+		return StructBalanceBalanced
+	}
+}
+
+// AggregateStructBalance counts production structs per balance class and keeps the most
+// pronounced examples of each: anemic structs with the most fields, behavior-rich structs with
+// the most methods, and balanced structs with the most fields and methods combined.
+func AggregateStructBalance(structs []StructMetrics) StructBalanceSummary {
+	summary := StructBalanceSummary{
+		Counts:   make(map[StructBalance]int),
+		Examples: make(map[StructBalance][]StructBalanceExample),
+	}
+	for _, s := range structs {
+		if s.IsTestFile || s.Balance == "" {
+			continue
+		}
+		summary.Counts[s.Balance]++
+		summary.Examples[s.Balance] = append(summary.Examples[s.Balance], StructBalanceExample{
+			Name:    s.Name,
+			Package: s.Package,
+			File:    s.File,
+			Line:    s.Line,
+			Fields:  s.TotalFields,
+			Methods: len(s.Methods),
+		})
+	}
+
+	for balance, examples := range summary.Examples {
+		sort.SliceStable(examples, func(i, j int) bool {
+			wi, wj := balanceExampleWeight(balance, examples[i]), balanceExampleWeight(balance, examples[j])
+			if wi != wj {
+				return wi > wj
+			}
+			return examples[i].Package+"."+examples[i].Name < examples[j].Package+"."+examples[j].Name
+		})
+		if len(examples) > maxStructBalanceExamples {
+			examples = examples[:maxStructBalanceExamples]
+		}
+		summary.Examples[balance] = examples
+	}
+	return summary
+}
+
+// balanceExampleWeight ranks how strongly an example represents its balance class
+func balanceExampleWeight(balance StructBalance, example StructBalanceExample) int {
+	switch balance {
+	case StructBalanceAnemic:
+		return example.Fields
+	case StructBalanceBehaviorRich:
+		return example.Methods
+	default:
+		return example.Fields + example.Methods
+	}
+}
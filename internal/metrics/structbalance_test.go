@@ -0,0 +1,53 @@
+package metrics
+
+import "testing"
+
+func TestClassifyStructBalance(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  int
+		methods int
+		want    StructBalance
+	}{
+		{name: "field-only struct", fields: 6, methods: 0, want: StructBalanceAnemic},
+		{name: "single field without methods", fields: 1, methods: 0, want: StructBalanceBalanced},
+		{name: "fields and methods", fields: 4, methods: 3, want: StructBalanceBalanced},
+		{name: "method-rich struct", fields: 2, methods: 7, want: StructBalanceBehaviorRich},
+		{name: "stateless service", fields: 0, methods: 3, want: StructBalanceBehaviorRich},
+		{name: "few methods on empty struct", fields: 0, methods: 2, want: StructBalanceBalanced},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyStructBalance(tt.fields, tt.methods); got != tt.want {
+				t.Errorf("ClassifyStructBalance(%d, %d) = %s, want %s", tt.fields, tt.methods, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateStructBalance(t *testing.T) {
+	withMethods := func(n int) []MethodInfo { return make([]MethodInfo, n) }
+	structs := []StructMetrics{
+		{Name: "Row", Package: "db", TotalFields: 3, Balance: StructBalanceAnemic},
+		{Name: "Config", Package: "app", TotalFields: 9, Balance: StructBalanceAnemic},
+		{Name: "Engine", Package: "app", TotalFields: 1, Methods: withMethods(6), Balance: StructBalanceBehaviorRich},
+		{Name: "Fixture", IsTestFile: true, TotalFields: 12, Balance: StructBalanceAnemic},
+	}
+
+	summary := AggregateStructBalance(structs)
+
+	if summary.Counts[StructBalanceAnemic] != 2 {
+		t.Fatalf("expected 2 anemic production structs, got %d", summary.Counts[StructBalanceAnemic])
+	}
+	if summary.Counts[StructBalanceBehaviorRich] != 1 {
+		t.Errorf("expected 1 behavior-rich struct, got %d", summary.Counts[StructBalanceBehaviorRich])
+	}
+	anemic := summary.Examples[StructBalanceAnemic]
+	if len(anemic) != 2 || anemic[0].Name != "Config" {
+		t.Errorf("expected anemic examples ranked by field count with Config first, got %+v", anemic)
+	}
+	if rich := summary.Examples[StructBalanceBehaviorRich]; len(rich) != 1 || rich[0].Methods != 6 {
+		t.Errorf("expected Engine as the behavior-rich example with 6 methods, got %+v", rich)
+	}
+}
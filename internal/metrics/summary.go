@@ -0,0 +1,85 @@
+package metrics
+
+import "math"
+
+// Penalty weights of each summarized issue kind in the health score. A weight is the share of the
+// score lost when every production function carries one issue of that kind.
+const (
+	longFunctionWeight         = 1.0
+	highComplexityWeight       = 2.0
+	godObjectWeight            = 5.0
+	undocumentedExportedWeight = 0.5
+	goroutineLeakWeight        = 5.0
+)
+
+// healthGrades maps minimum health scores to grades, best first
+var healthGrades = []struct {
+	minScore float64
+	grade    string
+}{
+	{90, "A"},
+	{80, "B"},
+	{70, "C"},
+	{60, "D"},
+}
+
+// SummarizeReport counts long functions, functions above maxCyclomatic, god objects, undocumented
+// exported symbols and potential goroutine leaks, and grades the report from A to F. The health
+// score starts at 100 and loses the weighted issue count per production function, so the grade
+// reflects issue density rather than codebase size. Test files are not counted, and functions
+// carrying a suppression directive do not count as high-complexity. It reads the god object and
+// long method anti-patterns, so it must run after they are detected.
+func SummarizeReport(report *Report, maxCyclomatic int) ReportSummary {
+	summary := ReportSummary{
+		LongFunctions:  len(report.Patterns.AntiPatterns.LongMethods),
+		GodObjects:     len(report.Patterns.AntiPatterns.GodObjects),
+		GoroutineLeaks: len(report.Patterns.ConcurrencyPatterns.Goroutines.GoroutineLeaks),
+	}
+
+	productionFunctions := 0
+	for _, fn := range report.Functions {
+		if fn.IsTestFile {
+			continue
+		}
+		productionFunctions++
+		if !fn.Suppressed && maxCyclomatic > 0 && fn.Complexity.Cyclomatic > maxCyclomatic {
+			summary.HighComplexityFunctions++
+		}
+		if fn.IsExported && !fn.Documentation.HasComment {
+			summary.UndocumentedExported++
+		}
+	}
+	for _, st := range report.Structs {
+		if !st.IsTestFile && st.IsExported && !st.Documentation.HasComment {
+			summary.UndocumentedExported++
+		}
+	}
+	for _, iface := range report.Interfaces {
+		if iface.IsExported && !iface.Documentation.HasComment {
+			summary.UndocumentedExported++
+		}
+	}
+
+	summary.CriticalIssues = summary.LongFunctions + summary.HighComplexityFunctions + summary.GodObjects +
+		summary.UndocumentedExported + summary.GoroutineLeaks
+
+	penalty := longFunctionWeight*float64(summary.LongFunctions) +
+		highComplexityWeight*float64(summary.HighComplexityFunctions) +
+		godObjectWeight*float64(summary.GodObjects) +
+		undocumentedExportedWeight*float64(summary.UndocumentedExported) +
+		goroutineLeakWeight*float64(summary.GoroutineLeaks)
+	score := 100 - 100*penalty/math.Max(float64(productionFunctions), 1)
+	summary.HealthScore = math.Round(math.Max(score, 0)*10) / 10
+	summary.Grade = healthGrade(summary.HealthScore)
+	return summary
+}
+
+// healthGrade converts a health score into a letter grade
+func healthGrade(score float64) string {
+	for _, g := range healthGrades {
+		if score >= g.minScore {
+			return g.grade
+		}
+	}
+	return "F"
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import "testing"
+
+func TestSummarizeReport(t *testing.T) {
+	report := &Report{
+		Functions: []FunctionMetrics{
+			{Name: "Parse", IsExported: true, Complexity: ComplexityScore{Cyclomatic: 14}},
+			{Name: "render", Complexity: ComplexityScore{Cyclomatic: 3}},
+			{Name: "lex", Suppressed: true, Complexity: ComplexityScore{Cyclomatic: 30}},
+			{Name: "Load", IsExported: true, Documentation: DocumentationInfo{HasComment: true}},
+			{Name: "TestParse", IsExported: true, IsTestFile: true, Complexity: ComplexityScore{Cyclomatic: 25}},
+		},
+		Structs:    []StructMetrics{{Name: "Node", IsExported: true}, {Name: "fixture", IsExported: true, IsTestFile: true}},
+		Interfaces: []InterfaceMetrics{{Name: "Renderer", IsExported: true, Documentation: DocumentationInfo{HasComment: true}}},
+	}
+	report.Patterns.AntiPatterns.LongMethods = []AntiPatternWarning{{Function: "Parse"}}
+	report.Patterns.ConcurrencyPatterns.Goroutines.GoroutineLeaks = []GoroutineLeakWarning{{Function: "render"}}
+
+	got := SummarizeReport(report, 10)
+	want := ReportSummary{
+		LongFunctions:           1,
+		HighComplexityFunctions: 1,
+		UndocumentedExported:    2,
+		GoroutineLeaks:          1,
+		CriticalIssues:          5,
+		HealthScore:             0,
+		Grade:                   "F",
+	}
+	if got != want {
+		t.Errorf("SummarizeReport() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarizeReport_EmptyReportIsHealthy(t *testing.T) {
+	got := SummarizeReport(&Report{}, 10)
+	if got.Grade != "A" || got.HealthScore != 100 {
+		t.Errorf("empty report = %+v, want grade A with score 100", got)
+	}
+}
+
+func TestHealthGrade(t *testing.T) {
+	tests := map[float64]string{100: "A", 90: "A", 89.9: "B", 80: "B", 75: "C", 60: "D", 59.9: "F", 0: "F"}
+	for score, want := range tests {
+		if got := healthGrade(score); got != want {
+			t.Errorf("healthGrade(%v) = %q, want %q", score, got, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ThresholdViolation is a single metric that broke an absolute gating threshold
+type ThresholdViolation struct {
+	Metric    string  `json:"metric"`
+	Target    string  `json:"target"`
+	File      string  `json:"file,omitempty"`
+	Line      int     `json:"line,omitempty"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+}
+
+// String formats the violation as a one-line summary with its location
+func (v ThresholdViolation) String() string {
+	location := v.Target
+	if v.File != "" {
+		location = fmt.Sprintf("%s (%s:%d)", v.Target, v.File, v.Line)
+	}
+	switch v.Metric {
+	case "documentation_coverage":
+		return fmt.Sprintf("%s: %s %.1f%% is below %.1f%%", location, v.Metric, v.Value, v.Threshold)
+	default:
+		return fmt.Sprintf("%s: %s %.0f exceeds %.0f", location, v.Metric, v.Value, v.Threshold)
+	}
+}
+
+// CheckThresholds gates a single report against absolute limits, as opposed to CompareSnapshots,
+// which judges changes between two reports. Functions whose cyclomatic complexity exceeds
+// FunctionComplexity.Error or whose code lines exceed FunctionLength.MaxLines are reported, as is
+// overall documentation coverage below Documentation.MinCoverage (a percentage). A zero
+// threshold disables its check, and suppressed functions are never reported. Violations are
+// ordered by file and line.
+func CheckThresholds(report *Report, config ThresholdConfig) []ThresholdViolation {
+	var violations []ThresholdViolation
+
+	for _, fn := range report.Functions {
+		if fn.Suppressed {
+			continue
+		}
+		target := functionTarget(fn)
+		if limit := config.FunctionComplexity.Error; limit > 0 && fn.Complexity.Cyclomatic > limit {
+			violations = append(violations, ThresholdViolation{
+				Metric: "cyclomatic_complexity", Target: target, File: fn.File, Line: fn.Line,
+				Value: float64(fn.Complexity.Cyclomatic), Threshold: float64(limit),
+			})
+		}
+		if limit := config.FunctionLength.MaxLines; limit > 0 && fn.Lines.Code > limit {
+			violations = append(violations, ThresholdViolation{
+				Metric: "function_length", Target: target, File: fn.File, Line: fn.Line,
+				Value: float64(fn.Lines.Code), Threshold: float64(limit),
+			})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+
+	if limit := config.Documentation.MinCoverage; limit > 0 && report.Documentation.Coverage.Overall < limit {
+		violations = append(violations, ThresholdViolation{
+			Metric: "documentation_coverage", Target: "overall",
+			Value: report.Documentation.Coverage.Overall, Threshold: limit,
+		})
+	}
+
+	return violations
+}
+
+// functionTarget names a function, qualifying methods with their receiver type
+func functionTarget(fn FunctionMetrics) string {
+	if fn.ReceiverType != "" {
+		return fn.ReceiverType + "." + fn.Name
+	}
+	return fn.Name
+}
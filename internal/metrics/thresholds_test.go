@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thresholdReport builds a report with two functions and 60% documentation coverage
+func thresholdReport() *Report {
+	report := &Report{
+		Functions: []FunctionMetrics{
+			{Name: "Parse", File: "b.go", Line: 40, Lines: LineMetrics{Code: 120}, Complexity: ComplexityScore{Cyclomatic: 25}},
+			{Name: "Load", ReceiverType: "Store", File: "a.go", Line: 10, Lines: LineMetrics{Code: 12}, Complexity: ComplexityScore{Cyclomatic: 4}},
+		},
+	}
+	report.Documentation.Coverage.Overall = 60
+	return report
+}
+
+func TestCheckThresholds_ZeroConfigDisablesChecks(t *testing.T) {
+	assert.Empty(t, CheckThresholds(thresholdReport(), ThresholdConfig{}))
+}
+
+func TestCheckThresholds_ReportsEachViolation(t *testing.T) {
+	var config ThresholdConfig
+	config.FunctionComplexity.Error = 3
+	config.FunctionLength.MaxLines = 100
+	config.Documentation.MinCoverage = 75
+
+	violations := CheckThresholds(thresholdReport(), config)
+	require.Len(t, violations, 4)
+
+	assert.Equal(t, ThresholdViolation{
+		Metric: "cyclomatic_complexity", Target: "Store.Load", File: "a.go", Line: 10, Value: 4, Threshold: 3,
+	}, violations[0], "violations are ordered by file and line")
+	assert.Equal(t, "cyclomatic_complexity", violations[1].Metric)
+	assert.Equal(t, "function_length", violations[2].Metric)
+	assert.Equal(t, "Parse", violations[2].Target)
+	assert.Equal(t, "documentation_coverage", violations[3].Metric)
+
+	assert.Equal(t, "Parse (b.go:40): function_length 120 exceeds 100", violations[2].String())
+	assert.Equal(t, "overall: documentation_coverage 60.0% is below 75.0%", violations[3].String())
+}
+
+func TestCheckThresholds_SkipsSuppressedFunctions(t *testing.T) {
+	report := thresholdReport()
+	report.Functions[0].Suppressed = true
+
+	var config ThresholdConfig
+	config.FunctionComplexity.Error = 10
+	config.FunctionLength.MaxLines = 100
+	assert.Empty(t, CheckThresholds(report, config))
+}
@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TreeNodeType distinguishes the directory and file nodes of the tree BuildDirectoryTree returns
+type TreeNodeType string
+
+const (
+	TreeNodeDirectory TreeNodeType = "directory"
+	TreeNodeFile      TreeNodeType = "file"
+)
+
+// TreeNode is a directory or file of the analyzed tree with its metrics rolled up. The counts of
+// a directory are the sums of its children's, so a parent's totals always equal the totals of
+// everything below it; the averages and documentation coverage are recomputed from those sums.
+type TreeNode struct {
+	Name string       `json:"name"`
+	Path string       `json:"path"`
+	Type TreeNodeType `json:"type"`
+	// Package is the package a file declares; directories leave it empty
+	Package string `json:"package,omitempty"`
+	Files   int    `json:"files"`
+	// Lines counts every line of the files; CodeLines only the code lines of their functions
+	Lines     int `json:"lines"`
+	CodeLines int `json:"code_lines"`
+	Functions int `json:"functions"`
+	Methods   int `json:"methods"`
+	// TotalComplexity sums the overall complexity of the functions and methods, which
+	// AverageComplexity divides by their number
+	TotalComplexity   float64 `json:"total_complexity"`
+	AverageComplexity float64 `json:"average_complexity"`
+	MaxComplexity     float64 `json:"max_complexity"`
+	// DocumentationCoverage is the percentage of exported functions, methods, structs, and
+	// interfaces that have a doc comment
+	ExportedSymbols       int         `json:"exported_symbols"`
+	DocumentedSymbols     int         `json:"documented_symbols"`
+	DocumentationCoverage float64     `json:"documentation_coverage"`
+	Children              []*TreeNode `json:"children,omitempty"`
+}
+
+// BuildDirectoryTree rolls the report's functions, structs, and interfaces up into a tree of
+// the directories and files in fileLines, which maps each analyzed file's relative path to
+// its line count. Files that only declare symbols are added even when fileLines lacks them.
+// Children are ordered directories first, then files, each by name, so the tree is the same
+// for the same input. It returns nil when there are no files.
+func BuildDirectoryTree(fileLines map[string]int, report *Report) *TreeNode {
+	builder := treeBuilder{files: make(map[string]*TreeNode)}
+	for path, lines := range fileLines {
+		builder.file(path).Lines = lines
+	}
+	for _, fn := range report.Functions {
+		if fn.File != "" {
+			builder.addFunction(fn)
+		}
+	}
+	for _, s := range report.Structs {
+		if s.File != "" {
+			builder.file(s.File).addSymbol(s.IsExported, s.Documentation.HasComment)
+		}
+	}
+	for _, iface := range report.Interfaces {
+		if iface.File != "" {
+			builder.file(iface.File).addSymbol(iface.IsExported, iface.Documentation.HasComment)
+		}
+	}
+	if len(builder.files) == 0 {
+		return nil
+	}
+	return builder.build()
+}
+
+// FileLines returns the line count of each file node below the tree, keyed by path, the input
+// BuildDirectoryTree needs to rebuild a tree that holds them
+func (n *TreeNode) FileLines() map[string]int {
+	lines := make(map[string]int)
+	var walk func(*TreeNode)
+	walk = func(node *TreeNode) {
+		if node.Type == TreeNodeFile {
+			lines[node.Path] = node.Lines
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	if n != nil {
+		walk(n)
+	}
+	return lines
+}
+
+// treeBuilder collects file nodes by path before they are linked into directories
+type treeBuilder struct {
+	files map[string]*TreeNode
+}
+
+// file returns the node of the file at path, creating it on first use
+func (b *treeBuilder) file(path string) *TreeNode {
+	path = filepath.Clean(path)
+	node := b.files[path]
+	if node == nil {
+		node = &TreeNode{Name: filepath.Base(path), Path: path, Type: TreeNodeFile, Files: 1}
+		b.files[path] = node
+	}
+	return node
+}
+
+// addFunction counts fn in its file's node
+func (b *treeBuilder) addFunction(fn FunctionMetrics) {
+	node := b.file(fn.File)
+	if node.Package == "" {
+		node.Package = fn.Package
+	}
+	if fn.IsMethod {
+		node.Methods++
+	} else {
+		node.Functions++
+	}
+	node.CodeLines += fn.Lines.Code
+	node.TotalComplexity += fn.Complexity.Overall
+	if fn.Complexity.Overall > node.MaxComplexity {
+		node.MaxComplexity = fn.Complexity.Overall
+	}
+	node.addSymbol(fn.IsExported, fn.Documentation.HasComment)
+}
+
+// addSymbol counts an exported symbol and whether it is documented
+func (n *TreeNode) addSymbol(exported, documented bool) {
+	if !exported {
+		return
+	}
+	n.ExportedSymbols++
+	if documented {
+		n.DocumentedSymbols++
+	}
+}
+
+// build links every file node to its directory chain under a root node for "." and sums the
+// directory totals
+func (b *treeBuilder) build() *TreeNode {
+	root := &TreeNode{Name: ".", Path: ".", Type: TreeNodeDirectory}
+	dirs := map[string]*TreeNode{".": root}
+	var dirFor func(path string) *TreeNode
+	dirFor = func(path string) *TreeNode {
+		if dir, ok := dirs[path]; ok {
+			return dir
+		}
+		dir := &TreeNode{Name: filepath.Base(path), Path: path, Type: TreeNodeDirectory}
+		dirs[path] = dir
+		parent := dirFor(filepath.Dir(path))
+		parent.Children = append(parent.Children, dir)
+		return dir
+	}
+	for path, node := range b.files {
+		if path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) || filepath.IsAbs(path) {
+			// Files outside the analyzed directory hang off the root by their full path
+			root.Children = append(root.Children, node)
+			continue
+		}
+		parent := dirFor(filepath.Dir(path))
+		parent.Children = append(parent.Children, node)
+	}
+	root.rollUp()
+	return root
+}
+
+// rollUp orders the children of a directory and sums them into it, depth first, deriving the
+// averages of every node. Summing in child order keeps the float totals reproducible.
+func (n *TreeNode) rollUp() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+		if a.Type != b.Type {
+			return a.Type == TreeNodeDirectory
+		}
+		return a.Name < b.Name || (a.Name == b.Name && a.Path < b.Path)
+	})
+	for _, child := range n.Children {
+		if child.Type == TreeNodeDirectory {
+			child.rollUp()
+		} else {
+			child.derive()
+		}
+		n.Files += child.Files
+		n.Lines += child.Lines
+		n.CodeLines += child.CodeLines
+		n.Functions += child.Functions
+		n.Methods += child.Methods
+		n.TotalComplexity += child.TotalComplexity
+		if child.MaxComplexity > n.MaxComplexity {
+			n.MaxComplexity = child.MaxComplexity
+		}
+		n.ExportedSymbols += child.ExportedSymbols
+		n.DocumentedSymbols += child.DocumentedSymbols
+	}
+	n.derive()
+}
+
+// derive computes the average complexity and documentation coverage from the node's sums
+func (n *TreeNode) derive() {
+	n.AverageComplexity = 0
+	if callables := n.Functions + n.Methods; callables > 0 {
+		n.AverageComplexity = n.TotalComplexity / float64(callables)
+	}
+	n.DocumentationCoverage = 0
+	if n.ExportedSymbols > 0 {
+		n.DocumentationCoverage = float64(n.DocumentedSymbols) / float64(n.ExportedSymbols) * 100
+	}
+}
@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedTreeReport has files two directories deep, a file at the root, and a file with no symbols
+func nestedTreeReport() (map[string]int, *Report) {
+	fileLines := map[string]int{
+		"main.go":                  20,
+		"pkg/store/store.go":       120,
+		"pkg/store/store_test.go":  60,
+		"pkg/store/cache/lru.go":   80,
+		"pkg/api/handler.go":       45,
+		"pkg/api/doc.go":           3,
+		"internal/util/strings.go": 30,
+	}
+	fn := func(name, file string, method, exported, documented bool, code int, complexity float64) FunctionMetrics {
+		return FunctionMetrics{
+			Name: name, File: file, Package: "p", IsMethod: method, IsExported: exported,
+			Lines:         LineMetrics{Code: code},
+			Complexity:    ComplexityScore{Overall: complexity},
+			Documentation: DocumentationInfo{HasComment: documented},
+		}
+	}
+	report := &Report{
+		Functions: []FunctionMetrics{
+			fn("main", "main.go", false, false, false, 10, 2),
+			fn("Get", "pkg/store/store.go", true, true, true, 30, 6.5),
+			fn("Put", "pkg/store/store.go", true, true, false, 40, 9),
+			fn("TestGet", "pkg/store/store_test.go", false, true, false, 25, 3),
+			fn("evict", "pkg/store/cache/lru.go", true, false, false, 35, 12.25),
+			fn("Serve", "pkg/api/handler.go", false, true, true, 20, 4),
+			fn("Trim", "internal/util/strings.go", false, true, true, 8, 1.5),
+		},
+		Structs: []StructMetrics{
+			{Name: "Store", File: "pkg/store/store.go", IsExported: true, Documentation: DocumentationInfo{HasComment: true}},
+			{Name: "entry", File: "pkg/store/cache/lru.go"},
+		},
+		Interfaces: []InterfaceMetrics{
+			{Name: "Handler", File: "pkg/api/handler.go", IsExported: true},
+		},
+	}
+	return fileLines, report
+}
+
+// assertRolledUp checks that every directory's totals are the sums of its children's
+func assertRolledUp(t *testing.T, node *TreeNode) {
+	t.Helper()
+	if node.Type == TreeNodeFile {
+		assert.Equal(t, 1, node.Files, node.Path)
+		assert.Empty(t, node.Children, node.Path)
+		return
+	}
+	var sum TreeNode
+	for _, child := range node.Children {
+		assertRolledUp(t, child)
+		sum.Files += child.Files
+		sum.Lines += child.Lines
+		sum.CodeLines += child.CodeLines
+		sum.Functions += child.Functions
+		sum.Methods += child.Methods
+		sum.TotalComplexity += child.TotalComplexity
+		sum.ExportedSymbols += child.ExportedSymbols
+		sum.DocumentedSymbols += child.DocumentedSymbols
+		if child.MaxComplexity > sum.MaxComplexity {
+			sum.MaxComplexity = child.MaxComplexity
+		}
+	}
+	assert.Equal(t, sum.Files, node.Files, node.Path)
+	assert.Equal(t, sum.Lines, node.Lines, node.Path)
+	assert.Equal(t, sum.CodeLines, node.CodeLines, node.Path)
+	assert.Equal(t, sum.Functions, node.Functions, node.Path)
+	assert.Equal(t, sum.Methods, node.Methods, node.Path)
+	assert.InDelta(t, sum.TotalComplexity, node.TotalComplexity, 1e-9, node.Path)
+	assert.Equal(t, sum.MaxComplexity, node.MaxComplexity, node.Path)
+	assert.Equal(t, sum.ExportedSymbols, node.ExportedSymbols, node.Path)
+	assert.Equal(t, sum.DocumentedSymbols, node.DocumentedSymbols, node.Path)
+}
+
+// childNames lists the names of a node's children in order
+func childNames(node *TreeNode) []string {
+	names := make([]string, len(node.Children))
+	for i, child := range node.Children {
+		names[i] = child.Name
+	}
+	return names
+}
+
+func TestBuildDirectoryTree_ParentTotalsEqualChildren(t *testing.T) {
+	fileLines, report := nestedTreeReport()
+
+	root := BuildDirectoryTree(fileLines, report)
+
+	require.NotNil(t, root)
+	assertRolledUp(t, root)
+
+	assert.Equal(t, ".", root.Path)
+	assert.Equal(t, TreeNodeDirectory, root.Type)
+	assert.Equal(t, 7, root.Files)
+	assert.Equal(t, 358, root.Lines)
+	assert.Equal(t, 168, root.CodeLines)
+	assert.Equal(t, 4, root.Functions)
+	assert.Equal(t, 3, root.Methods)
+	assert.InDelta(t, 38.25/7, root.AverageComplexity, 1e-9)
+	assert.Equal(t, 12.25, root.MaxComplexity)
+	assert.Equal(t, 7, root.ExportedSymbols)
+	assert.Equal(t, 4, root.DocumentedSymbols)
+	assert.InDelta(t, 400.0/7, root.DocumentationCoverage, 1e-9)
+
+	assert.Equal(t, []string{"internal", "pkg", "main.go"}, childNames(root), "directories come before files")
+	pkg := root.Children[1]
+	assert.Equal(t, []string{"api", "store"}, childNames(pkg))
+	store := pkg.Children[1]
+	assert.Equal(t, "pkg/store", store.Path)
+	assert.Equal(t, []string{"cache", "store.go", "store_test.go"}, childNames(store))
+
+	storeGo := store.Children[1]
+	assert.Equal(t, TreeNodeFile, storeGo.Type)
+	assert.Equal(t, "pkg/store/store.go", storeGo.Path)
+	assert.Equal(t, "p", storeGo.Package)
+	assert.Equal(t, 2, storeGo.Methods)
+	assert.InDelta(t, 7.75, storeGo.AverageComplexity, 1e-9)
+	assert.InDelta(t, 200.0/3, storeGo.DocumentationCoverage, 1e-9)
+
+	docGo := pkg.Children[0].Children[0]
+	assert.Equal(t, "doc.go", docGo.Name)
+	assert.Equal(t, 3, docGo.Lines)
+	assert.Zero(t, docGo.AverageComplexity, "a file without functions has no average")
+}
+
+func TestBuildDirectoryTree_Reproducible(t *testing.T) {
+	fileLines, report := nestedTreeReport()
+	first, err := json.Marshal(BuildDirectoryTree(fileLines, report))
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		again, err := json.Marshal(BuildDirectoryTree(fileLines, report))
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(again))
+	}
+
+	assert.Equal(t, fileLines, BuildDirectoryTree(fileLines, report).FileLines(),
+		"the file lines of a tree rebuild the same tree")
+	assert.Nil(t, BuildDirectoryTree(nil, &Report{}))
+}
@@ -12,6 +12,7 @@ import (
 type ConsoleReporter struct {
 	config    *config.OutputConfig
 	useColors bool
+	sections  []string
 }
 
 // sectionContent holds information for printing a standardized analysis section.
@@ -49,9 +50,16 @@ func NewConsoleReporter(cfg *config.OutputConfig) *ConsoleReporter {
 	return &ConsoleReporter{
 		config:    cfg,
 		useColors: cfg.UseColors,
+		sections:  cfg.Sections,
 	}
 }
 
+// SetSections restricts console output to the named report sections. An empty list, like "all",
+// prints every section; Generate rejects names that are not in metrics.ValidSections.
+func (cr *ConsoleReporter) SetSections(sections []string) {
+	cr.sections = sections
+}
+
 // calculateDisplayLimit returns the effective limit for displaying items,
 // capped at both the configured limit and a maximum of 10 items
 func (cr *ConsoleReporter) calculateDisplayLimit(itemCount int) int {
@@ -70,6 +78,9 @@ func (cr *ConsoleReporter) calculateDisplayLimit(itemCount int) int {
 // formatting, pagination limits, and visual separators. Output is optimized for 80-120 column terminal widths with
 // ANSI color codes for improved readability. This is the default output format when no --format flag is specified.
 func (cr *ConsoleReporter) Generate(report *metrics.Report, output io.Writer) error {
+	if err := metrics.ValidateSections(cr.sections); err != nil {
+		return err
+	}
 	cr.writeHeader(output, report)
 	cr.writeReportSections(report, output)
 	cr.writeFooter(output, report)
@@ -77,6 +88,7 @@ func (cr *ConsoleReporter) Generate(report *metrics.Report, output io.Writer) er
 }
 
 type sectionWriter struct {
+	name        string
 	shouldWrite func(*metrics.Report) bool
 	write       func(io.Writer, *metrics.Report)
 }
@@ -84,22 +96,31 @@ type sectionWriter struct {
 // writeReportSections iterates through all configured sections and writes those that should be included.
 func (cr *ConsoleReporter) writeReportSections(report *metrics.Report, output io.Writer) {
 	sections := []sectionWriter{
-		{cr.shouldWriteOverview, cr.writeOverview},
-		{cr.shouldWriteFunctionAnalysis, cr.writeFunctionAnalysis},
-		{cr.shouldWriteComplexityAnalysis, cr.writeComplexityAnalysis},
-		{cr.shouldWritePackageAnalysis, cr.writePackageAnalysis},
-		{cr.shouldWriteCircularDependencies, cr.writeCircularDependencies},
-		{cr.shouldWriteDuplicationAnalysis, cr.writeDuplicationAnalysis},
-		{cr.shouldWriteNamingAnalysis, cr.writeNamingAnalysis},
-		{cr.shouldWritePlacementAnalysis, cr.writePlacementAnalysis},
-		{cr.shouldWriteDocumentationAnalysis, cr.writeDocumentationAnalysis},
-		{cr.shouldWriteBurdenAnalysis, cr.writeBurdenAnalysis},
-		{cr.shouldWriteOrganizationAnalysis, cr.writeOrganizationAnalysis},
-		{cr.shouldWriteRefactoringSuggestions, cr.writeRefactoringSuggestions},
+		{"overview", cr.shouldWriteOverview, cr.writeOverview},
+		{"functions", cr.shouldWriteFunctionAnalysis, cr.writeFunctionAnalysis},
+		{"functions", cr.shouldWriteParamTypeComposition, cr.writeParamTypeComposition},
+		{"complexity", cr.shouldWriteComplexityAnalysis, cr.writeComplexityAnalysis},
+		{"complexity", cr.shouldWriteTestComplexity, cr.writeTestComplexity},
+		{"test_presence", cr.shouldWriteTestPresence, cr.writeTestPresence},
+		{"error_handling", cr.shouldWriteErrorHandling, cr.writeErrorHandling},
+		{"packages", cr.shouldWritePackageAnalysis, cr.writePackageAnalysis},
+		{"packages", cr.shouldWriteCircularDependencies, cr.writeCircularDependencies},
+		{"structs", cr.shouldWriteFieldTypeComposition, cr.writeFieldTypeComposition},
+		{"structs", cr.shouldWriteStructBalance, cr.writeStructBalance},
+		{"interfaces", cr.shouldWriteInterfaceAnalysis, cr.writeInterfaceAnalysis},
+		{"concurrency", cr.shouldWriteConcurrencyAnalysis, cr.writeConcurrencyAnalysis},
+		{"anti-patterns", cr.shouldWriteAntiPatternAnalysis, cr.writeAntiPatternAnalysis},
+		{"duplication", cr.shouldWriteDuplicationAnalysis, cr.writeDuplicationAnalysis},
+		{"naming", cr.shouldWriteNamingAnalysis, cr.writeNamingAnalysis},
+		{"placement", cr.shouldWritePlacementAnalysis, cr.writePlacementAnalysis},
+		{"documentation", cr.shouldWriteDocumentationAnalysis, cr.writeDocumentationAnalysis},
+		{"burden", cr.shouldWriteBurdenAnalysis, cr.writeBurdenAnalysis},
+		{"organization", cr.shouldWriteOrganizationAnalysis, cr.writeOrganizationAnalysis},
+		{"suggestions", cr.shouldWriteRefactoringSuggestions, cr.writeRefactoringSuggestions},
 	}
 
 	for _, section := range sections {
-		if section.shouldWrite(report) {
+		if metrics.IsSectionSelected(cr.sections, section.name) && section.shouldWrite(report) {
 			section.write(output, report)
 		}
 	}
@@ -115,11 +136,31 @@ func (cr *ConsoleReporter) shouldWriteFunctionAnalysis(report *metrics.Report) b
 	return cr.config.IncludeDetails && len(report.Functions) > 0
 }
 
+// shouldWriteParamTypeComposition returns true if function parameter type percentages should be included.
+func (cr *ConsoleReporter) shouldWriteParamTypeComposition(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && report.ParamTypes.TotalParams > 0
+}
+
 // shouldWriteComplexityAnalysis returns true if complexity analysis should be included.
 func (cr *ConsoleReporter) shouldWriteComplexityAnalysis(report *metrics.Report) bool {
 	return cr.config.IncludeDetails
 }
 
+// shouldWriteTestComplexity returns true if Test* function complexity should be included.
+func (cr *ConsoleReporter) shouldWriteTestComplexity(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && report.Complexity.TestFunctions.TotalTests > 0
+}
+
+// shouldWriteTestPresence returns true if the heuristic test presence correlation should be included.
+func (cr *ConsoleReporter) shouldWriteTestPresence(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && report.TestPresence != nil && report.TestPresence.TotalFunctions > 0
+}
+
+// shouldWriteErrorHandling returns true if the error return classification should be included.
+func (cr *ConsoleReporter) shouldWriteErrorHandling(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && report.ErrorHandling != nil && report.ErrorHandling.ErrorReturns > 0
+}
+
 // shouldWritePackageAnalysis returns true if package metrics should be included.
 func (cr *ConsoleReporter) shouldWritePackageAnalysis(report *metrics.Report) bool {
 	return cr.config.IncludeDetails && len(report.Packages) > 0
@@ -130,9 +171,29 @@ func (cr *ConsoleReporter) shouldWriteCircularDependencies(report *metrics.Repor
 	return cr.config.IncludeDetails && len(report.Packages) > 0
 }
 
+// shouldWriteFieldTypeComposition returns true if struct field type percentages should be included.
+func (cr *ConsoleReporter) shouldWriteFieldTypeComposition(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && report.FieldTypes.TotalFields > 0
+}
+
+// shouldWriteStructBalance returns true if struct data/behavior balance classes should be included.
+func (cr *ConsoleReporter) shouldWriteStructBalance(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && len(report.StructBalance.Counts) > 0
+}
+
+// shouldWriteInterfaceAnalysis returns true if interface metrics should be included.
+func (cr *ConsoleReporter) shouldWriteInterfaceAnalysis(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && len(report.Interfaces) > 0
+}
+
+// shouldWriteAntiPatternAnalysis returns true if detected anti-patterns should be included.
+func (cr *ConsoleReporter) shouldWriteAntiPatternAnalysis(report *metrics.Report) bool {
+	return cr.config.IncludeDetails && countAntiPatterns(report.Patterns.AntiPatterns) > 0
+}
+
 // shouldWriteDuplicationAnalysis returns true if duplication metrics should be included.
 func (cr *ConsoleReporter) shouldWriteDuplicationAnalysis(report *metrics.Report) bool {
-	return cr.config.IncludeDetails && report.Duplication.ClonePairs > 0
+	return cr.config.IncludeDetails && (report.Duplication.ClonePairs > 0 || len(report.Duplication.HelperDuplicates) > 0)
 }
 
 // shouldWriteNamingAnalysis returns true if naming violation analysis should be included.
@@ -155,7 +216,7 @@ func (cr *ConsoleReporter) shouldWriteDocumentationAnalysis(report *metrics.Repo
 
 // shouldWriteBurdenAnalysis returns true if code burden metrics should be included.
 func (cr *ConsoleReporter) shouldWriteBurdenAnalysis(report *metrics.Report) bool {
-	totalBurdenIssues := len(report.Burden.MagicNumbers) + len(report.Burden.DeadCode.UnreferencedFunctions) + len(report.Burden.DeadCode.UnreachableCode) + len(report.Burden.ComplexSignatures) + len(report.Burden.DeeplyNestedFunctions) + len(report.Burden.FeatureEnvyMethods)
+	totalBurdenIssues := len(report.Burden.MagicNumbers) + len(report.Burden.DeadCode.UnreferencedFunctions) + len(report.Burden.DeadCode.UnreachableCode) + len(report.Burden.ComplexSignatures) + len(report.Burden.DeeplyNestedFunctions) + len(report.Burden.FeatureEnvyMethods) + len(report.Burden.ComplexTypeExprs) + len(report.Burden.LongMethodChains) + len(report.Burden.UnimplementedInterfaces)
 	return cr.config.IncludeDetails && totalBurdenIssues > 0
 }
 
@@ -22,6 +22,9 @@ func (cr *ConsoleReporter) writeBurdenAnalysis(output io.Writer, report *metrics
 	fmt.Fprintf(output, "Complex Signatures: %d\n", len(burden.ComplexSignatures))
 	fmt.Fprintf(output, "Deeply Nested Functions: %d\n", len(burden.DeeplyNestedFunctions))
 	fmt.Fprintf(output, "Feature Envy Methods: %d\n", len(burden.FeatureEnvyMethods))
+	fmt.Fprintf(output, "Deeply Nested Types: %d\n", len(burden.ComplexTypeExprs))
+	fmt.Fprintf(output, "Long Method Chains: %d\n", len(burden.LongMethodChains))
+	fmt.Fprintf(output, "Unimplemented Interfaces: %d\n", len(burden.UnimplementedInterfaces))
 	fmt.Fprintln(output)
 
 	cr.writeTopBurdenIssues(output, burden)
@@ -33,6 +36,9 @@ func (cr *ConsoleReporter) writeBurdenAnalysis(output io.Writer, report *metrics
 func (cr *ConsoleReporter) writeTopBurdenIssues(output io.Writer, burden metrics.BurdenMetrics) {
 	cr.writeTopComplexSignatures(output, burden.ComplexSignatures)
 	cr.writeTopDeeplyNestedFunctions(output, burden.DeeplyNestedFunctions)
+	cr.writeTopComplexTypeExprs(output, burden.ComplexTypeExprs)
+	cr.writeTopLongMethodChains(output, burden.LongMethodChains)
+	cr.writeUnimplementedInterfaces(output, burden.UnimplementedInterfaces)
 	cr.writeTopMagicNumbers(output, burden.MagicNumbers)
 }
 
@@ -59,6 +65,87 @@ func (cr *ConsoleReporter) writeTopComplexSignatures(output io.Writer, signature
 	fmt.Fprintln(output)
 }
 
+// writeTopComplexTypeExprs displays signature types with excessive nesting
+func (cr *ConsoleReporter) writeTopComplexTypeExprs(output io.Writer, issues []metrics.TypeDepthIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	sorted := make([]metrics.TypeDepthIssue, len(issues))
+	copy(sorted, issues)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Depth > sorted[j].Depth
+	})
+
+	limit := cr.calculateDisplayLimit(len(sorted))
+	fmt.Fprintf(output, "Top %d Deeply Nested Types:\n", limit)
+	fmt.Fprintf(output, "%-30s %-10s %6s  %s\n", "Function", "Position", "Depth", "Type")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+
+	for i := 0; i < limit; i++ {
+		issue := sorted[i]
+		fmt.Fprintf(output, "%-30s %-10s %6d  %s\n",
+			cr.truncate(issue.Function, 30),
+			issue.Position,
+			issue.Depth,
+			cr.truncate(issue.TypeExpr, 40),
+		)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeUnimplementedInterfaces displays exported interfaces no analyzed type implements
+func (cr *ConsoleReporter) writeUnimplementedInterfaces(output io.Writer, interfaces []metrics.UnimplementedInterface) {
+	if len(interfaces) == 0 {
+		return
+	}
+
+	limit := cr.calculateDisplayLimit(len(interfaces))
+	fmt.Fprintf(output, "Top %d Unimplemented Interfaces:\n", limit)
+	fmt.Fprintf(output, "%-30s %-30s %8s %10s\n", "Interface", "File", "Methods", "Param Uses")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+
+	for i := 0; i < limit; i++ {
+		iface := interfaces[i]
+		fmt.Fprintf(output, "%-30s %-30s %8d %10d\n",
+			cr.truncate(iface.Package+"."+iface.Name, 30),
+			cr.truncate(fmt.Sprintf("%s:%d", iface.File, iface.Line), 30),
+			iface.MethodCount,
+			iface.ParameterUses,
+		)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeTopLongMethodChains displays the longest method call chains
+func (cr *ConsoleReporter) writeTopLongMethodChains(output io.Writer, issues []metrics.MethodChainIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	sorted := make([]metrics.MethodChainIssue, len(issues))
+	copy(sorted, issues)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Depth > sorted[j].Depth
+	})
+
+	limit := cr.calculateDisplayLimit(len(sorted))
+	fmt.Fprintf(output, "Top %d Long Method Chains:\n", limit)
+	fmt.Fprintf(output, "%-30s %-20s %6s  %s\n", "Function", "File", "Depth", "Chain")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+
+	for i := 0; i < limit; i++ {
+		issue := sorted[i]
+		fmt.Fprintf(output, "%-30s %-20s %6d  %s\n",
+			cr.truncate(issue.Function, 30),
+			cr.truncate(fmt.Sprintf("%s:%d", issue.File, issue.Line), 20),
+			issue.Depth,
+			cr.truncate(issue.Chain, 40),
+		)
+	}
+	fmt.Fprintln(output)
+}
+
 // writeTopDeeplyNestedFunctions displays functions with deep nesting
 func (cr *ConsoleReporter) writeTopDeeplyNestedFunctions(output io.Writer, nesting []metrics.NestingIssue) {
 	if len(nesting) == 0 {
@@ -0,0 +1,154 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// concurrencyPatternGroup names one category of detected concurrency patterns
+type concurrencyPatternGroup struct {
+	label     string
+	instances []metrics.PatternInstance
+}
+
+// shouldWriteConcurrencyAnalysis returns true if any goroutines, channels, select statements, concurrency patterns, or leak, lock or context warnings were found.
+func (cr *ConsoleReporter) shouldWriteConcurrencyAnalysis(report *metrics.Report) bool {
+	cp := report.Patterns.ConcurrencyPatterns
+	if cp.Goroutines.TotalCount > 0 || cp.Channels.TotalCount > 0 || len(cp.SelectStatements) > 0 || len(cp.Goroutines.GoroutineLeaks) > 0 || len(cp.SyncWarnings) > 0 || len(cp.ContextWarnings) > 0 {
+		return cr.config.IncludeDetails
+	}
+	for _, group := range concurrencyPatternGroups(cp) {
+		if len(group.instances) > 0 {
+			return cr.config.IncludeDetails
+		}
+	}
+	return false
+}
+
+// writeConcurrencyAnalysis outputs goroutine, channel and select counts, detected concurrency patterns
+// with their confidence, and goroutine leak, lock and context warnings. Empty categories are omitted.
+func (cr *ConsoleReporter) writeConcurrencyAnalysis(output io.Writer, report *metrics.Report) {
+	fmt.Fprintln(output, "=== CONCURRENCY ANALYSIS ===")
+
+	cp := report.Patterns.ConcurrencyPatterns
+	if cp.Goroutines.TotalCount > 0 {
+		fmt.Fprintf(output, "Goroutines: %d (anonymous: %d, named: %d)\n",
+			cp.Goroutines.TotalCount, cp.Goroutines.AnonymousCount, cp.Goroutines.NamedCount)
+	}
+	if cp.Channels.TotalCount > 0 {
+		fmt.Fprintf(output, "Channels: %d (buffered: %d, unbuffered: %d, directional: %d)\n",
+			cp.Channels.TotalCount, cp.Channels.BufferedCount, cp.Channels.UnbufferedCount, cp.Channels.DirectionalCount)
+	}
+	cr.writeSelectSummary(output, cp.SelectStatements)
+	fmt.Fprintln(output)
+
+	for _, group := range concurrencyPatternGroups(cp) {
+		cr.writeConcurrencyPatterns(output, group)
+	}
+	cr.writeGoroutineLeaks(output, cp.Goroutines.GoroutineLeaks)
+	cr.writeSyncWarnings(output, cp.SyncWarnings)
+	cr.writeContextWarnings(output, cp.ContextWarnings)
+}
+
+// writeSelectSummary outputs the number of select statements by kind and lists selects that can never proceed
+func (cr *ConsoleReporter) writeSelectSummary(output io.Writer, selects []metrics.SelectInstance) {
+	if len(selects) == 0 {
+		return
+	}
+
+	var nonBlocking, cancellable, timed int
+	var warnings []metrics.SelectInstance
+	for _, s := range selects {
+		if s.HasDefault {
+			nonBlocking++
+		}
+		if s.HasCancelCase {
+			cancellable++
+		}
+		if s.HasTimeoutCase {
+			timed++
+		}
+		if s.Warning != "" {
+			warnings = append(warnings, s)
+		}
+	}
+	fmt.Fprintf(output, "Select Statements: %d (non-blocking: %d, cancellable: %d, with timeout: %d)\n",
+		len(selects), nonBlocking, cancellable, timed)
+	for _, s := range warnings {
+		fmt.Fprintf(output, "  [warning] %s:%d in %s: %s\n", s.File, s.Line, s.Function, s.Warning)
+	}
+}
+
+// concurrencyPatternGroups lists the detected concurrency pattern categories in display order
+func concurrencyPatternGroups(cp metrics.ConcurrencyPatternMetrics) []concurrencyPatternGroup {
+	return []concurrencyPatternGroup{
+		{"Worker Pools", cp.WorkerPools},
+		{"Pipelines", cp.Pipelines},
+		{"Fan-In", cp.FanIn},
+		{"Fan-Out", cp.FanOut},
+		{"Semaphores", cp.Semaphores},
+	}
+}
+
+// writeConcurrencyPatterns displays one category of detected patterns with their locations and confidence
+func (cr *ConsoleReporter) writeConcurrencyPatterns(output io.Writer, group concurrencyPatternGroup) {
+	if len(group.instances) == 0 {
+		return
+	}
+
+	fmt.Fprintf(output, "%s: %d\n", group.label, len(group.instances))
+	limit := cr.calculateDisplayLimit(len(group.instances))
+	for i := 0; i < limit; i++ {
+		p := group.instances[i]
+		fmt.Fprintf(output, "  %-30s %s:%d (confidence: %.0f%%)\n",
+			cr.truncate(p.Name, 30), p.File, p.Line, p.ConfidenceScore*100)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeGoroutineLeaks displays potential goroutine leaks with their risk level
+func (cr *ConsoleReporter) writeGoroutineLeaks(output io.Writer, leaks []metrics.GoroutineLeakWarning) {
+	if len(leaks) == 0 {
+		return
+	}
+
+	fmt.Fprintf(output, "Potential Goroutine Leaks: %d\n", len(leaks))
+	limit := cr.calculateDisplayLimit(len(leaks))
+	for i := 0; i < limit; i++ {
+		leak := leaks[i]
+		fmt.Fprintf(output, "  [%s] %s:%d in %s: %s\n", leak.RiskLevel, leak.File, leak.Line, leak.Function, leak.Description)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeSyncWarnings displays lock calls that look unbalanced with their risk level
+func (cr *ConsoleReporter) writeSyncWarnings(output io.Writer, warnings []metrics.SyncWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(output, "Lock Warnings: %d\n", len(warnings))
+	limit := cr.calculateDisplayLimit(len(warnings))
+	for i := 0; i < limit; i++ {
+		w := warnings[i]
+		fmt.Fprintf(output, "  [%s] %s:%d in %s: %s\n", w.RiskLevel, w.File, w.Line, w.Function, w.Description)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeContextWarnings displays functions that do not accept or do not use a context.Context
+func (cr *ConsoleReporter) writeContextWarnings(output io.Writer, warnings []metrics.ContextWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(output, "Context Warnings: %d\n", len(warnings))
+	limit := cr.calculateDisplayLimit(len(warnings))
+	for i := 0; i < limit; i++ {
+		w := warnings[i]
+		fmt.Fprintf(output, "  [%s] %s:%d in %s: %s\n", w.Type, w.File, w.Line, w.Function, w.Description)
+	}
+	fmt.Fprintln(output)
+}
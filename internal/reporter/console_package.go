@@ -84,26 +84,26 @@ func (cr *ConsoleReporter) writeHighCouplingPackages(output io.Writer, packages
 	if len(highCouplingPkgs) > 0 {
 		fmt.Fprintln(output, "High Coupling Packages (>3 dependencies):")
 		for _, pkg := range highCouplingPkgs {
-			fmt.Fprintf(output, "  %s: %d dependencies (coupling: %.1f)\n",
-				pkg.Name, len(pkg.Dependencies), pkg.CouplingScore)
+			fmt.Fprintf(output, "  %s: %d dependencies, %d dependents (instability: %.2f)\n",
+				pkg.Name, len(pkg.Dependencies), len(pkg.Dependents), pkg.CouplingScore)
 		}
 		fmt.Fprintln(output)
 	}
 }
 
-// writeLowCohesionPackages reports packages with poor internal cohesion (<2.0)
+// writeLowCohesionPackages reports packages with poor internal cohesion (<0.5)
 func (cr *ConsoleReporter) writeLowCohesionPackages(output io.Writer, packages []metrics.PackageMetrics) {
 	var lowCohesionPkgs []metrics.PackageMetrics
 	for _, pkg := range packages {
-		if pkg.CohesionScore < 2.0 {
+		if pkg.CohesionScore < 0.5 {
 			lowCohesionPkgs = append(lowCohesionPkgs, pkg)
 		}
 	}
 
 	if len(lowCohesionPkgs) > 0 {
-		fmt.Fprintln(output, "Low Cohesion Packages (<2.0 cohesion score):")
+		fmt.Fprintln(output, "Low Cohesion Packages (<0.5 cohesion score):")
 		for _, pkg := range lowCohesionPkgs {
-			fmt.Fprintf(output, "  %s: %.1f cohesion, %d files, %d functions\n",
+			fmt.Fprintf(output, "  %s: %.2f cohesion, %d files, %d functions\n",
 				pkg.Name, pkg.CohesionScore, len(pkg.Files), pkg.Functions)
 		}
 		fmt.Fprintln(output)
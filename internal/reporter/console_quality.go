@@ -13,10 +13,23 @@ import (
 func (cr *ConsoleReporter) writeDuplicationAnalysis(output io.Writer, report *metrics.Report) {
 	fmt.Fprintln(output, "=== DUPLICATION ANALYSIS ===")
 	cr.writeDuplicationSummary(output, report.Duplication)
-	if len(report.Duplication.Clones) == 0 {
-		return
+	if len(report.Duplication.Clones) > 0 {
+		cr.writeDuplicationTable(output, report.Duplication.Clones)
+	}
+	if len(report.Duplication.HelperDuplicates) > 0 {
+		cr.writeHelperDuplicates(output, report.Duplication.HelperDuplicates)
+	}
+}
+
+// writeHelperDuplicates lists free functions that repeat a method of their parameter's type
+func (cr *ConsoleReporter) writeHelperDuplicates(output io.Writer, helpers []metrics.HelperDuplicate) {
+	limit := cr.calculateDisplayLimit(len(helpers))
+	fmt.Fprintf(output, "Helper Functions Duplicating Methods (%d shown):\n", limit)
+	for _, helper := range helpers[:limit] {
+		fmt.Fprintf(output, "  %s:%d %s duplicates %s (%.0f%% similar)\n",
+			helper.File, helper.Line, helper.Function, helper.Method, helper.Similarity*100)
 	}
-	cr.writeDuplicationTable(output, report.Duplication.Clones)
+	fmt.Fprintln(output)
 }
 
 func (cr *ConsoleReporter) writeDuplicationSummary(output io.Writer, dup metrics.DuplicationMetrics) {
@@ -377,6 +390,48 @@ func (cr *ConsoleReporter) formatSuggestedSplits(splits []string) string {
 	return result
 }
 
+// countAntiPatterns returns the total number of anti-pattern warnings across all categories
+func countAntiPatterns(ap metrics.AntiPatternMetrics) int {
+	return len(ap.GodObjects) + len(ap.LongMethods) + len(ap.DeepNesting) + len(ap.LongParameterLists) +
+		len(ap.MagicNumbers) + len(ap.NakedReturns) + len(ap.PerformanceAntipatterns)
+}
+
+// writeAntiPatternAnalysis generates anti-pattern analysis output grouped by pattern type
+func (cr *ConsoleReporter) writeAntiPatternAnalysis(output io.Writer, report *metrics.Report) {
+	fmt.Fprintln(output, "=== ANTI-PATTERN ANALYSIS ===")
+
+	ap := report.Patterns.AntiPatterns
+	fmt.Fprintf(output, "Total Anti-Patterns: %d\n", countAntiPatterns(ap))
+	fmt.Fprintf(output, "God Objects: %d\n", len(ap.GodObjects))
+	fmt.Fprintf(output, "Long Methods: %d\n", len(ap.LongMethods))
+	fmt.Fprintf(output, "Deep Nesting: %d\n", len(ap.DeepNesting))
+	fmt.Fprintf(output, "Long Parameter Lists: %d\n", len(ap.LongParameterLists))
+	fmt.Fprintf(output, "Magic Numbers: %d\n", len(ap.MagicNumbers))
+	fmt.Fprintf(output, "Naked Returns: %d\n", len(ap.NakedReturns))
+	fmt.Fprintf(output, "Performance Anti-Patterns: %d\n", len(ap.PerformanceAntipatterns))
+	fmt.Fprintln(output)
+
+	if len(ap.PerformanceAntipatterns) == 0 {
+		return
+	}
+
+	byType := make(map[string]int)
+	for _, p := range ap.PerformanceAntipatterns {
+		byType[p.Type]++
+	}
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	fmt.Fprintln(output, "Performance Anti-Patterns by Type:")
+	for _, t := range types {
+		fmt.Fprintf(output, "  %s: %d\n", t, byType[t])
+	}
+	fmt.Fprintln(output)
+}
+
 // writeDocumentationAnalysis generates documentation analysis output
 func (cr *ConsoleReporter) writeDocumentationAnalysis(output io.Writer, report *metrics.Report) {
 	fmt.Fprintln(output, "=== DOCUMENTATION ANALYSIS ===")
@@ -391,6 +446,8 @@ func (cr *ConsoleReporter) writeDocumentationAnalysis(output io.Writer, report *
 	fmt.Fprintf(output, "Method Coverage: %.1f%%\n", doc.Coverage.Methods)
 	fmt.Fprintln(output)
 
+	cr.writeCommentDensity(output, doc.CommentDensity)
+
 	// Annotation summary
 	totalAnnotations := len(doc.TODOComments) + len(doc.FIXMEComments) + len(doc.HACKComments) + len(doc.BUGComments) + len(doc.XXXComments) + len(doc.DEPRECATEDComments) + len(doc.NOTEComments)
 	if totalAnnotations > 0 {
@@ -412,6 +469,23 @@ func (cr *ConsoleReporter) writeDocumentationAnalysis(output io.Writer, report *
 	fmt.Fprintln(output)
 }
 
+// writeCommentDensity outputs the overall comment-to-code ratio and packages outside the expected range
+func (cr *ConsoleReporter) writeCommentDensity(output io.Writer, density metrics.CommentDensityMetrics) {
+	if len(density.Packages) == 0 {
+		return
+	}
+
+	fmt.Fprintf(output, "Comment-to-Code Ratio: %.1f%%\n", density.Overall*100)
+	for _, pkg := range density.Packages {
+		if pkg.Flag == "" {
+			continue
+		}
+		fmt.Fprintf(output, "  %s: %.1f%% (%s; %d comment / %d code lines)\n",
+			pkg.Name, pkg.Ratio*100, pkg.Flag, pkg.CommentLines, pkg.CodeLines)
+	}
+	fmt.Fprintln(output)
+}
+
 // annotationItem represents a code annotation with its metadata for console display.
 type annotationItem struct {
 	category string
@@ -432,7 +506,7 @@ func collectAnnotations(doc metrics.DocumentationMetrics) []annotationItem {
 		annotations = append(annotations, annotationItem{"BUG", c.File, c.Line, c.Description, "critical"})
 	}
 	for _, c := range doc.HACKComments {
-		annotations = append(annotations, annotationItem{"HACK", c.File, c.Line, c.Reason, "high"})
+		annotations = append(annotations, annotationItem{"HACK", c.File, c.Line, c.Description, "high"})
 	}
 	for _, c := range doc.TODOComments {
 		annotations = append(annotations, annotationItem{"TODO", c.File, c.Line, c.Description, "medium"})
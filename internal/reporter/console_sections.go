@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
@@ -15,9 +16,23 @@ func (cr *ConsoleReporter) writeHeader(output io.Writer, report *metrics.Report)
 	fmt.Fprintf(output, "Generated: %s\n", report.Metadata.GeneratedAt.Format("2006-01-02 15:04:05"))
 	fmt.Fprintf(output, "Analysis Time: %v\n", report.Metadata.AnalysisTime.Round(time.Millisecond))
 	fmt.Fprintf(output, "Files Processed: %d\n", report.Metadata.FilesProcessed)
+	if goVersion := report.Metadata.MinimumGoVersion; goVersion != nil && goVersion.MinimumVersion != "" {
+		fmt.Fprintf(output, "Minimum Go Version: %s (%s)\n", goVersion.MinimumVersion, strings.Join(drivingFeatures(goVersion), ", "))
+	}
 	fmt.Fprintln(output)
 }
 
+// drivingFeatures names the features that require the minimum Go version
+func drivingFeatures(goVersion *metrics.GoVersionRequirement) []string {
+	var names []string
+	for _, feature := range goVersion.Features {
+		if feature.Version == goVersion.MinimumVersion {
+			names = append(names, feature.Feature)
+		}
+	}
+	return names
+}
+
 // writeOverview outputs the overview statistics section.
 func (cr *ConsoleReporter) writeOverview(output io.Writer, report *metrics.Report) {
 	fmt.Fprintln(output, "=== OVERVIEW ===")
@@ -30,6 +45,10 @@ func (cr *ConsoleReporter) writeOverview(output io.Writer, report *metrics.Repor
 	fmt.Fprintf(output, "Total Interfaces: %d\n", overview.TotalInterfaces)
 	fmt.Fprintf(output, "Total Packages: %d\n", overview.TotalPackages)
 	fmt.Fprintf(output, "Total Files: %d\n", overview.TotalFiles)
+	if summary := report.Summary; summary.Grade != "" {
+		fmt.Fprintf(output, "Health Grade: %s (score %.1f, %d critical issues)\n",
+			summary.Grade, summary.HealthScore, summary.CriticalIssues)
+	}
 	fmt.Fprintln(output)
 }
 
@@ -55,6 +74,8 @@ func (cr *ConsoleReporter) writeFunctionAnalysis(output io.Writer, report *metri
 	fmt.Fprintf(output, "  Functions > 100 lines: %d (%.1f%%)\n", stats.VeryLongFunctions, stats.VeryLongFunctionsPct)
 	fmt.Fprintf(output, "  Average Complexity: %.1f\n", stats.AvgComplexity)
 	fmt.Fprintf(output, "  High Complexity (>10): %d functions\n", stats.HighComplexity)
+	fmt.Fprintf(output, "  Thin Wrappers: %d functions\n", stats.ThinWrappers)
+	fmt.Fprintf(output, "  Parameter-Heavy: %d functions\n", stats.ParameterHeavy)
 	fmt.Fprintln(output)
 
 	// Top complex functions
@@ -63,43 +84,259 @@ func (cr *ConsoleReporter) writeFunctionAnalysis(output io.Writer, report *metri
 	}
 }
 
-// writeComplexityAnalysis outputs the complexity analysis section with rankings.
+// writeComplexityAnalysis outputs the complexity rankings, averages and distribution computed
+// during analysis.
 func (cr *ConsoleReporter) writeComplexityAnalysis(output io.Writer, report *metrics.Report) {
-	if len(report.Functions) == 0 {
+	complexity := report.Complexity
+	if len(complexity.HighestComplexity) == 0 {
 		return
 	}
 
 	fmt.Fprintln(output, "=== COMPLEXITY ANALYSIS ===")
 
-	// Sort functions by complexity
-	sortedFunctions := make([]metrics.FunctionMetrics, len(report.Functions))
-	copy(sortedFunctions, report.Functions)
+	limit := cr.calculateDisplayLimit(len(complexity.HighestComplexity))
 
-	sort.Slice(sortedFunctions, func(i, j int) bool {
-		// Primary sort: by complexity (descending)
-		if sortedFunctions[i].Complexity.Overall != sortedFunctions[j].Complexity.Overall {
-			return sortedFunctions[i].Complexity.Overall > sortedFunctions[j].Complexity.Overall
+	fmt.Fprintf(output, "Top %d Most Complex Items:\n", limit)
+	fmt.Fprintf(output, "%-30s %-10s %-30s %10s\n", "Name", "Type", "Location", "Overall")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+
+	for _, item := range complexity.HighestComplexity[:limit] {
+		fmt.Fprintf(output, "%-30s %-10s %-30s %10.1f\n",
+			cr.truncate(item.Name, 30),
+			item.Type,
+			cr.truncate(fmt.Sprintf("%s:%d", item.File, item.Line), 30),
+			item.Complexity,
+		)
+	}
+	fmt.Fprintln(output)
+
+	fmt.Fprintf(output, "Average Function Complexity: %.1f\n", complexity.AverageFunction)
+	fmt.Fprintf(output, "Average Struct Complexity: %.1f\n", complexity.AverageStruct)
+	fmt.Fprintln(output, "Complexity Distribution:")
+	for _, bucket := range metrics.ComplexityDistributionBuckets {
+		fmt.Fprintf(output, "  %-6s %d\n", bucket+":", complexity.Distribution[bucket])
+	}
+	fmt.Fprintln(output)
+}
+
+// writeTestComplexity outputs the most complex Test* functions and those over the test threshold.
+func (cr *ConsoleReporter) writeTestComplexity(output io.Writer, report *metrics.Report) {
+	tc := report.Complexity.TestFunctions
+	fmt.Fprintln(output, "=== TEST COMPLEXITY ===")
+	fmt.Fprintf(output, "Test Functions: %d\n", tc.TotalTests)
+	fmt.Fprintf(output, "Average Cyclomatic: %.1f\n", tc.AverageCyclomatic)
+	fmt.Fprintf(output, "Over Threshold (%d): %d\n", tc.Threshold, len(tc.OverThreshold))
+	fmt.Fprintln(output)
+
+	limit := cr.calculateDisplayLimit(len(tc.MostComplex))
+	fmt.Fprintf(output, "Top %d Most Complex Tests:\n", limit)
+	fmt.Fprintf(output, "%-40s %-30s %10s\n", "Test", "File", "Cyclomatic")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+
+	for i := 0; i < limit; i++ {
+		item := tc.MostComplex[i]
+		fmt.Fprintf(output, "%-40s %-30s %10.0f\n",
+			cr.truncate(item.Name, 40),
+			cr.truncate(item.File, 30),
+			item.Complexity,
+		)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeTestPresence outputs the share of functions with a test per package and the complex
+// functions without one
+func (cr *ConsoleReporter) writeTestPresence(output io.Writer, report *metrics.Report) {
+	tp := report.TestPresence
+	fmt.Fprintln(output, "=== TEST PRESENCE (heuristic) ===")
+	fmt.Fprintf(output, "Functions With Tests: %d/%d (%.1f%%)\n", tp.TestedFunctions, tp.TotalFunctions, tp.TestedRatio*100)
+	fmt.Fprintf(output, "Untested Above Complexity %d: %d\n", tp.ComplexityThreshold, len(tp.UntestedComplex))
+	fmt.Fprintln(output)
+
+	fmt.Fprintf(output, "%-50s %10s %8s\n", "Package Directory", "Functions", "Tested")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+	for _, pkg := range tp.Packages {
+		fmt.Fprintf(output, "%-50s %10d %7.1f%%\n", cr.truncate(pkg.Directory, 50), pkg.Functions, pkg.TestedRatio*100)
+	}
+	fmt.Fprintln(output)
+
+	if len(tp.UntestedComplex) == 0 {
+		return
+	}
+	limit := cr.calculateDisplayLimit(len(tp.UntestedComplex))
+	fmt.Fprintf(output, "Top %d Untested Complex Functions:\n", limit)
+	fmt.Fprintf(output, "%-30s %-40s %10s\n", "Function", "Location", "Cyclomatic")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+	for _, fn := range tp.UntestedComplex[:limit] {
+		fmt.Fprintf(output, "%-30s %-40s %10d\n",
+			cr.truncate(fn.Name, 30),
+			cr.truncate(fmt.Sprintf("%s:%d", fn.File, fn.Line), 40),
+			fn.Cyclomatic,
+		)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeErrorHandling outputs how error returns build their errors and the functions that return
+// bare errors from several places
+func (cr *ConsoleReporter) writeErrorHandling(output io.Writer, report *metrics.Report) {
+	eh := report.ErrorHandling
+	fmt.Fprintln(output, "=== ERROR HANDLING ===")
+	fmt.Fprintf(output, "Error-Returning Functions: %d\n", eh.ErrorFunctions)
+	fmt.Fprintf(output, "Error Returns: %d (wrapped %d, bare %d, created %d, sentinel %d, other %d)\n",
+		eh.ErrorReturns, eh.WrappedReturns, eh.BareReturns, eh.CreatedReturns, eh.SentinelReturns, eh.OtherReturns)
+	fmt.Fprintf(output, "Wrapped Propagations: %.1f%%\n", eh.WrapRatio*100)
+	fmt.Fprintf(output, "Calls: errors.New %d, fmt.Errorf %d (%d with %%w), pkg/errors wrappers %d\n",
+		eh.ErrorsNewCalls, eh.ErrorfCalls, eh.ErrorfWrapCalls, eh.PkgErrorsWrapCalls)
+	fmt.Fprintln(output)
+
+	if len(eh.BareReturnFunctions) == 0 {
+		return
+	}
+	limit := cr.calculateDisplayLimit(len(eh.BareReturnFunctions))
+	fmt.Fprintf(output, "Top %d Functions Returning Bare Errors:\n", limit)
+	fmt.Fprintf(output, "%-30s %-40s %10s\n", "Function", "Location", "Bare")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+	for _, fn := range eh.BareReturnFunctions[:limit] {
+		fmt.Fprintf(output, "%-30s %-40s %10d\n",
+			cr.truncate(fn.Name, 30),
+			cr.truncate(fmt.Sprintf("%s:%d", fn.File, fn.Line), 40),
+			fn.BareReturns,
+		)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeFieldTypeComposition outputs the codebase-wide share of each struct field category.
+func (cr *ConsoleReporter) writeFieldTypeComposition(output io.Writer, report *metrics.Report) {
+	fmt.Fprintln(output, "=== STRUCT FIELD COMPOSITION ===")
+	fmt.Fprintf(output, "Total Fields: %d\n", report.FieldTypes.TotalFields)
+	fmt.Fprintf(output, "%-12s %8s %10s\n", "Field Type", "Count", "Percent")
+	fmt.Fprintln(output, "--------------------------------")
+
+	for _, fieldType := range metrics.FieldTypeOrder {
+		count := report.FieldTypes.Counts[fieldType]
+		if count == 0 {
+			continue
 		}
-		// Secondary sort: by function length (descending) when complexity is tied
-		return sortedFunctions[i].Lines.Total > sortedFunctions[j].Lines.Total
+		fmt.Fprintf(output, "%-12s %8d %9.1f%%\n", fieldType, count, report.FieldTypes.Percentages[fieldType])
+	}
+	fmt.Fprintln(output)
+}
+
+// writeParamTypeComposition outputs the codebase-wide share of each function parameter category.
+func (cr *ConsoleReporter) writeParamTypeComposition(output io.Writer, report *metrics.Report) {
+	fmt.Fprintln(output, "=== PARAMETER TYPE COMPOSITION ===")
+	fmt.Fprintf(output, "Total Parameters: %d\n", report.ParamTypes.TotalParams)
+	fmt.Fprintf(output, "%-15s %8s %10s\n", "Parameter Type", "Count", "Percent")
+	fmt.Fprintln(output, "-----------------------------------")
+
+	for _, paramType := range metrics.ParamTypeOrder {
+		count := report.ParamTypes.Counts[paramType]
+		if count == 0 {
+			continue
+		}
+		fmt.Fprintf(output, "%-15s %8d %9.1f%%\n", paramType, count, report.ParamTypes.Percentages[paramType])
+	}
+	fmt.Fprintln(output)
+}
+
+// writeStructBalance outputs how many structs are anemic, balanced, or behavior-rich with examples of each.
+func (cr *ConsoleReporter) writeStructBalance(output io.Writer, report *metrics.Report) {
+	fmt.Fprintln(output, "=== STRUCT DATA/BEHAVIOR BALANCE ===")
+	for _, balance := range metrics.StructBalanceOrder {
+		fmt.Fprintf(output, "%-20s %d\n", metrics.StructBalanceLabels[balance]+":", report.StructBalance.Counts[balance])
+	}
+
+	for _, balance := range metrics.StructBalanceOrder {
+		examples := report.StructBalance.Examples[balance]
+		if len(examples) == 0 {
+			continue
+		}
+		fmt.Fprintf(output, "\n%s examples:\n", metrics.StructBalanceLabels[balance])
+		fmt.Fprintf(output, "%-30s %-20s %8s %8s\n", "Struct", "Package", "Fields", "Methods")
+		fmt.Fprintln(output, "--------------------------------------------------------------------")
+		for _, example := range examples {
+			fmt.Fprintf(output, "%-30s %-20s %8d %8d\n",
+				cr.truncate(example.Name, 30),
+				cr.truncate(example.Package, 20),
+				example.Fields,
+				example.Methods,
+			)
+		}
+	}
+	fmt.Fprintln(output)
+}
+
+// writeInterfaceAnalysis outputs the interface analysis section ranked by method count.
+func (cr *ConsoleReporter) writeInterfaceAnalysis(output io.Writer, report *metrics.Report) {
+	fmt.Fprintln(output, "=== INTERFACE ANALYSIS ===")
+
+	sorted := make([]metrics.InterfaceMetrics, len(report.Interfaces))
+	copy(sorted, report.Interfaces)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].MethodCount != sorted[j].MethodCount {
+			return sorted[i].MethodCount > sorted[j].MethodCount
+		}
+		return sorted[i].Name < sorted[j].Name
 	})
 
-	// Show top complex functions
-	limit := cr.calculateDisplayLimit(len(sortedFunctions))
+	limit := cr.calculateDisplayLimit(len(sorted))
+	fmt.Fprintf(output, "Top %d Interfaces by Method Count:\n", limit)
+	fmt.Fprintf(output, "%-30s %-20s %8s %16s\n", "Interface", "Package", "Methods", "Implementations")
+	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
+
+	for i := 0; i < limit; i++ {
+		iface := sorted[i]
+		fmt.Fprintf(output, "%-30s %-20s %8d %16d\n",
+			cr.truncate(iface.Name, 30),
+			cr.truncate(iface.Package, 20),
+			iface.MethodCount,
+			iface.ImplementationCount,
+		)
+	}
+	fmt.Fprintln(output)
+	cr.writeOversizedInterfaceMethods(output, collectOversizedMethods(report.Interfaces))
+	cr.writeInterfaceAssertions(output, report.InterfaceAssertions)
+}
+
+// writeOversizedInterfaceMethods lists interface methods with too many parameters or returns.
+func (cr *ConsoleReporter) writeOversizedInterfaceMethods(output io.Writer, issues []metrics.SignatureIssue) {
+	if len(issues) == 0 {
+		return
+	}
 
-	fmt.Fprintf(output, "Top %d Most Complex Functions:\n", limit)
-	fmt.Fprintf(output, "%-30s %-20s %8s %10s %10s\n", "Function", "Package", "Lines", "Cyclomatic", "Overall")
+	limit := cr.calculateDisplayLimit(len(issues))
+	fmt.Fprintf(output, "Oversized Interface Methods (%d):\n", len(issues))
+	fmt.Fprintf(output, "%-40s %-25s %8s %8s %10s\n", "Method", "Location", "Params", "Returns", "Severity")
 	fmt.Fprintln(output, "--------------------------------------------------------------------------------")
 
 	for i := 0; i < limit; i++ {
-		fn := sortedFunctions[i]
-		fmt.Fprintf(output, "%-30s %-20s %8d %10d %10.1f\n",
-			cr.truncate(fn.Name, 30),
-			cr.truncate(fn.Package, 20),
-			fn.Lines.Total,
-			fn.Complexity.Cyclomatic,
-			fn.Complexity.Overall,
+		issue := issues[i]
+		fmt.Fprintf(output, "%-40s %-25s %8d %8d %10s\n",
+			cr.truncate(issue.Function, 40),
+			cr.truncate(fmt.Sprintf("%s:%d", issue.File, issue.Line), 25),
+			issue.ParameterCount,
+			issue.ReturnCount,
+			issue.Severity,
 		)
+		fmt.Fprintf(output, "  %s\n", issue.Suggestion)
+	}
+	fmt.Fprintln(output)
+}
+
+// writeInterfaceAssertions outputs compile-time interface assertion counts and any mismatches.
+func (cr *ConsoleReporter) writeInterfaceAssertions(output io.Writer, assertions metrics.InterfaceAssertionMetrics) {
+	if assertions.Total == 0 {
+		return
+	}
+	fmt.Fprintf(output, "Compile-time Assertions: %d (verified: %d, mismatched: %d, unverified: %d)\n",
+		assertions.Total, assertions.Verified, assertions.Mismatched, assertions.Unverified)
+	for _, a := range assertions.Assertions {
+		if a.Status == metrics.AssertionMismatch {
+			fmt.Fprintf(output, "  ❌ %s does not implement %s (%s:%d): missing %s\n",
+				a.Type, a.Interface, a.File, a.Line, strings.Join(a.MissingMethods, ", "))
+		}
 	}
 	fmt.Fprintln(output)
 }
@@ -207,6 +444,8 @@ type functionStats struct {
 	VeryLongFunctionsPct float64
 	AvgComplexity        float64
 	HighComplexity       int
+	ThinWrappers         int
+	ParameterHeavy       int
 }
 
 // calculateFunctionStats aggregates statistics across all functions, computing
@@ -232,6 +471,7 @@ func (cr *ConsoleReporter) calculateFunctionStats(functions []metrics.FunctionMe
 		updateLongestFunction(&stats, fn)
 		incrementLengthCounters(&stats, fn.Lines.Total)
 		incrementComplexityCounters(&stats, fn.Complexity.Cyclomatic)
+		incrementShapeCounters(&stats, fn.Shape)
 	}
 
 	count := float64(len(functions))
@@ -267,3 +507,13 @@ func incrementComplexityCounters(stats *functionStats, complexity int) {
 		stats.HighComplexity++
 	}
 }
+
+// incrementShapeCounters updates counters for thin wrappers and parameter-heavy functions.
+func incrementShapeCounters(stats *functionStats, shape metrics.FunctionShape) {
+	switch shape {
+	case metrics.FunctionShapeThinWrapper:
+		stats.ThinWrappers++
+	case metrics.FunctionShapeParameterHeavy:
+		stats.ParameterHeavy++
+	}
+}
@@ -11,364 +11,43 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestConsoleReporter_ComplexitySorting_TieBreakByLength(t *testing.T) {
-	// Create test data with functions that have the same complexity but different lengths
+func TestConsoleReporter_ComplexityAnalysisRendersReportRankings(t *testing.T) {
 	report := &metrics.Report{
-		Metadata: metrics.ReportMetadata{
-			Repository:     "test-repo",
-			GeneratedAt:    time.Now(),
-			AnalysisTime:   time.Second,
-			FilesProcessed: 5,
-			ToolVersion:    "1.0.0",
-		},
-		Overview: metrics.OverviewMetrics{
-			TotalLinesOfCode: 1000,
-			TotalFunctions:   5,
-		},
+		Metadata: metrics.ReportMetadata{Repository: "test-repo", GeneratedAt: time.Now()},
 		Functions: []metrics.FunctionMetrics{
-			{
-				Name:    "ShortFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 10,
-					Code:  8,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-			{
-				Name:    "MediumFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 50,
-					Code:  45,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-			{
-				Name:    "LongFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 100,
-					Code:  90,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-			{
-				Name:    "VeryShortFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 5,
-					Code:  4,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-			{
-				Name:    "VeryLongFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 150,
-					Code:  140,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-		},
-	}
-
-	cfg := &config.OutputConfig{
-		IncludeOverview: false,
-		IncludeDetails:  true,
-		Limit:           5,
-	}
-
-	reporter := NewConsoleReporter(cfg)
-	var buf bytes.Buffer
-	err := reporter.Generate(report, &buf)
-
-	assert.NoError(t, err)
-	output := buf.String()
-
-	// Find the complexity analysis section
-	lines := strings.Split(output, "\n")
-	var complexitySection []string
-	inComplexitySection := false
-
-	for _, line := range lines {
-		if strings.Contains(line, "=== COMPLEXITY ANALYSIS ===") {
-			inComplexitySection = true
-			continue
-		}
-		if inComplexitySection && strings.Contains(line, "===") {
-			break
-		}
-		if inComplexitySection && strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "Top") && !strings.HasPrefix(line, "Function") && !strings.HasPrefix(line, "---") {
-			complexitySection = append(complexitySection, line)
-		}
-	}
-
-	// Verify that functions are sorted by length when complexity is tied
-	// Expected order: VeryLongFunc (150), LongFunc (100), MediumFunc (50), ShortFunc (10), VeryShortFunc (5)
-	assert.True(t, len(complexitySection) >= 5, "Should have at least 5 function entries")
-
-	// Extract function names from the output
-	var functionOrder []string
-	for _, line := range complexitySection {
-		fields := strings.Fields(line)
-		if len(fields) > 0 {
-			functionOrder = append(functionOrder, fields[0])
-		}
-	}
-
-	assert.Equal(t, "VeryLongFunc", functionOrder[0], "VeryLongFunc (150 lines) should be first")
-	assert.Equal(t, "LongFunc", functionOrder[1], "LongFunc (100 lines) should be second")
-	assert.Equal(t, "MediumFunc", functionOrder[2], "MediumFunc (50 lines) should be third")
-	assert.Equal(t, "ShortFunc", functionOrder[3], "ShortFunc (10 lines) should be fourth")
-	assert.Equal(t, "VeryShortFunc", functionOrder[4], "VeryShortFunc (5 lines) should be fifth")
-}
-
-func TestConsoleReporter_ComplexitySorting_DifferentComplexities(t *testing.T) {
-	// Create test data with functions that have different complexities
-	report := &metrics.Report{
-		Metadata: metrics.ReportMetadata{
-			Repository:     "test-repo",
-			GeneratedAt:    time.Now(),
-			AnalysisTime:   time.Second,
-			FilesProcessed: 3,
-			ToolVersion:    "1.0.0",
+			{Name: "Unranked", Package: "test", File: "test.go", Complexity: metrics.ComplexityScore{Overall: 99}},
 		},
-		Overview: metrics.OverviewMetrics{
-			TotalLinesOfCode: 500,
-			TotalFunctions:   3,
-		},
-		Functions: []metrics.FunctionMetrics{
-			{
-				Name:    "LowComplexityFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 100,
-					Code:  90,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 2,
-					Overall:    2.0,
-				},
-			},
-			{
-				Name:    "HighComplexityFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 20,
-					Code:  18,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 15,
-					Overall:    15.0,
-				},
-			},
-			{
-				Name:    "MediumComplexityFunc",
-				Package: "test",
-				File:    "test.go",
-				Lines: metrics.LineMetrics{
-					Total: 50,
-					Code:  45,
-				},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 8,
-					Overall:    8.0,
-				},
+		Complexity: metrics.ComplexityMetrics{
+			AverageFunction: 7.5,
+			AverageStruct:   3.25,
+			HighestComplexity: []metrics.ComplexityItem{
+				{Name: "Complexity15", Type: "function", File: "a.go", Line: 3, Complexity: 15},
+				{Name: "Serve", Type: "method", File: "server.go", Line: 40, Complexity: 10},
+				{Name: "Config", Type: "struct", File: "config.go", Line: 8, Complexity: 4},
 			},
+			Distribution: map[string]int{"0-5": 1, "6-10": 1, "11-15": 1, "16-20": 0, "20+": 0},
 		},
 	}
 
-	cfg := &config.OutputConfig{
-		IncludeOverview: false,
-		IncludeDetails:  true,
-		Limit:           10,
-	}
-
-	reporter := NewConsoleReporter(cfg)
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10})
 	var buf bytes.Buffer
-	err := reporter.Generate(report, &buf)
-
-	assert.NoError(t, err)
+	assert.NoError(t, reporter.Generate(report, &buf))
 	output := buf.String()
 
-	// Find the complexity analysis section
-	lines := strings.Split(output, "\n")
-	var functionOrder []string
-	inComplexitySection := false
-
-	for _, line := range lines {
-		if strings.Contains(line, "=== COMPLEXITY ANALYSIS ===") {
-			inComplexitySection = true
-			continue
-		}
-		if inComplexitySection && strings.Contains(line, "===") {
-			break
-		}
-		if inComplexitySection && strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "Top") && !strings.HasPrefix(line, "Function") && !strings.HasPrefix(line, "---") {
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				functionOrder = append(functionOrder, fields[0])
-			}
-		}
+	start := strings.Index(output, "=== COMPLEXITY ANALYSIS ===")
+	assert.NotEqual(t, -1, start)
+	section := output[start:]
+	if end := strings.Index(section, "\n==="); end != -1 {
+		section = section[:end]
 	}
 
-	// When complexities are different, order should be by complexity descending
-	assert.Equal(t, "HighComplexityFunc", functionOrder[0], "HighComplexityFunc (15) should be first")
-	assert.Equal(t, "MediumComplexityFunc", functionOrder[1], "MediumComplexityFunc (8) should be second")
-	assert.Equal(t, "LowComplexityFunc", functionOrder[2], "LowComplexityFunc (2) should be third")
-}
-
-func TestConsoleReporter_ComplexitySorting_MixedTiesAndDifferent(t *testing.T) {
-	// Create test data with a mix of same and different complexities
-	report := &metrics.Report{
-		Metadata: metrics.ReportMetadata{
-			Repository:     "test-repo",
-			GeneratedAt:    time.Now(),
-			AnalysisTime:   time.Second,
-			FilesProcessed: 6,
-			ToolVersion:    "1.0.0",
-		},
-		Overview: metrics.OverviewMetrics{
-			TotalLinesOfCode: 600,
-			TotalFunctions:   6,
-		},
-		Functions: []metrics.FunctionMetrics{
-			{
-				Name:    "Complexity10_Short",
-				Package: "test",
-				File:    "test.go",
-				Lines:   metrics.LineMetrics{Total: 20},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 10,
-					Overall:    10.0,
-				},
-			},
-			{
-				Name:    "Complexity10_Long",
-				Package: "test",
-				File:    "test.go",
-				Lines:   metrics.LineMetrics{Total: 80},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 10,
-					Overall:    10.0,
-				},
-			},
-			{
-				Name:    "Complexity15",
-				Package: "test",
-				File:    "test.go",
-				Lines:   metrics.LineMetrics{Total: 30},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 15,
-					Overall:    15.0,
-				},
-			},
-			{
-				Name:    "Complexity5_Medium",
-				Package: "test",
-				File:    "test.go",
-				Lines:   metrics.LineMetrics{Total: 40},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-			{
-				Name:    "Complexity5_Short",
-				Package: "test",
-				File:    "test.go",
-				Lines:   metrics.LineMetrics{Total: 15},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 5,
-					Overall:    5.0,
-				},
-			},
-			{
-				Name:    "Complexity10_Medium",
-				Package: "test",
-				File:    "test.go",
-				Lines:   metrics.LineMetrics{Total: 50},
-				Complexity: metrics.ComplexityScore{
-					Cyclomatic: 10,
-					Overall:    10.0,
-				},
-			},
-		},
-	}
-
-	cfg := &config.OutputConfig{
-		IncludeOverview: false,
-		IncludeDetails:  true,
-		Limit:           10,
-	}
-
-	reporter := NewConsoleReporter(cfg)
-	var buf bytes.Buffer
-	err := reporter.Generate(report, &buf)
-
-	assert.NoError(t, err)
-	output := buf.String()
-
-	// Find the complexity analysis section
-	lines := strings.Split(output, "\n")
-	var functionOrder []string
-	inComplexitySection := false
-
-	for _, line := range lines {
-		if strings.Contains(line, "=== COMPLEXITY ANALYSIS ===") {
-			inComplexitySection = true
-			continue
-		}
-		if inComplexitySection && strings.Contains(line, "===") {
-			break
-		}
-		if inComplexitySection && strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "Top") && !strings.HasPrefix(line, "Function") && !strings.HasPrefix(line, "---") {
-			fields := strings.Fields(line)
-			if len(fields) > 0 {
-				functionOrder = append(functionOrder, fields[0])
-			}
-		}
-	}
-
-	// Expected order:
-	// 1. Complexity15 (complexity 15, 30 lines)
-	// 2. Complexity10_Long (complexity 10, 80 lines) - longest of complexity 10
-	// 3. Complexity10_Medium (complexity 10, 50 lines)
-	// 4. Complexity10_Short (complexity 10, 20 lines) - shortest of complexity 10
-	// 5. Complexity5_Medium (complexity 5, 40 lines) - longest of complexity 5
-	// 6. Complexity5_Short (complexity 5, 15 lines) - shortest of complexity 5
-
-	assert.Equal(t, 6, len(functionOrder), "Should have 6 functions")
-	assert.Equal(t, "Complexity15", functionOrder[0], "Complexity15 should be first")
-	assert.Equal(t, "Complexity10_Long", functionOrder[1], "Complexity10_Long (80 lines) should be second")
-	assert.Equal(t, "Complexity10_Medium", functionOrder[2], "Complexity10_Medium (50 lines) should be third")
-	assert.Equal(t, "Complexity10_Short", functionOrder[3], "Complexity10_Short (20 lines) should be fourth")
-	assert.Equal(t, "Complexity5_Medium", functionOrder[4], "Complexity5_Medium (40 lines) should be fifth")
-	assert.Equal(t, "Complexity5_Short", functionOrder[5], "Complexity5_Short (15 lines) should be sixth")
+	assert.Contains(t, section, "Top 3 Most Complex Items:")
+	assert.Regexp(t, `(?s)Complexity15\s+function\s+a\.go:3\s+15\.0.*Serve\s+method\s+server\.go:40\s+10\.0.*Config\s+struct\s+config\.go:8\s+4\.0`, section,
+		"items are listed in the order computed during analysis")
+	assert.NotContains(t, section, "Unranked", "the reporter does not re-rank report.Functions")
+	assert.Contains(t, section, "Average Function Complexity: 7.5")
+	assert.Contains(t, section, "Average Struct Complexity: 3.2")
+	assert.Regexp(t, `(?s)0-5:\s+1\n\s+6-10:\s+1\n\s+11-15:\s+1\n\s+16-20:\s+0\n\s+20\+:\s+0`, section)
 }
 
 func TestConsoleReporter_WithPlacement(t *testing.T) {
@@ -585,3 +264,294 @@ func TestConsoleReporter_PlacementSorting(t *testing.T) {
 	assert.Equal(t, "MediumSeverity_SamePackage", methodOrder[1], "Medium severity should be second")
 	assert.Equal(t, "LowSeverity_SamePackage", methodOrder[2], "Low severity should be third")
 }
+
+func TestConsoleReporter_SectionSelection(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "test-repo", GeneratedAt: time.Now()},
+		Overview: metrics.OverviewMetrics{TotalLinesOfCode: 100, TotalFunctions: 1},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Run", Package: "main", File: "main.go", Lines: metrics.LineMetrics{Code: 10}},
+		},
+		Interfaces: []metrics.InterfaceMetrics{
+			{Name: "Runner", Package: "main", MethodCount: 2},
+		},
+		Packages: []metrics.PackageMetrics{
+			{Name: "main", Path: ".", Files: []string{"main.go"}, Functions: 1},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		sections []string
+		present  []string
+		absent   []string
+	}{
+		{
+			name:     "all sections by default",
+			sections: nil,
+			present:  []string{"=== OVERVIEW ===", "=== FUNCTION ANALYSIS ===", "=== INTERFACE ANALYSIS ==="},
+		},
+		{
+			name:     "functions only",
+			sections: []string{"functions"},
+			present:  []string{"=== FUNCTION ANALYSIS ==="},
+			absent:   []string{"=== OVERVIEW ===", "=== INTERFACE ANALYSIS ===", "=== COMPLEXITY ANALYSIS ==="},
+		},
+		{
+			name:     "packages only",
+			sections: []string{"packages"},
+			present:  []string{"=== PACKAGE ANALYSIS ==="},
+			absent:   []string{"=== OVERVIEW ===", "=== FUNCTION ANALYSIS ===", "=== INTERFACE ANALYSIS ==="},
+		},
+		{
+			name:     "all keyword",
+			sections: []string{"all"},
+			present:  []string{"=== OVERVIEW ===", "=== FUNCTION ANALYSIS ===", "=== PACKAGE ANALYSIS ==="},
+		},
+		{
+			name:     "interfaces and overview",
+			sections: []string{"interfaces", "overview"},
+			present:  []string{"=== OVERVIEW ===", "=== INTERFACE ANALYSIS ==="},
+			absent:   []string{"=== FUNCTION ANALYSIS ==="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewConsoleReporter(&config.OutputConfig{
+				IncludeOverview: true,
+				IncludeDetails:  true,
+				Limit:           10,
+			})
+			reporter.SetSections(tt.sections)
+
+			var buf bytes.Buffer
+			assert.NoError(t, reporter.Generate(report, &buf))
+			output := buf.String()
+
+			for _, header := range tt.present {
+				assert.Contains(t, output, header)
+			}
+			for _, header := range tt.absent {
+				assert.NotContains(t, output, header)
+			}
+		})
+	}
+}
+
+func TestConsoleReporter_UnknownSection(t *testing.T) {
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeOverview: true, IncludeDetails: true})
+	reporter.SetSections([]string{"packages", "func"})
+
+	var buf bytes.Buffer
+	err := reporter.Generate(&metrics.Report{}, &buf)
+
+	assert.ErrorContains(t, err, `unknown section "func"`)
+	assert.Empty(t, buf.String(), "nothing is printed for an invalid selection")
+}
+
+func TestConsoleReporter_TestComplexitySection(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "test-repo", GeneratedAt: time.Now()},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Parse", Package: "p", File: "parse.go", Complexity: metrics.ComplexityScore{Cyclomatic: 3, Overall: 4}},
+			{Name: "TestParseTable", Package: "p", File: "parse_test.go", IsTestFile: true, Complexity: metrics.ComplexityScore{Cyclomatic: 25, Overall: 30}},
+		},
+		Complexity: metrics.ComplexityMetrics{
+			HighestComplexity: []metrics.ComplexityItem{
+				{Name: "Parse", Type: "function", File: "parse.go", Complexity: 4},
+			},
+			TestFunctions: metrics.TestComplexityMetrics{
+				TotalTests: 1,
+				Threshold:  15,
+				MostComplex: []metrics.ComplexityItem{
+					{Name: "TestParseTable", File: "parse_test.go", Complexity: 25},
+				},
+				OverThreshold: []metrics.ComplexityItem{
+					{Name: "TestParseTable", File: "parse_test.go", Complexity: 25},
+				},
+			},
+		},
+	}
+
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10})
+	var buf bytes.Buffer
+	assert.NoError(t, reporter.Generate(report, &buf))
+	output := buf.String()
+
+	complexityStart := strings.Index(output, "=== COMPLEXITY ANALYSIS ===")
+	testStart := strings.Index(output, "=== TEST COMPLEXITY ===")
+	assert.NotEqual(t, -1, complexityStart)
+	assert.NotEqual(t, -1, testStart)
+	assert.Less(t, complexityStart, testStart)
+
+	production := output[complexityStart:testStart]
+	assert.Contains(t, production, "Parse")
+	assert.NotContains(t, production, "TestParseTable")
+	assert.Contains(t, output[testStart:], "TestParseTable")
+	assert.Contains(t, output[testStart:], "Over Threshold (15): 1")
+}
+
+func TestConsoleReporter_ConcurrencySection(t *testing.T) {
+	report := createConcurrencyTestReport()
+	report.Patterns.ConcurrencyPatterns.Goroutines.GoroutineLeaks = []metrics.GoroutineLeakWarning{
+		{File: "main.go", Line: 60, Function: "main.listen", RiskLevel: "high", Description: "goroutine blocks on a channel that is never closed"},
+	}
+	report.Patterns.ConcurrencyPatterns.SelectStatements = []metrics.SelectInstance{
+		{File: "main.go", Line: 30, Function: "trySend", CaseCount: 1, HasDefault: true},
+		{File: "main.go", Line: 42, Function: "wait", CaseCount: 2, HasCancelCase: true, HasTimeoutCase: true},
+		{File: "main.go", Line: 70, Function: "main", Warning: "empty select blocks forever"},
+	}
+	report.Patterns.ConcurrencyPatterns.SyncWarnings = []metrics.SyncWarning{
+		{File: "main.go", Line: 80, Function: "Cache.Forget", Variable: "c.mu", Type: "missing_unlock", RiskLevel: "medium", Description: "c.mu.Lock() has no matching unlock or deferred unlock in the function"},
+	}
+	report.Patterns.ConcurrencyPatterns.ContextWarnings = []metrics.ContextWarning{
+		{File: "main.go", Line: 90, Function: "Server.Start", Type: "missing_context", Description: "starts a goroutine but the function does not accept a context.Context"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10}).Generate(report, &buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "=== CONCURRENCY ANALYSIS ===")
+	assert.Contains(t, output, "Goroutines: 2 (anonymous: 0, named: 0)")
+	assert.Contains(t, output, "Channels: 2 (buffered: 1, unbuffered: 1, directional: 0)")
+	assert.Contains(t, output, "Select Statements: 3 (non-blocking: 1, cancellable: 1, with timeout: 1)")
+	assert.Contains(t, output, "[warning] main.go:70 in main: empty select blocks forever")
+	assert.Contains(t, output, "Worker Pools: 1")
+	assert.Regexp(t, `JobProcessor\s+main\.go:20 \(confidence: 95%\)`, output)
+	assert.Contains(t, output, "Fan-In: 1")
+	assert.Contains(t, output, "Potential Goroutine Leaks: 1")
+	assert.Contains(t, output, "[high] main.go:60 in main.listen")
+	assert.Contains(t, output, "Lock Warnings: 1")
+	assert.Contains(t, output, "[medium] main.go:80 in Cache.Forget: c.mu.Lock() has no matching unlock")
+	assert.Contains(t, output, "Context Warnings: 1")
+	assert.Contains(t, output, "[missing_context] main.go:90 in Server.Start: starts a goroutine")
+
+	report.Patterns.ConcurrencyPatterns.Pipelines = nil
+	buf.Reset()
+	assert.NoError(t, NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10}).Generate(report, &buf))
+	assert.NotContains(t, buf.String(), "Pipelines:", "empty categories are omitted")
+
+	buf.Reset()
+	assert.NoError(t, NewConsoleReporter(&config.OutputConfig{IncludeOverview: true}).Generate(report, &buf))
+	assert.NotContains(t, buf.String(), "=== CONCURRENCY ANALYSIS ===")
+}
+
+func TestConsoleReporter_UnimplementedInterfaces(t *testing.T) {
+	report := &metrics.Report{
+		Burden: metrics.BurdenMetrics{
+			UnimplementedInterfaces: []metrics.UnimplementedInterface{
+				{Name: "Unused", Package: "api", File: "api.go", Line: 4, MethodCount: 2},
+			},
+		},
+	}
+
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10})
+	var buf bytes.Buffer
+	assert.NoError(t, reporter.Generate(report, &buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "=== MAINTENANCE BURDEN ===")
+	assert.Contains(t, output, "Unimplemented Interfaces: 1")
+	assert.Contains(t, output, "Top 1 Unimplemented Interfaces:")
+	assert.Regexp(t, `api\.Unused\s+api\.go:4\s+2\s+0`, output)
+}
+
+func TestConsoleReporter_OversizedInterfaceMethods(t *testing.T) {
+	report := &metrics.Report{
+		Interfaces: []metrics.InterfaceMetrics{{
+			Name: "Store", Package: "storage", File: "store.go", MethodCount: 1,
+			OversizedMethods: []metrics.SignatureIssue{{
+				Function: "Store.Save", File: "store.go", Line: 4, ParameterCount: 7, ReturnCount: 1,
+				Severity: metrics.SeverityLevelWarning, Suggestion: "Consider accepting a parameter object",
+			}},
+		}},
+	}
+
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10})
+	var buf bytes.Buffer
+	assert.NoError(t, reporter.Generate(report, &buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "Oversized Interface Methods (1):")
+	assert.Regexp(t, `Store\.Save\s+store\.go:4\s+7\s+1\s+warning`, output)
+	assert.Contains(t, output, "Consider accepting a parameter object")
+}
+
+func TestConsoleReporter_TestPresence(t *testing.T) {
+	report := &metrics.Report{
+		TestPresence: &metrics.TestPresenceMetrics{
+			TotalFunctions: 4, TestedFunctions: 3, TestedRatio: 0.75, ComplexityThreshold: 10,
+			Packages: []metrics.PackageTestPresence{
+				{Package: "orders", Directory: "orders", HasTestFiles: true, Functions: 4, TestedFunctions: 3, TestedRatio: 0.75},
+			},
+			UntestedComplex: []metrics.UntestedFunction{
+				{Name: "Discount", Package: "orders", File: "orders/cart.go", Line: 12, Cyclomatic: 14},
+			},
+		},
+	}
+
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10})
+	var buf bytes.Buffer
+	assert.NoError(t, reporter.Generate(report, &buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "=== TEST PRESENCE (heuristic) ===")
+	assert.Contains(t, output, "Functions With Tests: 3/4 (75.0%)")
+	assert.Regexp(t, `orders\s+4\s+75\.0%`, output)
+	assert.Contains(t, output, "Top 1 Untested Complex Functions:")
+	assert.Regexp(t, `Discount\s+orders/cart\.go:12\s+14`, output)
+}
+
+func TestConsoleReporter_ErrorHandling(t *testing.T) {
+	report := &metrics.Report{
+		ErrorHandling: &metrics.ErrorHandlingMetrics{
+			ErrorHandlingCounts: metrics.ErrorHandlingCounts{
+				ErrorFunctions: 3, ErrorReturns: 6, BareReturns: 3, WrappedReturns: 1, CreatedReturns: 2, WrapRatio: 0.25,
+				ErrorsNewCalls: 2, ErrorfCalls: 1, ErrorfWrapCalls: 1,
+			},
+			BareReturnFunctions: []metrics.BareErrorFunction{
+				{Name: "Copy", Package: "main", File: "copy.go", Line: 20, BareReturns: 3},
+			},
+		},
+	}
+
+	reporter := NewConsoleReporter(&config.OutputConfig{IncludeDetails: true, Limit: 10})
+	var buf bytes.Buffer
+	assert.NoError(t, reporter.Generate(report, &buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "=== ERROR HANDLING ===")
+	assert.Contains(t, output, "Error Returns: 6 (wrapped 1, bare 3, created 2, sentinel 0, other 0)")
+	assert.Contains(t, output, "Wrapped Propagations: 25.0%")
+	assert.Contains(t, output, "fmt.Errorf 1 (1 with %w)")
+	assert.Contains(t, output, "Top 1 Functions Returning Bare Errors:")
+	assert.Regexp(t, `Copy\s+copy\.go:20\s+3`, output)
+
+	buf.Reset()
+	reporter.SetSections([]string{"test_presence"})
+	assert.NoError(t, reporter.Generate(report, &buf))
+	assert.NotContains(t, buf.String(), "=== ERROR HANDLING ===")
+}
+
+func TestConsoleReporter_MinimumGoVersion(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{
+			MinimumGoVersion: &metrics.GoVersionRequirement{
+				MinimumVersion: "1.22",
+				Features: []metrics.GoVersionFeature{
+					{Feature: "range_over_int", Version: "1.22"},
+					{Feature: "package go/version", Version: "1.22"},
+					{Feature: "generics", Version: "1.18"},
+				},
+			},
+		},
+	}
+
+	reporter := NewConsoleReporter(&config.OutputConfig{})
+	var buf bytes.Buffer
+	assert.NoError(t, reporter.Generate(report, &buf))
+
+	assert.Contains(t, buf.String(), "Minimum Go Version: 1.22 (range_over_int, package go/version)")
+}
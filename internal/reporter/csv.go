@@ -9,39 +9,171 @@ import (
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
-// CSVReporter generates analysis reports in CSV format.
-type CSVReporter struct{}
+// CSVReporter generates analysis reports in CSV format and implements StreamingReporter so
+// large reports can be written section by section.
+type CSVReporter struct {
+	// skipStructs and skipPackages omit those tables; the zero value writes every table
+	skipStructs  bool
+	skipPackages bool
+	// headersWritten records the streamed sections whose title and column headers are already out
+	headersWritten map[string]bool
+	// warningsOnly makes Generate write the flattened warnings instead of the report tables
+	warningsOnly bool
+}
 
 // NewCSVReporter creates a new CSV reporter for generating analysis reports in comma-separated values format.
 // CSV output is ideal for importing into spreadsheet applications, business intelligence tools, or data pipelines.
 // Each section (functions, structs, packages) is written as a separate CSV table with appropriate headers.
 func NewCSVReporter() Reporter {
-	return &CSVReporter{}
+	return NewCSVReporterWithOptions(true, true)
 }
 
-// Generate writes the analysis report to the output writer in CSV format.
+// NewCSVReporterWithOptions creates a CSV reporter that always writes the functions table and
+// writes the structs and packages tables only when requested.
+func NewCSVReporterWithOptions(includeStructs, includePackages bool) *CSVReporter {
+	return &CSVReporter{
+		skipStructs:  !includeStructs,
+		skipPackages: !includePackages,
+	}
+}
+
+// Generate writes the analysis report to the output writer in CSV format. Each section is
+// flushed as soon as it is written, and any error from the underlying writer is returned.
+// In warnings-only mode it writes a single table of the report's warnings instead.
 func (r *CSVReporter) Generate(report *metrics.Report, output io.Writer) error {
 	writer := csv.NewWriter(output)
-	defer writer.Flush()
+	if r.warningsOnly {
+		return r.writeWarnings(writer, report)
+	}
 
 	sections := []func(*csv.Writer, *metrics.Report) error{
 		r.writeMetadataSection,
 		r.writeOverviewSection,
 		r.writeFunctionsSection,
-		r.writeStructsSection,
-		r.writePackagesSection,
-		r.writeNamingSection,
 	}
+	if !r.skipStructs {
+		sections = append(sections, r.writeStructsSection)
+	}
+	if !r.skipPackages {
+		sections = append(sections, r.writePackagesSection)
+	}
+	sections = append(sections, r.writeNamingSection)
 
 	for _, writeSection := range sections {
 		if err := writeSection(writer, report); err != nil {
 			return err
 		}
+		if err := flushCSV(writer); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// SetWarningsOnly makes Generate write only the report's warnings: a header row followed by
+// one row per warning, without section titles, so the output imports as a plain CSV file.
+func (r *CSVReporter) SetWarningsOnly(warningsOnly bool) {
+	r.warningsOnly = warningsOnly
+}
+
+// writeWarnings writes the column headers and one row per warning of the report
+func (r *CSVReporter) writeWarnings(writer *csv.Writer, report *metrics.Report) error {
+	headers := []string{"Rule ID", "Category", "Severity", "File", "Line", "Function", "Message", "Suggestion"}
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("failed to write column headers: %w", err)
+	}
+	if err := writeCSVDataRows(writer, report.AllFindings(), formatFindingRow); err != nil {
+		return err
+	}
+	return flushCSV(writer)
+}
+
+// formatFindingRow converts a Finding to a CSV row.
+func formatFindingRow(f metrics.Finding) []string {
+	return []string{
+		f.RuleID,
+		f.Category,
+		string(f.Severity),
+		f.File,
+		strconv.Itoa(f.Line),
+		f.Function,
+		f.Message,
+		f.Suggestion,
+	}
+}
+
+// BeginReport writes the metadata table for streaming output. Must be called before WriteSection.
+func (r *CSVReporter) BeginReport(output io.Writer, metadata *metrics.ReportMetadata) error {
+	r.headersWritten = make(map[string]bool)
+	writer := csv.NewWriter(output)
+	if err := r.writeMetadataSection(writer, &metrics.Report{Metadata: *metadata}); err != nil {
+		return err
+	}
+	return flushCSV(writer)
+}
+
+// WriteSection streams a batch of functions, structs, or packages to the output. The first batch
+// of a section writes its title and column headers; later batches append rows only, so callers
+// can stream large slices in chunks. Other section data has no tabular form and is skipped.
+func (r *CSVReporter) WriteSection(output io.Writer, sectionName string, sectionData interface{}) error {
+	if r.headersWritten == nil {
+		r.headersWritten = make(map[string]bool)
+	}
+	writer := csv.NewWriter(output)
+
+	var err error
+	switch data := sectionData.(type) {
+	case []metrics.FunctionMetrics:
+		err = streamRows(r, writer, sectionName, "# FUNCTIONS", functionHeaders(), data, formatFunctionRow)
+	case []metrics.StructMetrics:
+		if !r.skipStructs {
+			err = streamRows(r, writer, sectionName, "# STRUCTS", structHeaders(), data, formatStructRow)
+		}
+	case []metrics.PackageMetrics:
+		if !r.skipPackages {
+			err = streamRows(r, writer, sectionName, "# PACKAGES", packageHeaders(), data, formatPackageRow)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return flushCSV(writer)
+}
+
+// EndReport finishes streaming output. CSV has no footer, so it only resets the section state.
+func (r *CSVReporter) EndReport(output io.Writer) error {
+	r.headersWritten = nil
+	return nil
+}
+
+// streamRows writes the section title and column headers the first time sectionName is seen,
+// then the rows of this batch
+func streamRows[T any](r *CSVReporter, writer *csv.Writer, sectionName, title string, headers []string, data []T, rowFormatter func(T) []string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if !r.headersWritten[sectionName] {
+		if err := writeCSVSectionHeader(writer, title); err != nil {
+			return err
+		}
+		if err := writer.Write(headers); err != nil {
+			return fmt.Errorf("failed to write column headers: %w", err)
+		}
+		r.headersWritten[sectionName] = true
+	}
+	return writeCSVDataRows(writer, data, rowFormatter)
+}
+
+// flushCSV flushes buffered rows to the underlying writer and reports any write error
+func flushCSV(writer *csv.Writer) error {
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV output: %w", err)
+	}
+	return nil
+}
+
 // writeMetadataSection writes the metadata section to CSV output.
 func (r *CSVReporter) writeMetadataSection(writer *csv.Writer, report *metrics.Report) error {
 	if err := writer.Write([]string{"# METADATA"}); err != nil {
@@ -142,60 +274,68 @@ func formatFunctionRow(fn metrics.FunctionMetrics) []string {
 // writing headers (name, package, file, fields, methods) and detailed rows
 // for each struct with complexity metrics and field categorization.
 func (r *CSVReporter) writeStructsSection(writer *csv.Writer, report *metrics.Report) error {
-	headers := []string{
+	return writeSectionData(writer, "# STRUCTS", structHeaders(), report.Structs, formatStructRow)
+}
+
+// structHeaders returns the CSV column headers for struct metrics.
+func structHeaders() []string {
+	return []string{
 		"Name", "Package", "File", "Line", "Is Exported", "Total Fields",
 		"Methods Count", "Cyclomatic Complexity", "Overall Complexity",
 		"Has Documentation", "Documentation Quality",
 	}
+}
 
-	formatter := func(st metrics.StructMetrics) []string {
-		return []string{
-			st.Name,
-			st.Package,
-			st.File,
-			strconv.Itoa(st.Line),
-			formatBool(st.IsExported),
-			strconv.Itoa(st.TotalFields),
-			strconv.Itoa(len(st.Methods)),
-			strconv.Itoa(st.Complexity.Cyclomatic),
-			formatFloat(st.Complexity.Overall),
-			formatBool(st.Documentation.HasComment),
-			formatFloat(st.Documentation.QualityScore),
-		}
+// formatStructRow converts a StructMetrics to a CSV row.
+func formatStructRow(st metrics.StructMetrics) []string {
+	return []string{
+		st.Name,
+		st.Package,
+		st.File,
+		strconv.Itoa(st.Line),
+		formatBool(st.IsExported),
+		strconv.Itoa(st.TotalFields),
+		strconv.Itoa(len(st.Methods)),
+		strconv.Itoa(st.Complexity.Cyclomatic),
+		formatFloat(st.Complexity.Overall),
+		formatBool(st.Documentation.HasComment),
+		formatFloat(st.Documentation.QualityScore),
 	}
-
-	return writeSectionData(writer, "# STRUCTS", headers, report.Structs, formatter)
 }
 
 // writePackagesSection outputs the packages analysis section to CSV format,
 // writing headers (name, path, files, functions, structs) and detailed rows
 // for each package with dependency metrics, cohesion, and coupling scores.
 func (r *CSVReporter) writePackagesSection(writer *csv.Writer, report *metrics.Report) error {
-	headers := []string{
+	return writeSectionData(writer, "# PACKAGES", packageHeaders(), report.Packages, formatPackageRow)
+}
+
+// packageHeaders returns the CSV column headers for package metrics.
+func packageHeaders() []string {
+	return []string{
 		"Name", "Path", "Files", "Functions", "Structs", "Interfaces",
 		"Lines of Code", "Dependencies", "Dependents", "Cohesion", "Coupling",
 		"Has Documentation", "Documentation Quality",
 	}
+}
 
-	formatter := func(pkg metrics.PackageMetrics) []string {
-		return []string{
-			pkg.Name,
-			pkg.Path,
-			strconv.Itoa(len(pkg.Files)),
-			strconv.Itoa(pkg.Functions),
-			strconv.Itoa(pkg.Structs),
-			strconv.Itoa(pkg.Interfaces),
-			strconv.Itoa(pkg.Lines.Code),
-			strconv.Itoa(len(pkg.Dependencies)),
-			strconv.Itoa(len(pkg.Dependents)),
-			formatFloat(pkg.CohesionScore),
-			formatFloat(pkg.CouplingScore),
-			formatBool(pkg.Documentation.HasComment),
-			formatFloat(pkg.Documentation.QualityScore),
-		}
+// formatPackageRow converts a PackageMetrics to a CSV row.
+func formatPackageRow(pkg metrics.PackageMetrics) []string {
+	return []string{
+		pkg.Name,
+		pkg.Path,
+		strconv.Itoa(len(pkg.Files)),
+		strconv.Itoa(pkg.Functions),
+		strconv.Itoa(pkg.Structs),
+		strconv.Itoa(pkg.Interfaces),
+		strconv.Itoa(pkg.Lines.Code),
+		strconv.Itoa(len(pkg.Dependencies)),
+		strconv.Itoa(len(pkg.Dependents)),
+		formatFloat(pkg.CohesionScore),
+		formatFloat(pkg.CouplingScore),
+		formatBool(pkg.Documentation.HasComment),
+		formatFloat(pkg.Documentation.QualityScore),
 	}
-
-	return writeSectionData(writer, "# PACKAGES", headers, report.Packages, formatter)
 }
 
 // writeNamingSection outputs the naming convention analysis section to CSV format,
@@ -324,7 +464,6 @@ func (r *CSVReporter) writePackageNameIssues(writer *csv.Writer, report *metrics
 // WriteDiff writes a metrics comparison report to the output writer in CSV format.
 func (r *CSVReporter) WriteDiff(output io.Writer, diff *metrics.ComplexityDiff) error {
 	writer := csv.NewWriter(output)
-	defer writer.Flush()
 
 	if err := writer.Write([]string{"# METRICS COMPARISON REPORT"}); err != nil {
 		return fmt.Errorf("failed to write diff header: %w", err)
@@ -342,7 +481,7 @@ func (r *CSVReporter) WriteDiff(output io.Writer, diff *metrics.ComplexityDiff)
 		return err
 	}
 
-	return nil
+	return flushCSV(writer)
 }
 
 // writeDiffSummary writes the diff summary section to CSV output.
@@ -0,0 +1,199 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readCSVRecords parses CSV output, allowing the sections to have different widths
+func readCSVRecords(t *testing.T, output string) [][]string {
+	t.Helper()
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+// sectionRows returns the column headers and data rows of the section with the given title
+func sectionRows(records [][]string, title string) (headers []string, rows [][]string) {
+	for i, record := range records {
+		if len(record) != 1 || record[0] != title || i+1 >= len(records) {
+			continue
+		}
+		headers = records[i+1]
+		for _, row := range records[i+2:] {
+			if len(row) == 1 {
+				break
+			}
+			rows = append(rows, row)
+		}
+	}
+	return headers, rows
+}
+
+func TestCSVReporter_FunctionRows(t *testing.T) {
+	report := &metrics.Report{
+		Functions: []metrics.FunctionMetrics{
+			{
+				Name:       "Map[K comparable, V any]",
+				Package:    "cache",
+				File:       "cache/map.go",
+				Line:       12,
+				Lines:      metrics.LineMetrics{Total: 20, Code: 15},
+				Complexity: metrics.ComplexityScore{Cyclomatic: 4, Cognitive: 3, Overall: 6.4},
+				Signature:  metrics.FunctionSignature{ParameterCount: 2, ReturnCount: 1},
+			},
+			{Name: `say "hi"`, Package: "greet"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewCSVReporter().Generate(report, &buf))
+
+	assert.Contains(t, buf.String(), `"Map[K comparable, V any]",cache,cache/map.go,12,`)
+	assert.Contains(t, buf.String(), `"say ""hi""",greet,`)
+
+	headers, rows := sectionRows(readCSVRecords(t, buf.String()), "# FUNCTIONS")
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		assert.Len(t, row, len(headers))
+	}
+	assert.Equal(t, "Map[K comparable, V any]", rows[0][0])
+	assert.Equal(t, "20", rows[0][6])
+	assert.Equal(t, "4", rows[0][10])
+	assert.Equal(t, "3", rows[0][11])
+	assert.Equal(t, `say "hi"`, rows[1][0])
+}
+
+func TestCSVReporter_OptionalSections(t *testing.T) {
+	report := &metrics.Report{
+		Functions: []metrics.FunctionMetrics{{Name: "Run"}},
+		Structs:   []metrics.StructMetrics{{Name: "Server"}},
+		Packages:  []metrics.PackageMetrics{{Name: "server"}},
+	}
+
+	var full bytes.Buffer
+	require.NoError(t, NewCSVReporter().Generate(report, &full))
+	assert.Contains(t, full.String(), "# STRUCTS")
+	assert.Contains(t, full.String(), "# PACKAGES")
+
+	var functionsOnly bytes.Buffer
+	require.NoError(t, NewCSVReporterWithOptions(false, false).Generate(report, &functionsOnly))
+	assert.Contains(t, functionsOnly.String(), "# FUNCTIONS")
+	assert.NotContains(t, functionsOnly.String(), "# STRUCTS")
+	assert.NotContains(t, functionsOnly.String(), "# PACKAGES")
+}
+
+func TestNewReporterWithConfig_CSVTables(t *testing.T) {
+	report := &metrics.Report{
+		Structs:  []metrics.StructMetrics{{Name: "Server"}},
+		Packages: []metrics.PackageMetrics{{Name: "server"}},
+	}
+
+	cfg := config.DefaultConfig().Output
+	cfg.CSV.IncludePackages = false
+	rep, err := NewReporterWithConfig(string(TypeCSV), &cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, rep.Generate(report, &buf))
+	assert.Contains(t, buf.String(), "# STRUCTS")
+	assert.NotContains(t, buf.String(), "# PACKAGES")
+
+	defaults, err := NewReporterWithConfig(string(TypeCSV), nil)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, defaults.Generate(report, &buf))
+	assert.Contains(t, buf.String(), "# PACKAGES")
+}
+
+func TestCSVReporter_StreamingBatches(t *testing.T) {
+	r := NewCSVReporterWithOptions(true, false)
+	var buf bytes.Buffer
+
+	require.NoError(t, r.BeginReport(&buf, &metrics.ReportMetadata{Repository: "/repo"}))
+	require.NoError(t, r.WriteSection(&buf, "functions", []metrics.FunctionMetrics{{Name: "A"}, {Name: "B"}}))
+	require.NoError(t, r.WriteSection(&buf, "functions", []metrics.FunctionMetrics{{Name: "C"}}))
+	require.NoError(t, r.WriteSection(&buf, "structs", []metrics.StructMetrics{{Name: "S"}}))
+	require.NoError(t, r.WriteSection(&buf, "packages", []metrics.PackageMetrics{{Name: "p"}}))
+	require.NoError(t, r.WriteSection(&buf, "overview", metrics.OverviewMetrics{}))
+	require.NoError(t, r.EndReport(&buf))
+
+	output := buf.String()
+	assert.Equal(t, 1, strings.Count(output, "# FUNCTIONS"), "later batches append rows without repeating headers")
+	assert.NotContains(t, output, "# PACKAGES")
+
+	records := readCSVRecords(t, output)
+	assert.Equal(t, []string{"Repository", "/repo"}, records[1])
+	headers, rows := sectionRows(records, "# FUNCTIONS")
+	assert.Equal(t, functionHeaders(), headers)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "C", rows[2][0])
+	_, structRows := sectionRows(records, "# STRUCTS")
+	assert.Len(t, structRows, 1)
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestCSVReporter_ReportsWriteErrors(t *testing.T) {
+	err := NewCSVReporter().Generate(&metrics.Report{}, failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
+
+// warningsReport has one warning in each of four lists
+func warningsReport() *metrics.Report {
+	return &metrics.Report{
+		Functions: []metrics.FunctionMetrics{{Name: "Run", File: "main.go"}},
+		Patterns: metrics.PatternMetrics{
+			AntiPatterns: metrics.AntiPatternMetrics{
+				LongMethods: []metrics.AntiPatternWarning{{
+					Type: "long_method", File: "main.go", Line: 7, Function: "Run", Severity: metrics.SeverityLevelWarning,
+					Description: "Function 'Run' has 80 lines of code, over the limit of 50", Recommendation: "Extract helpers",
+				}},
+			},
+			ConcurrencyPatterns: metrics.ConcurrencyPatternMetrics{
+				Goroutines: metrics.GoroutineMetrics{
+					GoroutineLeaks: []metrics.GoroutineLeakWarning{{File: "worker.go", Line: 3, Function: "start", RiskLevel: "high"}},
+				},
+				ContextWarnings: []metrics.ContextWarning{{Type: "unused_context", File: "api.go", Line: 10, Function: "Get"}},
+			},
+		},
+		Performance: &metrics.PerformanceMetrics{
+			Warnings: []metrics.PerformanceWarning{{Type: "append_in_loop", File: "main.go", Line: 12, Function: "Run"}},
+		},
+	}
+}
+
+func TestCSVReporter_WarningsOnly(t *testing.T) {
+	report := warningsReport()
+	reporter := NewCSVReporterWithOptions(true, true)
+	reporter.SetWarningsOnly(true)
+
+	var buf bytes.Buffer
+	require.NoError(t, reporter.Generate(report, &buf))
+
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	require.NoError(t, err, "every row has the same columns and there are no section titles")
+	assert.Equal(t, []string{"Rule ID", "Category", "Severity", "File", "Line", "Function", "Message", "Suggestion"}, records[0])
+	rows := records[1:]
+	assert.Len(t, rows, len(report.AllFindings()))
+	assert.Len(t, rows, 4)
+	assert.Equal(t, []string{
+		"anti-pattern/long_method", "anti-pattern", "warning", "main.go", "7", "Run",
+		"Function 'Run' has 80 lines of code, over the limit of 50", "Extract helpers",
+	}, rows[0])
+	assert.NotContains(t, buf.String(), "# FUNCTIONS")
+}
@@ -0,0 +1,163 @@
+package reporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// DOTReporter renders the internal package dependency graph as a Graphviz DOT digraph. Each
+// analyzed package is a node sized by its function count and each internal import an edge from
+// the importing package to the imported one. Edges and nodes that take part in a dependency cycle
+// are drawn in red.
+type DOTReporter struct{}
+
+// dotEdge is an import of package to by package from
+type dotEdge struct {
+	from, to string
+}
+
+// NewDOTReporter creates a new Graphviz DOT reporter. Render its output with, for example,
+// `dot -Tsvg report.dot -o packages.svg`. Use --format dot flag to activate.
+func NewDOTReporter() *DOTReporter {
+	return &DOTReporter{}
+}
+
+// Generate writes the package dependency graph of report. Edges are taken from the Dependents
+// of each package, which the package analyzer has already resolved to analyzed packages, so
+// imports of packages outside the analysis are left out.
+func (dr *DOTReporter) Generate(report *metrics.Report, output io.Writer) error {
+	packages := make([]metrics.PackageMetrics, len(report.Packages))
+	copy(packages, report.Packages)
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Name < packages[j].Name })
+
+	known := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		known[pkg.Name] = true
+	}
+	var edges []dotEdge
+	for _, pkg := range packages {
+		for _, importer := range pkg.Dependents {
+			if known[importer] && importer != pkg.Name {
+				edges = append(edges, dotEdge{from: importer, to: pkg.Name})
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].from != edges[j].from {
+			return edges[i].from < edges[j].from
+		}
+		return edges[i].to < edges[j].to
+	})
+	component := dependencyCycles(packages, edges)
+
+	w := bufio.NewWriter(output)
+	fmt.Fprintln(w, "digraph packages {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [shape=box, style="rounded,filled", fillcolor="#e8f0fe", fontname="Helvetica"];`)
+	fmt.Fprintln(w, `  edge [color="#5f6368"];`)
+	for _, pkg := range packages {
+		width := 0.75 + 0.25*math.Sqrt(float64(pkg.Functions))
+		attrs := fmt.Sprintf(`label="%s\n%d functions", width=%.2f, height=%.2f, fontsize=%d`,
+			dotEscape(pkg.Name), pkg.Functions, width, math.Max(0.5, width/2), 10+int(2*math.Log1p(float64(pkg.Functions))))
+		if _, inCycle := component[pkg.Name]; inCycle {
+			attrs += `, color=red, penwidth=2`
+		}
+		fmt.Fprintf(w, "  %s [%s];\n", dotID(pkg.Name), attrs)
+	}
+	for _, edge := range edges {
+		from, fromCycle := component[edge.from]
+		to, toCycle := component[edge.to]
+		if fromCycle && toCycle && from == to {
+			fmt.Fprintf(w, "  %s -> %s [color=red, penwidth=2];\n", dotID(edge.from), dotID(edge.to))
+		} else {
+			fmt.Fprintf(w, "  %s -> %s;\n", dotID(edge.from), dotID(edge.to))
+		}
+	}
+	fmt.Fprintln(w, "}")
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write DOT output: %w", err)
+	}
+	return nil
+}
+
+// WriteDiff is not supported for DOT output, which describes the package graph of one report.
+func (dr *DOTReporter) WriteDiff(output io.Writer, diff *metrics.ComplexityDiff) error {
+	return fmt.Errorf("dot format is not supported for diff output")
+}
+
+// dependencyCycles finds the strongly connected components of the package graph with Tarjan's
+// algorithm and maps every package that lies on a cycle to the index of its component
+func dependencyCycles(packages []metrics.PackageMetrics, edges []dotEdge) map[string]int {
+	adjacent := make(map[string][]string)
+	for _, edge := range edges {
+		adjacent[edge.from] = append(adjacent[edge.from], edge.to)
+	}
+
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	component := make(map[string]int)
+	components := 0
+
+	var visit func(name string)
+	visit = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, next := range adjacent[name] {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				lowLink[name] = min(lowLink[name], lowLink[next])
+			} else if onStack[next] {
+				lowLink[name] = min(lowLink[name], index[next])
+			}
+		}
+		if lowLink[name] != index[name] {
+			return
+		}
+
+		var members []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members = append(members, top)
+			if top == name {
+				break
+			}
+		}
+		if len(members) > 1 {
+			for _, member := range members {
+				component[member] = components
+			}
+			components++
+		}
+	}
+
+	for _, pkg := range packages {
+		if _, seen := index[pkg.Name]; !seen {
+			visit(pkg.Name)
+		}
+	}
+	return component
+}
+
+// dotID quotes name as a DOT identifier
+func dotID(name string) string {
+	return `"` + dotEscape(name) + `"`
+}
+
+// dotEscape escapes backslashes and double quotes for use inside a quoted DOT string
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,135 @@
+package reporter
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/analyzer"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dotNodePattern = regexp.MustCompile(`^\s*"([^"]+)" \[(.*)\];$`)
+	dotEdgePattern = regexp.MustCompile(`^\s*"([^"]+)" -> "([^"]+)"(?: \[(.*)\])?;$`)
+)
+
+// parsedDOT holds the node and edge statements of a DOT digraph with their attribute lists
+type parsedDOT struct {
+	nodes map[string]string
+	edges map[[2]string]string
+}
+
+// parseDOT reads the node and edge statements of output, failing on duplicates
+func parseDOT(t *testing.T, output string) parsedDOT {
+	t.Helper()
+	require.Regexp(t, `^digraph packages \{\n`, output)
+	require.Regexp(t, `\n\}\n$`, output)
+
+	graph := parsedDOT{nodes: make(map[string]string), edges: make(map[[2]string]string)}
+	for _, line := range bytes.Split([]byte(output), []byte("\n")) {
+		if m := dotEdgePattern.FindSubmatch(line); m != nil {
+			key := [2]string{string(m[1]), string(m[2])}
+			require.NotContains(t, graph.edges, key)
+			graph.edges[key] = string(m[3])
+		} else if m := dotNodePattern.FindSubmatch(line); m != nil {
+			require.NotContains(t, graph.nodes, string(m[1]))
+			graph.nodes[string(m[1])] = string(m[2])
+		}
+	}
+	return graph
+}
+
+func TestDOTReporter_Generate(t *testing.T) {
+	root := t.TempDir()
+	sources := map[string]string{
+		"api/api.go": `package api
+
+import (
+	"fmt"
+
+	"example.com/app/store"
+)
+
+func Serve() { fmt.Println(store.Load()) }
+func Stop()  {}
+func Health() bool { return true }
+`,
+		"store/store.go": `package store
+
+import "example.com/app/model"
+
+func Load() model.Item { return model.Item{} }
+`,
+		"model/model.go": `package model
+
+import "example.com/app/store"
+
+type Item struct{}
+
+func Refresh() { store.Load() }
+`,
+		"util/util.go": `package util
+
+func Clamp(v int) int { return v }
+`,
+	}
+
+	fset := token.NewFileSet()
+	pa := analyzer.NewPackageAnalyzer(fset)
+	pa.SetModule("example.com/app", root)
+	for rel, src := range sources {
+		path := filepath.Join(root, rel)
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		require.NoError(t, err)
+		require.NoError(t, pa.AnalyzePackage(file, path))
+	}
+	packageReport, err := pa.GenerateReport()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewDOTReporter().Generate(&metrics.Report{Packages: packageReport.Packages}, &buf))
+	graph := parseDOT(t, buf.String())
+
+	assert.Len(t, graph.nodes, 4)
+	assert.Len(t, graph.edges, 3)
+	assert.Contains(t, graph.nodes["api"], `label="api\n3 functions"`)
+	assert.NotContains(t, graph.nodes["api"], "color=red")
+	assert.Contains(t, graph.nodes["store"], "color=red")
+	assert.Contains(t, graph.nodes["model"], "color=red")
+	assert.NotContains(t, graph.nodes["util"], "color=red")
+
+	assert.NotContains(t, graph.edges[[2]string{"api", "store"}], "red")
+	assert.Contains(t, graph.edges[[2]string{"store", "model"}], "color=red")
+	assert.Contains(t, graph.edges[[2]string{"model", "store"}], "color=red")
+}
+
+func TestDOTReporter_NodesGrowWithFunctionCount(t *testing.T) {
+	report := &metrics.Report{Packages: []metrics.PackageMetrics{
+		{Name: "small", Functions: 1},
+		{Name: "large", Functions: 64},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewDOTReporter().Generate(report, &buf))
+	graph := parseDOT(t, buf.String())
+
+	width := regexp.MustCompile(`width=([0-9.]+)`)
+	small := width.FindStringSubmatch(graph.nodes["small"])
+	large := width.FindStringSubmatch(graph.nodes["large"])
+	require.NotNil(t, small)
+	require.NotNil(t, large)
+	assert.Equal(t, "1.00", small[1])
+	assert.Equal(t, "2.75", large[1])
+	assert.Empty(t, graph.edges)
+}
+
+func TestDOTReporter_WriteDiffUnsupported(t *testing.T) {
+	err := NewDOTReporter().WriteDiff(&bytes.Buffer{}, &metrics.ComplexityDiff{})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/opd-ai/go-stats-generator/internal/config"
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 )
 
@@ -26,6 +27,20 @@ type StreamingReporter interface {
 	EndReport(output io.Writer) error
 }
 
+// SectionSelector defines an optional interface for reporters that can restrict their output
+// to a subset of report sections (overview, functions, complexity, packages, concurrency,
+// anti-patterns, documentation, interfaces). An empty selection emits every section.
+type SectionSelector interface {
+	SetSections(sections []string)
+}
+
+// WarningsExporter defines an optional interface for reporters that can emit only the warnings
+// of a report, flattened by Report.AllFindings into one row or object per warning, for piping
+// into issue trackers
+type WarningsExporter interface {
+	SetWarningsOnly(warningsOnly bool)
+}
+
 // Type represents the type of reporter
 type Type string
 
@@ -35,21 +50,33 @@ const (
 	TypeCSV      Type = "csv"
 	TypeHTML     Type = "html"
 	TypeMarkdown Type = "markdown"
+	// TypeJSONL emits one JSON object per line for stream processing of large reports
+	TypeJSONL Type = "jsonl"
+	// TypeDOT emits the package dependency graph in Graphviz DOT format
+	TypeDOT Type = "dot"
+	// TypeJSONPatch emits an RFC 6902 JSON Patch and is only valid for diff output
+	TypeJSONPatch Type = "jsonpatch"
 )
 
-// NewReporter creates a new reporter of the specified type (console, JSON, CSV, HTML, or Markdown).
+// NewReporter creates a new reporter of the specified type (console, JSON, JSON Lines, CSV, HTML, Markdown, or DOT).
 // Returns an error if the reporterType is unsupported or invalid. Console reporter uses default configuration
 // (colors enabled, overview included). For custom configuration, create reporters directly with their New*WithConfig constructors.
 func NewReporter(reporterType string) (Reporter, error) {
 	switch Type(reporterType) {
 	case TypeJSON:
 		return NewJSONReporter(), nil
+	case TypeJSONL:
+		return NewJSONLReporter(), nil
 	case TypeCSV:
 		return NewCSVReporter(), nil
 	case TypeHTML:
 		return NewHTMLReporter(), nil
 	case TypeMarkdown:
 		return NewMarkdownReporter(), nil
+	case TypeDOT:
+		return NewDOTReporter(), nil
+	case TypeJSONPatch:
+		return NewJSONPatchReporter(), nil
 	case TypeConsole:
 		return NewConsoleReporter(nil), nil
 	default:
@@ -57,6 +84,16 @@ func NewReporter(reporterType string) (Reporter, error) {
 	}
 }
 
+// NewReporterWithConfig creates a reporter of the specified type like NewReporter, applying the
+// format-specific settings of cfg. Currently only the csv format has such settings
+// (cfg.CSV selects its optional tables). A nil cfg behaves like NewReporter.
+func NewReporterWithConfig(reporterType string, cfg *config.OutputConfig) (Reporter, error) {
+	if cfg != nil && Type(reporterType) == TypeCSV {
+		return NewCSVReporterWithOptions(cfg.CSV.IncludeStructs, cfg.CSV.IncludePackages), nil
+	}
+	return NewReporter(reporterType)
+}
+
 // CreateReporter creates a new reporter of the specified type using typed Type enum (legacy function).
 // The options parameter is ignored in the current implementation for backward compatibility.
 // Prefer using NewReporter with string type or individual New*Reporter constructors for new code.
@@ -64,12 +101,16 @@ func CreateReporter(reporterType Type, options interface{}) Reporter {
 	switch reporterType {
 	case TypeJSON:
 		return NewJSONReporter()
+	case TypeJSONL:
+		return NewJSONLReporter()
 	case TypeCSV:
 		return NewCSVReporter()
 	case TypeHTML:
 		return NewHTMLReporter()
 	case TypeMarkdown:
 		return NewMarkdownReporter()
+	case TypeDOT:
+		return NewDOTReporter()
 	case TypeConsole:
 		fallthrough
 	default:
@@ -2,9 +2,11 @@ package reporter
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
+	"reflect"
 	"time"
 
 	"github.com/opd-ai/go-stats-generator/internal/config"
@@ -17,6 +19,10 @@ var htmlReportTemplate string
 //go:embed templates/html/diff.html
 var htmlDiffTemplate string
 
+// htmlMaxPrerenderedRows caps the rows each server-rendered table of the HTML report holds. The
+// function and struct tables are rendered client-side from the embedded report data instead.
+const htmlMaxPrerenderedRows = 100
+
 // HTMLReporterImpl generates HTML reports with interactive charts
 type HTMLReporterImpl struct {
 	config *config.OutputConfig
@@ -39,15 +45,26 @@ func NewHTMLReporterWithConfig(cfg *config.OutputConfig) *HTMLReporterImpl {
 	}
 }
 
-// Generate generates an HTML report
+// Generate generates an HTML report. The full report is embedded as a JSON data island
+// (<script type="application/json" id="report-data">) from which the page's scripts render the
+// function and struct tables and the function charts, keeping the HTML small for large codebases.
 func (hr *HTMLReporterImpl) Generate(report *metrics.Report, output io.Writer) error {
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to serialize report data: %w", err)
+	}
+
 	tmpl, err := template.New("report").Funcs(template.FuncMap{
-		"formatTime":     formatTime,
-		"formatDuration": formatDuration,
-		"formatFloat":    formatFloat,
-		"formatPercent":  formatPercent,
-		"sub":            func(a, b int) int { return a - b },
-		"subtract":       func(a, b float64) float64 { return a - b },
+		"formatTime":         formatTime,
+		"formatDuration":     formatDuration,
+		"formatFloat":        formatFloat,
+		"formatPercent":      formatPercent,
+		"fieldTypeOrder":     func() []metrics.FieldType { return metrics.FieldTypeOrder },
+		"prerendered":        prerenderedRows,
+		"oversizedMethods":   collectOversizedMethods,
+		"maxPrerenderedRows": func() int { return htmlMaxPrerenderedRows },
+		"sub":                func(a, b int) int { return a - b },
+		"subtract":           func(a, b float64) float64 { return a - b },
 		"add": func(values ...int) int {
 			sum := 0
 			for _, v := range values {
@@ -60,41 +77,74 @@ func (hr *HTMLReporterImpl) Generate(report *metrics.Report, output io.Writer) e
 		return fmt.Errorf("failed to parse embedded report template: %w", err)
 	}
 
+	// json.Marshal escapes <, > and &, so the data cannot close the script element early
 	data := struct {
-		Report *metrics.Report
-		Config *config.OutputConfig
+		Report     *metrics.Report
+		Config     *config.OutputConfig
+		ReportJSON template.JS
 	}{
-		Report: report,
-		Config: hr.config,
+		Report:     report,
+		Config:     hr.config,
+		ReportJSON: template.JS(reportJSON),
 	}
 
 	return tmpl.Execute(output, data)
 }
 
-// WriteDiff generates an HTML diff report
+// prerenderedRows returns at most the first htmlMaxPrerenderedRows elements of a slice
+func prerenderedRows(items interface{}) interface{} {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice || v.Len() <= htmlMaxPrerenderedRows {
+		return items
+	}
+	return v.Slice(0, htmlMaxPrerenderedRows).Interface()
+}
+
+// WriteDiff generates an HTML diff report: a summary banner with the quality score, the change
+// in overall quality and the trend, before and after tables for each function whose complexity
+// changed, color-coded regression and improvement tables, and a chart of how the changes are
+// distributed by magnitude, drawn from a JSON data island (<script type="application/json"
+// id="diff-data">).
 func (hr *HTMLReporterImpl) WriteDiff(output io.Writer, diff *metrics.ComplexityDiff) error {
+	chartJSON, err := magnitudeChart(diff)
+	if err != nil {
+		return err
+	}
+
 	tmpl, err := template.New("diff").Funcs(template.FuncMap{
-		"formatTime":     formatTime,
-		"formatDuration": formatDuration,
-		"formatFloat":    formatFloat,
-		"formatValue":    formatValue,
-		"formatPercent":  formatPercent,
-		"formatChange":   formatChange,
-		"changeClass":    changeClass,
-		"severityClass":  severityClass,
-		"thresholdClass": thresholdClass,
-		"trendClass":     trendClass,
+		"formatTime":         formatTime,
+		"formatDuration":     formatDuration,
+		"formatFloat":        formatFloat,
+		"formatValue":        formatValue,
+		"formatPercent":      formatPercent,
+		"formatChange":       formatChange,
+		"signedChange":       signedChange,
+		"signedFloat":        signedFloat,
+		"qualityDeltaClass":  qualityDeltaClass,
+		"changeClass":        changeClass,
+		"severityClass":      severityClass,
+		"thresholdClass":     thresholdClass,
+		"trendClass":         trendClass,
+		"metricRows":         functionMetricRows,
+		"maxPrerenderedRows": func() int { return htmlMaxPrerenderedRows },
 	}).Parse(htmlDiffTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse embedded diff template: %w", err)
 	}
 
+	functions, totalFunctions := changedFunctions(diff)
 	data := struct {
-		Diff   *metrics.ComplexityDiff
-		Config *config.OutputConfig
+		Diff           *metrics.ComplexityDiff
+		Config         *config.OutputConfig
+		Functions      []htmlFunctionDiff
+		TotalFunctions int
+		ChartJSON      template.JS
 	}{
-		Diff:   diff,
-		Config: hr.config,
+		Diff:           diff,
+		Config:         hr.config,
+		Functions:      functions,
+		TotalFunctions: totalFunctions,
+		ChartJSON:      chartJSON,
 	}
 
 	return tmpl.Execute(output, data)
@@ -0,0 +1,211 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// htmlMagnitudeOrder lists the change magnitudes from smallest to largest, the x-axis of the
+// change magnitude chart of the HTML diff report
+var htmlMagnitudeOrder = []metrics.ChangeMagnitude{
+	metrics.ChangeMagnitudeMinor,
+	metrics.ChangeMagnitudeModerate,
+	metrics.ChangeMagnitudeSignificant,
+	metrics.ChangeMagnitudeMajor,
+	metrics.ChangeMagnitudeCritical,
+}
+
+// htmlFunctionDiff pairs the baseline and current metrics of a function whose complexity changed
+type htmlFunctionDiff struct {
+	Path   string
+	Before metrics.FunctionMetrics
+	After  metrics.FunctionMetrics
+	// Class is "regression", "improvement" or "neutral", from how the diff classified the function
+	Class string
+}
+
+// htmlMetricRow is one metric of a changed function, shown in its before and after tables
+type htmlMetricRow struct {
+	Name   string
+	Before string
+	After  string
+	// Class colors the after value: "increase" when it got worse, "decrease" when it got better
+	Class string
+}
+
+// htmlMagnitudeChart is the change magnitude distribution, serialized for the diff page's chart
+type htmlMagnitudeChart struct {
+	Labels       []metrics.ChangeMagnitude `json:"labels"`
+	Regressions  []int                     `json:"regressions"`
+	Improvements []int                     `json:"improvements"`
+	Neutral      []int                     `json:"neutral"`
+}
+
+// changeKey identifies a change by the entity it concerns and what changed, the fields that a
+// Regression or Improvement built from a MetricChange keeps
+func changeKey(path, description string) string {
+	return path + "\x00" + description
+}
+
+// classifyChanges returns the keys of the changes the diff reports as regressions and as
+// improvements, and the entity paths with at least one of each
+func classifyChanges(diff *metrics.ComplexityDiff) (regressed, improved map[string]bool) {
+	regressed = make(map[string]bool)
+	improved = make(map[string]bool)
+	for _, r := range diff.Regressions {
+		regressed[changeKey(r.Location, r.Description)] = true
+		regressed[r.Location] = true
+	}
+	for _, i := range diff.Improvements {
+		improved[changeKey(i.Location, i.Description)] = true
+		improved[i.Location] = true
+	}
+	return regressed, improved
+}
+
+// changedFunctions returns the functions present in both snapshots whose complexity changed,
+// regressions first, then improvements, then neutral changes, each group ordered by path. At most
+// htmlMaxPrerenderedRows functions are returned, along with the total.
+func changedFunctions(diff *metrics.ComplexityDiff) ([]htmlFunctionDiff, int) {
+	before := functionsByPath(diff.Baseline.Report.Functions)
+	after := functionsByPath(diff.Current.Report.Functions)
+	regressed, improved := classifyChanges(diff)
+
+	seen := make(map[string]bool)
+	var functions []htmlFunctionDiff
+	for _, change := range diff.Changes {
+		if change.Category != "function_complexity" && change.Category != "function_overall_complexity" {
+			continue
+		}
+		b, inBefore := before[change.Path]
+		a, inAfter := after[change.Path]
+		if seen[change.Path] || !inBefore || !inAfter {
+			continue
+		}
+		seen[change.Path] = true
+
+		class := "neutral"
+		if regressed[change.Path] {
+			class = "regression"
+		} else if improved[change.Path] {
+			class = "improvement"
+		}
+		functions = append(functions, htmlFunctionDiff{Path: change.Path, Before: b, After: a, Class: class})
+	}
+
+	rank := map[string]int{"regression": 0, "improvement": 1, "neutral": 2}
+	sort.Slice(functions, func(i, j int) bool {
+		if rank[functions[i].Class] != rank[functions[j].Class] {
+			return rank[functions[i].Class] < rank[functions[j].Class]
+		}
+		return functions[i].Path < functions[j].Path
+	})
+
+	total := len(functions)
+	if total > htmlMaxPrerenderedRows {
+		functions = functions[:htmlMaxPrerenderedRows]
+	}
+	return functions, total
+}
+
+// functionsByPath indexes functions by "package.name", the path diff changes refer to them by
+func functionsByPath(functions []metrics.FunctionMetrics) map[string]metrics.FunctionMetrics {
+	byPath := make(map[string]metrics.FunctionMetrics, len(functions))
+	for _, f := range functions {
+		byPath[fmt.Sprintf("%s.%s", f.Package, f.Name)] = f
+	}
+	return byPath
+}
+
+// functionMetricRows lists the metrics compared in the before and after tables of a changed
+// function. Every metric is one where a higher value is worse.
+func functionMetricRows(f htmlFunctionDiff) []htmlMetricRow {
+	rows := []struct {
+		name          string
+		before, after float64
+		format        func(float64) string
+	}{
+		{"Cyclomatic", float64(f.Before.Complexity.Cyclomatic), float64(f.After.Complexity.Cyclomatic), formatCount},
+		{"Cognitive", float64(f.Before.Complexity.Cognitive), float64(f.After.Complexity.Cognitive), formatCount},
+		{"Nesting Depth", float64(f.Before.Complexity.NestingDepth), float64(f.After.Complexity.NestingDepth), formatCount},
+		{"Overall", f.Before.Complexity.Overall, f.After.Complexity.Overall, formatFloat},
+		{"Code Lines", float64(f.Before.Lines.Code), float64(f.After.Lines.Code), formatCount},
+		{"Parameters", float64(f.Before.Signature.ParameterCount), float64(f.After.Signature.ParameterCount), formatCount},
+	}
+
+	result := make([]htmlMetricRow, len(rows))
+	for i, row := range rows {
+		result[i] = htmlMetricRow{
+			Name:   row.name,
+			Before: row.format(row.before),
+			After:  row.format(row.after),
+			Class:  changeClass(row.after - row.before),
+		}
+	}
+	return result
+}
+
+// formatCount formats a whole-number metric
+func formatCount(f float64) string {
+	return fmt.Sprintf("%.0f", f)
+}
+
+// magnitudeChart counts the diff's changes by magnitude, split into regressions, improvements,
+// and neutral changes, and returns the counts as JSON for the change magnitude chart
+func magnitudeChart(diff *metrics.ComplexityDiff) (template.JS, error) {
+	regressed, improved := classifyChanges(diff)
+	index := make(map[metrics.ChangeMagnitude]int, len(htmlMagnitudeOrder))
+	for i, m := range htmlMagnitudeOrder {
+		index[m] = i
+	}
+
+	chart := htmlMagnitudeChart{
+		Labels:       htmlMagnitudeOrder,
+		Regressions:  make([]int, len(htmlMagnitudeOrder)),
+		Improvements: make([]int, len(htmlMagnitudeOrder)),
+		Neutral:      make([]int, len(htmlMagnitudeOrder)),
+	}
+	for _, change := range diff.Changes {
+		i, ok := index[change.Delta.Magnitude]
+		if !ok {
+			i = 0
+		}
+		switch key := changeKey(change.Path, change.Description); {
+		case regressed[key]:
+			chart.Regressions[i]++
+		case improved[key]:
+			chart.Improvements[i]++
+		default:
+			chart.Neutral[i]++
+		}
+	}
+
+	// json.Marshal escapes <, > and &, so the data cannot close the script element early
+	data, err := json.Marshal(chart)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize change magnitude data: %w", err)
+	}
+	return template.JS(data), nil
+}
+
+// signedChange formats the percentage of delta with the sign of its direction
+func signedChange(delta metrics.Delta) string {
+	if delta.Direction == metrics.ChangeDirectionDecrease {
+		return formatChange(-delta.Percentage)
+	}
+	return formatChange(delta.Percentage)
+}
+
+// signedFloat formats f with two decimal places and an explicit sign
+func signedFloat(f float64) string {
+	return fmt.Sprintf("%+.2f", f)
+}
+
+// qualityDeltaClass returns the CSS class for a change in quality, where an increase is better
+func qualityDeltaClass(delta float64) string {
+	return changeClass(-delta)
+}
@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// diffFunction returns a function of package app with the given cyclomatic complexity
+func diffFunction(name string, cyclomatic int) metrics.FunctionMetrics {
+	return metrics.FunctionMetrics{
+		Name:       name,
+		Package:    "app",
+		File:       "app/" + name + ".go",
+		Line:       10,
+		Lines:      metrics.LineMetrics{Code: cyclomatic * 3},
+		Complexity: metrics.ComplexityScore{Cyclomatic: cyclomatic, Overall: float64(cyclomatic) * 1.5},
+	}
+}
+
+func TestHTMLReporter_WriteDiffRendersRegressionsAndQuality(t *testing.T) {
+	baseline := metrics.Snapshot{ID: "base", Metadata: metrics.SnapshotMetadata{Timestamp: time.Now().Add(-time.Hour)}}
+	baseline.Report.Functions = []metrics.FunctionMetrics{diffFunction("Handle", 4), diffFunction("Parse", 20)}
+	baseline.Report.Scores.OverallQuality = 72.5
+	current := metrics.Snapshot{ID: "head", Metadata: metrics.SnapshotMetadata{Timestamp: time.Now()}}
+	current.Report.Functions = []metrics.FunctionMetrics{diffFunction("Handle", 18), diffFunction("Parse", 5)}
+	current.Report.Scores.OverallQuality = 70
+
+	diff, err := metrics.CompareSnapshots(baseline, current, metrics.DefaultThresholdConfig())
+	require.NoError(t, err)
+	require.NotEmpty(t, diff.Regressions)
+	assert.InDelta(t, -2.5, diff.Summary.QualityDelta, 1e-9)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewHTMLReporterWithConfig(nil).WriteDiff(&buf, diff))
+	page := buf.String()
+
+	assert.Contains(t, page, formatFloat(diff.Summary.QualityScore)+"/100", "quality score in the banner")
+	assert.Contains(t, page, "-2.50", "quality delta in the banner")
+	assert.Contains(t, page, "72.50 &rarr; 70.00")
+	assert.Contains(t, page, `class="diff-banner `+trendClass(diff.Summary.OverallTrend)+`"`)
+
+	for _, regression := range diff.Regressions {
+		assert.Contains(t, page, regression.Location)
+		assert.Contains(t, page, regression.Description)
+	}
+	assert.Regexp(t, `<tr class="regression-row">\s*<td>`+regexp.QuoteMeta(string(diff.Regressions[0].Type)), page)
+
+	// Each changed function gets before and after tables, the regressed one listed first and
+	// marked as a regression
+	assert.Regexp(t, `(?s)function-diff regression-row">\s*<h3>app\.Handle.*function-diff improvement-row">\s*<h3>app\.Parse`, page)
+	assert.Regexp(t, `<td>Cyclomatic</td><td class="increase">18</td>`, page)
+	assert.Regexp(t, `<td>Cyclomatic</td><td class="decrease">5</td>`, page)
+
+	match := regexp.MustCompile(`<script type="application/json" id="diff-data">(.*?)</script>`).FindStringSubmatch(page)
+	require.Len(t, match, 2, "the chart data island is embedded")
+	var chart htmlMagnitudeChart
+	require.NoError(t, json.Unmarshal([]byte(match[1]), &chart))
+	assert.Equal(t, htmlMagnitudeOrder, chart.Labels)
+	total := 0
+	for i := range chart.Labels {
+		total += chart.Regressions[i] + chart.Improvements[i] + chart.Neutral[i]
+	}
+	assert.Equal(t, len(diff.Changes), total, "every change is counted in the magnitude chart")
+}
+
+func TestHTMLReporter_WriteDiffWithoutChanges(t *testing.T) {
+	diff := &metrics.ComplexityDiff{
+		Baseline: metrics.Snapshot{ID: "base"},
+		Current:  metrics.Snapshot{ID: "head"},
+		Summary:  metrics.DiffSummary{QualityScore: 100, OverallTrend: metrics.TrendStable},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewHTMLReporterWithConfig(&config.OutputConfig{IncludeDetails: true}).WriteDiff(&buf, diff))
+	page := buf.String()
+	assert.Contains(t, page, "100.00/100")
+	assert.NotContains(t, page, `id="magnitudeChart"`, "no chart is drawn without changes")
+	assert.NotContains(t, page, "Changed Functions")
+}
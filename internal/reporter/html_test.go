@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -351,6 +352,55 @@ func TestHTMLReporterChartIntegration(t *testing.T) {
 	assert.Contains(t, html, "maintainAspectRatio: false", "Charts should adapt to container")
 }
 
+// TestHTMLReporter_FieldTypeChart verifies the struct field composition pie chart data
+func TestHTMLReporter_FieldTypeChart(t *testing.T) {
+	reporter := NewHTMLReporterWithConfig(&config.OutputConfig{
+		IncludeOverview: true,
+		IncludeDetails:  true,
+	})
+
+	counts := map[metrics.FieldType]int{metrics.FieldTypePrimitive: 3, metrics.FieldTypePointer: 1}
+	report := &metrics.Report{
+		Structs: []metrics.StructMetrics{{Name: "User", FieldsByType: counts}},
+		FieldTypes: metrics.FieldTypeDistribution{
+			TotalFields: 4,
+			Counts:      counts,
+			Percentages: metrics.CalculateFieldTypePercentages(counts),
+		},
+	}
+
+	var output bytes.Buffer
+	require.NoError(t, reporter.Generate(report, &output))
+	html := output.String()
+
+	assert.Contains(t, html, `<canvas id="fieldTypeChart">`)
+	assert.Contains(t, html, "type: 'pie'")
+	assert.Contains(t, html, "type: 'primitive'")
+	assert.Regexp(t, `percent:\s*75\s`, html)
+	assert.NotContains(t, html, "type: 'slice'", "Absent categories should not be charted")
+}
+
+func TestHTMLReporter_OversizedInterfaceMethods(t *testing.T) {
+	reporter := NewHTMLReporterWithConfig(&config.OutputConfig{IncludeOverview: true, IncludeDetails: true})
+	report := &metrics.Report{
+		Interfaces: []metrics.InterfaceMetrics{{
+			Name: "Store", Package: "storage", File: "store.go", MethodCount: 1,
+			OversizedMethods: []metrics.SignatureIssue{{
+				Function: "Store.Save", File: "store.go", Line: 4, ParameterCount: 7,
+				Severity: metrics.SeverityLevelWarning, Suggestion: "Consider accepting a parameter object",
+			}},
+		}},
+	}
+
+	var output bytes.Buffer
+	require.NoError(t, reporter.Generate(report, &output))
+	html := output.String()
+
+	assert.Contains(t, html, "<h3>Oversized Interface Methods</h3>")
+	assert.Contains(t, html, "<td><code>Store.Save</code></td>")
+	assert.Contains(t, html, "Consider accepting a parameter object")
+}
+
 // TestHTMLReporterInteractivity tests interactive features
 func TestHTMLReporterInteractivity(t *testing.T) {
 	reporter := NewHTMLReporterWithConfig(&config.OutputConfig{
@@ -624,6 +674,45 @@ func TestHTMLReporterPerformance(t *testing.T) {
 	assert.Contains(t, html, "TestFunction999", "Should contain last function")
 }
 
+// TestHTMLReporter_EmbedsReportDataIsland verifies large tables are rendered from the embedded
+// JSON instead of pre-rendered rows
+func TestHTMLReporter_EmbedsReportDataIsland(t *testing.T) {
+	report := createLargeTestReport(1000)
+	for i := 0; i < 500; i++ {
+		report.Structs = append(report.Structs, metrics.StructMetrics{
+			Name: fmt.Sprintf("TestStruct%d", i), Package: "test", TotalFields: i % 12,
+		})
+	}
+	for i := 0; i < 300; i++ {
+		report.Packages = append(report.Packages, metrics.PackageMetrics{
+			Name: fmt.Sprintf("pkg%d", i), Path: fmt.Sprintf("example.com/pkg%d", i),
+		})
+	}
+
+	var output bytes.Buffer
+	reporter := NewHTMLReporterWithConfig(&config.OutputConfig{IncludeOverview: true, IncludeDetails: true})
+	require.NoError(t, reporter.Generate(report, &output))
+	html := output.String()
+
+	const islandStart = `<script type="application/json" id="report-data">`
+	start := strings.Index(html, islandStart)
+	require.NotEqual(t, -1, start, "report data island should be present")
+	island := html[start+len(islandStart):]
+	end := strings.Index(island, "</script>")
+	require.NotEqual(t, -1, end)
+
+	var embedded metrics.Report
+	require.NoError(t, json.Unmarshal([]byte(island[:end]), &embedded), "report data island should be valid JSON")
+	assert.Len(t, embedded.Functions, 1000)
+	assert.Len(t, embedded.Structs, 500)
+	assert.Equal(t, "TestFunction999", embedded.Functions[999].Name)
+
+	// Header rows of every table plus at most htmlMaxPrerenderedRows body rows per capped table
+	rows := strings.Count(html, "<tr")
+	assert.LessOrEqual(t, rows, 30+htmlMaxPrerenderedRows, "large lists should not be pre-rendered")
+	assert.Contains(t, html, "Showing the first 100 of 300 rows")
+}
+
 // createLargeTestReport creates a test report with many functions for performance testing
 func createLargeTestReport(functionCount int) *metrics.Report {
 	functions := make([]metrics.FunctionMetrics, functionCount)
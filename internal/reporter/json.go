@@ -13,6 +13,8 @@ type JSONReporter struct {
 	indent          bool
 	firstSection    bool
 	sectionsWritten int
+	// warningsOnly makes Generate write the flattened warnings instead of the full report
+	warningsOnly bool
 }
 
 // NewJSONReporter creates a new JSON reporter with pretty-printing enabled by default.
@@ -121,16 +123,26 @@ func (jr *JSONReporter) EndReport(output io.Writer) error {
 // Generate generates a JSON-formatted analysis report by encoding the metrics.Report structure
 // with proper indentation for human readability. The output includes all analysis sections
 // (functions, structs, packages, patterns, etc.) in a structured format. Errors occur only if
-// the writer fails; the Report structure is always valid JSON-serializable.
+// the writer fails; the Report structure is always valid JSON-serializable. In warnings-only
+// mode the output is an array with one object per warning instead.
 func (jr *JSONReporter) Generate(report *metrics.Report, output io.Writer) error {
 	encoder := json.NewEncoder(output)
 	if jr.indent {
 		encoder.SetIndent("", "  ")
 	}
 
+	if jr.warningsOnly {
+		return encoder.Encode(report.AllFindings())
+	}
 	return encoder.Encode(report)
 }
 
+// SetWarningsOnly makes Generate write only the report's warnings as a JSON array of
+// metrics.Finding objects.
+func (jr *JSONReporter) SetWarningsOnly(warningsOnly bool) {
+	jr.warningsOnly = warningsOnly
+}
+
 // WriteDiff generates a JSON-formatted differential analysis report comparing two analysis snapshots.
 // The output includes detailed change categories (improvements, regressions, new functions, removed
 // functions) with line-level deltas and complexity changes. Essential for CI/CD quality gates and
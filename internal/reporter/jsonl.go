@@ -0,0 +1,105 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// JSONLReporter generates JSON Lines output: one compact JSON object per line, each tagged with
+// the kind of record it carries. The first line holds the report metadata, followed by one line
+// per function, struct, interface, and package, so downstream tools can process arbitrarily large
+// reports without loading a single document into memory.
+type JSONLReporter struct{}
+
+// jsonlRecord is a single line of JSON Lines output
+type jsonlRecord struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// NewJSONLReporter creates a new JSON Lines reporter. Each record is written as soon as it is
+// encoded, and the reporter implements StreamingReporter so callers can feed it metrics in
+// batches instead of materializing a full Report. Use --format jsonl flag to activate.
+func NewJSONLReporter() *JSONLReporter {
+	return &JSONLReporter{}
+}
+
+// Generate writes the metadata line followed by one line per function, struct, interface, and
+// package, and ends with an overview line carrying the report totals and a summary line carrying
+// the issue counts and health grade.
+func (jr *JSONLReporter) Generate(report *metrics.Report, output io.Writer) error {
+	if err := jr.BeginReport(output, &report.Metadata); err != nil {
+		return err
+	}
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"functions", report.Functions},
+		{"structs", report.Structs},
+		{"interfaces", report.Interfaces},
+		{"packages", report.Packages},
+		{"overview", report.Overview},
+		{"summary", report.Summary},
+	}
+	for _, section := range sections {
+		if err := jr.WriteSection(output, section.name, section.data); err != nil {
+			return err
+		}
+	}
+	return jr.EndReport(output)
+}
+
+// BeginReport writes the metadata line that opens every JSON Lines report.
+func (jr *JSONLReporter) BeginReport(output io.Writer, metadata *metrics.ReportMetadata) error {
+	return writeJSONLRecord(output, "metadata", metadata)
+}
+
+// WriteSection writes one line per element of a functions, structs, interfaces, or packages
+// batch, typed "function", "struct", "interface", or "package". Any other section is written as
+// a single line typed with the section name. Can be called repeatedly for the same section.
+func (jr *JSONLReporter) WriteSection(output io.Writer, sectionName string, sectionData interface{}) error {
+	switch data := sectionData.(type) {
+	case []metrics.FunctionMetrics:
+		return writeJSONLRecords(output, "function", data)
+	case []metrics.StructMetrics:
+		return writeJSONLRecords(output, "struct", data)
+	case []metrics.InterfaceMetrics:
+		return writeJSONLRecords(output, "interface", data)
+	case []metrics.PackageMetrics:
+		return writeJSONLRecords(output, "package", data)
+	default:
+		return writeJSONLRecord(output, sectionName, sectionData)
+	}
+}
+
+// EndReport finalizes streaming output. JSON Lines has no footer, so nothing is written.
+func (jr *JSONLReporter) EndReport(output io.Writer) error {
+	return nil
+}
+
+// WriteDiff writes a differential analysis report as a single line typed "diff".
+func (jr *JSONLReporter) WriteDiff(output io.Writer, diff *metrics.ComplexityDiff) error {
+	return writeJSONLRecord(output, "diff", diff)
+}
+
+// writeJSONLRecords writes each item as its own line with the given record type
+func writeJSONLRecords[T any](output io.Writer, recordType string, items []T) error {
+	for i := range items {
+		if err := writeJSONLRecord(output, recordType, &items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONLRecord encodes one record as compact JSON followed by a newline
+func writeJSONLRecord(output io.Writer, recordType string, data interface{}) error {
+	if err := json.NewEncoder(output).Encode(jsonlRecord{Type: recordType, Data: data}); err != nil {
+		return fmt.Errorf("failed to write %s record: %w", recordType, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package reporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodedJSONLRecord is a JSON Lines record with its payload left undecoded
+type decodedJSONLRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// readJSONLRecords parses output line by line, failing if any line is not a standalone JSON object
+func readJSONLRecords(t *testing.T, output []byte) []decodedJSONLRecord {
+	t.Helper()
+	var records []decodedJSONLRecord
+	lines := bufio.NewScanner(bytes.NewReader(output))
+	for lines.Scan() {
+		var record decodedJSONLRecord
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &record), "line %d: %s", len(records)+1, lines.Text())
+		records = append(records, record)
+	}
+	require.NoError(t, lines.Err())
+	return records
+}
+
+func TestJSONLReporter_Generate(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "github.com/test/repo", FilesProcessed: 3},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Parse", Package: "parser", File: "parser/parse.go", Line: 10},
+			{Name: "Render", Package: "render", File: "render/render.go", Line: 4},
+			{Name: "main", Package: "main", File: "main.go", Line: 1},
+		},
+		Structs:    []metrics.StructMetrics{{Name: "Node", Package: "parser"}, {Name: "Page", Package: "render"}},
+		Interfaces: []metrics.InterfaceMetrics{{Name: "Renderer", Package: "render"}},
+		Packages:   []metrics.PackageMetrics{{Name: "main"}, {Name: "parser"}, {Name: "render"}},
+		Overview:   metrics.OverviewMetrics{TotalFunctions: 3, TotalStructs: 2, TotalInterfaces: 1, TotalPackages: 3},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONLReporter().Generate(report, &buf))
+
+	records := readJSONLRecords(t, buf.Bytes())
+	require.NotEmpty(t, records)
+	assert.Equal(t, "metadata", records[0].Type, "metadata comes first")
+
+	var metadata metrics.ReportMetadata
+	require.NoError(t, json.Unmarshal(records[0].Data, &metadata))
+	assert.Equal(t, report.Metadata.Repository, metadata.Repository)
+
+	counts := make(map[string]int)
+	var functionNames []string
+	var overview metrics.OverviewMetrics
+	for _, record := range records {
+		counts[record.Type]++
+		switch record.Type {
+		case "function":
+			var fn metrics.FunctionMetrics
+			require.NoError(t, json.Unmarshal(record.Data, &fn))
+			functionNames = append(functionNames, fn.Name)
+		case "overview":
+			require.NoError(t, json.Unmarshal(record.Data, &overview))
+		}
+	}
+
+	assert.Equal(t, len(report.Functions), counts["function"])
+	assert.Equal(t, len(report.Structs), counts["struct"])
+	assert.Equal(t, len(report.Interfaces), counts["interface"])
+	assert.Equal(t, len(report.Packages), counts["package"])
+	assert.Equal(t, 1, counts["metadata"])
+	assert.Equal(t, []string{"Parse", "Render", "main"}, functionNames)
+	assert.Equal(t, report.Overview, overview)
+}
+
+func TestJSONLReporter_StreamingBatches(t *testing.T) {
+	r := NewJSONLReporter()
+	var buf bytes.Buffer
+
+	require.NoError(t, r.BeginReport(&buf, &metrics.ReportMetadata{Repository: "/repo"}))
+	require.NoError(t, r.WriteSection(&buf, "functions", []metrics.FunctionMetrics{{Name: "A"}, {Name: "B"}}))
+	require.NoError(t, r.WriteSection(&buf, "functions", []metrics.FunctionMetrics{{Name: "C"}}))
+	require.NoError(t, r.WriteSection(&buf, "packages", []metrics.PackageMetrics{{Name: "p"}}))
+	require.NoError(t, r.EndReport(&buf))
+
+	records := readJSONLRecords(t, buf.Bytes())
+	types := make([]string, len(records))
+	for i, record := range records {
+		types[i] = record.Type
+	}
+	assert.Equal(t, []string{"metadata", "function", "function", "function", "package"}, types)
+}
+
+func TestJSONLReporter_ReportsWriteErrors(t *testing.T) {
+	err := NewJSONLReporter().Generate(&metrics.Report{}, failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
@@ -0,0 +1,193 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// JSONPatchOperation is a single RFC 6902 JSON Patch operation.
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatchReporter emits an RFC 6902 JSON Patch that transforms the serialized baseline
+// report into the serialized current report. Unlike the semantic ComplexityDiff output it
+// operates purely on the JSON structure, which makes it suitable for generic tooling.
+type JSONPatchReporter struct {
+	indent bool
+}
+
+// NewJSONPatchReporter creates a new JSON Patch reporter with pretty-printing enabled.
+func NewJSONPatchReporter() *JSONPatchReporter {
+	return &JSONPatchReporter{indent: true}
+}
+
+// Generate is not supported for JSON Patch output, which always describes a change between two reports.
+func (jp *JSONPatchReporter) Generate(report *metrics.Report, output io.Writer) error {
+	return fmt.Errorf("jsonpatch format is only supported for diff output")
+}
+
+// WriteDiff writes the JSON Patch transforming diff.Baseline.Report into diff.Current.Report.
+func (jp *JSONPatchReporter) WriteDiff(output io.Writer, diff *metrics.ComplexityDiff) error {
+	patch, err := GenerateJSONPatch(diff.Baseline.Report, diff.Current.Report)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(output)
+	if jp.indent {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(patch)
+}
+
+// GenerateJSONPatch serializes both values to JSON and returns the RFC 6902 operations that
+// transform baseline into current. Object keys are visited in sorted order so the output is
+// deterministic for identical inputs.
+func GenerateJSONPatch(baseline, current interface{}) ([]JSONPatchOperation, error) {
+	from, err := toGenericJSON(baseline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize baseline: %w", err)
+	}
+	to, err := toGenericJSON(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize current: %w", err)
+	}
+
+	patch := make([]JSONPatchOperation, 0)
+	if err := diffJSONValues("", from, to, &patch); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// toGenericJSON round-trips a value through JSON into maps, slices, and json.Number scalars.
+func toGenericJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// diffJSONValues appends the operations needed to turn from into to at the given pointer path.
+func diffJSONValues(path string, from, to interface{}, patch *[]JSONPatchOperation) error {
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+
+	fromObj, fromIsObj := from.(map[string]interface{})
+	toObj, toIsObj := to.(map[string]interface{})
+	if fromIsObj && toIsObj {
+		return diffJSONObjects(path, fromObj, toObj, patch)
+	}
+
+	fromArr, fromIsArr := from.([]interface{})
+	toArr, toIsArr := to.([]interface{})
+	if fromIsArr && toIsArr {
+		return diffJSONArrays(path, fromArr, toArr, patch)
+	}
+
+	return appendJSONPatchOp(patch, "replace", path, to)
+}
+
+// diffJSONObjects compares two JSON objects key by key in sorted order.
+func diffJSONObjects(path string, from, to map[string]interface{}, patch *[]JSONPatchOperation) error {
+	for _, key := range sortedJSONKeys(from) {
+		if _, ok := to[key]; !ok {
+			if err := appendJSONPatchOp(patch, "remove", path+"/"+escapeJSONPointer(key), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range sortedJSONKeys(to) {
+		childPath := path + "/" + escapeJSONPointer(key)
+		fromVal, ok := from[key]
+		if !ok {
+			if err := appendJSONPatchOp(patch, "add", childPath, to[key]); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := diffJSONValues(childPath, fromVal, to[key], patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffJSONArrays compares arrays positionally, then appends or trims the tail.
+// Trailing elements are removed from the highest index down so each index stays valid.
+func diffJSONArrays(path string, from, to []interface{}, patch *[]JSONPatchOperation) error {
+	common := len(from)
+	if len(to) < common {
+		common = len(to)
+	}
+
+	for i := 0; i < common; i++ {
+		if err := diffJSONValues(path+"/"+strconv.Itoa(i), from[i], to[i], patch); err != nil {
+			return err
+		}
+	}
+
+	for i := len(from) - 1; i >= common; i-- {
+		if err := appendJSONPatchOp(patch, "remove", path+"/"+strconv.Itoa(i), nil); err != nil {
+			return err
+		}
+	}
+
+	for i := common; i < len(to); i++ {
+		if err := appendJSONPatchOp(patch, "add", path+"/"+strconv.Itoa(i), to[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendJSONPatchOp encodes the value (if any) and appends the operation to the patch.
+func appendJSONPatchOp(patch *[]JSONPatchOperation, op, path string, value interface{}) error {
+	operation := JSONPatchOperation{Op: op, Path: path}
+	if op != "remove" {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value at %s: %w", path, err)
+		}
+		operation.Value = data
+	}
+	*patch = append(*patch, operation)
+	return nil
+}
+
+// sortedJSONKeys returns the keys of a JSON object in lexical order.
+func sortedJSONKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapeJSONPointer escapes a reference token per RFC 6901.
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,160 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPatchReporter_WriteDiff_AppliesToBaseline(t *testing.T) {
+	baseline := metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "repo", GeneratedAt: time.Unix(1700000000, 0).UTC()},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Parse", File: "parse.go", Complexity: metrics.ComplexityScore{Cyclomatic: 4}},
+			{Name: "Render", File: "render.go"},
+			{Name: "Legacy", File: "legacy.go"},
+		},
+		Overview: metrics.OverviewMetrics{TotalFunctions: 3},
+	}
+	current := metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "repo", GeneratedAt: time.Unix(1700003600, 0).UTC()},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Parse", File: "parse.go", Complexity: metrics.ComplexityScore{Cyclomatic: 9}},
+			{Name: "Render", File: "render/view.go"},
+		},
+		Overview: metrics.OverviewMetrics{TotalFunctions: 2},
+	}
+
+	diff := &metrics.ComplexityDiff{
+		Baseline: metrics.Snapshot{ID: "baseline", Report: baseline},
+		Current:  metrics.Snapshot{ID: "current", Report: current},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONPatchReporter().WriteDiff(&buf, diff))
+
+	var patch []JSONPatchOperation
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &patch))
+	assert.NotEmpty(t, patch)
+
+	doc, err := toGenericJSON(baseline)
+	require.NoError(t, err)
+	patched, err := applyJSONPatch(doc, patch)
+	require.NoError(t, err)
+
+	expected, err := toGenericJSON(current)
+	require.NoError(t, err)
+	assert.Equal(t, expected, patched)
+}
+
+func TestGenerateJSONPatch_Deterministic(t *testing.T) {
+	from := map[string]interface{}{"b": 1, "a": map[string]interface{}{"x/y": 1, "z~": 2}}
+	to := map[string]interface{}{"c": 3, "a": map[string]interface{}{"x/y": 2}}
+
+	first, err := GenerateJSONPatch(from, to)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		again, err := GenerateJSONPatch(from, to)
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+
+	paths := make([]string, len(first))
+	for i, op := range first {
+		paths[i] = op.Op + " " + op.Path
+	}
+	assert.Equal(t, []string{"remove /b", "remove /a/z~0", "replace /a/x~1y", "add /c"}, paths)
+}
+
+func TestJSONPatchReporter_GenerateUnsupported(t *testing.T) {
+	err := NewJSONPatchReporter().Generate(&metrics.Report{}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+// applyJSONPatch is a minimal RFC 6902 applier supporting add, remove, and replace.
+func applyJSONPatch(doc interface{}, patch []JSONPatchOperation) (interface{}, error) {
+	for _, op := range patch {
+		var value interface{}
+		if op.Op != "remove" {
+			decoder := json.NewDecoder(bytes.NewReader(op.Value))
+			decoder.UseNumber()
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+		}
+
+		var err error
+		doc, err = applyJSONPatchOp(doc, splitJSONPointer(op.Path), op.Op, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func applyJSONPatchOp(node interface{}, tokens []string, op string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	token := tokens[0]
+	last := len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if last {
+			if op == "remove" {
+				delete(n, token)
+			} else {
+				n[token] = value
+			}
+			return n, nil
+		}
+		child, err := applyJSONPatchOp(n[token], tokens[1:], op, value)
+		n[token] = child
+		return n, err
+	case []interface{}:
+		idx, err := strconv.Atoi(token)
+		if err != nil || idx < 0 || idx > len(n) {
+			return nil, fmt.Errorf("invalid array index %q", token)
+		}
+		if last {
+			switch op {
+			case "remove":
+				return append(n[:idx], n[idx+1:]...), nil
+			case "add":
+				n = append(n, nil)
+				copy(n[idx+1:], n[idx:])
+				n[idx] = value
+				return n, nil
+			default:
+				n[idx] = value
+				return n, nil
+			}
+		}
+		child, err := applyJSONPatchOp(n[idx], tokens[1:], op, value)
+		n[idx] = child
+		return n, err
+	default:
+		return nil, fmt.Errorf("cannot traverse %T", node)
+	}
+}
+
+func splitJSONPointer(path string) []string {
+	if path == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+	}
+	return tokens
+}
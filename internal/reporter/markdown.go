@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -17,11 +18,15 @@ var markdownTemplate string
 //go:embed templates/markdown/diff.md
 var markdownDiffTemplate string
 
+// markdownTopItems is the number of rows in the "top N" tables of the Markdown report
+const markdownTopItems = 10
+
 // MarkdownReporter generates Markdown reports for Git workflows
 type MarkdownReporter struct {
 	includeOverview bool
 	includeDetails  bool
 	maxItems        int
+	sections        []string
 }
 
 // NewMarkdownReporter creates a new Markdown reporter with default settings for generating GitHub-flavored Markdown reports.
@@ -51,13 +56,23 @@ func NewMarkdownReporterWithOptions(includeOverview, includeDetails bool, maxIte
 func (mr *MarkdownReporter) Generate(report *metrics.Report, output io.Writer) error {
 	// Create template with helper functions
 	tmpl, err := template.New("markdown-report").Funcs(template.FuncMap{
-		"formatDuration": mr.formatDuration,
-		"formatFloat":    mr.formatFloat,
-		"formatPercent":  mr.formatPercent,
-		"truncateList":   mr.truncateList,
-		"escapeMarkdown": mr.escapeMarkdown,
-		"add":            func(a, b int) int { return a + b },
-		"subtract":       func(a, b float64) float64 { return a - b },
+		"formatDuration":   mr.formatDuration,
+		"formatFloat":      mr.formatFloat,
+		"formatPercent":    mr.formatPercent,
+		"truncateList":     mr.truncateList,
+		"escapeMarkdown":   mr.escapeMarkdown,
+		"oversizedMethods": collectOversizedMethods,
+		"mostComplex":      mr.mostComplexFunctions,
+		"concatPatterns":   mr.concatPatterns,
+		"syncPrimitives":   mr.collectSyncPrimitives,
+		"showSection":      mr.showSection,
+		"fieldTypeOrder":   func() []metrics.FieldType { return metrics.FieldTypeOrder },
+		"paramTypeOrder":   func() []string { return metrics.ParamTypeOrder },
+		"balanceOrder":     func() []metrics.StructBalance { return metrics.StructBalanceOrder },
+		"balanceLabel":     func(b metrics.StructBalance) string { return metrics.StructBalanceLabels[b] },
+		"join":             strings.Join,
+		"add":              func(a, b int) int { return a + b },
+		"subtract":         func(a, b float64) float64 { return a - b },
 	}).Parse(markdownTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse embedded markdown template: %w", err)
@@ -69,6 +84,7 @@ func (mr *MarkdownReporter) Generate(report *metrics.Report, output io.Writer) e
 		"IncludeOverview": mr.includeOverview,
 		"IncludeDetails":  mr.includeDetails,
 		"MaxItems":        mr.maxItems,
+		"TopItems":        markdownTopItems,
 	})
 }
 
@@ -89,8 +105,18 @@ func (mr *MarkdownReporter) WriteDiff(output io.Writer, diff *metrics.Complexity
 	return tmpl.Execute(output, diff)
 }
 
+// SetSections restricts the report to the named sections; an empty list renders every section.
+func (mr *MarkdownReporter) SetSections(sections []string) {
+	mr.sections = sections
+}
+
 // Template helper functions
 
+// showSection reports whether the named section was selected for output.
+func (mr *MarkdownReporter) showSection(name string) bool {
+	return metrics.IsSectionSelected(mr.sections, name)
+}
+
 // formatDuration formats a duration with appropriate units (μs, ms, or s).
 func (mr *MarkdownReporter) formatDuration(d time.Duration) string {
 	if d < time.Millisecond {
@@ -110,6 +136,50 @@ func (mr *MarkdownReporter) formatFloat(f float64) string {
 	return fmt.Sprintf("%.2f", f)
 }
 
+// collectOversizedMethods gathers oversized method signatures across all interfaces.
+func collectOversizedMethods(interfaces []metrics.InterfaceMetrics) []metrics.SignatureIssue {
+	var issues []metrics.SignatureIssue
+	for _, iface := range interfaces {
+		issues = append(issues, iface.OversizedMethods...)
+	}
+	return issues
+}
+
+// mostComplexFunctions returns the limit production functions with the highest overall
+// complexity, most complex first. Test functions are ranked in the test complexity section.
+func (mr *MarkdownReporter) mostComplexFunctions(functions []metrics.FunctionMetrics, limit int) []metrics.FunctionMetrics {
+	ranked := make([]metrics.FunctionMetrics, 0, len(functions))
+	for _, fn := range functions {
+		if !fn.IsTestFile {
+			ranked = append(ranked, fn)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Complexity.Overall > ranked[j].Complexity.Overall
+	})
+	return truncate(ranked, limit)
+}
+
+// concatPatterns joins pattern instance lists into one list for a shared table
+func (mr *MarkdownReporter) concatPatterns(lists ...[]metrics.PatternInstance) []metrics.PatternInstance {
+	var patterns []metrics.PatternInstance
+	for _, list := range lists {
+		patterns = append(patterns, list...)
+	}
+	return patterns
+}
+
+// collectSyncPrimitives gathers the instances of every synchronization primitive kind
+func (mr *MarkdownReporter) collectSyncPrimitives(prims metrics.SyncPrimitives) []metrics.SyncPrimitiveInstance {
+	var instances []metrics.SyncPrimitiveInstance
+	for _, list := range [][]metrics.SyncPrimitiveInstance{
+		prims.Mutexes, prims.RWMutexes, prims.WaitGroups, prims.Once, prims.Cond, prims.Atomic, prims.ErrGroups,
+	} {
+		instances = append(instances, list...)
+	}
+	return instances
+}
+
 // formatPercent formats a decimal value as a percentage string.
 func (mr *MarkdownReporter) formatPercent(f float64) string {
 	return fmt.Sprintf("%.1f%%", f*100)
@@ -147,30 +217,34 @@ func (mr *MarkdownReporter) formatChangeSign(change float64) string {
 func (mr *MarkdownReporter) truncateList(items interface{}, limit int) interface{} {
 	switch v := items.(type) {
 	case []metrics.FunctionMetrics:
-		if len(v) <= limit {
-			return v
-		}
-		return v[:limit]
+		return truncate(v, limit)
 	case []metrics.StructMetrics:
-		if len(v) <= limit {
-			return v
-		}
-		return v[:limit]
+		return truncate(v, limit)
 	case []metrics.InterfaceMetrics:
-		if len(v) <= limit {
-			return v
-		}
-		return v[:limit]
+		return truncate(v, limit)
 	case []metrics.PackageMetrics:
-		if len(v) <= limit {
-			return v
-		}
-		return v[:limit]
+		return truncate(v, limit)
+	case []metrics.GoroutineInstance:
+		return truncate(v, limit)
+	case []metrics.ChannelInstance:
+		return truncate(v, limit)
+	case []metrics.SyncPrimitiveInstance:
+		return truncate(v, limit)
+	case []metrics.PatternInstance:
+		return truncate(v, limit)
 	default:
 		return items
 	}
 }
 
+// truncate returns at most the first limit items of a slice
+func truncate[T any](items []T, limit int) []T {
+	if len(items) <= limit {
+		return items
+	}
+	return items[:limit]
+}
+
 // escapeMarkdown escapes special Markdown characters to prevent formatting issues.
 func (mr *MarkdownReporter) escapeMarkdown(s string) string {
 	// Escape special Markdown characters that could break formatting
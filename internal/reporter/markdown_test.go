@@ -345,3 +345,240 @@ func TestMarkdownReporter_WithPlacement(t *testing.T) {
 		t.Error("Low cohesion files count not found in summary")
 	}
 }
+
+func TestMarkdownReporter_OversizedInterfaceMethods(t *testing.T) {
+	reporter := NewMarkdownReporter()
+
+	testReport := &metrics.Report{
+		Interfaces: []metrics.InterfaceMetrics{
+			{
+				Name:        "Store",
+				Package:     "storage",
+				File:        "store.go",
+				MethodCount: 1,
+				OversizedMethods: []metrics.SignatureIssue{
+					{
+						Function:       "Store.Save",
+						File:           "store.go",
+						Line:           4,
+						ParameterCount: 7,
+						Severity:       metrics.SeverityLevelWarning,
+						Suggestion:     "Consider accepting a parameter object",
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := reporter.Generate(testReport, &buf); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "### Oversized Interface Methods") {
+		t.Error("Expected oversized interface methods section")
+	}
+	if !strings.Contains(output, "Store.Save") {
+		t.Error("Expected Store.Save in oversized interface methods table")
+	}
+}
+
+func TestMarkdownReporter_SectionSelection(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "test-repo", GeneratedAt: time.Now()},
+		Overview: metrics.OverviewMetrics{TotalFunctions: 1},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Run", File: "main.go"},
+		},
+		Packages: []metrics.PackageMetrics{
+			{Name: "main"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		sections []string
+		present  []string
+		absent   []string
+	}{
+		{
+			name:    "all sections by default",
+			present: []string{"## 📊 Overview", "## 🔧 Functions", "## 📦 Packages", "## 📈 Analysis Summary"},
+		},
+		{
+			name:     "functions only",
+			sections: []string{"functions"},
+			present:  []string{"## 🔧 Functions"},
+			absent:   []string{"## 📊 Overview", "## 📦 Packages", "## 📈 Analysis Summary"},
+		},
+		{
+			name:     "packages only",
+			sections: []string{"packages"},
+			present:  []string{"## 📦 Packages"},
+			absent:   []string{"## 🔧 Functions", "## 📊 Overview"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewMarkdownReporter().(*MarkdownReporter)
+			reporter.SetSections(tt.sections)
+
+			var buf bytes.Buffer
+			if err := reporter.Generate(report, &buf); err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+			output := buf.String()
+
+			for _, header := range tt.present {
+				if !strings.Contains(output, header) {
+					t.Errorf("expected output to contain %q", header)
+				}
+			}
+			for _, header := range tt.absent {
+				if strings.Contains(output, header) {
+					t.Errorf("expected output not to contain %q", header)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownReporter_ConcurrentMapWrites(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "test-repo", GeneratedAt: time.Now()},
+	}
+	report.Patterns.ConcurrencyPatterns.Goroutines.DataRaces = []metrics.DataRaceWarning{
+		{File: "cache.go", Line: 12, Function: "fill", Variable: "entries", RiskLevel: "high",
+			Description: "Map 'entries' is written from a goroutine without a mutex in scope"},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter().Generate(report, &buf); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, "### ⚠️ Potential Concurrent Map Writes") {
+		t.Error("expected concurrent map writes section")
+	}
+	if !strings.Contains(output, "cache.go:12") {
+		t.Error("expected data race location in output")
+	}
+}
+
+// checkMarkdownTables verifies that every table in output has a header row, a separator row,
+// and body rows with the same number of columns
+func checkMarkdownTables(t *testing.T, output string) int {
+	t.Helper()
+	columns := func(row string) int {
+		return strings.Count(strings.ReplaceAll(row, `\|`, ""), "|") - 1
+	}
+
+	tables := 0
+	lines := strings.Split(output, "\n")
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "|") {
+			continue
+		}
+		tables++
+		header := lines[i]
+		if i+1 >= len(lines) || !strings.HasPrefix(lines[i+1], "|-") {
+			t.Errorf("table header %q is not followed by a separator row", header)
+			continue
+		}
+		want := columns(header)
+		if got := columns(lines[i+1]); got != want {
+			t.Errorf("separator of table %q has %d columns, want %d", header, got, want)
+		}
+		for i += 2; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+			if got := columns(lines[i]); got != want {
+				t.Errorf("row %q of table %q has %d columns, want %d", lines[i], header, got, want)
+			}
+		}
+	}
+	return tables
+}
+
+func TestMarkdownReporter_TablesAndDetails(t *testing.T) {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "test-repo", GeneratedAt: time.Now()},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "parseConfig", File: "config.go", Line: 10, Complexity: metrics.ComplexityScore{Cyclomatic: 4, Overall: 6}},
+			{Name: "renderReport", File: "render.go", Line: 42, Complexity: metrics.ComplexityScore{Cyclomatic: 12, Cognitive: 15, Overall: 18.5}},
+			{Name: "TestRender", File: "render_test.go", Line: 8, IsTestFile: true, Complexity: metrics.ComplexityScore{Cyclomatic: 30, Overall: 40}},
+		},
+		Structs: []metrics.StructMetrics{{Name: "Options", File: "config.go", Line: 3, TotalFields: 3}},
+		FieldTypes: metrics.FieldTypeDistribution{
+			TotalFields: 3,
+			Counts:      map[metrics.FieldType]int{metrics.FieldTypePrimitive: 2, metrics.FieldTypeMap: 1},
+			Percentages: map[metrics.FieldType]float64{metrics.FieldTypePrimitive: 66.67, metrics.FieldTypeMap: 33.33},
+		},
+		ParamTypes: metrics.ParamTypeDistribution{
+			TotalParams: 4,
+			Counts:      map[string]int{metrics.ParamTypeNamed: 1, metrics.ParamTypeVariadic: 3},
+			Percentages: map[string]float64{metrics.ParamTypeNamed: 25, metrics.ParamTypeVariadic: 75},
+		},
+	}
+	concurrency := &report.Patterns.ConcurrencyPatterns
+	concurrency.Goroutines.Instances = []metrics.GoroutineInstance{{File: "worker.go", Line: 20, Function: "Start", IsAnonymous: true}}
+	concurrency.Goroutines.GoroutineLeaks = []metrics.GoroutineLeakWarning{
+		{File: "worker.go", Line: 20, Function: "Start", RiskLevel: "medium", Description: "loop has no exit path"},
+	}
+	concurrency.Channels.Instances = []metrics.ChannelInstance{
+		{File: "worker.go", Line: 18, Function: "Start", Name: "jobs", Type: "chan int", IsBuffered: true, BufferSize: 8, SendCount: 1, ReceiveCount: 1},
+	}
+	concurrency.Pipelines = []metrics.PatternInstance{{Name: "Pipeline", File: "worker.go", Line: 18, ConfidenceScore: 0.9, Description: "Pipeline with 2 stages and 1 channels"}}
+	concurrency.SyncPrims.Mutexes = []metrics.SyncPrimitiveInstance{{File: "worker.go", Line: 5, Function: "<package>", Type: "Mutex", Variable: "mu"}}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter().Generate(report, &buf); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	output := buf.String()
+
+	if tables := checkMarkdownTables(t, output); tables == 0 {
+		t.Fatal("expected the report to contain tables")
+	}
+	for _, name := range []string{"parseConfig", "renderReport", "Options", "jobs"} {
+		if !strings.Contains(output, name) {
+			t.Errorf("expected %q in output", name)
+		}
+	}
+
+	if !strings.Contains(output, "### Most Complex Functions") {
+		t.Fatal("expected most complex functions table")
+	}
+	ranked := strings.SplitN(output[strings.Index(output, "### Most Complex Functions"):], "\n\n", 3)[1]
+	if !strings.Contains(ranked, "| 1 | renderReport |") || !strings.Contains(ranked, "| 2 | parseConfig |") {
+		t.Errorf("expected functions ranked by complexity, got:\n%s", ranked)
+	}
+	if strings.Contains(ranked, "TestRender") {
+		t.Error("test functions should not be ranked with production functions")
+	}
+
+	if !strings.Contains(output, "### Field Type Composition") || !strings.Contains(output, "| map | 1 |") {
+		t.Error("expected struct field type breakdown")
+	}
+	if !strings.Contains(output, "### Parameter Type Composition") || !strings.Contains(output, "| variadic | 3 | 75% |") {
+		t.Error("expected function parameter type breakdown")
+	}
+
+	for _, summary := range []string{
+		"<summary>Goroutines (1)</summary>",
+		"<summary>Channels (1)</summary>",
+		"<summary>Worker Pools, Pipelines, and Semaphores (1)</summary>",
+		"<summary>Synchronization Primitives (1)</summary>",
+	} {
+		if !strings.Contains(output, summary) {
+			t.Errorf("expected collapsible section %q", summary)
+		}
+	}
+	if opened, closed := strings.Count(output, "<details>"), strings.Count(output, "</details>"); opened != closed {
+		t.Errorf("unbalanced details tags: %d opened, %d closed", opened, closed)
+	}
+	if !strings.Contains(output, "worker.go:20): loop has no exit path") {
+		t.Error("expected goroutine leak description in output")
+	}
+}
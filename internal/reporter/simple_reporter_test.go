@@ -51,6 +51,25 @@ func TestJSONReporter_Generate_Simple(t *testing.T) {
 	assert.Equal(t, "github.com/test/repo", decoded.Metadata.Repository)
 }
 
+func TestJSONReporter_WarningsOnly(t *testing.T) {
+	report := warningsReport()
+	reporter := NewJSONReporter()
+	reporter.SetWarningsOnly(true)
+
+	var buf bytes.Buffer
+	require.NoError(t, reporter.Generate(report, &buf))
+
+	var findings []metrics.Finding
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &findings), "the output is an array of findings")
+	assert.Len(t, findings, len(report.AllFindings()))
+	assert.Len(t, findings, 4)
+	assert.Equal(t, metrics.Finding{
+		RuleID: "concurrency/unused_context", Category: metrics.FindingCategoryConcurrency, Severity: metrics.SeverityLevelWarning,
+		File: "api.go", Line: 10, Function: "Get",
+	}, findings[2])
+	assert.Equal(t, metrics.SeverityLevelViolation, findings[1].Severity, "the high-risk goroutine leak")
+}
+
 func TestNewReporter_AllTypes(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -58,6 +77,7 @@ func TestNewReporter_AllTypes(t *testing.T) {
 		wantErr      bool
 	}{
 		{"JSON", "json", false},
+		{"JSON Lines", "jsonl", false},
 		{"CSV", "csv", false},
 		{"HTML", "html", false},
 		{"Markdown", "markdown", false},
@@ -79,7 +99,7 @@ func TestNewReporter_AllTypes(t *testing.T) {
 }
 
 func TestCreateReporter_AllTypes(t *testing.T) {
-	types := []Type{TypeJSON, TypeCSV, TypeHTML, TypeMarkdown, TypeConsole}
+	types := []Type{TypeJSON, TypeJSONL, TypeCSV, TypeHTML, TypeMarkdown, TypeConsole}
 	for _, rtype := range types {
 		reporter := CreateReporter(rtype, nil)
 		assert.NotNil(t, reporter)
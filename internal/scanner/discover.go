@@ -11,8 +11,12 @@ import (
 	"strings"
 )
 
-// DiscoverFiles finds all Go source files in the given root directory
+// DiscoverFiles finds all Go source files in the given root directory. It fails before walking
+// the directory when an include or exclude pattern is malformed.
 func (d *Discoverer) DiscoverFiles(rootDir string) ([]FileInfo, error) {
+	if err := ValidatePatterns(d.config); err != nil {
+		return nil, err
+	}
 	var files []FileInfo
 	walkFunc := d.createWalkDirFunction(rootDir, &files)
 	err := filepath.WalkDir(rootDir, walkFunc)
@@ -66,11 +70,10 @@ func (d *Discoverer) analyzeFile(path, rootDir string, info fs.FileInfo) (FileIn
 	}
 
 	fileInfo := FileInfo{
-		Path:        path,
-		RelPath:     relPath,
-		Size:        info.Size(),
-		IsTestFile:  strings.HasSuffix(path, "_test.go"),
-		IsGenerated: false,
+		Path:       path,
+		RelPath:    relPath,
+		Size:       info.Size(),
+		IsTestFile: strings.HasSuffix(path, "_test.go"),
 	}
 
 	// Read file once; cache bytes in FileInfo.Src so the worker can reuse them
@@ -85,7 +88,7 @@ func (d *Discoverer) analyzeFile(path, rootDir string, info fs.FileInfo) (FileIn
 	fileInfo.FileLines = bytes.Count(src, []byte{'\n'}) + 1
 
 	// Check for generated file markers
-	fileInfo.IsGenerated = isGeneratedFile(string(src))
+	fileInfo.IsGenerated = IsGeneratedSource(src)
 
 	// Parse to get package name (PackageClauseOnly is fast; reuses src to avoid another read)
 	file, err := parser.ParseFile(d.fset, path, src, parser.PackageClauseOnly)
@@ -131,6 +134,12 @@ func (d *Discoverer) ParseFile(path string) (*ast.File, error) {
 	return d.parseFileWithSrc(path, src)
 }
 
+// ParseSource parses Go source held in memory, recording positions under the given filename,
+// which need not exist on disk.
+func (d *Discoverer) ParseSource(filename string, src []byte) (*ast.File, error) {
+	return d.parseFileWithSrc(filename, src)
+}
+
 // parseFileWithSrc parses a Go source file using already-read bytes, eliminating a redundant
 // os.ReadFile call for files whose content was cached during discovery.
 func (d *Discoverer) parseFileWithSrc(path string, src []byte) (*ast.File, error) {
@@ -3,6 +3,7 @@ package scanner
 import (
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -159,14 +160,19 @@ func TestDiscoverFiles_SkipVendor(t *testing.T) {
 func TestDiscoverFiles_SkipGenerated(t *testing.T) {
 	testFiles := map[string]string{
 		"main.go": "package main\n\nfunc main() {}\n",
-		"generated.go": `// Code generated by protoc-gen-go. DO NOT EDIT.
+		"api.pb.go": `// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: api.proto
+
 package main
 
 func Generated() {}`,
-		"autogenerated.go": `// This file was generated automatically
+		"handwritten.go": `// This file was written by hand; please do not edit it without review
 package main
 
-func AutoGenerated() {}`,
+func Handwritten() {}`,
 	}
 
 	tempDir := createTestFiles(t, testFiles)
@@ -182,18 +188,29 @@ func AutoGenerated() {}`,
 		t.Fatalf("DiscoverFiles failed: %v", err)
 	}
 
-	// Should find 1 file (excluding generated files)
-	expectedCount := 1
+	// Should find 2 files (excluding the protobuf output)
+	expectedCount := 2
 	if len(files) != expectedCount {
 		t.Errorf("Expected %d files, got %d", expectedCount, len(files))
 	}
 
 	// Verify no generated files are included
 	for _, file := range files {
-		if file.IsGenerated {
+		if file.IsGenerated || file.RelPath == "api.pb.go" {
 			t.Errorf("Generated file should have been skipped: %s", file.Path)
 		}
 	}
+
+	// Without the filter the generated file is discovered and marked
+	files, err = NewDiscoverer(&config.FilterConfig{}).DiscoverFiles(tempDir)
+	if err != nil {
+		t.Fatalf("DiscoverFiles failed: %v", err)
+	}
+	for _, file := range files {
+		if file.IsGenerated != (file.RelPath == "api.pb.go") {
+			t.Errorf("%s: IsGenerated = %v", file.RelPath, file.IsGenerated)
+		}
+	}
 }
 
 func TestDiscoverFiles_IncludePatterns(t *testing.T) {
@@ -270,6 +287,129 @@ func TestDiscoverFiles_ExcludePatterns(t *testing.T) {
 	}
 }
 
+// nestedPatternFixture is a tree with handlers and mocks at several depths
+var nestedPatternFixture = map[string]string{
+	"main.go":                             "package main\n\nfunc main() {}\n",
+	"user_handler.go":                     "package main\n\nfunc User() {}\n",
+	"api/order_handler.go":                "package api\n\nfunc Order() {}\n",
+	"api/order_handler_test.go":           "package api\n",
+	"api/v2/item_handler.go":              "package v2\n\nfunc Item() {}\n",
+	"api/v2/routes.go":                    "package v2\n\nfunc Routes() {}\n",
+	"mocks/store.go":                      "package mocks\n\nfunc Store() {}\n",
+	"internal/mocks/fake_handler.go":      "package mocks\n\nfunc Fake() {}\n",
+	"internal/service/mocks/deep/repo.go": "package deep\n\nfunc Repo() {}\n",
+	"internal/service/service.go":         "package service\n\nfunc Run() {}\n",
+	"vendor/lib/vendored_handler.go":      "package lib\n\nfunc Vendored() {}\n",
+}
+
+// discoveredPaths returns the slash-separated relative paths discovered under dir with cfg
+func discoveredPaths(t *testing.T, dir string, cfg *config.FilterConfig) []string {
+	t.Helper()
+	files, err := NewDiscoverer(cfg).DiscoverFiles(dir)
+	if err != nil {
+		t.Fatalf("DiscoverFiles failed: %v", err)
+	}
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, filepath.ToSlash(file.RelPath))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestDiscoverFiles_RecursiveGlobPatterns(t *testing.T) {
+	tempDir := createTestFiles(t, nestedPatternFixture)
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name     string
+		cfg      config.FilterConfig
+		expected []string
+	}{
+		{
+			name: "exclude mocks at any depth",
+			cfg: config.FilterConfig{
+				IncludePatterns: []string{"**/*.go"},
+				ExcludePatterns: []string{"**/mocks/**"},
+				SkipVendor:      true,
+				SkipTestFiles:   true,
+			},
+			expected: []string{
+				"api/order_handler.go",
+				"api/v2/item_handler.go",
+				"api/v2/routes.go",
+				"internal/service/service.go",
+				"main.go",
+				"user_handler.go",
+			},
+		},
+		{
+			name: "include handlers at any depth",
+			cfg: config.FilterConfig{
+				IncludePatterns: []string{"**/*_handler.go"},
+				SkipVendor:      true,
+			},
+			expected: []string{
+				"api/order_handler.go",
+				"api/v2/item_handler.go",
+				"internal/mocks/fake_handler.go",
+				"user_handler.go",
+			},
+		},
+		{
+			name: "include and exclude compose",
+			cfg: config.FilterConfig{
+				IncludePatterns: []string{"**/*_handler.go"},
+				ExcludePatterns: []string{"**/mocks/**"},
+			},
+			expected: []string{
+				"api/order_handler.go",
+				"api/v2/item_handler.go",
+				"user_handler.go",
+				"vendor/lib/vendored_handler.go",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := discoveredPaths(t, tempDir, &tt.cfg)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPatternMatches(t *testing.T) {
+	discoverer := NewDiscoverer(&config.FilterConfig{})
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "a/b/c.go", true},
+		{"**/*.go", "README.md", false},
+		{"**/mocks/**", "mocks/store.go", true},
+		{"**/mocks/**", "a/b/mocks/c/d.go", true},
+		{"**/mocks/**", "mocksfoo/store.go", false},
+		{"api/**/*_handler.go", "api/order_handler.go", true},
+		{"api/**/*_handler.go", "api/v2/item_handler.go", true},
+		{"api/**/*_handler.go", "user_handler.go", false},
+		{"cmd/*.go", "cmd/cli.go", true},
+		{"cmd/*.go", "cmd/sub/cli.go", false},
+		{"[", "main.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := discoverer.patternMatches(tt.pattern, filepath.FromSlash(tt.path)); got != tt.want {
+			t.Errorf("patternMatches(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestDiscoverFiles_MaxFileSize(t *testing.T) {
 	// Create a large file content (>1KB)
 	largeContent := "package main\n\n" + strings.Repeat("// Large comment\n", 100)
@@ -369,7 +509,7 @@ func TestAnalyzeFile_TestFile(t *testing.T) {
 	}
 }
 
-func TestIsGeneratedFile(t *testing.T) {
+func TestIsGeneratedSource(t *testing.T) {
 	tests := []struct {
 		name     string
 		content  string
@@ -386,37 +526,42 @@ func TestIsGeneratedFile(t *testing.T) {
 			expected: true,
 		},
 		{
-			name:     "DoNotEdit",
-			content:  "// DO NOT EDIT this file\npackage main\n",
+			name:     "AfterBuildConstraintAndComments",
+			content:  "//go:build linux\n\n// Copyright 2024\n\n// Code generated by stringer -type=Kind; DO NOT EDIT.\r\n\npackage main\n",
 			expected: true,
 		},
 		{
-			name:     "AutoGenerated",
-			content:  "// This file was autogenerated\npackage main\n",
-			expected: true,
+			name:     "AfterPackageClause",
+			content:  "package main\n\n// Code generated by tool. DO NOT EDIT.\n",
+			expected: false,
 		},
 		{
-			name:     "GeneratedAutomatically",
-			content:  "// Generated automatically by tool\npackage main\n",
-			expected: true,
+			name:     "DoNotEditWithoutMarker",
+			content:  "// DO NOT EDIT this file\npackage main\n",
+			expected: false,
 		},
 		{
-			name:     "ThisFileWasGenerated",
-			content:  "// This file was generated by generator\npackage main\n",
-			expected: true,
+			name:     "AutoGeneratedProse",
+			content:  "// This file was autogenerated\npackage main\n",
+			expected: false,
 		},
 		{
-			name:     "CaseInsensitive",
+			name:     "MarkerIsCaseSensitive",
 			content:  "// CODE GENERATED BY TOOL. DO NOT EDIT.\npackage main\n",
-			expected: true,
+			expected: false,
+		},
+		{
+			name:     "MissingFinalPeriod",
+			content:  "// Code generated by tool. DO NOT EDIT\npackage main\n",
+			expected: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isGeneratedFile(tt.content)
+			result := IsGeneratedSource([]byte(tt.content))
 			if result != tt.expected {
-				t.Errorf("isGeneratedFile() = %v, expected %v", result, tt.expected)
+				t.Errorf("IsGeneratedSource() = %v, expected %v", result, tt.expected)
 			}
 		})
 	}
@@ -573,3 +718,22 @@ func TestDiscoverFiles_NonExistentDirectory(t *testing.T) {
 		t.Error("DiscoverFiles should have failed for non-existent directory")
 	}
 }
+
+func TestDiscoverFiles_InvalidPattern(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, cfg := range []*config.FilterConfig{
+		{IncludePatterns: []string{"**/[.go"}},
+		{ExcludePatterns: []string{"vendor/**", "a\\"}},
+	} {
+		_, err := NewDiscoverer(cfg).DiscoverFiles(tempDir)
+		if err == nil || !strings.Contains(err.Error(), "invalid pattern") {
+			t.Errorf("DiscoverFiles with %+v: err = %v, want invalid pattern error", cfg, err)
+		}
+	}
+
+	valid := &config.FilterConfig{IncludePatterns: []string{"**/*.go", "cmd/[a-z]*.go"}}
+	if err := ValidatePatterns(valid); err != nil {
+		t.Errorf("ValidatePatterns(%v) = %v, want nil", valid.IncludePatterns, err)
+	}
+}
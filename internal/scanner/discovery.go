@@ -1,6 +1,8 @@
 package scanner
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"go/token"
 
 	"github.com/opd-ai/go-stats-generator/internal/config"
@@ -16,6 +18,13 @@ type FileInfo struct {
 	IsGenerated bool
 	Src         []byte // raw file bytes cached during discovery to avoid a second read during parsing
 	FileLines   int    // total line count computed from Src bytes during discovery
+	ContentHash string // hex SHA-256 of the file bytes, set when an analysis cache is consulted
+}
+
+// HashContent returns the hex-encoded SHA-256 digest used to recognize unchanged file content
+func HashContent(src []byte) string {
+	sum := sha256.Sum256(src)
+	return hex.EncodeToString(sum[:])
 }
 
 // Discoverer handles file discovery and filtering
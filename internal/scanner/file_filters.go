@@ -1,10 +1,14 @@
 package scanner
 
 import (
+	"bytes"
 	"fmt"
-	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
 )
 
 // shouldIncludeFile determines if a file should be included in analysis
@@ -67,13 +71,7 @@ func (d *Discoverer) matchesIncludePatterns(fileInfo FileInfo) bool {
 // matchesExcludePatterns checks if file matches any exclude patterns
 func (d *Discoverer) matchesExcludePatterns(fileInfo FileInfo) bool {
 	for _, pattern := range d.config.ExcludePatterns {
-		matched, err := filepath.Match(pattern, fileInfo.RelPath)
-		if err != nil {
-			// Invalid pattern - warn but continue (treat as non-matching)
-			fmt.Fprintf(os.Stderr, "Warning: invalid exclude pattern %q: %v\n", pattern, err)
-			continue
-		}
-		if matched {
+		if d.patternMatches(pattern, fileInfo.RelPath) {
 			return true
 		}
 	}
@@ -108,42 +106,77 @@ func (d *Discoverer) isPackageExcluded(pkg string) bool {
 	return false
 }
 
-// patternMatches checks if a file path matches a given pattern
+// patternMatches checks if a file path relative to the target directory matches a glob pattern.
+// A "**" segment matches any number of directories, including none, so "**/*.go" also matches
+// files in the target directory itself; other segments use path.Match syntax. Malformed
+// patterns never match; DiscoverFiles rejects them before any file is filtered.
 func (d *Discoverer) patternMatches(pattern, relPath string) bool {
-	if strings.Contains(pattern, "**") {
-		return strings.HasSuffix(relPath, ".go")
-	}
+	matched, err := matchGlobSegments(
+		strings.Split(filepath.ToSlash(pattern), "/"),
+		strings.Split(filepath.ToSlash(relPath), "/"),
+	)
+	return err == nil && matched
+}
 
-	matched, err := filepath.Match(pattern, relPath)
-	if err != nil {
-		// Invalid pattern - warn but continue (treat as non-matching)
-		fmt.Fprintf(os.Stderr, "Warning: invalid pattern %q: %v\n", pattern, err)
-		return false
+// ValidatePatterns checks that every include and exclude pattern of cfg is a well-formed glob
+func ValidatePatterns(cfg *config.FilterConfig) error {
+	for _, patterns := range [][]string{cfg.IncludePatterns, cfg.ExcludePatterns} {
+		for _, pattern := range patterns {
+			for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+				if _, err := path.Match(segment, ""); err != nil {
+					return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+				}
+			}
+		}
 	}
-	return matched
+	return nil
 }
 
-// isGeneratedFile checks if a file appears to be generated
-func isGeneratedFile(content string) bool {
-	lines := strings.Split(content, "\n")
-	checkLines := len(lines)
-	if checkLines > 10 {
-		checkLines = 10
+// matchGlobSegments matches path segments against pattern segments, trying every possible span
+// for each "**" segment
+func matchGlobSegments(pattern, segments []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return true, nil
+			}
+			for i := 0; i <= len(segments); i++ {
+				matched, err := matchGlobSegments(pattern[1:], segments[i:])
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+			return false, nil
+		}
+
+		if len(segments) == 0 {
+			return false, nil
+		}
+		matched, err := path.Match(pattern[0], segments[0])
+		if err != nil || !matched {
+			return false, err
+		}
+		pattern, segments = pattern[1:], segments[1:]
 	}
+	return len(segments) == 0, nil
+}
 
-	for i := 0; i < checkLines; i++ {
-		line := strings.ToLower(strings.TrimSpace(lines[i]))
+// generatedCodePattern is the marker comment that identifies generated Go source by convention
+// (see "go help generate")
+var generatedCodePattern = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
 
-		if strings.Contains(line, "code generated") ||
-			strings.Contains(line, "do not edit") ||
-			strings.Contains(line, "autogenerated") ||
-			strings.Contains(line, "auto-generated") ||
-			strings.Contains(line, "generated automatically") ||
-			strings.Contains(line, "this file was generated") {
+// IsGeneratedSource reports whether src carries the standard generated-code marker. Following
+// the convention, only lines before the package clause are considered.
+func IsGeneratedSource(src []byte) bool {
+	for _, line := range bytes.Split(src, []byte{'\n'}) {
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+		if generatedCodePattern.Match(line) {
 			return true
 		}
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("package ")) {
+			return false
+		}
 	}
-
 	return false
 }
 
@@ -0,0 +1,195 @@
+package scanner
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// LoadModuleInfo reads and parses the go.mod governing dir, searching parent directories until
+// one is found, and records the modules nested below it. The analysis workflow calls it once
+// per run and hands the result to every analyzer that needs module context. Returns nil
+// without error when dir is not inside a module.
+func LoadModuleInfo(dir string) (*metrics.ModuleInfo, error) {
+	goModPath := FindGoMod(dir)
+	if goModPath == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", goModPath, err)
+	}
+
+	info, err := ParseModuleFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", goModPath, err)
+	}
+	info.GoModPath = goModPath
+	info.NestedModules = findNestedModules(filepath.Dir(goModPath), info.Replaces)
+	return info, nil
+}
+
+// FindGoMod returns the path of the nearest go.mod at or above dir, or "" if none exists.
+func FindGoMod(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(abs, "go.mod")
+		if stat, err := os.Stat(candidate); err == nil && !stat.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
+// findNestedModules returns the modules rooted below root, sorted by path: every directory
+// with its own go.mod, skipping vendor and hidden directories, and every local directory
+// inside root that a replace directive points at. Unreadable go.mod files are ignored.
+func findNestedModules(root string, replaces []metrics.ModuleReplacement) []metrics.NestedModule {
+	var nested []metrics.NestedModule
+	seen := make(map[string]bool)
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != root && (entry.Name() == "vendor" || strings.HasPrefix(entry.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		dir := filepath.Dir(path)
+		if entry.Name() != "go.mod" || dir == root {
+			return nil
+		}
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil
+		}
+		if info, parseErr := ParseModuleFile(data); parseErr == nil {
+			nested = append(nested, metrics.NestedModule{Path: info.Path, Dir: dir})
+			seen[dir] = true
+		}
+		return nil
+	})
+
+	for _, replace := range replaces {
+		if !isLocalModulePath(replace.NewPath) {
+			continue
+		}
+		dir := replace.NewPath
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || seen[dir] {
+			continue
+		}
+		nested = append(nested, metrics.NestedModule{Path: replace.OldPath, Dir: dir})
+		seen[dir] = true
+	}
+
+	sort.Slice(nested, func(i, j int) bool { return nested[i].Path < nested[j].Path })
+	return nested
+}
+
+// isLocalModulePath reports whether a replace target is a file system path rather than a module path
+func isLocalModulePath(path string) bool {
+	return strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") || filepath.IsAbs(path)
+}
+
+// ParseModuleFile extracts the module path, go version, require list, and replace directives
+// from go.mod contents. Parsing is lax, as for dependencies' go.mod files, so directives that do
+// not affect analysis, such as retract, toolchain, or unknown ones, are accepted and ignored.
+// Replace directives are still honored because nested-module detection relies on them.
+func ParseModuleFile(data []byte) (*metrics.ModuleInfo, error) {
+	file, err := modfile.ParseLax("go.mod", data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if file.Module == nil || file.Module.Mod.Path == "" {
+		return nil, fmt.Errorf("missing module directive")
+	}
+
+	info := &metrics.ModuleInfo{Path: file.Module.Mod.Path}
+	if file.Go != nil {
+		info.GoVersion = file.Go.Version
+	}
+	for _, req := range file.Require {
+		info.Requires = append(info.Requires, metrics.ModuleRequirement{
+			Path:     req.Mod.Path,
+			Version:  req.Mod.Version,
+			Indirect: req.Indirect,
+		})
+	}
+	for _, stmt := range file.Syntax.Stmt {
+		switch x := stmt.(type) {
+		case *modfile.Line:
+			if rep, ok := parseModReplace(x.Token); ok {
+				info.Replaces = append(info.Replaces, rep)
+			}
+		case *modfile.LineBlock:
+			if len(x.Token) != 1 || x.Token[0] != "replace" {
+				continue
+			}
+			for _, line := range x.Line {
+				if rep, ok := parseModReplace(append([]string{"replace"}, line.Token...)); ok {
+					info.Replaces = append(info.Replaces, rep)
+				}
+			}
+		}
+	}
+	return info, nil
+}
+
+// parseModReplace converts the tokens of a replace line, verb included, into a replacement.
+// ParseLax treats replace as main-module-only and drops it from File.Replace, so the
+// directives are read from the syntax tree it keeps.
+func parseModReplace(tokens []string) (metrics.ModuleReplacement, bool) {
+	if len(tokens) < 4 || tokens[0] != "replace" {
+		return metrics.ModuleReplacement{}, false
+	}
+	args := make([]string, 0, len(tokens)-1)
+	for _, tok := range tokens[1:] {
+		args = append(args, unquoteModToken(tok))
+	}
+
+	var rep metrics.ModuleReplacement
+	switch {
+	case len(args) == 3 && args[1] == "=>":
+		rep = metrics.ModuleReplacement{OldPath: args[0], NewPath: args[2]}
+	case len(args) == 4 && args[1] == "=>":
+		rep = metrics.ModuleReplacement{OldPath: args[0], NewPath: args[2], NewVersion: args[3]}
+	case len(args) == 4 && args[2] == "=>":
+		rep = metrics.ModuleReplacement{OldPath: args[0], OldVersion: args[1], NewPath: args[3]}
+	case len(args) == 5 && args[2] == "=>":
+		rep = metrics.ModuleReplacement{OldPath: args[0], OldVersion: args[1], NewPath: args[3], NewVersion: args[4]}
+	default:
+		return metrics.ModuleReplacement{}, false
+	}
+	return rep, true
+}
+
+// unquoteModToken strips Go string quoting from a go.mod token, leaving bare tokens unchanged.
+func unquoteModToken(tok string) string {
+	if unquoted, err := strconv.Unquote(tok); err == nil {
+		return unquoted
+	}
+	return tok
+}
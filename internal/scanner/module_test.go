@@ -0,0 +1,186 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const fixtureGoMod = `module example.com/fixture
+
+go 1.22
+
+require (
+	github.com/spf13/cobra v1.9.1
+	golang.org/x/sync v0.8.0 // indirect
+)
+
+require github.com/stretchr/testify v1.10.0
+
+replace github.com/spf13/cobra => ../cobra
+
+replace (
+	golang.org/x/sync v0.8.0 => golang.org/x/sync v0.7.0
+)
+`
+
+func TestParseModuleFile(t *testing.T) {
+	info, err := ParseModuleFile([]byte(fixtureGoMod))
+	if err != nil {
+		t.Fatalf("ParseModuleFile failed: %v", err)
+	}
+
+	if info.Path != "example.com/fixture" {
+		t.Errorf("Expected module path example.com/fixture, got %s", info.Path)
+	}
+	if info.GoVersion != "1.22" {
+		t.Errorf("Expected go version 1.22, got %s", info.GoVersion)
+	}
+
+	expectedRequires := []metrics.ModuleRequirement{
+		{Path: "github.com/spf13/cobra", Version: "v1.9.1"},
+		{Path: "golang.org/x/sync", Version: "v0.8.0", Indirect: true},
+		{Path: "github.com/stretchr/testify", Version: "v1.10.0"},
+	}
+	if len(info.Requires) != len(expectedRequires) {
+		t.Fatalf("Expected %d requires, got %d", len(expectedRequires), len(info.Requires))
+	}
+	for i, req := range expectedRequires {
+		if info.Requires[i] != req {
+			t.Errorf("Require %d: expected %+v, got %+v", i, req, info.Requires[i])
+		}
+	}
+
+	expectedReplaces := []metrics.ModuleReplacement{
+		{OldPath: "github.com/spf13/cobra", NewPath: "../cobra"},
+		{OldPath: "golang.org/x/sync", OldVersion: "v0.8.0", NewPath: "golang.org/x/sync", NewVersion: "v0.7.0"},
+	}
+	if len(info.Replaces) != len(expectedReplaces) {
+		t.Fatalf("Expected %d replaces, got %d", len(expectedReplaces), len(info.Replaces))
+	}
+	for i, rep := range expectedReplaces {
+		if info.Replaces[i] != rep {
+			t.Errorf("Replace %d: expected %+v, got %+v", i, rep, info.Replaces[i])
+		}
+	}
+}
+
+func TestParseModuleFile_MissingModule(t *testing.T) {
+	if _, err := ParseModuleFile([]byte("go 1.22\n")); err == nil {
+		t.Error("Expected error for go.mod without module directive")
+	}
+}
+
+func TestParseModuleFile_LaxSyntax(t *testing.T) {
+	data := `// Module header comment
+module "example.com/quoted" // trailing comment
+
+go 1.24
+toolchain go1.24.2
+
+require (
+	"github.com/spf13/cobra" v1.9.1 // indirect
+)
+
+retract [v1.0.0, v1.0.5] // published by mistake
+
+replace github.com/spf13/cobra v1.9.1 => "../my cobra"
+`
+	info, err := ParseModuleFile([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseModuleFile failed: %v", err)
+	}
+
+	if info.Path != "example.com/quoted" {
+		t.Errorf("Expected module path example.com/quoted, got %s", info.Path)
+	}
+	if info.GoVersion != "1.24" {
+		t.Errorf("Expected go version 1.24, got %s", info.GoVersion)
+	}
+	wantRequire := metrics.ModuleRequirement{Path: "github.com/spf13/cobra", Version: "v1.9.1", Indirect: true}
+	if len(info.Requires) != 1 || info.Requires[0] != wantRequire {
+		t.Errorf("Expected requires [%+v], got %+v", wantRequire, info.Requires)
+	}
+	wantReplace := metrics.ModuleReplacement{OldPath: "github.com/spf13/cobra", OldVersion: "v1.9.1", NewPath: "../my cobra"}
+	if len(info.Replaces) != 1 || info.Replaces[0] != wantReplace {
+		t.Errorf("Expected replaces [%+v], got %+v", wantReplace, info.Replaces)
+	}
+}
+
+func TestLoadModuleInfo_FromSubdirectory(t *testing.T) {
+	tempDir := createTestFiles(t, map[string]string{
+		"go.mod":          fixtureGoMod,
+		"pkg/sub/file.go": "package sub\n",
+	})
+	defer os.RemoveAll(tempDir)
+
+	info, err := LoadModuleInfo(filepath.Join(tempDir, "pkg", "sub"))
+	if err != nil {
+		t.Fatalf("LoadModuleInfo failed: %v", err)
+	}
+	if info == nil {
+		t.Fatal("Expected ModuleInfo for a directory inside a module")
+	}
+	if info.GoModPath != filepath.Join(tempDir, "go.mod") {
+		t.Errorf("Expected go.mod path %s, got %s", filepath.Join(tempDir, "go.mod"), info.GoModPath)
+	}
+	if len(info.Replaces) != 2 {
+		t.Errorf("Expected 2 replace directives, got %d", len(info.Replaces))
+	}
+}
+
+func TestLoadModuleInfo_NestedModules(t *testing.T) {
+	tempDir := createTestFiles(t, map[string]string{
+		"go.mod":               "module example.com/app\n\nreplace example.com/app/tools => ./tools\n\nreplace example.com/lib => ../lib\n",
+		"main.go":              "package main\n",
+		"plugins/go.mod":       "module example.com/app/plugins\n",
+		"plugins/auth/auth.go": "package auth\n",
+		"tools/gen/gen.go":     "package gen\n",
+		"vendor/x/go.mod":      "module example.com/x\n",
+		".cache/y/go.mod":      "module example.com/y\n",
+		"broken/go.mod":        "go 1.22\n",
+		"internal/store/db.go": "package store\n",
+	})
+	defer os.RemoveAll(tempDir)
+
+	info, err := LoadModuleInfo(filepath.Join(tempDir, "internal", "store"))
+	if err != nil {
+		t.Fatalf("LoadModuleInfo failed: %v", err)
+	}
+
+	root, err := filepath.Abs(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []metrics.NestedModule{
+		{Path: "example.com/app/plugins", Dir: filepath.Join(root, "plugins")},
+		{Path: "example.com/app/tools", Dir: filepath.Join(root, "tools")},
+	}
+	if len(info.NestedModules) != len(expected) {
+		t.Fatalf("Expected nested modules %+v, got %+v", expected, info.NestedModules)
+	}
+	for i, want := range expected {
+		if info.NestedModules[i] != want {
+			t.Errorf("Nested module %d: expected %+v, got %+v", i, want, info.NestedModules[i])
+		}
+	}
+}
+
+func TestLoadModuleInfo_NoModule(t *testing.T) {
+	tempDir := createTestFiles(t, map[string]string{"main.go": "package main\n"})
+	defer os.RemoveAll(tempDir)
+
+	if FindGoMod(tempDir) != "" {
+		t.Skip("temporary directory is inside a Go module")
+	}
+
+	info, err := LoadModuleInfo(tempDir)
+	if err != nil {
+		t.Fatalf("LoadModuleInfo failed: %v", err)
+	}
+	if info != nil {
+		t.Errorf("Expected nil ModuleInfo outside a module, got %+v", info)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
+	"sort"
 	"sync"
 
 	"github.com/opd-ai/go-stats-generator/internal/config"
@@ -15,6 +17,13 @@ import (
 type WorkerPool struct {
 	workerCount int
 	discoverer  *Discoverer
+	cache       ResultCache
+}
+
+// ResultCache looks up analysis results an earlier run stored for a file, keyed by its
+// relative path and valid only for the content hash they were computed from
+type ResultCache interface {
+	Get(key, contentHash string) ([]byte, bool)
 }
 
 // Job represents a file analysis job
@@ -33,10 +42,14 @@ type Result struct {
 	// on nodes belonging to File, enabling concurrent workers to each use their
 	// own FileSet and eliminating contention on a single shared mutex.
 	FileSet *token.FileSet
+	// Cached holds the analysis results stored for this exact file content by an earlier run,
+	// or nil on a cache miss. Callers may reuse them instead of running per-file analyzers.
+	// Cached files are parsed like any other, so File is set either way.
+	Cached []byte
 }
 
-// ProgressCallback is called to report progress
-type ProgressCallback func(completed, total int)
+// ProgressCallback is called with an analysis-phase event after each file is processed
+type ProgressCallback func(event config.ProgressEvent)
 
 // NewWorkerPool creates a new worker pool for concurrent file processing with configurable parallelism.
 // The worker count is determined by cfg.WorkerCount (defaults to 1 if <= 0). Each worker processes Go source files
@@ -53,7 +66,16 @@ func NewWorkerPool(cfg *config.PerformanceConfig, discoverer *Discoverer) *Worke
 	}
 }
 
-// ProcessFiles processes a list of files concurrently
+// SetCache makes workers look each file up in cache by its content hash and attach any stored
+// results to the file's Result. Cached files are still parsed, because package-scope analyses
+// need the AST of every file. Passing nil disables lookups.
+func (wp *WorkerPool) SetCache(cache ResultCache) {
+	wp.cache = cache
+}
+
+// ProcessFiles processes a list of files concurrently and delivers their results in the order of
+// files, so whatever is built from them does not depend on worker scheduling. Progress is still
+// reported as each file completes. The Src of each entry in files is cleared once it is queued.
 func (wp *WorkerPool) ProcessFiles(ctx context.Context, files []FileInfo, progressCb ProgressCallback) (<-chan Result, error) {
 	if len(files) == 0 {
 		return wp.createEmptyChannel(), nil
@@ -64,7 +86,8 @@ func (wp *WorkerPool) ProcessFiles(ctx context.Context, files []FileInfo, progre
 	wp.distributeJobs(ctx, jobChan, files)
 	wp.closeResultOnCompletion(wg, resultChan)
 
-	return wp.applyProgressTracking(ctx, resultChan, len(files), progressCb), nil
+	tracked := wp.applyProgressTracking(ctx, resultChan, len(files), progressCb)
+	return wp.orderResults(ctx, tracked, files), nil
 }
 
 // createEmptyChannel returns a closed channel for empty file lists
@@ -98,13 +121,15 @@ func (wp *WorkerPool) startWorkers(ctx context.Context, jobChan <-chan FileInfo,
 	return &wg
 }
 
-// distributeJobs sends files to the job channel asynchronously
+// distributeJobs sends files to the job channel asynchronously. Once a file is queued its
+// cached source bytes are dropped from files, so only the queued jobs hold source in memory.
 func (wp *WorkerPool) distributeJobs(ctx context.Context, jobChan chan<- FileInfo, files []FileInfo) {
 	go func() {
 		defer close(jobChan)
-		for _, file := range files {
+		for i := range files {
 			select {
-			case jobChan <- file:
+			case jobChan <- files[i]:
+				files[i].Src = nil
 			case <-ctx.Done():
 				return
 			}
@@ -128,6 +153,61 @@ func (wp *WorkerPool) applyProgressTracking(ctx context.Context, resultChan <-ch
 	return resultChan
 }
 
+// orderResults forwards results in the order of files. A result that completes ahead of an
+// earlier file is held until that file's result arrives; results still held when the upstream
+// channel closes, as after a cancellation, are delivered in order at the end.
+func (wp *WorkerPool) orderResults(ctx context.Context, resultChan <-chan Result, files []FileInfo) <-chan Result {
+	index := make(map[string]int, len(files))
+	for i := range files {
+		index[files[i].Path] = i
+	}
+	orderedChan := make(chan Result, cap(resultChan))
+
+	go func() {
+		defer close(orderedChan)
+		send := func(result Result) bool {
+			select {
+			case orderedChan <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		pending := make(map[int]Result)
+		next := 0
+		for result := range resultChan {
+			i, ok := index[result.FileInfo.Path]
+			if !ok {
+				if !send(result) {
+					return
+				}
+				continue
+			}
+			pending[i] = result
+			for held, ready := pending[next]; ready; held, ready = pending[next] {
+				delete(pending, next)
+				next++
+				if !send(held) {
+					return
+				}
+			}
+		}
+
+		remaining := make([]int, 0, len(pending))
+		for i := range pending {
+			remaining = append(remaining, i)
+		}
+		sort.Ints(remaining)
+		for _, i := range remaining {
+			if !send(pending[i]) {
+				return
+			}
+		}
+	}()
+	return orderedChan
+}
+
 // worker processes jobs from the job channel
 func (wp *WorkerPool) worker(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan FileInfo, resultChan chan<- Result) {
 	defer wg.Done()
@@ -174,11 +254,14 @@ func (wp *WorkerPool) sendResultOrCancel(ctx context.Context, result Result, res
 // contend on the shared FileSet mutex in token.FileSet.AddFile.  The per-file
 // FileSet is stored in the returned Result so that downstream analyzers can
 // call fset.Position on AST nodes without needing a shared FileSet.
+// The file is looked up in the cache first, and any stored results are attached to the Result.
 // After parsing, Src is cleared to release the cached bytes and reduce peak memory pressure.
 func (wp *WorkerPool) processFile(fileInfo FileInfo) Result {
 	// Give each file its own FileSet to eliminate shared-mutex contention during parsing.
 	localFset := token.NewFileSet()
 
+	cached := wp.lookupCached(&fileInfo)
+
 	var (
 		file *ast.File
 		err  error
@@ -212,9 +295,34 @@ func (wp *WorkerPool) processFile(fileInfo FileInfo) Result {
 		File:     file,
 		FileSet:  localFset,
 		Error:    nil,
+		Cached:   cached,
 	}
 }
 
+// lookupCached fills in the file's content hash if needed and returns the results the cache
+// holds for it, or nil when no cache is set or the file cannot be read. Source bytes read to
+// hash the file are kept in Src so they are not read again for parsing.
+func (wp *WorkerPool) lookupCached(fileInfo *FileInfo) []byte {
+	if wp.cache == nil {
+		return nil
+	}
+	if fileInfo.ContentHash == "" {
+		if fileInfo.Src == nil {
+			src, err := os.ReadFile(fileInfo.Path)
+			if err != nil {
+				return nil
+			}
+			fileInfo.Src = src
+		}
+		fileInfo.ContentHash = HashContent(fileInfo.Src)
+	}
+	data, ok := wp.cache.Get(fileInfo.RelPath, fileInfo.ContentHash)
+	if !ok {
+		return nil
+	}
+	return data
+}
+
 // trackProgress monitors processing progress and calls the callback
 func (wp *WorkerPool) trackProgress(ctx context.Context, resultChan <-chan Result, total int, progressCb ProgressCallback) <-chan Result {
 	completed := 0
@@ -236,8 +344,10 @@ func (wp *WorkerPool) trackProgress(ctx context.Context, resultChan <-chan Resul
 			select {
 			case result, ok := <-resultChan:
 				if !ok {
-					// Upstream channel closed; emit the final progress update and exit.
-					progressCb(total, total)
+					// Upstream channel closed; make sure the phase is reported complete.
+					if completed < total {
+						progressCb(analysisProgress(total, total, ""))
+					}
 					return
 				}
 				select {
@@ -246,7 +356,7 @@ func (wp *WorkerPool) trackProgress(ctx context.Context, resultChan <-chan Resul
 					return
 				}
 				completed++
-				progressCb(completed, total)
+				progressCb(analysisProgress(completed, total, result.FileInfo.RelPath))
 			case <-ctx.Done():
 				return
 			}
@@ -256,6 +366,11 @@ func (wp *WorkerPool) trackProgress(ctx context.Context, resultChan <-chan Resul
 	return forwardChan
 }
 
+// analysisProgress builds the analysis-phase event for completed of total processed files
+func analysisProgress(completed, total int, file string) config.ProgressEvent {
+	return config.ProgressEvent{Phase: config.PhaseAnalysis, Completed: completed, Total: total, File: file}
+}
+
 // ProcessFilesSequential processes files one by one (useful for debugging)
 func (wp *WorkerPool) ProcessFilesSequential(ctx context.Context, files []FileInfo, progressCb ProgressCallback) (<-chan Result, error) {
 	resultChan := make(chan Result, len(files))
@@ -274,11 +389,10 @@ func (wp *WorkerPool) ProcessFilesSequential(ctx context.Context, files []FileIn
 			resultChan <- result
 
 			if progressCb != nil {
-				progressCb(i+1, len(files))
+				progressCb(analysisProgress(i+1, len(files), fileInfo.RelPath))
 			}
 		}
 	}()
 
 	return resultChan, nil
 }
-
@@ -0,0 +1,120 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+)
+
+// mapCache is a ResultCache backed by a map from key to content hash and data
+type mapCache map[string][2]string
+
+func (m mapCache) Get(key, contentHash string) ([]byte, bool) {
+	entry, ok := m[key]
+	if !ok || entry[0] != contentHash {
+		return nil, false
+	}
+	return []byte(entry[1]), true
+}
+
+func TestWorkerPoolCacheLookup(t *testing.T) {
+	tempDir := createTestFiles(t, map[string]string{
+		"cached.go": "package main\n\nfunc cached() {}\n",
+		"fresh.go":  "package main\n\nfunc fresh() {}\n",
+	})
+	defer os.RemoveAll(tempDir)
+
+	discoverer := NewDiscoverer(&config.FilterConfig{IncludePatterns: []string{"**/*.go"}})
+	files, err := discoverer.DiscoverFiles(tempDir)
+	if err != nil {
+		t.Fatalf("DiscoverFiles failed: %v", err)
+	}
+
+	cache := mapCache{
+		"cached.go": {HashContent([]byte("package main\n\nfunc cached() {}\n")), "stored"},
+		"fresh.go":  {"outdated-hash", "stale"},
+	}
+	pool := NewWorkerPool(&config.PerformanceConfig{WorkerCount: 2}, discoverer)
+	pool.SetCache(cache)
+
+	results, err := pool.ProcessFiles(context.Background(), files, nil)
+	if err != nil {
+		t.Fatalf("ProcessFiles failed: %v", err)
+	}
+
+	seen := 0
+	for result := range results {
+		seen++
+		if result.Error != nil {
+			t.Fatalf("unexpected error for %s: %v", result.FileInfo.RelPath, result.Error)
+		}
+		if result.FileInfo.ContentHash == "" {
+			t.Errorf("%s: content hash not set", result.FileInfo.RelPath)
+		}
+		switch result.FileInfo.RelPath {
+		case "cached.go":
+			if string(result.Cached) != "stored" {
+				t.Errorf("cached.go: expected stored results, got %q", result.Cached)
+			}
+			if result.File == nil {
+				t.Error("cached.go: a cache hit must still be parsed for package-scope analyses")
+			}
+		case "fresh.go":
+			if result.Cached != nil {
+				t.Errorf("fresh.go: changed content must not match, got %q", result.Cached)
+			}
+			if result.File == nil {
+				t.Error("fresh.go: a cache miss must be parsed")
+			}
+		}
+	}
+	if seen != 2 {
+		t.Errorf("expected 2 results, got %d", seen)
+	}
+}
+
+func TestWorkerPoolResultsInFileOrder(t *testing.T) {
+	sources := make(map[string]string)
+	for i := 0; i < 24; i++ {
+		// Earlier files are larger so they tend to finish after later ones
+		body := strings.Repeat(fmt.Sprintf("func f%d() { _ = %d }\n", i, i), (24-i)*50)
+		sources[fmt.Sprintf("file%02d.go", i)] = "package main\n\n" + body
+	}
+	tempDir := createTestFiles(t, sources)
+	defer os.RemoveAll(tempDir)
+
+	discoverer := NewDiscoverer(&config.FilterConfig{IncludePatterns: []string{"**/*.go"}})
+	files, err := discoverer.DiscoverFiles(tempDir)
+	if err != nil {
+		t.Fatalf("DiscoverFiles failed: %v", err)
+	}
+
+	pool := NewWorkerPool(&config.PerformanceConfig{WorkerCount: 4}, discoverer)
+	results, err := pool.ProcessFiles(context.Background(), files, func(config.ProgressEvent) {})
+	if err != nil {
+		t.Fatalf("ProcessFiles failed: %v", err)
+	}
+
+	i := 0
+	for result := range results {
+		if i >= len(files) {
+			t.Fatalf("more results than files, extra %s", result.FileInfo.RelPath)
+		}
+		if result.FileInfo.Path != files[i].Path {
+			t.Errorf("result %d: got %s, want %s", i, result.FileInfo.RelPath, files[i].RelPath)
+		}
+		i++
+	}
+	if i != len(files) {
+		t.Errorf("expected %d results, got %d", len(files), i)
+	}
+	for _, file := range files {
+		if file.Src != nil {
+			t.Errorf("%s: source bytes still held after processing", file.RelPath)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// AnalysisCache persists analysis results between runs, keyed by an arbitrary name (usually a
+// file path) and validated by the content hash the results were computed from. The whole cache
+// is tied to a version string: opening it with a different version, such as after a tool
+// upgrade or a configuration change, discards every entry. It is safe for concurrent use.
+type AnalysisCache struct {
+	path    string
+	version string
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	used    map[string]bool
+	dirty   bool
+}
+
+// cacheEntry is the stored result for one key
+type cacheEntry struct {
+	Hash string `json:"hash"`
+	Data []byte `json:"data"`
+}
+
+// cacheDocument is the on-disk layout of an analysis cache file
+type cacheDocument struct {
+	Version string                `json:"version"`
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+// OpenAnalysisCache loads the gzip-compressed cache file at path. A missing or unreadable
+// cache, or one written under a different version, yields an empty cache rather than an
+// error, since a cache miss only costs recomputation; only I/O failures are reported.
+func OpenAnalysisCache(path, version string) (*AnalysisCache, error) {
+	cache := &AnalysisCache{
+		path:    path,
+		version: version,
+		entries: make(map[string]cacheEntry),
+		used:    make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read analysis cache: %w", err)
+	}
+
+	var doc cacheDocument
+	raw, err := decompress(data)
+	if err != nil || json.Unmarshal(raw, &doc) != nil || doc.Version != version {
+		cache.dirty = true
+		return cache, nil
+	}
+	if doc.Entries != nil {
+		cache.entries = doc.Entries
+	}
+	return cache, nil
+}
+
+// Get returns the data stored under key if it was computed from content with the given hash
+func (c *AnalysisCache) Get(key, hash string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.Hash != hash {
+		return nil, false
+	}
+	c.used[key] = true
+	return entry.Data, true
+}
+
+// Put stores data computed from content with the given hash, replacing any previous entry
+func (c *AnalysisCache) Put(key, hash string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{Hash: hash, Data: data}
+	c.used[key] = true
+	c.dirty = true
+}
+
+// Save writes the cache back to disk, keeping only the entries read or written since it was
+// opened so results for deleted or renamed files do not accumulate. The file is replaced
+// atomically, and nothing is written when no entry changed.
+func (c *AnalysisCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if !c.used[key] {
+			delete(c.entries, key)
+			c.dirty = true
+		}
+	}
+	if !c.dirty {
+		return nil
+	}
+
+	raw, err := json.Marshal(cacheDocument{Version: c.version, Entries: c.entries})
+	if err != nil {
+		return fmt.Errorf("failed to encode analysis cache: %w", err)
+	}
+	data, err := compress(raw)
+	if err != nil {
+		return fmt.Errorf("failed to compress analysis cache: %w", err)
+	}
+	if err := writeFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("failed to write analysis cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place, so
+// concurrent readers never observe a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisCache_SaveAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "analysis.json.gz")
+
+	cache, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	_, ok := cache.Get("main.go", "abc")
+	assert.False(t, ok, "a new cache is empty")
+
+	cache.Put("main.go", "abc", []byte(`{"functions":[]}`))
+	require.NoError(t, cache.Save())
+
+	reopened, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	data, ok := reopened.Get("main.go", "abc")
+	require.True(t, ok)
+	assert.Equal(t, []byte(`{"functions":[]}`), data)
+
+	_, ok = reopened.Get("main.go", "changed")
+	assert.False(t, ok, "entries only match the content hash they were stored with")
+}
+
+func TestAnalysisCache_VersionChangeDiscardsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analysis.json.gz")
+
+	cache, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	cache.Put("main.go", "abc", []byte("data"))
+	require.NoError(t, cache.Save())
+
+	upgraded, err := OpenAnalysisCache(path, "1.1.0")
+	require.NoError(t, err)
+	_, ok := upgraded.Get("main.go", "abc")
+	assert.False(t, ok)
+}
+
+func TestAnalysisCache_SavePrunesUnusedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analysis.json.gz")
+
+	cache, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	cache.Put("kept.go", "1", []byte("kept"))
+	cache.Put("deleted.go", "2", []byte("deleted"))
+	require.NoError(t, cache.Save())
+
+	second, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	_, ok := second.Get("kept.go", "1")
+	require.True(t, ok)
+	require.NoError(t, second.Save())
+
+	third, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	_, ok = third.Get("kept.go", "1")
+	assert.True(t, ok)
+	_, ok = third.Get("deleted.go", "2")
+	assert.False(t, ok, "entries not used by the previous run are dropped")
+}
+
+func TestAnalysisCache_CorruptFileIsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analysis.json.gz")
+	require.NoError(t, os.WriteFile(path, []byte("not gzip"), 0o644))
+
+	cache, err := OpenAnalysisCache(path, "1.0.0")
+	require.NoError(t, err)
+	_, ok := cache.Get("main.go", "abc")
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Save(), "a corrupt cache is overwritten")
+	_, err = OpenAnalysisCache(path, "1.0.0")
+	assert.NoError(t, err)
+}
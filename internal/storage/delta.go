@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// defaultFullSnapshotInterval is how many consecutive snapshots share one full base when
+// SQLiteConfig.FullSnapshotInterval is not set
+const defaultFullSnapshotInterval = 10
+
+// maxDeltaRatio is the largest delta size, relative to the full report, that is still worth
+// storing as a delta; larger deltas are stored as a new full base instead
+const maxDeltaRatio = 0.5
+
+// symbolSections are the top-level report keys holding per-symbol lists. Deltas record only the
+// changed elements of these lists instead of replacing the whole section.
+var symbolSections = map[string]bool{
+	"functions":  true,
+	"structs":    true,
+	"interfaces": true,
+	"packages":   true,
+}
+
+// snapshotDelta describes how a report's JSON differs from the JSON of its base snapshot
+type snapshotDelta struct {
+	Sections map[string]json.RawMessage `json:"sections,omitempty"`
+	Removed  []string                   `json:"removed,omitempty"`
+	Symbols  map[string]symbolListDelta `json:"symbols,omitempty"`
+}
+
+// symbolListDelta describes a changed symbol list. Keys lists the identity of every element
+// in order unless SameOrder is set, in which case the base order is reused. Changed holds the
+// full JSON of each element that is new or differs from the base.
+type symbolListDelta struct {
+	SameOrder bool                       `json:"same_order,omitempty"`
+	Keys      []string                   `json:"keys,omitempty"`
+	Changed   map[string]json.RawMessage `json:"changed,omitempty"`
+}
+
+// symbolIdentity holds the fields that identify an element of a symbol list
+type symbolIdentity struct {
+	Name         string `json:"name"`
+	Package      string `json:"package"`
+	File         string `json:"file"`
+	Path         string `json:"path"`
+	ReceiverType string `json:"receiver_type"`
+}
+
+// encodeSnapshotDelta computes the delta that turns the base report JSON into the current report JSON
+func encodeSnapshotDelta(base, current []byte) ([]byte, error) {
+	var baseSections, currentSections map[string]json.RawMessage
+	if err := json.Unmarshal(base, &baseSections); err != nil {
+		return nil, fmt.Errorf("failed to decode base report: %w", err)
+	}
+	if err := json.Unmarshal(current, &currentSections); err != nil {
+		return nil, fmt.Errorf("failed to decode current report: %w", err)
+	}
+
+	delta := snapshotDelta{}
+	for key, value := range currentSections {
+		baseValue, exists := baseSections[key]
+		if exists && bytes.Equal(baseValue, value) {
+			continue
+		}
+		if exists && symbolSections[key] {
+			if listDelta, ok := diffSymbolList(baseValue, value); ok {
+				setSymbolDelta(&delta, key, listDelta)
+				continue
+			}
+		}
+		setSectionDelta(&delta, key, value)
+	}
+	for key := range baseSections {
+		if _, exists := currentSections[key]; !exists {
+			delta.Removed = append(delta.Removed, key)
+		}
+	}
+
+	return json.Marshal(delta)
+}
+
+// setSymbolDelta records a symbol list delta for key, allocating the map on first use
+func setSymbolDelta(delta *snapshotDelta, key string, listDelta symbolListDelta) {
+	if delta.Symbols == nil {
+		delta.Symbols = make(map[string]symbolListDelta)
+	}
+	delta.Symbols[key] = listDelta
+}
+
+// setSectionDelta records a whole-section replacement for key, allocating the map on first use
+func setSectionDelta(delta *snapshotDelta, key string, value json.RawMessage) {
+	if delta.Sections == nil {
+		delta.Sections = make(map[string]json.RawMessage)
+	}
+	delta.Sections[key] = value
+}
+
+// applySnapshotDelta rebuilds the current report JSON from the base report JSON and a delta
+func applySnapshotDelta(base, encodedDelta []byte) ([]byte, error) {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(base, &sections); err != nil {
+		return nil, fmt.Errorf("failed to decode base report: %w", err)
+	}
+	var delta snapshotDelta
+	if err := json.Unmarshal(encodedDelta, &delta); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot delta: %w", err)
+	}
+
+	for _, key := range delta.Removed {
+		delete(sections, key)
+	}
+	for key, value := range delta.Sections {
+		sections[key] = value
+	}
+	for key, listDelta := range delta.Symbols {
+		value, err := applySymbolListDelta(sections[key], listDelta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild %s: %w", key, err)
+		}
+		sections[key] = value
+	}
+
+	return json.Marshal(sections)
+}
+
+// diffSymbolList compares two JSON arrays element by element using symbol identity. It returns
+// false when either value is not an array, so the caller falls back to replacing the section.
+func diffSymbolList(base, current json.RawMessage) (symbolListDelta, bool) {
+	baseKeys, baseElements, ok := indexSymbolList(base)
+	if !ok {
+		return symbolListDelta{}, false
+	}
+	currentKeys, currentElements, ok := indexSymbolList(current)
+	if !ok {
+		return symbolListDelta{}, false
+	}
+
+	listDelta := symbolListDelta{Changed: make(map[string]json.RawMessage)}
+	for _, key := range currentKeys {
+		if baseElement, exists := baseElements[key]; !exists || !bytes.Equal(baseElement, currentElements[key]) {
+			listDelta.Changed[key] = currentElements[key]
+		}
+	}
+	if slices.Equal(baseKeys, currentKeys) {
+		listDelta.SameOrder = true
+	} else {
+		listDelta.Keys = currentKeys
+	}
+	return listDelta, true
+}
+
+// applySymbolListDelta rebuilds a JSON array from the base array and a symbol list delta
+func applySymbolListDelta(base json.RawMessage, listDelta symbolListDelta) (json.RawMessage, error) {
+	baseKeys, baseElements, ok := indexSymbolList(base)
+	if !ok {
+		return nil, fmt.Errorf("base section is not a list")
+	}
+
+	keys := listDelta.Keys
+	if listDelta.SameOrder {
+		keys = baseKeys
+	}
+
+	elements := make([]json.RawMessage, 0, len(keys))
+	for _, key := range keys {
+		element, exists := listDelta.Changed[key]
+		if !exists {
+			element, exists = baseElements[key]
+		}
+		if !exists {
+			return nil, fmt.Errorf("symbol %q missing from base and delta", key)
+		}
+		elements = append(elements, element)
+	}
+	return json.Marshal(elements)
+}
+
+// indexSymbolList splits a JSON array into ordered identity keys and a key-to-element map.
+// Elements sharing an identity are disambiguated by their occurrence number.
+func indexSymbolList(raw json.RawMessage) ([]string, map[string]json.RawMessage, bool) {
+	if len(raw) == 0 || raw[0] != '[' {
+		return nil, nil, false
+	}
+	var elements []json.RawMessage
+	if err := json.Unmarshal(raw, &elements); err != nil {
+		return nil, nil, false
+	}
+
+	keys := make([]string, 0, len(elements))
+	byKey := make(map[string]json.RawMessage, len(elements))
+	seen := make(map[string]int)
+	for _, element := range elements {
+		var id symbolIdentity
+		if err := json.Unmarshal(element, &id); err != nil {
+			return nil, nil, false
+		}
+		key := id.Package + "|" + id.Path + "|" + id.File + "|" + id.ReceiverType + "|" + id.Name
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key += "#" + strconv.Itoa(n)
+		}
+		keys = append(keys, key)
+		byKey[key] = element
+	}
+	return keys, byKey, true
+}
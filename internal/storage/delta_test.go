@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createDeltaTestReport builds a report with enough symbols that small edits produce small deltas
+func createDeltaTestReport(functionCount int) metrics.Report {
+	report := metrics.Report{
+		Overview: metrics.OverviewMetrics{TotalFiles: 10, TotalFunctions: functionCount},
+		Packages: []metrics.PackageMetrics{{Name: "core", Path: "example.com/core"}},
+	}
+	for i := 0; i < functionCount; i++ {
+		report.Functions = append(report.Functions, metrics.FunctionMetrics{
+			Name:    fmt.Sprintf("Function%d", i),
+			Package: "core",
+			File:    fmt.Sprintf("core/file%d.go", i%5),
+			Line:    i * 10,
+			Lines:   metrics.LineMetrics{Code: 12, Total: 15},
+		})
+	}
+	return report
+}
+
+func TestSnapshotDelta_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(r *metrics.Report)
+	}{
+		{
+			name:   "unchanged report",
+			mutate: func(r *metrics.Report) {},
+		},
+		{
+			name:   "changed function",
+			mutate: func(r *metrics.Report) { r.Functions[3].Lines.Code = 40 },
+		},
+		{
+			name: "added and removed functions",
+			mutate: func(r *metrics.Report) {
+				r.Functions = append(r.Functions[1:], metrics.FunctionMetrics{Name: "Added", Package: "core", File: "core/new.go"})
+			},
+		},
+		{
+			name: "duplicate identities",
+			mutate: func(r *metrics.Report) {
+				r.Functions = append(r.Functions, metrics.FunctionMetrics{Name: "init", File: "core/a.go"},
+					metrics.FunctionMetrics{Name: "init", File: "core/a.go", Line: 30})
+			},
+		},
+		{
+			name:   "emptied symbol list",
+			mutate: func(r *metrics.Report) { r.Functions = []metrics.FunctionMetrics{} },
+		},
+		{
+			name:   "optional section added",
+			mutate: func(r *metrics.Report) { r.Team = &metrics.TeamMetrics{} },
+		},
+		{
+			name:   "non-symbol section changed",
+			mutate: func(r *metrics.Report) { r.Metadata.GeneratedAt = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := json.Marshal(createDeltaTestReport(20))
+			require.NoError(t, err)
+
+			report := createDeltaTestReport(20)
+			tt.mutate(&report)
+			current, err := json.Marshal(report)
+			require.NoError(t, err)
+
+			delta, err := encodeSnapshotDelta(base, current)
+			require.NoError(t, err)
+			rebuilt, err := applySnapshotDelta(base, delta)
+			require.NoError(t, err)
+
+			var decoded metrics.Report
+			require.NoError(t, json.Unmarshal(rebuilt, &decoded))
+			roundTrip, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, string(current), string(roundTrip))
+		})
+	}
+}
+
+func TestSQLiteStorage_DeltaSnapshots(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewSQLiteStorageImpl(SQLiteConfig{
+		Path:              filepath.Join(t.TempDir(), "delta.db"),
+		MaxConnections:    5,
+		EnableWAL:         true,
+		EnableFK:          true,
+		EnableCompression: true,
+		EnableDeltas:      true,
+	})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	baseReport := createDeltaTestReport(200)
+	metadata := createTestSQLiteMetadata()
+	require.NoError(t, storage.Store(ctx, metrics.Snapshot{ID: "base", Report: baseReport}, metadata))
+
+	nextReport := createDeltaTestReport(200)
+	nextReport.Functions[42].Lines.Code = 99
+	nextReport.Functions = append(nextReport.Functions, metrics.FunctionMetrics{Name: "Added", Package: "core", File: "core/new.go"})
+	metadata.Timestamp = metadata.Timestamp.Add(time.Minute)
+	require.NoError(t, storage.Store(ctx, metrics.Snapshot{ID: "next", Report: nextReport}, metadata))
+
+	assert.Equal(t, "base", deltaBaseOf(t, storage, "next"))
+	infos, err := storage.List(ctx, SnapshotFilter{})
+	require.NoError(t, err)
+	sizes := make(map[string]int64)
+	for _, info := range infos {
+		sizes[info.ID] = info.Size
+	}
+	assert.Less(t, sizes["next"], sizes["base"], "delta snapshot should be smaller than its base")
+
+	expected, err := json.Marshal(nextReport)
+	require.NoError(t, err)
+	assertReportBytes(t, storage, "next", expected)
+
+	require.NoError(t, storage.Delete(ctx, "base"))
+	assert.Empty(t, deltaBaseOf(t, storage, "next"), "dependents are rewritten in full when their base is deleted")
+	assertReportBytes(t, storage, "next", expected)
+}
+
+func TestSQLiteStorage_DeltaFullSnapshotInterval(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewSQLiteStorageImpl(SQLiteConfig{
+		Path:                 filepath.Join(t.TempDir(), "interval.db"),
+		MaxConnections:       5,
+		EnableDeltas:         true,
+		FullSnapshotInterval: 2,
+	})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	metadata := createTestSQLiteMetadata()
+	for i, id := range []string{"s1", "s2", "s3", "s4"} {
+		metadata.Timestamp = metadata.Timestamp.Add(time.Minute)
+		report := createDeltaTestReport(100)
+		report.Functions[i].Lines.Code = 50
+		require.NoError(t, storage.Store(ctx, metrics.Snapshot{ID: id, Report: report}, metadata))
+	}
+
+	assert.Empty(t, deltaBaseOf(t, storage, "s1"))
+	assert.Equal(t, "s1", deltaBaseOf(t, storage, "s2"))
+	assert.Empty(t, deltaBaseOf(t, storage, "s3"), "a new full base is written once the interval is reached")
+	assert.Equal(t, "s3", deltaBaseOf(t, storage, "s4"))
+
+	require.NoError(t, storage.Cleanup(ctx, RetentionPolicy{MaxCount: 1}))
+	_, err = storage.Retrieve(ctx, "s4")
+	assert.NoError(t, err, "cleanup keeps the base of a retained delta snapshot")
+}
+
+func TestSQLiteStorage_CountCleanupWithDeltas(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewSQLiteStorageImpl(SQLiteConfig{
+		Path:                 filepath.Join(t.TempDir(), "retention.db"),
+		MaxConnections:       5,
+		EnableDeltas:         true,
+		FullSnapshotInterval: 2,
+	})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	metadata := createTestSQLiteMetadata()
+	for i, id := range []string{"s1", "s2", "s3", "s4", "s5"} {
+		metadata.Timestamp = metadata.Timestamp.Add(time.Minute)
+		report := createDeltaTestReport(100)
+		report.Functions[i].Lines.Code = 50
+		require.NoError(t, storage.Store(ctx, metrics.Snapshot{ID: id, Report: report}, metadata))
+	}
+	require.Equal(t, "s1", deltaBaseOf(t, storage, "s2"))
+	require.Equal(t, "s3", deltaBaseOf(t, storage, "s4"))
+	require.Empty(t, deltaBaseOf(t, storage, "s5"))
+
+	require.NoError(t, storage.Cleanup(ctx, RetentionPolicy{MaxCount: 2}))
+
+	infos, err := storage.List(ctx, SnapshotFilter{})
+	require.NoError(t, err)
+	var remaining []string
+	for _, info := range infos {
+		remaining = append(remaining, info.ID)
+	}
+	assert.ElementsMatch(t, []string{"s3", "s4", "s5"}, remaining,
+		"the newest MaxCount snapshots remain, plus only the base a remaining delta needs")
+	_, err = storage.Retrieve(ctx, "s4")
+	assert.NoError(t, err)
+}
+
+// deltaBaseOf returns the base snapshot ID recorded for id, or "" for full snapshots
+func deltaBaseOf(t *testing.T, storage *SQLiteStorage, id string) string {
+	t.Helper()
+	var baseID sql.NullString
+	err := storage.db.QueryRow("SELECT base_id FROM snapshots WHERE id = ?", id).Scan(&baseID)
+	require.NoError(t, err)
+	return baseID.String
+}
+
+// assertReportBytes checks that the retrieved report marshals to exactly the expected JSON
+func assertReportBytes(t *testing.T, storage *SQLiteStorage, id string, expected []byte) {
+	t.Helper()
+	retrieved, err := storage.Retrieve(context.Background(), id)
+	require.NoError(t, err)
+	actual, err := json.Marshal(retrieved.Report)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -1,7 +1,8 @@
 // Package storage provides historical metrics persistence for trend analysis.
 //
 // The storage package defines the MetricsStorage interface and provides multiple
-// implementations including SQLite for persistent storage, JSON file storage,
+// implementations including SQLite and PostgreSQL for persistent storage, JSON file storage,
 // and in-memory storage for testing. It supports storing, retrieving, listing,
-// and cleaning up metrics snapshots with metadata tagging.
+// and cleaning up metrics snapshots with metadata tagging. AnalysisCache persists per-file
+// analysis results keyed by content hash so unchanged files can be skipped on later runs.
 package storage
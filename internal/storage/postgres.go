@@ -0,0 +1,482 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// PostgresStorage implements MetricsStorage using PostgreSQL so several CI runners can share one
+// snapshot history. The schema mirrors SQLiteStorage; report JSON is gzip-compressed when enabled.
+type PostgresStorage struct {
+	db     *sql.DB
+	config PostgresConfig
+}
+
+const (
+	createPostgresSnapshotsTableSQL = `
+	CREATE TABLE IF NOT EXISTS snapshots (
+		id TEXT PRIMARY KEY,
+		timestamp TIMESTAMPTZ NOT NULL,
+		git_commit TEXT,
+		git_branch TEXT,
+		git_tag TEXT,
+		version TEXT,
+		author TEXT,
+		description TEXT,
+		size_bytes BIGINT NOT NULL,
+		data_compressed BYTEA NOT NULL,
+		mbi_score_avg DOUBLE PRECISION,
+		duplication_ratio DOUBLE PRECISION,
+		doc_coverage DOUBLE PRECISION,
+		complexity_violations INTEGER,
+		naming_violations INTEGER,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	createPostgresTagsTableSQL = `
+	CREATE TABLE IF NOT EXISTS snapshot_tags (
+		snapshot_id TEXT NOT NULL REFERENCES snapshots(id) ON DELETE CASCADE,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (snapshot_id, key)
+	);`
+)
+
+// postgresSchemaIndexes are the SQLite indexes minus the one on base_id, as PostgreSQL stores
+// every snapshot in full
+var postgresSchemaIndexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_snapshots_timestamp ON snapshots(timestamp)",
+	"CREATE INDEX IF NOT EXISTS idx_snapshots_branch ON snapshots(git_branch)",
+	"CREATE INDEX IF NOT EXISTS idx_snapshots_tag ON snapshots(git_tag)",
+	"CREATE INDEX IF NOT EXISTS idx_tags_key ON snapshot_tags(key)",
+	"CREATE INDEX IF NOT EXISTS idx_tags_value ON snapshot_tags(value)",
+}
+
+// Advisory lock keys serializing schema setup and retention cleanup across clients of one database
+const (
+	postgresSchemaLockKey  int64 = 0x67737467_0001
+	postgresCleanupLockKey int64 = 0x67737467_0002
+)
+
+// postgresUniqueViolation is the SQLSTATE PostgreSQL reports for a duplicate primary key
+const postgresUniqueViolation = "23505"
+
+// NewPostgresStorageImpl connects to PostgreSQL and creates the snapshot schema if needed.
+// Concurrent first-time initialization from several runners is serialized with an advisory lock.
+func NewPostgresStorageImpl(config PostgresConfig) (*PostgresStorage, error) {
+	if config.ConnectionString == "" {
+		return nil, fmt.Errorf("postgres connection string is required")
+	}
+
+	db, err := sql.Open("postgres", config.ConnectionString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if config.MaxConnections > 0 {
+		db.SetMaxOpenConns(config.MaxConnections)
+		db.SetMaxIdleConns(config.MaxConnections / 2)
+	}
+
+	storage := &PostgresStorage{
+		db:     db,
+		config: config,
+	}
+
+	if err := db.PingContext(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := storage.initSchema(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return storage, nil
+}
+
+// initSchema creates the snapshots and snapshot_tags tables and their indexes in one transaction.
+// CREATE TABLE IF NOT EXISTS is not safe against a concurrent identical statement in PostgreSQL,
+// so the transaction first takes an advisory lock that other initializing clients wait on.
+func (p *PostgresStorage) initSchema(ctx context.Context) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresSchemaLockKey); err != nil {
+		return fmt.Errorf("failed to acquire schema lock: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, createPostgresSnapshotsTableSQL); err != nil {
+		return fmt.Errorf("failed to create snapshots table: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, createPostgresTagsTableSQL); err != nil {
+		return fmt.Errorf("failed to create tags table: %w", err)
+	}
+	for _, indexSQL := range postgresSchemaIndexes {
+		if _, err := tx.ExecContext(ctx, indexSQL); err != nil {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Store saves a metrics snapshot with metadata and tags in a single transaction. Storing an ID
+// that another runner has already written fails with a "snapshot already exists" error.
+func (p *PostgresStorage) Store(ctx context.Context, snapshot metrics.Snapshot, metadata metrics.SnapshotMetadata) error {
+	data, err := json.Marshal(snapshot.Report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot data: %w", err)
+	}
+	if p.config.EnableCompression {
+		if data, err = compress(data); err != nil {
+			return fmt.Errorf("failed to compress data: %w", err)
+		}
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := p.insertSnapshotRecord(ctx, tx, snapshot, metadata, data); err != nil {
+		return err
+	}
+
+	for key, value := range metadata.Tags {
+		_, err := tx.ExecContext(ctx, "INSERT INTO snapshot_tags (snapshot_id, key, value) VALUES ($1, $2, $3)",
+			snapshot.ID, key, value)
+		if err != nil {
+			return fmt.Errorf("failed to insert tag %s: %w", key, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertSnapshotRecord inserts the snapshot row with its metadata and extracted burden metrics
+func (p *PostgresStorage) insertSnapshotRecord(ctx context.Context, tx *sql.Tx, snapshot metrics.Snapshot,
+	metadata metrics.SnapshotMetadata, data []byte,
+) error {
+	mbiAvg, dupRatio, docCov, complexViolations, namingViolations := extractBurdenMetrics(snapshot.Report)
+
+	insertSnapshot := `
+	INSERT INTO snapshots (
+		id, timestamp, git_commit, git_branch, git_tag, version,
+		author, description, size_bytes, data_compressed,
+		mbi_score_avg, duplication_ratio, doc_coverage,
+		complexity_violations, naming_violations
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err := tx.ExecContext(ctx, insertSnapshot,
+		snapshot.ID,
+		metadata.Timestamp,
+		metadata.GitCommit,
+		metadata.GitBranch,
+		metadata.GitTag,
+		metadata.Version,
+		metadata.Author,
+		metadata.Description,
+		len(data),
+		data,
+		mbiAvg,
+		dupRatio,
+		docCov,
+		complexViolations,
+		namingViolations,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && string(pqErr.Code) == postgresUniqueViolation {
+			return fmt.Errorf("snapshot already exists: %s", snapshot.ID)
+		}
+		return fmt.Errorf("failed to insert snapshot: %w", err)
+	}
+	return nil
+}
+
+// Retrieve gets a specific snapshot by ID, including its tags and decompressed report
+func (p *PostgresStorage) Retrieve(ctx context.Context, id string) (metrics.Snapshot, error) {
+	var snapshot metrics.Snapshot
+	var metadata metrics.SnapshotMetadata
+	var gitCommit, gitBranch, gitTag, version, author, description sql.NullString
+	var data []byte
+
+	query := `
+	SELECT id, timestamp, git_commit, git_branch, git_tag, version,
+		   author, description, data_compressed
+	FROM snapshots WHERE id = $1`
+
+	err := p.db.QueryRowContext(ctx, query, id).Scan(
+		&snapshot.ID,
+		&metadata.Timestamp,
+		&gitCommit,
+		&gitBranch,
+		&gitTag,
+		&version,
+		&author,
+		&description,
+		&data,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return snapshot, fmt.Errorf("snapshot not found: %s", id)
+		}
+		return snapshot, fmt.Errorf("failed to retrieve snapshot: %w", err)
+	}
+	populateNullableStrings(&metadata.GitCommit, &metadata.GitBranch, &metadata.GitTag, &metadata.Version, &metadata.Author, &metadata.Description, gitCommit, gitBranch, gitTag, version, author, description)
+
+	if metadata.Tags, err = p.loadTags(ctx, id); err != nil {
+		return snapshot, err
+	}
+
+	if data, err = decompressIfGzipped(data); err != nil {
+		return snapshot, fmt.Errorf("failed to decompress data: %w", err)
+	}
+	if err := json.Unmarshal(data, &snapshot.Report); err != nil {
+		return snapshot, fmt.Errorf("failed to unmarshal report data: %w", err)
+	}
+
+	snapshot.Metadata = metadata
+	return snapshot, nil
+}
+
+// loadTags returns the tags attached to a snapshot
+func (p *PostgresStorage) loadTags(ctx context.Context, id string) (map[string]string, error) {
+	rows, err := p.db.QueryContext(ctx, "SELECT key, value FROM snapshot_tags WHERE snapshot_id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags for snapshot %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags[key] = value
+	}
+	return tags, rows.Err()
+}
+
+// decompressIfGzipped decompresses data that starts with the gzip magic number and returns
+// anything else unchanged. Detecting the format per row keeps a shared database readable when
+// runners disagree on EnableCompression; JSON never begins with these bytes.
+func decompressIfGzipped(data []byte) ([]byte, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		return decompress(data)
+	}
+	return data, nil
+}
+
+// List returns snapshots matching the filter, newest first, with the same filter semantics as
+// SQLiteStorage
+func (p *PostgresStorage) List(ctx context.Context, filter SnapshotFilter) ([]SnapshotInfo, error) {
+	query, args := buildListQuery(filter)
+	rows, err := p.db.QueryContext(ctx, rebindPostgres(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	var snapshots []SnapshotInfo
+	for rows.Next() {
+		info, err := scanPostgresSnapshotInfo(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		snapshots = append(snapshots, info)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// Tags are loaded after the listing is closed so each lookup can reuse its connection
+	for i := range snapshots {
+		if snapshots[i].Tags, err = p.loadTags(ctx, snapshots[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return snapshots, nil
+}
+
+// scanPostgresSnapshotInfo scans a listing row into a SnapshotInfo
+func scanPostgresSnapshotInfo(rows *sql.Rows) (SnapshotInfo, error) {
+	var info SnapshotInfo
+	var gitCommit, gitBranch, gitTag, version, author, description sql.NullString
+	var mbiScoreAvg, duplicationRatio, docCoverage sql.NullFloat64
+	var complexityViolations, namingViolations sql.NullInt64
+
+	err := rows.Scan(
+		&info.ID,
+		&info.Timestamp,
+		&gitCommit,
+		&gitBranch,
+		&gitTag,
+		&version,
+		&author,
+		&description,
+		&info.Size,
+		&mbiScoreAvg,
+		&duplicationRatio,
+		&docCoverage,
+		&complexityViolations,
+		&namingViolations,
+	)
+	if err != nil {
+		return info, fmt.Errorf("failed to scan snapshot: %w", err)
+	}
+
+	populateNullableStrings(&info.GitCommit, &info.GitBranch, &info.GitTag, &info.Version, &info.Author, &info.Description, gitCommit, gitBranch, gitTag, version, author, description)
+	populateBurdenMetrics(&info, mbiScoreAvg, duplicationRatio, docCoverage, complexityViolations, namingViolations)
+	return info, nil
+}
+
+// rebindPostgres rewrites the ? placeholders of a query built for SQLite into PostgreSQL's
+// positional $1, $2, ... form
+func rebindPostgres(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Delete removes a snapshot; its tags are removed by the ON DELETE CASCADE foreign key
+func (p *PostgresStorage) Delete(ctx context.Context, id string) error {
+	result, err := p.db.ExecContext(ctx, "DELETE FROM snapshots WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("snapshot not found: %s", id)
+	}
+	return nil
+}
+
+// Cleanup removes old snapshots based on retention policy, honoring KeepTagged and KeepReleases
+// exactly as SQLiteStorage does. The age and count passes run in one transaction holding an
+// advisory lock, so runners cleaning up concurrently cannot both count the same excess snapshots
+// and delete twice as many as the policy allows.
+func (p *PostgresStorage) Cleanup(ctx context.Context, policy RetentionPolicy) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresCleanupLockKey); err != nil {
+		return fmt.Errorf("failed to acquire cleanup lock: %w", err)
+	}
+
+	var deletedCount int64
+	if policy.MaxAge > 0 {
+		cutoff := time.Now().Add(-policy.MaxAge)
+		affected, err := execPostgresDelete(ctx, tx, buildAgeBasedDeleteQuery(policy, false), "old", cutoff)
+		if err != nil {
+			return err
+		}
+		deletedCount += affected
+	}
+
+	if policy.MaxCount > 0 {
+		var currentCount int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM snapshots").Scan(&currentCount); err != nil {
+			return fmt.Errorf("failed to count snapshots: %w", err)
+		}
+		if currentCount > policy.MaxCount {
+			affected, err := execPostgresDelete(ctx, tx, buildCountBasedDeleteQuery(policy, false), "excess", currentCount-policy.MaxCount)
+			if err != nil {
+				return err
+			}
+			deletedCount += affected
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit cleanup: %w", err)
+	}
+	if deletedCount > 0 {
+		fmt.Fprintf(os.Stderr, "Cleaned up %d old snapshots\n", deletedCount)
+	}
+	return nil
+}
+
+// execPostgresDelete runs a retention delete query inside tx and returns the number of deleted rows
+func execPostgresDelete(ctx context.Context, tx *sql.Tx, query, desc string, arg interface{}) (int64, error) {
+	result, err := tx.ExecContext(ctx, rebindPostgres(query), arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete %s snapshots: %w", desc, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted row count: %w", err)
+	}
+	return affected, nil
+}
+
+// GetLatest returns the most recent snapshot
+func (p *PostgresStorage) GetLatest(ctx context.Context) (metrics.Snapshot, error) {
+	var id string
+	row := p.db.QueryRowContext(ctx, "SELECT id FROM snapshots ORDER BY timestamp DESC LIMIT 1")
+	if err := row.Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return metrics.Snapshot{}, fmt.Errorf("no snapshots found")
+		}
+		return metrics.Snapshot{}, fmt.Errorf("failed to get latest snapshot: %w", err)
+	}
+
+	return p.Retrieve(ctx, id)
+}
+
+// GetByTag returns snapshots matching a specific tag
+func (p *PostgresStorage) GetByTag(ctx context.Context, key, value string) ([]metrics.Snapshot, error) {
+	infos, err := p.List(ctx, SnapshotFilter{Tags: map[string]string{key: value}})
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []metrics.Snapshot
+	for _, info := range infos {
+		snapshot, err := p.Retrieve(ctx, info.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve snapshot %s: %w", info.ID, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// Close releases the database connection pool
+func (p *PostgresStorage) Close() error {
+	return p.db.Close()
+}
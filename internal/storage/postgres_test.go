@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPostgresStorage connects to the database named by POSTGRES_TEST_URL and empties the
+// snapshot tables. Tests using it are skipped when the variable is unset; never point it at a
+// database holding real history.
+func newTestPostgresStorage(t *testing.T, compression bool) *PostgresStorage {
+	t.Helper()
+	connStr := os.Getenv("POSTGRES_TEST_URL")
+	if connStr == "" {
+		t.Skip("POSTGRES_TEST_URL not set; skipping PostgreSQL integration test")
+	}
+
+	storage, err := NewPostgresStorageImpl(PostgresConfig{
+		ConnectionString:  connStr,
+		MaxConnections:    10,
+		EnableCompression: compression,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { storage.Close() })
+
+	_, err = storage.db.Exec("TRUNCATE snapshots CASCADE")
+	require.NoError(t, err)
+	return storage
+}
+
+func TestRebindPostgres(t *testing.T) {
+	query, args := buildListQuery(SnapshotFilter{Branch: "main", Tags: map[string]string{"env": "ci"}, Limit: 5})
+	rebound := rebindPostgres(query)
+
+	assert.Contains(t, rebound, "git_branch = $1")
+	assert.Contains(t, rebound, "key = $2 AND value = $3")
+	assert.Contains(t, rebound, "LIMIT $4")
+	assert.NotContains(t, rebound, "?")
+	assert.Len(t, args, 4)
+}
+
+func TestDecompressIfGzipped(t *testing.T) {
+	raw := []byte(`{"overview":{}}`)
+	compressed, err := compress(raw)
+	require.NoError(t, err)
+
+	for name, data := range map[string][]byte{"compressed": compressed, "plain": raw} {
+		got, err := decompressIfGzipped(data)
+		require.NoError(t, err, name)
+		assert.Equal(t, raw, got, name)
+	}
+}
+
+func TestNewPostgresStorageImpl_RequiresConnectionString(t *testing.T) {
+	_, err := NewPostgresStorageImpl(PostgresConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection string is required")
+}
+
+// tableColumns returns the column names declared in a CREATE TABLE statement
+func tableColumns(createSQL string) map[string]bool {
+	columns := make(map[string]bool)
+	for _, match := range regexp.MustCompile(`(?m)^\s*([a-z_]+) [A-Z]`).FindAllStringSubmatch(createSQL, -1) {
+		columns[match[1]] = true
+	}
+	return columns
+}
+
+func TestPostgresSchema_OnlyReferencesPostgresColumns(t *testing.T) {
+	snapshotColumns := tableColumns(createPostgresSnapshotsTableSQL)
+	tables := map[string]map[string]bool{
+		"snapshots":     snapshotColumns,
+		"snapshot_tags": tableColumns(createPostgresTagsTableSQL),
+	}
+	require.Contains(t, snapshotColumns, "timestamp")
+
+	for _, indexSQL := range postgresSchemaIndexes {
+		match := regexp.MustCompile(`ON (\w+)\((\w+)\)`).FindStringSubmatch(indexSQL)
+		require.NotNil(t, match, indexSQL)
+		assert.True(t, tables[match[1]][match[2]], "%s indexes a column PostgreSQL lacks", indexSQL)
+	}
+
+	// Columns only the SQLite table has must not appear in any PostgreSQL statement
+	var sqliteOnly []string
+	for column := range tableColumns(createSnapshotsTableSQL) {
+		if !snapshotColumns[column] {
+			sqliteOnly = append(sqliteOnly, column)
+		}
+	}
+	require.NotEmpty(t, sqliteOnly, "the SQLite table has delta columns PostgreSQL does not")
+
+	statements := append([]string{}, postgresSchemaIndexes...)
+	for _, policy := range []RetentionPolicy{{}, {KeepTagged: true}, {KeepReleases: true}, {KeepTagged: true, KeepReleases: true}} {
+		statements = append(statements, buildAgeBasedDeleteQuery(policy, false), buildCountBasedDeleteQuery(policy, false))
+	}
+	for _, statement := range statements {
+		for _, column := range sqliteOnly {
+			assert.False(t, strings.Contains(statement, column), "%q references %s", statement, column)
+		}
+	}
+}
+
+func TestPostgresStorage_StoreAndRetrieve(t *testing.T) {
+	for _, compression := range []bool{true, false} {
+		t.Run(fmt.Sprintf("compression=%v", compression), func(t *testing.T) {
+			storage := newTestPostgresStorage(t, compression)
+			ctx := context.Background()
+
+			metadata := createTestSQLiteMetadata()
+			metadata.Tags = map[string]string{"env": "ci", "runner": "linux"}
+			require.NoError(t, storage.Store(ctx, createTestSQLiteSnapshot("pg-1"), metadata))
+
+			snapshot, err := storage.Retrieve(ctx, "pg-1")
+			require.NoError(t, err)
+			assert.Equal(t, 50, snapshot.Report.Overview.TotalFunctions)
+			assert.Equal(t, "main", snapshot.Metadata.GitBranch)
+			assert.Equal(t, metadata.Tags, snapshot.Metadata.Tags)
+
+			err = storage.Store(ctx, createTestSQLiteSnapshot("pg-1"), metadata)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "snapshot already exists")
+
+			_, err = storage.Retrieve(ctx, "missing")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPostgresStorage_ListFiltersAndTags(t *testing.T) {
+	storage := newTestPostgresStorage(t, true)
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+
+	for i, branch := range []string{"main", "feature", "main"} {
+		metadata := createTestSQLiteMetadata()
+		metadata.GitBranch = branch
+		metadata.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		if i == 2 {
+			metadata.Tags = map[string]string{"release": "true"}
+		}
+		require.NoError(t, storage.Store(ctx, createTestSQLiteSnapshot(fmt.Sprintf("list-%d", i)), metadata))
+	}
+
+	mainOnly, err := storage.List(ctx, SnapshotFilter{Branch: "main"})
+	require.NoError(t, err)
+	require.Len(t, mainOnly, 2)
+	assert.Equal(t, "list-2", mainOnly[0].ID, "newest first")
+	assert.Equal(t, map[string]string{"release": "true"}, mainOnly[0].Tags)
+
+	page, err := storage.List(ctx, SnapshotFilter{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "list-1", page[0].ID)
+
+	tagged, err := storage.GetByTag(ctx, "release", "true")
+	require.NoError(t, err)
+	require.Len(t, tagged, 1)
+	assert.Equal(t, "list-2", tagged[0].ID)
+
+	latest, err := storage.GetLatest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "list-2", latest.ID)
+
+	require.NoError(t, storage.Delete(ctx, "list-2"))
+	tagged, err = storage.GetByTag(ctx, "release", "true")
+	require.NoError(t, err)
+	assert.Empty(t, tagged, "tags are removed with their snapshot")
+	assert.Error(t, storage.Delete(ctx, "list-2"))
+}
+
+func TestPostgresStorage_CleanupRetention(t *testing.T) {
+	storage := newTestPostgresStorage(t, true)
+	ctx := context.Background()
+	old := time.Now().Add(-60 * 24 * time.Hour)
+
+	seed := []struct {
+		id     string
+		gitTag string
+		tags   map[string]string
+	}{
+		{"old-plain", "", nil},
+		{"old-tagged", "", map[string]string{"keep": "yes"}},
+		{"old-release", "v1.0.0", nil},
+	}
+	for i, s := range seed {
+		metadata := createTestSQLiteMetadata()
+		metadata.Timestamp = old.Add(time.Duration(i) * time.Minute)
+		metadata.GitTag = s.gitTag
+		metadata.Tags = s.tags
+		require.NoError(t, storage.Store(ctx, createTestSQLiteSnapshot(s.id), metadata))
+	}
+	for i := 0; i < 3; i++ {
+		metadata := createTestSQLiteMetadata()
+		metadata.Timestamp = time.Now().Add(-time.Duration(3-i) * time.Minute)
+		require.NoError(t, storage.Store(ctx, createTestSQLiteSnapshot(fmt.Sprintf("recent-%d", i)), metadata))
+	}
+
+	err := storage.Cleanup(ctx, RetentionPolicy{MaxAge: 30 * 24 * time.Hour, KeepTagged: true, KeepReleases: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"old-tagged", "old-release", "recent-0", "recent-1", "recent-2"}, listIDs(t, storage))
+
+	err = storage.Cleanup(ctx, RetentionPolicy{MaxCount: 3, KeepTagged: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"old-tagged", "recent-1", "recent-2"}, listIDs(t, storage),
+		"oldest untagged snapshots are removed first")
+}
+
+func TestPostgresStorage_ConcurrentWritesAndCleanup(t *testing.T) {
+	storage := newTestPostgresStorage(t, true)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			metadata := createTestSQLiteMetadata()
+			metadata.Timestamp = time.Now().Add(time.Duration(i) * time.Second)
+			metadata.Tags = map[string]string{"runner": fmt.Sprintf("r%d", i)}
+			errs <- storage.Store(ctx, createTestSQLiteSnapshot(fmt.Sprintf("concurrent-%d", i)), metadata)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, listIDs(t, storage), 20)
+
+	// Several runners applying the same policy at once must not delete more than the excess
+	cleanupErrs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cleanupErrs <- storage.Cleanup(ctx, RetentionPolicy{MaxCount: 15})
+		}()
+	}
+	wg.Wait()
+	close(cleanupErrs)
+	for err := range cleanupErrs {
+		require.NoError(t, err)
+	}
+	assert.Len(t, listIDs(t, storage), 15)
+}
+
+// listIDs returns the IDs of every stored snapshot
+func listIDs(t *testing.T, storage MetricsStorage) []string {
+	t.Helper()
+	infos, err := storage.List(context.Background(), SnapshotFilter{})
+	require.NoError(t, err)
+	ids := make([]string, len(infos))
+	for i, info := range infos {
+		ids[i] = info.ID
+	}
+	return ids
+}
+
+func TestPostgresStorage_BurdenMetricsInListing(t *testing.T) {
+	storage := newTestPostgresStorage(t, false)
+	ctx := context.Background()
+
+	snapshot := createTestSQLiteSnapshot("burden")
+	snapshot.Report.Duplication = metrics.DuplicationMetrics{DuplicationRatio: 0.25}
+	require.NoError(t, storage.Store(ctx, snapshot, createTestSQLiteMetadata()))
+
+	infos, err := storage.List(ctx, SnapshotFilter{})
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.NotNil(t, infos[0].DuplicationRatio)
+	assert.Equal(t, 0.25, *infos[0].DuplicationRatio)
+	assert.Positive(t, infos[0].Size)
+}
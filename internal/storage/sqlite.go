@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"database/sql"
@@ -40,6 +41,7 @@ const (
 		doc_coverage REAL,
 		complexity_violations INTEGER,
 		naming_violations INTEGER,
+		base_id TEXT,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
 
@@ -53,9 +55,13 @@ const (
 	);`
 )
 
+// excludeDeltaBases is a retention filter that keeps full snapshots other snapshots are encoded against
+const excludeDeltaBases = " AND id NOT IN (SELECT base_id FROM snapshots WHERE base_id IS NOT NULL)"
+
 var schemaIndexes = []string{
 	"CREATE INDEX IF NOT EXISTS idx_snapshots_timestamp ON snapshots(timestamp)",
 	"CREATE INDEX IF NOT EXISTS idx_snapshots_branch ON snapshots(git_branch)",
+	"CREATE INDEX IF NOT EXISTS idx_snapshots_base ON snapshots(base_id)",
 	"CREATE INDEX IF NOT EXISTS idx_snapshots_tag ON snapshots(git_tag)",
 	"CREATE INDEX IF NOT EXISTS idx_tags_key ON snapshot_tags(key)",
 	"CREATE INDEX IF NOT EXISTS idx_tags_value ON snapshot_tags(value)",
@@ -172,7 +178,7 @@ func (s *SQLiteStorage) createSchemaIndexes(ctx context.Context) error {
 	return nil
 }
 
-// migrateSchema adds burden metric columns to existing databases
+// migrateSchema adds burden metric and delta base columns to existing databases
 func (s *SQLiteStorage) migrateSchema(ctx context.Context) error {
 	// Check if snapshots table exists
 	var tableExists int
@@ -189,6 +195,7 @@ func (s *SQLiteStorage) migrateSchema(ctx context.Context) error {
 		"ALTER TABLE snapshots ADD COLUMN doc_coverage REAL",
 		"ALTER TABLE snapshots ADD COLUMN complexity_violations INTEGER",
 		"ALTER TABLE snapshots ADD COLUMN naming_violations INTEGER",
+		"ALTER TABLE snapshots ADD COLUMN base_id TEXT",
 	}
 
 	for _, columnSQL := range columns {
@@ -237,7 +244,7 @@ func extractBurdenMetrics(report metrics.Report) (mbiAvg, dupRatio, docCov float
 
 // Store saves a metrics snapshot with metadata
 func (s *SQLiteStorage) Store(ctx context.Context, snapshot metrics.Snapshot, metadata metrics.SnapshotMetadata) error {
-	compressedData, err := s.prepareSnapshotData(snapshot.Report)
+	compressedData, baseID, err := s.prepareSnapshotData(ctx, snapshot.Report)
 	if err != nil {
 		return err
 	}
@@ -248,7 +255,7 @@ func (s *SQLiteStorage) Store(ctx context.Context, snapshot metrics.Snapshot, me
 	}
 	defer tx.Rollback()
 
-	if err := s.insertSnapshotRecord(ctx, tx, snapshot, metadata, compressedData); err != nil {
+	if err := s.insertSnapshotRecord(ctx, tx, snapshot, metadata, compressedData, baseID); err != nil {
 		return err
 	}
 
@@ -261,29 +268,85 @@ func (s *SQLiteStorage) Store(ctx context.Context, snapshot metrics.Snapshot, me
 
 // prepareSnapshotData marshals a metrics report to JSON and optionally compresses it for storage.
 // Compression is enabled via storage config and can reduce database size by 60-80% for large reports.
-// Returns the serialized (and possibly compressed) data ready for database insertion.
-func (s *SQLiteStorage) prepareSnapshotData(report metrics.Report) ([]byte, error) {
+// When deltas are enabled the report may instead be encoded relative to the latest full snapshot,
+// in which case the returned base ID is valid. Returns the data ready for database insertion.
+func (s *SQLiteStorage) prepareSnapshotData(ctx context.Context, report metrics.Report) ([]byte, sql.NullString, error) {
+	var baseID sql.NullString
 	data, err := json.Marshal(report)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal snapshot data: %w", err)
+		return nil, baseID, fmt.Errorf("failed to marshal snapshot data: %w", err)
+	}
+
+	if s.config.EnableDeltas {
+		delta, id, err := s.encodeAgainstBase(ctx, data)
+		if err != nil {
+			return nil, baseID, err
+		}
+		if id != "" {
+			data = delta
+			baseID = sql.NullString{String: id, Valid: true}
+		}
 	}
 
 	if s.config.EnableCompression {
 		compressedData, err := compress(data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to compress data: %w", err)
+			return nil, baseID, fmt.Errorf("failed to compress data: %w", err)
 		}
-		return compressedData, nil
+		return compressedData, baseID, nil
 	}
 
-	return data, nil
+	return data, baseID, nil
+}
+
+// encodeAgainstBase encodes report JSON as a delta against the most recent full snapshot. It returns
+// an empty base ID when the report should be stored in full instead: no base exists yet, the base
+// already has FullSnapshotInterval-1 dependents, or the delta is not meaningfully smaller.
+func (s *SQLiteStorage) encodeAgainstBase(ctx context.Context, data []byte) ([]byte, string, error) {
+	var baseID string
+	row := s.db.QueryRowContext(ctx, "SELECT id FROM snapshots WHERE base_id IS NULL ORDER BY timestamp DESC LIMIT 1")
+	if err := row.Scan(&baseID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to find delta base: %w", err)
+	}
+
+	var dependents int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM snapshots WHERE base_id = ?", baseID).Scan(&dependents); err != nil {
+		return nil, "", fmt.Errorf("failed to count delta dependents: %w", err)
+	}
+	if dependents+1 >= s.fullSnapshotInterval() {
+		return nil, "", nil
+	}
+
+	baseData, err := s.loadReportData(ctx, baseID)
+	if err != nil {
+		return nil, "", err
+	}
+	delta, err := encodeSnapshotDelta(baseData, data)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode snapshot delta: %w", err)
+	}
+	if float64(len(delta)) > float64(len(data))*maxDeltaRatio {
+		return nil, "", nil
+	}
+	return delta, baseID, nil
+}
+
+// fullSnapshotInterval returns the configured base interval, falling back to the default
+func (s *SQLiteStorage) fullSnapshotInterval() int {
+	if s.config.FullSnapshotInterval > 0 {
+		return s.config.FullSnapshotInterval
+	}
+	return defaultFullSnapshotInterval
 }
 
 // insertSnapshotRecord inserts a new snapshot record into the database with metadata fields (timestamp,
 // git info, author, description) and extracted burden metrics (MBI, duplication, documentation coverage,
 // violation counts). The compressed data blob and metadata are stored in a single transaction for atomicity.
 func (s *SQLiteStorage) insertSnapshotRecord(ctx context.Context, tx *sql.Tx, snapshot metrics.Snapshot,
-	metadata metrics.SnapshotMetadata, compressedData []byte,
+	metadata metrics.SnapshotMetadata, compressedData []byte, baseID sql.NullString,
 ) error {
 	mbiAvg, dupRatio, docCov, complexViolations, namingViolations := extractBurdenMetrics(snapshot.Report)
 
@@ -292,8 +355,8 @@ func (s *SQLiteStorage) insertSnapshotRecord(ctx context.Context, tx *sql.Tx, sn
 		id, timestamp, git_commit, git_branch, git_tag, version, 
 		author, description, size_bytes, data_compressed,
 		mbi_score_avg, duplication_ratio, doc_coverage, 
-		complexity_violations, naming_violations
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		complexity_violations, naming_violations, base_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := tx.ExecContext(ctx, insertSnapshot,
 		snapshot.ID,
@@ -311,6 +374,7 @@ func (s *SQLiteStorage) insertSnapshotRecord(ctx context.Context, tx *sql.Tx, sn
 		docCov,
 		complexViolations,
 		namingViolations,
+		baseID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert snapshot: %w", err)
@@ -339,11 +403,12 @@ func (s *SQLiteStorage) insertSnapshotTags(ctx context.Context, tx *sql.Tx, snap
 // Retrieve fetches a complete baseline snapshot by ID from SQLite storage including all metrics and metadata.
 // It deserializes compressed snapshot data, reconstructs the full metrics report structure, and populates metadata
 // fields (timestamp, git commit, tags). Used by diff and trend commands to load historical baselines for comparison.
+// Delta snapshots are rebuilt from their full base before deserialization.
 // Returns error if snapshot doesn't exist or if data deserialization fails due to schema version mismatch.
 func (s *SQLiteStorage) Retrieve(ctx context.Context, id string) (metrics.Snapshot, error) {
 	var snapshot metrics.Snapshot
 
-	compressedData, metadata, err := s.fetchSnapshotData(ctx, id, &snapshot)
+	metadata, err := s.fetchSnapshotData(ctx, id, &snapshot)
 	if err != nil {
 		return snapshot, err
 	}
@@ -352,7 +417,7 @@ func (s *SQLiteStorage) Retrieve(ctx context.Context, id string) (metrics.Snapsh
 		return snapshot, err
 	}
 
-	data, err := s.decompressIfNeeded(compressedData)
+	data, err := s.loadReportData(ctx, id)
 	if err != nil {
 		return snapshot, err
 	}
@@ -365,15 +430,14 @@ func (s *SQLiteStorage) Retrieve(ctx context.Context, id string) (metrics.Snapsh
 	return snapshot, nil
 }
 
-// fetchSnapshotData retrieves snapshot data and metadata from the database
-func (s *SQLiteStorage) fetchSnapshotData(ctx context.Context, id string, snapshot *metrics.Snapshot) ([]byte, metrics.SnapshotMetadata, error) {
+// fetchSnapshotData retrieves snapshot metadata from the database
+func (s *SQLiteStorage) fetchSnapshotData(ctx context.Context, id string, snapshot *metrics.Snapshot) (metrics.SnapshotMetadata, error) {
 	var metadata metrics.SnapshotMetadata
-	var compressedData []byte
 	var gitCommit, gitBranch, gitTag, version, author, description sql.NullString
 
 	query := `
 	SELECT id, timestamp, git_commit, git_branch, git_tag, version, 
-		   author, description, data_compressed
+		   author, description
 	FROM snapshots WHERE id = ?`
 
 	row := s.db.QueryRowContext(ctx, query, id)
@@ -386,17 +450,45 @@ func (s *SQLiteStorage) fetchSnapshotData(ctx context.Context, id string, snapsh
 		&version,
 		&author,
 		&description,
-		&compressedData,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, metadata, fmt.Errorf("snapshot not found: %s", id)
+			return metadata, fmt.Errorf("snapshot not found: %s", id)
 		}
-		return nil, metadata, fmt.Errorf("failed to retrieve snapshot: %w", err)
+		return metadata, fmt.Errorf("failed to retrieve snapshot: %w", err)
 	}
 
 	s.populateNullableFields(&metadata, gitCommit, gitBranch, gitTag, version, author, description)
-	return compressedData, metadata, nil
+	return metadata, nil
+}
+
+// loadReportData returns the uncompressed report JSON for a snapshot, applying its delta to the
+// base snapshot's report when the snapshot was stored as a delta
+func (s *SQLiteStorage) loadReportData(ctx context.Context, id string) ([]byte, error) {
+	var compressedData []byte
+	var baseID sql.NullString
+	row := s.db.QueryRowContext(ctx, "SELECT data_compressed, base_id FROM snapshots WHERE id = ?", id)
+	if err := row.Scan(&compressedData, &baseID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("snapshot not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to retrieve snapshot: %w", err)
+	}
+
+	data, err := s.decompressIfNeeded(compressedData)
+	if err != nil || !baseID.Valid {
+		return data, err
+	}
+
+	baseData, err := s.loadReportData(ctx, baseID.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load delta base %s: %w", baseID.String, err)
+	}
+	data, err = applySnapshotDelta(baseData, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply snapshot delta: %w", err)
+	}
+	return data, nil
 }
 
 // populateNullableFields converts SQL nullable fields to metadata fields
@@ -443,7 +535,7 @@ func (s *SQLiteStorage) List(ctx context.Context, filter SnapshotFilter) ([]Snap
 	var snapshots []SnapshotInfo
 
 	// Build and execute query
-	query, args := s.buildListQuery(filter)
+	query, args := buildListQuery(filter)
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list snapshots: %w", err)
@@ -468,21 +560,22 @@ func (s *SQLiteStorage) List(ctx context.Context, filter SnapshotFilter) ([]Snap
 	return snapshots, nil
 }
 
-// buildListQuery constructs the SQL query and parameters based on filter criteria
-func (s *SQLiteStorage) buildListQuery(filter SnapshotFilter) (string, []interface{}) {
+// buildListQuery constructs the SQL query and parameters based on filter criteria. Queries use
+// ? placeholders; PostgresStorage rewrites them with rebindPostgres.
+func buildListQuery(filter SnapshotFilter) (string, []interface{}) {
 	query := "SELECT id, timestamp, git_commit, git_branch, git_tag, version, author, description, size_bytes, mbi_score_avg, duplication_ratio, doc_coverage, complexity_violations, naming_violations FROM snapshots WHERE 1=1"
 	var args []interface{}
 
-	query, args = s.addTimeFilters(query, args, filter)
-	query, args = s.addMetadataFilters(query, args, filter)
-	query, args = s.addTagFilters(query, args, filter)
-	query = s.addOrderingAndLimits(query, &args, filter)
+	query, args = addTimeFilters(query, args, filter)
+	query, args = addMetadataFilters(query, args, filter)
+	query, args = addTagFilters(query, args, filter)
+	query = addOrderingAndLimits(query, &args, filter)
 
 	return query, args
 }
 
 // addTimeFilters adds timestamp-based filtering to the query
-func (s *SQLiteStorage) addTimeFilters(query string, args []interface{}, filter SnapshotFilter) (string, []interface{}) {
+func addTimeFilters(query string, args []interface{}, filter SnapshotFilter) (string, []interface{}) {
 	if filter.After != nil {
 		query += " AND timestamp > ?"
 		args = append(args, *filter.After)
@@ -497,7 +590,7 @@ func (s *SQLiteStorage) addTimeFilters(query string, args []interface{}, filter
 }
 
 // addMetadataFilters adds git metadata filtering to the query
-func (s *SQLiteStorage) addMetadataFilters(query string, args []interface{}, filter SnapshotFilter) (string, []interface{}) {
+func addMetadataFilters(query string, args []interface{}, filter SnapshotFilter) (string, []interface{}) {
 	if filter.Branch != "" {
 		query += " AND git_branch = ?"
 		args = append(args, filter.Branch)
@@ -517,7 +610,7 @@ func (s *SQLiteStorage) addMetadataFilters(query string, args []interface{}, fil
 }
 
 // addTagFilters adds custom tag filtering to the query
-func (s *SQLiteStorage) addTagFilters(query string, args []interface{}, filter SnapshotFilter) (string, []interface{}) {
+func addTagFilters(query string, args []interface{}, filter SnapshotFilter) (string, []interface{}) {
 	for key, value := range filter.Tags {
 		query += " AND id IN (SELECT snapshot_id FROM snapshot_tags WHERE key = ? AND value = ?)"
 		args = append(args, key, value)
@@ -526,7 +619,7 @@ func (s *SQLiteStorage) addTagFilters(query string, args []interface{}, filter S
 }
 
 // addOrderingAndLimits adds sorting and pagination to the query
-func (s *SQLiteStorage) addOrderingAndLimits(query string, args *[]interface{}, filter SnapshotFilter) string {
+func addOrderingAndLimits(query string, args *[]interface{}, filter SnapshotFilter) string {
 	query += " ORDER BY timestamp DESC"
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
@@ -631,8 +724,13 @@ func (s *SQLiteStorage) attachSnapshotTags(ctx context.Context, info *SnapshotIn
 // Delete removes a baseline snapshot and all associated metadata from SQLite persistent storage.
 // The operation cascades to related tables (snapshot_tags, snapshot_files) due to foreign key constraints,
 // ensuring complete cleanup without orphaned records. Returns error if the snapshot ID doesn't exist or if
-// database access fails. This method is used by the "baseline delete" command for baseline management.
+// database access fails. Delta snapshots based on the deleted snapshot are rewritten as full snapshots first
+// so they remain retrievable. This method is used by the "baseline delete" command for baseline management.
 func (s *SQLiteStorage) Delete(ctx context.Context, id string) error {
+	if err := s.materializeDependents(ctx, id); err != nil {
+		return err
+	}
+
 	// Delete will cascade to tags due to foreign key constraint
 	result, err := s.db.ExecContext(ctx, "DELETE FROM snapshots WHERE id = ?", id)
 	if err != nil {
@@ -651,7 +749,45 @@ func (s *SQLiteStorage) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// Cleanup removes old snapshots based on retention policy
+// materializeDependents rewrites every delta snapshot based on baseID as a full snapshot
+func (s *SQLiteStorage) materializeDependents(ctx context.Context, baseID string) error {
+	rows, err := s.db.QueryContext(ctx, "SELECT id FROM snapshots WHERE base_id = ?", baseID)
+	if err != nil {
+		return fmt.Errorf("failed to find delta dependents: %w", err)
+	}
+	var dependents []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan delta dependent: %w", err)
+		}
+		dependents = append(dependents, id)
+	}
+	rows.Close()
+
+	for _, id := range dependents {
+		data, err := s.loadReportData(ctx, id)
+		if err != nil {
+			return err
+		}
+		if s.config.EnableCompression {
+			if data, err = compress(data); err != nil {
+				return fmt.Errorf("failed to compress data: %w", err)
+			}
+		}
+		_, err = s.db.ExecContext(ctx,
+			"UPDATE snapshots SET data_compressed = ?, size_bytes = ?, base_id = NULL WHERE id = ?",
+			data, len(data), id)
+		if err != nil {
+			return fmt.Errorf("failed to materialize snapshot %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Cleanup removes old snapshots based on retention policy. Full snapshots that still serve as
+// the base of a remaining delta snapshot are kept until their dependents are removed.
 func (s *SQLiteStorage) Cleanup(ctx context.Context, policy RetentionPolicy) error {
 	deletedCount, err := s.deleteByAge(ctx, policy)
 	if err != nil {
@@ -674,7 +810,7 @@ func (s *SQLiteStorage) deleteByAge(ctx context.Context, policy RetentionPolicy)
 	}
 
 	cutoff := time.Now().Add(-policy.MaxAge)
-	query := s.buildAgeBasedDeleteQuery(policy)
+	query := buildAgeBasedDeleteQuery(policy, true)
 
 	return s.execDeleteQuery(ctx, query, "old", cutoff)
 }
@@ -695,7 +831,7 @@ func (s *SQLiteStorage) deleteByCount(ctx context.Context, policy RetentionPolic
 	}
 
 	toDelete := currentCount - policy.MaxCount
-	query := s.buildCountBasedDeleteQuery(policy)
+	query := buildCountBasedDeleteQuery(policy, true)
 
 	return s.execDeleteQuery(ctx, query, "excess", toDelete)
 }
@@ -714,9 +850,13 @@ func (s *SQLiteStorage) execDeleteQuery(ctx context.Context, query, desc string,
 	return affected, nil
 }
 
-// buildAgeBasedDeleteQuery constructs the SQL query for age-based deletion
-func (s *SQLiteStorage) buildAgeBasedDeleteQuery(policy RetentionPolicy) string {
+// buildAgeBasedDeleteQuery constructs the SQL query for age-based deletion. keepDeltaBases
+// excludes the bases of delta snapshots, for schemas that have a base_id column.
+func buildAgeBasedDeleteQuery(policy RetentionPolicy, keepDeltaBases bool) string {
 	query := "DELETE FROM snapshots WHERE timestamp < ?"
+	if keepDeltaBases {
+		query += excludeDeltaBases
+	}
 
 	if policy.KeepTagged {
 		query += " AND id NOT IN (SELECT DISTINCT snapshot_id FROM snapshot_tags)"
@@ -729,22 +869,21 @@ func (s *SQLiteStorage) buildAgeBasedDeleteQuery(policy RetentionPolicy) string
 	return query
 }
 
-// buildCountBasedDeleteQuery constructs the SQL query for count-based deletion
-func (s *SQLiteStorage) buildCountBasedDeleteQuery(policy RetentionPolicy) string {
+// buildCountBasedDeleteQuery constructs the SQL query for count-based deletion, which removes
+// the oldest excess snapshots. keepDeltaBases spares those among them that are the base of a
+// remaining delta snapshot, for schemas that have a base_id column.
+func buildCountBasedDeleteQuery(policy RetentionPolicy, keepDeltaBases bool) string {
+	excess := "SELECT id FROM snapshots"
 	if policy.KeepTagged {
-		return `DELETE FROM snapshots WHERE id IN (
-			SELECT id FROM snapshots 
-			WHERE id NOT IN (SELECT DISTINCT snapshot_id FROM snapshot_tags)
-			ORDER BY timestamp ASC 
-			LIMIT ?
-		)`
+		excess += " WHERE id NOT IN (SELECT DISTINCT snapshot_id FROM snapshot_tags)"
 	}
+	excess += " ORDER BY timestamp ASC LIMIT ?"
 
-	return `DELETE FROM snapshots WHERE id IN (
-		SELECT id FROM snapshots 
-		ORDER BY timestamp ASC 
-		LIMIT ?
-	)`
+	if !keepDeltaBases {
+		return "DELETE FROM snapshots WHERE id IN (" + excess + ")"
+	}
+	return "WITH excess AS (" + excess + ") DELETE FROM snapshots WHERE id IN (SELECT id FROM excess)" +
+		" AND id NOT IN (SELECT base_id FROM snapshots WHERE base_id IS NOT NULL AND id NOT IN (SELECT id FROM excess))"
 }
 
 // countSnapshots returns the total number of snapshots in the database
@@ -822,7 +961,7 @@ func createDirIfNotExists(path string) error {
 
 // compress compresses data using gzip encoding.
 func compress(data []byte) ([]byte, error) {
-	var buf strings.Builder
+	var buf bytes.Buffer
 	gz := gzip.NewWriter(&buf)
 	if _, err := gz.Write(data); err != nil {
 		return nil, err
@@ -830,12 +969,12 @@ func compress(data []byte) ([]byte, error) {
 	if err := gz.Close(); err != nil {
 		return nil, err
 	}
-	return []byte(buf.String()), nil
+	return buf.Bytes(), nil
 }
 
 // decompress decompresses gzip-encoded data.
 func decompress(data []byte) ([]byte, error) {
-	reader, err := gzip.NewReader(strings.NewReader(string(data)))
+	reader, err := gzip.NewReader(bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
@@ -432,3 +432,78 @@ func TestSQLiteStorage_StoreWithoutCompression(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "uncompressed-test", retrieved.ID)
 }
+
+func TestCompress_BinaryRoundTrip(t *testing.T) {
+	allBytes := make([]byte, 256)
+	for i := range allBytes {
+		allBytes[i] = byte(i)
+	}
+	payloads := map[string][]byte{
+		"empty":        {},
+		"every byte":   allBytes,
+		"invalid utf8": {0xff, 0xfe, 0xc3, 0x28, 0xa0, 0xa1, 0xe2, 0x28, 0xa1},
+		"nul runs":     make([]byte, 4096),
+		"json":         []byte(`{"name":"héllo","emoji":"📊"}`),
+	}
+
+	for name, payload := range payloads {
+		t.Run(name, func(t *testing.T) {
+			compressed, err := compress(payload)
+			require.NoError(t, err)
+			assert.Equal(t, []byte{0x1f, 0x8b}, compressed[:2], "gzip magic number")
+
+			decompressed, err := decompress(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, payload, decompressed)
+		})
+	}
+}
+
+func TestSQLiteStorage_CompressedReportRoundTrip(t *testing.T) {
+	storage, err := NewSQLiteStorageImpl(SQLiteConfig{
+		Path:              filepath.Join(t.TempDir(), "test.db"),
+		MaxConnections:    5,
+		EnableFK:          true,
+		EnableCompression: true,
+	})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	report := metrics.Report{
+		Metadata: metrics.ReportMetadata{
+			Repository:     "github.com/test/repo",
+			GeneratedAt:    time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC),
+			AnalysisTime:   1500 * time.Millisecond,
+			FilesProcessed: 2,
+			ToolVersion:    "1.0.0",
+		},
+		Overview: metrics.OverviewMetrics{TotalFiles: 2, TotalLinesOfCode: 120, TotalFunctions: 2},
+		Functions: []metrics.FunctionMetrics{
+			{
+				Name:       "Parse",
+				Package:    "parser",
+				File:       "parser/parse.go",
+				Line:       10,
+				IsExported: true,
+				Lines:      metrics.LineMetrics{Total: 30, Code: 25, Comments: 3, Blank: 2},
+				Complexity: metrics.ComplexityScore{Cyclomatic: 7, Cognitive: 9, NestingDepth: 3, Overall: 11.2},
+				Signature:  metrics.FunctionSignature{ParameterCount: 2, ReturnCount: 2, ErrorReturn: true},
+			},
+			{Name: "ünïcødé", Package: "parser", File: "parser/names.go", Line: 3},
+		},
+		Structs:     []metrics.StructMetrics{{Name: "Node", Package: "parser", File: "parser/node.go", TotalFields: 4}},
+		Packages:    []metrics.PackageMetrics{{Name: "parser", Files: []string{"parser/parse.go"}, CohesionScore: 0.75}},
+		Duplication: metrics.DuplicationMetrics{DuplicationRatio: 0.125},
+		Scores: metrics.ScoringMetrics{
+			FileScores: []metrics.FileScore{{File: "parser/parse.go", Score: 42.5}},
+		},
+	}
+
+	ctx := context.Background()
+	snapshot := metrics.Snapshot{ID: "roundtrip", Report: report}
+	require.NoError(t, storage.Store(ctx, snapshot, createTestSQLiteMetadata()))
+
+	retrieved, err := storage.Retrieve(ctx, "roundtrip")
+	require.NoError(t, err)
+	assert.Equal(t, report, retrieved.Report)
+}
@@ -1,98 +1,76 @@
 package generator
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"go/ast"
-	"go/parser"
-	"go/token"
 	"os"
 	"path/filepath"
-	"strings"
 
+	"github.com/opd-ai/go-stats-generator/internal/config"
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
-	"github.com/opd-ai/go-stats-generator/internal/scanner"
 )
 
-// AnalyzeDirectory analyzes all Go files in the specified directory
-func (a *Analyzer) AnalyzeDirectory(ctx context.Context, dir string) (*metrics.Report, error) {
-	absPath, err := filepath.Abs(dir)
+// Analyze runs the complete analysis workflow on path, which may be a Go source file or a
+// directory: file discovery, concurrent processing through the worker pool, and report
+// finalization. It reads no global state, so callers can run analyses with different
+// configurations side by side. Diagnostics and progress are only delivered to
+// cfg.Output.Logger and cfg.Output.Progress; nothing is written to stderr.
+func Analyze(ctx context.Context, path string, cfg config.Config) (*metrics.Report, error) {
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
 	}
 
-	discoverer := scanner.NewDiscoverer(&a.config.Filters)
-	files, err := discoverer.DiscoverFiles(absPath)
+	info, err := os.Stat(absPath)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to access %s: %w", absPath, err)
 	}
 
-	workerPool := scanner.NewWorkerPool(&a.config.Performance, discoverer)
-	results, err := workerPool.ProcessFiles(ctx, files, nil)
-	if err != nil {
-		return nil, err
+	if info.IsDir() {
+		return runDirectoryAnalysis(ctx, absPath, &cfg)
 	}
+	return runFileAnalysis(ctx, absPath, &cfg)
+}
 
-	return a.buildReport(ctx, results, absPath, len(files))
+// AnalyzeSource analyzes Go source held in memory, such as source read from stdin, as a single
+// file named filename; the package name comes from the parsed source. The same analyzers and
+// finalization steps as Analyze run on it, except team metrics, which need the file's git
+// history. Module context is looked up from the directory of filename.
+func AnalyzeSource(ctx context.Context, filename string, src []byte, cfg config.Config) (*metrics.Report, error) {
+	return runSourceAnalysis(ctx, filename, src, &cfg)
 }
 
-// AnalyzeFile analyzes a single Go source file and produces a comprehensive metrics report for that file only.
-// It parses the file AST, runs all configured analyzers (functions, structs, documentation, complexity, patterns),
-// and aggregates results into a full metrics report structure. This method is used for file-scoped analysis workflows
-// and enables integration with editors/IDEs for real-time code quality feedback on individual files.
-func (a *Analyzer) AnalyzeFile(ctx context.Context, filePath string) (*metrics.Report, error) {
-	fileInfo := createFileInfo(filePath)
+// Analyzer provides programmatic access to Go code analysis with a fixed configuration
+type Analyzer struct {
+	config *config.Config
+}
 
-	// Parse using a dedicated per-file FileSet so that position lookups in per-file analyzers
-	// work correctly without depending on the discoverer's shared FileSet.
-	localFset := token.NewFileSet()
-	file, err := parseFileForAnalysis(localFset, filePath, fileInfo.Src)
-	if err != nil {
-		return nil, err
-	}
-	if file.Name != nil {
-		fileInfo.Package = file.Name.Name
+// NewAnalyzer creates a new analyzer with default configuration settings for comprehensive Go codebase analysis.
+// It enables all analyzers (complexity, documentation, naming, etc.) and uses default thresholds.
+// Returns an Analyzer ready for immediate use with the AnalyzeDirectory() or AnalyzeFile() methods.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{
+		config: config.DefaultConfig(),
 	}
-	fileInfo.Src = nil // release bytes after parsing
-
-	result := scanner.Result{FileInfo: fileInfo, File: file, FileSet: localFset, Error: nil}
-	results := make(chan scanner.Result, 1)
-	results <- result
-	close(results)
-
-	return a.buildReport(ctx, results, filePath, 1)
 }
 
-// createFileInfo creates FileInfo for a single file analysis, reading and caching
-// the file bytes so that the caller can reuse them for parsing without a second disk read.
-func createFileInfo(filePath string) scanner.FileInfo {
-	info := scanner.FileInfo{
-		Path:        filePath,
-		RelPath:     filepath.Base(filePath),
-		IsTestFile:  strings.HasSuffix(filePath, "_test.go"),
-		IsGenerated: false,
+// NewAnalyzerWithConfig creates analyzer with custom configuration, allowing fine-grained control over
+// analysis thresholds (complexity, function length, documentation coverage), performance settings (worker count),
+// and feature toggles. Use this when default settings don't match your project's requirements.
+func NewAnalyzerWithConfig(cfg *config.Config) *Analyzer {
+	return &Analyzer{
+		config: cfg,
 	}
-	if fileInfo, err := os.Stat(filePath); err == nil {
-		info.Size = fileInfo.Size()
-	}
-	if src, err := os.ReadFile(filePath); err == nil {
-		info.Src = src
-		info.FileLines = bytes.Count(src, []byte{'\n'}) + 1
-	}
-	return info
 }
 
-// parseFileForAnalysis parses a Go source file using the provided FileSet.
-// It uses src bytes when available to avoid a second disk read.
-func parseFileForAnalysis(fset *token.FileSet, filePath string, src []byte) (*ast.File, error) {
-	var srcArg interface{}
-	if src != nil {
-		srcArg = src
-	}
-	file, err := parser.ParseFile(fset, filePath, srcArg, parser.ParseComments)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
-	}
-	return file, nil
+// AnalyzeDirectory analyzes all Go files in the specified directory
+func (a *Analyzer) AnalyzeDirectory(ctx context.Context, dir string) (*metrics.Report, error) {
+	return Analyze(ctx, dir, *a.config)
+}
+
+// AnalyzeFile analyzes a single Go source file and produces a comprehensive metrics report for that file only.
+// It runs the same analyzers and finalization steps as AnalyzeDirectory, scoped to the one file, which
+// enables integration with editors/IDEs for real-time code quality feedback on individual files.
+func (a *Analyzer) AnalyzeFile(ctx context.Context, filePath string) (*metrics.Report, error) {
+	return Analyze(ctx, filePath, *a.config)
 }
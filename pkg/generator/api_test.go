@@ -0,0 +1,436 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// writeAnalyzeFixture creates a small two-file package in a temporary directory
+func writeAnalyzeFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "service.go"), []byte(`package service
+
+import "sync"
+
+// Service counts processed jobs
+type Service struct {
+	mu   sync.Mutex
+	jobs int
+}
+
+// Run processes jobs from a channel in a worker goroutine
+func (s *Service) Run(jobs <-chan int) {
+	go func() {
+		for range jobs {
+			s.mu.Lock()
+			s.jobs++
+			s.mu.Unlock()
+		}
+	}()
+}
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store.go"), []byte(`package service
+
+// Store persists values
+type Store interface {
+	Put(key string, value int) error
+}
+
+// Clamp limits value to the range [low, high]
+func Clamp(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
+`), 0o644))
+	return dir
+}
+
+func TestAnalyze_Directory(t *testing.T) {
+	dir := writeAnalyzeFixture(t)
+
+	var mu sync.Mutex
+	var logged []string
+	var progress []config.ProgressEvent
+	cfg := *config.DefaultConfig()
+	cfg.Output.Logger = func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+	cfg.Output.Progress = func(event config.ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, event)
+	}
+
+	report, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Metadata.FilesProcessed)
+	assert.Len(t, report.Functions, 2)
+	assert.Len(t, report.Structs, 1)
+	assert.Len(t, report.Interfaces, 1)
+	require.Len(t, report.Packages, 1)
+	assert.Equal(t, "service", report.Packages[0].Name)
+	assert.Len(t, report.Patterns.ConcurrencyPatterns.Goroutines.Instances, 1)
+	assert.NotEmpty(t, report.Metadata.ContentHash)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, logged, "Found 2 Go files\n")
+	require.NotEmpty(t, progress)
+	assert.Equal(t, config.ProgressEvent{Phase: config.PhaseFinalization, Completed: 1, Total: 1}, progress[len(progress)-1])
+}
+
+func TestAnalyze_ProgressEvents(t *testing.T) {
+	dir := writeAnalyzeFixture(t)
+
+	var mu sync.Mutex
+	var events []config.ProgressEvent
+	cfg := *config.DefaultConfig()
+	cfg.Performance.WorkerCount = 4
+	cfg.Output.Progress = func(event config.ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	_, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	phaseOrder := []config.ProgressPhase{config.PhaseDiscovery, config.PhaseAnalysis, config.PhaseFinalization}
+	phaseIndex := 0
+	last := map[config.ProgressPhase]config.ProgressEvent{}
+	files := map[string]bool{}
+	for _, event := range events {
+		for phaseIndex < len(phaseOrder) && phaseOrder[phaseIndex] != event.Phase {
+			phaseIndex++
+		}
+		require.Less(t, phaseIndex, len(phaseOrder), "phase %s reported out of order", event.Phase)
+
+		if prev, ok := last[event.Phase]; ok {
+			assert.GreaterOrEqual(t, event.Completed, prev.Completed, "%s completion must not decrease", event.Phase)
+			assert.Equal(t, prev.Total, event.Total)
+		}
+		assert.LessOrEqual(t, event.Completed, event.Total)
+		last[event.Phase] = event
+		if event.Phase == config.PhaseAnalysis {
+			files[event.File] = true
+		}
+	}
+
+	for _, phase := range phaseOrder {
+		require.Contains(t, last, phase)
+		assert.Equal(t, last[phase].Total, last[phase].Completed, "%s reaches its total", phase)
+	}
+	assert.Equal(t, 2, last[config.PhaseAnalysis].Total)
+	assert.Equal(t, map[string]bool{"service.go": true, "store.go": true}, files)
+}
+
+func TestAnalyze_File(t *testing.T) {
+	dir := writeAnalyzeFixture(t)
+
+	report, err := Analyze(context.Background(), filepath.Join(dir, "store.go"), *config.DefaultConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Metadata.FilesProcessed)
+	require.Len(t, report.Functions, 1)
+	assert.Equal(t, "Clamp", report.Functions[0].Name)
+	assert.Len(t, report.Interfaces, 1)
+	assert.Empty(t, report.Structs)
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	ctx := context.Background()
+	cfg := *config.DefaultConfig()
+
+	_, err := Analyze(ctx, filepath.Join(t.TempDir(), "missing"), cfg)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Analyze(ctx, t.TempDir(), cfg)
+	assert.True(t, errors.Is(err, ErrNoGoFiles), "empty directory should report ErrNoGoFiles, got %v", err)
+
+	notGo := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(notGo, []byte("not Go"), 0o644))
+	_, err = Analyze(ctx, notGo, cfg)
+	assert.ErrorContains(t, err, "is not a Go source file")
+
+	badPattern := cfg
+	badPattern.Filters.ExcludePatterns = []string{"[vendor"}
+	_, err = Analyze(ctx, writeAnalyzeFixture(t), badPattern)
+	assert.ErrorContains(t, err, `invalid pattern "[vendor"`)
+}
+
+func TestAnalyzer_UsesAnalyze(t *testing.T) {
+	dir := writeAnalyzeFixture(t)
+	ctx := context.Background()
+
+	fromAnalyzer, err := NewAnalyzer().AnalyzeDirectory(ctx, dir)
+	require.NoError(t, err)
+	fromAnalyze, err := Analyze(ctx, dir, *config.DefaultConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, fromAnalyze.Metadata.ContentHash, fromAnalyzer.Metadata.ContentHash)
+}
+
+func TestAnalyze_IncludePatternsToggle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "registry.go"), []byte(`package registry
+
+import "sync"
+
+// Registry holds registered names
+type Registry struct {
+	names []string
+}
+
+var (
+	registry     *Registry
+	registryOnce sync.Once
+)
+
+// GetRegistry returns the process-wide registry
+func GetRegistry() *Registry {
+	registryOnce.Do(func() {
+		registry = &Registry{}
+	})
+	return registry
+}
+`), 0o644))
+
+	cfg := *config.DefaultConfig()
+	report, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	assert.Len(t, report.Patterns.DesignPatterns.Singleton, 1)
+
+	cfg.Analysis.IncludePatterns = false
+	report, err = Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	assert.Empty(t, report.Patterns.DesignPatterns.Singleton)
+}
+
+func TestAnalyze_IncludeGenericsToggle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cache.go"), []byte(`package cache
+
+// Cache maps keys to values
+type Cache[K comparable, V any] struct {
+	items map[K]V
+}
+
+// Default is a process-wide cache of names
+var Default = Cache[string, int]{items: map[string]int{}}
+`), 0o644))
+
+	cfg := *config.DefaultConfig()
+	report, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Generics.TypeParameters.Count)
+	require.Len(t, report.Generics.Instantiations.Types, 1)
+	assert.Equal(t, []string{"string", "int"}, report.Generics.Instantiations.Types[0].TypeArgs)
+
+	cfg.Analysis.IncludeGenerics = false
+	report, err = Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	assert.Zero(t, report.Generics.TypeParameters.Count)
+	assert.Empty(t, report.Generics.Instantiations.Types)
+}
+
+func TestAnalyze_StrategyAcrossFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "router.go"), []byte(`package router
+
+// Balancer picks a backend
+type Balancer interface {
+	Pick(backends []string) string
+}
+
+// Router forwards requests using a swappable balancer
+type Router struct {
+	balancer Balancer
+}
+
+// SetBalancer replaces the balancing strategy
+func (r *Router) SetBalancer(b Balancer) {
+	r.balancer = b
+}
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "balancers.go"), []byte(`package router
+
+// RoundRobin cycles through backends
+type RoundRobin struct{ next int }
+
+// Pick returns the next backend
+func (r *RoundRobin) Pick(backends []string) string { return backends[0] }
+
+// Random picks any backend
+type Random struct{}
+
+// Pick returns a random backend
+func (Random) Pick(backends []string) string { return backends[0] }
+`), 0o644))
+
+	report, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+
+	require.Len(t, report.Patterns.DesignPatterns.Strategy, 1)
+	assert.Equal(t, "router.go", report.Patterns.DesignPatterns.Strategy[0].File)
+}
+
+func TestAnalyze_DependenciesCountOnlySameModuleImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n\nrequire github.com/google/uuid v1.6.0\n\nreplace example.com/app/tools => ./tools\n",
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/app/internal/store"
+	"example.com/app/plugins/auth"
+	"example.com/app/tools/gen"
+	"github.com/google/uuid"
+)
+
+func main() { fmt.Println(store.Open(), auth.Check(), gen.Run(), uuid.NewString()) }
+`,
+		"internal/store/store.go": "package store\n\nfunc Open() string { return \"db\" }\n",
+		"plugins/go.mod":          "module example.com/app/plugins\n\ngo 1.24\n",
+		"plugins/auth/auth.go":    "package auth\n\nfunc Check() bool { return true }\n",
+		"tools/gen/gen.go":        "package gen\n\nfunc Run() int { return 1 }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	report, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+
+	require.NotNil(t, report.Metadata.Module)
+	assert.Equal(t, "example.com/app", report.Metadata.Module.Path)
+	require.Len(t, report.Metadata.Module.NestedModules, 2)
+
+	var mainPkg *metrics.PackageMetrics
+	for i := range report.Packages {
+		if report.Packages[i].Name == "main" {
+			mainPkg = &report.Packages[i]
+		}
+	}
+	require.NotNil(t, mainPkg)
+	assert.Equal(t, []string{"example.com/app/internal/store"}, mainPkg.Dependencies,
+		"standard library, third-party and nested-module imports are not dependencies")
+}
+
+func TestAnalyze_ModuleInfoPerRun(t *testing.T) {
+	dir := t.TempDir()
+	goMod := filepath.Join(dir, "go.mod")
+	require.NoError(t, os.WriteFile(goMod, []byte("module example.com/first\n\ngo 1.24\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+	cfg := *config.DefaultConfig()
+	cfg.Performance.EnableCache = false
+
+	first, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	second, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, first.Metadata.Module)
+	require.NotNil(t, second.Metadata.Module)
+	assert.NotSame(t, first.Metadata.Module, second.Metadata.Module, "each report owns its module metadata")
+
+	first.Metadata.Module.Path = "changed"
+	assert.Equal(t, "example.com/first", second.Metadata.Module.Path)
+
+	require.NoError(t, os.WriteFile(goMod, []byte("module example.com/second\n\ngo 1.24\n"), 0o644))
+	third, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, third.Metadata.Module)
+	assert.Equal(t, "example.com/second", third.Metadata.Module.Path, "go.mod is read again by a later run")
+}
+
+func TestAnalyze_ParsesGoModOnce(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n\n" +
+			"replace example.com/app/plugin => ./plugin\n\nreplace example.com/lib v1.0.0 => ../lib\n",
+		"main.go":        "package main\n\nimport (\n\t_ \"example.com/app/plugin\"\n\t_ \"example.com/app/util\"\n)\n\nfunc main() {}\n",
+		"util/util.go":   "package util\n\nfunc Helper() {}\n",
+		"plugin/load.go": "package plugin\n\nfunc Load() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	loads := 0
+	original := loadModuleInfo
+	loadModuleInfo = func(dir string) (*metrics.ModuleInfo, error) {
+		loads++
+		return original(dir)
+	}
+	t.Cleanup(func() { loadModuleInfo = original })
+
+	cfg := *config.DefaultConfig()
+	cfg.Performance.EnableCache = false
+	report, err := Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loads, "go.mod is parsed once per run")
+
+	require.NotNil(t, report.Metadata.Module)
+	assert.Len(t, report.Metadata.Module.Replaces, 2)
+	require.Len(t, report.Metadata.Module.NestedModules, 1)
+	assert.Equal(t, "example.com/app/plugin", report.Metadata.Module.NestedModules[0].Path)
+
+	var mainPkg *metrics.PackageMetrics
+	for i := range report.Packages {
+		if report.Packages[i].Name == "main" {
+			mainPkg = &report.Packages[i]
+		}
+	}
+	require.NotNil(t, mainPkg)
+	assert.Equal(t, []string{"example.com/app/util"}, mainPkg.Dependencies,
+		"the package analyzer uses the same module info, so the replaced module is external")
+
+	_, err = Analyze(context.Background(), dir, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads, "each run reads go.mod again")
+}
+
+func TestAnalyzeSource(t *testing.T) {
+	cfg := config.DefaultConfig()
+	report, err := AnalyzeSource(context.Background(), "stdin.go", []byte(`package piped
+
+func One() int { return 1 }
+
+func Two() int { return 2 }
+`), *cfg)
+	require.NoError(t, err)
+	require.Len(t, report.Functions, 2)
+	assert.Equal(t, "piped", report.Functions[0].Package)
+	assert.Equal(t, "stdin.go", report.Functions[0].File)
+	assert.Nil(t, report.Team, "team metrics need the file's git history")
+
+	_, err = AnalyzeSource(context.Background(), "stdin.go", []byte("package broken\n\nfunc {"), *cfg)
+	assert.ErrorContains(t, err, "stdin.go:3")
+}
@@ -0,0 +1,285 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/opd-ai/go-stats-generator/internal/scanner"
+	"github.com/opd-ai/go-stats-generator/internal/storage"
+)
+
+// reportCacheKey names the cache entry holding the finished report for the whole file set;
+// it cannot collide with file entries, which are keyed by relative .go paths
+const reportCacheKey = "report"
+
+// fileAnalysis holds everything the per-file analyzers contribute for one file. It is what the
+// analysis cache stores, so a file whose content is unchanged can be merged into the report
+// without re-running those analyzers. Inputs to package-scope analyses (packages, duplication,
+// documentation, dead code, placement, organization) need the AST and are not part of it.
+type fileAnalysis struct {
+	Functions            []metrics.FunctionMetrics     `json:"functions"`
+	Structs              []metrics.StructMetrics       `json:"structs"`
+	Interfaces           []metrics.InterfaceMetrics    `json:"interfaces"`
+	Generics             []metrics.GenericMetrics      `json:"generics"`
+	InterfaceAssertions  []metrics.InterfaceAssertion  `json:"interface_assertions"`
+	IdentifierViolations []metrics.IdentifierViolation `json:"identifier_violations"`
+	TotalIdentifiers     int                           `json:"total_identifiers"`
+	Performance          []metrics.PerformanceWarning  `json:"performance"`
+	Patterns             metrics.PatternMetrics        `json:"patterns"`
+	Burden               metrics.BurdenMetrics         `json:"burden"`
+	// Lines classifies every line of the file, for the comment density
+	Lines metrics.LineMetrics `json:"lines"`
+	// Extensions holds the results of registered third-party file analyzers, keyed by name
+	Extensions map[string]metrics.ExtensionResult `json:"extensions,omitempty"`
+}
+
+// analyzeFileMetrics runs the registered file analyzers on a parsed file, collecting their
+// output into a fileAnalysis instead of the shared report
+func analyzeFileMetrics(result scanner.Result, perFile, analyzers *AnalyzerSet, cfg *config.Config) *fileAnalysis {
+	fc := &FileContext{
+		Fset:       result.FileSet,
+		Path:       result.FileInfo.Path,
+		RelPath:    result.FileInfo.RelPath,
+		Package:    result.FileInfo.Package,
+		IsTestFile: result.FileInfo.IsTestFile,
+		result:     result,
+		perFile:    perFile,
+		shared:     analyzers,
+		cfg:        cfg,
+		collected:  &CollectedMetrics{},
+		scratch:    &metrics.Report{},
+	}
+	extensions := runFileAnalyzers(fc)
+
+	return &fileAnalysis{
+		Functions:            fc.collected.Functions,
+		Structs:              fc.collected.Structs,
+		Interfaces:           fc.collected.Interfaces,
+		Generics:             fc.collected.Generics,
+		InterfaceAssertions:  fc.collected.InterfaceAssertions,
+		IdentifierViolations: fc.collected.IdentifierViolations,
+		TotalIdentifiers:     fc.collected.TotalIdentifiers,
+		Performance:          fc.collected.PerformanceWarnings,
+		Patterns:             fc.scratch.Patterns,
+		Burden:               fc.scratch.Burden,
+		Lines:                perFile.Function.CountFileLines(result.File),
+		Extensions:           extensions,
+	}
+}
+
+// mergeFileAnalysis adds one file's per-file results, fresh or cached, to the collected
+// metrics and the report
+func mergeFileAnalysis(fa *fileAnalysis, collectedMetrics *CollectedMetrics, report *metrics.Report) {
+	if collectedMetrics.spool != nil {
+		collectedMetrics.spool.write(fa.Functions, fa.Structs)
+	} else {
+		collectedMetrics.Functions = append(collectedMetrics.Functions, fa.Functions...)
+		collectedMetrics.Structs = append(collectedMetrics.Structs, fa.Structs...)
+	}
+	collectedMetrics.Interfaces = append(collectedMetrics.Interfaces, fa.Interfaces...)
+	collectedMetrics.Generics = append(collectedMetrics.Generics, fa.Generics...)
+	collectedMetrics.InterfaceAssertions = append(collectedMetrics.InterfaceAssertions, fa.InterfaceAssertions...)
+	collectedMetrics.IdentifierViolations = append(collectedMetrics.IdentifierViolations, fa.IdentifierViolations...)
+	collectedMetrics.TotalIdentifiers += fa.TotalIdentifiers
+	collectedMetrics.PerformanceWarnings = append(collectedMetrics.PerformanceWarnings, fa.Performance...)
+
+	aggregateConcurrencyMetrics(report, &fa.Patterns.ConcurrencyPatterns)
+	aggregateDesignPatternMetrics(report, &fa.Patterns.DesignPatterns)
+	report.Patterns.AntiPatterns.PerformanceAntipatterns = append(report.Patterns.AntiPatterns.PerformanceAntipatterns,
+		fa.Patterns.AntiPatterns.PerformanceAntipatterns...)
+	report.Patterns.AntiPatterns.NakedReturns = append(report.Patterns.AntiPatterns.NakedReturns,
+		fa.Patterns.AntiPatterns.NakedReturns...)
+
+	report.Burden.MagicNumbers = append(report.Burden.MagicNumbers, fa.Burden.MagicNumbers...)
+	report.Burden.ComplexSignatures = append(report.Burden.ComplexSignatures, fa.Burden.ComplexSignatures...)
+	report.Burden.DeeplyNestedFunctions = append(report.Burden.DeeplyNestedFunctions, fa.Burden.DeeplyNestedFunctions...)
+	report.Burden.FeatureEnvyMethods = append(report.Burden.FeatureEnvyMethods, fa.Burden.FeatureEnvyMethods...)
+	report.Burden.ComplexTypeExprs = append(report.Burden.ComplexTypeExprs, fa.Burden.ComplexTypeExprs...)
+	report.Burden.LongMethodChains = append(report.Burden.LongMethodChains, fa.Burden.LongMethodChains...)
+
+	for name, result := range fa.Extensions {
+		if report.Extensions == nil {
+			report.Extensions = make(map[string][]metrics.ExtensionResult)
+		}
+		report.Extensions[name] = append(report.Extensions[name], result)
+	}
+}
+
+// cachedFileAnalysis decodes the results the worker found in the cache for this file, or
+// returns nil when there were none or they cannot be decoded
+func cachedFileAnalysis(result scanner.Result, cfg *config.Config) *fileAnalysis {
+	if result.Cached == nil {
+		return nil
+	}
+	var fa fileAnalysis
+	if err := json.Unmarshal(result.Cached, &fa); err != nil {
+		logVerbose(cfg, "Warning: ignoring unreadable cache entry for %s: %v\n", result.FileInfo.RelPath, err)
+		return nil
+	}
+	return &fa
+}
+
+// storeFileAnalysis records freshly computed per-file results under the file's content hash
+func storeFileAnalysis(cache *storage.AnalysisCache, result scanner.Result, fa *fileAnalysis, cfg *config.Config) {
+	if cache == nil || result.FileInfo.ContentHash == "" {
+		return
+	}
+	data, err := json.Marshal(fa)
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to cache results for %s: %v\n", result.FileInfo.RelPath, err)
+		return
+	}
+	cache.Put(result.FileInfo.RelPath, result.FileInfo.ContentHash, data)
+}
+
+// openAnalysisCache opens the analysis cache for targetDir, or returns nil when caching is
+// disabled or the cache cannot be used. Each analyzed directory gets its own cache file in
+// the cache directory, so entries of one project never evict those of another.
+func openAnalysisCache(targetDir string, cfg *config.Config) *storage.AnalysisCache {
+	if !cfg.Performance.EnableCache || cfg.Performance.CacheDirectory == "" {
+		return nil
+	}
+	version, err := analysisCacheVersion(cfg)
+	if err != nil {
+		logVerbose(cfg, "Warning: analysis cache disabled: %v\n", err)
+		return nil
+	}
+	absDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		absDir = targetDir
+	}
+	sum := sha256.Sum256([]byte(absDir))
+	path := filepath.Join(cfg.Performance.CacheDirectory, "analysis-"+hex.EncodeToString(sum[:8])+".json.gz")
+
+	cache, err := storage.OpenAnalysisCache(path, version)
+	if err != nil {
+		logVerbose(cfg, "Warning: analysis cache disabled: %v\n", err)
+		return nil
+	}
+	return cache
+}
+
+// analysisCacheVersion combines the tool version with a fingerprint of the running executable,
+// the analysis and filter settings, and the registered file analyzers, so rebuilding the tool,
+// changing a threshold, or registering another analyzer invalidates every cached result
+func analysisCacheVersion(cfg *config.Config) (string, error) {
+	settings, err := json.Marshal(struct {
+		Build         string                `json:"build"`
+		Analysis      config.AnalysisConfig `json:"analysis"`
+		Filters       config.FilterConfig   `json:"filters"`
+		FileAnalyzers []string              `json:"file_analyzers"`
+	}{executableFingerprint(), cfg.Analysis, cfg.Filters, RegisteredFileAnalyzers()})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(settings)
+	return toolVersion + "+" + hex.EncodeToString(sum[:8]), nil
+}
+
+var (
+	executableFingerprintOnce sync.Once
+	executableFingerprintHash string
+)
+
+// executableFingerprint hashes the running executable once per process. The tool version alone
+// does not change when the analyzers do, so without it a build with a fixed analyzer would reuse
+// results cached by the build that had the bug. It is empty when the executable cannot be read.
+func executableFingerprint() string {
+	executableFingerprintOnce.Do(func() {
+		path, err := os.Executable()
+		if err != nil {
+			return
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err == nil {
+			executableFingerprintHash = hex.EncodeToString(h.Sum(nil))
+		}
+	})
+	return executableFingerprintHash
+}
+
+// hashFileContents sets the content hash of every discovered file and returns a hash of the
+// whole file set together with the module metadata, which identifies the inputs of a report
+func hashFileContents(files []scanner.FileInfo, module *metrics.ModuleInfo) string {
+	for i := range files {
+		if files[i].ContentHash == "" && files[i].Src != nil {
+			files[i].ContentHash = scanner.HashContent(files[i].Src)
+		}
+	}
+
+	entries := make([]string, len(files))
+	for i, f := range files {
+		entries[i] = f.RelPath + "\x00" + f.ContentHash
+	}
+	sort.Strings(entries)
+
+	h := sha256.New()
+	for _, entry := range entries {
+		h.Write([]byte(entry + "\n"))
+	}
+	if module != nil {
+		if moduleData, err := json.Marshal(module); err == nil {
+			h.Write(moduleData)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// reportCacheable reports whether a finished report depends only on the analyzed files. Team
+// metrics read Git history and coverage correlation reads a profile, both of which can change
+// while the sources stay the same.
+func reportCacheable(cfg *config.Config) bool {
+	return !cfg.Analysis.EnableTeamMetrics && cfg.Analysis.CoverageProfile == ""
+}
+
+// restoreCachedReport returns the report an earlier run produced for the same file set, with
+// fresh timing metadata, or nil when there is none
+func restoreCachedReport(cache *storage.AnalysisCache, filesHash string, startTime time.Time, cfg *config.Config) *metrics.Report {
+	if cache == nil || !reportCacheable(cfg) {
+		return nil
+	}
+	data, ok := cache.Get(reportCacheKey, filesHash)
+	if !ok {
+		return nil
+	}
+	var report metrics.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		logVerbose(cfg, "Warning: ignoring unreadable cached report: %v\n", err)
+		return nil
+	}
+	report.Metadata.GeneratedAt = time.Now()
+	report.Metadata.AnalysisTime = time.Since(startTime)
+	report.Metadata.CachedFiles = report.Metadata.FilesProcessed
+	return &report
+}
+
+// saveAnalysisCache stores the finished report for the file set and writes the cache to disk
+func saveAnalysisCache(cache *storage.AnalysisCache, filesHash string, report *metrics.Report, cfg *config.Config) {
+	if cache == nil {
+		return
+	}
+	if reportCacheable(cfg) {
+		if data, err := json.Marshal(report); err == nil {
+			cache.Put(reportCacheKey, filesHash, data)
+		} else {
+			logVerbose(cfg, "Warning: failed to cache report: %v\n", err)
+		}
+	}
+	if err := cache.Save(); err != nil {
+		logVerbose(cfg, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+const cacheFixtureStore = `package shop
+
+import "sync"
+
+// Store keeps items behind a mutex
+type Store struct {
+	mu    sync.Mutex
+	items map[string]int
+}
+
+// Add records quantity for name
+func (s *Store) Add(name string, quantity int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if quantity > 1000 {
+		quantity = 1000
+	}
+	s.items[name] += quantity
+}
+
+// Watch reports changes on a channel
+func (s *Store) Watch() <-chan string {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for name := range s.items {
+			ch <- name
+		}
+	}()
+	return ch
+}
+`
+
+const cacheFixtureReader = `package shop
+
+// Reader reads stored quantities
+type Reader interface {
+	Get(name string) (int, bool)
+}
+
+// Get returns the quantity recorded for name
+func (s *Store) Get(name string) (int, bool) {
+	for i := 0; i < 3; i++ {
+		if q, ok := s.items[name]; ok {
+			return q, true
+		}
+	}
+	return 0, false
+}
+`
+
+const cacheFixtureCounter = `package shop
+
+// Count returns the number of distinct items in s
+func Count(s *Store) int {
+	return len(s.items)
+}
+`
+
+// writeCacheFixture writes the fixture package to a fresh directory
+func writeCacheFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/shop\n\ngo 1.24\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store.go"), []byte(cacheFixtureStore), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "reader.go"), []byte(cacheFixtureReader), 0o644))
+	return dir
+}
+
+// analyzeCounting analyzes dir with the analysis cache in cacheDir (disabled when empty) and
+// returns the report together with the number of files the worker pool parsed
+func analyzeCounting(t *testing.T, dir, cacheDir string) (*metrics.Report, int) {
+	t.Helper()
+	parsed := 0
+	cfg := config.DefaultConfig()
+	cfg.Performance.WorkerCount = 1
+	cfg.Performance.EnableCache = cacheDir != ""
+	cfg.Performance.CacheDirectory = cacheDir
+	cfg.Output.Progress = func(event config.ProgressEvent) {
+		if event.Phase == config.PhaseAnalysis {
+			parsed = event.Completed
+		}
+	}
+
+	report, err := Analyze(context.Background(), dir, *cfg)
+	require.NoError(t, err)
+	return report, parsed
+}
+
+// assertSameMetrics compares the analysis results of two reports, ignoring run metadata
+func assertSameMetrics(t *testing.T, expected, actual *metrics.Report) {
+	t.Helper()
+	assert.Equal(t, expected.Metadata.ContentHash, actual.Metadata.ContentHash)
+	for name, pair := range map[string][2]interface{}{
+		"functions":  {expected.Functions, actual.Functions},
+		"structs":    {expected.Structs, actual.Structs},
+		"interfaces": {expected.Interfaces, actual.Interfaces},
+		"packages":   {expected.Packages, actual.Packages},
+		"patterns":   {expected.Patterns, actual.Patterns},
+		"burden":     {expected.Burden, actual.Burden},
+		"overview":   {expected.Overview, actual.Overview},
+	} {
+		want, err := json.Marshal(pair[0])
+		require.NoError(t, err)
+		got, err := json.Marshal(pair[1])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got), name)
+	}
+}
+
+// An unchanged tree reuses the whole cached report; once any file changes, every file is parsed
+// again for the package-scope analyses and only the per-file metrics come from the cache
+func TestAnalysisCache_UnchangedTreeReusesReport(t *testing.T) {
+	dir := writeCacheFixture(t)
+	cacheDir := t.TempDir()
+
+	first, parsed := analyzeCounting(t, dir, cacheDir)
+	require.Equal(t, 2, parsed)
+	assert.Zero(t, first.Metadata.CachedFiles)
+	require.NotEmpty(t, first.Functions)
+
+	second, parsed := analyzeCounting(t, dir, cacheDir)
+	assert.Zero(t, parsed, "no file is parsed when nothing changed")
+	assert.Equal(t, 2, second.Metadata.CachedFiles)
+	assertSameMetrics(t, first, second)
+}
+
+func TestAnalysisCache_ChangedFileMergesWithCachedMetrics(t *testing.T) {
+	dir := writeCacheFixture(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "counter.go"), []byte(cacheFixtureCounter), 0o644))
+	cacheDir := t.TempDir()
+	analyzeCounting(t, dir, cacheDir)
+
+	changed := strings.Replace(cacheFixtureReader, "i < 3", "i < 5", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "reader.go"), []byte(changed), 0o644))
+
+	incremental, parsed := analyzeCounting(t, dir, cacheDir)
+	assert.Equal(t, 3, parsed, "package-scope analyses still need every AST")
+	assert.Equal(t, 2, incremental.Metadata.CachedFiles, "the metrics of both unchanged files are reused")
+
+	fresh, _ := analyzeCounting(t, dir, "")
+	assert.Zero(t, fresh.Metadata.CachedFiles)
+	assertSameMetrics(t, fresh, incremental)
+}
+
+func TestAnalysisCacheVersion(t *testing.T) {
+	cfg := config.DefaultConfig()
+	base, err := analysisCacheVersion(cfg)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(base, toolVersion+"+"), "the tool version is part of the cache version")
+
+	same, err := analysisCacheVersion(config.DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, base, same)
+
+	cfg.Analysis.Burden.MaxParams++
+	changed, err := analysisCacheVersion(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, base, changed, "changing a threshold invalidates cached results")
+}
+
+func TestAnalysisCache_DisabledWritesNothing(t *testing.T) {
+	dir := writeCacheFixture(t)
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	cfg := config.DefaultConfig()
+	cfg.Performance.EnableCache = false
+	cfg.Performance.CacheDirectory = cacheDir
+	_, err := Analyze(context.Background(), dir, *cfg)
+	require.NoError(t, err)
+
+	_, err = os.Stat(cacheDir)
+	assert.True(t, os.IsNotExist(err))
+}
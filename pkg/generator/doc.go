@@ -14,6 +14,18 @@
 //	}
 //	fmt.Printf("Found %d functions\n", len(report.Functions))
 //
+// Analyze runs the complete workflow of the analyze command, on a single file or
+// a directory, with an explicit configuration:
+//
+//	cfg := generator.DefaultConfig()
+//	cfg.Output.Logger = log.Printf
+//	report, err := generator.Analyze(ctx, "/path/to/code", *cfg)
+//
+// MergeReports combines the reports of several analyses, such as the shards of a
+// monorepo or one report per repository:
+//
+//	merged := generator.MergeReports(api, web)
+//
 // The package re-exports commonly used types from the internal metrics package
 // for convenience.
 package generator
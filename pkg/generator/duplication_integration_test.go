@@ -1,4 +1,4 @@
-package cmd
+package generator
 
 import (
 	"context"
@@ -21,7 +21,7 @@ func TestDuplicationIntegration_ExactClones(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	testDir := filepath.Join("..", "testdata", "duplication")
+	testDir := filepath.Join("..", "..", "testdata", "duplication")
 	report, err := runAnalysisWorkflow(ctx, testDir, cfg)
 
 	require.NoError(t, err, "Analysis should complete successfully")
@@ -87,7 +87,7 @@ func TestDuplicationIntegration_ConfigThresholds(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
-			testDir := filepath.Join("..", "testdata", "duplication")
+			testDir := filepath.Join("..", "..", "testdata", "duplication")
 			report, err := runAnalysisWorkflow(ctx, testDir, cfg)
 
 			require.NoError(t, err, "Analysis should complete successfully")
@@ -121,7 +121,7 @@ func TestDuplicationIntegration_FalsePositiveRegression(t *testing.T) {
 
 	// Test against real codebase - internal/analyzer
 	// Should not flag every function with error handling as duplicate
-	testDir := filepath.Join("..", "internal", "analyzer")
+	testDir := filepath.Join("..", "..", "internal", "analyzer")
 	report, err := runAnalysisWorkflow(ctx, testDir, cfg)
 
 	require.NoError(t, err, "Analysis should complete successfully on real codebase")
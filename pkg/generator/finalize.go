@@ -1,12 +1,14 @@
-package cmd
+package generator
 
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/opd-ai/go-stats-generator/internal/analyzer"
 	"github.com/opd-ai/go-stats-generator/internal/config"
@@ -14,13 +16,11 @@ import (
 )
 
 // finalizeReport populates the report with collected metrics and generates final package report
-func finalizeReport(report *metrics.Report, collectedMetrics *CollectedMetrics, packageAnalyzer *analyzer.PackageAnalyzer, cfg *config.Config) {
+func finalizeReport(report *metrics.Report, collectedMetrics *CollectedMetrics, packageAnalyzer *analyzer.PackageAnalyzer, packages *packageFindings, cfg *config.Config) {
 	// Generate package report
 	packageReport, err := packageAnalyzer.GenerateReport()
 	if err != nil {
-		if cfg.Output.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: failed to generate package report: %v\n", err)
-		}
+		logVerbose(cfg, "Warning: failed to generate package report: %v\n", err)
 		packageReport = &metrics.PackageReport{
 			Packages:             []metrics.PackageMetrics{},
 			CircularDependencies: []metrics.CircularDependency{},
@@ -28,24 +28,63 @@ func finalizeReport(report *metrics.Report, collectedMetrics *CollectedMetrics,
 		}
 	}
 
-	// Populate main metrics
+	// Populate main metrics; struct method lists were completed by the package-scope passes
 	report.Functions = collectedMetrics.Functions
 	report.Structs = collectedMetrics.Structs
 	report.Interfaces = collectedMetrics.Interfaces
+	analyzer.ResolveEmbeddingDepths(report.Interfaces)
+	report.InterfaceAssertions = analyzer.VerifyInterfaceAssertions(collectedMetrics.InterfaceAssertions,
+		report.Interfaces, report.Structs, report.Functions)
+	// Measure how heavy each interface's implementations are, now that struct method lists are complete
+	analyzer.AnalyzeImplementationComplexity(report.Interfaces, report.Structs)
 	report.Packages = packageReport.Packages
 	report.CircularDependencies = packageReport.CircularDependencies
+	sortExtensionResults(report.Extensions)
 
-	// Aggregate generics metrics from all files
-	aggregateGenericsMetrics(report, collectedMetrics)
+	// Flag structs mixing value and pointer receivers across their methods
+	report.Patterns.AntiPatterns.ReceiverConsistency = analyzer.AnalyzeReceiverConsistency(report.Structs)
+	report.Patterns.AntiPatterns.PerformanceAntipatterns = append(report.Patterns.AntiPatterns.PerformanceAntipatterns,
+		analyzer.CheckReceiverConsistency(report.Patterns.AntiPatterns.ReceiverConsistency)...)
 
-	// Calculate overview metrics
-	calculateOverviewMetrics(report, collectedMetrics, packageReport)
+	// Flag single-method interfaces with one implementer and no test double
+	if cfg.Analysis.Burden.DetectInterfacePollution {
+		report.Patterns.AntiPatterns.PerformanceAntipatterns = append(report.Patterns.AntiPatterns.PerformanceAntipatterns,
+			analyzer.CheckInterfacePollution(report.Interfaces, report.Functions)...)
+	}
 
-	// Finalize complexity metrics aggregation
-	finalizeComplexityMetrics(report)
+	// List exported interfaces no analyzed type implements
+	if cfg.Analysis.Burden.DetectUnimplementedInterfaces {
+		report.Burden.UnimplementedInterfaces = append(report.Burden.UnimplementedInterfaces,
+			analyzer.FindUnimplementedInterfaces(report.Interfaces, report.Functions, collectedMetrics.ParamUses,
+				cfg.Analysis.Burden.ExternalInterfaceMaxMethods)...)
+	}
 
-	// Finalize concurrency metrics summary statistics
-	finalizeConcurrencyMetrics(report)
+	// Aggregate the populated metrics. Each step only reads the metrics above and fills its own
+	// part of the report, so they run side by side.
+	runConcurrently(cfg.Performance.WorkerCount,
+		func() { report.ParamTypes = metrics.AggregateParamTypes(report.Functions) },
+		func() {
+			report.FieldTypes = metrics.AggregateFieldTypes(report.Structs)
+			report.StructBalance = metrics.AggregateStructBalance(report.Structs)
+		},
+		// Flag god objects and long methods, and list burden issues as anti-patterns
+		func() { finalizeStructuralAntiPatterns(report, cfg) },
+		// Aggregate generics metrics from all files
+		func() { aggregateGenericsMetrics(report, collectedMetrics) },
+		func() { calculateOverviewMetrics(report, collectedMetrics, packageReport) },
+		// Complexity averages, rankings, and histogram
+		func() { finalizeComplexityMetrics(report, cfg) },
+		// Concurrency summary statistics
+		func() { finalizeConcurrencyMetrics(report) },
+		func() { finalizePerformanceMetrics(report, collectedMetrics, cfg) },
+	)
+
+	// Infer the minimum Go version from the version-gated features the files use
+	goVersion := packages.minimumGoVersion()
+	report.Metadata.MinimumGoVersion = &goVersion
+
+	// Roll line counts, complexity, and documentation up per directory and file
+	report.Tree = metrics.BuildDirectoryTree(collectedMetrics.FileLinesCount, report)
 
 	// Finalize burden metrics (dead code percentage)
 	finalizeBurdenMetrics(report)
@@ -55,11 +94,39 @@ func finalizeReport(report *metrics.Report, collectedMetrics *CollectedMetrics,
 
 	// Analyze test coverage correlation if coverage profile provided
 	finalizeTestCoverageMetrics(report, cfg)
+
+	// Correlate complexity with the presence of tests, which needs the test files
+	if !cfg.Filters.SkipTestFiles {
+		report.TestPresence = collectedMetrics.Tests.TestPresence(report.Functions, cfg.Analysis.MaxCyclomaticComplexity)
+	}
+
+	// Count bare and wrapped error returns from the production files
+	report.ErrorHandling = collectedMetrics.ErrorHandling.Metrics()
+
+	// Count the most severe issues and grade overall health
+	report.Summary = metrics.SummarizeReport(report, cfg.Analysis.MaxCyclomaticComplexity)
+}
+
+// finalizeStructuralAntiPatterns fills the god object, long method, deep nesting, long
+// parameter list, and magic number anti-patterns. The last three mirror burden issues.
+func finalizeStructuralAntiPatterns(report *metrics.Report, cfg *config.Config) {
+	structural := analyzer.CheckStructuralAntiPatterns(report.Structs, report.Functions, report.Burden, analyzer.StructuralThresholds{
+		MaxStructMembers:   cfg.Analysis.MaxStructMembers,
+		MaxFunctionLength:  cfg.Analysis.MaxFunctionLength,
+		MaxNesting:         cfg.Analysis.Burden.MaxNesting,
+		MaxParameters:      cfg.Analysis.Burden.MaxParams,
+		ExemptConstructors: cfg.Analysis.Burden.ExemptConstructorParams,
+	})
+	report.Patterns.AntiPatterns.GodObjects = structural.GodObjects
+	report.Patterns.AntiPatterns.LongMethods = structural.LongMethods
+	report.Patterns.AntiPatterns.DeepNesting = structural.DeepNesting
+	report.Patterns.AntiPatterns.LongParameterLists = structural.LongParameterLists
+	report.Patterns.AntiPatterns.MagicNumbers = analyzer.MagicNumberAntiPatterns(report.Burden.MagicNumbers)
 }
 
 // finalizeScoringMetrics calculates maintenance burden index for files and packages
 func finalizeScoringMetrics(report *metrics.Report, cfg *config.Config) {
-	scoringAnalyzer := analyzer.NewScoringAnalyzer(cfg.Analysis.Scoring.Weights)
+	scoringAnalyzer := analyzer.NewScoringAnalyzerWithConfig(cfg.Analysis.Scoring)
 	report.Scores = *scoringAnalyzer.CalculateAllScores(report)
 }
 
@@ -76,13 +143,30 @@ func finalizeDuplicationMetrics(report *metrics.Report, duplicationAnalyzer *ana
 
 	if len(blocks) == 0 {
 		report.Duplication = createEmptyDuplicationMetrics()
-		return
+	} else {
+		logDuplicationStart(cfg, len(collectedMetrics.FileLinesCount))
+		duplicationMetrics := duplicationAnalyzer.AnalyzeDuplicationFromBlocks(blocks, totalLines, cfg.Analysis.Duplication.SimilarityThreshold)
+		report.Duplication = duplicationMetrics
+		logDuplicationResults(cfg, duplicationMetrics)
 	}
 
-	logDuplicationStart(cfg, len(collectedMetrics.Files))
-	duplicationMetrics := duplicationAnalyzer.AnalyzeDuplicationFromBlocks(blocks, totalLines, cfg.Analysis.Duplication.SimilarityThreshold)
-	report.Duplication = duplicationMetrics
-	logDuplicationResults(cfg, duplicationMetrics)
+	bodies := collectedMetrics.DupBodies
+	if cfg.Analysis.Duplication.IgnoreTestFiles {
+		bodies = filterTestBodies(bodies)
+	}
+	report.Duplication.HelperDuplicates = duplicationAnalyzer.DetectHelperDuplicates(bodies,
+		collectedMetrics.Structs, cfg.Analysis.Duplication.SimilarityThreshold)
+}
+
+// filterTestBodies removes function bodies belonging to test files.
+func filterTestBodies(bodies []analyzer.FunctionBody) []analyzer.FunctionBody {
+	var filtered []analyzer.FunctionBody
+	for _, body := range bodies {
+		if !strings.HasSuffix(body.File, "_test.go") {
+			filtered = append(filtered, body)
+		}
+	}
+	return filtered
 }
 
 // filterTestBlocks removes blocks belonging to test files and returns the adjusted total line count.
@@ -113,24 +197,18 @@ func createEmptyDuplicationMetrics() metrics.DuplicationMetrics {
 	}
 }
 
-// logDuplicationStart prints duplication analysis progress if verbose mode is enabled.
+// logDuplicationStart reports duplication analysis progress to the configured logger.
 func logDuplicationStart(cfg *config.Config, fileCount int) {
-	if !cfg.Output.Verbose {
-		return
-	}
 	msg := fmt.Sprintf("Running duplication analysis on %d files", fileCount)
 	if cfg.Analysis.Duplication.IgnoreTestFiles {
 		msg += " (excluding test files)"
 	}
-	fmt.Fprintf(os.Stderr, "%s...\n", msg)
+	logVerbose(cfg, "%s...\n", msg)
 }
 
-// logDuplicationResults prints duplication analysis results if verbose mode is enabled.
+// logDuplicationResults reports duplication analysis results to the configured logger.
 func logDuplicationResults(cfg *config.Config, metrics metrics.DuplicationMetrics) {
-	if !cfg.Output.Verbose {
-		return
-	}
-	fmt.Fprintf(os.Stderr, "Found %d clone pairs, %d duplicated lines (%.2f%% duplication ratio)\n",
+	logVerbose(cfg, "Found %d clone pairs, %d duplicated lines (%.2f%% duplication ratio)\n",
 		metrics.ClonePairs,
 		metrics.DuplicatedLines,
 		metrics.DuplicationRatio*100)
@@ -166,9 +244,10 @@ func finalizeNamingMetrics(report *metrics.Report, analyzers *AnalyzerSet, colle
 // extractFilePaths extracts sorted file paths from collected metrics for analysis.
 func extractFilePaths(collectedMetrics *CollectedMetrics) []string {
 	var filePaths []string
-	for filePath := range collectedMetrics.Files {
+	for filePath := range collectedMetrics.FileLinesCount {
 		filePaths = append(filePaths, filePath)
 	}
+	sort.Strings(filePaths)
 	return filePaths
 }
 
@@ -187,9 +266,7 @@ func createEmptyNamingMetrics() metrics.NamingMetrics {
 
 // logNamingStart prints naming analysis progress if verbose mode is enabled.
 func logNamingStart(cfg *config.Config, fileCount int) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Running naming convention analysis on %d files...\n", fileCount)
-	}
+	logVerbose(cfg, "Running naming convention analysis on %d files...\n", fileCount)
 }
 
 // analyzeAllPackageNames extracts unique packages and analyzes package naming conventions.
@@ -199,8 +276,14 @@ func analyzeAllPackageNames(analyzers *AnalyzerSet, collectedMetrics *CollectedM
 },
 ) {
 	uniquePackages := collectUniquePackages(collectedMetrics)
+	pkgNames := make([]string, 0, len(uniquePackages))
+	for pkgName := range uniquePackages {
+		pkgNames = append(pkgNames, pkgName)
+	}
+	sort.Strings(pkgNames)
 	var packageNameViolations []metrics.PackageNameViolation
-	for pkgName, info := range uniquePackages {
+	for _, pkgName := range pkgNames {
+		info := uniquePackages[pkgName]
 		violations := analyzers.Naming.AnalyzePackageName(pkgName, info.dirName, info.filePath)
 		packageNameViolations = append(packageNameViolations, violations...)
 	}
@@ -216,9 +299,8 @@ func collectUniquePackages(collectedMetrics *CollectedMetrics) map[string]struct
 		dirName  string
 		filePath string
 	})
-	for filePath, astFile := range collectedMetrics.Files {
-		if astFile.Name != nil {
-			pkgName := astFile.Name.Name
+	for _, filePath := range extractFilePaths(collectedMetrics) {
+		if pkgName, ok := collectedMetrics.FilePackages[filePath]; ok {
 			dirName := filepath.Base(filepath.Dir(filePath))
 			if _, exists := uniquePackages[pkgName]; !exists {
 				uniquePackages[pkgName] = struct {
@@ -254,20 +336,17 @@ func buildNamingMetrics(analyzers *AnalyzerSet, fileNameViolations []metrics.Fil
 
 // logNamingResults prints naming analysis summary if verbose mode is enabled.
 func logNamingResults(cfg *config.Config, naming metrics.NamingMetrics) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Found %d file, %d identifier, %d package naming violations (score: %.2f)\n",
-			naming.FileNameViolations,
-			naming.IdentifierViolations,
-			naming.PackageNameViolations,
-			naming.OverallNamingScore)
-	}
+	logVerbose(cfg, "Found %d file, %d identifier, %d package naming violations (score: %.2f)\n",
+		naming.FileNameViolations,
+		naming.IdentifierViolations,
+		naming.PackageNameViolations,
+		naming.OverallNamingScore)
 }
 
-// finalizePlacementMetrics performs placement and cohesion analysis on all collected files.
-// It uses AnalyzeMap (which derives filenames from the map keys) rather than Analyze
-// (which calls fset.Position on each file), so that a shared token.FileSet is not needed.
+// finalizePlacementMetrics performs placement and cohesion analysis on the files the placement
+// analyzer recorded during the streaming phase (see collectFileSummaries), by relative path.
 func finalizePlacementMetrics(report *metrics.Report, analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, cfg *config.Config) {
-	if len(collectedMetrics.Files) == 0 {
+	if len(collectedMetrics.FilePackages) == 0 {
 		report.Placement = metrics.PlacementMetrics{
 			MisplacedFunctions: 0,
 			MisplacedMethods:   0,
@@ -280,94 +359,74 @@ func finalizePlacementMetrics(report *metrics.Report, analyzers *AnalyzerSet, co
 		return
 	}
 
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Running placement analysis on %d files...\n", len(collectedMetrics.Files))
-	}
+	logVerbose(cfg, "Running placement analysis on %d files...\n", len(collectedMetrics.FilePackages))
 
-	placementMetrics := analyzers.Placement.AnalyzeMap(collectedMetrics.Files)
+	placementMetrics := analyzers.Placement.AnalyzeAdded()
 	report.Placement = placementMetrics
 
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Found %d misplaced functions, %d misplaced methods, %d low cohesion files (avg cohesion: %.2f)\n",
-			placementMetrics.MisplacedFunctions,
-			placementMetrics.MisplacedMethods,
-			placementMetrics.LowCohesionFiles,
-			placementMetrics.AvgFileCohesion)
-	}
+	logVerbose(cfg, "Found %d misplaced functions, %d misplaced methods, %d low cohesion files (avg cohesion: %.2f)\n",
+		placementMetrics.MisplacedFunctions,
+		placementMetrics.MisplacedMethods,
+		placementMetrics.LowCohesionFiles,
+		placementMetrics.AvgFileCohesion)
 }
 
 // countIdentifiers counts total identifiers in an AST for scoring
 func countIdentifiers(file *ast.File) int {
 	count := 0
 	ast.Inspect(file, func(n ast.Node) bool {
-		switch n.(type) {
+		switch node := n.(type) {
 		case *ast.FuncDecl, *ast.TypeSpec, *ast.ValueSpec:
 			count++
+		case *ast.StructType:
+			if node.Fields != nil {
+				for _, field := range node.Fields.List {
+					count += len(field.Names)
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				count += len(node.Lhs)
+			}
 		}
 		return true
 	})
 	return count
 }
 
-// finalizeDocumentationMetrics performs documentation analysis on all collected files
-func finalizeDocumentationMetrics(report *metrics.Report, analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, cfg *config.Config) {
+// finalizeDocumentationMetrics completes the documentation analysis the streaming phase
+// accumulated file by file, with annotation lines resolved against each file's own FileSet
+func finalizeDocumentationMetrics(report *metrics.Report, collectedMetrics *CollectedMetrics, cfg *config.Config) {
 	// Skip if documentation analysis is disabled or no files
-	if !cfg.Analysis.IncludeDocumentation || len(collectedMetrics.DocFiles) == 0 {
+	if collectedMetrics.Documentation == nil || len(collectedMetrics.FilePackages) == 0 {
 		report.Documentation = metrics.DocumentationMetrics{}
 		return
 	}
 
-	// Build packages map from DocFiles (mirrors what prepareDocumentationInput did from Files map).
-	pkgs := buildPkgsFromDocFiles(collectedMetrics.DocFiles)
+	logVerbose(cfg, "Running documentation analysis on %d files...\n", len(collectedMetrics.FilePackages))
 
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Running documentation analysis on %d files in %d packages...\n", len(collectedMetrics.DocFiles), len(pkgs))
-	}
-
-	// Use AnalyzeWithFileSets so that annotation line numbers are resolved against each
-	// file's own FileSet rather than the shared discoverer FileSet.
-	docMetrics := analyzers.Documentation.AnalyzeWithFileSets(collectedMetrics.DocFiles, pkgs)
+	docMetrics := collectedMetrics.Documentation.Metrics()
+	docMetrics.CommentDensity = analyzer.AnalyzeCommentDensity(collectedMetrics.LineCounts)
 	report.Documentation = *docMetrics
 
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Documentation coverage: %.1f%% (%.1f%% packages, %.1f%% functions, %.1f%% types)\n",
-			docMetrics.Coverage.Overall,
-			docMetrics.Coverage.Packages,
-			docMetrics.Coverage.Functions,
-			docMetrics.Coverage.Types)
-	}
-}
-
-// buildPkgsFromDocFiles builds an ast.Package map keyed by package name from DocFileInfo entries.
-func buildPkgsFromDocFiles(docFiles []analyzer.DocFileInfo) map[string]*ast.Package {
-	pkgs := make(map[string]*ast.Package)
-	for _, fi := range docFiles {
-		if fi.File == nil || fi.File.Name == nil {
-			continue
-		}
-		pkgName := fi.File.Name.Name
-		if _, exists := pkgs[pkgName]; !exists {
-			pkgs[pkgName] = &ast.Package{
-				Name:  pkgName,
-				Files: make(map[string]*ast.File),
-			}
-		}
-		pkgs[pkgName].Files[fi.Path] = fi.File
-	}
-	return pkgs
+	logVerbose(cfg, "Documentation coverage: %.1f%% (%.1f%% packages, %.1f%% functions, %.1f%% types)\n",
+		docMetrics.Coverage.Overall,
+		docMetrics.Coverage.Packages,
+		docMetrics.Coverage.Functions,
+		docMetrics.Coverage.Types)
 }
 
 // finalizeOrganizationMetrics performs organization analysis on all collected files and packages
 func finalizeOrganizationMetrics(report *metrics.Report, analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, cfg *config.Config, targetPath string) {
-	if len(collectedMetrics.Files) == 0 {
+	if len(collectedMetrics.FilePackages) == 0 {
 		report.Organization = metrics.OrganizationMetrics{}
 		return
 	}
 
 	orgConfig := getOrganizationConfig(cfg)
-	logOrganizationStart(cfg, len(collectedMetrics.Files))
+	logOrganizationStart(cfg, len(collectedMetrics.FilePackages))
 
-	oversizedFiles := analyzeOversizedFiles(analyzers, collectedMetrics, orgConfig)
+	oversizedFiles := sortedOversizedFiles(collectedMetrics)
 	oversizedPackages := analyzeOversizedPackages(analyzers, collectedMetrics, report, orgConfig)
 	deepDirs := analyzeDeepDirectories(analyzers, collectedMetrics, targetPath, orgConfig)
 	highFanIn, highFanOut, avgStability := analyzeImportGraph(analyzers, collectedMetrics, orgConfig)
@@ -384,23 +443,28 @@ func finalizeOrganizationMetrics(report *metrics.Report, analyzers *AnalyzerSet,
 	logOrganizationResults(cfg, len(oversizedFiles), len(oversizedPackages), len(deepDirs))
 }
 
+// getOrganizationConfig extracts organization analysis configuration from the main config
+func getOrganizationConfig(cfg *config.Config) analyzer.OrganizationConfig {
+	return analyzer.OrganizationConfig{
+		MaxFileLines:       cfg.Analysis.Organization.MaxFileLines,
+		MaxFileFunctions:   cfg.Analysis.Organization.MaxFileFunctions,
+		MaxFileTypes:       cfg.Analysis.Organization.MaxFileTypes,
+		MaxPackageFiles:    cfg.Analysis.Organization.MaxPackageFiles,
+		MaxExportedSymbols: cfg.Analysis.Organization.MaxExportedSymbols,
+		MaxDirectoryDepth:  cfg.Analysis.Organization.MaxDirectoryDepth,
+		MaxFileImports:     cfg.Analysis.Organization.MaxFileImports,
+	}
+}
+
 // logOrganizationStart prints verbose logging for organization analysis start
 func logOrganizationStart(cfg *config.Config, fileCount int) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Running organization analysis on %d files...\n", fileCount)
-	}
+	logVerbose(cfg, "Running organization analysis on %d files...\n", fileCount)
 }
 
-// analyzeOversizedFiles analyzes all files for size violations using pre-computed line counts.
-func analyzeOversizedFiles(analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, orgConfig analyzer.OrganizationConfig) []metrics.OversizedFile {
-	var oversizedFiles []metrics.OversizedFile
-	for filePath, astFile := range collectedMetrics.Files {
-		lineCount := collectedMetrics.FileLinesCount[filePath]
-		result, err := analyzers.Organization.AnalyzeFileSizesWithLines(astFile, filePath, lineCount, orgConfig)
-		if err == nil && result != nil {
-			oversizedFiles = append(oversizedFiles, *result)
-		}
-	}
+// sortedOversizedFiles returns the oversized files found during streaming, ordered by path
+func sortedOversizedFiles(collectedMetrics *CollectedMetrics) []metrics.OversizedFile {
+	oversizedFiles := append([]metrics.OversizedFile(nil), collectedMetrics.OversizedFiles...)
+	sort.Slice(oversizedFiles, func(i, j int) bool { return oversizedFiles[i].File < oversizedFiles[j].File })
 	return oversizedFiles
 }
 
@@ -438,43 +502,19 @@ func extractOrgImportMetrics(importMetrics *analyzer.ImportGraphMetrics) ([]metr
 
 // logOrganizationResults prints verbose logging for organization analysis results
 func logOrganizationResults(cfg *config.Config, filesCount, packagesCount, dirsCount int) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Found %d oversized files, %d oversized packages, %d deep directories\n",
-			filesCount, packagesCount, dirsCount)
-	}
-}
-
-// prepareDocumentationInput converts files map to slice and groups by package
-func prepareDocumentationInput(filesMap map[string]*ast.File) ([]*ast.File, map[string]*ast.Package) {
-	var files []*ast.File
-	pkgs := make(map[string]*ast.Package)
-
-	for filePath, astFile := range filesMap {
-		files = append(files, astFile)
-		if astFile.Name != nil {
-			pkgName := astFile.Name.Name
-			if _, exists := pkgs[pkgName]; !exists {
-				pkgs[pkgName] = &ast.Package{
-					Name:  pkgName,
-					Files: make(map[string]*ast.File),
-				}
-			}
-			pkgs[pkgName].Files[filePath] = astFile
-		}
-	}
-
-	return files, pkgs
+	logVerbose(cfg, "Found %d oversized files, %d oversized packages, %d deep directories\n",
+		filesCount, packagesCount, dirsCount)
 }
 
 // buildPackageInfo constructs package metadata from collected metrics for placement analysis.
 func buildPackageInfo(collectedMetrics *CollectedMetrics, report *metrics.Report) map[string]*analyzer.PackageInfo {
 	pkgInfo := make(map[string]*analyzer.PackageInfo)
 
-	for filePath, astFile := range collectedMetrics.Files {
-		if astFile.Name == nil {
+	for _, filePath := range extractFilePaths(collectedMetrics) {
+		pkgName, ok := collectedMetrics.FilePackages[filePath]
+		if !ok {
 			continue
 		}
-		pkgName := astFile.Name.Name
 		if _, exists := pkgInfo[pkgName]; !exists {
 			pkgInfo[pkgName] = &analyzer.PackageInfo{
 				Name:  pkgName,
@@ -509,18 +549,9 @@ func buildImportGraphData(collectedMetrics *CollectedMetrics) *analyzer.ImportGr
 		FilePackageMap: make(map[string]string),
 	}
 
-	for filePath, astFile := range collectedMetrics.Files {
-		if astFile.Name != nil {
-			graphData.FilePackageMap[filePath] = astFile.Name.Name
-		}
-
-		importCount := 0
-		for _, imp := range astFile.Imports {
-			if imp.Path != nil {
-				importCount++
-			}
-		}
-		graphData.FileImports[filePath] = importCount
+	for filePath, pkgName := range collectedMetrics.FilePackages {
+		graphData.FilePackageMap[filePath] = pkgName
+		graphData.FileImports[filePath] = collectedMetrics.FileImports[filePath]
 	}
 
 	return graphData
@@ -574,6 +605,37 @@ func finalizeConcurrencyMetrics(report *metrics.Report) {
 			report.Patterns.ConcurrencyPatterns.Channels.DirectionalCount++
 		}
 	}
+	report.Patterns.ConcurrencyPatterns.SyncPrims.AtomicOperations = metrics.CountAtomicOperations(report.Patterns.ConcurrencyPatterns.SyncPrims.Atomic)
+}
+
+// finalizePerformanceMetrics publishes the hot-path allocation warnings when
+// analysis.include_performance is on; the section is omitted from the report otherwise
+func finalizePerformanceMetrics(report *metrics.Report, collectedMetrics *CollectedMetrics, cfg *config.Config) {
+	if !cfg.Analysis.IncludePerformance {
+		return
+	}
+	report.Performance = newPerformanceMetrics(collectedMetrics.PerformanceWarnings)
+}
+
+// newPerformanceMetrics wraps warnings with their per-kind counts
+func newPerformanceMetrics(warnings []metrics.PerformanceWarning) *metrics.PerformanceMetrics {
+	perf := &metrics.PerformanceMetrics{
+		Warnings: make([]metrics.PerformanceWarning, 0, len(warnings)),
+		ByType:   make(map[string]int),
+	}
+	perf.Warnings = append(perf.Warnings, warnings...)
+	for _, w := range warnings {
+		perf.ByType[w.Type]++
+	}
+	return perf
+}
+
+// sortExtensionResults orders each file analyzer's results by file, since files are merged in the
+// order the workers finish them
+func sortExtensionResults(extensions map[string][]metrics.ExtensionResult) {
+	for _, results := range extensions {
+		sort.Slice(results, func(i, j int) bool { return results[i].File < results[j].File })
+	}
 }
 
 // finalizeBurdenMetrics calculates derived burden statistics
@@ -585,41 +647,27 @@ func finalizeBurdenMetrics(report *metrics.Report) {
 	}
 }
 
-// finalizeDeadCodeMetrics groups the accumulated BurdenFiles by package name and runs
-// package-scope dead-code detection for each package. Results are merged into the report.
+// finalizeDeadCodeMetrics merges the dead code the package-scope passes found into the report.
 // This must be called after the streaming phase so all files of every package are present.
-func finalizeDeadCodeMetrics(report *metrics.Report, collectedMetrics *CollectedMetrics, burdenAnalyzer *analyzer.BurdenAnalyzer) {
-	// Group files by package name.
-	pkgFiles := make(map[string][]analyzer.BurdenFileInfo)
-	for _, fi := range collectedMetrics.BurdenFiles {
-		pkgName := fi.Pkg
-		if pkgName == "" {
-			// Pkg should always be populated by processFileAnalysis; if it's empty
-			// that indicates a data quality issue upstream.  Fall back to the AST
-			// package name and emit a warning so the problem is visible.
-			if fi.File != nil && fi.File.Name != nil {
-				pkgName = fi.File.Name.Name
-				fmt.Fprintf(os.Stderr, "Warning: BurdenFileInfo has empty Pkg field; falling back to AST package name %q\n", pkgName)
-			}
-			if pkgName == "" {
-				continue // cannot determine package; skip this file
-			}
-		}
-		pkgFiles[pkgName] = append(pkgFiles[pkgName], fi)
-	}
+func finalizeDeadCodeMetrics(report *metrics.Report, packages *packageFindings) {
+	report.Burden.DeadCode.UnreferencedFunctions = append(
+		report.Burden.DeadCode.UnreferencedFunctions, packages.deadCode.UnreferencedFunctions...)
+	report.Burden.DeadCode.UnreachableCode = append(
+		report.Burden.DeadCode.UnreachableCode, packages.deadCode.UnreachableCode...)
+	report.Burden.DeadCode.TotalDeadLines += packages.deadCode.TotalDeadLines
+}
 
-	// Run dead-code detection at package scope and merge results.
-	for _, fileInfos := range pkgFiles {
-		deadCode := burdenAnalyzer.DetectDeadCodeForPackage(fileInfos)
-		if deadCode == nil {
-			continue
-		}
-		report.Burden.DeadCode.UnreferencedFunctions = append(
-			report.Burden.DeadCode.UnreferencedFunctions, deadCode.UnreferencedFunctions...)
-		report.Burden.DeadCode.UnreachableCode = append(
-			report.Burden.DeadCode.UnreachableCode, deadCode.UnreachableCode...)
-		report.Burden.DeadCode.TotalDeadLines += deadCode.TotalDeadLines
-	}
+// finalizeConstructorBypass appends the struct literals that skip an existing New<Type>
+// constructor, found at package scope, to the anti-pattern list.
+func finalizeConstructorBypass(report *metrics.Report, packages *packageFindings) {
+	report.Patterns.AntiPatterns.PerformanceAntipatterns = append(report.Patterns.AntiPatterns.PerformanceAntipatterns,
+		packages.constructorBypass...)
+}
+
+// finalizeStrategyPatterns appends the Strategy patterns found at package scope, which needs
+// the interfaces and implementers from every file of a package.
+func finalizeStrategyPatterns(report *metrics.Report, packages *packageFindings) {
+	report.Patterns.DesignPatterns.Strategy = append(report.Patterns.DesignPatterns.Strategy, packages.strategy...)
 }
 
 // complexityEntry holds temporary complexity data for sorting and analysis
@@ -629,23 +677,30 @@ type complexityEntry struct {
 	itemType   string
 	file       string
 	line       int
+	lines      int
 }
 
 // finalizeComplexityMetrics calculates aggregated complexity statistics
-func finalizeComplexityMetrics(report *metrics.Report) {
+func finalizeComplexityMetrics(report *metrics.Report, cfg *config.Config) {
 	calculateAverageComplexities(report)
 	buildHighestComplexityList(report)
 	buildComplexityDistribution(report)
+	buildTestComplexityMetrics(report, cfg.Analysis.MaxTestComplexity)
 }
 
-// calculateAverageComplexities computes average complexity for functions and structs
+// calculateAverageComplexities computes average complexity for production functions and structs
 func calculateAverageComplexities(report *metrics.Report) {
 	var totalFunctionComplexity float64
+	productionFunctions := 0
 	for _, fn := range report.Functions {
+		if fn.IsTestFile {
+			continue
+		}
 		totalFunctionComplexity += fn.Complexity.Overall
+		productionFunctions++
 	}
-	if len(report.Functions) > 0 {
-		report.Complexity.AverageFunction = totalFunctionComplexity / float64(len(report.Functions))
+	if productionFunctions > 0 {
+		report.Complexity.AverageFunction = totalFunctionComplexity / float64(productionFunctions)
 	}
 
 	var totalStructComplexity float64
@@ -657,12 +712,23 @@ func calculateAverageComplexities(report *metrics.Report) {
 	}
 }
 
-// buildHighestComplexityList creates a sorted list of top 20 most complex items
+// buildHighestComplexityList creates a sorted list of top 20 most complex items. Ties are broken
+// by length, longest first, then by location so the ranking is deterministic.
 func buildHighestComplexityList(report *metrics.Report) {
 	allItems := collectComplexityEntries(report)
 
-	sort.Slice(allItems, func(i, j int) bool {
-		return allItems[i].complexity > allItems[j].complexity
+	sort.SliceStable(allItems, func(i, j int) bool {
+		a, b := allItems[i], allItems[j]
+		if a.complexity != b.complexity {
+			return a.complexity > b.complexity
+		}
+		if a.lines != b.lines {
+			return a.lines > b.lines
+		}
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		return a.line < b.line
 	})
 
 	topCount := 20
@@ -682,17 +748,26 @@ func buildHighestComplexityList(report *metrics.Report) {
 	}
 }
 
-// collectComplexityEntries gathers complexity data from functions and structs
+// collectComplexityEntries gathers complexity data from production functions and structs.
+// Functions in test files are ranked separately by buildTestComplexityMetrics.
 func collectComplexityEntries(report *metrics.Report) []complexityEntry {
 	var entries []complexityEntry
 
 	for _, fn := range report.Functions {
+		if fn.IsTestFile {
+			continue
+		}
+		itemType := "function"
+		if fn.IsMethod {
+			itemType = "method"
+		}
 		entries = append(entries, complexityEntry{
 			name:       fn.Name,
 			complexity: fn.Complexity.Overall,
-			itemType:   "function",
+			itemType:   itemType,
 			file:       fn.File,
 			line:       fn.Line,
+			lines:      fn.Lines.Total,
 		})
 	}
 
@@ -709,25 +784,90 @@ func collectComplexityEntries(report *metrics.Report) []complexityEntry {
 	return entries
 }
 
-// buildComplexityDistribution creates histogram of complexity ranges
+// buildComplexityDistribution creates a histogram of overall complexity over production functions,
+// methods and structs, with every bucket of metrics.ComplexityDistributionBuckets present
 func buildComplexityDistribution(report *metrics.Report) {
 	allItems := collectComplexityEntries(report)
+	buckets := metrics.ComplexityDistributionBuckets
 
-	report.Complexity.Distribution = make(map[string]int)
+	report.Complexity.Distribution = make(map[string]int, len(buckets))
+	for _, bucket := range buckets {
+		report.Complexity.Distribution[bucket] = 0
+	}
 	for _, item := range allItems {
 		switch {
 		case item.complexity <= 5:
-			report.Complexity.Distribution["0-5"]++
+			report.Complexity.Distribution[buckets[0]]++
 		case item.complexity <= 10:
-			report.Complexity.Distribution["6-10"]++
+			report.Complexity.Distribution[buckets[1]]++
 		case item.complexity <= 15:
-			report.Complexity.Distribution["11-15"]++
+			report.Complexity.Distribution[buckets[2]]++
 		case item.complexity <= 20:
-			report.Complexity.Distribution["16-20"]++
+			report.Complexity.Distribution[buckets[3]]++
 		default:
-			report.Complexity.Distribution["20+"]++
+			report.Complexity.Distribution[buckets[4]]++
+		}
+	}
+}
+
+// buildTestComplexityMetrics ranks Test* functions by cyclomatic complexity and flags those
+// exceeding the threshold, keeping them out of the production complexity rankings
+func buildTestComplexityMetrics(report *metrics.Report, threshold int) {
+	testMetrics := metrics.TestComplexityMetrics{
+		Threshold:     threshold,
+		MostComplex:   []metrics.ComplexityItem{},
+		OverThreshold: []metrics.ComplexityItem{},
+	}
+
+	var tests []metrics.ComplexityItem
+	totalCyclomatic := 0
+	for _, fn := range report.Functions {
+		if !isTestFunction(fn) {
+			continue
+		}
+		totalCyclomatic += fn.Complexity.Cyclomatic
+		item := metrics.ComplexityItem{
+			Name:       fn.Name,
+			Type:       "test_function",
+			File:       fn.File,
+			Line:       fn.Line,
+			Complexity: float64(fn.Complexity.Cyclomatic),
 		}
+		tests = append(tests, item)
+		if threshold > 0 && fn.Complexity.Cyclomatic > threshold {
+			item.Severity = metrics.SeverityLevelWarning
+			item.Metric = "cyclomatic"
+			item.ActualValue = item.Complexity
+			item.Threshold = float64(threshold)
+			item.Suggestion = "Split the test into table-driven cases or extract assertion helpers"
+			testMetrics.OverThreshold = append(testMetrics.OverThreshold, item)
+		}
+	}
+
+	testMetrics.TotalTests = len(tests)
+	if len(tests) > 0 {
+		testMetrics.AverageCyclomatic = float64(totalCyclomatic) / float64(len(tests))
+	}
+
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].Complexity > tests[j].Complexity
+	})
+	topCount := 20
+	if len(tests) < topCount {
+		topCount = len(tests)
 	}
+	testMetrics.MostComplex = append(testMetrics.MostComplex, tests[:topCount]...)
+
+	sort.SliceStable(testMetrics.OverThreshold, func(i, j int) bool {
+		return testMetrics.OverThreshold[i].Complexity > testMetrics.OverThreshold[j].Complexity
+	})
+
+	report.Complexity.TestFunctions = testMetrics
+}
+
+// isTestFunction reports whether fn is a top-level Test* function declared in a _test.go file
+func isTestFunction(fn metrics.FunctionMetrics) bool {
+	return fn.IsTestFile && !fn.IsMethod && strings.HasPrefix(fn.Name, "Test")
 }
 
 // finalizeRefactoringSuggestions generates prioritized refactoring recommendations
@@ -754,6 +894,17 @@ func finalizeRefactoringSuggestions(report *metrics.Report, cfg *config.Config)
 	}
 }
 
+// finalizeContentHash fingerprints the finished report for use as a CI cache key. It must run
+// after every other finalization step so the hash covers all results.
+func finalizeContentHash(report *metrics.Report, cfg *config.Config) {
+	hash, err := metrics.ComputeContentHash(report)
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to compute report content hash: %v\n", err)
+		return
+	}
+	report.Metadata.ContentHash = hash
+}
+
 // aggregateGenericsMetrics merges generic metrics from all analyzed files
 func aggregateGenericsMetrics(report *metrics.Report, collected *CollectedMetrics) {
 	if len(collected.Generics) == 0 {
@@ -846,10 +997,23 @@ func analyzeTestQualityMetrics(report *metrics.Report, cfg *config.Config) {
 		testQuality.TotalTests, len(testQuality.TestFiles))
 }
 
-// logVerbose prints message if verbose mode is enabled
+// logMu serializes logger calls from finalization steps running concurrently
+var logMu sync.Mutex
+
+// logVerbose passes a diagnostic message to the configured logger, if any. The analysis
+// never writes to stderr itself; the CLI installs a stderr logger in verbose mode.
 func logVerbose(cfg *config.Config, format string, args ...interface{}) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, format, args...)
+	if cfg.Output.Logger != nil {
+		logMu.Lock()
+		defer logMu.Unlock()
+		cfg.Output.Logger(format, args...)
+	}
+}
+
+// reportProgress delivers event to the configured progress callback, if any
+func reportProgress(cfg *config.Config, event config.ProgressEvent) {
+	if cfg.Output.Progress != nil {
+		cfg.Output.Progress(event)
 	}
 }
 
@@ -863,15 +1027,11 @@ func finalizeTeamMetrics(report *metrics.Report, targetPath string, cfg *config.
 	teamAnalyzer := analyzer.NewTeamAnalyzer(targetPath)
 	teamMetrics, err := teamAnalyzer.AnalyzeTeamMetrics()
 	if err != nil {
-		if cfg.Output.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: team metrics unavailable (not a Git repo?): %v\n", err)
-		}
+		logVerbose(cfg, "Warning: team metrics unavailable (not a Git repo?): %v\n", err)
 		return
 	}
 
 	report.Team = teamMetrics
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Team metrics: %d developers analyzed\n",
-			teamMetrics.TotalDevelopers)
-	}
+	logVerbose(cfg, "Team metrics: %d developers analyzed\n",
+		teamMetrics.TotalDevelopers)
 }
@@ -0,0 +1,503 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalizeComplexityMetrics_SeparatesTestFunctions(t *testing.T) {
+	report := &metrics.Report{
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Parse", File: "parse.go", Complexity: metrics.ComplexityScore{Cyclomatic: 6, Overall: 8}},
+			{Name: "Render", File: "render.go", Complexity: metrics.ComplexityScore{Cyclomatic: 2, Overall: 3}},
+			{Name: "TestParse", File: "parse_test.go", IsTestFile: true, Complexity: metrics.ComplexityScore{Cyclomatic: 22, Overall: 30}},
+			{Name: "TestRender", File: "render_test.go", IsTestFile: true, Complexity: metrics.ComplexityScore{Cyclomatic: 4, Overall: 5}},
+			{Name: "newFixture", File: "parse_test.go", IsTestFile: true, Complexity: metrics.ComplexityScore{Cyclomatic: 9, Overall: 12}},
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	finalizeComplexityMetrics(report, cfg)
+
+	for _, item := range report.Complexity.HighestComplexity {
+		assert.NotContains(t, []string{"TestParse", "TestRender", "newFixture"}, item.Name,
+			"test-file functions must not appear in production rankings")
+	}
+	require.NotEmpty(t, report.Complexity.HighestComplexity)
+	assert.Equal(t, "Parse", report.Complexity.HighestComplexity[0].Name)
+	assert.InDelta(t, 5.5, report.Complexity.AverageFunction, 0.001)
+
+	tc := report.Complexity.TestFunctions
+	assert.Equal(t, 2, tc.TotalTests, "only Test* functions are counted")
+	assert.Equal(t, cfg.Analysis.MaxTestComplexity, tc.Threshold)
+	assert.InDelta(t, 13.0, tc.AverageCyclomatic, 0.001)
+	require.Len(t, tc.MostComplex, 2)
+	assert.Equal(t, "TestParse", tc.MostComplex[0].Name)
+	assert.Equal(t, "TestRender", tc.MostComplex[1].Name)
+	require.Len(t, tc.OverThreshold, 1)
+	assert.Equal(t, "TestParse", tc.OverThreshold[0].Name)
+	assert.Equal(t, metrics.SeverityLevelWarning, tc.OverThreshold[0].Severity)
+}
+
+// rankedNames returns the names in report.Complexity.HighestComplexity in ranking order
+func rankedNames(report *metrics.Report) []string {
+	names := make([]string, len(report.Complexity.HighestComplexity))
+	for i, item := range report.Complexity.HighestComplexity {
+		names[i] = item.Name
+	}
+	return names
+}
+
+// complexFunction builds a production function with the given overall complexity and length
+func complexFunction(name string, overall float64, lines int) metrics.FunctionMetrics {
+	return metrics.FunctionMetrics{
+		Name: name, Package: "test", File: "test.go",
+		Lines:      metrics.LineMetrics{Total: lines},
+		Complexity: metrics.ComplexityScore{Cyclomatic: int(overall), Overall: overall},
+	}
+}
+
+func TestBuildHighestComplexityList_TieBreakByLength(t *testing.T) {
+	report := &metrics.Report{Functions: []metrics.FunctionMetrics{
+		complexFunction("ShortFunc", 5, 10),
+		complexFunction("LongFunc", 5, 100),
+		complexFunction("VeryShortFunc", 5, 5),
+		complexFunction("VeryLongFunc", 5, 150),
+		complexFunction("MediumFunc", 5, 50),
+	}}
+
+	buildHighestComplexityList(report)
+
+	assert.Equal(t, []string{"VeryLongFunc", "LongFunc", "MediumFunc", "ShortFunc", "VeryShortFunc"}, rankedNames(report))
+}
+
+func TestBuildHighestComplexityList_MixedTiesAndDifferent(t *testing.T) {
+	report := &metrics.Report{
+		Functions: []metrics.FunctionMetrics{
+			complexFunction("Complexity10_Short", 10, 20),
+			complexFunction("Complexity10_Long", 10, 80),
+			complexFunction("Complexity15", 15, 30),
+			complexFunction("Complexity5_Medium", 5, 40),
+			complexFunction("Complexity5_Short", 5, 15),
+			complexFunction("Complexity10_Medium", 10, 50),
+		},
+		Structs: []metrics.StructMetrics{
+			{Name: "Config", File: "config.go", Line: 3, Complexity: metrics.ComplexityScore{Overall: 12}},
+		},
+	}
+	report.Functions[2].IsMethod = true
+
+	buildHighestComplexityList(report)
+
+	assert.Equal(t, []string{
+		"Complexity15", "Config", "Complexity10_Long", "Complexity10_Medium",
+		"Complexity10_Short", "Complexity5_Medium", "Complexity5_Short",
+	}, rankedNames(report))
+	assert.Equal(t, "method", report.Complexity.HighestComplexity[0].Type)
+	assert.Equal(t, "struct", report.Complexity.HighestComplexity[1].Type)
+	assert.Equal(t, "function", report.Complexity.HighestComplexity[2].Type)
+}
+
+func TestBuildComplexityDistribution_BucketCounts(t *testing.T) {
+	report := &metrics.Report{
+		Functions: []metrics.FunctionMetrics{
+			complexFunction("Trivial", 1, 3),
+			complexFunction("Small", 5, 8),
+			complexFunction("Moderate", 5.5, 12),
+			complexFunction("Branchy", 10, 20),
+			complexFunction("Tangled", 14, 40),
+			complexFunction("Monster", 31, 200),
+			{Name: "TestMonster", File: "m_test.go", IsTestFile: true, Complexity: metrics.ComplexityScore{Overall: 40}},
+		},
+		Structs: []metrics.StructMetrics{
+			{Name: "Config", Complexity: metrics.ComplexityScore{Overall: 2}},
+		},
+	}
+
+	buildComplexityDistribution(report)
+
+	assert.Equal(t, map[string]int{
+		"0-5":   3,
+		"6-10":  2,
+		"11-15": 1,
+		"16-20": 0,
+		"20+":   1,
+	}, report.Complexity.Distribution, "test-file functions are excluded and empty buckets are kept")
+}
+
+func TestFinalizeContentHash_StableAcrossRuns(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "sample.go")
+	require.NoError(t, os.WriteFile(source, []byte(`package sample
+
+func Add(a, b int) int { return a + b }
+
+func Scale(values []int, factor int) []int {
+	out := make([]int, 0, len(values))
+	for _, v := range values {
+		out = append(out, v*factor)
+	}
+	return out
+}
+`), 0o644))
+
+	first, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+	second, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, first.Metadata.ContentHash)
+	assert.Equal(t, first.Metadata.ContentHash, second.Metadata.ContentHash, "identical code should produce the same hash")
+
+	require.NoError(t, os.WriteFile(source, []byte(`package sample
+
+func Add(a, b int) int {
+	if a == 0 {
+		return b
+	}
+	return a + b
+}
+`), 0o644))
+
+	changed, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Metadata.ContentHash, changed.Metadata.ContentHash, "changed code should change the hash")
+}
+
+func TestFinalizeStructuralAntiPatterns(t *testing.T) {
+	report := &metrics.Report{
+		Structs: []metrics.StructMetrics{
+			{Name: "Everything", File: "big.go", Line: 3, TotalFields: 40},
+			{Name: "Small", File: "small.go", Line: 1, TotalFields: 2},
+		},
+		Burden: metrics.BurdenMetrics{
+			DeeplyNestedFunctions: []metrics.NestingIssue{{Function: "nested", File: "big.go", Line: 50, MaxDepth: 6}},
+		},
+	}
+
+	finalizeStructuralAntiPatterns(report, config.DefaultConfig())
+
+	anti := report.Patterns.AntiPatterns
+	require.Len(t, anti.GodObjects, 1)
+	assert.Equal(t, "Everything", anti.GodObjects[0].ItemName)
+	require.Len(t, anti.DeepNesting, 1)
+	assert.Equal(t, "nested", anti.DeepNesting[0].Function)
+	assert.Empty(t, anti.LongMethods)
+}
+
+func TestFinalizeStructuralAntiPatterns_MaxParams(t *testing.T) {
+	report := &metrics.Report{
+		Burden: metrics.BurdenMetrics{
+			ComplexSignatures: []metrics.SignatureIssue{
+				{Function: "Render", File: "render.go", Line: 4, ParameterCount: 7},
+				{Function: "Join", File: "render.go", Line: 9, ParameterCount: 3, BoolParams: []string{"escape"}},
+			},
+		},
+	}
+
+	cfg := config.DefaultConfig()
+	finalizeStructuralAntiPatterns(report, cfg)
+	require.Len(t, report.Patterns.AntiPatterns.LongParameterLists, 1)
+	assert.Equal(t, "Render", report.Patterns.AntiPatterns.LongParameterLists[0].Function)
+
+	cfg.Analysis.Burden.MaxParams = 8
+	finalizeStructuralAntiPatterns(report, cfg)
+	assert.Empty(t, report.Patterns.AntiPatterns.LongParameterLists, "analysis.burden.max_params sets the threshold")
+}
+
+func TestAllFindings_BurdenAntiPatternsReportedOnce(t *testing.T) {
+	report := analyzeSource(t, `package lib
+
+func Expired(x int) bool {
+	if x > 86400 {
+		return true
+	}
+	return false
+}
+
+func Render(name, title, body string, width, height int, theme, lang string) string {
+	for _, a := range name {
+		for _, b := range title {
+			for _, c := range body {
+				if a == b {
+					if b == c {
+						return theme + lang
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+`)
+
+	anti := report.Patterns.AntiPatterns
+	require.Len(t, anti.MagicNumbers, 1)
+	assert.Equal(t, "86400", anti.MagicNumbers[0].ItemName)
+	require.Len(t, anti.LongParameterLists, 1)
+	require.Len(t, anti.DeepNesting, 1)
+
+	rules := map[string]int{}
+	for _, f := range report.AllFindings() {
+		rules[f.RuleID]++
+	}
+	assert.Equal(t, 1, rules["burden/magic_number"])
+	assert.Equal(t, 1, rules["burden/complex_signature"])
+	assert.Equal(t, 1, rules["burden/deep_nesting"])
+	for _, rule := range []string{"anti-pattern/magic_number", "anti-pattern/long_parameter_list", "anti-pattern/deep_nesting"} {
+		assert.Zero(t, rules[rule], "%s duplicates a burden finding", rule)
+	}
+}
+
+// analyzeSource analyzes a package made of the single file src
+func analyzeSource(t *testing.T, src string) *metrics.Report {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.go"), []byte(src), 0o644))
+	report, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+	return report
+}
+
+// classifyFunction is a documented function with a cyclomatic complexity of 14
+const classifyFunction = `
+// %[1]s buckets n
+func %[1]s(n int) string {
+	if n < 0 && n > -10 || n == -100 {
+		return "small negative"
+	}
+	switch {
+	case n == 0:
+		return "zero"
+	case n == 1:
+		return "one"
+	case n == 2:
+		return "two"
+	case n < 10:
+		return "digit"
+	case n < 100:
+		return "tens"
+	case n < 1000:
+		return "hundreds"
+	}
+	for i := 0; i < n; i++ {
+		if i%%7 == 0 && i%%11 == 0 {
+			return "lucky"
+		}
+	}
+	return "large"
+}
+`
+
+func TestFinalizeReport_SummaryGradeDropsWithIssues(t *testing.T) {
+	var clean strings.Builder
+	clean.WriteString("package lib\n")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&clean, "\n// Add%[1]d adds %[1]d to n\nfunc Add%[1]d(n int) int { return n + %[1]d }\n", i)
+	}
+	complex := clean.String() + fmt.Sprintf(classifyFunction, "Classify") + fmt.Sprintf(classifyFunction, "Bucket")
+	leaky := complex + `
+func Spin(values chan int) {
+	go func() {
+		for {
+			values <- 1
+		}
+	}()
+}
+
+func Undocumented() {}
+`
+
+	cleanReport := analyzeSource(t, clean.String())
+	complexReport := analyzeSource(t, complex)
+	leakyReport := analyzeSource(t, leaky)
+
+	assert.Equal(t, "A", cleanReport.Summary.Grade)
+	assert.Zero(t, cleanReport.Summary.CriticalIssues)
+
+	assert.Equal(t, 2, complexReport.Summary.HighComplexityFunctions)
+	assert.Less(t, complexReport.Summary.HealthScore, cleanReport.Summary.HealthScore)
+	assert.Greater(t, complexReport.Summary.Grade, cleanReport.Summary.Grade, "later letters are worse grades")
+
+	assert.Equal(t, 2, leakyReport.Summary.UndocumentedExported)
+	assert.NotZero(t, leakyReport.Summary.GoroutineLeaks)
+	assert.Less(t, leakyReport.Summary.HealthScore, complexReport.Summary.HealthScore)
+	assert.Greater(t, leakyReport.Summary.Grade, complexReport.Summary.Grade)
+}
+
+func TestFinalizeReport_CountsMethodsDeclaredInOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte(`package users
+
+// User is an account holder
+type User struct {
+	Name string
+}
+
+// Display returns the name shown for u
+func (u User) Display() string { return u.Name }
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user_methods.go"), []byte(`package users
+
+// Rename changes the name of u
+func (u *User) Rename(name string) { u.Name = name }
+
+// Reset clears u
+func (u *User) Reset() { *u = User{} }
+`), 0o644))
+
+	report, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+	require.Len(t, report.Structs, 1)
+	assert.Len(t, report.Structs[0].Methods, 3, "methods in user_methods.go belong to User")
+}
+
+func TestAnalyze_PerformanceWarningsRequireFlag(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/hot\n\ngo 1.24\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hot.go"), []byte(`package hot
+
+func Join(parts []string) (s string, ids []int) {
+	for i, p := range parts {
+		s += p
+		ids = append(ids, i)
+	}
+	return s, ids
+}
+`), 0o644))
+
+	cfg := config.DefaultConfig()
+	cfg.Analysis.EnableTeamMetrics = false
+	report, err := Analyze(context.Background(), dir, *cfg)
+	require.NoError(t, err)
+	assert.Nil(t, report.Performance, "the section is omitted unless include_performance is set")
+
+	cfg.Analysis.IncludePerformance = true
+	report, err = Analyze(context.Background(), dir, *cfg)
+	require.NoError(t, err)
+	require.NotNil(t, report.Performance)
+	require.Len(t, report.Performance.Warnings, 2)
+	assert.Equal(t, map[string]int{"string_concat_in_loop": 1, "append_in_loop": 1}, report.Performance.ByType)
+	for _, w := range report.Performance.Warnings {
+		assert.Equal(t, "Join", w.Function)
+	}
+
+	var performanceFindings int
+	for _, f := range report.AllFindings() {
+		if f.Category == metrics.FindingCategoryPerformance {
+			performanceFindings++
+		}
+	}
+	assert.Equal(t, 2, performanceFindings)
+}
+
+func TestAnalyze_InterfaceMethodThresholds(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/store\n\ngo 1.24\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store.go"), []byte(`package store
+
+type Store interface {
+	Save(a, b, c, d, e, f string) error
+}
+`), 0o644))
+
+	oversized := func(cfg *config.Config) []metrics.SignatureIssue {
+		t.Helper()
+		report, err := Analyze(context.Background(), dir, *cfg)
+		require.NoError(t, err)
+		require.Len(t, report.Interfaces, 1)
+		return report.Interfaces[0].OversizedMethods
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Analysis.EnableTeamMetrics = false
+	require.Len(t, oversized(cfg), 1, "six parameters exceed the default of five")
+
+	cfg.Analysis.Burden.MaxParams = 10
+	assert.Len(t, oversized(cfg), 1, "the function parameter limit does not apply to interface methods")
+
+	cfg.Analysis.Burden.MaxInterfaceParams = 6
+	assert.Empty(t, oversized(cfg))
+}
+
+func TestAnalyze_ComplexityWeightsFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/weights\n\ngo 1.24\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "weights.go"), []byte(`package weights
+
+type Config struct {
+	Name  string
+	Hooks map[string]func()
+}
+
+func (c *Config) Sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		if v > 0 {
+			total += v
+		}
+	}
+	return total
+}
+`), 0o644))
+
+	cfg := config.DefaultConfig()
+	cfg.Analysis.EnableTeamMetrics = false
+	cfg.Analysis.ComplexityWeights.Function = config.FunctionComplexityWeights{Cyclomatic: 2}
+	cfg.Analysis.ComplexityWeights.Struct = config.StructComplexityWeights{Nesting: 1}
+	report, err := Analyze(context.Background(), dir, *cfg)
+	require.NoError(t, err)
+
+	require.Len(t, report.Functions, 1)
+	fn := report.Functions[0].Complexity
+	assert.InDelta(t, float64(fn.Cyclomatic)*2, fn.Overall, 1e-9)
+	require.Len(t, report.Structs, 1)
+	assert.Zero(t, report.Structs[0].Complexity.Overall, "Config embeds nothing, so only the nesting weight is left")
+}
+
+func TestFinalizeReport_DirectoryTree(t *testing.T) {
+	dir := t.TempDir()
+	sources := map[string]string{
+		"app.go":           "package app\n\n// Run starts the app\nfunc Run() { setup() }\n\nfunc setup() {}\n",
+		"store/store.go":   "package store\n\n// Store keeps values\ntype Store struct{ m map[string]int }\n\n// Get returns a value\nfunc (s *Store) Get(k string) int {\n\tif v, ok := s.m[k]; ok {\n\t\treturn v\n\t}\n\treturn 0\n}\n",
+		"store/lru/lru.go": "package lru\n\nfunc Evict(keys []string) []string {\n\tfor len(keys) > 8 {\n\t\tkeys = keys[1:]\n\t}\n\treturn keys\n}\n",
+	}
+	for path, src := range sources {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(src), 0o644))
+	}
+
+	report, err := Analyze(context.Background(), dir, *config.DefaultConfig())
+	require.NoError(t, err)
+
+	root := report.Tree
+	require.NotNil(t, root)
+	assert.Equal(t, report.Metadata.FilesProcessed, root.Files)
+	assert.Equal(t, report.Overview.TotalLinesOfCode, root.CodeLines)
+	assert.Equal(t, report.Overview.TotalFunctions, root.Functions)
+	assert.Equal(t, report.Overview.TotalMethods, root.Methods)
+
+	require.Len(t, root.Children, 2)
+	store, app := root.Children[0], root.Children[1]
+	assert.Equal(t, "store", store.Path)
+	assert.Equal(t, "app.go", app.Path)
+	require.Len(t, store.Children, 2)
+	lru, storeGo := store.Children[0], store.Children[1]
+	assert.Equal(t, filepath.Join("store", "lru"), lru.Path)
+	assert.Equal(t, storeGo.Lines+lru.Lines, store.Lines)
+	assert.Equal(t, storeGo.CodeLines+lru.CodeLines, store.CodeLines)
+	assert.Equal(t, root.Lines, store.Lines+app.Lines)
+	assert.InDelta(t, 100.0*3/4, root.DocumentationCoverage, 1e-9, "Evict is the only undocumented exported symbol")
+
+	merged := MergeReportsWithConfig(config.DefaultConfig(), report, report)
+	assert.Equal(t, root, merged.Tree, "merging rebuilds the same tree")
+}
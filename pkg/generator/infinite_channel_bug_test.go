@@ -1,4 +1,4 @@
-package cmd
+package generator
 
 import (
 	"context"
@@ -41,14 +41,16 @@ func test() {
 
 	// Create test analyzers and report
 	analyzers := &AnalyzerSet{
-		Function:    analyzer.NewFunctionAnalyzer(fset),
-		Struct:      analyzer.NewStructAnalyzer(fset),
-		Interface:   analyzer.NewInterfaceAnalyzer(fset),
-		Package:     analyzer.NewPackageAnalyzer(fset),
-		Concurrency: analyzer.NewConcurrencyAnalyzer(fset),
-		Burden:      analyzer.NewBurdenAnalyzer(fset),
-		Naming:      analyzer.NewNamingAnalyzer(),
-		Duplication: analyzer.NewDuplicationAnalyzer(fset),
+		Function:     analyzer.NewFunctionAnalyzer(fset),
+		Struct:       analyzer.NewStructAnalyzer(fset),
+		Interface:    analyzer.NewInterfaceAnalyzer(fset),
+		Package:      analyzer.NewPackageAnalyzer(fset),
+		Concurrency:  analyzer.NewConcurrencyAnalyzer(fset),
+		Burden:       analyzer.NewBurdenAnalyzer(fset),
+		Naming:       analyzer.NewNamingAnalyzer(),
+		Duplication:  analyzer.NewDuplicationAnalyzer(fset),
+		Placement:    analyzer.NewPlacementAnalyzer(0, 0),
+		Organization: analyzer.NewOrganizationAnalyzer(fset),
 	}
 
 	report := &metrics.Report{}
@@ -111,14 +113,16 @@ func test() {
 
 	// Create test analyzers and report
 	analyzers := &AnalyzerSet{
-		Function:    analyzer.NewFunctionAnalyzer(fset),
-		Struct:      analyzer.NewStructAnalyzer(fset),
-		Interface:   analyzer.NewInterfaceAnalyzer(fset),
-		Package:     analyzer.NewPackageAnalyzer(fset),
-		Concurrency: analyzer.NewConcurrencyAnalyzer(fset),
-		Burden:      analyzer.NewBurdenAnalyzer(fset),
-		Naming:      analyzer.NewNamingAnalyzer(),
-		Duplication: analyzer.NewDuplicationAnalyzer(fset),
+		Function:     analyzer.NewFunctionAnalyzer(fset),
+		Struct:       analyzer.NewStructAnalyzer(fset),
+		Interface:    analyzer.NewInterfaceAnalyzer(fset),
+		Package:      analyzer.NewPackageAnalyzer(fset),
+		Concurrency:  analyzer.NewConcurrencyAnalyzer(fset),
+		Burden:       analyzer.NewBurdenAnalyzer(fset),
+		Naming:       analyzer.NewNamingAnalyzer(),
+		Duplication:  analyzer.NewDuplicationAnalyzer(fset),
+		Placement:    analyzer.NewPlacementAnalyzer(0, 0),
+		Organization: analyzer.NewOrganizationAnalyzer(fset),
 	}
 
 	report := &metrics.Report{}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/analyzer"
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// MergeReports combines reports into one, such as the shards of a monorepo or the reports of
+// several repositories. Symbol lists are concatenated and deduplicated, and every derived
+// section is recomputed through the same finalization steps Analyze runs, with the default
+// thresholds. Reports of one repository, identified by module path
+// or else by analyzed path, are deduplicated by position, so a file two shards analyzed counts
+// once. When the reports come from several repositories, the relative paths of each are
+// prefixed with its module path, or analyzed path, so their files stay apart. The reports are
+// copied and never changed, so callers may keep reading them while MergeReports runs; nil
+// reports are skipped. MergeReports lives here rather than in the metrics package because
+// recomputing the derived sections needs the analyzer and finalization steps, and the
+// analyzer imports metrics.
+func MergeReports(reports ...*metrics.Report) *metrics.Report {
+	return MergeReportsWithConfig(nil, reports...)
+}
+
+// MergeReportsWithConfig merges reports like MergeReports, recomputing the derived sections
+// with the thresholds of cfg, or the defaults when cfg is nil
+func MergeReportsWithConfig(cfg *config.Config, reports ...*metrics.Report) *metrics.Report {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	reports, labels := prepareMergeInputs(reports)
+	merged := &metrics.Report{Metadata: mergeMetadata(reports, labels)}
+	collected := collectMergedSymbols(reports)
+
+	merged.Functions = collected.Functions
+	merged.ParamTypes = metrics.AggregateParamTypes(merged.Functions)
+	merged.Structs = collected.Structs
+	merged.FieldTypes = metrics.AggregateFieldTypes(merged.Structs)
+	merged.StructBalance = metrics.AggregateStructBalance(merged.Structs)
+	merged.Interfaces = collected.Interfaces
+	merged.InterfaceAssertions = analyzer.VerifyInterfaceAssertions(collected.InterfaceAssertions,
+		merged.Interfaces, merged.Structs, merged.Functions)
+
+	packageReport := mergePackages(reports)
+	merged.Packages = packageReport.Packages
+	merged.CircularDependencies = packageReport.CircularDependencies
+
+	merged.Patterns = mergePatterns(reports)
+	merged.Burden = mergeBurden(reports)
+	merged.Duplication = mergeDuplication(reports)
+	merged.Naming = mergeNaming(reports)
+	merged.Placement = mergePlacement(reports)
+	merged.Organization = mergeOrganization(reports)
+	merged.Documentation = mergeDocumentation(reports)
+	for _, r := range reports {
+		if r.Team != nil {
+			merged.Team = r.Team
+			break
+		}
+	}
+	merged.Performance = mergePerformance(reports)
+	merged.TestPresence = mergeTestPresence(reports)
+	merged.ErrorHandling = mergeErrorHandling(reports)
+	merged.Extensions = mergeExtensions(reports)
+
+	aggregateGenericsMetrics(merged, collected)
+	fileLines := mergeTreeFileLines(reports)
+	calculateOverviewMetrics(merged, collected, packageReport)
+	merged.Overview.TotalFiles = mergedFileCount(reports, fileLines)
+	merged.Tree = metrics.BuildDirectoryTree(fileLines, merged)
+	finalizeComplexityMetrics(merged, cfg)
+	finalizeConcurrencyMetrics(merged)
+	finalizeBurdenMetrics(merged)
+	finalizeScoringMetrics(merged, cfg)
+	merged.Summary = metrics.SummarizeReport(merged, cfg.Analysis.MaxCyclomaticComplexity)
+	finalizeRefactoringSuggestions(merged, cfg)
+	finalizeContentHash(merged, cfg)
+
+	return merged
+}
+
+// mergeMetadata keeps the tool information of the first report, and its repository and module
+// when all reports share one repository label; reports of several repositories get the labels
+// joined by commas and no module. GeneratedAt is the earliest generation time, or now when no
+// report has one. The processing statistics, including the analysis time, are summed, and the
+// minimum Go versions combined.
+func mergeMetadata(reports []*metrics.Report, labels []string) metrics.ReportMetadata {
+	var first metrics.ReportMetadata
+	if len(reports) > 0 {
+		first = reports[0].Metadata
+	}
+	metadata := metrics.ReportMetadata{
+		Repository:  first.Repository,
+		ToolVersion: first.ToolVersion,
+		GoVersion:   first.GoVersion,
+		Module:      first.Module,
+	}
+	if len(labels) > 1 {
+		metadata.Repository = strings.Join(labels, ", ")
+		metadata.Module = nil
+	}
+	var goVersions []metrics.GoVersionRequirement
+	for _, r := range reports {
+		if generated := r.Metadata.GeneratedAt; !generated.IsZero() &&
+			(metadata.GeneratedAt.IsZero() || generated.Before(metadata.GeneratedAt)) {
+			metadata.GeneratedAt = generated
+		}
+		if r.Metadata.MinimumGoVersion != nil {
+			goVersions = append(goVersions, *r.Metadata.MinimumGoVersion)
+		}
+		metadata.AnalysisTime += r.Metadata.AnalysisTime
+		metadata.FilesProcessed += r.Metadata.FilesProcessed
+		metadata.BytesProcessed += r.Metadata.BytesProcessed
+		metadata.CachedFiles += r.Metadata.CachedFiles
+	}
+	if metadata.GeneratedAt.IsZero() {
+		metadata.GeneratedAt = time.Now()
+	}
+	if len(goVersions) > 0 {
+		goVersion := analyzer.MergeGoVersionRequirements(goVersions)
+		metadata.MinimumGoVersion = &goVersion
+	}
+	return metadata
+}
+
+// mergedFileCount counts the distinct files in the trees of the reports, so a file that
+// overlapping shards both analyzed counts once, plus the processed files of reports without a tree
+func mergedFileCount(reports []*metrics.Report, fileLines map[string]int) int {
+	count := len(fileLines)
+	for _, r := range reports {
+		if r.Tree == nil {
+			count += r.Metadata.FilesProcessed
+		}
+	}
+	return count
+}
+
+// collectMergedSymbols gathers the functions, structs, interfaces, generics, and interface
+// assertions of all shards, dropping symbols that overlapping shards both reported.
+// Assertion results and counts are reset so they are verified against the combined symbols.
+func collectMergedSymbols(reports []*metrics.Report) *CollectedMetrics {
+	collected := &CollectedMetrics{}
+	for _, r := range reports {
+		collected.Functions = append(collected.Functions, r.Functions...)
+		collected.Structs = append(collected.Structs, r.Structs...)
+		collected.Interfaces = append(collected.Interfaces, r.Interfaces...)
+		collected.InterfaceAssertions = append(collected.InterfaceAssertions, r.InterfaceAssertions.Assertions...)
+		collected.Generics = append(collected.Generics, r.Generics)
+	}
+
+	collected.Functions = uniqueByKey(collected.Functions, func(fn metrics.FunctionMetrics) string {
+		return symbolKey(fn.Package, fn.File, fn.Line, fn.Name)
+	})
+	collected.Structs = uniqueByKey(collected.Structs, func(s metrics.StructMetrics) string {
+		return symbolKey(s.Package, s.File, s.Line, s.Name)
+	})
+	collected.Interfaces = uniqueByKey(collected.Interfaces, func(i metrics.InterfaceMetrics) string {
+		return symbolKey(i.Package, i.File, i.Line, i.Name)
+	})
+	collected.InterfaceAssertions = uniqueByKey(collected.InterfaceAssertions, func(a metrics.InterfaceAssertion) string {
+		return symbolKey(a.Package, a.File, a.Line, a.Interface)
+	})
+
+	for i := range collected.Interfaces {
+		collected.Interfaces[i].AssertionCount = 0
+	}
+	for i := range collected.InterfaceAssertions {
+		collected.InterfaceAssertions[i].Status = ""
+		collected.InterfaceAssertions[i].MissingMethods = nil
+	}
+	return collected
+}
+
+// symbolKey identifies a symbol by its package, declaration position, and name
+func symbolKey(pkg, file string, line int, name string) string {
+	return fmt.Sprintf("%s|%s:%d|%s", pkg, file, line, name)
+}
@@ -0,0 +1,437 @@
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// prepareMergeInputs returns deep copies of the non-nil reports, so merging neither changes nor
+// shares the caller's reports, and the distinct repository labels of the reports in order. When
+// the reports come from more than one repository, every relative path of a copy is prefixed with
+// its repository label, so files at the same path in two repositories stay apart.
+func prepareMergeInputs(reports []*metrics.Report) ([]*metrics.Report, []string) {
+	inputs := make([]*metrics.Report, 0, len(reports))
+	var labels []string
+	seen := make(map[string]bool)
+	for _, r := range reports {
+		if r == nil {
+			continue
+		}
+		inputs = append(inputs, cloneReport(r))
+		if label := repositoryLabel(r); !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) > 1 {
+		for _, r := range inputs {
+			prefixReportPaths(r, repositoryLabel(r))
+		}
+	}
+	return inputs, labels
+}
+
+// repositoryLabel identifies the repository of a report by its module path, so shards of one
+// module share a label, or by the analyzed path when no go.mod was found
+func repositoryLabel(r *metrics.Report) string {
+	if r.Metadata.Module != nil && r.Metadata.Module.Path != "" {
+		return r.Metadata.Module.Path
+	}
+	return r.Metadata.Repository
+}
+
+// cloneReport returns a deep copy of report that shares no pointers, slices, or maps with it
+func cloneReport(report *metrics.Report) *metrics.Report {
+	return deepCopy(reflect.ValueOf(report)).Interface().(*metrics.Report)
+}
+
+// deepCopy copies v and everything it references through exported fields. Unexported fields,
+// such as those of time.Time, are copied by value.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		if v.Kind() == reflect.Ptr {
+			c := reflect.New(v.Type().Elem())
+			c.Elem().Set(deepCopy(v.Elem()))
+			return c
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	}
+	return v
+}
+
+// prefixReportPaths joins label before every relative file and directory path of report, section
+// by section, and qualifies the package references that merging matches packages by. Package
+// names, such as the Package of symbols and findings and the Name of packages, are kept.
+func prefixReportPaths(r *metrics.Report, label string) {
+	file := func(path *string) { *path = prefixPath(label, *path) }
+	pkg := func(ref *string) { *ref = qualifyPackage(label, *ref) }
+
+	if v := r.Metadata.MinimumGoVersion; v != nil {
+		for i := range v.Features {
+			file(&v.Features[i].File)
+		}
+	}
+	prefixSymbolPaths(r, file)
+	prefixPackageReferences(r, file, pkg)
+	prefixPatternPaths(&r.Patterns, file)
+	prefixFindingPaths(r, file)
+	for _, results := range r.Extensions {
+		for i := range results {
+			file(&results[i].File)
+		}
+	}
+	prefixTreePaths(r.Tree, file)
+}
+
+// prefixSymbolPaths prefixes the files of the functions, structs, interfaces, and the sections
+// derived from them
+func prefixSymbolPaths(r *metrics.Report, file func(*string)) {
+	for i := range r.Functions {
+		file(&r.Functions[i].File)
+	}
+	for i := range r.Structs {
+		file(&r.Structs[i].File)
+	}
+	for i := range r.Interfaces {
+		iface := &r.Interfaces[i]
+		file(&iface.File)
+		for j := range iface.OversizedMethods {
+			file(&iface.OversizedMethods[j].File)
+		}
+		if ic := iface.ImplementationComplexity; ic != nil {
+			for j := range ic.Implementations {
+				file(&ic.Implementations[j].File)
+			}
+		}
+	}
+	for i := range r.InterfaceAssertions.Assertions {
+		file(&r.InterfaceAssertions.Assertions[i].File)
+	}
+	for _, examples := range r.StructBalance.Examples {
+		for i := range examples {
+			file(&examples[i].File)
+		}
+	}
+
+	generics := &r.Generics
+	for i := range generics.TypeParameters.Complexity {
+		file(&generics.TypeParameters.Complexity[i].File)
+	}
+	for _, list := range [][]metrics.GenericInstantiation{generics.Instantiations.Functions,
+		generics.Instantiations.Types, generics.Instantiations.Methods} {
+		for i := range list {
+			file(&list[i].File)
+		}
+	}
+
+	complexity := &r.Complexity
+	for _, list := range [][]metrics.ComplexityItem{complexity.HighestComplexity,
+		complexity.TestFunctions.MostComplex, complexity.TestFunctions.OverThreshold} {
+		for i := range list {
+			file(&list[i].File)
+		}
+	}
+}
+
+// prefixPackageReferences prefixes the files and directories of packages and qualifies their
+// paths and the dependency references between them, so a package of one repository is never
+// merged with or linked to a package of the same name in another
+func prefixPackageReferences(r *metrics.Report, file, pkg func(*string)) {
+	for i := range r.Packages {
+		p := &r.Packages[i]
+		pkg(&p.Path)
+		for j := range p.Files {
+			file(&p.Files[j])
+		}
+		for j := range p.Dependencies {
+			pkg(&p.Dependencies[j])
+		}
+		for j := range p.Dependents {
+			pkg(&p.Dependents[j])
+		}
+	}
+	for _, cycle := range r.CircularDependencies {
+		for i := range cycle.Packages {
+			pkg(&cycle.Packages[i])
+		}
+	}
+
+	o := &r.Organization
+	for i := range o.OversizedFiles {
+		file(&o.OversizedFiles[i].File)
+	}
+	for i := range o.DeepDirectories {
+		file(&o.DeepDirectories[i].Path)
+	}
+	for _, fanIn := range o.HighFanInPackages {
+		for i := range fanIn.Dependents {
+			pkg(&fanIn.Dependents[i])
+		}
+	}
+	for _, fanOut := range o.HighFanOutPackages {
+		for i := range fanOut.Dependencies {
+			pkg(&fanOut.Dependencies[i])
+		}
+	}
+}
+
+// prefixPatternPaths prefixes the files of the design, concurrency, and anti-pattern detections
+func prefixPatternPaths(p *metrics.PatternMetrics, file func(*string)) {
+	dp, cp, ap := &p.DesignPatterns, &p.ConcurrencyPatterns, &p.AntiPatterns
+	for _, list := range [][]metrics.PatternInstance{dp.Singleton, dp.Factory, dp.Builder, dp.Observer,
+		dp.Strategy, cp.WorkerPools, cp.Pipelines, cp.FanOut, cp.FanIn, cp.Semaphores} {
+		for i := range list {
+			file(&list[i].File)
+		}
+	}
+
+	for i := range cp.Goroutines.GoroutineLeaks {
+		file(&cp.Goroutines.GoroutineLeaks[i].File)
+	}
+	for i := range cp.Goroutines.DataRaces {
+		file(&cp.Goroutines.DataRaces[i].File)
+	}
+	for i := range cp.Goroutines.Instances {
+		file(&cp.Goroutines.Instances[i].File)
+	}
+	for i := range cp.Channels.Instances {
+		file(&cp.Channels.Instances[i].File)
+	}
+	sp := &cp.SyncPrims
+	for _, list := range [][]metrics.SyncPrimitiveInstance{sp.Mutexes, sp.RWMutexes, sp.WaitGroups,
+		sp.Once, sp.Cond, sp.Atomic, sp.ErrGroups} {
+		for i := range list {
+			file(&list[i].File)
+		}
+	}
+	for i := range cp.SelectStatements {
+		file(&cp.SelectStatements[i].File)
+	}
+	for i := range cp.SyncWarnings {
+		file(&cp.SyncWarnings[i].File)
+	}
+	for i := range cp.ContextWarnings {
+		file(&cp.ContextWarnings[i].File)
+	}
+
+	for _, list := range [][]metrics.AntiPatternWarning{ap.GodObjects, ap.LongMethods, ap.DeepNesting,
+		ap.LongParameterLists, ap.MagicNumbers, ap.NakedReturns} {
+		for i := range list {
+			file(&list[i].File)
+		}
+	}
+	for i := range ap.PerformanceAntipatterns {
+		file(&ap.PerformanceAntipatterns[i].File)
+	}
+	for i := range ap.ReceiverConsistency {
+		file(&ap.ReceiverConsistency[i].File)
+	}
+}
+
+// prefixFindingPaths prefixes the files and directories of the documentation, duplication,
+// naming, placement, burden, scoring, and testing findings
+func prefixFindingPaths(r *metrics.Report, file func(*string)) {
+	prefixDocumentationPaths(&r.Documentation, file)
+
+	for _, clone := range r.Duplication.Clones {
+		for i := range clone.Instances {
+			file(&clone.Instances[i].File)
+		}
+	}
+	for i := range r.Duplication.HelperDuplicates {
+		file(&r.Duplication.HelperDuplicates[i].File)
+		file(&r.Duplication.HelperDuplicates[i].MethodFile)
+	}
+
+	for i := range r.Naming.FileNameIssues {
+		file(&r.Naming.FileNameIssues[i].File)
+	}
+	for i := range r.Naming.IdentifierIssues {
+		file(&r.Naming.IdentifierIssues[i].File)
+	}
+	for i := range r.Naming.PackageNameIssues {
+		file(&r.Naming.PackageNameIssues[i].Directory)
+	}
+
+	for i := range r.Placement.FunctionIssues {
+		file(&r.Placement.FunctionIssues[i].CurrentFile)
+		file(&r.Placement.FunctionIssues[i].SuggestedFile)
+	}
+	for i := range r.Placement.MethodIssues {
+		file(&r.Placement.MethodIssues[i].CurrentFile)
+		file(&r.Placement.MethodIssues[i].ReceiverFile)
+	}
+	for i := range r.Placement.CohesionIssues {
+		file(&r.Placement.CohesionIssues[i].File)
+	}
+
+	prefixBurdenPaths(&r.Burden, file)
+	for i := range r.Scores.FileScores {
+		file(&r.Scores.FileScores[i].File)
+	}
+	prefixTestingPaths(r, file)
+	if r.ErrorHandling != nil {
+		for i := range r.ErrorHandling.Packages {
+			file(&r.ErrorHandling.Packages[i].Directory)
+		}
+		for i := range r.ErrorHandling.BareReturnFunctions {
+			file(&r.ErrorHandling.BareReturnFunctions[i].File)
+		}
+	}
+	if r.Performance != nil {
+		for i := range r.Performance.Warnings {
+			file(&r.Performance.Warnings[i].File)
+		}
+	}
+}
+
+// prefixDocumentationPaths prefixes the files of annotations and the files and package
+// directories of the comment density
+func prefixDocumentationPaths(d *metrics.DocumentationMetrics, file func(*string)) {
+	for i := range d.TODOComments {
+		file(&d.TODOComments[i].File)
+	}
+	for i := range d.FIXMEComments {
+		file(&d.FIXMEComments[i].File)
+	}
+	for i := range d.HACKComments {
+		file(&d.HACKComments[i].File)
+	}
+	for i := range d.BUGComments {
+		file(&d.BUGComments[i].File)
+	}
+	for i := range d.XXXComments {
+		file(&d.XXXComments[i].File)
+	}
+	for i := range d.DEPRECATEDComments {
+		file(&d.DEPRECATEDComments[i].File)
+	}
+	for i := range d.NOTEComments {
+		file(&d.NOTEComments[i].File)
+	}
+	for i := range d.CommentDensity.Files {
+		file(&d.CommentDensity.Files[i].Name)
+	}
+	for i := range d.CommentDensity.Packages {
+		file(&d.CommentDensity.Packages[i].Name)
+	}
+}
+
+// prefixBurdenPaths prefixes the files of the maintenance burden findings
+func prefixBurdenPaths(b *metrics.BurdenMetrics, file func(*string)) {
+	for i := range b.MagicNumbers {
+		file(&b.MagicNumbers[i].File)
+	}
+	for i := range b.DeadCode.UnreferencedFunctions {
+		file(&b.DeadCode.UnreferencedFunctions[i].File)
+	}
+	for i := range b.DeadCode.UnreachableCode {
+		file(&b.DeadCode.UnreachableCode[i].File)
+	}
+	for i := range b.ComplexSignatures {
+		file(&b.ComplexSignatures[i].File)
+	}
+	for i := range b.DeeplyNestedFunctions {
+		file(&b.DeeplyNestedFunctions[i].File)
+	}
+	for i := range b.FeatureEnvyMethods {
+		file(&b.FeatureEnvyMethods[i].File)
+	}
+	for i := range b.ComplexTypeExprs {
+		file(&b.ComplexTypeExprs[i].File)
+	}
+	for i := range b.LongMethodChains {
+		file(&b.LongMethodChains[i].File)
+	}
+	for i := range b.UnimplementedInterfaces {
+		file(&b.UnimplementedInterfaces[i].File)
+	}
+}
+
+// prefixTestingPaths prefixes the files and directories of the coverage, test quality, and
+// test presence sections
+func prefixTestingPaths(r *metrics.Report, file func(*string)) {
+	for i := range r.TestCoverage.HighRiskFunctions {
+		file(&r.TestCoverage.HighRiskFunctions[i].File)
+	}
+	for i := range r.TestCoverage.CoverageGaps {
+		file(&r.TestCoverage.CoverageGaps[i].File)
+	}
+	for i := range r.TestQuality.TestFiles {
+		file(&r.TestQuality.TestFiles[i].File)
+	}
+	if r.TestPresence != nil {
+		for i := range r.TestPresence.Packages {
+			file(&r.TestPresence.Packages[i].Directory)
+		}
+		for i := range r.TestPresence.UntestedComplex {
+			file(&r.TestPresence.UntestedComplex[i].File)
+		}
+	}
+}
+
+// prefixTreePaths prefixes the path of every node of a directory tree
+func prefixTreePaths(node *metrics.TreeNode, file func(*string)) {
+	if node == nil {
+		return
+	}
+	file(&node.Path)
+	for _, child := range node.Children {
+		prefixTreePaths(child, file)
+	}
+}
+
+// prefixPath joins label before a relative path; empty and absolute paths are returned unchanged
+func prefixPath(label, path string) string {
+	if path == "" || label == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(label, path)
+}
+
+// qualifyPackage qualifies a package reference with the repository label as "label:ref". Import
+// paths inside the repository's module already name it and are returned unchanged, as are empty
+// references.
+func qualifyPackage(label, ref string) string {
+	if ref == "" || label == "" || ref == label || strings.HasPrefix(ref, label+"/") {
+		return ref
+	}
+	return label + ":" + ref
+}
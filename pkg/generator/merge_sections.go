@@ -0,0 +1,481 @@
+package generator
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/go-stats-generator/internal/analyzer"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// mergePackages unifies packages reported by several shards, keyed by path. Files, dependencies,
+// and dependents are unioned and sorted, element counts summed, cohesion averaged across shards
+// weighted by function count, and coupling recomputed as instability from the combined
+// dependencies and dependents. A package whose files were all already seen is a duplicate from
+// overlapping shards and contributes nothing.
+func mergePackages(reports []*metrics.Report) *metrics.PackageReport {
+	index := make(map[string]int)
+	packages := []metrics.PackageMetrics{}
+	var cycles []metrics.CircularDependency
+	for _, r := range reports {
+		for _, pkg := range r.Packages {
+			idx, exists := index[pkg.Path]
+			if !exists {
+				index[pkg.Path] = len(packages)
+				pkg.Files = append([]string(nil), pkg.Files...)
+				packages = append(packages, pkg)
+				continue
+			}
+			addPackageShard(&packages[idx], pkg)
+		}
+		cycles = append(cycles, r.CircularDependencies...)
+	}
+
+	for i := range packages {
+		pkg := &packages[i]
+		sort.Strings(pkg.Files)
+		sort.Strings(pkg.Dependencies)
+		sort.Strings(pkg.Dependents)
+		pkg.CouplingScore = analyzer.PackageInstability(len(pkg.Dependencies), len(pkg.Dependents))
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	return &metrics.PackageReport{
+		Packages:      packages,
+		TotalPackages: len(packages),
+		CircularDependencies: uniqueByKey(cycles, func(c metrics.CircularDependency) string {
+			return strings.Join(c.Packages, "->")
+		}),
+	}
+}
+
+// addPackageShard folds another shard's view of a package into the unified package
+func addPackageShard(pkg *metrics.PackageMetrics, shard metrics.PackageMetrics) {
+	newFiles := unionStrings(pkg.Files, shard.Files)
+	if len(newFiles) == len(pkg.Files) {
+		return
+	}
+	pkg.Files = newFiles
+	if total := pkg.Functions + shard.Functions; total > 0 {
+		pkg.CohesionScore = (pkg.CohesionScore*float64(pkg.Functions) + shard.CohesionScore*float64(shard.Functions)) / float64(total)
+	}
+	pkg.Lines.Total += shard.Lines.Total
+	pkg.Lines.Code += shard.Lines.Code
+	pkg.Lines.Comments += shard.Lines.Comments
+	pkg.Lines.Blank += shard.Lines.Blank
+	pkg.Functions += shard.Functions
+	pkg.Structs += shard.Structs
+	pkg.Interfaces += shard.Interfaces
+	pkg.Dependencies = unionStrings(pkg.Dependencies, shard.Dependencies)
+	pkg.Dependents = unionStrings(pkg.Dependents, shard.Dependents)
+	if !pkg.Documentation.HasComment && shard.Documentation.HasComment {
+		pkg.Documentation = shard.Documentation
+	}
+}
+
+// mergePatterns concatenates pattern detections of all shards, dropping duplicates.
+// Goroutine and channel counts are left for finalizeConcurrencyMetrics to recompute.
+func mergePatterns(reports []*metrics.Report) metrics.PatternMetrics {
+	var merged metrics.PatternMetrics
+	design := &merged.DesignPatterns
+	conc := &merged.ConcurrencyPatterns
+	anti := &merged.AntiPatterns
+	for _, r := range reports {
+		rd, rc, ra := r.Patterns.DesignPatterns, r.Patterns.ConcurrencyPatterns, r.Patterns.AntiPatterns
+
+		design.Singleton = append(design.Singleton, rd.Singleton...)
+		design.Factory = append(design.Factory, rd.Factory...)
+		design.Builder = append(design.Builder, rd.Builder...)
+		design.Observer = append(design.Observer, rd.Observer...)
+		design.Strategy = append(design.Strategy, rd.Strategy...)
+
+		conc.WorkerPools = append(conc.WorkerPools, rc.WorkerPools...)
+		conc.Pipelines = append(conc.Pipelines, rc.Pipelines...)
+		conc.FanOut = append(conc.FanOut, rc.FanOut...)
+		conc.FanIn = append(conc.FanIn, rc.FanIn...)
+		conc.Semaphores = append(conc.Semaphores, rc.Semaphores...)
+		conc.Goroutines.Instances = append(conc.Goroutines.Instances, rc.Goroutines.Instances...)
+		conc.Goroutines.GoroutineLeaks = append(conc.Goroutines.GoroutineLeaks, rc.Goroutines.GoroutineLeaks...)
+		conc.Goroutines.DataRaces = append(conc.Goroutines.DataRaces, rc.Goroutines.DataRaces...)
+		conc.Channels.Instances = append(conc.Channels.Instances, rc.Channels.Instances...)
+		conc.SyncPrims.Mutexes = append(conc.SyncPrims.Mutexes, rc.SyncPrims.Mutexes...)
+		conc.SyncPrims.RWMutexes = append(conc.SyncPrims.RWMutexes, rc.SyncPrims.RWMutexes...)
+		conc.SyncPrims.WaitGroups = append(conc.SyncPrims.WaitGroups, rc.SyncPrims.WaitGroups...)
+		conc.SyncPrims.Once = append(conc.SyncPrims.Once, rc.SyncPrims.Once...)
+		conc.SyncPrims.Cond = append(conc.SyncPrims.Cond, rc.SyncPrims.Cond...)
+		conc.SyncPrims.Atomic = append(conc.SyncPrims.Atomic, rc.SyncPrims.Atomic...)
+		conc.SyncPrims.ErrGroups = append(conc.SyncPrims.ErrGroups, rc.SyncPrims.ErrGroups...)
+		conc.SelectStatements = append(conc.SelectStatements, rc.SelectStatements...)
+		conc.SyncWarnings = append(conc.SyncWarnings, rc.SyncWarnings...)
+		conc.ContextWarnings = append(conc.ContextWarnings, rc.ContextWarnings...)
+
+		anti.GodObjects = append(anti.GodObjects, ra.GodObjects...)
+		anti.LongMethods = append(anti.LongMethods, ra.LongMethods...)
+		anti.DeepNesting = append(anti.DeepNesting, ra.DeepNesting...)
+		anti.LongParameterLists = append(anti.LongParameterLists, ra.LongParameterLists...)
+		anti.MagicNumbers = append(anti.MagicNumbers, ra.MagicNumbers...)
+		anti.NakedReturns = append(anti.NakedReturns, ra.NakedReturns...)
+		anti.PerformanceAntipatterns = append(anti.PerformanceAntipatterns, ra.PerformanceAntipatterns...)
+		anti.ReceiverConsistency = append(anti.ReceiverConsistency, ra.ReceiverConsistency...)
+	}
+
+	for _, list := range []*[]metrics.PatternInstance{
+		&design.Singleton, &design.Factory, &design.Builder, &design.Observer, &design.Strategy,
+		&conc.WorkerPools, &conc.Pipelines, &conc.FanOut, &conc.FanIn, &conc.Semaphores,
+	} {
+		*list = uniqueValues(*list)
+	}
+	for _, list := range []*[]metrics.SyncPrimitiveInstance{
+		&conc.SyncPrims.Mutexes, &conc.SyncPrims.RWMutexes, &conc.SyncPrims.WaitGroups,
+		&conc.SyncPrims.Once, &conc.SyncPrims.Cond, &conc.SyncPrims.Atomic, &conc.SyncPrims.ErrGroups,
+	} {
+		*list = uniqueValues(*list)
+	}
+	for _, list := range []*[]metrics.AntiPatternWarning{
+		&anti.GodObjects, &anti.LongMethods, &anti.DeepNesting, &anti.LongParameterLists, &anti.MagicNumbers, &anti.NakedReturns,
+	} {
+		*list = uniqueValues(*list)
+	}
+	conc.Goroutines.Instances = uniqueValues(conc.Goroutines.Instances)
+	conc.Goroutines.GoroutineLeaks = uniqueValues(conc.Goroutines.GoroutineLeaks)
+	conc.Goroutines.DataRaces = uniqueValues(conc.Goroutines.DataRaces)
+	conc.Channels.Instances = uniqueValues(conc.Channels.Instances)
+	conc.SelectStatements = uniqueValues(conc.SelectStatements)
+	conc.SyncWarnings = uniqueValues(conc.SyncWarnings)
+	conc.ContextWarnings = uniqueValues(conc.ContextWarnings)
+	anti.PerformanceAntipatterns = uniqueValues(anti.PerformanceAntipatterns)
+	anti.ReceiverConsistency = uniqueByKey(anti.ReceiverConsistency, func(r metrics.ReceiverConsistency) string {
+		return r.File + "\x00" + r.Type
+	})
+	return merged
+}
+
+// mergeBurden concatenates the burden findings of all shards. The dead code percentage is
+// recomputed by finalizeBurdenMetrics from the summed dead lines.
+func mergeBurden(reports []*metrics.Report) metrics.BurdenMetrics {
+	merged := createInitialBurden()
+	for _, r := range reports {
+		b := r.Burden
+		merged.MagicNumbers = append(merged.MagicNumbers, b.MagicNumbers...)
+		merged.DeadCode.UnreferencedFunctions = append(merged.DeadCode.UnreferencedFunctions, b.DeadCode.UnreferencedFunctions...)
+		merged.DeadCode.UnreachableCode = append(merged.DeadCode.UnreachableCode, b.DeadCode.UnreachableCode...)
+		merged.DeadCode.TotalDeadLines += b.DeadCode.TotalDeadLines
+		merged.ComplexSignatures = append(merged.ComplexSignatures, b.ComplexSignatures...)
+		merged.DeeplyNestedFunctions = append(merged.DeeplyNestedFunctions, b.DeeplyNestedFunctions...)
+		merged.FeatureEnvyMethods = append(merged.FeatureEnvyMethods, b.FeatureEnvyMethods...)
+		merged.ComplexTypeExprs = append(merged.ComplexTypeExprs, b.ComplexTypeExprs...)
+		merged.LongMethodChains = append(merged.LongMethodChains, b.LongMethodChains...)
+		merged.UnimplementedInterfaces = append(merged.UnimplementedInterfaces, b.UnimplementedInterfaces...)
+	}
+	return merged
+}
+
+// mergeExtensions concatenates the file analyzer results of all shards, dropping results for a
+// file that an earlier shard already contributed
+func mergeExtensions(reports []*metrics.Report) map[string][]metrics.ExtensionResult {
+	var merged map[string][]metrics.ExtensionResult
+	seen := make(map[string]bool)
+	for _, r := range reports {
+		for name, results := range r.Extensions {
+			for _, result := range results {
+				key := name + "\x00" + result.File
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				if merged == nil {
+					merged = make(map[string][]metrics.ExtensionResult)
+				}
+				merged[name] = append(merged[name], result)
+			}
+		}
+	}
+	sortExtensionResults(merged)
+	return merged
+}
+
+// mergePerformance concatenates the hot-path allocation warnings of all shards, dropping repeated
+// entries; the section stays absent unless some shard was analyzed with it enabled
+func mergePerformance(reports []*metrics.Report) *metrics.PerformanceMetrics {
+	var warnings []metrics.PerformanceWarning
+	enabled := false
+	for _, r := range reports {
+		if r.Performance != nil {
+			enabled = true
+			warnings = append(warnings, r.Performance.Warnings...)
+		}
+	}
+	if !enabled {
+		return nil
+	}
+	return newPerformanceMetrics(uniqueValues(warnings))
+}
+
+// mergeTestPresence combines the test presence of all shards, taking each package, keyed by name
+// and directory, from the first shard that analyzed it; the section stays absent unless some shard analyzed test files
+func mergeTestPresence(reports []*metrics.Report) *metrics.TestPresenceMetrics {
+	var merged *metrics.TestPresenceMetrics
+	owners := make(map[string]int)
+	for i, r := range reports {
+		if r.TestPresence == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &metrics.TestPresenceMetrics{
+				ComplexityThreshold: r.TestPresence.ComplexityThreshold,
+				Packages:            []metrics.PackageTestPresence{},
+				UntestedComplex:     []metrics.UntestedFunction{},
+			}
+		}
+		for _, pkg := range r.TestPresence.Packages {
+			key := pkg.Package + "\x00" + pkg.Directory
+			if _, ok := owners[key]; ok {
+				continue
+			}
+			owners[key] = i
+			merged.Packages = append(merged.Packages, pkg)
+			merged.TotalFunctions += pkg.Functions
+			merged.TestedFunctions += pkg.TestedFunctions
+		}
+		for _, fn := range r.TestPresence.UntestedComplex {
+			if owners[fn.Package+"\x00"+filepath.Dir(fn.File)] == i {
+				merged.UntestedComplex = append(merged.UntestedComplex, fn)
+			}
+		}
+	}
+	if merged == nil {
+		return nil
+	}
+
+	if merged.TotalFunctions > 0 {
+		merged.TestedRatio = float64(merged.TestedFunctions) / float64(merged.TotalFunctions)
+	}
+	sort.Slice(merged.Packages, func(i, j int) bool {
+		return merged.Packages[i].Directory < merged.Packages[j].Directory
+	})
+	analyzer.SortUntestedFunctions(merged.UntestedComplex)
+	return merged
+}
+
+// mergeErrorHandling combines the error handling of all shards, taking each package, keyed by name
+// and directory, from the first shard that analyzed it; the section stays absent unless some shard has it
+func mergeErrorHandling(reports []*metrics.Report) *metrics.ErrorHandlingMetrics {
+	var merged *metrics.ErrorHandlingMetrics
+	owners := make(map[string]int)
+	for i, r := range reports {
+		if r.ErrorHandling == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &metrics.ErrorHandlingMetrics{
+				Packages:            []metrics.PackageErrorHandling{},
+				BareReturnFunctions: []metrics.BareErrorFunction{},
+			}
+		}
+		for _, pkg := range r.ErrorHandling.Packages {
+			key := pkg.Package + "\x00" + pkg.Directory
+			if _, ok := owners[key]; ok {
+				continue
+			}
+			owners[key] = i
+			merged.Packages = append(merged.Packages, pkg)
+			merged.Add(pkg.ErrorHandlingCounts)
+		}
+		for _, fn := range r.ErrorHandling.BareReturnFunctions {
+			if owners[fn.Package+"\x00"+filepath.Dir(fn.File)] == i {
+				merged.BareReturnFunctions = append(merged.BareReturnFunctions, fn)
+			}
+		}
+	}
+	if merged == nil {
+		return nil
+	}
+
+	sort.Slice(merged.Packages, func(i, j int) bool {
+		return merged.Packages[i].Directory < merged.Packages[j].Directory
+	})
+	analyzer.SortBareErrorFunctions(merged.BareReturnFunctions)
+	return merged
+}
+
+// mergeTreeFileLines collects the line count of every file in the shards' directory trees; the
+// first shard to list a file decides its count
+func mergeTreeFileLines(reports []*metrics.Report) map[string]int {
+	lines := make(map[string]int)
+	for _, r := range reports {
+		for path, count := range r.Tree.FileLines() {
+			if _, ok := lines[path]; !ok {
+				lines[path] = count
+			}
+		}
+	}
+	return lines
+}
+
+// mergeDuplication concatenates the clone pairs and helper duplicates of all shards, dropping
+// repeated entries, and weights the duplication ratio by each shard's lines of code
+func mergeDuplication(reports []*metrics.Report) metrics.DuplicationMetrics {
+	merged := createEmptyDuplicationMetrics()
+	for _, r := range reports {
+		merged.Clones = append(merged.Clones, r.Duplication.Clones...)
+		merged.HelperDuplicates = append(merged.HelperDuplicates, r.Duplication.HelperDuplicates...)
+		merged.DuplicatedLines += r.Duplication.DuplicatedLines
+		merged.LargestCloneSize = max(merged.LargestCloneSize, r.Duplication.LargestCloneSize)
+	}
+	merged.Clones = uniqueByKey(merged.Clones, func(c metrics.ClonePair) string { return c.Hash })
+	merged.HelperDuplicates = uniqueByKey(merged.HelperDuplicates, func(h metrics.HelperDuplicate) string {
+		return symbolKey(h.Package, h.File, h.Line, h.Function)
+	})
+	merged.ClonePairs = len(merged.Clones)
+	merged.DuplicationRatio = weightedAverage(reports,
+		func(r *metrics.Report) float64 { return r.Duplication.DuplicationRatio }, linesOfCodeWeight)
+	return merged
+}
+
+// mergeNaming concatenates naming violations and weights the naming score by file count
+func mergeNaming(reports []*metrics.Report) metrics.NamingMetrics {
+	merged := createEmptyNamingMetrics()
+	for _, r := range reports {
+		merged.FileNameIssues = append(merged.FileNameIssues, r.Naming.FileNameIssues...)
+		merged.IdentifierIssues = append(merged.IdentifierIssues, r.Naming.IdentifierIssues...)
+		merged.PackageNameIssues = append(merged.PackageNameIssues, r.Naming.PackageNameIssues...)
+	}
+	merged.FileNameViolations = len(merged.FileNameIssues)
+	merged.IdentifierViolations = len(merged.IdentifierIssues)
+	merged.PackageNameViolations = len(merged.PackageNameIssues)
+	if score := weightedAverage(reports,
+		func(r *metrics.Report) float64 { return r.Naming.OverallNamingScore }, fileCountWeight); score > 0 {
+		merged.OverallNamingScore = score
+	}
+	return merged
+}
+
+// mergePlacement concatenates placement issues and weights the file cohesion by file count
+func mergePlacement(reports []*metrics.Report) metrics.PlacementMetrics {
+	var merged metrics.PlacementMetrics
+	for _, r := range reports {
+		merged.MisplacedFunctions += r.Placement.MisplacedFunctions
+		merged.MisplacedMethods += r.Placement.MisplacedMethods
+		merged.LowCohesionFiles += r.Placement.LowCohesionFiles
+		merged.FunctionIssues = append(merged.FunctionIssues, r.Placement.FunctionIssues...)
+		merged.MethodIssues = append(merged.MethodIssues, r.Placement.MethodIssues...)
+		merged.CohesionIssues = append(merged.CohesionIssues, r.Placement.CohesionIssues...)
+	}
+	merged.AvgFileCohesion = weightedAverage(reports,
+		func(r *metrics.Report) float64 { return r.Placement.AvgFileCohesion }, fileCountWeight)
+	return merged
+}
+
+// mergeOrganization concatenates organization findings and weights the package stability
+// by package count
+func mergeOrganization(reports []*metrics.Report) metrics.OrganizationMetrics {
+	var merged metrics.OrganizationMetrics
+	for _, r := range reports {
+		o := r.Organization
+		merged.OversizedFiles = append(merged.OversizedFiles, o.OversizedFiles...)
+		merged.OversizedPackages = append(merged.OversizedPackages, o.OversizedPackages...)
+		merged.DeepDirectories = append(merged.DeepDirectories, o.DeepDirectories...)
+		merged.HighFanInPackages = append(merged.HighFanInPackages, o.HighFanInPackages...)
+		merged.HighFanOutPackages = append(merged.HighFanOutPackages, o.HighFanOutPackages...)
+	}
+	merged.AvgPackageStability = weightedAverage(reports,
+		func(r *metrics.Report) float64 { return r.Organization.AvgPackageStability },
+		func(r *metrics.Report) float64 { return float64(r.Overview.TotalPackages) })
+	return merged
+}
+
+// mergeDocumentation concatenates annotations and comment ratios, sums the comment counts,
+// and weights coverage and quality scores by file count
+func mergeDocumentation(reports []*metrics.Report) metrics.DocumentationMetrics {
+	var merged metrics.DocumentationMetrics
+	for _, r := range reports {
+		d := r.Documentation
+		merged.TODOComments = append(merged.TODOComments, d.TODOComments...)
+		merged.FIXMEComments = append(merged.FIXMEComments, d.FIXMEComments...)
+		merged.HACKComments = append(merged.HACKComments, d.HACKComments...)
+		merged.BUGComments = append(merged.BUGComments, d.BUGComments...)
+		merged.XXXComments = append(merged.XXXComments, d.XXXComments...)
+		merged.DEPRECATEDComments = append(merged.DEPRECATEDComments, d.DEPRECATEDComments...)
+		merged.NOTEComments = append(merged.NOTEComments, d.NOTEComments...)
+		merged.StaleAnnotations += d.StaleAnnotations
+		for category, count := range d.AnnotationsByCategory {
+			if merged.AnnotationsByCategory == nil {
+				merged.AnnotationsByCategory = make(map[string]int)
+			}
+			merged.AnnotationsByCategory[category] += count
+		}
+		merged.Quality.CodeExamples += d.Quality.CodeExamples
+		merged.Quality.InlineComments += d.Quality.InlineComments
+		merged.Quality.BlockComments += d.Quality.BlockComments
+		merged.CommentDensity.Files = append(merged.CommentDensity.Files, d.CommentDensity.Files...)
+		merged.CommentDensity.Packages = append(merged.CommentDensity.Packages, d.CommentDensity.Packages...)
+	}
+
+	byFiles := func(value func(metrics.DocumentationMetrics) float64) float64 {
+		return weightedAverage(reports, func(r *metrics.Report) float64 { return value(r.Documentation) }, fileCountWeight)
+	}
+	merged.Coverage = metrics.DocumentationCoverage{
+		Packages:  byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Coverage.Packages }),
+		Functions: byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Coverage.Functions }),
+		Types:     byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Coverage.Types }),
+		Methods:   byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Coverage.Methods }),
+		Overall:   byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Coverage.Overall }),
+	}
+	merged.Quality.AverageLength = byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Quality.AverageLength })
+	merged.Quality.QualityScore = byFiles(func(d metrics.DocumentationMetrics) float64 { return d.Quality.QualityScore })
+	merged.CommentDensity.Overall = byFiles(func(d metrics.DocumentationMetrics) float64 { return d.CommentDensity.Overall })
+	return merged
+}
+
+// weightedAverage averages a per-report value weighted by a per-report weight such as file
+// count, returning 0 when every weight is zero
+func weightedAverage(reports []*metrics.Report, value, weight func(*metrics.Report) float64) float64 {
+	var total, totalWeight float64
+	for _, r := range reports {
+		w := weight(r)
+		total += value(r) * w
+		totalWeight += w
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return total / totalWeight
+}
+
+// fileCountWeight weights a report by the number of files it analyzed
+func fileCountWeight(r *metrics.Report) float64 {
+	return float64(r.Metadata.FilesProcessed)
+}
+
+// linesOfCodeWeight weights a report by its total lines of code
+func linesOfCodeWeight(r *metrics.Report) float64 {
+	return float64(r.Overview.TotalLinesOfCode)
+}
+
+// uniqueValues returns items with repeated values removed, keeping the first occurrence
+func uniqueValues[T comparable](items []T) []T {
+	return uniqueByKey(items, func(item T) T { return item })
+}
+
+// uniqueByKey returns items with repeated keys removed, keeping the first occurrence
+func uniqueByKey[T any, K comparable](items []T, key func(T) K) []T {
+	seen := make(map[K]bool, len(items))
+	unique := make([]T, 0, len(items))
+	for _, item := range items {
+		k := key(item)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, item)
+	}
+	return unique
+}
+
+// unionStrings appends the items of extra missing from base, preserving order
+func unionStrings(base, extra []string) []string {
+	return uniqueValues(append(append([]string(nil), base...), extra...))
+}
@@ -0,0 +1,252 @@
+package generator
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+func TestMergeReports(t *testing.T) {
+	sharedGoroutine := metrics.GoroutineInstance{File: "worker/pool.go", Line: 12, Function: "Start", IsAnonymous: true}
+
+	shardA := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "mono", FilesProcessed: 2, BytesProcessed: 400},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Start", Package: "worker", File: "worker/pool.go", Line: 10, Lines: metrics.LineMetrics{Code: 20}},
+			{Name: "Stop", Package: "worker", File: "worker/pool.go", Line: 40, IsMethod: true, Lines: metrics.LineMetrics{Code: 5}},
+		},
+		Structs: []metrics.StructMetrics{{Name: "Pool", Package: "worker", File: "worker/pool.go", Line: 5, TotalFields: 3}},
+		Packages: []metrics.PackageMetrics{
+			{Name: "worker", Path: "worker", Files: []string{"worker/pool.go"}, Functions: 2, Structs: 1, Dependencies: []string{"sync"}, CohesionScore: 1.0},
+		},
+	}
+	shardA.Patterns.ConcurrencyPatterns.Goroutines.Instances = []metrics.GoroutineInstance{sharedGoroutine}
+
+	shardB := &metrics.Report{
+		Metadata: metrics.ReportMetadata{Repository: "mono", FilesProcessed: 3, BytesProcessed: 600},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "Submit", Package: "worker", File: "worker/queue.go", Line: 8, Lines: metrics.LineMetrics{Code: 12}},
+			{Name: "Serve", Package: "api", File: "api/server.go", Line: 15, Lines: metrics.LineMetrics{Code: 30}},
+		},
+		Interfaces: []metrics.InterfaceMetrics{{Name: "Handler", Package: "api", File: "api/server.go", Line: 3}},
+		Packages: []metrics.PackageMetrics{
+			{Name: "worker", Path: "worker", Files: []string{"worker/queue.go"}, Functions: 1, Dependencies: []string{"context", "sync"}, CohesionScore: 0.4},
+			{Name: "api", Path: "api", Files: []string{"api/server.go"}, Functions: 1, Interfaces: 1},
+		},
+	}
+	shardB.Patterns.ConcurrencyPatterns.Goroutines.Instances = []metrics.GoroutineInstance{
+		sharedGoroutine,
+		{File: "api/server.go", Line: 22, Function: "Serve", IsAnonymous: false},
+	}
+
+	var reports []*metrics.Report
+	for _, shard := range []*metrics.Report{shardA, shardB} {
+		data, err := json.Marshal(shard)
+		require.NoError(t, err)
+
+		var loaded metrics.Report
+		require.NoError(t, json.Unmarshal(data, &loaded))
+		reports = append(reports, &loaded)
+	}
+
+	merged := MergeReportsWithConfig(config.DefaultConfig(), reports...)
+
+	assert.Equal(t, 5, merged.Metadata.FilesProcessed)
+	assert.Equal(t, int64(1000), merged.Metadata.BytesProcessed)
+	assert.Equal(t, 5, merged.Overview.TotalFiles)
+	assert.Equal(t, 67, merged.Overview.TotalLinesOfCode)
+	assert.Equal(t, 3, merged.Overview.TotalFunctions)
+	assert.Equal(t, 1, merged.Overview.TotalMethods)
+	assert.Equal(t, 1, merged.Overview.TotalStructs)
+	assert.Equal(t, 1, merged.Overview.TotalInterfaces)
+	assert.Equal(t, 2, merged.Overview.TotalPackages)
+
+	require.Len(t, merged.Packages, 2)
+	api, worker := merged.Packages[0], merged.Packages[1]
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, "worker", worker.Name)
+	assert.Equal(t, []string{"worker/pool.go", "worker/queue.go"}, worker.Files)
+	assert.Equal(t, 3, worker.Functions)
+	assert.Equal(t, 1, worker.Structs)
+	assert.Equal(t, []string{"context", "sync"}, worker.Dependencies)
+	assert.InDelta(t, 0.8, worker.CohesionScore, 1e-9, "shard scores weighted by function count")
+	assert.InDelta(t, 1.0, worker.CouplingScore, 1e-9, "2 unioned dependencies")
+
+	goroutines := merged.Patterns.ConcurrencyPatterns.Goroutines
+	assert.Len(t, goroutines.Instances, 2, "the goroutine reported by both shards is kept once")
+	assert.Equal(t, 2, goroutines.TotalCount)
+	assert.Equal(t, 1, goroutines.AnonymousCount)
+	assert.Equal(t, 1, goroutines.NamedCount)
+}
+
+func TestMergeReports_OverlappingShards(t *testing.T) {
+	shard := &metrics.Report{
+		Metadata:  metrics.ReportMetadata{FilesProcessed: 1},
+		Functions: []metrics.FunctionMetrics{{Name: "Run", Package: "app", File: "app/main.go", Line: 3}},
+		Packages:  []metrics.PackageMetrics{{Name: "app", Path: "app", Files: []string{"app/main.go"}, Functions: 1}},
+	}
+
+	merged := MergeReportsWithConfig(config.DefaultConfig(), shard, shard)
+
+	assert.Len(t, merged.Functions, 1, "functions reported by both shards are kept once")
+	require.Len(t, merged.Packages, 1)
+	assert.Equal(t, 1, merged.Packages[0].Functions, "a package whose files were already merged is not counted twice")
+}
+
+// repositoryReport is the report of a small repository with a main package in main.go and a
+// store package, so two of them share relative paths
+func repositoryReport(module string, generatedAt time.Time, storeComplexity float64) *metrics.Report {
+	report := &metrics.Report{
+		Metadata: metrics.ReportMetadata{
+			Repository: "/src/" + path.Base(module), GeneratedAt: generatedAt, AnalysisTime: 2 * time.Second,
+			FilesProcessed: 2, Module: &metrics.ModuleInfo{Path: module},
+		},
+		Functions: []metrics.FunctionMetrics{
+			{Name: "main", Package: "main", File: "main.go", Line: 5, Lines: metrics.LineMetrics{Code: 10},
+				Complexity: metrics.ComplexityScore{Overall: 2}},
+			{Name: "Get", Package: "store", File: "store/store.go", Line: 8, IsMethod: true, Lines: metrics.LineMetrics{Code: 25},
+				Complexity: metrics.ComplexityScore{Overall: storeComplexity}},
+		},
+		Structs: []metrics.StructMetrics{{Name: "Store", Package: "store", File: "store/store.go", Line: 3}},
+		Packages: []metrics.PackageMetrics{
+			{Name: "main", Path: "main", Files: []string{"main.go"}, Functions: 1,
+				Dependencies: []string{module + "/store"}},
+			{Name: "store", Path: "store", Files: []string{"store/store.go"}, Functions: 1, Structs: 1,
+				Dependents: []string{"main"}},
+		},
+	}
+	report.Patterns.ConcurrencyPatterns.Goroutines.Instances = []metrics.GoroutineInstance{
+		{File: "store/store.go", Line: 14, Function: "Get", IsAnonymous: true},
+	}
+	report.Tree = metrics.BuildDirectoryTree(map[string]int{"main.go": 20, "store/store.go": 40}, report)
+	return report
+}
+
+func TestMergeReports_Repositories(t *testing.T) {
+	earliest := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	api := repositoryReport("example.com/api", earliest.Add(time.Hour), 4)
+	web := repositoryReport("example.com/web", earliest, 13)
+	snapshot := []*metrics.Report{cloneReport(api), cloneReport(web)}
+
+	merged := MergeReports(api, nil, web)
+
+	assert.Equal(t, snapshot, []*metrics.Report{api, web}, "the inputs are not changed")
+	assert.Equal(t, "example.com/api, example.com/web", merged.Metadata.Repository)
+	assert.Nil(t, merged.Metadata.Module, "the reports have no module in common")
+	assert.Equal(t, earliest, merged.Metadata.GeneratedAt)
+	assert.Equal(t, 4*time.Second, merged.Metadata.AnalysisTime)
+
+	single := MergeReports(api)
+	other := MergeReports(web)
+	for _, field := range []func(*metrics.Report) int{
+		func(r *metrics.Report) int { return r.Overview.TotalFiles },
+		func(r *metrics.Report) int { return r.Overview.TotalFunctions },
+		func(r *metrics.Report) int { return r.Overview.TotalMethods },
+		func(r *metrics.Report) int { return r.Overview.TotalStructs },
+		func(r *metrics.Report) int { return r.Overview.TotalPackages },
+		func(r *metrics.Report) int { return r.Overview.TotalLinesOfCode },
+		func(r *metrics.Report) int { return r.Patterns.ConcurrencyPatterns.Goroutines.TotalCount },
+	} {
+		assert.Equal(t, field(single)+field(other), field(merged), "totals are the sums of the inputs")
+	}
+	assert.Equal(t, 4, merged.Overview.TotalFiles)
+	assert.Equal(t, 70, merged.Overview.TotalLinesOfCode)
+	assert.Equal(t, 2, merged.Patterns.ConcurrencyPatterns.Goroutines.AnonymousCount)
+	assert.Equal(t, map[string]int{"0-5": 5, "6-10": 0, "11-15": 1, "16-20": 0, "20+": 0}, merged.Complexity.Distribution,
+		"three functions and both structs are simple, and the web store's Get is not")
+
+	require.Len(t, merged.Functions, 4, "functions at the same path in two repositories are both kept")
+	files := make([]string, len(merged.Functions))
+	for i, fn := range merged.Functions {
+		files[i] = fn.File
+	}
+	assert.ElementsMatch(t, []string{
+		filepath.Join("example.com/api", "main.go"), filepath.Join("example.com/api", "store", "store.go"),
+		filepath.Join("example.com/web", "main.go"), filepath.Join("example.com/web", "store", "store.go"),
+	}, files)
+	require.Len(t, merged.Packages, 4, "same-named packages of two repositories stay apart")
+	assert.Equal(t, 120, merged.Tree.Lines)
+	require.Len(t, merged.Tree.Children, 1)
+	assert.Equal(t, "example.com", merged.Tree.Children[0].Name, "each repository is a directory of the tree")
+	assert.Len(t, merged.Tree.Children[0].Children, 2)
+}
+
+func TestMergeReports_SamePackageNames(t *testing.T) {
+	api := repositoryReport("example.com/api", time.Time{}, 4)
+	web := repositoryReport("example.com/web", time.Time{}, 13)
+
+	merged := MergeReports(api, web)
+
+	stores := make(map[string]metrics.PackageMetrics)
+	for _, pkg := range merged.Packages {
+		if pkg.Name == "store" {
+			stores[pkg.Path] = pkg
+		}
+	}
+	require.Len(t, stores, 2, "the store packages of both repositories are kept")
+	for _, module := range []string{"example.com/api", "example.com/web"} {
+		store, ok := stores[module+":store"]
+		require.True(t, ok, "the package reference of %s is qualified by its repository", module)
+		assert.Equal(t, "store", store.Name, "package names are not changed")
+		assert.Equal(t, []string{filepath.Join(module, "store", "store.go")}, store.Files)
+		assert.Equal(t, []string{module + ":main"}, store.Dependents, "dependents name the package of the same repository")
+	}
+
+	for _, pkg := range merged.Packages {
+		if pkg.Name == "main" {
+			module := strings.TrimSuffix(pkg.Path, ":main")
+			assert.Equal(t, []string{module + "/store"}, pkg.Dependencies, "import paths already name their repository")
+		}
+	}
+	for _, fn := range merged.Functions {
+		assert.Contains(t, []string{"main", "store"}, fn.Package, "symbols keep their package names")
+	}
+}
+
+func TestMergeReports_RepositoryMergedTwice(t *testing.T) {
+	api := repositoryReport("example.com/api", time.Time{}, 4)
+
+	merged := MergeReports(api, api)
+
+	assert.Equal(t, "/src/api", merged.Metadata.Repository)
+	require.NotNil(t, merged.Metadata.Module)
+	assert.Equal(t, "example.com/api", merged.Metadata.Module.Path)
+	assert.False(t, merged.Metadata.GeneratedAt.IsZero(), "without generation times the merge time is used")
+	assert.Equal(t, 2, merged.Overview.TotalFiles, "files in both reports are not counted twice")
+	assert.Len(t, merged.Functions, 2)
+	assert.Equal(t, 1, merged.Patterns.ConcurrencyPatterns.Goroutines.TotalCount)
+	assert.Equal(t, filepath.Join("store", "store.go"), merged.Functions[1].File, "paths of one repository are not prefixed")
+}
+
+func TestMergeReports_Concurrent(t *testing.T) {
+	api := repositoryReport("example.com/api", time.Time{}, 4)
+	web := repositoryReport("example.com/web", time.Time{}, 13)
+	want := MergeReports(api, web)
+
+	var wg sync.WaitGroup
+	results := make([]*metrics.Report, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = MergeReports(api, web)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, merged := range results {
+		assert.Equal(t, want.Overview, merged.Overview)
+		assert.Equal(t, want.Metadata.ContentHash, merged.Metadata.ContentHash)
+	}
+	assert.Empty(t, MergeReports().Functions)
+}
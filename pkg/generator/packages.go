@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"sort"
+
+	"github.com/opd-ai/go-stats-generator/internal/analyzer"
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// packageFile is a processed file as the package-scope passes see it
+type packageFile struct {
+	path string
+	// group is the package name the file is analyzed with
+	group string
+	// info has no File or Fset in low-memory mode, where load parses the file again
+	info analyzer.BurdenFileInfo
+}
+
+// load returns the file's burden inputs, parsing it from disk if its AST was not kept
+func (pf packageFile) load() (analyzer.BurdenFileInfo, error) {
+	if pf.info.File != nil {
+		return pf.info, nil
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, pf.path, nil, parser.ParseComments)
+	if err != nil {
+		return analyzer.BurdenFileInfo{}, fmt.Errorf("failed to parse file %s: %w", pf.path, err)
+	}
+	info := pf.info
+	info.File, info.Fset = file, fset
+	return info, nil
+}
+
+// forEachPackage calls fn with the files of each package, in package name order and, within a
+// package, in the order they were processed. Only the ASTs of the package being visited are
+// alive in low-memory mode, as the files are parsed again one package at a time.
+func forEachPackage(collected *CollectedMetrics, cfg *config.Config, fn func(name string, files []analyzer.BurdenFileInfo)) {
+	groups := make(map[string][]packageFile)
+	for _, pf := range collected.packageFiles {
+		groups[pf.group] = append(groups[pf.group], pf)
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		files := make([]analyzer.BurdenFileInfo, 0, len(groups[name]))
+		for _, pf := range groups[name] {
+			info, err := pf.load()
+			if err != nil {
+				logVerbose(cfg, "Warning: %v\n", err)
+				continue
+			}
+			files = append(files, info)
+		}
+		fn(name, files)
+	}
+}
+
+// packageFindings holds the results of the package-scope passes until finalization publishes
+// them in the report
+type packageFindings struct {
+	deadCode          metrics.DeadCodeMetrics
+	constructorBypass []metrics.PerformanceAntipattern
+	strategy          []metrics.PatternInstance
+	goVersions        []metrics.GoVersionRequirement
+}
+
+// analyzePackages runs every pass that needs all files of a package at once in a single walk
+// over the packages, so low-memory mode parses each package only once. It completes the method
+// lists and promoted members of the collected structs, and gathers dead code, constructor
+// bypasses, Strategy patterns, and version-gated features.
+func analyzePackages(collected *CollectedMetrics, burdenAnalyzer *analyzer.BurdenAnalyzer, cfg *config.Config) *packageFindings {
+	findings := &packageFindings{}
+	weights := structComplexityWeights(cfg)
+	structsByPackage := make(map[string][]int)
+	for i, s := range collected.Structs {
+		structsByPackage[s.Package] = append(structsByPackage[s.Package], i)
+	}
+
+	forEachPackage(collected, cfg, func(name string, files []analyzer.BurdenFileInfo) {
+		var (
+			deadCode  *metrics.DeadCodeMetrics
+			bypass    []metrics.PerformanceAntipattern
+			strategy  []metrics.PatternInstance
+			goVersion metrics.GoVersionRequirement
+		)
+		// The passes only read the package's ASTs, and only the first writes to the structs
+		runConcurrently(cfg.Performance.WorkerCount,
+			func() { completeStructs(collected.Structs, structsByPackage[name], files, weights) },
+			func() { deadCode = burdenAnalyzer.DetectDeadCodeForPackage(files) },
+			func() {
+				if cfg.Analysis.Burden.DetectConstructorBypass {
+					bypass = analyzer.CheckConstructorBypass(files)
+				}
+			},
+			func() {
+				if cfg.Analysis.IncludePatterns {
+					strategy = analyzer.DetectStrategyPatterns(files)
+				}
+			},
+			func() { goVersion = analyzer.DetectGoVersionFeatures(files) },
+		)
+
+		if deadCode != nil {
+			findings.deadCode.UnreferencedFunctions = append(findings.deadCode.UnreferencedFunctions, deadCode.UnreferencedFunctions...)
+			findings.deadCode.UnreachableCode = append(findings.deadCode.UnreachableCode, deadCode.UnreachableCode...)
+			findings.deadCode.TotalDeadLines += deadCode.TotalDeadLines
+		}
+		findings.constructorBypass = append(findings.constructorBypass, bypass...)
+		findings.strategy = append(findings.strategy, strategy...)
+		findings.goVersions = append(findings.goVersions, goVersion)
+	})
+	return findings
+}
+
+// completeStructs attaches the methods declared in other files of the package to the structs
+// at the given indices, and counts the members they gain from embedded types
+func completeStructs(structs []metrics.StructMetrics, indices []int, files []analyzer.BurdenFileInfo, weights analyzer.ComplexityWeights) {
+	if len(indices) == 0 {
+		return
+	}
+	pkgStructs := make([]metrics.StructMetrics, len(indices))
+	for i, index := range indices {
+		pkgStructs[i] = structs[index]
+	}
+	analyzer.AttachCrossFileMethods(pkgStructs, files, weights)
+	analyzer.ResolvePromotedMembers(pkgStructs, files)
+	for i, index := range indices {
+		structs[index] = pkgStructs[i]
+	}
+}
+
+// minimumGoVersion combines the version-gated features found in each package
+func (f *packageFindings) minimumGoVersion() metrics.GoVersionRequirement {
+	return analyzer.MergeGoVersionRequirements(f.goVersions)
+}
@@ -0,0 +1,29 @@
+package generator
+
+import "sync"
+
+// runConcurrently runs tasks on at most workers goroutines and returns once every task has
+// finished. With workers of one or less the tasks run in order on the calling goroutine, which is
+// the serial path. Tasks must not depend on each other's results and must write disjoint parts of
+// the report; each task keeps its own output ordering, so results do not depend on scheduling.
+func runConcurrently(workers int, tasks ...func()) {
+	if workers <= 1 || len(tasks) <= 1 {
+		for _, task := range tasks {
+			task()
+		}
+		return
+	}
+
+	slots := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(task func()) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			task()
+		}(task)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// writeSyntheticModule writes a module of packages, each with files of funcsPerFile documented
+// functions, a struct with methods, and an import of the next package, so that every package
+// takes part in the package graph
+func writeSyntheticModule(tb testing.TB, dir string, packages, files, funcsPerFile int) {
+	tb.Helper()
+	require.NoError(tb, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/synthetic\n\ngo 1.24\n"), 0o644))
+	for p := 0; p < packages; p++ {
+		pkgDir := filepath.Join(dir, fmt.Sprintf("pkg%02d", p))
+		require.NoError(tb, os.MkdirAll(pkgDir, 0o755))
+		next := fmt.Sprintf("pkg%02d", (p+1)%packages)
+		for f := 0; f < files; f++ {
+			var src strings.Builder
+			fmt.Fprintf(&src, "// Package pkg%02d is synthetic\npackage pkg%02d\n\n", p, p)
+			if f == 0 && p+1 < packages {
+				fmt.Fprintf(&src, "import %q\n\nvar _ = %s.Helper000\n\n", "example.com/synthetic/"+next, next)
+			}
+			fmt.Fprintf(&src, "// Store%d holds values\ntype Store%d struct {\n\titems []int\n\ttotal int\n}\n\n", f, f)
+			fmt.Fprintf(&src, "// Add appends v\nfunc (s *Store%d) Add(v int) { s.items = append(s.items, v); s.total += v }\n\n", f)
+			for i := 0; i < funcsPerFile; i++ {
+				fmt.Fprintf(&src, `// Helper%d%02d sums the positive values below n
+func Helper%d%02d(n int) int {
+	total := 0
+	for i := 0; i < n; i++ {
+		if i%%2 == 0 && i > %d {
+			total += i
+		} else if i%%3 == 0 {
+			total -= i
+		}
+	}
+	return total
+}
+
+`, f, i, f, i, i)
+			}
+			require.NoError(tb, os.WriteFile(filepath.Join(pkgDir, fmt.Sprintf("file%02d.go", f)), []byte(src.String()), 0o644))
+		}
+	}
+}
+
+// collectSynthetic runs discovery and per-file analysis of dir on a single worker, so results
+// arrive in a fixed order, and returns the state finalizeAllMetrics starts from
+func collectSynthetic(tb testing.TB, dir string) (*metrics.Report, *CollectedMetrics, *AnalyzerSet) {
+	tb.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Performance.WorkerCount = 1
+	cfg.Analysis.EnableTeamMetrics = false
+
+	discoverer, files, err := discoverAndValidateFiles(dir, cfg)
+	require.NoError(tb, err)
+	analyzers := createAnalyzers(discoverer.GetFileSet(), cfg)
+	report := createInitialReport(dir, time.Now(), len(files))
+	attachModuleInfo(report, analyzers, dir, cfg)
+	results, err := processFilesWithWorkerPool(context.Background(), files, discoverer, nil, cfg)
+	require.NoError(tb, err)
+	collected, _, err := processAnalysisResults(context.Background(), results, analyzers, report, cfg)
+	require.NoError(tb, err)
+	return report, collected, analyzers
+}
+
+// finalizeSynthetic collects dir and finalizes the report with the given number of workers
+func finalizeSynthetic(tb testing.TB, dir string, workers int) *metrics.Report {
+	tb.Helper()
+	report, collected, analyzers := collectSynthetic(tb, dir)
+	cfg := config.DefaultConfig()
+	cfg.Analysis.EnableTeamMetrics = false
+	cfg.Performance.WorkerCount = workers
+	analyzers.Package.SetWorkerCount(workers)
+	finalizeAllMetrics(report, collected, analyzers, dir, cfg)
+	return report
+}
+
+func TestFinalizeAllMetrics_ConcurrentMatchesSerial(t *testing.T) {
+	dir := t.TempDir()
+	writeSyntheticModule(t, dir, 12, 3, 5)
+
+	serial := finalizeSynthetic(t, dir, 1)
+	concurrent := finalizeSynthetic(t, dir, 8)
+
+	require.Len(t, serial.Packages, 12)
+	assert.Equal(t, serial.Metadata.ContentHash, concurrent.Metadata.ContentHash)
+	for name, pair := range map[string][2]interface{}{
+		"packages":      {serial.Packages, concurrent.Packages},
+		"overview":      {serial.Overview, concurrent.Overview},
+		"summary":       {serial.Summary, concurrent.Summary},
+		"complexity":    {serial.Complexity, concurrent.Complexity},
+		"documentation": {serial.Documentation, concurrent.Documentation},
+		"patterns":      {serial.Patterns, concurrent.Patterns},
+		"burden":        {serial.Burden, concurrent.Burden},
+		"scores":        {serial.Scores, concurrent.Scores},
+		"suggestions":   {serial.Suggestions, concurrent.Suggestions},
+	} {
+		want, err := json.Marshal(pair[0])
+		require.NoError(t, err)
+		got, err := json.Marshal(pair[1])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got), name)
+	}
+}
+
+func TestRunConcurrently_BoundsWorkers(t *testing.T) {
+	var running, peak, done int32
+	task := func() {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&done, 1)
+	}
+
+	runConcurrently(3, task, task, task, task, task, task, task, task)
+
+	assert.Equal(t, int32(8), done)
+	assert.LessOrEqual(t, peak, int32(3))
+}
+
+func TestRunConcurrently_SerialKeepsOrder(t *testing.T) {
+	var order []int
+	runConcurrently(1, func() { order = append(order, 1) }, func() { order = append(order, 2) }, func() { order = append(order, 3) })
+	assert.Equal(t, []int{1, 2, 3}, order)
+}
+
+// BenchmarkFinalizeAllMetrics measures finalization of a synthetic 5000-function module with
+// growing worker counts; per-file analysis is excluded from the timing
+func BenchmarkFinalizeAllMetrics(b *testing.B) {
+	dir := b.TempDir()
+	writeSyntheticModule(b, dir, 50, 10, 10)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			cfg := config.DefaultConfig()
+			cfg.Analysis.EnableTeamMetrics = false
+			cfg.Performance.WorkerCount = workers
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				report, collected, analyzers := collectSynthetic(b, dir)
+				analyzers.Package.SetWorkerCount(workers)
+				b.StartTimer()
+				finalizeAllMetrics(report, collected, analyzers, dir, cfg)
+			}
+		})
+	}
+}
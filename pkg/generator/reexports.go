@@ -1,6 +1,9 @@
 package generator
 
-import "github.com/opd-ai/go-stats-generator/internal/metrics"
+import (
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
 
 // Re-export commonly used types for public API
 
@@ -21,3 +24,24 @@ type OverviewMetrics = metrics.OverviewMetrics
 
 // ReportMetadata contains information about the analysis run
 type ReportMetadata = metrics.ReportMetadata
+
+// Config holds the analysis, filter, performance, and output settings passed to Analyze
+type Config = config.Config
+
+// ProgressEvent is delivered to Config.Output.Progress as each analysis phase advances
+type ProgressEvent = config.ProgressEvent
+
+// ProgressPhase identifies the analysis stage a ProgressEvent belongs to
+type ProgressPhase = config.ProgressPhase
+
+// Analysis phases reported in progress events
+const (
+	PhaseDiscovery    = config.PhaseDiscovery
+	PhaseAnalysis     = config.PhaseAnalysis
+	PhaseFinalization = config.PhaseFinalization
+)
+
+// DefaultConfig returns a configuration with every analyzer enabled and the default thresholds
+func DefaultConfig() *Config {
+	return config.DefaultConfig()
+}
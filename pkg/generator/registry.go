@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sync"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+	"github.com/opd-ai/go-stats-generator/internal/scanner"
+)
+
+// ExtensionResult is one file's contribution from a registered FileAnalyzer
+type ExtensionResult = metrics.ExtensionResult
+
+// FileAnalyzer analyzes one parsed Go file at a time. Analyzers registered with
+// RegisterFileAnalyzer run on every analyzed file after the built-in analyzers, and each
+// non-nil result they return is JSON-encoded into Report.Extensions under the analyzer's name.
+// A FileAnalyzer may be called from several analyses running at once, so it must be safe for
+// concurrent use.
+type FileAnalyzer interface {
+	// Name identifies the analyzer and keys its results in Report.Extensions
+	Name() string
+	// AnalyzeFile returns the analyzer's result for file, or nil when it has nothing to report
+	AnalyzeFile(file *ast.File, fc *FileContext) (any, error)
+}
+
+// FileContext describes the file a FileAnalyzer is given
+type FileContext struct {
+	// Fset resolves the positions of the file's nodes
+	Fset *token.FileSet
+	// Path is the file's path as discovered; RelPath is relative to the analyzed directory
+	Path       string
+	RelPath    string
+	Package    string
+	IsTestFile bool
+
+	// The built-in analyzers write their typed results into the per-file accumulators below
+	// instead of returning them
+	result    scanner.Result
+	perFile   *AnalyzerSet
+	shared    *AnalyzerSet
+	cfg       *config.Config
+	collected *CollectedMetrics
+	scratch   *metrics.Report
+}
+
+// builtinAnalyzer is one of the tool's own per-file analysis stages
+type builtinAnalyzer struct {
+	name string
+	run  func(fc *FileContext)
+}
+
+// Name returns the stage name
+func (b builtinAnalyzer) Name() string { return b.name }
+
+// AnalyzeFile runs the stage, which records its results in fc and returns none
+func (b builtinAnalyzer) AnalyzeFile(_ *ast.File, fc *FileContext) (any, error) {
+	b.run(fc)
+	return nil, nil
+}
+
+// builtinFileAnalyzers returns the built-in per-file analysis stages in the order they run
+func builtinFileAnalyzers() []FileAnalyzer {
+	return []FileAnalyzer{
+		builtinAnalyzer{"structure", func(fc *FileContext) {
+			collectStructuralMetrics(fc.result, fc.perFile, fc.collected, fc.cfg)
+		}},
+		builtinAnalyzer{"concurrency", func(fc *FileContext) {
+			analyzeConcurrencyPatterns(fc.result, fc.perFile, fc.scratch, fc.cfg)
+		}},
+		builtinAnalyzer{"design_patterns", func(fc *FileContext) {
+			analyzeDesignPatterns(fc.result, fc.perFile, fc.scratch, fc.cfg)
+		}},
+		builtinAnalyzer{"antipatterns", func(fc *FileContext) {
+			analyzePerformanceAntipatterns(fc.result, fc.perFile, fc.scratch, fc.cfg)
+		}},
+		builtinAnalyzer{"burden", func(fc *FileContext) {
+			analyzeBurdenIndicators(fc.result, fc.perFile, fc.scratch, fc.cfg)
+		}},
+		builtinAnalyzer{"naming", func(fc *FileContext) {
+			fc.collected.IdentifierViolations = fc.shared.Naming.AnalyzeIdentifiers(fc.result.File, fc.RelPath, fc.Fset)
+			fc.collected.TotalIdentifiers = countIdentifiers(fc.result.File)
+		}},
+		builtinAnalyzer{"performance", func(fc *FileContext) {
+			fc.collected.PerformanceWarnings = analyzeHotPaths(fc.result, fc.perFile, fc.cfg)
+		}},
+	}
+}
+
+// fileAnalyzerRegistry holds the file analyzers every analysis runs, built-in stages first
+type fileAnalyzerRegistry struct {
+	mu        sync.RWMutex
+	analyzers []FileAnalyzer
+}
+
+// fileAnalyzers is the process-wide registry, holding the built-in analyzers by default
+var fileAnalyzers = &fileAnalyzerRegistry{analyzers: builtinFileAnalyzers()}
+
+// RegisterFileAnalyzer adds a to the analyzers run on every file by later analyses. It fails
+// when a is nil, has an empty name, or has the name of an analyzer that is already registered,
+// built-in ones included. Register analyzers at startup, before analyses run.
+func RegisterFileAnalyzer(a FileAnalyzer) error {
+	if a == nil {
+		return errors.New("file analyzer is nil")
+	}
+	name := a.Name()
+	if name == "" {
+		return errors.New("file analyzer name is empty")
+	}
+
+	fileAnalyzers.mu.Lock()
+	defer fileAnalyzers.mu.Unlock()
+	for _, existing := range fileAnalyzers.analyzers {
+		if existing.Name() == name {
+			return fmt.Errorf("file analyzer %q is already registered", name)
+		}
+	}
+	fileAnalyzers.analyzers = append(fileAnalyzers.analyzers, a)
+	return nil
+}
+
+// UnregisterFileAnalyzer removes the registered analyzer with the given name, reporting whether
+// one was removed. Built-in analyzers cannot be removed.
+func UnregisterFileAnalyzer(name string) bool {
+	fileAnalyzers.mu.Lock()
+	defer fileAnalyzers.mu.Unlock()
+	for i, a := range fileAnalyzers.analyzers {
+		if _, builtin := a.(builtinAnalyzer); builtin || a.Name() != name {
+			continue
+		}
+		fileAnalyzers.analyzers = append(fileAnalyzers.analyzers[:i:i], fileAnalyzers.analyzers[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// RegisteredFileAnalyzers returns the names of the registered analyzers in the order they run
+func RegisteredFileAnalyzers() []string {
+	fileAnalyzers.mu.RLock()
+	defer fileAnalyzers.mu.RUnlock()
+	names := make([]string, len(fileAnalyzers.analyzers))
+	for i, a := range fileAnalyzers.analyzers {
+		names[i] = a.Name()
+	}
+	return names
+}
+
+// registeredFileAnalyzers returns a snapshot of the registered analyzers
+func registeredFileAnalyzers() []FileAnalyzer {
+	fileAnalyzers.mu.RLock()
+	defer fileAnalyzers.mu.RUnlock()
+	return append([]FileAnalyzer(nil), fileAnalyzers.analyzers...)
+}
+
+// runFileAnalyzers runs every registered analyzer on fc's file, returning the encoded results of
+// the analyzers that are not built in, keyed by analyzer name
+func runFileAnalyzers(fc *FileContext) map[string]metrics.ExtensionResult {
+	var extensions map[string]metrics.ExtensionResult
+	for _, a := range registeredFileAnalyzers() {
+		result, err := a.AnalyzeFile(fc.result.File, fc)
+		if err != nil {
+			logVerbose(fc.cfg, "Warning: file analyzer %s failed on %s: %v\n", a.Name(), fc.Path, err)
+			continue
+		}
+		if result == nil {
+			continue
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			logVerbose(fc.cfg, "Warning: failed to encode %s results for %s: %v\n", a.Name(), fc.Path, err)
+			continue
+		}
+		if extensions == nil {
+			extensions = make(map[string]metrics.ExtensionResult)
+		}
+		extensions[a.Name()] = metrics.ExtensionResult{File: fc.RelPath, Package: fc.Package, Data: data}
+	}
+	return extensions
+}
+
+// DecodeExtension decodes the results the named file analyzer contributed to report, keyed by
+// the relative path of the file each came from
+func DecodeExtension[T any](report *Report, name string) (map[string]T, error) {
+	decoded := make(map[string]T, len(report.Extensions[name]))
+	for _, result := range report.Extensions[name] {
+		var value T
+		if err := json.Unmarshal(result.Data, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode %s results for %s: %w", name, result.File, err)
+		}
+		decoded[result.File] = value
+	}
+	return decoded, nil
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+)
+
+// funcCount is the result of funcCounter for one file
+type funcCount struct {
+	Package   string `json:"package"`
+	Functions int    `json:"functions"`
+}
+
+// funcCounter is a custom file analyzer counting function declarations
+type funcCounter struct{ name string }
+
+func (c funcCounter) Name() string { return c.name }
+
+func (c funcCounter) AnalyzeFile(file *ast.File, fc *FileContext) (any, error) {
+	count := 0
+	for _, decl := range file.Decls {
+		if _, ok := decl.(*ast.FuncDecl); ok {
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return funcCount{Package: fc.Package, Functions: count}, nil
+}
+
+// failingAnalyzer is a custom file analyzer whose every run fails
+type failingAnalyzer struct{}
+
+func (failingAnalyzer) Name() string { return "failing" }
+
+func (failingAnalyzer) AnalyzeFile(*ast.File, *FileContext) (any, error) {
+	return nil, errors.New("boom")
+}
+
+// registerForTest registers a for the duration of the test
+func registerForTest(t *testing.T, a FileAnalyzer) {
+	t.Helper()
+	require.NoError(t, RegisterFileAnalyzer(a))
+	t.Cleanup(func() { UnregisterFileAnalyzer(a.Name()) })
+}
+
+func TestRegisterFileAnalyzer_ResultsAppearInReport(t *testing.T) {
+	registerForTest(t, funcCounter{name: "func_counter"})
+	registerForTest(t, failingAnalyzer{})
+
+	dir := writeCacheFixture(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte("package shop\n\ntype ID string\n"), 0o644))
+	cacheDir := t.TempDir()
+
+	for _, run := range []string{"fresh", "cached"} {
+		report, _ := analyzeCounting(t, dir, cacheDir)
+
+		require.Contains(t, report.Extensions, "func_counter", run)
+		assert.NotContains(t, report.Extensions, "failing", "a failing analyzer contributes nothing")
+		assert.NotContains(t, report.Extensions, "structure", "built-in analyzers write to their own sections")
+
+		results := report.Extensions["func_counter"]
+		require.Len(t, results, 2, "files without functions have no result")
+		assert.Equal(t, "reader.go", results[0].File, "results are ordered by file")
+		assert.Equal(t, "shop", results[0].Package)
+
+		counts, err := DecodeExtension[funcCount](report, "func_counter")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]funcCount{
+			"reader.go": {Package: "shop", Functions: 1},
+			"store.go":  {Package: "shop", Functions: 2},
+		}, counts, run)
+		assert.NotEmpty(t, report.Functions, "built-in analyzers still run")
+	}
+}
+
+func TestRegisterFileAnalyzer_Validation(t *testing.T) {
+	builtins := RegisteredFileAnalyzers()
+	assert.Equal(t, []string{"structure", "concurrency", "design_patterns", "antipatterns", "burden", "naming", "performance"}, builtins)
+
+	assert.Error(t, RegisterFileAnalyzer(nil))
+	assert.Error(t, RegisterFileAnalyzer(funcCounter{}), "the name must not be empty")
+	assert.Error(t, RegisterFileAnalyzer(funcCounter{name: "burden"}), "built-in names are taken")
+
+	registerForTest(t, funcCounter{name: "custom"})
+	assert.Error(t, RegisterFileAnalyzer(funcCounter{name: "custom"}), "names are unique")
+	assert.Equal(t, append(append([]string(nil), builtins...), "custom"), RegisteredFileAnalyzers())
+
+	assert.False(t, UnregisterFileAnalyzer("burden"), "built-in analyzers stay registered")
+	assert.True(t, UnregisterFileAnalyzer("custom"))
+	assert.False(t, UnregisterFileAnalyzer("custom"))
+	assert.Equal(t, builtins, RegisteredFileAnalyzers())
+}
+
+func TestRegisterFileAnalyzer_InvalidatesCache(t *testing.T) {
+	cfg := config.DefaultConfig()
+	before, err := analysisCacheVersion(cfg)
+	require.NoError(t, err)
+
+	registerForTest(t, funcCounter{name: "func_counter"})
+	after, err := analysisCacheVersion(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+
+	_, err = Analyze(context.Background(), writeCacheFixture(t), *cfg)
+	require.NoError(t, err)
+}
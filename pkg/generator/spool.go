@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// metricSpool streams the function and struct metrics of each file to a temporary JSON lines
+// file as results arrive, so that only counters stay in memory while the worker pool runs.
+// The metrics are read back once, at finalization, when the report is built.
+type metricSpool struct {
+	file      *os.File
+	writer    *bufio.Writer
+	encoder   *json.Encoder
+	functions int
+	structs   int
+	// err is the first write error; later writes are dropped and drain reports it
+	err error
+}
+
+// spoolRecord is one line of the spool file, holding the metrics of a single file
+type spoolRecord struct {
+	Functions []metrics.FunctionMetrics `json:"functions,omitempty"`
+	Structs   []metrics.StructMetrics   `json:"structs,omitempty"`
+}
+
+// newMetricSpool creates a spool backed by a new temporary file in dir, or in the default
+// temporary directory when dir is empty
+func newMetricSpool(dir string) (*metricSpool, error) {
+	file, err := os.CreateTemp(dir, "go-stats-generator-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics spool: %w", err)
+	}
+	writer := bufio.NewWriter(file)
+	return &metricSpool{file: file, writer: writer, encoder: json.NewEncoder(writer)}, nil
+}
+
+// write appends the metrics of one file to the spool
+func (s *metricSpool) write(functions []metrics.FunctionMetrics, structs []metrics.StructMetrics) {
+	if s.err != nil || (len(functions) == 0 && len(structs) == 0) {
+		return
+	}
+	if err := s.encoder.Encode(spoolRecord{Functions: functions, Structs: structs}); err != nil {
+		s.err = fmt.Errorf("failed to write metrics spool: %w", err)
+		return
+	}
+	s.functions += len(functions)
+	s.structs += len(structs)
+}
+
+// drain reads every spooled metric back in the order it was written, then removes the spool file
+func (s *metricSpool) drain() ([]metrics.FunctionMetrics, []metrics.StructMetrics, error) {
+	defer s.discard()
+
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if err := s.writer.Flush(); err != nil {
+		return nil, nil, fmt.Errorf("failed to flush metrics spool: %w", err)
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, fmt.Errorf("failed to rewind metrics spool: %w", err)
+	}
+
+	functions := make([]metrics.FunctionMetrics, 0, s.functions)
+	structs := make([]metrics.StructMetrics, 0, s.structs)
+	decoder := json.NewDecoder(bufio.NewReader(s.file))
+	for {
+		var record spoolRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read metrics spool: %w", err)
+		}
+		functions = append(functions, record.Functions...)
+		structs = append(structs, record.Structs...)
+	}
+	return functions, structs, nil
+}
+
+// discard closes and removes the spool file; it is safe to call more than once
+func (s *metricSpool) discard() {
+	if s.file == nil {
+		return
+	}
+	s.file.Close()
+	os.Remove(s.file.Name())
+	s.file = nil
+}
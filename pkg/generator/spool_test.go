@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+	"github.com/opd-ai/go-stats-generator/internal/metrics"
+)
+
+// analyzeSynthetic runs the directory workflow over dir on a single worker with low-memory
+// spooling switched on or off, stopping before finalization
+func analyzeSynthetic(t *testing.T, dir string, lowMemory bool) (*metrics.Report, *CollectedMetrics, *AnalyzerSet, *config.Config) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Performance.WorkerCount = 1
+	cfg.Performance.LowMemory = lowMemory
+	cfg.Analysis.EnableTeamMetrics = false
+
+	discoverer, files, err := discoverAndValidateFiles(dir, cfg)
+	require.NoError(t, err)
+	analyzers := createAnalyzers(discoverer.GetFileSet(), cfg)
+	report := createInitialReport(dir, time.Now(), len(files))
+	attachModuleInfo(report, analyzers, dir, cfg)
+	results, err := processFilesWithWorkerPool(context.Background(), files, discoverer, nil, cfg)
+	require.NoError(t, err)
+	collected, _, err := processAnalysisResults(context.Background(), results, analyzers, report, cfg)
+	require.NoError(t, err)
+	return report, collected, analyzers, cfg
+}
+
+func TestLowMemory_MatchesInMemoryAggregates(t *testing.T) {
+	dir := t.TempDir()
+	writeSyntheticModule(t, dir, 20, 10, 10)
+
+	memReport, memCollected, memAnalyzers, memCfg := analyzeSynthetic(t, dir, false)
+	lowReport, lowCollected, lowAnalyzers, lowCfg := analyzeSynthetic(t, dir, true)
+
+	// While files are processed only counters are kept; the metrics themselves sit in the spool
+	require.NotNil(t, lowCollected.spool)
+	spoolPath := lowCollected.spool.file.Name()
+	assert.Empty(t, lowCollected.Functions)
+	assert.Empty(t, lowCollected.Structs)
+	assert.Equal(t, len(memCollected.Functions), lowCollected.functionCount())
+	assert.Equal(t, len(memCollected.Structs), lowCollected.structCount())
+	assert.Equal(t, 20*10*11, lowCollected.functionCount())
+	require.Len(t, lowCollected.packageFiles, len(memCollected.packageFiles))
+	for i := range lowCollected.packageFiles {
+		assert.Nil(t, lowCollected.packageFiles[i].info.File, "low-memory mode keeps no ASTs")
+		assert.NotNil(t, memCollected.packageFiles[i].info.File)
+	}
+
+	require.NoError(t, lowCollected.restoreSpooledMetrics())
+	assert.Nil(t, lowCollected.spool)
+	_, err := os.Stat(spoolPath)
+	assert.True(t, os.IsNotExist(err), "spool file is removed once read back")
+	assert.Equal(t, memCollected.Functions, lowCollected.Functions)
+	assert.Equal(t, memCollected.Structs, lowCollected.Structs)
+
+	finalizeAllMetrics(memReport, memCollected, memAnalyzers, dir, memCfg)
+	finalizeAllMetrics(lowReport, lowCollected, lowAnalyzers, dir, lowCfg)
+
+	assert.Equal(t, memReport.Metadata.ContentHash, lowReport.Metadata.ContentHash)
+	for name, pair := range map[string][2]interface{}{
+		"overview":   {memReport.Overview, lowReport.Overview},
+		"complexity": {memReport.Complexity, lowReport.Complexity},
+		"summary":    {memReport.Summary, lowReport.Summary},
+		"packages":   {memReport.Packages, lowReport.Packages},
+	} {
+		want, err := json.Marshal(pair[0])
+		require.NoError(t, err)
+		got, err := json.Marshal(pair[1])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got), name)
+	}
+}
+
+func TestLowMemory_DirectoryAnalysis(t *testing.T) {
+	dir := t.TempDir()
+	writeSyntheticModule(t, dir, 3, 2, 2)
+
+	cfg := config.DefaultConfig()
+	cfg.Analysis.EnableTeamMetrics = false
+	inMemory, err := runDirectoryAnalysis(context.Background(), dir, cfg)
+	require.NoError(t, err)
+
+	cfg.Performance.LowMemory = true
+	lowMemory, err := runDirectoryAnalysis(context.Background(), dir, cfg)
+	require.NoError(t, err)
+
+	assert.Len(t, lowMemory.Functions, len(inMemory.Functions))
+	assert.Len(t, lowMemory.Structs, len(inMemory.Structs))
+	assert.Equal(t, inMemory.Metadata.ContentHash, lowMemory.Metadata.ContentHash)
+}
+
+// retainedHeap returns the live heap after collecting garbage
+func retainedHeap() int64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+func TestLowMemory_BoundsRetainedHeap(t *testing.T) {
+	dir := t.TempDir()
+	writeSyntheticModule(t, dir, 20, 10, 10)
+
+	base := retainedHeap()
+	_, memCollected, _, _ := analyzeSynthetic(t, dir, false)
+	inMemory := retainedHeap() - base
+	runtime.KeepAlive(memCollected)
+
+	base = retainedHeap()
+	_, lowCollected, _, _ := analyzeSynthetic(t, dir, true)
+	lowMemory := retainedHeap() - base
+	runtime.KeepAlive(lowCollected)
+	t.Logf("heap retained before finalization: in-memory %d bytes, low-memory %d bytes", inMemory, lowMemory)
+
+	// Without ASTs and per-function metrics only the per-file summaries and counters remain
+	assert.Less(t, lowMemory, inMemory/4)
+}
@@ -1,4 +1,4 @@
-package cmd
+package generator
 
 import (
 	"bytes"
@@ -15,6 +15,7 @@ import (
 	"github.com/opd-ai/go-stats-generator/internal/config"
 	"github.com/opd-ai/go-stats-generator/internal/metrics"
 	"github.com/opd-ai/go-stats-generator/internal/scanner"
+	"github.com/opd-ai/go-stats-generator/internal/storage"
 )
 
 // runDirectoryAnalysis performs comprehensive code analysis on a directory,
@@ -41,20 +42,55 @@ func runFileAnalysis(ctx context.Context, filePath string, cfg *config.Config) (
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Filters.SkipGenerated && result.FileInfo.IsGenerated {
+		return nil, fmt.Errorf("file %s is generated code and generated files are skipped (disable with --skip-generated=false)", filePath)
+	}
 
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseDiscovery, Completed: 1, Total: 1})
 	report, collectedMetrics, analyzers := runSingleFileAnalysis(result, discoverer, filePath, startTime, cfg)
-	finalizeAllMetrics(report, collectedMetrics, analyzers, projectRoot, cfg)
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseAnalysis, Completed: 1, Total: 1, File: result.FileInfo.RelPath})
+	finalizeWithProgress(report, collectedMetrics, analyzers, projectRoot, cfg)
 
 	logVerboseFileResults(collectedMetrics, cfg)
 
 	return report, nil
 }
 
-// logVerboseFileAnalysis prints file analysis progress if verbose mode is enabled.
-func logVerboseFileAnalysis(filePath string, cfg *config.Config) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Analyzing file: %s\n", filePath)
+// runSourceAnalysis analyzes in-memory Go source as a single file named filename. The source
+// has no location on disk, so team metrics, which need the file's git history, are skipped.
+func runSourceAnalysis(ctx context.Context, filename string, src []byte, cfg *config.Config) (*metrics.Report, error) {
+	startTime := time.Now()
+	cfg.Analysis.EnableTeamMetrics = false
+
+	logVerboseFileAnalysis(filename, cfg)
+
+	discoverer := scanner.NewDiscoverer(&cfg.Filters)
+	file, err := discoverer.ParseSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	result := scanner.Result{
+		FileInfo: newSingleFileInfo(filename, filename, src, file),
+		File:     file,
+		FileSet:  discoverer.GetFileSet(),
 	}
+	if cfg.Filters.SkipGenerated && result.FileInfo.IsGenerated {
+		return nil, fmt.Errorf("%s is generated code and generated files are skipped (disable with --skip-generated=false)", filename)
+	}
+
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseDiscovery, Completed: 1, Total: 1})
+	report, collectedMetrics, analyzers := runSingleFileAnalysis(result, discoverer, filename, startTime, cfg)
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseAnalysis, Completed: 1, Total: 1, File: filename})
+	finalizeWithProgress(report, collectedMetrics, analyzers, "", cfg)
+
+	logVerboseFileResults(collectedMetrics, cfg)
+
+	return report, nil
+}
+
+// logVerboseFileAnalysis reports the file being analyzed to the configured logger.
+func logVerboseFileAnalysis(filePath string, cfg *config.Config) {
+	logVerbose(cfg, "Analyzing file: %s\n", filePath)
 }
 
 // parseAndPrepareFile parses a single file and creates its scanner result with metadata.
@@ -86,30 +122,30 @@ func parseAndPrepareFile(filePath, projectRoot string, cfg *config.Config) (scan
 
 // createFileInfoForSingleFile builds scanner file metadata from file system and AST information.
 func createFileInfoForSingleFile(filePath, projectRoot string, file *ast.File) (scanner.FileInfo, error) {
-	fileInfo, err := os.Stat(filePath)
+	src, err := os.ReadFile(filePath)
 	if err != nil {
 		return scanner.FileInfo{}, fmt.Errorf("failed to get file info: %w", err)
 	}
 
-	relPath := calculateRelativePath(filePath, projectRoot)
-
-	src, _ := os.ReadFile(filePath)
-	fileLines := bytes.Count(src, []byte{'\n'}) + 1
+	return newSingleFileInfo(filePath, calculateRelativePath(filePath, projectRoot), src, file), nil
+}
 
+// newSingleFileInfo builds scanner file metadata for one file from its source and AST
+func newSingleFileInfo(filePath, relPath string, src []byte, file *ast.File) scanner.FileInfo {
 	scannerFileInfo := scanner.FileInfo{
 		Path:        filePath,
 		RelPath:     relPath,
-		Size:        fileInfo.Size(),
+		Size:        int64(len(src)),
 		IsTestFile:  strings.HasSuffix(filePath, "_test.go"),
-		IsGenerated: false,
-		FileLines:   fileLines,
+		IsGenerated: scanner.IsGeneratedSource(src),
+		FileLines:   bytes.Count(src, []byte{'\n'}) + 1,
 	}
 
 	if file.Name != nil {
 		scannerFileInfo.Package = file.Name.Name
 	}
 
-	return scannerFileInfo, nil
+	return scannerFileInfo
 }
 
 // calculateRelativePath computes the relative path from project root, falling back to basename.
@@ -126,6 +162,7 @@ func calculateRelativePath(filePath, projectRoot string) string {
 func runSingleFileAnalysis(result scanner.Result, discoverer *scanner.Discoverer, filePath string, startTime time.Time, cfg *config.Config) (*metrics.Report, *CollectedMetrics, *AnalyzerSet) {
 	analyzers := createAnalyzers(discoverer.GetFileSet(), cfg)
 	report := createInitialReport(filepath.Dir(filePath), startTime, 1)
+	attachModuleInfo(report, analyzers, filepath.Dir(filePath), cfg)
 	collectedMetrics := &CollectedMetrics{}
 
 	processFileAnalysis(result, analyzers, collectedMetrics, report, cfg)
@@ -134,23 +171,61 @@ func runSingleFileAnalysis(result scanner.Result, discoverer *scanner.Discoverer
 	return report, collectedMetrics, analyzers
 }
 
+// finalizeWithProgress runs finalizeAllMetrics between the start and end events of the
+// finalization phase
+func finalizeWithProgress(report *metrics.Report, collectedMetrics *CollectedMetrics, analyzers *AnalyzerSet, projectRoot string, cfg *config.Config) {
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseFinalization, Completed: 0, Total: 1})
+	finalizeAllMetrics(report, collectedMetrics, analyzers, projectRoot, cfg)
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseFinalization, Completed: 1, Total: 1})
+}
+
 // finalizeAllMetrics runs all post-processing steps to complete the analysis report.
 func finalizeAllMetrics(report *metrics.Report, collectedMetrics *CollectedMetrics, analyzers *AnalyzerSet, projectRoot string, cfg *config.Config) {
-	finalizeReport(report, collectedMetrics, analyzers.Package, cfg)
-	finalizeDeadCodeMetrics(report, collectedMetrics, analyzers.Burden)
-	finalizeDuplicationMetrics(report, analyzers.Duplication, collectedMetrics, cfg)
-	finalizeNamingMetrics(report, analyzers, collectedMetrics, cfg)
-	finalizePlacementMetrics(report, analyzers, collectedMetrics, cfg)
-	finalizeDocumentationMetrics(report, analyzers, collectedMetrics, cfg)
-	finalizeOrganizationMetrics(report, analyzers, collectedMetrics, cfg, projectRoot)
-	finalizeTeamMetrics(report, projectRoot, cfg)
+	collectedMetrics.initFileSummaries(analyzers, cfg)
+	packages := analyzePackages(collectedMetrics, analyzers.Burden, cfg)
+	finalizeReport(report, collectedMetrics, analyzers.Package, packages, cfg)
+
+	finalizeDeadCodeMetrics(report, packages)
+	finalizeConstructorBypass(report, packages)
+	finalizeStrategyPatterns(report, packages)
+
+	// The codebase-wide passes each use their own analyzer and fill a separate part of the
+	// report, so they run side by side on up to WorkerCount goroutines
+	runConcurrently(cfg.Performance.WorkerCount,
+		func() { finalizeDuplicationMetrics(report, analyzers.Duplication, collectedMetrics, cfg) },
+		func() { finalizeNamingMetrics(report, analyzers, collectedMetrics, cfg) },
+		func() { finalizePlacementMetrics(report, analyzers, collectedMetrics, cfg) },
+		func() { finalizeDocumentationMetrics(report, collectedMetrics, cfg) },
+		func() { finalizeOrganizationMetrics(report, analyzers, collectedMetrics, cfg, projectRoot) },
+		func() { finalizeTeamMetrics(report, projectRoot, cfg) },
+	)
+
 	finalizeRefactoringSuggestions(report, cfg)
+	finalizeContentHash(report, cfg)
 }
 
 func logVerboseFileResults(collectedMetrics *CollectedMetrics, cfg *config.Config) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Analyzed 1 file, found %d functions, %d structs, %d interfaces\n",
-			len(collectedMetrics.Functions), len(collectedMetrics.Structs), len(collectedMetrics.Interfaces))
+	logVerbose(cfg, "Analyzed 1 file, found %d functions, %d structs, %d interfaces\n",
+		len(collectedMetrics.Functions), len(collectedMetrics.Structs), len(collectedMetrics.Interfaces))
+}
+
+// loadModuleInfo parses the go.mod governing a directory; tests replace it to count the reads
+var loadModuleInfo = scanner.LoadModuleInfo
+
+// attachModuleInfo loads the go.mod governing dir once at the start of an analysis run and
+// hands it to the package analyzer, the only analyzer that needs module context, so a later run
+// picks up edits to go.mod. The report gets its own copy, so changing one report's metadata
+// never affects another run or the analyzers.
+func attachModuleInfo(report *metrics.Report, analyzers *AnalyzerSet, dir string, cfg *config.Config) {
+	module, err := loadModuleInfo(dir)
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to load module info: %v\n", err)
+		return
+	}
+	report.Metadata.Module = module.Clone()
+	if module != nil {
+		analyzers.Package.SetModule(module.Path, filepath.Dir(module.GoModPath))
+		analyzers.Package.SetNestedModules(module.NestedModules)
 	}
 }
 
@@ -198,24 +273,43 @@ func runAnalysisWorkflow(ctx context.Context, targetDir string, cfg *config.Conf
 		return nil, err
 	}
 
-	// Step 2: Process files through worker pool
-	results, err := processFilesWithWorkerPool(ctx, files, discoverer, cfg)
+	// Step 2: Create analyzers and initial report structure
+	analyzers := createAnalyzers(discoverer.GetFileSet(), cfg)
+	report := createInitialReport(targetDir, startTime, len(files))
+	attachModuleInfo(report, analyzers, targetDir, cfg)
+
+	// Step 3: Reuse the previous report outright if no file changed since it was cached
+	analyzers.Cache = openAnalysisCache(targetDir, cfg)
+	var filesHash string
+	if analyzers.Cache != nil {
+		filesHash = hashFileContents(files, report.Metadata.Module)
+		if cached := restoreCachedReport(analyzers.Cache, filesHash, startTime, cfg); cached != nil {
+			logVerbose(cfg, "Reusing cached analysis of %d unchanged files\n", len(files))
+			return cached, nil
+		}
+	}
+
+	// Step 4: Process files through worker pool
+	results, err := processFilesWithWorkerPool(ctx, files, discoverer, analyzers.Cache, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 3: Create analyzers and initial report structure
-	analyzers := createAnalyzers(discoverer.GetFileSet(), cfg)
-	report := createInitialReport(targetDir, startTime, len(files))
-
-	// Step 4: Process analysis results from worker pool
+	// Step 5: Process analysis results from worker pool
 	collectedMetrics, _, err := processAnalysisResults(ctx, results, analyzers, report, cfg)
 	if err != nil {
 		return nil, err
 	}
+	report.Metadata.CachedFiles = collectedMetrics.CachedFiles
+
+	// Step 6: Read metrics spooled to disk in low-memory mode back for the report
+	if err := collectedMetrics.restoreSpooledMetrics(); err != nil {
+		return nil, err
+	}
 
-	// Step 5: Finalize report with all collected metrics
-	finalizeAllMetrics(report, collectedMetrics, analyzers, targetDir, cfg)
+	// Step 7: Finalize report with all collected metrics
+	finalizeWithProgress(report, collectedMetrics, analyzers, targetDir, cfg)
+	saveAnalysisCache(analyzers.Cache, filesHash, report, cfg)
 
 	report.Metadata.AnalysisTime = time.Since(startTime)
 
@@ -238,7 +332,9 @@ type AnalyzerSet struct {
 	Organization  *analyzer.OrganizationAnalyzer
 	Burden        *analyzer.BurdenAnalyzer
 	Generic       *analyzer.GenericAnalyzer
-	fileSet       *token.FileSet
+	// Cache stores per-file results for reuse by later runs (nil when caching is disabled)
+	Cache   *storage.AnalysisCache
+	fileSet *token.FileSet
 }
 
 // CollectedMetrics holds all metrics collected during analysis
@@ -248,37 +344,129 @@ type CollectedMetrics struct {
 	Interfaces []metrics.InterfaceMetrics
 	Generics   []metrics.GenericMetrics
 	TotalLines int
-	Files      map[string]*ast.File
+	// CachedFiles counts the files whose per-file metrics were taken from the analysis cache
+	CachedFiles int
+	// InterfaceAssertions accumulates var _ Iface = (*T)(nil) declarations during streaming;
+	// they are verified against all interfaces and methods in finalization.
+	InterfaceAssertions []metrics.InterfaceAssertion
 	// FileLinesCount maps relative file path to pre-computed line count (from FileInfo.FileLines).
 	// Used by OrganizationAnalyzer.AnalyzeFileSizesWithLines to avoid fset position lookups
 	// when each file was parsed into its own per-worker token.FileSet.
 	FileLinesCount map[string]int
+	// LineCounts holds the code, comment, and blank line counts of every file, from which
+	// finalization derives the comment density
+	LineCounts []analyzer.FileLineCounts
 	// IdentifierViolations and TotalIdentifiers accumulate per-file naming results during the
 	// streaming phase so that finalizeNamingMetrics can skip the fset-dependent
 	// analyzeAllIdentifiers loop in finalization.
 	IdentifierViolations []metrics.IdentifierViolation
 	TotalIdentifiers     int
+	// PerformanceWarnings accumulates hot-path allocation warnings when analysis.include_performance is on
+	PerformanceWarnings []metrics.PerformanceWarning
 	// DupBlocks and DupTotalLines accumulate duplication data during streaming.
 	// Blocks are extracted per-file with the per-file fset immediately after parsing,
 	// so ASTs can be reclaimed by the GC rather than being kept alive until finalization.
 	DupBlocks     []analyzer.StatementBlock
 	DupTotalLines int
-	// DocFiles accumulates per-file documentation inputs during streaming.
-	// Each entry carries its own FileSet so that annotation line numbers are resolved
-	// against the correct position table (rather than a stale shared FileSet).
-	DocFiles []analyzer.DocFileInfo
-	// BurdenFiles accumulates per-file burden inputs during streaming.
-	// Each entry carries its own FileSet so that position lookups in dead-code
-	// detection are resolved correctly even when files were parsed by separate
-	// worker goroutines. Dead code analysis runs at package scope in finalization.
-	BurdenFiles []analyzer.BurdenFileInfo
+	// DupBodies holds normalized method and single-parameter function bodies, compared in
+	// finalization against the struct registry to find helpers that duplicate methods.
+	DupBodies []analyzer.FunctionBody
+	// FilePackages and FileImports map each relative file path to its package name and import
+	// count, and OversizedFiles lists the files over the organization size limits. With the
+	// collectors below they hold what the codebase-wide finalization passes need from each
+	// file, recorded during streaming so the file's AST is not needed afterwards.
+	FilePackages   map[string]string
+	FileImports    map[string]int
+	OversizedFiles []metrics.OversizedFile
+	ParamUses      analyzer.InterfaceParamUses
+	Tests          *analyzer.TestIndex
+	ErrorHandling  *analyzer.ErrorHandlingCollector
+	// Documentation is nil unless analysis.include_documentation is on
+	Documentation *analyzer.DocumentationCollector
+	// packageFiles lists every processed file with the package it is analyzed with, for the
+	// package-scope passes of finalization. Each entry carries its own FileSet so that position
+	// lookups are resolved correctly even when files were parsed by separate worker goroutines.
+	// In low-memory mode the ASTs are not kept, and each package is parsed again when its turn comes.
+	packageFiles []packageFile
+	// lowMemory is set when performance.low_memory applies to the run
+	lowMemory bool
+	// spool holds Functions and Structs on disk while files are processed in low-memory mode
+	// (nil otherwise); restoreSpooledMetrics moves them back into the slices for finalization.
+	spool *metricSpool
+}
+
+// newCollectedMetrics creates the metrics accumulator for a run, spooling function and struct
+// metrics to a temporary file when performance.low_memory is enabled
+func newCollectedMetrics(cfg *config.Config) (*CollectedMetrics, error) {
+	collected := &CollectedMetrics{}
+	if cfg.Performance.LowMemory {
+		spool, err := newMetricSpool("")
+		if err != nil {
+			return nil, err
+		}
+		collected.spool = spool
+		collected.lowMemory = true
+	}
+	return collected, nil
+}
+
+// functionCount returns the number of functions collected so far, including spooled ones
+func (c *CollectedMetrics) functionCount() int {
+	if c.spool != nil {
+		return len(c.Functions) + c.spool.functions
+	}
+	return len(c.Functions)
+}
+
+// structCount returns the number of structs collected so far, including spooled ones
+func (c *CollectedMetrics) structCount() int {
+	if c.spool != nil {
+		return len(c.Structs) + c.spool.structs
+	}
+	return len(c.Structs)
+}
+
+// restoreSpooledMetrics reads the spooled functions and structs back into the slices and removes
+// the spool file. It does nothing when metrics were collected in memory.
+func (c *CollectedMetrics) restoreSpooledMetrics() error {
+	if c.spool == nil {
+		return nil
+	}
+	functions, structs, err := c.spool.drain()
+	c.spool = nil
+	if err != nil {
+		return err
+	}
+	c.Functions, c.Structs = functions, structs
+	return nil
+}
+
+// initFileSummaries creates the per-file summary maps and collectors on first use
+func (c *CollectedMetrics) initFileSummaries(analyzers *AnalyzerSet, cfg *config.Config) {
+	if c.FilePackages != nil {
+		return
+	}
+	c.FilePackages = make(map[string]string)
+	c.FileImports = make(map[string]int)
+	c.ParamUses = make(analyzer.InterfaceParamUses)
+	c.Tests = analyzer.NewTestIndex()
+	c.ErrorHandling = analyzer.NewErrorHandlingCollector()
+	if cfg.Analysis.IncludeDocumentation {
+		c.Documentation = analyzers.Documentation.NewCollector()
+	}
+}
+
+// discardSpool removes the spool file of a run that ends before finalization
+func (c *CollectedMetrics) discardSpool() {
+	if c.spool != nil {
+		c.spool.discard()
+		c.spool = nil
+	}
 }
 
 // discoverAndValidateFiles discovers Go files in the target directory and validates the results
 func discoverAndValidateFiles(targetDir string, cfg *config.Config) (*scanner.Discoverer, []scanner.FileInfo, error) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Analyzing directory: %s\n", targetDir)
-	}
+	logVerbose(cfg, "Analyzing directory: %s\n", targetDir)
 
 	discoverer := scanner.NewDiscoverer(&cfg.Filters)
 	files, err := discoverer.DiscoverFiles(targetDir)
@@ -287,37 +475,28 @@ func discoverAndValidateFiles(targetDir string, cfg *config.Config) (*scanner.Di
 	}
 
 	if len(files) == 0 {
-		return nil, nil, fmt.Errorf("no Go files found in %s", targetDir)
+		return nil, nil, fmt.Errorf("%w in %s", ErrNoGoFiles, targetDir)
 	}
 
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Found %d Go files\n", len(files))
-	}
+	logVerbose(cfg, "Found %d Go files\n", len(files))
+	reportProgress(cfg, config.ProgressEvent{Phase: config.PhaseDiscovery, Completed: len(files), Total: len(files)})
 
 	return discoverer, files, nil
 }
 
-// processFilesWithWorkerPool processes files using the worker pool with optional progress reporting
-func processFilesWithWorkerPool(ctx context.Context, files []scanner.FileInfo, discoverer *scanner.Discoverer, cfg *config.Config) (<-chan scanner.Result, error) {
+// processFilesWithWorkerPool processes files using the worker pool, reporting progress to the
+// configured callback and looking up each file in cache when one is given
+func processFilesWithWorkerPool(ctx context.Context, files []scanner.FileInfo, discoverer *scanner.Discoverer, cache *storage.AnalysisCache, cfg *config.Config) (<-chan scanner.Result, error) {
 	workerPool := scanner.NewWorkerPool(&cfg.Performance, discoverer)
-
-	var progressCallback scanner.ProgressCallback
-	if cfg.Output.ShowProgress {
-		progressCallback = func(completed, total int) {
-			fmt.Fprintf(os.Stderr, "\rProcessing files: %d/%d (%.1f%%)",
-				completed, total, float64(completed)/float64(total)*100)
-		}
+	if cache != nil {
+		workerPool.SetCache(cache)
 	}
 
-	results, err := workerPool.ProcessFiles(ctx, files, progressCallback)
+	results, err := workerPool.ProcessFiles(ctx, files, cfg.Output.Progress)
 	if err != nil {
 		return nil, fmt.Errorf("file processing failed: %w", err)
 	}
 
-	if cfg.Output.ShowProgress {
-		fmt.Fprintf(os.Stderr, "\n")
-	}
-
 	return results, nil
 }
 
@@ -331,10 +510,10 @@ func createAnalyzers(fileSet *token.FileSet, cfg *config.Config) *AnalyzerSet {
 	}
 
 	return &AnalyzerSet{
-		Function:      analyzer.NewFunctionAnalyzer(fileSet),
-		Struct:        analyzer.NewStructAnalyzer(fileSet),
+		Function:      newFunctionAnalyzer(fileSet, cfg),
+		Struct:        newStructAnalyzer(fileSet, cfg),
 		Interface:     analyzer.NewInterfaceAnalyzer(fileSet),
-		Package:       analyzer.NewPackageAnalyzer(fileSet),
+		Package:       newPackageAnalyzer(fileSet, cfg),
 		Concurrency:   analyzer.NewConcurrencyAnalyzer(fileSet),
 		Pattern:       analyzer.NewPatternAnalyzer(fileSet),
 		Antipattern:   analyzer.NewAntipatternAnalyzer(fileSet),
@@ -349,6 +528,42 @@ func createAnalyzers(fileSet *token.FileSet, cfg *config.Config) *AnalyzerSet {
 	}
 }
 
+// newFunctionAnalyzer creates a function analyzer scoring overall complexity with the configured
+// function weights
+func newFunctionAnalyzer(fset *token.FileSet, cfg *config.Config) *analyzer.FunctionAnalyzer {
+	fa := analyzer.NewFunctionAnalyzer(fset)
+	fa.SetComplexityWeights(functionComplexityWeights(cfg))
+	return fa
+}
+
+// newStructAnalyzer creates a struct analyzer scoring overall complexity with the configured
+// struct weights
+func newStructAnalyzer(fset *token.FileSet, cfg *config.Config) *analyzer.StructAnalyzer {
+	sa := analyzer.NewStructAnalyzer(fset)
+	sa.SetComplexityWeights(structComplexityWeights(cfg))
+	return sa
+}
+
+// functionComplexityWeights converts the configured function complexity weights
+func functionComplexityWeights(cfg *config.Config) analyzer.ComplexityWeights {
+	w := cfg.Analysis.ComplexityWeights.Function
+	return analyzer.ComplexityWeights{Cyclomatic: w.Cyclomatic, Nesting: w.Nesting, Cognitive: w.Cognitive}
+}
+
+// structComplexityWeights converts the configured struct complexity weights
+func structComplexityWeights(cfg *config.Config) analyzer.ComplexityWeights {
+	w := cfg.Analysis.ComplexityWeights.Struct
+	return analyzer.ComplexityWeights{Cyclomatic: w.Cyclomatic, Nesting: w.Nesting}
+}
+
+// newPackageAnalyzer creates the package analyzer, computing per-package metrics on up to
+// WorkerCount goroutines
+func newPackageAnalyzer(fileSet *token.FileSet, cfg *config.Config) *analyzer.PackageAnalyzer {
+	pa := analyzer.NewPackageAnalyzer(fileSet)
+	pa.SetWorkerCount(cfg.Performance.WorkerCount)
+	return pa
+}
+
 // createInitialReport creates the initial report structure with metadata and empty pattern metrics containers.
 // This function constructs the foundational Report object that will be populated during analysis phases, including
 // metadata fields (repository path, timestamp, analysis duration), and initializes empty data structures for all
@@ -363,13 +578,16 @@ func createInitialReport(targetDir string, startTime time.Time, fileCount int) *
 	}
 }
 
+// toolVersion is recorded in report metadata; analysis caches written by another version are discarded
+const toolVersion = "1.0.0"
+
 func createReportMetadata(targetDir string, startTime time.Time, fileCount int) metrics.ReportMetadata {
 	return metrics.ReportMetadata{
 		Repository:     targetDir,
 		GeneratedAt:    time.Now(),
 		AnalysisTime:   time.Since(startTime),
 		FilesProcessed: fileCount,
-		ToolVersion:    "1.0.0",
+		ToolVersion:    toolVersion,
 	}
 }
 
@@ -401,6 +619,7 @@ func createInitialConcurrencyPatterns() metrics.ConcurrencyPatternMetrics {
 		Goroutines: metrics.GoroutineMetrics{
 			Instances:      []metrics.GoroutineInstance{},
 			GoroutineLeaks: []metrics.GoroutineLeakWarning{},
+			DataRaces:      []metrics.DataRaceWarning{},
 		},
 		Channels: metrics.ChannelMetrics{
 			Instances: []metrics.ChannelInstance{},
@@ -412,7 +631,11 @@ func createInitialConcurrencyPatterns() metrics.ConcurrencyPatternMetrics {
 			Once:       []metrics.SyncPrimitiveInstance{},
 			Cond:       []metrics.SyncPrimitiveInstance{},
 			Atomic:     []metrics.SyncPrimitiveInstance{},
+			ErrGroups:  []metrics.SyncPrimitiveInstance{},
 		},
+		SelectStatements: []metrics.SelectInstance{},
+		SyncWarnings:     []metrics.SyncWarning{},
+		ContextWarnings:  []metrics.ContextWarning{},
 	}
 }
 
@@ -421,17 +644,23 @@ func createInitialAntiPatterns() metrics.AntiPatternMetrics {
 		GodObjects:              []metrics.AntiPatternWarning{},
 		LongMethods:             []metrics.AntiPatternWarning{},
 		DeepNesting:             []metrics.AntiPatternWarning{},
+		LongParameterLists:      []metrics.AntiPatternWarning{},
 		MagicNumbers:            []metrics.AntiPatternWarning{},
+		NakedReturns:            []metrics.AntiPatternWarning{},
 		PerformanceAntipatterns: []metrics.PerformanceAntipattern{},
+		ReceiverConsistency:     []metrics.ReceiverConsistency{},
 	}
 }
 
 func createInitialBurden() metrics.BurdenMetrics {
 	return metrics.BurdenMetrics{
-		MagicNumbers:          []metrics.MagicNumber{},
-		ComplexSignatures:     []metrics.SignatureIssue{},
-		DeeplyNestedFunctions: []metrics.NestingIssue{},
-		FeatureEnvyMethods:    []metrics.FeatureEnvyIssue{},
+		MagicNumbers:            []metrics.MagicNumber{},
+		ComplexSignatures:       []metrics.SignatureIssue{},
+		DeeplyNestedFunctions:   []metrics.NestingIssue{},
+		FeatureEnvyMethods:      []metrics.FeatureEnvyIssue{},
+		ComplexTypeExprs:        []metrics.TypeDepthIssue{},
+		LongMethodChains:        []metrics.MethodChainIssue{},
+		UnimplementedInterfaces: []metrics.UnimplementedInterface{},
 		DeadCode: metrics.DeadCodeMetrics{
 			UnreferencedFunctions: []metrics.UnreferencedSymbol{},
 			UnreachableCode:       []metrics.UnreachableBlock{},
@@ -448,12 +677,18 @@ func createInitialScores() metrics.ScoringMetrics {
 
 // processAnalysisResults coordinates the analysis of all scanner results
 func processAnalysisResults(ctx context.Context, results <-chan scanner.Result, analyzers *AnalyzerSet, report *metrics.Report, cfg *config.Config) (*CollectedMetrics, *analyzer.PackageAnalyzer, error) {
-	collectedMetrics := &CollectedMetrics{}
+	collectedMetrics, err := newCollectedMetrics(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
 	processedFiles := 0
 
 	for {
 		done, err := processNextResult(ctx, results, &processedFiles, analyzers, collectedMetrics, report, cfg)
 		if done {
+			if err != nil {
+				collectedMetrics.discardSpool()
+			}
 			return collectedMetrics, analyzers.Package, err
 		}
 	}
@@ -489,9 +724,7 @@ func processValidResult(result scanner.Result, processedFiles *int, analyzers *A
 // handleScannerError processes scanner errors and returns whether to continue processing
 func handleScannerError(err error, cfg *config.Config) bool {
 	if err != nil {
-		if cfg.Output.Verbose {
-			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
-		}
+		logVerbose(cfg, "Warning: %v\n", err)
 		return false
 	}
 	return true
@@ -502,29 +735,35 @@ func handleScannerError(err error, cfg *config.Config) bool {
 // correctly without contending on a shared fset. Cross-file state (PackageAnalyzer) is
 // accessed through the shared AnalyzerSet.
 func processFileAnalysis(result scanner.Result, analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, report *metrics.Report, cfg *config.Config) {
-	// Store the parsed file (still needed for placement and organization finalization).
-	if collectedMetrics.Files == nil {
-		collectedMetrics.Files = make(map[string]*ast.File)
-	}
-	collectedMetrics.Files[result.FileInfo.RelPath] = result.File
-
 	// Record pre-computed line count so finalization can use AnalyzeFileSizesWithLines.
 	if collectedMetrics.FileLinesCount == nil {
 		collectedMetrics.FileLinesCount = make(map[string]int)
 	}
 	collectedMetrics.FileLinesCount[result.FileInfo.RelPath] = result.FileInfo.FileLines
 	collectedMetrics.DupTotalLines += result.FileInfo.FileLines
+	report.Metadata.BytesProcessed += result.FileInfo.Size
 
 	// Create per-file analyzers bound to this result's FileSet to avoid shared-fset contention.
 	fset := result.FileSet
 	perFile := createPerFileAnalyzers(fset, cfg)
 
-	collectStructuralMetrics(result, perFile, collectedMetrics, cfg)
+	// Per-file metrics are taken from the analysis cache when the file content is unchanged;
+	// everything below depends on other files as well and always runs on the parsed AST.
+	fileMetrics := cachedFileAnalysis(result, cfg)
+	if fileMetrics != nil {
+		collectedMetrics.CachedFiles++
+	} else {
+		fileMetrics = analyzeFileMetrics(result, perFile, analyzers, cfg)
+		storeFileAnalysis(analyzers.Cache, result, fileMetrics, cfg)
+	}
+	mergeFileAnalysis(fileMetrics, collectedMetrics, report)
+	collectedMetrics.LineCounts = append(collectedMetrics.LineCounts, analyzer.FileLineCounts{
+		File:    result.FileInfo.RelPath,
+		Package: result.FileInfo.Package,
+		Lines:   fileMetrics.Lines,
+	})
+
 	analyzePackageStructure(result, analyzers.Package, cfg)
-	analyzeConcurrencyPatterns(result, perFile, report, cfg)
-	analyzeDesignPatterns(result, perFile, report, cfg)
-	analyzePerformanceAntipatterns(result, perFile, report, cfg)
-	analyzeBurdenIndicators(result, perFile, report, cfg)
 
 	// Extract duplication blocks now with the per-file fset so positions are resolved correctly.
 	// Accumulating blocks (rather than full ASTs) allows the GC to reclaim each *ast.File
@@ -532,28 +771,71 @@ func processFileAnalysis(result scanner.Result, analyzers *AnalyzerSet, collecte
 	minBlockLines := cfg.Analysis.Duplication.MinBlockLines
 	blocks := perFile.Duplication.ExtractBlocks(result.File, result.FileInfo.RelPath, minBlockLines)
 	collectedMetrics.DupBlocks = append(collectedMetrics.DupBlocks, blocks...)
-
-	// Identifier naming is analysed here (per-file with the correct fset) and accumulated
-	// so that finalizeNamingMetrics can skip the fset-dependent loop over all ASTs.
-	violations := analyzers.Naming.AnalyzeIdentifiers(result.File, result.FileInfo.RelPath, fset)
-	collectedMetrics.IdentifierViolations = append(collectedMetrics.IdentifierViolations, violations...)
-	collectedMetrics.TotalIdentifiers += countIdentifiers(result.File)
-
-	// Accumulate per-file documentation info with its own fset so that annotation
-	// line numbers are resolved correctly in finalizeDocumentationMetrics.
-	collectedMetrics.DocFiles = append(collectedMetrics.DocFiles, analyzer.DocFileInfo{
-		File: result.File,
-		Fset: fset,
-		Path: result.FileInfo.Path,
+	bodies := perFile.Duplication.ExtractFunctionBodies(result.File, result.FileInfo.Package, result.FileInfo.RelPath)
+	collectedMetrics.DupBodies = append(collectedMetrics.DupBodies, bodies...)
+
+	// Accumulate per-file burden info with its own fset so that position lookups in the
+	// package-scope passes are resolved correctly.
+	fi := analyzer.BurdenFileInfo{
+		File:    result.File,
+		Fset:    fset,
+		Pkg:     result.FileInfo.Package,
+		RelPath: result.FileInfo.RelPath,
+	}
+	collectFileSummaries(result, fi, analyzers, collectedMetrics, cfg)
+
+	group := fi.Pkg
+	if group == "" {
+		// Discovery always reads the package clause, so an empty package name points to a
+		// problem upstream; fall back to the parsed one and make it visible.
+		group = result.File.Name.Name
+		logVerbose(cfg, "Warning: %s has no package name from discovery; falling back to %q\n", fi.RelPath, group)
+	}
+	if collectedMetrics.lowMemory {
+		fi.File, fi.Fset = nil, nil
+	}
+	collectedMetrics.packageFiles = append(collectedMetrics.packageFiles, packageFile{
+		path:  result.FileInfo.Path,
+		group: group,
+		info:  fi,
 	})
+}
 
-	// Accumulate per-file burden info with its own fset so that dead-code
-	// position lookups are resolved correctly in finalizeDeadCodeMetrics.
-	collectedMetrics.BurdenFiles = append(collectedMetrics.BurdenFiles, analyzer.BurdenFileInfo{
-		File: result.File,
-		Fset: fset,
-		Pkg:  result.FileInfo.Package,
-	})
+// collectFileSummaries records what the codebase-wide finalization passes need from one file:
+// its package and imports for naming and organization, its size, the inputs of the placement
+// analysis, and those of the parameter use, test presence, error handling, and documentation
+// analyses
+func collectFileSummaries(result scanner.Result, fi analyzer.BurdenFileInfo, analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, cfg *config.Config) {
+	collectedMetrics.initFileSummaries(analyzers, cfg)
+
+	relPath := result.FileInfo.RelPath
+	collectedMetrics.FilePackages[relPath] = result.File.Name.Name
+	collectedMetrics.FileImports[relPath] = countImports(result.File)
+	oversized, err := analyzers.Organization.AnalyzeFileSizesWithLines(result.File, relPath,
+		result.FileInfo.FileLines, getOrganizationConfig(cfg))
+	if err == nil && oversized != nil {
+		collectedMetrics.OversizedFiles = append(collectedMetrics.OversizedFiles, *oversized)
+	}
+	analyzers.Placement.AddFile(result.File, relPath)
+
+	collectedMetrics.ParamUses.Add(fi)
+	collectedMetrics.Tests.Add(fi)
+	collectedMetrics.ErrorHandling.Add(fi)
+	// Annotation line numbers are resolved against the file's own fset
+	if collectedMetrics.Documentation != nil {
+		collectedMetrics.Documentation.Add(analyzer.DocFileInfo{File: result.File, Fset: fi.Fset, Path: result.FileInfo.Path})
+	}
+}
+
+// countImports returns the number of import declarations in file
+func countImports(file *ast.File) int {
+	count := 0
+	for _, imp := range file.Imports {
+		if imp.Path != nil {
+			count++
+		}
+	}
+	return count
 }
 
 // createPerFileAnalyzers builds a set of analyzers bound to the given FileSet.
@@ -561,8 +843,8 @@ func processFileAnalysis(result scanner.Result, analyzers *AnalyzerSet, collecte
 // Naming, Placement, Organization) are managed by the shared AnalyzerSet.
 func createPerFileAnalyzers(fset *token.FileSet, cfg *config.Config) *AnalyzerSet {
 	return &AnalyzerSet{
-		Function:    analyzer.NewFunctionAnalyzer(fset),
-		Struct:      analyzer.NewStructAnalyzer(fset),
+		Function:    newFunctionAnalyzer(fset, cfg),
+		Struct:      newStructAnalyzer(fset, cfg),
 		Interface:   analyzer.NewInterfaceAnalyzer(fset),
 		Concurrency: analyzer.NewConcurrencyAnalyzer(fset),
 		Pattern:     analyzer.NewPatternAnalyzer(fset),
@@ -576,18 +858,35 @@ func createPerFileAnalyzers(fset *token.FileSet, cfg *config.Config) *AnalyzerSe
 
 // collectStructuralMetrics analyzes functions, structs, and interfaces in a file
 func collectStructuralMetrics(result scanner.Result, analyzers *AnalyzerSet, collectedMetrics *CollectedMetrics, cfg *config.Config) {
+	isTestFile := result.FileInfo.IsTestFile
+
 	if functions, err := analyzeFunctionsInFile(analyzers.Function, result, cfg); err == nil {
+		for i := range functions {
+			functions[i].IsTestFile = isTestFile
+		}
 		collectedMetrics.Functions = append(collectedMetrics.Functions, functions...)
 	}
 
 	if structs, err := analyzeStructsInFile(analyzers.Struct, result, cfg); err == nil {
+		for i := range structs {
+			structs[i].IsTestFile = isTestFile
+		}
 		collectedMetrics.Structs = append(collectedMetrics.Structs, structs...)
 	}
 
 	if interfaces, err := analyzeInterfacesInFile(analyzers.Interface, result, cfg); err == nil {
+		for i := range interfaces {
+			interfaces[i].OversizedMethods = analyzers.Interface.DetectOversizedMethods(&interfaces[i],
+				cfg.Analysis.Burden.MaxInterfaceParams, cfg.Analysis.Burden.MaxInterfaceReturns)
+		}
 		collectedMetrics.Interfaces = append(collectedMetrics.Interfaces, interfaces...)
 	}
+	collectedMetrics.InterfaceAssertions = append(collectedMetrics.InterfaceAssertions,
+		analyzers.Interface.ExtractInterfaceAssertions(result.File, result.FileInfo.Package, result.FileInfo.RelPath)...)
 
+	if !cfg.Analysis.IncludeGenerics {
+		return
+	}
 	if generics, err := analyzeGenericsInFile(analyzers.Generic, result, cfg); err == nil {
 		collectedMetrics.Generics = append(collectedMetrics.Generics, generics)
 	}
@@ -595,57 +894,67 @@ func collectStructuralMetrics(result scanner.Result, analyzers *AnalyzerSet, col
 
 // analyzePackageStructure analyzes package information for a file using pre-computed line counts.
 func analyzePackageStructure(result scanner.Result, pkgAnalyzer *analyzer.PackageAnalyzer, cfg *config.Config) {
-	if err := pkgAnalyzer.AnalyzePackageWithFileLines(result.File, result.FileInfo.Path, result.FileInfo.FileLines); err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze package in %s: %v\n",
+	if err := pkgAnalyzer.AnalyzePackageWithFileLines(result.File, result.FileInfo.Path, result.FileInfo.FileLines); err != nil {
+		logVerbose(cfg, "Warning: failed to analyze package in %s: %v\n",
 			result.FileInfo.Path, err)
 	}
 }
 
 // analyzeConcurrencyPatterns analyzes concurrency patterns in a file
 func analyzeConcurrencyPatterns(result scanner.Result, analyzers *AnalyzerSet, report *metrics.Report, cfg *config.Config) {
-	if err := analyzeConcurrencyInFile(analyzers.Concurrency, result, report, cfg); err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze concurrency in %s: %v\n",
+	if err := analyzeConcurrencyInFile(analyzers.Concurrency, result, report, cfg); err != nil {
+		logVerbose(cfg, "Warning: failed to analyze concurrency in %s: %v\n",
 			result.FileInfo.Path, err)
 	}
 }
 
 // analyzeBurdenIndicators analyzes maintenance burden indicators in a file
 func analyzeBurdenIndicators(result scanner.Result, analyzers *AnalyzerSet, report *metrics.Report, cfg *config.Config) {
-	if err := analyzeBurdenInFile(analyzers.Burden, result, report, cfg); err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze burden in %s: %v\n",
+	if err := analyzeBurdenInFile(analyzers.Burden, result, report, cfg); err != nil {
+		logVerbose(cfg, "Warning: failed to analyze burden in %s: %v\n",
 			result.FileInfo.Path, err)
 	}
 }
 
-// analyzeDesignPatterns analyzes design patterns in a file
+// analyzeDesignPatterns analyzes design patterns in a file unless analysis.include_patterns is off
 func analyzeDesignPatterns(result scanner.Result, analyzers *AnalyzerSet, report *metrics.Report, cfg *config.Config) {
-	if err := analyzeDesignPatternsInFile(analyzers.Pattern, result, report, cfg); err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze design patterns in %s: %v\n",
+	if !cfg.Analysis.IncludePatterns {
+		return
+	}
+	if err := analyzeDesignPatternsInFile(analyzers.Pattern, result, report, cfg); err != nil {
+		logVerbose(cfg, "Warning: failed to analyze design patterns in %s: %v\n",
 			result.FileInfo.Path, err)
 	}
 }
 
 // analyzePerformanceAntipatterns analyzes performance anti-patterns in a file
 func analyzePerformanceAntipatterns(result scanner.Result, analyzers *AnalyzerSet, report *metrics.Report, cfg *config.Config) {
-	if err := analyzePerformanceAntipatternsInFile(analyzers.Antipattern, result, report, cfg); err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze performance antipatterns in %s: %v\n",
+	if err := analyzePerformanceAntipatternsInFile(analyzers.Antipattern, result, report, cfg); err != nil {
+		logVerbose(cfg, "Warning: failed to analyze performance antipatterns in %s: %v\n",
 			result.FileInfo.Path, err)
 	}
 }
 
+// analyzeHotPaths returns the hot-path allocation warnings of a file unless
+// analysis.include_performance is off
+func analyzeHotPaths(result scanner.Result, analyzers *AnalyzerSet, cfg *config.Config) []metrics.PerformanceWarning {
+	if !cfg.Analysis.IncludePerformance {
+		return nil
+	}
+	return analyzers.Antipattern.DetectHotPathAllocations(result.File)
+}
+
 // logProcessingSummary logs a summary of the processing results
 func logProcessingSummary(processedFiles int, collectedMetrics *CollectedMetrics, cfg *config.Config) {
-	if cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Processed %d files, found %d functions, %d structs, %d interfaces\n",
-			processedFiles, len(collectedMetrics.Functions), len(collectedMetrics.Structs), len(collectedMetrics.Interfaces))
-	}
+	logVerbose(cfg, "Processed %d files, found %d functions, %d structs, %d interfaces\n",
+		processedFiles, collectedMetrics.functionCount(), collectedMetrics.structCount(), len(collectedMetrics.Interfaces))
 }
 
 // analyzeFunctionsInFile analyzes functions in a single file result
 func analyzeFunctionsInFile(functionAnalyzer *analyzer.FunctionAnalyzer, result scanner.Result, cfg *config.Config) ([]metrics.FunctionMetrics, error) {
 	functions, err := functionAnalyzer.AnalyzeFunctionsWithPath(result.File, result.FileInfo.Package, result.FileInfo.RelPath)
-	if err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze functions in %s: %v\n",
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to analyze functions in %s: %v\n",
 			result.FileInfo.Path, err)
 		return nil, err
 	}
@@ -655,8 +964,8 @@ func analyzeFunctionsInFile(functionAnalyzer *analyzer.FunctionAnalyzer, result
 // analyzeStructsInFile analyzes structs in a single file result
 func analyzeStructsInFile(structAnalyzer *analyzer.StructAnalyzer, result scanner.Result, cfg *config.Config) ([]metrics.StructMetrics, error) {
 	structs, err := structAnalyzer.AnalyzeStructsWithPath(result.File, result.FileInfo.Package, result.FileInfo.RelPath)
-	if err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze structs in %s: %v\n",
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to analyze structs in %s: %v\n",
 			result.FileInfo.Path, err)
 		return nil, err
 	}
@@ -666,8 +975,8 @@ func analyzeStructsInFile(structAnalyzer *analyzer.StructAnalyzer, result scanne
 // analyzeInterfacesInFile analyzes interfaces in a single file result
 func analyzeInterfacesInFile(interfaceAnalyzer *analyzer.InterfaceAnalyzer, result scanner.Result, cfg *config.Config) ([]metrics.InterfaceMetrics, error) {
 	interfaces, err := interfaceAnalyzer.AnalyzeInterfacesWithPath(result.File, result.FileInfo.Package, result.FileInfo.RelPath)
-	if err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze interfaces in %s: %v\n",
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to analyze interfaces in %s: %v\n",
 			result.FileInfo.Path, err)
 		return nil, err
 	}
@@ -677,8 +986,8 @@ func analyzeInterfacesInFile(interfaceAnalyzer *analyzer.InterfaceAnalyzer, resu
 // analyzeGenericsInFile analyzes generic types and functions in a single file result
 func analyzeGenericsInFile(genericAnalyzer *analyzer.GenericAnalyzer, result scanner.Result, cfg *config.Config) (metrics.GenericMetrics, error) {
 	generics, err := genericAnalyzer.AnalyzeGenerics(result.File, result.FileInfo.Package, result.FileInfo.RelPath)
-	if err != nil && cfg.Output.Verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to analyze generics in %s: %v\n",
+	if err != nil {
+		logVerbose(cfg, "Warning: failed to analyze generics in %s: %v\n",
 			result.FileInfo.Path, err)
 		return metrics.GenericMetrics{}, err
 	}
@@ -700,6 +1009,7 @@ func analyzeConcurrencyInFile(concurrencyAnalyzer *analyzer.ConcurrencyAnalyzer,
 func aggregateConcurrencyMetrics(report *metrics.Report, concurrencyMetrics *metrics.ConcurrencyPatternMetrics) {
 	report.Patterns.ConcurrencyPatterns.Goroutines.Instances = append(report.Patterns.ConcurrencyPatterns.Goroutines.Instances, concurrencyMetrics.Goroutines.Instances...)
 	report.Patterns.ConcurrencyPatterns.Goroutines.GoroutineLeaks = append(report.Patterns.ConcurrencyPatterns.Goroutines.GoroutineLeaks, concurrencyMetrics.Goroutines.GoroutineLeaks...)
+	report.Patterns.ConcurrencyPatterns.Goroutines.DataRaces = append(report.Patterns.ConcurrencyPatterns.Goroutines.DataRaces, concurrencyMetrics.Goroutines.DataRaces...)
 	report.Patterns.ConcurrencyPatterns.Channels.Instances = append(report.Patterns.ConcurrencyPatterns.Channels.Instances, concurrencyMetrics.Channels.Instances...)
 	report.Patterns.ConcurrencyPatterns.SyncPrims.Mutexes = append(report.Patterns.ConcurrencyPatterns.SyncPrims.Mutexes, concurrencyMetrics.SyncPrims.Mutexes...)
 	report.Patterns.ConcurrencyPatterns.SyncPrims.RWMutexes = append(report.Patterns.ConcurrencyPatterns.SyncPrims.RWMutexes, concurrencyMetrics.SyncPrims.RWMutexes...)
@@ -707,11 +1017,15 @@ func aggregateConcurrencyMetrics(report *metrics.Report, concurrencyMetrics *met
 	report.Patterns.ConcurrencyPatterns.SyncPrims.Once = append(report.Patterns.ConcurrencyPatterns.SyncPrims.Once, concurrencyMetrics.SyncPrims.Once...)
 	report.Patterns.ConcurrencyPatterns.SyncPrims.Cond = append(report.Patterns.ConcurrencyPatterns.SyncPrims.Cond, concurrencyMetrics.SyncPrims.Cond...)
 	report.Patterns.ConcurrencyPatterns.SyncPrims.Atomic = append(report.Patterns.ConcurrencyPatterns.SyncPrims.Atomic, concurrencyMetrics.SyncPrims.Atomic...)
+	report.Patterns.ConcurrencyPatterns.SyncPrims.ErrGroups = append(report.Patterns.ConcurrencyPatterns.SyncPrims.ErrGroups, concurrencyMetrics.SyncPrims.ErrGroups...)
 	report.Patterns.ConcurrencyPatterns.WorkerPools = append(report.Patterns.ConcurrencyPatterns.WorkerPools, concurrencyMetrics.WorkerPools...)
 	report.Patterns.ConcurrencyPatterns.Pipelines = append(report.Patterns.ConcurrencyPatterns.Pipelines, concurrencyMetrics.Pipelines...)
 	report.Patterns.ConcurrencyPatterns.FanOut = append(report.Patterns.ConcurrencyPatterns.FanOut, concurrencyMetrics.FanOut...)
 	report.Patterns.ConcurrencyPatterns.FanIn = append(report.Patterns.ConcurrencyPatterns.FanIn, concurrencyMetrics.FanIn...)
 	report.Patterns.ConcurrencyPatterns.Semaphores = append(report.Patterns.ConcurrencyPatterns.Semaphores, concurrencyMetrics.Semaphores...)
+	report.Patterns.ConcurrencyPatterns.SelectStatements = append(report.Patterns.ConcurrencyPatterns.SelectStatements, concurrencyMetrics.SelectStatements...)
+	report.Patterns.ConcurrencyPatterns.SyncWarnings = append(report.Patterns.ConcurrencyPatterns.SyncWarnings, concurrencyMetrics.SyncWarnings...)
+	report.Patterns.ConcurrencyPatterns.ContextWarnings = append(report.Patterns.ConcurrencyPatterns.ContextWarnings, concurrencyMetrics.ContextWarnings...)
 }
 
 // analyzeBurdenInFile analyzes maintenance burden indicators in a single file.
@@ -719,7 +1033,11 @@ func aggregateConcurrencyMetrics(report *metrics.Report, concurrencyMetrics *met
 // so that all files of a package are available for accurate cross-file analysis.
 func analyzeBurdenInFile(burdenAnalyzer *analyzer.BurdenAnalyzer, result scanner.Result, report *metrics.Report, cfg *config.Config) error {
 	// File-level analysis: magic numbers only (dead code is handled at package scope)
-	magicNumbers := burdenAnalyzer.DetectMagicNumbers(result.File, result.FileInfo.Package)
+	magicNumbers := burdenAnalyzer.DetectMagicNumbersWithOptions(result.File, result.FileInfo.Package, analyzer.MagicNumberOptions{
+		Allowed:      cfg.Analysis.Burden.AllowedMagicNumbers,
+		IgnoreBenign: cfg.Analysis.Burden.IgnoreBenignMagic,
+		SkipTests:    cfg.Filters.SkipTestFiles,
+	})
 	report.Burden.MagicNumbers = append(report.Burden.MagicNumbers, magicNumbers...)
 
 	// Function-level analysis
@@ -752,6 +1070,11 @@ func analyzeSignatureAndNesting(burdenAnalyzer *analyzer.BurdenAnalyzer, fn *ast
 	if nestingIssue := burdenAnalyzer.DetectDeepNesting(fn, cfg.Analysis.Burden.MaxNesting); nestingIssue != nil {
 		report.Burden.DeeplyNestedFunctions = append(report.Burden.DeeplyNestedFunctions, *nestingIssue)
 	}
+
+	report.Burden.ComplexTypeExprs = append(report.Burden.ComplexTypeExprs,
+		burdenAnalyzer.DetectComplexTypeExpressions(fn, cfg.Analysis.Burden.MaxTypeDepth)...)
+	report.Burden.LongMethodChains = append(report.Burden.LongMethodChains,
+		burdenAnalyzer.DetectLongMethodChains(fn, cfg.Analysis.Burden.MaxChainDepth, cfg.Analysis.Burden.ChainExclusions)...)
 }
 
 // analyzeFeatureEnvy detects feature envy in methods
@@ -775,18 +1098,21 @@ func analyzeDesignPatternsInFile(patternAnalyzer *analyzer.PatternAnalyzer, resu
 	return nil
 }
 
-// aggregateDesignPatternMetrics aggregates design pattern metrics into the report
+// aggregateDesignPatternMetrics aggregates design pattern metrics into the report. Per-file
+// strategy results are dropped because finalizeStrategyPatterns detects them at package scope.
 func aggregateDesignPatternMetrics(report *metrics.Report, patterns *metrics.DesignPatternMetrics) {
 	report.Patterns.DesignPatterns.Singleton = append(report.Patterns.DesignPatterns.Singleton, patterns.Singleton...)
 	report.Patterns.DesignPatterns.Factory = append(report.Patterns.DesignPatterns.Factory, patterns.Factory...)
 	report.Patterns.DesignPatterns.Builder = append(report.Patterns.DesignPatterns.Builder, patterns.Builder...)
 	report.Patterns.DesignPatterns.Observer = append(report.Patterns.DesignPatterns.Observer, patterns.Observer...)
-	report.Patterns.DesignPatterns.Strategy = append(report.Patterns.DesignPatterns.Strategy, patterns.Strategy...)
 }
 
-// analyzePerformanceAntipatternsInFile analyzes performance anti-patterns in a single file
+// analyzePerformanceAntipatternsInFile analyzes performance anti-patterns and naked returns in a
+// single file
 func analyzePerformanceAntipatternsInFile(antipatternAnalyzer *analyzer.AntipatternAnalyzer, result scanner.Result, report *metrics.Report, cfg *config.Config) error {
 	patterns := antipatternAnalyzer.Analyze(result.File)
 	report.Patterns.AntiPatterns.PerformanceAntipatterns = append(report.Patterns.AntiPatterns.PerformanceAntipatterns, patterns...)
+	report.Patterns.AntiPatterns.NakedReturns = append(report.Patterns.AntiPatterns.NakedReturns,
+		antipatternAnalyzer.DetectNakedReturns(result.File, cfg.Analysis.Burden.MaxNakedReturnLines)...)
 	return nil
 }
@@ -0,0 +1,299 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opd-ai/go-stats-generator/internal/config"
+)
+
+func TestIsGoSourceFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		expected bool
+	}{
+		{
+			name:     "Go source file",
+			filePath: "/path/to/file.go",
+			expected: true,
+		},
+		{
+			name:     "Go test file",
+			filePath: "/path/to/file_test.go",
+			expected: true,
+		},
+		{
+			name:     "Non-Go file",
+			filePath: "/path/to/file.txt",
+			expected: false,
+		},
+		{
+			name:     "Markdown file",
+			filePath: "/path/to/README.md",
+			expected: false,
+		},
+		{
+			name:     "JSON file",
+			filePath: "/path/to/config.json",
+			expected: false,
+		},
+		{
+			name:     "No extension",
+			filePath: "/path/to/Makefile",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isGoSourceFile(tt.filePath)
+			if result != tt.expected {
+				t.Errorf("isGoSourceFile(%q) = %v, want %v", tt.filePath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunFileAnalysis(t *testing.T) {
+	// Create a temporary Go file for testing
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.go")
+
+	testContent := `package test
+
+import "fmt"
+
+// TestFunction demonstrates a simple function
+func TestFunction(name string) string {
+	if name == "" {
+		return "Hello, World!"
+	}
+	return fmt.Sprintf("Hello, %s!", name)
+}
+
+// AnotherFunction with more complexity
+func AnotherFunction(x, y int) int {
+	if x > y {
+		return x
+	} else if x < y {
+		return y
+	}
+	return 0
+}
+`
+
+	err := os.WriteFile(testFile, []byte(testContent), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Test single file analysis
+	cfg := config.DefaultConfig()
+	cfg.Output.Verbose = false // Avoid stderr output in tests
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report, err := runFileAnalysis(ctx, testFile, cfg)
+	if err != nil {
+		t.Fatalf("runFileAnalysis failed: %v", err)
+	}
+
+	// Verify basic report structure
+	if report == nil {
+		t.Fatal("Report is nil")
+	}
+
+	if report.Metadata.FilesProcessed != 1 {
+		t.Errorf("Expected 1 file processed, got %d", report.Metadata.FilesProcessed)
+	}
+
+	if len(report.Functions) == 0 {
+		t.Error("Expected at least one function in the report")
+	}
+
+	if report.Overview.TotalFiles != 1 {
+		t.Errorf("Expected 1 total file, got %d", report.Overview.TotalFiles)
+	}
+
+	// Verify that functions were found
+	if report.Overview.TotalFunctions == 0 {
+		t.Error("Expected at least one function to be found")
+	}
+
+	// Check that package information is correct
+	if len(report.Packages) == 0 {
+		t.Error("Expected at least one package in the report")
+	} else if report.Packages[0].Name != "test" {
+		t.Errorf("Expected package name 'test', got '%s'", report.Packages[0].Name)
+	}
+}
+
+func TestRunFileAnalysisWithNonGoFile(t *testing.T) {
+	// Create a temporary non-Go file
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.txt")
+
+	err := os.WriteFile(testFile, []byte("This is not a Go file"), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err = runFileAnalysis(ctx, testFile, cfg)
+	if err == nil {
+		t.Error("Expected error for non-Go file, but got none")
+	}
+
+	if !strings.Contains(err.Error(), "is not a Go source file") {
+		t.Errorf("Expected error message about non-Go file, got: %v", err)
+	}
+}
+
+func TestRunFileAnalysisWithGeneratedFile(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "api.pb.go")
+	src := "// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: api.proto\n\npackage api\n\nfunc Generated() {}\n"
+	if err := os.WriteFile(testFile, []byte(src), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cfg := config.DefaultConfig()
+	_, err := runFileAnalysis(ctx, testFile, cfg)
+	if err == nil || !strings.Contains(err.Error(), "is generated code") {
+		t.Errorf("Expected generated file to be skipped, got: %v", err)
+	}
+
+	cfg.Filters.SkipGenerated = false
+	report, err := runFileAnalysis(ctx, testFile, cfg)
+	if err != nil {
+		t.Fatalf("runFileAnalysis failed: %v", err)
+	}
+	if len(report.Functions) != 1 {
+		t.Errorf("Expected the generated function to be analyzed, got %d functions", len(report.Functions))
+	}
+}
+
+func TestRunFileAnalysisWithNonExistentFile(t *testing.T) {
+	cfg := config.DefaultConfig()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	nonExistentFile := "/path/that/does/not/exist.go"
+	_, err := runFileAnalysis(ctx, nonExistentFile, cfg)
+	if err == nil {
+		t.Error("Expected error for non-existent file, but got none")
+	}
+}
+
+// TestAnalyzeDuplicationIntegration tests duplication analysis integration
+func TestAnalyzeDuplicationIntegration(t *testing.T) {
+	// Create a temporary test directory with duplicated code
+	testDir := filepath.Join("..", "..", "testdata", "duplication")
+
+	// Check if test directory exists
+	if _, err := os.Stat(testDir); os.IsNotExist(err) {
+		t.Skip("Test directory does not exist, skipping integration test")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Output.Verbose = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Run analysis
+	report, err := runAnalysisWorkflow(ctx, testDir, cfg)
+	if err != nil {
+		t.Fatalf("Analysis failed: %v", err)
+	}
+
+	// Verify duplication metrics were populated
+	if report.Duplication.ClonePairs == 0 {
+		t.Error("Expected to find clone pairs in test data with intentional duplication")
+	}
+
+	if report.Duplication.DuplicatedLines == 0 {
+		t.Error("Expected to find duplicated lines in test data")
+	}
+
+	if report.Duplication.DuplicationRatio < 0.0 || report.Duplication.DuplicationRatio > 1.0 {
+		t.Errorf("Duplication ratio should be between 0 and 1, got: %f", report.Duplication.DuplicationRatio)
+	}
+
+	// Verify clone pairs have proper structure
+	for i, pair := range report.Duplication.Clones {
+		if len(pair.Instances) < 2 {
+			t.Errorf("Clone pair %d should have at least 2 instances, got %d", i, len(pair.Instances))
+		}
+
+		if pair.Hash == "" {
+			t.Errorf("Clone pair %d should have a non-empty hash", i)
+		}
+
+		if pair.LineCount <= 0 {
+			t.Errorf("Clone pair %d should have positive line count, got %d", i, pair.LineCount)
+		}
+
+		// Verify clone type is valid
+		validTypes := map[string]bool{
+			"exact":   true,
+			"renamed": true,
+			"near":    true,
+		}
+		if !validTypes[string(pair.Type)] {
+			t.Errorf("Clone pair %d has invalid type: %s", i, pair.Type)
+		}
+
+		// Verify instances have valid data
+		for j, instance := range pair.Instances {
+			if instance.File == "" {
+				t.Errorf("Clone pair %d, instance %d has empty file path", i, j)
+			}
+			if instance.StartLine <= 0 {
+				t.Errorf("Clone pair %d, instance %d has invalid start line: %d", i, j, instance.StartLine)
+			}
+			if instance.EndLine < instance.StartLine {
+				t.Errorf("Clone pair %d, instance %d has end line < start line: %d < %d",
+					i, j, instance.EndLine, instance.StartLine)
+			}
+		}
+	}
+
+	t.Logf("Duplication analysis found: %d clone pairs, %d duplicated lines (%.2f%% ratio)",
+		report.Duplication.ClonePairs,
+		report.Duplication.DuplicatedLines,
+		report.Duplication.DuplicationRatio*100)
+}
+
+// TestDuplicationAnalysisWithNoFiles tests duplication analysis with empty file set
+func TestDuplicationAnalysisWithNoFiles(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Create a temp directory with no Go files
+	tmpDir := t.TempDir()
+
+	report, err := runAnalysisWorkflow(ctx, tmpDir, cfg)
+
+	// Should fail because no files found
+	if err == nil {
+		t.Error("Expected error when analyzing directory with no Go files")
+	}
+	if report != nil {
+		t.Error("Expected nil report when analysis fails")
+	}
+}
@@ -0,0 +1,5 @@
+// Package testpresence provides test fixtures for the heuristic test presence analysis.
+//
+// The orders package mixes functions its tests name or mention with complex functions no
+// test reaches; the shipping package has no test files at all.
+package testpresence
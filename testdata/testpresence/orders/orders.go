@@ -0,0 +1,89 @@
+package orders
+
+import (
+	"errors"
+	"strings"
+)
+
+// Order is a parsed customer order
+type Order struct {
+	ID       string
+	Quantity int
+	Express  bool
+}
+
+// Cart holds the orders of one checkout
+type Cart struct {
+	Orders []Order
+}
+
+// ParseOrder is tested by a test named after it
+func ParseOrder(line string) (Order, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) < 2 {
+		return Order{}, errors.New("too few fields")
+	}
+	order := Order{ID: fields[0]}
+	for _, r := range fields[1] {
+		if r < '0' || r > '9' {
+			return Order{}, errors.New("bad quantity")
+		}
+		order.Quantity = order.Quantity*10 + int(r-'0')
+	}
+	if len(fields) > 2 && fields[2] == "express" {
+		order.Express = true
+	}
+	return order, nil
+}
+
+// validate is only mentioned in a test table
+func validate(o Order) error {
+	switch {
+	case o.ID == "":
+		return errors.New("missing id")
+	case o.Quantity <= 0:
+		return errors.New("missing quantity")
+	case o.Quantity > 100 && !o.Express:
+		return errors.New("large orders ship express")
+	}
+	return nil
+}
+
+// Total is tested by TestCart_Total
+func (c *Cart) Total() int {
+	total := 0
+	for _, o := range c.Orders {
+		if o.Quantity > 10 {
+			total += o.Quantity * 9
+		} else if o.Express {
+			total += o.Quantity * 12
+		} else {
+			total += o.Quantity * 10
+		}
+	}
+	return total
+}
+
+// Discount is complex and no test reaches it
+func (c *Cart) Discount(code string) int {
+	discount := 0
+	for _, o := range c.Orders {
+		switch {
+		case code == "BULK" && o.Quantity > 50:
+			discount += 20
+		case code == "FAST" && o.Express:
+			discount += 5
+		case strings.HasPrefix(code, "VIP"):
+			discount += 10
+		}
+	}
+	if discount > 50 {
+		discount = 50
+	}
+	return discount
+}
+
+// summary is simple and untested, so it lowers the ratio without being listed
+func summary(o Order) string {
+	return o.ID
+}
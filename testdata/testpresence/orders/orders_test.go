@@ -0,0 +1,25 @@
+package orders
+
+import "testing"
+
+func TestParseOrder(t *testing.T) {
+	if _, err := ParseOrder("a1,3"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRules(t *testing.T) {
+	checks := []func(Order) error{validate}
+	for _, check := range checks {
+		if check(Order{ID: "a1", Quantity: 1}) != nil {
+			t.Error("valid order rejected")
+		}
+	}
+}
+
+func TestCart_Total(t *testing.T) {
+	cart := &Cart{Orders: []Order{{ID: "a1", Quantity: 2}}}
+	if cart.Total() != 20 {
+		t.Error("wrong total")
+	}
+}
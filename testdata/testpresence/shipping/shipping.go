@@ -0,0 +1,19 @@
+package shipping
+
+// Rate has no test file in its directory
+func Rate(weight int, express, international bool) int {
+	rate := 5
+	if weight > 10 {
+		rate += 5
+	}
+	if weight > 50 {
+		rate += 20
+	}
+	if express {
+		rate *= 2
+	}
+	if international {
+		rate += 15
+	}
+	return rate
+}